@@ -69,7 +69,7 @@ func (x HealthCheck_Type) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use HealthCheck_Type.Descriptor instead.
 func (HealthCheck_Type) EnumDescriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{15, 0}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{28, 0}
 }
 
 type PortMapping_PublishMode int32
@@ -115,23 +115,27 @@ func (x PortMapping_PublishMode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use PortMapping_PublishMode.Descriptor instead.
 func (PortMapping_PublishMode) EnumDescriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{23, 0}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{36, 0}
 }
 
 // Node messages
 type Node struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"` // "manager" or "worker"
-	Address       string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
-	OverlayIp     string                 `protobuf:"bytes,4,opt,name=overlay_ip,json=overlayIp,proto3" json:"overlay_ip,omitempty"`
-	Resources     *NodeResources         `protobuf:"bytes,5,opt,name=resources,proto3" json:"resources,omitempty"`
-	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // "ready", "down", "unknown"
-	LastHeartbeat *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_heartbeat,json=lastHeartbeat,proto3" json:"last_heartbeat,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	Labels        map[string]string      `protobuf:"bytes,9,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Role             string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"` // "manager" or "worker"
+	Address          string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	OverlayIp        string                 `protobuf:"bytes,4,opt,name=overlay_ip,json=overlayIp,proto3" json:"overlay_ip,omitempty"`
+	Resources        *NodeResources         `protobuf:"bytes,5,opt,name=resources,proto3" json:"resources,omitempty"`
+	Status           string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // "ready", "down", "unknown"
+	LastHeartbeat    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_heartbeat,json=lastHeartbeat,proto3" json:"last_heartbeat,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Labels           map[string]string      `protobuf:"bytes,9,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	OverlayPublicKey string                 `protobuf:"bytes,10,opt,name=overlay_public_key,json=overlayPublicKey,proto3" json:"overlay_public_key,omitempty"` // WireGuard public key
+	OverlayPort      int32                  `protobuf:"varint,11,opt,name=overlay_port,json=overlayPort,proto3" json:"overlay_port,omitempty"`                 // WireGuard listen port (UDP)
+	Hostname         string                 `protobuf:"bytes,12,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Taints           []*Taint               `protobuf:"bytes,13,rep,name=taints,proto3" json:"taints,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *Node) Reset() {
@@ -227,18 +231,109 @@ func (x *Node) GetLabels() map[string]string {
 	return nil
 }
 
-type NodeResources struct {
+func (x *Node) GetOverlayPublicKey() string {
+	if x != nil {
+		return x.OverlayPublicKey
+	}
+	return ""
+}
+
+func (x *Node) GetOverlayPort() int32 {
+	if x != nil {
+		return x.OverlayPort
+	}
+	return 0
+}
+
+func (x *Node) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *Node) GetTaints() []*Taint {
+	if x != nil {
+		return x.Taints
+	}
+	return nil
+}
+
+// Taint repels services that do not tolerate it from a node
+type Taint struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	CpuCores      int64                  `protobuf:"varint,1,opt,name=cpu_cores,json=cpuCores,proto3" json:"cpu_cores,omitempty"`
-	MemoryBytes   int64                  `protobuf:"varint,2,opt,name=memory_bytes,json=memoryBytes,proto3" json:"memory_bytes,omitempty"`
-	DiskBytes     int64                  `protobuf:"varint,3,opt,name=disk_bytes,json=diskBytes,proto3" json:"disk_bytes,omitempty"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Effect        string                 `protobuf:"bytes,3,opt,name=effect,proto3" json:"effect,omitempty"` // "NoSchedule", "PreferNoSchedule" or "NoExecute"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *Taint) Reset() {
+	*x = Taint{}
+	mi := &file_api_proto_warren_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Taint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Taint) ProtoMessage() {}
+
+func (x *Taint) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Taint.ProtoReflect.Descriptor instead.
+func (*Taint) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Taint) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Taint) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Taint) GetEffect() string {
+	if x != nil {
+		return x.Effect
+	}
+	return ""
+}
+
+type NodeResources struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CpuCores        int64                  `protobuf:"varint,1,opt,name=cpu_cores,json=cpuCores,proto3" json:"cpu_cores,omitempty"`
+	MemoryBytes     int64                  `protobuf:"varint,2,opt,name=memory_bytes,json=memoryBytes,proto3" json:"memory_bytes,omitempty"`
+	DiskBytes       int64                  `protobuf:"varint,3,opt,name=disk_bytes,json=diskBytes,proto3" json:"disk_bytes,omitempty"`
+	CpuAllocated    float64                `protobuf:"fixed64,4,opt,name=cpu_allocated,json=cpuAllocated,proto3" json:"cpu_allocated,omitempty"`         // Cores reserved by containers on the node
+	MemoryAllocated int64                  `protobuf:"varint,5,opt,name=memory_allocated,json=memoryAllocated,proto3" json:"memory_allocated,omitempty"` // Bytes reserved by containers on the node
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
 func (x *NodeResources) Reset() {
 	*x = NodeResources{}
-	mi := &file_api_proto_warren_proto_msgTypes[1]
+	mi := &file_api_proto_warren_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -250,7 +345,7 @@ func (x *NodeResources) String() string {
 func (*NodeResources) ProtoMessage() {}
 
 func (x *NodeResources) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[1]
+	mi := &file_api_proto_warren_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -263,7 +358,7 @@ func (x *NodeResources) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NodeResources.ProtoReflect.Descriptor instead.
 func (*NodeResources) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{1}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *NodeResources) GetCpuCores() int64 {
@@ -287,20 +382,37 @@ func (x *NodeResources) GetDiskBytes() int64 {
 	return 0
 }
 
+func (x *NodeResources) GetCpuAllocated() float64 {
+	if x != nil {
+		return x.CpuAllocated
+	}
+	return 0
+}
+
+func (x *NodeResources) GetMemoryAllocated() int64 {
+	if x != nil {
+		return x.MemoryAllocated
+	}
+	return 0
+}
+
 type RegisterNodeRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
-	Address       string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
-	Resources     *NodeResources         `protobuf:"bytes,4,opt,name=resources,proto3" json:"resources,omitempty"`
-	Labels        map[string]string      `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Role             string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	Address          string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Resources        *NodeResources         `protobuf:"bytes,4,opt,name=resources,proto3" json:"resources,omitempty"`
+	Labels           map[string]string      `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	OverlayPublicKey string                 `protobuf:"bytes,6,opt,name=overlay_public_key,json=overlayPublicKey,proto3" json:"overlay_public_key,omitempty"` // WireGuard public key (empty = no overlay)
+	OverlayPort      int32                  `protobuf:"varint,7,opt,name=overlay_port,json=overlayPort,proto3" json:"overlay_port,omitempty"`                 // WireGuard listen port (UDP)
+	Hostname         string                 `protobuf:"bytes,8,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *RegisterNodeRequest) Reset() {
 	*x = RegisterNodeRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[2]
+	mi := &file_api_proto_warren_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -312,7 +424,7 @@ func (x *RegisterNodeRequest) String() string {
 func (*RegisterNodeRequest) ProtoMessage() {}
 
 func (x *RegisterNodeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[2]
+	mi := &file_api_proto_warren_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -325,7 +437,7 @@ func (x *RegisterNodeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterNodeRequest.ProtoReflect.Descriptor instead.
 func (*RegisterNodeRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{2}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *RegisterNodeRequest) GetId() string {
@@ -363,17 +475,39 @@ func (x *RegisterNodeRequest) GetLabels() map[string]string {
 	return nil
 }
 
+func (x *RegisterNodeRequest) GetOverlayPublicKey() string {
+	if x != nil {
+		return x.OverlayPublicKey
+	}
+	return ""
+}
+
+func (x *RegisterNodeRequest) GetOverlayPort() int32 {
+	if x != nil {
+		return x.OverlayPort
+	}
+	return 0
+}
+
+func (x *RegisterNodeRequest) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
 type RegisterNodeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Node          *Node                  `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
 	OverlayIp     string                 `protobuf:"bytes,2,opt,name=overlay_ip,json=overlayIp,proto3" json:"overlay_ip,omitempty"`
+	OverlaySubnet string                 `protobuf:"bytes,3,opt,name=overlay_subnet,json=overlaySubnet,proto3" json:"overlay_subnet,omitempty"` // Cluster subnet the overlay IP belongs to (CIDR)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RegisterNodeResponse) Reset() {
 	*x = RegisterNodeResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[3]
+	mi := &file_api_proto_warren_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -385,7 +519,7 @@ func (x *RegisterNodeResponse) String() string {
 func (*RegisterNodeResponse) ProtoMessage() {}
 
 func (x *RegisterNodeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[3]
+	mi := &file_api_proto_warren_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -398,7 +532,7 @@ func (x *RegisterNodeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterNodeResponse.ProtoReflect.Descriptor instead.
 func (*RegisterNodeResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{3}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *RegisterNodeResponse) GetNode() *Node {
@@ -415,6 +549,13 @@ func (x *RegisterNodeResponse) GetOverlayIp() string {
 	return ""
 }
 
+func (x *RegisterNodeResponse) GetOverlaySubnet() string {
+	if x != nil {
+		return x.OverlaySubnet
+	}
+	return ""
+}
+
 type HeartbeatRequest struct {
 	state              protoimpl.MessageState `protogen:"open.v1"`
 	NodeId             string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
@@ -426,7 +567,7 @@ type HeartbeatRequest struct {
 
 func (x *HeartbeatRequest) Reset() {
 	*x = HeartbeatRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[4]
+	mi := &file_api_proto_warren_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -438,7 +579,7 @@ func (x *HeartbeatRequest) String() string {
 func (*HeartbeatRequest) ProtoMessage() {}
 
 func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[4]
+	mi := &file_api_proto_warren_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -451,7 +592,7 @@ func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
 func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{4}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *HeartbeatRequest) GetNodeId() string {
@@ -484,7 +625,7 @@ type HeartbeatResponse struct {
 
 func (x *HeartbeatResponse) Reset() {
 	*x = HeartbeatResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[5]
+	mi := &file_api_proto_warren_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -496,7 +637,7 @@ func (x *HeartbeatResponse) String() string {
 func (*HeartbeatResponse) ProtoMessage() {}
 
 func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[5]
+	mi := &file_api_proto_warren_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -509,7 +650,7 @@ func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HeartbeatResponse.ProtoReflect.Descriptor instead.
 func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{5}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *HeartbeatResponse) GetStatus() string {
@@ -531,7 +672,7 @@ type ContainerStatus struct {
 
 func (x *ContainerStatus) Reset() {
 	*x = ContainerStatus{}
-	mi := &file_api_proto_warren_proto_msgTypes[6]
+	mi := &file_api_proto_warren_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -543,7 +684,7 @@ func (x *ContainerStatus) String() string {
 func (*ContainerStatus) ProtoMessage() {}
 
 func (x *ContainerStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[6]
+	mi := &file_api_proto_warren_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -556,7 +697,7 @@ func (x *ContainerStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ContainerStatus.ProtoReflect.Descriptor instead.
 func (*ContainerStatus) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{6}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ContainerStatus) GetContainerId() string {
@@ -596,7 +737,7 @@ type ListNodesRequest struct {
 
 func (x *ListNodesRequest) Reset() {
 	*x = ListNodesRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[7]
+	mi := &file_api_proto_warren_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -608,7 +749,7 @@ func (x *ListNodesRequest) String() string {
 func (*ListNodesRequest) ProtoMessage() {}
 
 func (x *ListNodesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[7]
+	mi := &file_api_proto_warren_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -621,7 +762,7 @@ func (x *ListNodesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListNodesRequest.ProtoReflect.Descriptor instead.
 func (*ListNodesRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{7}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ListNodesRequest) GetRoleFilter() string {
@@ -640,7 +781,7 @@ type ListNodesResponse struct {
 
 func (x *ListNodesResponse) Reset() {
 	*x = ListNodesResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[8]
+	mi := &file_api_proto_warren_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -652,7 +793,7 @@ func (x *ListNodesResponse) String() string {
 func (*ListNodesResponse) ProtoMessage() {}
 
 func (x *ListNodesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[8]
+	mi := &file_api_proto_warren_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -665,7 +806,7 @@ func (x *ListNodesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListNodesResponse.ProtoReflect.Descriptor instead.
 func (*ListNodesResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{8}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ListNodesResponse) GetNodes() []*Node {
@@ -684,7 +825,7 @@ type GetNodeRequest struct {
 
 func (x *GetNodeRequest) Reset() {
 	*x = GetNodeRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[9]
+	mi := &file_api_proto_warren_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -696,7 +837,7 @@ func (x *GetNodeRequest) String() string {
 func (*GetNodeRequest) ProtoMessage() {}
 
 func (x *GetNodeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[9]
+	mi := &file_api_proto_warren_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -709,7 +850,7 @@ func (x *GetNodeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetNodeRequest.ProtoReflect.Descriptor instead.
 func (*GetNodeRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{9}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetNodeRequest) GetId() string {
@@ -728,7 +869,7 @@ type GetNodeResponse struct {
 
 func (x *GetNodeResponse) Reset() {
 	*x = GetNodeResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[10]
+	mi := &file_api_proto_warren_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -740,7 +881,7 @@ func (x *GetNodeResponse) String() string {
 func (*GetNodeResponse) ProtoMessage() {}
 
 func (x *GetNodeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[10]
+	mi := &file_api_proto_warren_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -753,7 +894,7 @@ func (x *GetNodeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetNodeResponse.ProtoReflect.Descriptor instead.
 func (*GetNodeResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{10}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetNodeResponse) GetNode() *Node {
@@ -772,7 +913,7 @@ type RemoveNodeRequest struct {
 
 func (x *RemoveNodeRequest) Reset() {
 	*x = RemoveNodeRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[11]
+	mi := &file_api_proto_warren_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -784,7 +925,7 @@ func (x *RemoveNodeRequest) String() string {
 func (*RemoveNodeRequest) ProtoMessage() {}
 
 func (x *RemoveNodeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[11]
+	mi := &file_api_proto_warren_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -797,7 +938,7 @@ func (x *RemoveNodeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveNodeRequest.ProtoReflect.Descriptor instead.
 func (*RemoveNodeRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{11}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *RemoveNodeRequest) GetId() string {
@@ -816,7 +957,7 @@ type RemoveNodeResponse struct {
 
 func (x *RemoveNodeResponse) Reset() {
 	*x = RemoveNodeResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[12]
+	mi := &file_api_proto_warren_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -828,7 +969,7 @@ func (x *RemoveNodeResponse) String() string {
 func (*RemoveNodeResponse) ProtoMessage() {}
 
 func (x *RemoveNodeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[12]
+	mi := &file_api_proto_warren_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -841,7 +982,7 @@ func (x *RemoveNodeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveNodeResponse.ProtoReflect.Descriptor instead.
 func (*RemoveNodeResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{12}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *RemoveNodeResponse) GetStatus() string {
@@ -851,46 +992,32 @@ func (x *RemoveNodeResponse) GetStatus() string {
 	return ""
 }
 
-// Service messages
-type Service struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Image          string                 `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
-	Replicas       int32                  `protobuf:"varint,4,opt,name=replicas,proto3" json:"replicas,omitempty"`
-	Mode           string                 `protobuf:"bytes,5,opt,name=mode,proto3" json:"mode,omitempty"`                                           // "replicated" or "global"
-	DeployStrategy string                 `protobuf:"bytes,6,opt,name=deploy_strategy,json=deployStrategy,proto3" json:"deploy_strategy,omitempty"` // "rolling", "blue-green", "canary"
-	UpdateConfig   *UpdateConfig          `protobuf:"bytes,7,opt,name=update_config,json=updateConfig,proto3" json:"update_config,omitempty"`
-	HealthCheck    *HealthCheck           `protobuf:"bytes,8,opt,name=health_check,json=healthCheck,proto3" json:"health_check,omitempty"`
-	RestartPolicy  *RestartPolicy         `protobuf:"bytes,9,opt,name=restart_policy,json=restartPolicy,proto3" json:"restart_policy,omitempty"`
-	Resources      *ResourceRequirements  `protobuf:"bytes,10,opt,name=resources,proto3" json:"resources,omitempty"`
-	Networks       []string               `protobuf:"bytes,11,rep,name=networks,proto3" json:"networks,omitempty"`
-	Volumes        []*VolumeMount         `protobuf:"bytes,12,rep,name=volumes,proto3" json:"volumes,omitempty"`
-	Env            map[string]string      `protobuf:"bytes,13,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Command        []string               `protobuf:"bytes,14,rep,name=command,proto3" json:"command,omitempty"`
-	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	Ports          []*PortMapping         `protobuf:"bytes,17,rep,name=ports,proto3" json:"ports,omitempty"`                                 // Published ports
-	StopTimeout    int32                  `protobuf:"varint,18,opt,name=stop_timeout,json=stopTimeout,proto3" json:"stop_timeout,omitempty"` // Seconds to wait before force-killing (default: 10)
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type UpdateNodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	LabelAdd      map[string]string      `protobuf:"bytes,2,rep,name=label_add,json=labelAdd,proto3" json:"label_add,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Labels to add or overwrite
+	LabelRm       []string               `protobuf:"bytes,3,rep,name=label_rm,json=labelRm,proto3" json:"label_rm,omitempty"`                                                                              // Label keys to remove
+	TaintAdd      []*Taint               `protobuf:"bytes,4,rep,name=taint_add,json=taintAdd,proto3" json:"taint_add,omitempty"`                                                                           // Taints to add or overwrite (by key and effect)
+	TaintRm       []*Taint               `protobuf:"bytes,5,rep,name=taint_rm,json=taintRm,proto3" json:"taint_rm,omitempty"`                                                                              // Taints to remove by key, and by effect if set
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Service) Reset() {
-	*x = Service{}
-	mi := &file_api_proto_warren_proto_msgTypes[13]
+func (x *UpdateNodeRequest) Reset() {
+	*x = UpdateNodeRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Service) String() string {
+func (x *UpdateNodeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Service) ProtoMessage() {}
+func (*UpdateNodeRequest) ProtoMessage() {}
 
-func (x *Service) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[13]
+func (x *UpdateNodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -901,75 +1028,222 @@ func (x *Service) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Service.ProtoReflect.Descriptor instead.
-func (*Service) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use UpdateNodeRequest.ProtoReflect.Descriptor instead.
+func (*UpdateNodeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *Service) GetId() string {
+func (x *UpdateNodeRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *Service) GetName() string {
+func (x *UpdateNodeRequest) GetLabelAdd() map[string]string {
 	if x != nil {
-		return x.Name
+		return x.LabelAdd
 	}
-	return ""
+	return nil
 }
 
-func (x *Service) GetImage() string {
+func (x *UpdateNodeRequest) GetLabelRm() []string {
 	if x != nil {
-		return x.Image
+		return x.LabelRm
 	}
-	return ""
+	return nil
 }
 
-func (x *Service) GetReplicas() int32 {
+func (x *UpdateNodeRequest) GetTaintAdd() []*Taint {
 	if x != nil {
-		return x.Replicas
+		return x.TaintAdd
 	}
-	return 0
+	return nil
 }
 
-func (x *Service) GetMode() string {
+func (x *UpdateNodeRequest) GetTaintRm() []*Taint {
 	if x != nil {
-		return x.Mode
+		return x.TaintRm
 	}
-	return ""
+	return nil
 }
 
-func (x *Service) GetDeployStrategy() string {
-	if x != nil {
-		return x.DeployStrategy
-	}
-	return ""
+type UpdateNodeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Node          *Node                  `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Service) GetUpdateConfig() *UpdateConfig {
-	if x != nil {
-		return x.UpdateConfig
-	}
-	return nil
+func (x *UpdateNodeResponse) Reset() {
+	*x = UpdateNodeResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Service) GetHealthCheck() *HealthCheck {
+func (x *UpdateNodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNodeResponse) ProtoMessage() {}
+
+func (x *UpdateNodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[15]
 	if x != nil {
-		return x.HealthCheck
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Service) GetRestartPolicy() *RestartPolicy {
+// Deprecated: Use UpdateNodeResponse.ProtoReflect.Descriptor instead.
+func (*UpdateNodeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpdateNodeResponse) GetNode() *Node {
 	if x != nil {
-		return x.RestartPolicy
+		return x.Node
 	}
 	return nil
 }
 
-func (x *Service) GetResources() *ResourceRequirements {
+// Service messages
+type Service struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name             string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Image            string                 `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	Replicas         int32                  `protobuf:"varint,4,opt,name=replicas,proto3" json:"replicas,omitempty"`
+	Mode             string                 `protobuf:"bytes,5,opt,name=mode,proto3" json:"mode,omitempty"`                                           // "replicated", "global", "job" or "cronjob"
+	DeployStrategy   string                 `protobuf:"bytes,6,opt,name=deploy_strategy,json=deployStrategy,proto3" json:"deploy_strategy,omitempty"` // "rolling", "blue-green", "canary"
+	UpdateConfig     *UpdateConfig          `protobuf:"bytes,7,opt,name=update_config,json=updateConfig,proto3" json:"update_config,omitempty"`
+	HealthCheck      *HealthCheck           `protobuf:"bytes,8,opt,name=health_check,json=healthCheck,proto3" json:"health_check,omitempty"`
+	RestartPolicy    *RestartPolicy         `protobuf:"bytes,9,opt,name=restart_policy,json=restartPolicy,proto3" json:"restart_policy,omitempty"`
+	Resources        *ResourceRequirements  `protobuf:"bytes,10,opt,name=resources,proto3" json:"resources,omitempty"`
+	Networks         []string               `protobuf:"bytes,11,rep,name=networks,proto3" json:"networks,omitempty"`
+	Volumes          []*VolumeMount         `protobuf:"bytes,12,rep,name=volumes,proto3" json:"volumes,omitempty"`
+	Env              map[string]string      `protobuf:"bytes,13,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Command          []string               `protobuf:"bytes,14,rep,name=command,proto3" json:"command,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Ports            []*PortMapping         `protobuf:"bytes,17,rep,name=ports,proto3" json:"ports,omitempty"`                                 // Published ports
+	StopTimeout      int32                  `protobuf:"varint,18,opt,name=stop_timeout,json=stopTimeout,proto3" json:"stop_timeout,omitempty"` // Seconds to wait before force-killing (default: 10)
+	Vip              string                 `protobuf:"bytes,19,opt,name=vip,proto3" json:"vip,omitempty"`                                     // Virtual IP from the service subnet
+	Placement        *Placement             `protobuf:"bytes,20,opt,name=placement,proto3" json:"placement,omitempty"`
+	Labels           map[string]string      `protobuf:"bytes,21,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Affinity         *Affinity              `protobuf:"bytes,22,opt,name=affinity,proto3" json:"affinity,omitempty"`                             // Run replicas on nodes that run matching replicas
+	AntiAffinity     *Affinity              `protobuf:"bytes,23,opt,name=anti_affinity,json=antiAffinity,proto3" json:"anti_affinity,omitempty"` // Keep replicas off nodes that run matching replicas
+	Tolerations      []*Toleration          `protobuf:"bytes,24,rep,name=tolerations,proto3" json:"tolerations,omitempty"`
+	DisruptionBudget int32                  `protobuf:"varint,25,opt,name=disruption_budget,json=disruptionBudget,proto3" json:"disruption_budget,omitempty"`  // Most replicas rebalancing may leave unavailable (default: 1)
+	Priority         int32                  `protobuf:"varint,26,opt,name=priority,proto3" json:"priority,omitempty"`                                          // Higher-priority services preempt lower ones when the cluster is full
+	Job              *JobConfig             `protobuf:"bytes,27,opt,name=job,proto3" json:"job,omitempty"`                                                     // Job and cronjob modes
+	JobStatus        *JobStatus             `protobuf:"bytes,28,opt,name=job_status,json=jobStatus,proto3" json:"job_status,omitempty"`                        // Job mode
+	CronJob          *CronJobConfig         `protobuf:"bytes,29,opt,name=cron_job,json=cronJob,proto3" json:"cron_job,omitempty"`                              // Cronjob mode
+	LastScheduleTime *timestamppb.Timestamp `protobuf:"bytes,30,opt,name=last_schedule_time,json=lastScheduleTime,proto3" json:"last_schedule_time,omitempty"` // Cronjob mode: when the last job was due
+	CanaryAnalysis   *CanaryAnalysis        `protobuf:"bytes,31,opt,name=canary_analysis,json=canaryAnalysis,proto3" json:"canary_analysis,omitempty"`         // Last comparison of a canary update with this service
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Service) Reset() {
+	*x = Service{}
+	mi := &file_api_proto_warren_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Service) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Service) ProtoMessage() {}
+
+func (x *Service) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Service.ProtoReflect.Descriptor instead.
+func (*Service) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Service) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Service) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Service) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *Service) GetReplicas() int32 {
+	if x != nil {
+		return x.Replicas
+	}
+	return 0
+}
+
+func (x *Service) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *Service) GetDeployStrategy() string {
+	if x != nil {
+		return x.DeployStrategy
+	}
+	return ""
+}
+
+func (x *Service) GetUpdateConfig() *UpdateConfig {
+	if x != nil {
+		return x.UpdateConfig
+	}
+	return nil
+}
+
+func (x *Service) GetHealthCheck() *HealthCheck {
+	if x != nil {
+		return x.HealthCheck
+	}
+	return nil
+}
+
+func (x *Service) GetRestartPolicy() *RestartPolicy {
+	if x != nil {
+		return x.RestartPolicy
+	}
+	return nil
+}
+
+func (x *Service) GetResources() *ResourceRequirements {
 	if x != nil {
 		return x.Resources
 	}
@@ -1032,169 +1306,193 @@ func (x *Service) GetStopTimeout() int32 {
 	return 0
 }
 
-type UpdateConfig struct {
-	state                         protoimpl.MessageState `protogen:"open.v1"`
-	Parallelism                   int32                  `protobuf:"varint,1,opt,name=parallelism,proto3" json:"parallelism,omitempty"`
-	DelaySeconds                  int32                  `protobuf:"varint,2,opt,name=delay_seconds,json=delaySeconds,proto3" json:"delay_seconds,omitempty"`
-	FailureAction                 string                 `protobuf:"bytes,3,opt,name=failure_action,json=failureAction,proto3" json:"failure_action,omitempty"` // "pause", "continue", "rollback"
-	MaxSurge                      int32                  `protobuf:"varint,4,opt,name=max_surge,json=maxSurge,proto3" json:"max_surge,omitempty"`
-	MaxUnavailable                int32                  `protobuf:"varint,5,opt,name=max_unavailable,json=maxUnavailable,proto3" json:"max_unavailable,omitempty"`
-	HealthCheckGracePeriodSeconds int32                  `protobuf:"varint,6,opt,name=health_check_grace_period_seconds,json=healthCheckGracePeriodSeconds,proto3" json:"health_check_grace_period_seconds,omitempty"`
-	CanaryWeight                  int32                  `protobuf:"varint,7,opt,name=canary_weight,json=canaryWeight,proto3" json:"canary_weight,omitempty"`
-	CanarySteps                   []int32                `protobuf:"varint,8,rep,packed,name=canary_steps,json=canarySteps,proto3" json:"canary_steps,omitempty"`
-	CanaryStabilityWindowSeconds  int32                  `protobuf:"varint,9,opt,name=canary_stability_window_seconds,json=canaryStabilityWindowSeconds,proto3" json:"canary_stability_window_seconds,omitempty"`
-	BlueGreenGracePeriodSeconds   int32                  `protobuf:"varint,10,opt,name=blue_green_grace_period_seconds,json=blueGreenGracePeriodSeconds,proto3" json:"blue_green_grace_period_seconds,omitempty"`
-	AutoRollbackEnabled           bool                   `protobuf:"varint,11,opt,name=auto_rollback_enabled,json=autoRollbackEnabled,proto3" json:"auto_rollback_enabled,omitempty"`
-	FailureThresholdPercent       int32                  `protobuf:"varint,12,opt,name=failure_threshold_percent,json=failureThresholdPercent,proto3" json:"failure_threshold_percent,omitempty"`
-	unknownFields                 protoimpl.UnknownFields
-	sizeCache                     protoimpl.SizeCache
+func (x *Service) GetVip() string {
+	if x != nil {
+		return x.Vip
+	}
+	return ""
 }
 
-func (x *UpdateConfig) Reset() {
-	*x = UpdateConfig{}
-	mi := &file_api_proto_warren_proto_msgTypes[14]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Service) GetPlacement() *Placement {
+	if x != nil {
+		return x.Placement
+	}
+	return nil
 }
 
-func (x *UpdateConfig) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Service) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
 }
 
-func (*UpdateConfig) ProtoMessage() {}
+func (x *Service) GetAffinity() *Affinity {
+	if x != nil {
+		return x.Affinity
+	}
+	return nil
+}
 
-func (x *UpdateConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[14]
+func (x *Service) GetAntiAffinity() *Affinity {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.AntiAffinity
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use UpdateConfig.ProtoReflect.Descriptor instead.
-func (*UpdateConfig) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{14}
+func (x *Service) GetTolerations() []*Toleration {
+	if x != nil {
+		return x.Tolerations
+	}
+	return nil
 }
 
-func (x *UpdateConfig) GetParallelism() int32 {
+func (x *Service) GetDisruptionBudget() int32 {
 	if x != nil {
-		return x.Parallelism
+		return x.DisruptionBudget
 	}
 	return 0
 }
 
-func (x *UpdateConfig) GetDelaySeconds() int32 {
+func (x *Service) GetPriority() int32 {
 	if x != nil {
-		return x.DelaySeconds
+		return x.Priority
 	}
 	return 0
 }
 
-func (x *UpdateConfig) GetFailureAction() string {
+func (x *Service) GetJob() *JobConfig {
 	if x != nil {
-		return x.FailureAction
+		return x.Job
 	}
-	return ""
+	return nil
 }
 
-func (x *UpdateConfig) GetMaxSurge() int32 {
+func (x *Service) GetJobStatus() *JobStatus {
 	if x != nil {
-		return x.MaxSurge
+		return x.JobStatus
 	}
-	return 0
+	return nil
 }
 
-func (x *UpdateConfig) GetMaxUnavailable() int32 {
+func (x *Service) GetCronJob() *CronJobConfig {
 	if x != nil {
-		return x.MaxUnavailable
+		return x.CronJob
 	}
-	return 0
+	return nil
 }
 
-func (x *UpdateConfig) GetHealthCheckGracePeriodSeconds() int32 {
+func (x *Service) GetLastScheduleTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.HealthCheckGracePeriodSeconds
+		return x.LastScheduleTime
 	}
-	return 0
+	return nil
 }
 
-func (x *UpdateConfig) GetCanaryWeight() int32 {
+func (x *Service) GetCanaryAnalysis() *CanaryAnalysis {
 	if x != nil {
-		return x.CanaryWeight
+		return x.CanaryAnalysis
 	}
-	return 0
+	return nil
 }
 
-func (x *UpdateConfig) GetCanarySteps() []int32 {
+type JobConfig struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Completions           int32                  `protobuf:"varint,1,opt,name=completions,proto3" json:"completions,omitempty"`                                                    // Successful containers needed (default: 1)
+	Parallelism           int32                  `protobuf:"varint,2,opt,name=parallelism,proto3" json:"parallelism,omitempty"`                                                    // Containers running at once (default: 1)
+	BackoffLimit          *int32                 `protobuf:"varint,3,opt,name=backoff_limit,json=backoffLimit,proto3,oneof" json:"backoff_limit,omitempty"`                        // Failed containers tolerated before the job fails (default: 6)
+	ActiveDeadlineSeconds int64                  `protobuf:"varint,4,opt,name=active_deadline_seconds,json=activeDeadlineSeconds,proto3" json:"active_deadline_seconds,omitempty"` // Longest the job may run (default: no limit)
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *JobConfig) Reset() {
+	*x = JobConfig{}
+	mi := &file_api_proto_warren_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobConfig) ProtoMessage() {}
+
+func (x *JobConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[17]
 	if x != nil {
-		return x.CanarySteps
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *UpdateConfig) GetCanaryStabilityWindowSeconds() int32 {
+// Deprecated: Use JobConfig.ProtoReflect.Descriptor instead.
+func (*JobConfig) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *JobConfig) GetCompletions() int32 {
 	if x != nil {
-		return x.CanaryStabilityWindowSeconds
+		return x.Completions
 	}
 	return 0
 }
 
-func (x *UpdateConfig) GetBlueGreenGracePeriodSeconds() int32 {
+func (x *JobConfig) GetParallelism() int32 {
 	if x != nil {
-		return x.BlueGreenGracePeriodSeconds
+		return x.Parallelism
 	}
 	return 0
 }
 
-func (x *UpdateConfig) GetAutoRollbackEnabled() bool {
-	if x != nil {
-		return x.AutoRollbackEnabled
+func (x *JobConfig) GetBackoffLimit() int32 {
+	if x != nil && x.BackoffLimit != nil {
+		return *x.BackoffLimit
 	}
-	return false
+	return 0
 }
 
-func (x *UpdateConfig) GetFailureThresholdPercent() int32 {
+func (x *JobConfig) GetActiveDeadlineSeconds() int64 {
 	if x != nil {
-		return x.FailureThresholdPercent
+		return x.ActiveDeadlineSeconds
 	}
 	return 0
 }
 
-type HealthCheck struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	Type  HealthCheck_Type       `protobuf:"varint,1,opt,name=type,proto3,enum=warren.v1.HealthCheck_Type" json:"type,omitempty"`
-	// Type-specific configurations
-	Http *HTTPHealthCheck `protobuf:"bytes,2,opt,name=http,proto3" json:"http,omitempty"`
-	Tcp  *TCPHealthCheck  `protobuf:"bytes,3,opt,name=tcp,proto3" json:"tcp,omitempty"`
-	Exec *ExecHealthCheck `protobuf:"bytes,4,opt,name=exec,proto3" json:"exec,omitempty"`
-	// Common configuration
-	IntervalSeconds    int32 `protobuf:"varint,5,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"`            // Time between checks (default: 30s)
-	TimeoutSeconds     int32 `protobuf:"varint,6,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`               // Check timeout (default: 10s)
-	Retries            int32 `protobuf:"varint,7,opt,name=retries,proto3" json:"retries,omitempty"`                                                   // Failures before unhealthy (default: 3)
-	StartPeriodSeconds int32 `protobuf:"varint,8,opt,name=start_period_seconds,json=startPeriodSeconds,proto3" json:"start_period_seconds,omitempty"` // Grace period for startup (default: 0s)
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+type JobStatus struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	State          string                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"` // "running", "complete" or "failed"
+	Active         int32                  `protobuf:"varint,2,opt,name=active,proto3" json:"active,omitempty"`
+	Succeeded      int32                  `protobuf:"varint,3,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed         int32                  `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	StartTime      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	CompletionTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=completion_time,json=completionTime,proto3" json:"completion_time,omitempty"`
+	Reason         string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"` // Why the job failed
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *HealthCheck) Reset() {
-	*x = HealthCheck{}
-	mi := &file_api_proto_warren_proto_msgTypes[15]
+func (x *JobStatus) Reset() {
+	*x = JobStatus{}
+	mi := &file_api_proto_warren_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HealthCheck) String() string {
+func (x *JobStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HealthCheck) ProtoMessage() {}
+func (*JobStatus) ProtoMessage() {}
 
-func (x *HealthCheck) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[15]
+func (x *JobStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1205,94 +1503,85 @@ func (x *HealthCheck) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HealthCheck.ProtoReflect.Descriptor instead.
-func (*HealthCheck) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use JobStatus.ProtoReflect.Descriptor instead.
+func (*JobStatus) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *HealthCheck) GetType() HealthCheck_Type {
+func (x *JobStatus) GetState() string {
 	if x != nil {
-		return x.Type
+		return x.State
 	}
-	return HealthCheck_HTTP
+	return ""
 }
 
-func (x *HealthCheck) GetHttp() *HTTPHealthCheck {
+func (x *JobStatus) GetActive() int32 {
 	if x != nil {
-		return x.Http
+		return x.Active
 	}
-	return nil
+	return 0
 }
 
-func (x *HealthCheck) GetTcp() *TCPHealthCheck {
+func (x *JobStatus) GetSucceeded() int32 {
 	if x != nil {
-		return x.Tcp
+		return x.Succeeded
 	}
-	return nil
+	return 0
 }
 
-func (x *HealthCheck) GetExec() *ExecHealthCheck {
+func (x *JobStatus) GetFailed() int32 {
 	if x != nil {
-		return x.Exec
+		return x.Failed
 	}
-	return nil
+	return 0
 }
 
-func (x *HealthCheck) GetIntervalSeconds() int32 {
-	if x != nil {
-		return x.IntervalSeconds
-	}
-	return 0
-}
-
-func (x *HealthCheck) GetTimeoutSeconds() int32 {
+func (x *JobStatus) GetStartTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.TimeoutSeconds
+		return x.StartTime
 	}
-	return 0
+	return nil
 }
 
-func (x *HealthCheck) GetRetries() int32 {
+func (x *JobStatus) GetCompletionTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Retries
+		return x.CompletionTime
 	}
-	return 0
+	return nil
 }
 
-func (x *HealthCheck) GetStartPeriodSeconds() int32 {
+func (x *JobStatus) GetReason() string {
 	if x != nil {
-		return x.StartPeriodSeconds
+		return x.Reason
 	}
-	return 0
+	return ""
 }
 
-type HTTPHealthCheck struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                                           // HTTP path (e.g., "/health")
-	Port          int32                  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`                                          // Container port
-	Scheme        string                 `protobuf:"bytes,3,opt,name=scheme,proto3" json:"scheme,omitempty"`                                       // "http" or "https" (default: "http")
-	Headers       []*Header              `protobuf:"bytes,4,rep,name=headers,proto3" json:"headers,omitempty"`                                     // Custom headers
-	StatusCodeMin int32                  `protobuf:"varint,5,opt,name=status_code_min,json=statusCodeMin,proto3" json:"status_code_min,omitempty"` // Min acceptable status (default: 200)
-	StatusCodeMax int32                  `protobuf:"varint,6,opt,name=status_code_max,json=statusCodeMax,proto3" json:"status_code_max,omitempty"` // Max acceptable status (default: 399)
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type CronJobConfig struct {
+	state                      protoimpl.MessageState `protogen:"open.v1"`
+	Schedule                   string                 `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`                                                                                  // Five-field cron expression in UTC, or a descriptor like "@daily"
+	ConcurrencyPolicy          string                 `protobuf:"bytes,2,opt,name=concurrency_policy,json=concurrencyPolicy,proto3" json:"concurrency_policy,omitempty"`                                       // "Allow" (default), "Forbid" or "Replace"
+	SuccessfulJobsHistoryLimit *int32                 `protobuf:"varint,3,opt,name=successful_jobs_history_limit,json=successfulJobsHistoryLimit,proto3,oneof" json:"successful_jobs_history_limit,omitempty"` // Default: 3
+	FailedJobsHistoryLimit     *int32                 `protobuf:"varint,4,opt,name=failed_jobs_history_limit,json=failedJobsHistoryLimit,proto3,oneof" json:"failed_jobs_history_limit,omitempty"`             // Default: 1
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
 }
 
-func (x *HTTPHealthCheck) Reset() {
-	*x = HTTPHealthCheck{}
-	mi := &file_api_proto_warren_proto_msgTypes[16]
+func (x *CronJobConfig) Reset() {
+	*x = CronJobConfig{}
+	mi := &file_api_proto_warren_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HTTPHealthCheck) String() string {
+func (x *CronJobConfig) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HTTPHealthCheck) ProtoMessage() {}
+func (*CronJobConfig) ProtoMessage() {}
 
-func (x *HTTPHealthCheck) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[16]
+func (x *CronJobConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1303,76 +1592,62 @@ func (x *HTTPHealthCheck) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HTTPHealthCheck.ProtoReflect.Descriptor instead.
-func (*HTTPHealthCheck) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use CronJobConfig.ProtoReflect.Descriptor instead.
+func (*CronJobConfig) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *HTTPHealthCheck) GetPath() string {
+func (x *CronJobConfig) GetSchedule() string {
 	if x != nil {
-		return x.Path
+		return x.Schedule
 	}
 	return ""
 }
 
-func (x *HTTPHealthCheck) GetPort() int32 {
-	if x != nil {
-		return x.Port
-	}
-	return 0
-}
-
-func (x *HTTPHealthCheck) GetScheme() string {
+func (x *CronJobConfig) GetConcurrencyPolicy() string {
 	if x != nil {
-		return x.Scheme
+		return x.ConcurrencyPolicy
 	}
 	return ""
 }
 
-func (x *HTTPHealthCheck) GetHeaders() []*Header {
-	if x != nil {
-		return x.Headers
-	}
-	return nil
-}
-
-func (x *HTTPHealthCheck) GetStatusCodeMin() int32 {
-	if x != nil {
-		return x.StatusCodeMin
+func (x *CronJobConfig) GetSuccessfulJobsHistoryLimit() int32 {
+	if x != nil && x.SuccessfulJobsHistoryLimit != nil {
+		return *x.SuccessfulJobsHistoryLimit
 	}
 	return 0
 }
 
-func (x *HTTPHealthCheck) GetStatusCodeMax() int32 {
-	if x != nil {
-		return x.StatusCodeMax
+func (x *CronJobConfig) GetFailedJobsHistoryLimit() int32 {
+	if x != nil && x.FailedJobsHistoryLimit != nil {
+		return *x.FailedJobsHistoryLimit
 	}
 	return 0
 }
 
-type Header struct {
+type Placement struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Constraints   []string               `protobuf:"bytes,1,rep,name=constraints,proto3" json:"constraints,omitempty"` // e.g. "node.labels.zone==eu-1", "node.role!=manager"
+	Preferences   []*PlacementPreference `protobuf:"bytes,2,rep,name=preferences,proto3" json:"preferences,omitempty"` // Soft preferences, applied in order
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Header) Reset() {
-	*x = Header{}
-	mi := &file_api_proto_warren_proto_msgTypes[17]
+func (x *Placement) Reset() {
+	*x = Placement{}
+	mi := &file_api_proto_warren_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Header) String() string {
+func (x *Placement) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Header) ProtoMessage() {}
+func (*Placement) ProtoMessage() {}
 
-func (x *Header) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[17]
+func (x *Placement) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1383,47 +1658,47 @@ func (x *Header) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Header.ProtoReflect.Descriptor instead.
-func (*Header) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use Placement.ProtoReflect.Descriptor instead.
+func (*Placement) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *Header) GetKey() string {
+func (x *Placement) GetConstraints() []string {
 	if x != nil {
-		return x.Key
+		return x.Constraints
 	}
-	return ""
+	return nil
 }
 
-func (x *Header) GetValue() string {
+func (x *Placement) GetPreferences() []*PlacementPreference {
 	if x != nil {
-		return x.Value
+		return x.Preferences
 	}
-	return ""
+	return nil
 }
 
-type TCPHealthCheck struct {
+type PlacementPreference struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Port          int32                  `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"` // Container port to check
+	Spread        string                 `protobuf:"bytes,1,opt,name=spread,proto3" json:"spread,omitempty"` // Node label to spread replicas across, e.g. "node.labels.zone"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TCPHealthCheck) Reset() {
-	*x = TCPHealthCheck{}
-	mi := &file_api_proto_warren_proto_msgTypes[18]
+func (x *PlacementPreference) Reset() {
+	*x = PlacementPreference{}
+	mi := &file_api_proto_warren_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TCPHealthCheck) String() string {
+func (x *PlacementPreference) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TCPHealthCheck) ProtoMessage() {}
+func (*PlacementPreference) ProtoMessage() {}
 
-func (x *TCPHealthCheck) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[18]
+func (x *PlacementPreference) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1434,40 +1709,41 @@ func (x *TCPHealthCheck) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TCPHealthCheck.ProtoReflect.Descriptor instead.
-func (*TCPHealthCheck) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use PlacementPreference.ProtoReflect.Descriptor instead.
+func (*PlacementPreference) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *TCPHealthCheck) GetPort() int32 {
+func (x *PlacementPreference) GetSpread() string {
 	if x != nil {
-		return x.Port
+		return x.Spread
 	}
-	return 0
+	return ""
 }
 
-type ExecHealthCheck struct {
+type Affinity struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Command       []string               `protobuf:"bytes,1,rep,name=command,proto3" json:"command,omitempty"` // Command to execute (e.g., ["pg_isready"])
+	Required      []*AffinityTerm        `protobuf:"bytes,1,rep,name=required,proto3" json:"required,omitempty"`
+	Preferred     []*AffinityTerm        `protobuf:"bytes,2,rep,name=preferred,proto3" json:"preferred,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ExecHealthCheck) Reset() {
-	*x = ExecHealthCheck{}
-	mi := &file_api_proto_warren_proto_msgTypes[19]
+func (x *Affinity) Reset() {
+	*x = Affinity{}
+	mi := &file_api_proto_warren_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ExecHealthCheck) String() string {
+func (x *Affinity) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExecHealthCheck) ProtoMessage() {}
+func (*Affinity) ProtoMessage() {}
 
-func (x *ExecHealthCheck) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[19]
+func (x *Affinity) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1478,42 +1754,49 @@ func (x *ExecHealthCheck) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExecHealthCheck.ProtoReflect.Descriptor instead.
-func (*ExecHealthCheck) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use Affinity.ProtoReflect.Descriptor instead.
+func (*Affinity) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *ExecHealthCheck) GetCommand() []string {
+func (x *Affinity) GetRequired() []*AffinityTerm {
 	if x != nil {
-		return x.Command
+		return x.Required
 	}
 	return nil
 }
 
-type RestartPolicy struct {
+func (x *Affinity) GetPreferred() []*AffinityTerm {
+	if x != nil {
+		return x.Preferred
+	}
+	return nil
+}
+
+type AffinityTerm struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Condition     string                 `protobuf:"bytes,1,opt,name=condition,proto3" json:"condition,omitempty"` // "none", "on-failure", "any"
-	MaxAttempts   int32                  `protobuf:"varint,2,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
-	DelaySeconds  int32                  `protobuf:"varint,3,opt,name=delay_seconds,json=delaySeconds,proto3" json:"delay_seconds,omitempty"`
+	Service       string                 `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`                                                                         // Service name
+	Labels        map[string]string      `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Service labels
+	Weight        int32                  `protobuf:"varint,3,opt,name=weight,proto3" json:"weight,omitempty"`                                                                          // Preferred terms only (default 1)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RestartPolicy) Reset() {
-	*x = RestartPolicy{}
-	mi := &file_api_proto_warren_proto_msgTypes[20]
+func (x *AffinityTerm) Reset() {
+	*x = AffinityTerm{}
+	mi := &file_api_proto_warren_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RestartPolicy) String() string {
+func (x *AffinityTerm) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RestartPolicy) ProtoMessage() {}
+func (*AffinityTerm) ProtoMessage() {}
 
-func (x *RestartPolicy) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[20]
+func (x *AffinityTerm) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1524,56 +1807,58 @@ func (x *RestartPolicy) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RestartPolicy.ProtoReflect.Descriptor instead.
-func (*RestartPolicy) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use AffinityTerm.ProtoReflect.Descriptor instead.
+func (*AffinityTerm) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *RestartPolicy) GetCondition() string {
+func (x *AffinityTerm) GetService() string {
 	if x != nil {
-		return x.Condition
+		return x.Service
 	}
 	return ""
 }
 
-func (x *RestartPolicy) GetMaxAttempts() int32 {
+func (x *AffinityTerm) GetLabels() map[string]string {
 	if x != nil {
-		return x.MaxAttempts
+		return x.Labels
 	}
-	return 0
+	return nil
 }
 
-func (x *RestartPolicy) GetDelaySeconds() int32 {
+func (x *AffinityTerm) GetWeight() int32 {
 	if x != nil {
-		return x.DelaySeconds
+		return x.Weight
 	}
 	return 0
 }
 
-type ResourceRequirements struct {
-	state                  protoimpl.MessageState `protogen:"open.v1"`
-	CpuShares              int64                  `protobuf:"varint,1,opt,name=cpu_shares,json=cpuShares,proto3" json:"cpu_shares,omitempty"`
-	MemoryBytes            int64                  `protobuf:"varint,2,opt,name=memory_bytes,json=memoryBytes,proto3" json:"memory_bytes,omitempty"`
-	MemoryReservationBytes int64                  `protobuf:"varint,3,opt,name=memory_reservation_bytes,json=memoryReservationBytes,proto3" json:"memory_reservation_bytes,omitempty"`
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+// Toleration lets a service run on nodes with a matching taint
+type Toleration struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`           // Empty with operator "Exists" matches every taint
+	Operator      string                 `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"` // "Equal" (default) or "Exists"
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Effect        string                 `protobuf:"bytes,4,opt,name=effect,proto3" json:"effect,omitempty"` // Empty matches every effect
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ResourceRequirements) Reset() {
-	*x = ResourceRequirements{}
-	mi := &file_api_proto_warren_proto_msgTypes[21]
+func (x *Toleration) Reset() {
+	*x = Toleration{}
+	mi := &file_api_proto_warren_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ResourceRequirements) String() string {
+func (x *Toleration) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResourceRequirements) ProtoMessage() {}
+func (*Toleration) ProtoMessage() {}
 
-func (x *ResourceRequirements) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[21]
+func (x *Toleration) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1584,56 +1869,73 @@ func (x *ResourceRequirements) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResourceRequirements.ProtoReflect.Descriptor instead.
-func (*ResourceRequirements) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use Toleration.ProtoReflect.Descriptor instead.
+func (*Toleration) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *ResourceRequirements) GetCpuShares() int64 {
+func (x *Toleration) GetKey() string {
 	if x != nil {
-		return x.CpuShares
+		return x.Key
 	}
-	return 0
+	return ""
 }
 
-func (x *ResourceRequirements) GetMemoryBytes() int64 {
+func (x *Toleration) GetOperator() string {
 	if x != nil {
-		return x.MemoryBytes
+		return x.Operator
 	}
-	return 0
+	return ""
 }
 
-func (x *ResourceRequirements) GetMemoryReservationBytes() int64 {
+func (x *Toleration) GetValue() string {
 	if x != nil {
-		return x.MemoryReservationBytes
+		return x.Value
 	}
-	return 0
+	return ""
 }
 
-type VolumeMount struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
-	Target        string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
-	ReadOnly      bool                   `protobuf:"varint,3,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Toleration) GetEffect() string {
+	if x != nil {
+		return x.Effect
+	}
+	return ""
 }
 
-func (x *VolumeMount) Reset() {
-	*x = VolumeMount{}
-	mi := &file_api_proto_warren_proto_msgTypes[22]
+type UpdateConfig struct {
+	state                         protoimpl.MessageState `protogen:"open.v1"`
+	Parallelism                   int32                  `protobuf:"varint,1,opt,name=parallelism,proto3" json:"parallelism,omitempty"`
+	DelaySeconds                  int32                  `protobuf:"varint,2,opt,name=delay_seconds,json=delaySeconds,proto3" json:"delay_seconds,omitempty"`
+	FailureAction                 string                 `protobuf:"bytes,3,opt,name=failure_action,json=failureAction,proto3" json:"failure_action,omitempty"` // "pause", "continue", "rollback"
+	MaxSurge                      int32                  `protobuf:"varint,4,opt,name=max_surge,json=maxSurge,proto3" json:"max_surge,omitempty"`
+	MaxUnavailable                int32                  `protobuf:"varint,5,opt,name=max_unavailable,json=maxUnavailable,proto3" json:"max_unavailable,omitempty"`
+	HealthCheckGracePeriodSeconds int32                  `protobuf:"varint,6,opt,name=health_check_grace_period_seconds,json=healthCheckGracePeriodSeconds,proto3" json:"health_check_grace_period_seconds,omitempty"`
+	CanaryWeight                  int32                  `protobuf:"varint,7,opt,name=canary_weight,json=canaryWeight,proto3" json:"canary_weight,omitempty"`
+	CanarySteps                   []int32                `protobuf:"varint,8,rep,packed,name=canary_steps,json=canarySteps,proto3" json:"canary_steps,omitempty"`
+	CanaryStabilityWindowSeconds  int32                  `protobuf:"varint,9,opt,name=canary_stability_window_seconds,json=canaryStabilityWindowSeconds,proto3" json:"canary_stability_window_seconds,omitempty"`
+	BlueGreenGracePeriodSeconds   int32                  `protobuf:"varint,10,opt,name=blue_green_grace_period_seconds,json=blueGreenGracePeriodSeconds,proto3" json:"blue_green_grace_period_seconds,omitempty"`
+	AutoRollbackEnabled           bool                   `protobuf:"varint,11,opt,name=auto_rollback_enabled,json=autoRollbackEnabled,proto3" json:"auto_rollback_enabled,omitempty"`
+	FailureThresholdPercent       int32                  `protobuf:"varint,12,opt,name=failure_threshold_percent,json=failureThresholdPercent,proto3" json:"failure_threshold_percent,omitempty"` // Canary error rate % over stable to trigger rollback (default: 10)
+	P99ThresholdPercent           int32                  `protobuf:"varint,13,opt,name=p99_threshold_percent,json=p99ThresholdPercent,proto3" json:"p99_threshold_percent,omitempty"`             // Canary p99 latency % over stable to trigger rollback (default: 50)
+	unknownFields                 protoimpl.UnknownFields
+	sizeCache                     protoimpl.SizeCache
+}
+
+func (x *UpdateConfig) Reset() {
+	*x = UpdateConfig{}
+	mi := &file_api_proto_warren_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VolumeMount) String() string {
+func (x *UpdateConfig) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VolumeMount) ProtoMessage() {}
+func (*UpdateConfig) ProtoMessage() {}
 
-func (x *VolumeMount) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[22]
+func (x *UpdateConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1644,144 +1946,126 @@ func (x *VolumeMount) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VolumeMount.ProtoReflect.Descriptor instead.
-func (*VolumeMount) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use UpdateConfig.ProtoReflect.Descriptor instead.
+func (*UpdateConfig) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *VolumeMount) GetSource() string {
+func (x *UpdateConfig) GetParallelism() int32 {
 	if x != nil {
-		return x.Source
+		return x.Parallelism
 	}
-	return ""
+	return 0
 }
 
-func (x *VolumeMount) GetTarget() string {
+func (x *UpdateConfig) GetDelaySeconds() int32 {
 	if x != nil {
-		return x.Target
+		return x.DelaySeconds
 	}
-	return ""
+	return 0
 }
 
-func (x *VolumeMount) GetReadOnly() bool {
+func (x *UpdateConfig) GetFailureAction() string {
 	if x != nil {
-		return x.ReadOnly
+		return x.FailureAction
 	}
-	return false
+	return ""
 }
 
-type PortMapping struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	Name          string                  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                                          // Optional port name
-	ContainerPort int32                   `protobuf:"varint,2,opt,name=container_port,json=containerPort,proto3" json:"container_port,omitempty"`                                  // Port inside container (target)
-	HostPort      int32                   `protobuf:"varint,3,opt,name=host_port,json=hostPort,proto3" json:"host_port,omitempty"`                                                 // Port on host/cluster (published)
-	Protocol      string                  `protobuf:"bytes,4,opt,name=protocol,proto3" json:"protocol,omitempty"`                                                                  // "tcp" or "udp" (default: "tcp")
-	PublishMode   PortMapping_PublishMode `protobuf:"varint,5,opt,name=publish_mode,json=publishMode,proto3,enum=warren.v1.PortMapping_PublishMode" json:"publish_mode,omitempty"` // Publishing mode (default: HOST)
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *UpdateConfig) GetMaxSurge() int32 {
+	if x != nil {
+		return x.MaxSurge
+	}
+	return 0
 }
 
-func (x *PortMapping) Reset() {
-	*x = PortMapping{}
-	mi := &file_api_proto_warren_proto_msgTypes[23]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *UpdateConfig) GetMaxUnavailable() int32 {
+	if x != nil {
+		return x.MaxUnavailable
+	}
+	return 0
 }
 
-func (x *PortMapping) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *UpdateConfig) GetHealthCheckGracePeriodSeconds() int32 {
+	if x != nil {
+		return x.HealthCheckGracePeriodSeconds
+	}
+	return 0
 }
 
-func (*PortMapping) ProtoMessage() {}
-
-func (x *PortMapping) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[23]
+func (x *UpdateConfig) GetCanaryWeight() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.CanaryWeight
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use PortMapping.ProtoReflect.Descriptor instead.
-func (*PortMapping) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{23}
+func (x *UpdateConfig) GetCanarySteps() []int32 {
+	if x != nil {
+		return x.CanarySteps
+	}
+	return nil
 }
 
-func (x *PortMapping) GetName() string {
+func (x *UpdateConfig) GetCanaryStabilityWindowSeconds() int32 {
 	if x != nil {
-		return x.Name
+		return x.CanaryStabilityWindowSeconds
 	}
-	return ""
+	return 0
 }
 
-func (x *PortMapping) GetContainerPort() int32 {
+func (x *UpdateConfig) GetBlueGreenGracePeriodSeconds() int32 {
 	if x != nil {
-		return x.ContainerPort
+		return x.BlueGreenGracePeriodSeconds
 	}
 	return 0
 }
 
-func (x *PortMapping) GetHostPort() int32 {
+func (x *UpdateConfig) GetAutoRollbackEnabled() bool {
 	if x != nil {
-		return x.HostPort
+		return x.AutoRollbackEnabled
 	}
-	return 0
+	return false
 }
 
-func (x *PortMapping) GetProtocol() string {
+func (x *UpdateConfig) GetFailureThresholdPercent() int32 {
 	if x != nil {
-		return x.Protocol
+		return x.FailureThresholdPercent
 	}
-	return ""
+	return 0
 }
 
-func (x *PortMapping) GetPublishMode() PortMapping_PublishMode {
+func (x *UpdateConfig) GetP99ThresholdPercent() int32 {
 	if x != nil {
-		return x.PublishMode
+		return x.P99ThresholdPercent
 	}
-	return PortMapping_HOST
+	return 0
 }
 
-type CreateServiceRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Image          string                 `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
-	Replicas       int32                  `protobuf:"varint,3,opt,name=replicas,proto3" json:"replicas,omitempty"`
-	Mode           string                 `protobuf:"bytes,4,opt,name=mode,proto3" json:"mode,omitempty"`
-	DeployStrategy string                 `protobuf:"bytes,5,opt,name=deploy_strategy,json=deployStrategy,proto3" json:"deploy_strategy,omitempty"`
-	UpdateConfig   *UpdateConfig          `protobuf:"bytes,6,opt,name=update_config,json=updateConfig,proto3" json:"update_config,omitempty"`
-	HealthCheck    *HealthCheck           `protobuf:"bytes,7,opt,name=health_check,json=healthCheck,proto3" json:"health_check,omitempty"`
-	RestartPolicy  *RestartPolicy         `protobuf:"bytes,8,opt,name=restart_policy,json=restartPolicy,proto3" json:"restart_policy,omitempty"`
-	Resources      *ResourceRequirements  `protobuf:"bytes,9,opt,name=resources,proto3" json:"resources,omitempty"`
-	Networks       []string               `protobuf:"bytes,10,rep,name=networks,proto3" json:"networks,omitempty"`
-	Volumes        []*VolumeMount         `protobuf:"bytes,11,rep,name=volumes,proto3" json:"volumes,omitempty"`
-	Env            map[string]string      `protobuf:"bytes,12,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Command        []string               `protobuf:"bytes,13,rep,name=command,proto3" json:"command,omitempty"`
-	Ports          []*PortMapping         `protobuf:"bytes,14,rep,name=ports,proto3" json:"ports,omitempty"`                                 // Published ports
-	StopTimeout    int32                  `protobuf:"varint,15,opt,name=stop_timeout,json=stopTimeout,proto3" json:"stop_timeout,omitempty"` // Seconds to wait before force-killing (default: 10)
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type TrafficSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Requests      int32                  `protobuf:"varint,1,opt,name=requests,proto3" json:"requests,omitempty"`
+	Errors        int32                  `protobuf:"varint,2,opt,name=errors,proto3" json:"errors,omitempty"` // Requests answered with a 5xx status
+	P99Ms         int64                  `protobuf:"varint,3,opt,name=p99_ms,json=p99Ms,proto3" json:"p99_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateServiceRequest) Reset() {
-	*x = CreateServiceRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[24]
+func (x *TrafficSummary) Reset() {
+	*x = TrafficSummary{}
+	mi := &file_api_proto_warren_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateServiceRequest) String() string {
+func (x *TrafficSummary) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateServiceRequest) ProtoMessage() {}
+func (*TrafficSummary) ProtoMessage() {}
 
-func (x *CreateServiceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[24]
+func (x *TrafficSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1792,138 +2076,163 @@ func (x *CreateServiceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateServiceRequest.ProtoReflect.Descriptor instead.
-func (*CreateServiceRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use TrafficSummary.ProtoReflect.Descriptor instead.
+func (*TrafficSummary) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *CreateServiceRequest) GetName() string {
+func (x *TrafficSummary) GetRequests() int32 {
 	if x != nil {
-		return x.Name
+		return x.Requests
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateServiceRequest) GetImage() string {
+func (x *TrafficSummary) GetErrors() int32 {
 	if x != nil {
-		return x.Image
+		return x.Errors
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateServiceRequest) GetReplicas() int32 {
+func (x *TrafficSummary) GetP99Ms() int64 {
 	if x != nil {
-		return x.Replicas
+		return x.P99Ms
 	}
 	return 0
 }
 
-func (x *CreateServiceRequest) GetMode() string {
-	if x != nil {
-		return x.Mode
-	}
-	return ""
+type CanaryAnalysis struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        string                 `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"` // "passed", "failed" or "inconclusive"
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Image         string                 `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`    // Image of the canary
+	Weight        int32                  `protobuf:"varint,4,opt,name=weight,proto3" json:"weight,omitempty"` // Canary weight during the analysed step
+	Canary        *TrafficSummary        `protobuf:"bytes,5,opt,name=canary,proto3" json:"canary,omitempty"`
+	Stable        *TrafficSummary        `protobuf:"bytes,6,opt,name=stable,proto3" json:"stable,omitempty"`
+	RolledBack    bool                   `protobuf:"varint,7,opt,name=rolled_back,json=rolledBack,proto3" json:"rolled_back,omitempty"` // Whether the failed analysis rolled the update back
+	AnalyzedAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=analyzed_at,json=analyzedAt,proto3" json:"analyzed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateServiceRequest) GetDeployStrategy() string {
-	if x != nil {
-		return x.DeployStrategy
-	}
-	return ""
+func (x *CanaryAnalysis) Reset() {
+	*x = CanaryAnalysis{}
+	mi := &file_api_proto_warren_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateServiceRequest) GetUpdateConfig() *UpdateConfig {
-	if x != nil {
-		return x.UpdateConfig
-	}
-	return nil
+func (x *CanaryAnalysis) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *CreateServiceRequest) GetHealthCheck() *HealthCheck {
+func (*CanaryAnalysis) ProtoMessage() {}
+
+func (x *CanaryAnalysis) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[27]
 	if x != nil {
-		return x.HealthCheck
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *CreateServiceRequest) GetRestartPolicy() *RestartPolicy {
+// Deprecated: Use CanaryAnalysis.ProtoReflect.Descriptor instead.
+func (*CanaryAnalysis) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CanaryAnalysis) GetResult() string {
 	if x != nil {
-		return x.RestartPolicy
+		return x.Result
 	}
-	return nil
+	return ""
 }
 
-func (x *CreateServiceRequest) GetResources() *ResourceRequirements {
+func (x *CanaryAnalysis) GetReason() string {
 	if x != nil {
-		return x.Resources
+		return x.Reason
 	}
-	return nil
+	return ""
 }
 
-func (x *CreateServiceRequest) GetNetworks() []string {
+func (x *CanaryAnalysis) GetImage() string {
 	if x != nil {
-		return x.Networks
+		return x.Image
 	}
-	return nil
+	return ""
 }
 
-func (x *CreateServiceRequest) GetVolumes() []*VolumeMount {
+func (x *CanaryAnalysis) GetWeight() int32 {
 	if x != nil {
-		return x.Volumes
+		return x.Weight
 	}
-	return nil
+	return 0
 }
 
-func (x *CreateServiceRequest) GetEnv() map[string]string {
+func (x *CanaryAnalysis) GetCanary() *TrafficSummary {
 	if x != nil {
-		return x.Env
+		return x.Canary
 	}
 	return nil
 }
 
-func (x *CreateServiceRequest) GetCommand() []string {
+func (x *CanaryAnalysis) GetStable() *TrafficSummary {
 	if x != nil {
-		return x.Command
+		return x.Stable
 	}
 	return nil
 }
 
-func (x *CreateServiceRequest) GetPorts() []*PortMapping {
+func (x *CanaryAnalysis) GetRolledBack() bool {
 	if x != nil {
-		return x.Ports
+		return x.RolledBack
 	}
-	return nil
+	return false
 }
 
-func (x *CreateServiceRequest) GetStopTimeout() int32 {
+func (x *CanaryAnalysis) GetAnalyzedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.StopTimeout
+		return x.AnalyzedAt
 	}
-	return 0
+	return nil
 }
 
-type CreateServiceResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Service       *Service               `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type HealthCheck struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  HealthCheck_Type       `protobuf:"varint,1,opt,name=type,proto3,enum=warren.v1.HealthCheck_Type" json:"type,omitempty"`
+	// Type-specific configurations
+	Http *HTTPHealthCheck `protobuf:"bytes,2,opt,name=http,proto3" json:"http,omitempty"`
+	Tcp  *TCPHealthCheck  `protobuf:"bytes,3,opt,name=tcp,proto3" json:"tcp,omitempty"`
+	Exec *ExecHealthCheck `protobuf:"bytes,4,opt,name=exec,proto3" json:"exec,omitempty"`
+	// Common configuration
+	IntervalSeconds    int32 `protobuf:"varint,5,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"`            // Time between checks (default: 30s)
+	TimeoutSeconds     int32 `protobuf:"varint,6,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`               // Check timeout (default: 10s)
+	Retries            int32 `protobuf:"varint,7,opt,name=retries,proto3" json:"retries,omitempty"`                                                   // Failures before unhealthy (default: 3)
+	StartPeriodSeconds int32 `protobuf:"varint,8,opt,name=start_period_seconds,json=startPeriodSeconds,proto3" json:"start_period_seconds,omitempty"` // Grace period for startup (default: 0s)
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
-func (x *CreateServiceResponse) Reset() {
-	*x = CreateServiceResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[25]
+func (x *HealthCheck) Reset() {
+	*x = HealthCheck{}
+	mi := &file_api_proto_warren_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateServiceResponse) String() string {
+func (x *HealthCheck) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateServiceResponse) ProtoMessage() {}
+func (*HealthCheck) ProtoMessage() {}
 
-func (x *CreateServiceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[25]
+func (x *HealthCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1934,108 +2243,94 @@ func (x *CreateServiceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateServiceResponse.ProtoReflect.Descriptor instead.
-func (*CreateServiceResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use HealthCheck.ProtoReflect.Descriptor instead.
+func (*HealthCheck) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *CreateServiceResponse) GetService() *Service {
+func (x *HealthCheck) GetType() HealthCheck_Type {
 	if x != nil {
-		return x.Service
+		return x.Type
 	}
-	return nil
+	return HealthCheck_HTTP
 }
 
-type UpdateServiceRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Replicas      int32                  `protobuf:"varint,2,opt,name=replicas,proto3" json:"replicas,omitempty"`
-	Image         string                 `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
-	Env           map[string]string      `protobuf:"bytes,4,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *HealthCheck) GetHttp() *HTTPHealthCheck {
+	if x != nil {
+		return x.Http
+	}
+	return nil
 }
 
-func (x *UpdateServiceRequest) Reset() {
-	*x = UpdateServiceRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[26]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *HealthCheck) GetTcp() *TCPHealthCheck {
+	if x != nil {
+		return x.Tcp
+	}
+	return nil
 }
 
-func (x *UpdateServiceRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *HealthCheck) GetExec() *ExecHealthCheck {
+	if x != nil {
+		return x.Exec
+	}
+	return nil
 }
 
-func (*UpdateServiceRequest) ProtoMessage() {}
-
-func (x *UpdateServiceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[26]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use UpdateServiceRequest.ProtoReflect.Descriptor instead.
-func (*UpdateServiceRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{26}
-}
-
-func (x *UpdateServiceRequest) GetId() string {
+func (x *HealthCheck) GetIntervalSeconds() int32 {
 	if x != nil {
-		return x.Id
+		return x.IntervalSeconds
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateServiceRequest) GetReplicas() int32 {
+func (x *HealthCheck) GetTimeoutSeconds() int32 {
 	if x != nil {
-		return x.Replicas
+		return x.TimeoutSeconds
 	}
 	return 0
 }
 
-func (x *UpdateServiceRequest) GetImage() string {
+func (x *HealthCheck) GetRetries() int32 {
 	if x != nil {
-		return x.Image
+		return x.Retries
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateServiceRequest) GetEnv() map[string]string {
+func (x *HealthCheck) GetStartPeriodSeconds() int32 {
 	if x != nil {
-		return x.Env
+		return x.StartPeriodSeconds
 	}
-	return nil
+	return 0
 }
 
-type UpdateServiceResponse struct {
+type HTTPHealthCheck struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Service       *Service               `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                                           // HTTP path (e.g., "/health")
+	Port          int32                  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`                                          // Container port
+	Scheme        string                 `protobuf:"bytes,3,opt,name=scheme,proto3" json:"scheme,omitempty"`                                       // "http" or "https" (default: "http")
+	Headers       []*Header              `protobuf:"bytes,4,rep,name=headers,proto3" json:"headers,omitempty"`                                     // Custom headers
+	StatusCodeMin int32                  `protobuf:"varint,5,opt,name=status_code_min,json=statusCodeMin,proto3" json:"status_code_min,omitempty"` // Min acceptable status (default: 200)
+	StatusCodeMax int32                  `protobuf:"varint,6,opt,name=status_code_max,json=statusCodeMax,proto3" json:"status_code_max,omitempty"` // Max acceptable status (default: 399)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateServiceResponse) Reset() {
-	*x = UpdateServiceResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[27]
+func (x *HTTPHealthCheck) Reset() {
+	*x = HTTPHealthCheck{}
+	mi := &file_api_proto_warren_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateServiceResponse) String() string {
+func (x *HTTPHealthCheck) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateServiceResponse) ProtoMessage() {}
+func (*HTTPHealthCheck) ProtoMessage() {}
 
-func (x *UpdateServiceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[27]
+func (x *HTTPHealthCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2046,108 +2341,76 @@ func (x *UpdateServiceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateServiceResponse.ProtoReflect.Descriptor instead.
-func (*UpdateServiceResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use HTTPHealthCheck.ProtoReflect.Descriptor instead.
+func (*HTTPHealthCheck) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *UpdateServiceResponse) GetService() *Service {
+func (x *HTTPHealthCheck) GetPath() string {
 	if x != nil {
-		return x.Service
+		return x.Path
 	}
-	return nil
-}
-
-type UpdateServiceImageRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Image         string                 `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
-	Strategy      string                 `protobuf:"bytes,3,opt,name=strategy,proto3" json:"strategy,omitempty"` // "rolling", "blue-green", "canary"
-	UpdateConfig  *UpdateConfig          `protobuf:"bytes,4,opt,name=update_config,json=updateConfig,proto3" json:"update_config,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *UpdateServiceImageRequest) Reset() {
-	*x = UpdateServiceImageRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[28]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *UpdateServiceImageRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+	return ""
 }
 
-func (*UpdateServiceImageRequest) ProtoMessage() {}
-
-func (x *UpdateServiceImageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[28]
+func (x *HTTPHealthCheck) GetPort() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Port
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use UpdateServiceImageRequest.ProtoReflect.Descriptor instead.
-func (*UpdateServiceImageRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{28}
+	return 0
 }
 
-func (x *UpdateServiceImageRequest) GetId() string {
+func (x *HTTPHealthCheck) GetScheme() string {
 	if x != nil {
-		return x.Id
+		return x.Scheme
 	}
 	return ""
 }
 
-func (x *UpdateServiceImageRequest) GetImage() string {
+func (x *HTTPHealthCheck) GetHeaders() []*Header {
 	if x != nil {
-		return x.Image
+		return x.Headers
 	}
-	return ""
+	return nil
 }
 
-func (x *UpdateServiceImageRequest) GetStrategy() string {
+func (x *HTTPHealthCheck) GetStatusCodeMin() int32 {
 	if x != nil {
-		return x.Strategy
+		return x.StatusCodeMin
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateServiceImageRequest) GetUpdateConfig() *UpdateConfig {
+func (x *HTTPHealthCheck) GetStatusCodeMax() int32 {
 	if x != nil {
-		return x.UpdateConfig
+		return x.StatusCodeMax
 	}
-	return nil
+	return 0
 }
 
-type UpdateServiceImageResponse struct {
+type Header struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateServiceImageResponse) Reset() {
-	*x = UpdateServiceImageResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[29]
+func (x *Header) Reset() {
+	*x = Header{}
+	mi := &file_api_proto_warren_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateServiceImageResponse) String() string {
+func (x *Header) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateServiceImageResponse) ProtoMessage() {}
+func (*Header) ProtoMessage() {}
 
-func (x *UpdateServiceImageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[29]
+func (x *Header) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2158,40 +2421,47 @@ func (x *UpdateServiceImageResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateServiceImageResponse.ProtoReflect.Descriptor instead.
-func (*UpdateServiceImageResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use Header.ProtoReflect.Descriptor instead.
+func (*Header) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *UpdateServiceImageResponse) GetStatus() string {
+func (x *Header) GetKey() string {
 	if x != nil {
-		return x.Status
+		return x.Key
 	}
 	return ""
 }
 
-type RollbackServiceRequest struct {
+func (x *Header) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type TCPHealthCheck struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Port          int32                  `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"` // Container port to check
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RollbackServiceRequest) Reset() {
-	*x = RollbackServiceRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[30]
+func (x *TCPHealthCheck) Reset() {
+	*x = TCPHealthCheck{}
+	mi := &file_api_proto_warren_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RollbackServiceRequest) String() string {
+func (x *TCPHealthCheck) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RollbackServiceRequest) ProtoMessage() {}
+func (*TCPHealthCheck) ProtoMessage() {}
 
-func (x *RollbackServiceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[30]
+func (x *TCPHealthCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2202,40 +2472,40 @@ func (x *RollbackServiceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RollbackServiceRequest.ProtoReflect.Descriptor instead.
-func (*RollbackServiceRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use TCPHealthCheck.ProtoReflect.Descriptor instead.
+func (*TCPHealthCheck) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *RollbackServiceRequest) GetId() string {
+func (x *TCPHealthCheck) GetPort() int32 {
 	if x != nil {
-		return x.Id
+		return x.Port
 	}
-	return ""
+	return 0
 }
 
-type RollbackServiceResponse struct {
+type ExecHealthCheck struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Command       []string               `protobuf:"bytes,1,rep,name=command,proto3" json:"command,omitempty"` // Command to execute (e.g., ["pg_isready"])
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RollbackServiceResponse) Reset() {
-	*x = RollbackServiceResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[31]
+func (x *ExecHealthCheck) Reset() {
+	*x = ExecHealthCheck{}
+	mi := &file_api_proto_warren_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RollbackServiceResponse) String() string {
+func (x *ExecHealthCheck) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RollbackServiceResponse) ProtoMessage() {}
+func (*ExecHealthCheck) ProtoMessage() {}
 
-func (x *RollbackServiceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[31]
+func (x *ExecHealthCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2246,40 +2516,42 @@ func (x *RollbackServiceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RollbackServiceResponse.ProtoReflect.Descriptor instead.
-func (*RollbackServiceResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use ExecHealthCheck.ProtoReflect.Descriptor instead.
+func (*ExecHealthCheck) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *RollbackServiceResponse) GetStatus() string {
+func (x *ExecHealthCheck) GetCommand() []string {
 	if x != nil {
-		return x.Status
+		return x.Command
 	}
-	return ""
+	return nil
 }
 
-type DeleteServiceRequest struct {
+type RestartPolicy struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Condition     string                 `protobuf:"bytes,1,opt,name=condition,proto3" json:"condition,omitempty"` // "none", "on-failure", "any"
+	MaxAttempts   int32                  `protobuf:"varint,2,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	DelaySeconds  int32                  `protobuf:"varint,3,opt,name=delay_seconds,json=delaySeconds,proto3" json:"delay_seconds,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteServiceRequest) Reset() {
-	*x = DeleteServiceRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[32]
+func (x *RestartPolicy) Reset() {
+	*x = RestartPolicy{}
+	mi := &file_api_proto_warren_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteServiceRequest) String() string {
+func (x *RestartPolicy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteServiceRequest) ProtoMessage() {}
+func (*RestartPolicy) ProtoMessage() {}
 
-func (x *DeleteServiceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[32]
+func (x *RestartPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2290,84 +2562,56 @@ func (x *DeleteServiceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteServiceRequest.ProtoReflect.Descriptor instead.
-func (*DeleteServiceRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{32}
+// Deprecated: Use RestartPolicy.ProtoReflect.Descriptor instead.
+func (*RestartPolicy) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *DeleteServiceRequest) GetId() string {
+func (x *RestartPolicy) GetCondition() string {
 	if x != nil {
-		return x.Id
+		return x.Condition
 	}
 	return ""
 }
 
-type DeleteServiceResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *DeleteServiceResponse) Reset() {
-	*x = DeleteServiceResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[33]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *DeleteServiceResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*DeleteServiceResponse) ProtoMessage() {}
-
-func (x *DeleteServiceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[33]
+func (x *RestartPolicy) GetMaxAttempts() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.MaxAttempts
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use DeleteServiceResponse.ProtoReflect.Descriptor instead.
-func (*DeleteServiceResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{33}
+	return 0
 }
 
-func (x *DeleteServiceResponse) GetStatus() string {
+func (x *RestartPolicy) GetDelaySeconds() int32 {
 	if x != nil {
-		return x.Status
+		return x.DelaySeconds
 	}
-	return ""
+	return 0
 }
 
-type GetServiceRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"` // can query by name or id
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type ResourceRequirements struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	CpuShares              int64                  `protobuf:"varint,1,opt,name=cpu_shares,json=cpuShares,proto3" json:"cpu_shares,omitempty"`
+	MemoryBytes            int64                  `protobuf:"varint,2,opt,name=memory_bytes,json=memoryBytes,proto3" json:"memory_bytes,omitempty"`
+	MemoryReservationBytes int64                  `protobuf:"varint,3,opt,name=memory_reservation_bytes,json=memoryReservationBytes,proto3" json:"memory_reservation_bytes,omitempty"`
+	CpuReservationShares   int64                  `protobuf:"varint,4,opt,name=cpu_reservation_shares,json=cpuReservationShares,proto3" json:"cpu_reservation_shares,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
-func (x *GetServiceRequest) Reset() {
-	*x = GetServiceRequest{}
+func (x *ResourceRequirements) Reset() {
+	*x = ResourceRequirements{}
 	mi := &file_api_proto_warren_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetServiceRequest) String() string {
+func (x *ResourceRequirements) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetServiceRequest) ProtoMessage() {}
+func (*ResourceRequirements) ProtoMessage() {}
 
-func (x *GetServiceRequest) ProtoReflect() protoreflect.Message {
+func (x *ResourceRequirements) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_warren_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2379,46 +2623,62 @@ func (x *GetServiceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetServiceRequest.ProtoReflect.Descriptor instead.
-func (*GetServiceRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ResourceRequirements.ProtoReflect.Descriptor instead.
+func (*ResourceRequirements) Descriptor() ([]byte, []int) {
 	return file_api_proto_warren_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *GetServiceRequest) GetId() string {
+func (x *ResourceRequirements) GetCpuShares() int64 {
 	if x != nil {
-		return x.Id
+		return x.CpuShares
 	}
-	return ""
+	return 0
 }
 
-func (x *GetServiceRequest) GetName() string {
+func (x *ResourceRequirements) GetMemoryBytes() int64 {
 	if x != nil {
-		return x.Name
+		return x.MemoryBytes
 	}
-	return ""
+	return 0
 }
 
-type GetServiceResponse struct {
+func (x *ResourceRequirements) GetMemoryReservationBytes() int64 {
+	if x != nil {
+		return x.MemoryReservationBytes
+	}
+	return 0
+}
+
+func (x *ResourceRequirements) GetCpuReservationShares() int64 {
+	if x != nil {
+		return x.CpuReservationShares
+	}
+	return 0
+}
+
+type VolumeMount struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Service       *Service               `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Target        string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	ReadOnly      bool                   `protobuf:"varint,3,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetServiceResponse) Reset() {
-	*x = GetServiceResponse{}
+func (x *VolumeMount) Reset() {
+	*x = VolumeMount{}
 	mi := &file_api_proto_warren_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetServiceResponse) String() string {
+func (x *VolumeMount) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetServiceResponse) ProtoMessage() {}
+func (*VolumeMount) ProtoMessage() {}
 
-func (x *GetServiceResponse) ProtoReflect() protoreflect.Message {
+func (x *VolumeMount) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_warren_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2430,38 +2690,57 @@ func (x *GetServiceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetServiceResponse.ProtoReflect.Descriptor instead.
-func (*GetServiceResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use VolumeMount.ProtoReflect.Descriptor instead.
+func (*VolumeMount) Descriptor() ([]byte, []int) {
 	return file_api_proto_warren_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *GetServiceResponse) GetService() *Service {
+func (x *VolumeMount) GetSource() string {
 	if x != nil {
-		return x.Service
+		return x.Source
 	}
-	return nil
+	return ""
 }
 
-type ListServicesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+func (x *VolumeMount) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *VolumeMount) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+type PortMapping struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Name          string                  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                                          // Optional port name
+	ContainerPort int32                   `protobuf:"varint,2,opt,name=container_port,json=containerPort,proto3" json:"container_port,omitempty"`                                  // Port inside container (target)
+	HostPort      int32                   `protobuf:"varint,3,opt,name=host_port,json=hostPort,proto3" json:"host_port,omitempty"`                                                 // Port on host/cluster (published)
+	Protocol      string                  `protobuf:"bytes,4,opt,name=protocol,proto3" json:"protocol,omitempty"`                                                                  // "tcp" or "udp" (default: "tcp")
+	PublishMode   PortMapping_PublishMode `protobuf:"varint,5,opt,name=publish_mode,json=publishMode,proto3,enum=warren.v1.PortMapping_PublishMode" json:"publish_mode,omitempty"` // Publishing mode (default: HOST)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListServicesRequest) Reset() {
-	*x = ListServicesRequest{}
+func (x *PortMapping) Reset() {
+	*x = PortMapping{}
 	mi := &file_api_proto_warren_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListServicesRequest) String() string {
+func (x *PortMapping) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListServicesRequest) ProtoMessage() {}
+func (*PortMapping) ProtoMessage() {}
 
-func (x *ListServicesRequest) ProtoReflect() protoreflect.Message {
+func (x *PortMapping) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_warren_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2473,96 +2752,91 @@ func (x *ListServicesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListServicesRequest.ProtoReflect.Descriptor instead.
-func (*ListServicesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use PortMapping.ProtoReflect.Descriptor instead.
+func (*PortMapping) Descriptor() ([]byte, []int) {
 	return file_api_proto_warren_proto_rawDescGZIP(), []int{36}
 }
 
-type ListServicesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Services      []*Service             `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ListServicesResponse) Reset() {
-	*x = ListServicesResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[37]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *PortMapping) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
-func (x *ListServicesResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *PortMapping) GetContainerPort() int32 {
+	if x != nil {
+		return x.ContainerPort
+	}
+	return 0
 }
 
-func (*ListServicesResponse) ProtoMessage() {}
-
-func (x *ListServicesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[37]
+func (x *PortMapping) GetHostPort() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.HostPort
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use ListServicesResponse.ProtoReflect.Descriptor instead.
-func (*ListServicesResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{37}
+func (x *PortMapping) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
 }
 
-func (x *ListServicesResponse) GetServices() []*Service {
+func (x *PortMapping) GetPublishMode() PortMapping_PublishMode {
 	if x != nil {
-		return x.Services
+		return x.PublishMode
 	}
-	return nil
+	return PortMapping_HOST
 }
 
-// Container messages
-type Container struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ServiceId          string                 `protobuf:"bytes,2,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
-	ServiceName        string                 `protobuf:"bytes,3,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
-	NodeId             string                 `protobuf:"bytes,4,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
-	RuntimeContainerId string                 `protobuf:"bytes,5,opt,name=runtime_container_id,json=runtimeContainerId,proto3" json:"runtime_container_id,omitempty"`
-	DesiredState       string                 `protobuf:"bytes,6,opt,name=desired_state,json=desiredState,proto3" json:"desired_state,omitempty"` // "running", "shutdown"
-	ActualState        string                 `protobuf:"bytes,7,opt,name=actual_state,json=actualState,proto3" json:"actual_state,omitempty"`    // "pending", "running", "failed", "stopped"
-	Image              string                 `protobuf:"bytes,8,opt,name=image,proto3" json:"image,omitempty"`
-	Command            []string               `protobuf:"bytes,9,rep,name=command,proto3" json:"command,omitempty"`
-	Env                map[string]string      `protobuf:"bytes,10,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Resources          *ResourceRequirements  `protobuf:"bytes,11,opt,name=resources,proto3" json:"resources,omitempty"`
-	Volumes            []*VolumeMount         `protobuf:"bytes,12,rep,name=volumes,proto3" json:"volumes,omitempty"`
-	HealthCheck        *HealthCheck           `protobuf:"bytes,13,opt,name=health_check,json=healthCheck,proto3" json:"health_check,omitempty"`
-	RestartPolicy      *RestartPolicy         `protobuf:"bytes,14,opt,name=restart_policy,json=restartPolicy,proto3" json:"restart_policy,omitempty"`
-	CreatedAt          *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt          *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	Error              string                 `protobuf:"bytes,17,opt,name=error,proto3" json:"error,omitempty"`
-	Secrets            []string               `protobuf:"bytes,18,rep,name=secrets,proto3" json:"secrets,omitempty"`                             // Secret names to mount
-	StopTimeout        int32                  `protobuf:"varint,19,opt,name=stop_timeout,json=stopTimeout,proto3" json:"stop_timeout,omitempty"` // Seconds to wait before force-killing (default: 10)
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+type CreateServiceRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Name             string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Image            string                 `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	Replicas         int32                  `protobuf:"varint,3,opt,name=replicas,proto3" json:"replicas,omitempty"`
+	Mode             string                 `protobuf:"bytes,4,opt,name=mode,proto3" json:"mode,omitempty"`
+	DeployStrategy   string                 `protobuf:"bytes,5,opt,name=deploy_strategy,json=deployStrategy,proto3" json:"deploy_strategy,omitempty"`
+	UpdateConfig     *UpdateConfig          `protobuf:"bytes,6,opt,name=update_config,json=updateConfig,proto3" json:"update_config,omitempty"`
+	HealthCheck      *HealthCheck           `protobuf:"bytes,7,opt,name=health_check,json=healthCheck,proto3" json:"health_check,omitempty"`
+	RestartPolicy    *RestartPolicy         `protobuf:"bytes,8,opt,name=restart_policy,json=restartPolicy,proto3" json:"restart_policy,omitempty"`
+	Resources        *ResourceRequirements  `protobuf:"bytes,9,opt,name=resources,proto3" json:"resources,omitempty"`
+	Networks         []string               `protobuf:"bytes,10,rep,name=networks,proto3" json:"networks,omitempty"`
+	Volumes          []*VolumeMount         `protobuf:"bytes,11,rep,name=volumes,proto3" json:"volumes,omitempty"`
+	Env              map[string]string      `protobuf:"bytes,12,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Command          []string               `protobuf:"bytes,13,rep,name=command,proto3" json:"command,omitempty"`
+	Ports            []*PortMapping         `protobuf:"bytes,14,rep,name=ports,proto3" json:"ports,omitempty"`                                 // Published ports
+	StopTimeout      int32                  `protobuf:"varint,15,opt,name=stop_timeout,json=stopTimeout,proto3" json:"stop_timeout,omitempty"` // Seconds to wait before force-killing (default: 10)
+	Placement        *Placement             `protobuf:"bytes,16,opt,name=placement,proto3" json:"placement,omitempty"`
+	Labels           map[string]string      `protobuf:"bytes,17,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Affinity         *Affinity              `protobuf:"bytes,18,opt,name=affinity,proto3" json:"affinity,omitempty"`
+	AntiAffinity     *Affinity              `protobuf:"bytes,19,opt,name=anti_affinity,json=antiAffinity,proto3" json:"anti_affinity,omitempty"`
+	Tolerations      []*Toleration          `protobuf:"bytes,20,rep,name=tolerations,proto3" json:"tolerations,omitempty"`
+	DisruptionBudget int32                  `protobuf:"varint,21,opt,name=disruption_budget,json=disruptionBudget,proto3" json:"disruption_budget,omitempty"`
+	Priority         int32                  `protobuf:"varint,22,opt,name=priority,proto3" json:"priority,omitempty"`
+	Job              *JobConfig             `protobuf:"bytes,23,opt,name=job,proto3" json:"job,omitempty"`
+	CronJob          *CronJobConfig         `protobuf:"bytes,24,opt,name=cron_job,json=cronJob,proto3" json:"cron_job,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *Container) Reset() {
-	*x = Container{}
-	mi := &file_api_proto_warren_proto_msgTypes[38]
+func (x *CreateServiceRequest) Reset() {
+	*x = CreateServiceRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Container) String() string {
+func (x *CreateServiceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Container) ProtoMessage() {}
+func (*CreateServiceRequest) ProtoMessage() {}
 
-func (x *Container) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[38]
+func (x *CreateServiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2573,170 +2847,3052 @@ func (x *Container) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Container.ProtoReflect.Descriptor instead.
-func (*Container) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{38}
+// Deprecated: Use CreateServiceRequest.ProtoReflect.Descriptor instead.
+func (*CreateServiceRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *Container) GetId() string {
+func (x *CreateServiceRequest) GetName() string {
 	if x != nil {
-		return x.Id
+		return x.Name
 	}
 	return ""
 }
 
-func (x *Container) GetServiceId() string {
+func (x *CreateServiceRequest) GetImage() string {
 	if x != nil {
-		return x.ServiceId
+		return x.Image
 	}
 	return ""
 }
 
-func (x *Container) GetServiceName() string {
+func (x *CreateServiceRequest) GetReplicas() int32 {
 	if x != nil {
-		return x.ServiceName
+		return x.Replicas
 	}
-	return ""
+	return 0
 }
 
-func (x *Container) GetNodeId() string {
+func (x *CreateServiceRequest) GetMode() string {
 	if x != nil {
-		return x.NodeId
+		return x.Mode
 	}
 	return ""
 }
 
-func (x *Container) GetRuntimeContainerId() string {
+func (x *CreateServiceRequest) GetDeployStrategy() string {
 	if x != nil {
-		return x.RuntimeContainerId
+		return x.DeployStrategy
 	}
 	return ""
 }
 
-func (x *Container) GetDesiredState() string {
+func (x *CreateServiceRequest) GetUpdateConfig() *UpdateConfig {
 	if x != nil {
-		return x.DesiredState
+		return x.UpdateConfig
 	}
-	return ""
+	return nil
 }
 
-func (x *Container) GetActualState() string {
+func (x *CreateServiceRequest) GetHealthCheck() *HealthCheck {
 	if x != nil {
-		return x.ActualState
+		return x.HealthCheck
 	}
-	return ""
+	return nil
 }
 
-func (x *Container) GetImage() string {
+func (x *CreateServiceRequest) GetRestartPolicy() *RestartPolicy {
 	if x != nil {
-		return x.Image
+		return x.RestartPolicy
 	}
-	return ""
+	return nil
 }
 
-func (x *Container) GetCommand() []string {
+func (x *CreateServiceRequest) GetResources() *ResourceRequirements {
 	if x != nil {
-		return x.Command
+		return x.Resources
 	}
 	return nil
 }
 
-func (x *Container) GetEnv() map[string]string {
+func (x *CreateServiceRequest) GetNetworks() []string {
+	if x != nil {
+		return x.Networks
+	}
+	return nil
+}
+
+func (x *CreateServiceRequest) GetVolumes() []*VolumeMount {
+	if x != nil {
+		return x.Volumes
+	}
+	return nil
+}
+
+func (x *CreateServiceRequest) GetEnv() map[string]string {
 	if x != nil {
 		return x.Env
 	}
 	return nil
 }
 
-func (x *Container) GetResources() *ResourceRequirements {
+func (x *CreateServiceRequest) GetCommand() []string {
 	if x != nil {
-		return x.Resources
+		return x.Command
 	}
 	return nil
 }
 
-func (x *Container) GetVolumes() []*VolumeMount {
+func (x *CreateServiceRequest) GetPorts() []*PortMapping {
 	if x != nil {
-		return x.Volumes
+		return x.Ports
 	}
 	return nil
 }
 
-func (x *Container) GetHealthCheck() *HealthCheck {
+func (x *CreateServiceRequest) GetStopTimeout() int32 {
 	if x != nil {
-		return x.HealthCheck
+		return x.StopTimeout
+	}
+	return 0
+}
+
+func (x *CreateServiceRequest) GetPlacement() *Placement {
+	if x != nil {
+		return x.Placement
 	}
 	return nil
 }
 
-func (x *Container) GetRestartPolicy() *RestartPolicy {
+func (x *CreateServiceRequest) GetLabels() map[string]string {
 	if x != nil {
-		return x.RestartPolicy
+		return x.Labels
 	}
 	return nil
 }
 
-func (x *Container) GetCreatedAt() *timestamppb.Timestamp {
+func (x *CreateServiceRequest) GetAffinity() *Affinity {
 	if x != nil {
-		return x.CreatedAt
+		return x.Affinity
 	}
 	return nil
 }
 
-func (x *Container) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *CreateServiceRequest) GetAntiAffinity() *Affinity {
 	if x != nil {
-		return x.UpdatedAt
+		return x.AntiAffinity
 	}
 	return nil
 }
 
-func (x *Container) GetError() string {
+func (x *CreateServiceRequest) GetTolerations() []*Toleration {
 	if x != nil {
-		return x.Error
+		return x.Tolerations
 	}
-	return ""
+	return nil
 }
 
-func (x *Container) GetSecrets() []string {
+func (x *CreateServiceRequest) GetDisruptionBudget() int32 {
 	if x != nil {
-		return x.Secrets
+		return x.DisruptionBudget
+	}
+	return 0
+}
+
+func (x *CreateServiceRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *CreateServiceRequest) GetJob() *JobConfig {
+	if x != nil {
+		return x.Job
 	}
 	return nil
 }
 
-func (x *Container) GetStopTimeout() int32 {
+func (x *CreateServiceRequest) GetCronJob() *CronJobConfig {
 	if x != nil {
-		return x.StopTimeout
+		return x.CronJob
+	}
+	return nil
+}
+
+type CreateServiceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Service       *Service               `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateServiceResponse) Reset() {
+	*x = CreateServiceResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateServiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateServiceResponse) ProtoMessage() {}
+
+func (x *CreateServiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateServiceResponse.ProtoReflect.Descriptor instead.
+func (*CreateServiceResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CreateServiceResponse) GetService() *Service {
+	if x != nil {
+		return x.Service
+	}
+	return nil
+}
+
+type UpdateServiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Replicas      int32                  `protobuf:"varint,2,opt,name=replicas,proto3" json:"replicas,omitempty"`
+	Image         string                 `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	Env           map[string]string      `protobuf:"bytes,4,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ChangeCause   string                 `protobuf:"bytes,5,opt,name=change_cause,json=changeCause,proto3" json:"change_cause,omitempty"` // Recorded with the service revision
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateServiceRequest) Reset() {
+	*x = UpdateServiceRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateServiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateServiceRequest) ProtoMessage() {}
+
+func (x *UpdateServiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateServiceRequest.ProtoReflect.Descriptor instead.
+func (*UpdateServiceRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *UpdateServiceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateServiceRequest) GetReplicas() int32 {
+	if x != nil {
+		return x.Replicas
 	}
 	return 0
 }
 
-type UpdateContainerStatusRequest struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	ContainerId        string                 `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
-	NodeId             string                 `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
-	ActualState        string                 `protobuf:"bytes,3,opt,name=actual_state,json=actualState,proto3" json:"actual_state,omitempty"`
-	RuntimeContainerId string                 `protobuf:"bytes,4,opt,name=runtime_container_id,json=runtimeContainerId,proto3" json:"runtime_container_id,omitempty"`
-	Error              string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+func (x *UpdateServiceRequest) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *UpdateServiceRequest) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *UpdateServiceRequest) GetChangeCause() string {
+	if x != nil {
+		return x.ChangeCause
+	}
+	return ""
+}
+
+type UpdateServiceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Service       *Service               `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateServiceResponse) Reset() {
+	*x = UpdateServiceResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateServiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateServiceResponse) ProtoMessage() {}
+
+func (x *UpdateServiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateServiceResponse.ProtoReflect.Descriptor instead.
+func (*UpdateServiceResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *UpdateServiceResponse) GetService() *Service {
+	if x != nil {
+		return x.Service
+	}
+	return nil
+}
+
+type UpdateServiceImageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Image         string                 `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	Strategy      string                 `protobuf:"bytes,3,opt,name=strategy,proto3" json:"strategy,omitempty"` // "rolling", "blue-green", "canary"
+	UpdateConfig  *UpdateConfig          `protobuf:"bytes,4,opt,name=update_config,json=updateConfig,proto3" json:"update_config,omitempty"`
+	ChangeCause   string                 `protobuf:"bytes,5,opt,name=change_cause,json=changeCause,proto3" json:"change_cause,omitempty"`                                        // Recorded with the service revision
+	Env           map[string]string      `protobuf:"bytes,6,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Replaces the service's env if set
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateServiceImageRequest) Reset() {
+	*x = UpdateServiceImageRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateServiceImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateServiceImageRequest) ProtoMessage() {}
+
+func (x *UpdateServiceImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateServiceImageRequest.ProtoReflect.Descriptor instead.
+func (*UpdateServiceImageRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *UpdateServiceImageRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateServiceImageRequest) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *UpdateServiceImageRequest) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *UpdateServiceImageRequest) GetUpdateConfig() *UpdateConfig {
+	if x != nil {
+		return x.UpdateConfig
+	}
+	return nil
+}
+
+func (x *UpdateServiceImageRequest) GetChangeCause() string {
+	if x != nil {
+		return x.ChangeCause
+	}
+	return ""
+}
+
+func (x *UpdateServiceImageRequest) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+type UpdateServiceImageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	DeploymentId  string                 `protobuf:"bytes,2,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"` // Deployment tracking the update
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateServiceImageResponse) Reset() {
+	*x = UpdateServiceImageResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateServiceImageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateServiceImageResponse) ProtoMessage() {}
+
+func (x *UpdateServiceImageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateServiceImageResponse.ProtoReflect.Descriptor instead.
+func (*UpdateServiceImageResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *UpdateServiceImageResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateServiceImageResponse) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+type RollbackServiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ToRevision    int32                  `protobuf:"varint,2,opt,name=to_revision,json=toRevision,proto3" json:"to_revision,omitempty"` // Revision to restore; the one before the current one if 0
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackServiceRequest) Reset() {
+	*x = RollbackServiceRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackServiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackServiceRequest) ProtoMessage() {}
+
+func (x *RollbackServiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackServiceRequest.ProtoReflect.Descriptor instead.
+func (*RollbackServiceRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *RollbackServiceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RollbackServiceRequest) GetToRevision() int32 {
+	if x != nil {
+		return x.ToRevision
+	}
+	return 0
+}
+
+type RollbackServiceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	DeploymentId  string                 `protobuf:"bytes,2,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"` // Deployment restoring the revision
+	Revision      int32                  `protobuf:"varint,3,opt,name=revision,proto3" json:"revision,omitempty"`                            // Revision restored
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackServiceResponse) Reset() {
+	*x = RollbackServiceResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackServiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackServiceResponse) ProtoMessage() {}
+
+func (x *RollbackServiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackServiceResponse.ProtoReflect.Descriptor instead.
+func (*RollbackServiceResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *RollbackServiceResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *RollbackServiceResponse) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *RollbackServiceResponse) GetRevision() int32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+// ServiceRevision is a recorded version of a service's spec
+type ServiceRevision struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revision      int32                  `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	ChangeCause   string                 `protobuf:"bytes,2,opt,name=change_cause,json=changeCause,proto3" json:"change_cause,omitempty"`
+	Spec          *Service               `protobuf:"bytes,3,opt,name=spec,proto3" json:"spec,omitempty"` // Image, env, resources, health check and other settings
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceRevision) Reset() {
+	*x = ServiceRevision{}
+	mi := &file_api_proto_warren_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceRevision) ProtoMessage() {}
+
+func (x *ServiceRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceRevision.ProtoReflect.Descriptor instead.
+func (*ServiceRevision) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ServiceRevision) GetRevision() int32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *ServiceRevision) GetChangeCause() string {
+	if x != nil {
+		return x.ChangeCause
+	}
+	return ""
+}
+
+func (x *ServiceRevision) GetSpec() *Service {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *ServiceRevision) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListServiceRevisionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServiceId     string                 `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListServiceRevisionsRequest) Reset() {
+	*x = ListServiceRevisionsRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListServiceRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListServiceRevisionsRequest) ProtoMessage() {}
+
+func (x *ListServiceRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListServiceRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*ListServiceRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListServiceRevisionsRequest) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+type ListServiceRevisionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revisions     []*ServiceRevision     `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"` // Oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListServiceRevisionsResponse) Reset() {
+	*x = ListServiceRevisionsResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListServiceRevisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListServiceRevisionsResponse) ProtoMessage() {}
+
+func (x *ListServiceRevisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListServiceRevisionsResponse.ProtoReflect.Descriptor instead.
+func (*ListServiceRevisionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ListServiceRevisionsResponse) GetRevisions() []*ServiceRevision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+type DeleteServiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteServiceRequest) Reset() {
+	*x = DeleteServiceRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteServiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteServiceRequest) ProtoMessage() {}
+
+func (x *DeleteServiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteServiceRequest.ProtoReflect.Descriptor instead.
+func (*DeleteServiceRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *DeleteServiceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteServiceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteServiceResponse) Reset() {
+	*x = DeleteServiceResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteServiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteServiceResponse) ProtoMessage() {}
+
+func (x *DeleteServiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteServiceResponse.ProtoReflect.Descriptor instead.
+func (*DeleteServiceResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *DeleteServiceResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type GetServiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"` // can query by name or id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServiceRequest) Reset() {
+	*x = GetServiceRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServiceRequest) ProtoMessage() {}
+
+func (x *GetServiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServiceRequest.ProtoReflect.Descriptor instead.
+func (*GetServiceRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetServiceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetServiceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetServiceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Service       *Service               `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServiceResponse) Reset() {
+	*x = GetServiceResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServiceResponse) ProtoMessage() {}
+
+func (x *GetServiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServiceResponse.ProtoReflect.Descriptor instead.
+func (*GetServiceResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetServiceResponse) GetService() *Service {
+	if x != nil {
+		return x.Service
+	}
+	return nil
+}
+
+type ListServicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListServicesRequest) Reset() {
+	*x = ListServicesRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListServicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListServicesRequest) ProtoMessage() {}
+
+func (x *ListServicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListServicesRequest.ProtoReflect.Descriptor instead.
+func (*ListServicesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{52}
+}
+
+type ListServicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Services      []*Service             `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListServicesResponse) Reset() {
+	*x = ListServicesResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListServicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListServicesResponse) ProtoMessage() {}
+
+func (x *ListServicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListServicesResponse.ProtoReflect.Descriptor instead.
+func (*ListServicesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ListServicesResponse) GetServices() []*Service {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+// Service endpoints are the healthy replicas behind each service VIP;
+// workers program their VIP load balancing rules from them
+type ListServiceEndpointsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServiceNames  []string               `protobuf:"bytes,1,rep,name=service_names,json=serviceNames,proto3" json:"service_names,omitempty"` // Only these services (empty = all services)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListServiceEndpointsRequest) Reset() {
+	*x = ListServiceEndpointsRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListServiceEndpointsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListServiceEndpointsRequest) ProtoMessage() {}
+
+func (x *ListServiceEndpointsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListServiceEndpointsRequest.ProtoReflect.Descriptor instead.
+func (*ListServiceEndpointsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ListServiceEndpointsRequest) GetServiceNames() []string {
+	if x != nil {
+		return x.ServiceNames
+	}
+	return nil
+}
+
+type ListServiceEndpointsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Services      []*ServiceEndpoints    `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
+	ServiceSubnet string                 `protobuf:"bytes,2,opt,name=service_subnet,json=serviceSubnet,proto3" json:"service_subnet,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListServiceEndpointsResponse) Reset() {
+	*x = ListServiceEndpointsResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListServiceEndpointsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListServiceEndpointsResponse) ProtoMessage() {}
+
+func (x *ListServiceEndpointsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListServiceEndpointsResponse.ProtoReflect.Descriptor instead.
+func (*ListServiceEndpointsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ListServiceEndpointsResponse) GetServices() []*ServiceEndpoints {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+func (x *ListServiceEndpointsResponse) GetServiceSubnet() string {
+	if x != nil {
+		return x.ServiceSubnet
+	}
+	return ""
+}
+
+type GetPlacementDecisionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServiceId     string                 `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlacementDecisionsRequest) Reset() {
+	*x = GetPlacementDecisionsRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlacementDecisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlacementDecisionsRequest) ProtoMessage() {}
+
+func (x *GetPlacementDecisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlacementDecisionsRequest.ProtoReflect.Descriptor instead.
+func (*GetPlacementDecisionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetPlacementDecisionsRequest) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+type GetPlacementDecisionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Decisions     []*PlacementDecision   `protobuf:"bytes,1,rep,name=decisions,proto3" json:"decisions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlacementDecisionsResponse) Reset() {
+	*x = GetPlacementDecisionsResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlacementDecisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlacementDecisionsResponse) ProtoMessage() {}
+
+func (x *GetPlacementDecisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlacementDecisionsResponse.ProtoReflect.Descriptor instead.
+func (*GetPlacementDecisionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *GetPlacementDecisionsResponse) GetDecisions() []*PlacementDecision {
+	if x != nil {
+		return x.Decisions
+	}
+	return nil
+}
+
+// RebalanceService moves a service's replicas off its most loaded nodes,
+// a batch at a time, until no move narrows the skew
+type RebalanceServiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServiceId     string                 `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RebalanceServiceRequest) Reset() {
+	*x = RebalanceServiceRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebalanceServiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebalanceServiceRequest) ProtoMessage() {}
+
+func (x *RebalanceServiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebalanceServiceRequest.ProtoReflect.Descriptor instead.
+func (*RebalanceServiceRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *RebalanceServiceRequest) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+type RebalanceServiceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Skew          int32                  `protobuf:"varint,1,opt,name=skew,proto3" json:"skew,omitempty"`   // Most minus fewest replicas on the nodes the service may use
+	Moves         int32                  `protobuf:"varint,2,opt,name=moves,proto3" json:"moves,omitempty"` // Replicas that will be moved
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RebalanceServiceResponse) Reset() {
+	*x = RebalanceServiceResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebalanceServiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebalanceServiceResponse) ProtoMessage() {}
+
+func (x *RebalanceServiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebalanceServiceResponse.ProtoReflect.Descriptor instead.
+func (*RebalanceServiceResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *RebalanceServiceResponse) GetSkew() int32 {
+	if x != nil {
+		return x.Skew
+	}
+	return 0
+}
+
+func (x *RebalanceServiceResponse) GetMoves() int32 {
+	if x != nil {
+		return x.Moves
+	}
+	return 0
+}
+
+// Deployment is an update of a service to a new image, carried out step by
+// step by the leader
+type Deployment struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ServiceId      string                 `protobuf:"bytes,2,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	ServiceName    string                 `protobuf:"bytes,3,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Strategy       string                 `protobuf:"bytes,4,opt,name=strategy,proto3" json:"strategy,omitempty"` // "rolling", "blue-green" or "canary"
+	Image          string                 `protobuf:"bytes,5,opt,name=image,proto3" json:"image,omitempty"`
+	PreviousImage  string                 `protobuf:"bytes,6,opt,name=previous_image,json=previousImage,proto3" json:"previous_image,omitempty"`
+	Phase          string                 `protobuf:"bytes,7,opt,name=phase,proto3" json:"phase,omitempty"`                           // "running", "paused", "completed", "failed" or "aborted"
+	Step           string                 `protobuf:"bytes,8,opt,name=step,proto3" json:"step,omitempty"`                             // Current step, e.g. "replace-batch" or "stabilize"
+	StepIndex      int32                  `protobuf:"varint,9,opt,name=step_index,json=stepIndex,proto3" json:"step_index,omitempty"` // Rolling batch or canary step the step belongs to
+	StepStartedAt  *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=step_started_at,json=stepStartedAt,proto3" json:"step_started_at,omitempty"`
+	CloneServiceId string                 `protobuf:"bytes,11,opt,name=clone_service_id,json=cloneServiceId,proto3" json:"clone_service_id,omitempty"` // Service running the new version (blue-green and canary)
+	Message        string                 `protobuf:"bytes,12,opt,name=message,proto3" json:"message,omitempty"`                                       // Why the deployment failed, was aborted or paused
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CompletedAt    *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	Failures       int32                  `protobuf:"varint,16,opt,name=failures,proto3" json:"failures,omitempty"` // Rolling: new containers seen failing
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Deployment) Reset() {
+	*x = Deployment{}
+	mi := &file_api_proto_warren_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Deployment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Deployment) ProtoMessage() {}
+
+func (x *Deployment) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Deployment.ProtoReflect.Descriptor instead.
+func (*Deployment) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *Deployment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Deployment) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *Deployment) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *Deployment) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *Deployment) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *Deployment) GetPreviousImage() string {
+	if x != nil {
+		return x.PreviousImage
+	}
+	return ""
+}
+
+func (x *Deployment) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *Deployment) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+func (x *Deployment) GetStepIndex() int32 {
+	if x != nil {
+		return x.StepIndex
+	}
+	return 0
+}
+
+func (x *Deployment) GetStepStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StepStartedAt
+	}
+	return nil
+}
+
+func (x *Deployment) GetCloneServiceId() string {
+	if x != nil {
+		return x.CloneServiceId
+	}
+	return ""
+}
+
+func (x *Deployment) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Deployment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Deployment) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Deployment) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *Deployment) GetFailures() int32 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+type ListDeploymentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServiceId     string                 `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"` // Only deployments of this service, if set
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeploymentsRequest) Reset() {
+	*x = ListDeploymentsRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeploymentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeploymentsRequest) ProtoMessage() {}
+
+func (x *ListDeploymentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeploymentsRequest.ProtoReflect.Descriptor instead.
+func (*ListDeploymentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ListDeploymentsRequest) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+type ListDeploymentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deployments   []*Deployment          `protobuf:"bytes,1,rep,name=deployments,proto3" json:"deployments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeploymentsResponse) Reset() {
+	*x = ListDeploymentsResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeploymentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeploymentsResponse) ProtoMessage() {}
+
+func (x *ListDeploymentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeploymentsResponse.ProtoReflect.Descriptor instead.
+func (*ListDeploymentsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ListDeploymentsResponse) GetDeployments() []*Deployment {
+	if x != nil {
+		return x.Deployments
+	}
+	return nil
+}
+
+type GetDeploymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeploymentRequest) Reset() {
+	*x = GetDeploymentRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeploymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeploymentRequest) ProtoMessage() {}
+
+func (x *GetDeploymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeploymentRequest.ProtoReflect.Descriptor instead.
+func (*GetDeploymentRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *GetDeploymentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetDeploymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deployment    *Deployment            `protobuf:"bytes,1,opt,name=deployment,proto3" json:"deployment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeploymentResponse) Reset() {
+	*x = GetDeploymentResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeploymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeploymentResponse) ProtoMessage() {}
+
+func (x *GetDeploymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeploymentResponse.ProtoReflect.Descriptor instead.
+func (*GetDeploymentResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *GetDeploymentResponse) GetDeployment() *Deployment {
+	if x != nil {
+		return x.Deployment
+	}
+	return nil
+}
+
+type PauseDeploymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseDeploymentRequest) Reset() {
+	*x = PauseDeploymentRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseDeploymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseDeploymentRequest) ProtoMessage() {}
+
+func (x *PauseDeploymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseDeploymentRequest.ProtoReflect.Descriptor instead.
+func (*PauseDeploymentRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *PauseDeploymentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type PauseDeploymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deployment    *Deployment            `protobuf:"bytes,1,opt,name=deployment,proto3" json:"deployment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseDeploymentResponse) Reset() {
+	*x = PauseDeploymentResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseDeploymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseDeploymentResponse) ProtoMessage() {}
+
+func (x *PauseDeploymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseDeploymentResponse.ProtoReflect.Descriptor instead.
+func (*PauseDeploymentResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *PauseDeploymentResponse) GetDeployment() *Deployment {
+	if x != nil {
+		return x.Deployment
+	}
+	return nil
+}
+
+type ResumeDeploymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeDeploymentRequest) Reset() {
+	*x = ResumeDeploymentRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeDeploymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeDeploymentRequest) ProtoMessage() {}
+
+func (x *ResumeDeploymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeDeploymentRequest.ProtoReflect.Descriptor instead.
+func (*ResumeDeploymentRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ResumeDeploymentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ResumeDeploymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deployment    *Deployment            `protobuf:"bytes,1,opt,name=deployment,proto3" json:"deployment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeDeploymentResponse) Reset() {
+	*x = ResumeDeploymentResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeDeploymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeDeploymentResponse) ProtoMessage() {}
+
+func (x *ResumeDeploymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeDeploymentResponse.ProtoReflect.Descriptor instead.
+func (*ResumeDeploymentResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ResumeDeploymentResponse) GetDeployment() *Deployment {
+	if x != nil {
+		return x.Deployment
+	}
+	return nil
+}
+
+type AbortDeploymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AbortDeploymentRequest) Reset() {
+	*x = AbortDeploymentRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbortDeploymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortDeploymentRequest) ProtoMessage() {}
+
+func (x *AbortDeploymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortDeploymentRequest.ProtoReflect.Descriptor instead.
+func (*AbortDeploymentRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *AbortDeploymentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type AbortDeploymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deployment    *Deployment            `protobuf:"bytes,1,opt,name=deployment,proto3" json:"deployment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AbortDeploymentResponse) Reset() {
+	*x = AbortDeploymentResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbortDeploymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortDeploymentResponse) ProtoMessage() {}
+
+func (x *AbortDeploymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortDeploymentResponse.ProtoReflect.Descriptor instead.
+func (*AbortDeploymentResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *AbortDeploymentResponse) GetDeployment() *Deployment {
+	if x != nil {
+		return x.Deployment
+	}
+	return nil
+}
+
+// PlacementDecision explains where the scheduler placed a container, or why it could not
+type PlacementDecision struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ContainerId   string                 `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	NodeId        string                 `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"` // Empty if no node fits
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`               // Why no node fits
+	Time          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	Nodes         []*NodeEvaluation      `protobuf:"bytes,5,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlacementDecision) Reset() {
+	*x = PlacementDecision{}
+	mi := &file_api_proto_warren_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlacementDecision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlacementDecision) ProtoMessage() {}
+
+func (x *PlacementDecision) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlacementDecision.ProtoReflect.Descriptor instead.
+func (*PlacementDecision) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *PlacementDecision) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+func (x *PlacementDecision) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *PlacementDecision) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *PlacementDecision) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *PlacementDecision) GetNodes() []*NodeEvaluation {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+// NodeEvaluation is the outcome of running the scheduler plugins against one node
+type NodeEvaluation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NodeId        string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	FilteredBy    string                 `protobuf:"bytes,2,opt,name=filtered_by,json=filteredBy,proto3" json:"filtered_by,omitempty"` // Filter plugin that ruled the node out
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Scores        map[string]float64     `protobuf:"bytes,4,rep,name=scores,proto3" json:"scores,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"` // Normalized score per score plugin
+	Score         float64                `protobuf:"fixed64,5,opt,name=score,proto3" json:"score,omitempty"`                                                                             // Weighted sum of the scores
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NodeEvaluation) Reset() {
+	*x = NodeEvaluation{}
+	mi := &file_api_proto_warren_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeEvaluation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeEvaluation) ProtoMessage() {}
+
+func (x *NodeEvaluation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeEvaluation.ProtoReflect.Descriptor instead.
+func (*NodeEvaluation) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *NodeEvaluation) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *NodeEvaluation) GetFilteredBy() string {
+	if x != nil {
+		return x.FilteredBy
+	}
+	return ""
+}
+
+func (x *NodeEvaluation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *NodeEvaluation) GetScores() map[string]float64 {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+func (x *NodeEvaluation) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type ServiceEndpoints struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	ServiceId     string                  `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	ServiceName   string                  `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Vip           string                  `protobuf:"bytes,3,opt,name=vip,proto3" json:"vip,omitempty"`
+	Ports         []*ServicePortEndpoints `protobuf:"bytes,4,rep,name=ports,proto3" json:"ports,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceEndpoints) Reset() {
+	*x = ServiceEndpoints{}
+	mi := &file_api_proto_warren_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceEndpoints) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceEndpoints) ProtoMessage() {}
+
+func (x *ServiceEndpoints) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceEndpoints.ProtoReflect.Descriptor instead.
+func (*ServiceEndpoints) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ServiceEndpoints) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *ServiceEndpoints) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ServiceEndpoints) GetVip() string {
+	if x != nil {
+		return x.Vip
+	}
+	return ""
+}
+
+func (x *ServiceEndpoints) GetPorts() []*ServicePortEndpoints {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+type ServicePortEndpoints struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Protocol      string                 `protobuf:"bytes,1,opt,name=protocol,proto3" json:"protocol,omitempty"` // "tcp" or "udp"
+	Port          int32                  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`        // Port on the VIP (the container port)
+	Endpoints     []*ServiceEndpoint     `protobuf:"bytes,3,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	PublishedPort int32                  `protobuf:"varint,4,opt,name=published_port,json=publishedPort,proto3" json:"published_port,omitempty"` // Port published on every node (ingress mode); 0 if not published
+	ForwardPort   int32                  `protobuf:"varint,5,opt,name=forward_port,json=forwardPort,proto3" json:"forward_port,omitempty"`       // Port on each node's overlay IP reaching that node's replicas; 0 if none
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServicePortEndpoints) Reset() {
+	*x = ServicePortEndpoints{}
+	mi := &file_api_proto_warren_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServicePortEndpoints) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServicePortEndpoints) ProtoMessage() {}
+
+func (x *ServicePortEndpoints) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServicePortEndpoints.ProtoReflect.Descriptor instead.
+func (*ServicePortEndpoints) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ServicePortEndpoints) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *ServicePortEndpoints) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *ServicePortEndpoints) GetEndpoints() []*ServiceEndpoint {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+func (x *ServicePortEndpoints) GetPublishedPort() int32 {
+	if x != nil {
+		return x.PublishedPort
+	}
+	return 0
+}
+
+func (x *ServicePortEndpoints) GetForwardPort() int32 {
+	if x != nil {
+		return x.ForwardPort
+	}
+	return 0
+}
+
+type ServiceEndpoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NodeId        string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	ContainerId   string                 `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	Address       string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"` // host:port reachable from other nodes (host port, or forward port on the overlay IP)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceEndpoint) Reset() {
+	*x = ServiceEndpoint{}
+	mi := &file_api_proto_warren_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceEndpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceEndpoint) ProtoMessage() {}
+
+func (x *ServiceEndpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceEndpoint.ProtoReflect.Descriptor instead.
+func (*ServiceEndpoint) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *ServiceEndpoint) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *ServiceEndpoint) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+func (x *ServiceEndpoint) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+// Container messages
+type Container struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ServiceId          string                 `protobuf:"bytes,2,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	ServiceName        string                 `protobuf:"bytes,3,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	NodeId             string                 `protobuf:"bytes,4,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	RuntimeContainerId string                 `protobuf:"bytes,5,opt,name=runtime_container_id,json=runtimeContainerId,proto3" json:"runtime_container_id,omitempty"`
+	DesiredState       string                 `protobuf:"bytes,6,opt,name=desired_state,json=desiredState,proto3" json:"desired_state,omitempty"` // "running", "shutdown"
+	ActualState        string                 `protobuf:"bytes,7,opt,name=actual_state,json=actualState,proto3" json:"actual_state,omitempty"`    // "pending", "running", "failed", "stopped"
+	Image              string                 `protobuf:"bytes,8,opt,name=image,proto3" json:"image,omitempty"`
+	Command            []string               `protobuf:"bytes,9,rep,name=command,proto3" json:"command,omitempty"`
+	Env                map[string]string      `protobuf:"bytes,10,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Resources          *ResourceRequirements  `protobuf:"bytes,11,opt,name=resources,proto3" json:"resources,omitempty"`
+	Volumes            []*VolumeMount         `protobuf:"bytes,12,rep,name=volumes,proto3" json:"volumes,omitempty"`
+	HealthCheck        *HealthCheck           `protobuf:"bytes,13,opt,name=health_check,json=healthCheck,proto3" json:"health_check,omitempty"`
+	RestartPolicy      *RestartPolicy         `protobuf:"bytes,14,opt,name=restart_policy,json=restartPolicy,proto3" json:"restart_policy,omitempty"`
+	CreatedAt          *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt          *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Error              string                 `protobuf:"bytes,17,opt,name=error,proto3" json:"error,omitempty"`
+	Secrets            []string               `protobuf:"bytes,18,rep,name=secrets,proto3" json:"secrets,omitempty"`                             // Secret names to mount
+	StopTimeout        int32                  `protobuf:"varint,19,opt,name=stop_timeout,json=stopTimeout,proto3" json:"stop_timeout,omitempty"` // Seconds to wait before force-killing (default: 10)
+	Ports              []*PortMapping         `protobuf:"bytes,20,rep,name=ports,proto3" json:"ports,omitempty"`                                 // Published ports
+	PreemptedBy        string                 `protobuf:"bytes,21,opt,name=preempted_by,json=preemptedBy,proto3" json:"preempted_by,omitempty"`  // Service whose higher-priority container evicted this one
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Container) Reset() {
+	*x = Container{}
+	mi := &file_api_proto_warren_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Container) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Container) ProtoMessage() {}
+
+func (x *Container) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Container.ProtoReflect.Descriptor instead.
+func (*Container) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *Container) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Container) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *Container) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *Container) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *Container) GetRuntimeContainerId() string {
+	if x != nil {
+		return x.RuntimeContainerId
+	}
+	return ""
+}
+
+func (x *Container) GetDesiredState() string {
+	if x != nil {
+		return x.DesiredState
+	}
+	return ""
+}
+
+func (x *Container) GetActualState() string {
+	if x != nil {
+		return x.ActualState
+	}
+	return ""
+}
+
+func (x *Container) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *Container) GetCommand() []string {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *Container) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *Container) GetResources() *ResourceRequirements {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *Container) GetVolumes() []*VolumeMount {
+	if x != nil {
+		return x.Volumes
+	}
+	return nil
+}
+
+func (x *Container) GetHealthCheck() *HealthCheck {
+	if x != nil {
+		return x.HealthCheck
+	}
+	return nil
+}
+
+func (x *Container) GetRestartPolicy() *RestartPolicy {
+	if x != nil {
+		return x.RestartPolicy
+	}
+	return nil
+}
+
+func (x *Container) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Container) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Container) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Container) GetSecrets() []string {
+	if x != nil {
+		return x.Secrets
+	}
+	return nil
+}
+
+func (x *Container) GetStopTimeout() int32 {
+	if x != nil {
+		return x.StopTimeout
+	}
+	return 0
+}
+
+func (x *Container) GetPorts() []*PortMapping {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+func (x *Container) GetPreemptedBy() string {
+	if x != nil {
+		return x.PreemptedBy
+	}
+	return ""
+}
+
+type UpdateContainerStatusRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	ContainerId        string                 `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	NodeId             string                 `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	ActualState        string                 `protobuf:"bytes,3,opt,name=actual_state,json=actualState,proto3" json:"actual_state,omitempty"`
+	RuntimeContainerId string                 `protobuf:"bytes,4,opt,name=runtime_container_id,json=runtimeContainerId,proto3" json:"runtime_container_id,omitempty"`
+	Error              string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *UpdateContainerStatusRequest) Reset() {
+	*x = UpdateContainerStatusRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateContainerStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateContainerStatusRequest) ProtoMessage() {}
+
+func (x *UpdateContainerStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateContainerStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateContainerStatusRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *UpdateContainerStatusRequest) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+func (x *UpdateContainerStatusRequest) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *UpdateContainerStatusRequest) GetActualState() string {
+	if x != nil {
+		return x.ActualState
+	}
+	return ""
+}
+
+func (x *UpdateContainerStatusRequest) GetRuntimeContainerId() string {
+	if x != nil {
+		return x.RuntimeContainerId
+	}
+	return ""
+}
+
+func (x *UpdateContainerStatusRequest) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type UpdateContainerStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateContainerStatusResponse) Reset() {
+	*x = UpdateContainerStatusResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateContainerStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateContainerStatusResponse) ProtoMessage() {}
+
+func (x *UpdateContainerStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateContainerStatusResponse.ProtoReflect.Descriptor instead.
+func (*UpdateContainerStatusResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *UpdateContainerStatusResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ListContainersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServiceId     string                 `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"` // optional filter
+	NodeId        string                 `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`          // optional filter
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListContainersRequest) Reset() {
+	*x = ListContainersRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListContainersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListContainersRequest) ProtoMessage() {}
+
+func (x *ListContainersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListContainersRequest.ProtoReflect.Descriptor instead.
+func (*ListContainersRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *ListContainersRequest) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *ListContainersRequest) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+type ListContainersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Containers    []*Container           `protobuf:"bytes,1,rep,name=containers,proto3" json:"containers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListContainersResponse) Reset() {
+	*x = ListContainersResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListContainersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListContainersResponse) ProtoMessage() {}
+
+func (x *ListContainersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListContainersResponse.ProtoReflect.Descriptor instead.
+func (*ListContainersResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *ListContainersResponse) GetContainers() []*Container {
+	if x != nil {
+		return x.Containers
+	}
+	return nil
+}
+
+type GetContainerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetContainerRequest) Reset() {
+	*x = GetContainerRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetContainerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContainerRequest) ProtoMessage() {}
+
+func (x *GetContainerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetContainerRequest.ProtoReflect.Descriptor instead.
+func (*GetContainerRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *GetContainerRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetContainerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Container     *Container             `protobuf:"bytes,1,opt,name=container,proto3" json:"container,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetContainerResponse) Reset() {
+	*x = GetContainerResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetContainerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContainerResponse) ProtoMessage() {}
+
+func (x *GetContainerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetContainerResponse.ProtoReflect.Descriptor instead.
+func (*GetContainerResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *GetContainerResponse) GetContainer() *Container {
+	if x != nil {
+		return x.Container
+	}
+	return nil
+}
+
+type WatchContainersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NodeId        string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchContainersRequest) Reset() {
+	*x = WatchContainersRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchContainersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchContainersRequest) ProtoMessage() {}
+
+func (x *WatchContainersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchContainersRequest.ProtoReflect.Descriptor instead.
+func (*WatchContainersRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *WatchContainersRequest) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+type ContainerEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // "add", "update", "delete"
+	Container     *Container             `protobuf:"bytes,2,opt,name=container,proto3" json:"container,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContainerEvent) Reset() {
+	*x = ContainerEvent{}
+	mi := &file_api_proto_warren_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContainerEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerEvent) ProtoMessage() {}
+
+func (x *ContainerEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerEvent.ProtoReflect.Descriptor instead.
+func (*ContainerEvent) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *ContainerEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ContainerEvent) GetContainer() *Container {
+	if x != nil {
+		return x.Container
+	}
+	return nil
+}
+
+// Secret messages
+type Secret struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Data          []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"` // Encrypted data (only included in worker retrieval)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Secret) Reset() {
+	*x = Secret{}
+	mi := &file_api_proto_warren_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Secret) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Secret) ProtoMessage() {}
+
+func (x *Secret) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Secret.ProtoReflect.Descriptor instead.
+func (*Secret) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *Secret) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Secret) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Secret) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Secret) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type CreateSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSecretRequest) Reset() {
+	*x = CreateSecretRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSecretRequest) ProtoMessage() {}
+
+func (x *CreateSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSecretRequest.ProtoReflect.Descriptor instead.
+func (*CreateSecretRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *CreateSecretRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateSecretRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type CreateSecretResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secret        *Secret                `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSecretResponse) Reset() {
+	*x = CreateSecretResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSecretResponse) ProtoMessage() {}
+
+func (x *CreateSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSecretResponse.ProtoReflect.Descriptor instead.
+func (*CreateSecretResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *CreateSecretResponse) GetSecret() *Secret {
+	if x != nil {
+		return x.Secret
+	}
+	return nil
+}
+
+type DeleteSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateContainerStatusRequest) Reset() {
-	*x = UpdateContainerStatusRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[39]
+func (x *DeleteSecretRequest) Reset() {
+	*x = DeleteSecretRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[88]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateContainerStatusRequest) String() string {
+func (x *DeleteSecretRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateContainerStatusRequest) ProtoMessage() {}
+func (*DeleteSecretRequest) ProtoMessage() {}
 
-func (x *UpdateContainerStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[39]
+func (x *DeleteSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[88]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2747,68 +5903,84 @@ func (x *UpdateContainerStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateContainerStatusRequest.ProtoReflect.Descriptor instead.
-func (*UpdateContainerStatusRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{39}
+// Deprecated: Use DeleteSecretRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSecretRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{88}
 }
 
-func (x *UpdateContainerStatusRequest) GetContainerId() string {
+func (x *DeleteSecretRequest) GetId() string {
 	if x != nil {
-		return x.ContainerId
+		return x.Id
 	}
 	return ""
 }
 
-func (x *UpdateContainerStatusRequest) GetNodeId() string {
-	if x != nil {
-		return x.NodeId
-	}
-	return ""
+type DeleteSecretResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateContainerStatusRequest) GetActualState() string {
-	if x != nil {
-		return x.ActualState
-	}
-	return ""
+func (x *DeleteSecretResponse) Reset() {
+	*x = DeleteSecretResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateContainerStatusRequest) GetRuntimeContainerId() string {
+func (x *DeleteSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSecretResponse) ProtoMessage() {}
+
+func (x *DeleteSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[89]
 	if x != nil {
-		return x.RuntimeContainerId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *UpdateContainerStatusRequest) GetError() string {
+// Deprecated: Use DeleteSecretResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSecretResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *DeleteSecretResponse) GetStatus() string {
 	if x != nil {
-		return x.Error
+		return x.Status
 	}
 	return ""
 }
 
-type UpdateContainerStatusResponse struct {
+type GetSecretByNameRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateContainerStatusResponse) Reset() {
-	*x = UpdateContainerStatusResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[40]
+func (x *GetSecretByNameRequest) Reset() {
+	*x = GetSecretByNameRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateContainerStatusResponse) String() string {
+func (x *GetSecretByNameRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateContainerStatusResponse) ProtoMessage() {}
+func (*GetSecretByNameRequest) ProtoMessage() {}
 
-func (x *UpdateContainerStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[40]
+func (x *GetSecretByNameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2819,41 +5991,40 @@ func (x *UpdateContainerStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateContainerStatusResponse.ProtoReflect.Descriptor instead.
-func (*UpdateContainerStatusResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{40}
+// Deprecated: Use GetSecretByNameRequest.ProtoReflect.Descriptor instead.
+func (*GetSecretByNameRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{90}
 }
 
-func (x *UpdateContainerStatusResponse) GetStatus() string {
+func (x *GetSecretByNameRequest) GetName() string {
 	if x != nil {
-		return x.Status
+		return x.Name
 	}
 	return ""
 }
 
-type ListContainersRequest struct {
+type GetSecretByNameResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ServiceId     string                 `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"` // optional filter
-	NodeId        string                 `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`          // optional filter
+	Secret        *Secret                `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListContainersRequest) Reset() {
-	*x = ListContainersRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[41]
+func (x *GetSecretByNameResponse) Reset() {
+	*x = GetSecretByNameResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListContainersRequest) String() string {
+func (x *GetSecretByNameResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListContainersRequest) ProtoMessage() {}
+func (*GetSecretByNameResponse) ProtoMessage() {}
 
-func (x *ListContainersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[41]
+func (x *GetSecretByNameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2864,47 +6035,39 @@ func (x *ListContainersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListContainersRequest.ProtoReflect.Descriptor instead.
-func (*ListContainersRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{41}
-}
-
-func (x *ListContainersRequest) GetServiceId() string {
-	if x != nil {
-		return x.ServiceId
-	}
-	return ""
+// Deprecated: Use GetSecretByNameResponse.ProtoReflect.Descriptor instead.
+func (*GetSecretByNameResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{91}
 }
 
-func (x *ListContainersRequest) GetNodeId() string {
+func (x *GetSecretByNameResponse) GetSecret() *Secret {
 	if x != nil {
-		return x.NodeId
+		return x.Secret
 	}
-	return ""
+	return nil
 }
 
-type ListContainersResponse struct {
+type ListSecretsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Containers    []*Container           `protobuf:"bytes,1,rep,name=containers,proto3" json:"containers,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListContainersResponse) Reset() {
-	*x = ListContainersResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[42]
+func (x *ListSecretsRequest) Reset() {
+	*x = ListSecretsRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListContainersResponse) String() string {
+func (x *ListSecretsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListContainersResponse) ProtoMessage() {}
+func (*ListSecretsRequest) ProtoMessage() {}
 
-func (x *ListContainersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[42]
+func (x *ListSecretsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2915,40 +6078,33 @@ func (x *ListContainersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListContainersResponse.ProtoReflect.Descriptor instead.
-func (*ListContainersResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{42}
-}
-
-func (x *ListContainersResponse) GetContainers() []*Container {
-	if x != nil {
-		return x.Containers
-	}
-	return nil
+// Deprecated: Use ListSecretsRequest.ProtoReflect.Descriptor instead.
+func (*ListSecretsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{92}
 }
 
-type GetContainerRequest struct {
+type ListSecretsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Secrets       []*Secret              `protobuf:"bytes,1,rep,name=secrets,proto3" json:"secrets,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetContainerRequest) Reset() {
-	*x = GetContainerRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[43]
+func (x *ListSecretsResponse) Reset() {
+	*x = ListSecretsResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[93]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetContainerRequest) String() string {
+func (x *ListSecretsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetContainerRequest) ProtoMessage() {}
+func (*ListSecretsResponse) ProtoMessage() {}
 
-func (x *GetContainerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[43]
+func (x *ListSecretsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[93]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2959,40 +6115,48 @@ func (x *GetContainerRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetContainerRequest.ProtoReflect.Descriptor instead.
-func (*GetContainerRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{43}
+// Deprecated: Use ListSecretsResponse.ProtoReflect.Descriptor instead.
+func (*ListSecretsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{93}
 }
 
-func (x *GetContainerRequest) GetId() string {
+func (x *ListSecretsResponse) GetSecrets() []*Secret {
 	if x != nil {
-		return x.Id
+		return x.Secrets
 	}
-	return ""
+	return nil
 }
 
-type GetContainerResponse struct {
+// Volume messages
+type Volume struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Container     *Container             `protobuf:"bytes,1,opt,name=container,proto3" json:"container,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Driver        string                 `protobuf:"bytes,3,opt,name=driver,proto3" json:"driver,omitempty"`
+	DriverOpts    map[string]string      `protobuf:"bytes,4,rep,name=driver_opts,json=driverOpts,proto3" json:"driver_opts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Labels        map[string]string      `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	NodeId        string                 `protobuf:"bytes,7,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`          // Node affinity for local volumes
+	MountPath     string                 `protobuf:"bytes,8,opt,name=mount_path,json=mountPath,proto3" json:"mount_path,omitempty"` // Host mount path
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetContainerResponse) Reset() {
-	*x = GetContainerResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[44]
+func (x *Volume) Reset() {
+	*x = Volume{}
+	mi := &file_api_proto_warren_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetContainerResponse) String() string {
+func (x *Volume) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetContainerResponse) ProtoMessage() {}
+func (*Volume) ProtoMessage() {}
 
-func (x *GetContainerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[44]
+func (x *Volume) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3003,85 +6167,92 @@ func (x *GetContainerResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetContainerResponse.ProtoReflect.Descriptor instead.
-func (*GetContainerResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{44}
+// Deprecated: Use Volume.ProtoReflect.Descriptor instead.
+func (*Volume) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{94}
 }
 
-func (x *GetContainerResponse) GetContainer() *Container {
+func (x *Volume) GetId() string {
 	if x != nil {
-		return x.Container
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-type WatchContainersRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	NodeId        string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Volume) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
-func (x *WatchContainersRequest) Reset() {
-	*x = WatchContainersRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[45]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Volume) GetDriver() string {
+	if x != nil {
+		return x.Driver
+	}
+	return ""
 }
 
-func (x *WatchContainersRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Volume) GetDriverOpts() map[string]string {
+	if x != nil {
+		return x.DriverOpts
+	}
+	return nil
 }
 
-func (*WatchContainersRequest) ProtoMessage() {}
-
-func (x *WatchContainersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[45]
+func (x *Volume) GetLabels() map[string]string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Labels
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use WatchContainersRequest.ProtoReflect.Descriptor instead.
-func (*WatchContainersRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{45}
+func (x *Volume) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
 }
 
-func (x *WatchContainersRequest) GetNodeId() string {
+func (x *Volume) GetNodeId() string {
 	if x != nil {
 		return x.NodeId
 	}
 	return ""
 }
 
-type ContainerEvent struct {
+func (x *Volume) GetMountPath() string {
+	if x != nil {
+		return x.MountPath
+	}
+	return ""
+}
+
+type CreateVolumeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // "add", "update", "delete"
-	Container     *Container             `protobuf:"bytes,2,opt,name=container,proto3" json:"container,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Driver        string                 `protobuf:"bytes,2,opt,name=driver,proto3" json:"driver,omitempty"`
+	DriverOpts    map[string]string      `protobuf:"bytes,3,rep,name=driver_opts,json=driverOpts,proto3" json:"driver_opts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Labels        map[string]string      `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ContainerEvent) Reset() {
-	*x = ContainerEvent{}
-	mi := &file_api_proto_warren_proto_msgTypes[46]
+func (x *CreateVolumeRequest) Reset() {
+	*x = CreateVolumeRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ContainerEvent) String() string {
+func (x *CreateVolumeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ContainerEvent) ProtoMessage() {}
+func (*CreateVolumeRequest) ProtoMessage() {}
 
-func (x *ContainerEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[46]
+func (x *CreateVolumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3092,51 +6263,61 @@ func (x *ContainerEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ContainerEvent.ProtoReflect.Descriptor instead.
-func (*ContainerEvent) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{46}
+// Deprecated: Use CreateVolumeRequest.ProtoReflect.Descriptor instead.
+func (*CreateVolumeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{95}
 }
 
-func (x *ContainerEvent) GetType() string {
+func (x *CreateVolumeRequest) GetName() string {
 	if x != nil {
-		return x.Type
+		return x.Name
 	}
 	return ""
 }
 
-func (x *ContainerEvent) GetContainer() *Container {
+func (x *CreateVolumeRequest) GetDriver() string {
 	if x != nil {
-		return x.Container
+		return x.Driver
+	}
+	return ""
+}
+
+func (x *CreateVolumeRequest) GetDriverOpts() map[string]string {
+	if x != nil {
+		return x.DriverOpts
 	}
 	return nil
 }
 
-// Secret messages
-type Secret struct {
+func (x *CreateVolumeRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type CreateVolumeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	Data          []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"` // Encrypted data (only included in worker retrieval)
+	Volume        *Volume                `protobuf:"bytes,1,opt,name=volume,proto3" json:"volume,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Secret) Reset() {
-	*x = Secret{}
-	mi := &file_api_proto_warren_proto_msgTypes[47]
+func (x *CreateVolumeResponse) Reset() {
+	*x = CreateVolumeResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[96]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Secret) String() string {
+func (x *CreateVolumeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Secret) ProtoMessage() {}
+func (*CreateVolumeResponse) ProtoMessage() {}
 
-func (x *Secret) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[47]
+func (x *CreateVolumeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[96]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3147,62 +6328,40 @@ func (x *Secret) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Secret.ProtoReflect.Descriptor instead.
-func (*Secret) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{47}
-}
-
-func (x *Secret) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *Secret) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *Secret) GetCreatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return nil
+// Deprecated: Use CreateVolumeResponse.ProtoReflect.Descriptor instead.
+func (*CreateVolumeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{96}
 }
 
-func (x *Secret) GetData() []byte {
+func (x *CreateVolumeResponse) GetVolume() *Volume {
 	if x != nil {
-		return x.Data
+		return x.Volume
 	}
 	return nil
 }
 
-type CreateSecretRequest struct {
+type DeleteVolumeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateSecretRequest) Reset() {
-	*x = CreateSecretRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[48]
+func (x *DeleteVolumeRequest) Reset() {
+	*x = DeleteVolumeRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[97]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateSecretRequest) String() string {
+func (x *DeleteVolumeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateSecretRequest) ProtoMessage() {}
+func (*DeleteVolumeRequest) ProtoMessage() {}
 
-func (x *CreateSecretRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[48]
+func (x *DeleteVolumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[97]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3213,47 +6372,40 @@ func (x *CreateSecretRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateSecretRequest.ProtoReflect.Descriptor instead.
-func (*CreateSecretRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{48}
+// Deprecated: Use DeleteVolumeRequest.ProtoReflect.Descriptor instead.
+func (*DeleteVolumeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{97}
 }
 
-func (x *CreateSecretRequest) GetName() string {
+func (x *DeleteVolumeRequest) GetId() string {
 	if x != nil {
-		return x.Name
+		return x.Id
 	}
 	return ""
 }
 
-func (x *CreateSecretRequest) GetData() []byte {
-	if x != nil {
-		return x.Data
-	}
-	return nil
-}
-
-type CreateSecretResponse struct {
+type DeleteVolumeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Secret        *Secret                `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateSecretResponse) Reset() {
-	*x = CreateSecretResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[49]
+func (x *DeleteVolumeResponse) Reset() {
+	*x = DeleteVolumeResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[98]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateSecretResponse) String() string {
+func (x *DeleteVolumeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateSecretResponse) ProtoMessage() {}
+func (*DeleteVolumeResponse) ProtoMessage() {}
 
-func (x *CreateSecretResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[49]
+func (x *DeleteVolumeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[98]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3264,40 +6416,40 @@ func (x *CreateSecretResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateSecretResponse.ProtoReflect.Descriptor instead.
-func (*CreateSecretResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{49}
+// Deprecated: Use DeleteVolumeResponse.ProtoReflect.Descriptor instead.
+func (*DeleteVolumeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{98}
 }
 
-func (x *CreateSecretResponse) GetSecret() *Secret {
+func (x *DeleteVolumeResponse) GetStatus() string {
 	if x != nil {
-		return x.Secret
+		return x.Status
 	}
-	return nil
+	return ""
 }
 
-type DeleteSecretRequest struct {
+type GetVolumeByNameRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteSecretRequest) Reset() {
-	*x = DeleteSecretRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[50]
+func (x *GetVolumeByNameRequest) Reset() {
+	*x = GetVolumeByNameRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[99]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteSecretRequest) String() string {
+func (x *GetVolumeByNameRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteSecretRequest) ProtoMessage() {}
+func (*GetVolumeByNameRequest) ProtoMessage() {}
 
-func (x *DeleteSecretRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[50]
+func (x *GetVolumeByNameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[99]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3308,40 +6460,40 @@ func (x *DeleteSecretRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteSecretRequest.ProtoReflect.Descriptor instead.
-func (*DeleteSecretRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{50}
+// Deprecated: Use GetVolumeByNameRequest.ProtoReflect.Descriptor instead.
+func (*GetVolumeByNameRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{99}
 }
 
-func (x *DeleteSecretRequest) GetId() string {
+func (x *GetVolumeByNameRequest) GetName() string {
 	if x != nil {
-		return x.Id
+		return x.Name
 	}
 	return ""
 }
 
-type DeleteSecretResponse struct {
+type GetVolumeByNameResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Volume        *Volume                `protobuf:"bytes,1,opt,name=volume,proto3" json:"volume,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteSecretResponse) Reset() {
-	*x = DeleteSecretResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[51]
+func (x *GetVolumeByNameResponse) Reset() {
+	*x = GetVolumeByNameResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[100]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteSecretResponse) String() string {
+func (x *GetVolumeByNameResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteSecretResponse) ProtoMessage() {}
+func (*GetVolumeByNameResponse) ProtoMessage() {}
 
-func (x *DeleteSecretResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[51]
+func (x *GetVolumeByNameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[100]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3352,40 +6504,39 @@ func (x *DeleteSecretResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteSecretResponse.ProtoReflect.Descriptor instead.
-func (*DeleteSecretResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{51}
+// Deprecated: Use GetVolumeByNameResponse.ProtoReflect.Descriptor instead.
+func (*GetVolumeByNameResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{100}
 }
 
-func (x *DeleteSecretResponse) GetStatus() string {
+func (x *GetVolumeByNameResponse) GetVolume() *Volume {
 	if x != nil {
-		return x.Status
+		return x.Volume
 	}
-	return ""
+	return nil
 }
 
-type GetSecretByNameRequest struct {
+type ListVolumesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetSecretByNameRequest) Reset() {
-	*x = GetSecretByNameRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[52]
+func (x *ListVolumesRequest) Reset() {
+	*x = ListVolumesRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[101]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSecretByNameRequest) String() string {
+func (x *ListVolumesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSecretByNameRequest) ProtoMessage() {}
+func (*ListVolumesRequest) ProtoMessage() {}
 
-func (x *GetSecretByNameRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[52]
+func (x *ListVolumesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[101]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3396,40 +6547,33 @@ func (x *GetSecretByNameRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSecretByNameRequest.ProtoReflect.Descriptor instead.
-func (*GetSecretByNameRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{52}
-}
-
-func (x *GetSecretByNameRequest) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
+// Deprecated: Use ListVolumesRequest.ProtoReflect.Descriptor instead.
+func (*ListVolumesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{101}
 }
 
-type GetSecretByNameResponse struct {
+type ListVolumesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Secret        *Secret                `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	Volumes       []*Volume              `protobuf:"bytes,1,rep,name=volumes,proto3" json:"volumes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetSecretByNameResponse) Reset() {
-	*x = GetSecretByNameResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[53]
+func (x *ListVolumesResponse) Reset() {
+	*x = ListVolumesResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[102]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSecretByNameResponse) String() string {
+func (x *ListVolumesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSecretByNameResponse) ProtoMessage() {}
+func (*ListVolumesResponse) ProtoMessage() {}
 
-func (x *GetSecretByNameResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[53]
+func (x *ListVolumesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[102]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3440,39 +6584,41 @@ func (x *GetSecretByNameResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSecretByNameResponse.ProtoReflect.Descriptor instead.
-func (*GetSecretByNameResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{53}
+// Deprecated: Use ListVolumesResponse.ProtoReflect.Descriptor instead.
+func (*ListVolumesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{102}
 }
 
-func (x *GetSecretByNameResponse) GetSecret() *Secret {
+func (x *ListVolumesResponse) GetVolumes() []*Volume {
 	if x != nil {
-		return x.Secret
+		return x.Volumes
 	}
 	return nil
 }
 
-type ListSecretsRequest struct {
+// Cluster messages
+type GenerateJoinTokenRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"` // "manager" or "worker"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListSecretsRequest) Reset() {
-	*x = ListSecretsRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[54]
+func (x *GenerateJoinTokenRequest) Reset() {
+	*x = GenerateJoinTokenRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[103]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSecretsRequest) String() string {
+func (x *GenerateJoinTokenRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSecretsRequest) ProtoMessage() {}
+func (*GenerateJoinTokenRequest) ProtoMessage() {}
 
-func (x *ListSecretsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[54]
+func (x *GenerateJoinTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[103]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3483,33 +6629,43 @@ func (x *ListSecretsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSecretsRequest.ProtoReflect.Descriptor instead.
-func (*ListSecretsRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{54}
+// Deprecated: Use GenerateJoinTokenRequest.ProtoReflect.Descriptor instead.
+func (*GenerateJoinTokenRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{103}
 }
 
-type ListSecretsResponse struct {
+func (x *GenerateJoinTokenRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type GenerateJoinTokenResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Secrets       []*Secret              `protobuf:"bytes,1,rep,name=secrets,proto3" json:"secrets,omitempty"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Id            string                 `protobuf:"bytes,4,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListSecretsResponse) Reset() {
-	*x = ListSecretsResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[55]
+func (x *GenerateJoinTokenResponse) Reset() {
+	*x = GenerateJoinTokenResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[104]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSecretsResponse) String() string {
+func (x *GenerateJoinTokenResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSecretsResponse) ProtoMessage() {}
+func (*GenerateJoinTokenResponse) ProtoMessage() {}
 
-func (x *ListSecretsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[55]
+func (x *GenerateJoinTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[104]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3520,48 +6676,65 @@ func (x *ListSecretsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSecretsResponse.ProtoReflect.Descriptor instead.
-func (*ListSecretsResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{55}
+// Deprecated: Use GenerateJoinTokenResponse.ProtoReflect.Descriptor instead.
+func (*GenerateJoinTokenResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{104}
 }
 
-func (x *ListSecretsResponse) GetSecrets() []*Secret {
+func (x *GenerateJoinTokenResponse) GetToken() string {
 	if x != nil {
-		return x.Secrets
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GenerateJoinTokenResponse) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *GenerateJoinTokenResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
 	}
 	return nil
 }
 
-// Volume messages
-type Volume struct {
+func (x *GenerateJoinTokenResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// JoinToken describes a stored join token; the token itself is never returned
+type JoinToken struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Driver        string                 `protobuf:"bytes,3,opt,name=driver,proto3" json:"driver,omitempty"`
-	DriverOpts    map[string]string      `protobuf:"bytes,4,rep,name=driver_opts,json=driverOpts,proto3" json:"driver_opts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Labels        map[string]string      `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	NodeId        string                 `protobuf:"bytes,7,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`          // Node affinity for local volumes
-	MountPath     string                 `protobuf:"bytes,8,opt,name=mount_path,json=mountPath,proto3" json:"mount_path,omitempty"` // Host mount path
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Volume) Reset() {
-	*x = Volume{}
-	mi := &file_api_proto_warren_proto_msgTypes[56]
+func (x *JoinToken) Reset() {
+	*x = JoinToken{}
+	mi := &file_api_proto_warren_proto_msgTypes[105]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Volume) String() string {
+func (x *JoinToken) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Volume) ProtoMessage() {}
+func (*JoinToken) ProtoMessage() {}
 
-func (x *Volume) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[56]
+func (x *JoinToken) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[105]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3572,92 +6745,97 @@ func (x *Volume) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Volume.ProtoReflect.Descriptor instead.
-func (*Volume) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{56}
+// Deprecated: Use JoinToken.ProtoReflect.Descriptor instead.
+func (*JoinToken) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{105}
 }
 
-func (x *Volume) GetId() string {
+func (x *JoinToken) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *Volume) GetName() string {
+func (x *JoinToken) GetRole() string {
 	if x != nil {
-		return x.Name
+		return x.Role
 	}
 	return ""
 }
 
-func (x *Volume) GetDriver() string {
+func (x *JoinToken) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Driver
+		return x.CreatedAt
 	}
-	return ""
+	return nil
 }
 
-func (x *Volume) GetDriverOpts() map[string]string {
+func (x *JoinToken) GetExpiresAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.DriverOpts
+		return x.ExpiresAt
 	}
 	return nil
 }
 
-func (x *Volume) GetLabels() map[string]string {
-	if x != nil {
-		return x.Labels
-	}
-	return nil
+type ListJoinTokensRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Volume) GetCreatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return nil
+func (x *ListJoinTokensRequest) Reset() {
+	*x = ListJoinTokensRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Volume) GetNodeId() string {
-	if x != nil {
-		return x.NodeId
-	}
-	return ""
+func (x *ListJoinTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Volume) GetMountPath() string {
+func (*ListJoinTokensRequest) ProtoMessage() {}
+
+func (x *ListJoinTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[106]
 	if x != nil {
-		return x.MountPath
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-type CreateVolumeRequest struct {
+// Deprecated: Use ListJoinTokensRequest.ProtoReflect.Descriptor instead.
+func (*ListJoinTokensRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{106}
+}
+
+type ListJoinTokensResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Driver        string                 `protobuf:"bytes,2,opt,name=driver,proto3" json:"driver,omitempty"`
-	DriverOpts    map[string]string      `protobuf:"bytes,3,rep,name=driver_opts,json=driverOpts,proto3" json:"driver_opts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Labels        map[string]string      `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Tokens        []*JoinToken           `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateVolumeRequest) Reset() {
-	*x = CreateVolumeRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[57]
+func (x *ListJoinTokensResponse) Reset() {
+	*x = ListJoinTokensResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[107]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateVolumeRequest) String() string {
+func (x *ListJoinTokensResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateVolumeRequest) ProtoMessage() {}
+func (*ListJoinTokensResponse) ProtoMessage() {}
 
-func (x *CreateVolumeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[57]
+func (x *ListJoinTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[107]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3668,61 +6846,83 @@ func (x *CreateVolumeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateVolumeRequest.ProtoReflect.Descriptor instead.
-func (*CreateVolumeRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{57}
+// Deprecated: Use ListJoinTokensResponse.ProtoReflect.Descriptor instead.
+func (*ListJoinTokensResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{107}
 }
 
-func (x *CreateVolumeRequest) GetName() string {
+func (x *ListJoinTokensResponse) GetTokens() []*JoinToken {
 	if x != nil {
-		return x.Name
+		return x.Tokens
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateVolumeRequest) GetDriver() string {
-	if x != nil {
-		return x.Driver
-	}
-	return ""
+type RevokeJoinTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Token ID, or the token itself
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateVolumeRequest) GetDriverOpts() map[string]string {
+func (x *RevokeJoinTokenRequest) Reset() {
+	*x = RevokeJoinTokenRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeJoinTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeJoinTokenRequest) ProtoMessage() {}
+
+func (x *RevokeJoinTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[108]
 	if x != nil {
-		return x.DriverOpts
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *CreateVolumeRequest) GetLabels() map[string]string {
+// Deprecated: Use RevokeJoinTokenRequest.ProtoReflect.Descriptor instead.
+func (*RevokeJoinTokenRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *RevokeJoinTokenRequest) GetId() string {
 	if x != nil {
-		return x.Labels
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-type CreateVolumeResponse struct {
+type RevokeJoinTokenResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Volume        *Volume                `protobuf:"bytes,1,opt,name=volume,proto3" json:"volume,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateVolumeResponse) Reset() {
-	*x = CreateVolumeResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[58]
+func (x *RevokeJoinTokenResponse) Reset() {
+	*x = RevokeJoinTokenResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[109]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateVolumeResponse) String() string {
+func (x *RevokeJoinTokenResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateVolumeResponse) ProtoMessage() {}
+func (*RevokeJoinTokenResponse) ProtoMessage() {}
 
-func (x *CreateVolumeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[58]
+func (x *RevokeJoinTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[109]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3733,40 +6933,33 @@ func (x *CreateVolumeResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateVolumeResponse.ProtoReflect.Descriptor instead.
-func (*CreateVolumeResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{58}
-}
-
-func (x *CreateVolumeResponse) GetVolume() *Volume {
-	if x != nil {
-		return x.Volume
-	}
-	return nil
+// Deprecated: Use RevokeJoinTokenResponse.ProtoReflect.Descriptor instead.
+func (*RevokeJoinTokenResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{109}
 }
 
-type DeleteVolumeRequest struct {
+type RotateJoinTokenRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Role          string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"` // "manager" or "worker"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteVolumeRequest) Reset() {
-	*x = DeleteVolumeRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[59]
+func (x *RotateJoinTokenRequest) Reset() {
+	*x = RotateJoinTokenRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[110]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteVolumeRequest) String() string {
+func (x *RotateJoinTokenRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteVolumeRequest) ProtoMessage() {}
+func (*RotateJoinTokenRequest) ProtoMessage() {}
 
-func (x *DeleteVolumeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[59]
+func (x *RotateJoinTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[110]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3777,40 +6970,43 @@ func (x *DeleteVolumeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteVolumeRequest.ProtoReflect.Descriptor instead.
-func (*DeleteVolumeRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{59}
+// Deprecated: Use RotateJoinTokenRequest.ProtoReflect.Descriptor instead.
+func (*RotateJoinTokenRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{110}
 }
 
-func (x *DeleteVolumeRequest) GetId() string {
+func (x *RotateJoinTokenRequest) GetRole() string {
 	if x != nil {
-		return x.Id
+		return x.Role
 	}
 	return ""
 }
 
-type DeleteVolumeResponse struct {
+type RotateJoinTokenResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Id            string                 `protobuf:"bytes,4,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteVolumeResponse) Reset() {
-	*x = DeleteVolumeResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[60]
+func (x *RotateJoinTokenResponse) Reset() {
+	*x = RotateJoinTokenResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[111]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteVolumeResponse) String() string {
+func (x *RotateJoinTokenResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteVolumeResponse) ProtoMessage() {}
+func (*RotateJoinTokenResponse) ProtoMessage() {}
 
-func (x *DeleteVolumeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[60]
+func (x *RotateJoinTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[111]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3821,40 +7017,63 @@ func (x *DeleteVolumeResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteVolumeResponse.ProtoReflect.Descriptor instead.
-func (*DeleteVolumeResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{60}
+// Deprecated: Use RotateJoinTokenResponse.ProtoReflect.Descriptor instead.
+func (*RotateJoinTokenResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{111}
 }
 
-func (x *DeleteVolumeResponse) GetStatus() string {
+func (x *RotateJoinTokenResponse) GetToken() string {
 	if x != nil {
-		return x.Status
+		return x.Token
 	}
 	return ""
 }
 
-type GetVolumeByNameRequest struct {
+func (x *RotateJoinTokenResponse) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *RotateJoinTokenResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *RotateJoinTokenResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type JoinClusterRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	NodeId        string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	BindAddr      string                 `protobuf:"bytes,2,opt,name=bind_addr,json=bindAddr,proto3" json:"bind_addr,omitempty"`
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetVolumeByNameRequest) Reset() {
-	*x = GetVolumeByNameRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[61]
+func (x *JoinClusterRequest) Reset() {
+	*x = JoinClusterRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[112]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetVolumeByNameRequest) String() string {
+func (x *JoinClusterRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetVolumeByNameRequest) ProtoMessage() {}
+func (*JoinClusterRequest) ProtoMessage() {}
 
-func (x *GetVolumeByNameRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[61]
+func (x *JoinClusterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[112]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3865,40 +7084,55 @@ func (x *GetVolumeByNameRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetVolumeByNameRequest.ProtoReflect.Descriptor instead.
-func (*GetVolumeByNameRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{61}
+// Deprecated: Use JoinClusterRequest.ProtoReflect.Descriptor instead.
+func (*JoinClusterRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{112}
 }
 
-func (x *GetVolumeByNameRequest) GetName() string {
+func (x *JoinClusterRequest) GetNodeId() string {
 	if x != nil {
-		return x.Name
+		return x.NodeId
 	}
 	return ""
 }
 
-type GetVolumeByNameResponse struct {
+func (x *JoinClusterRequest) GetBindAddr() string {
+	if x != nil {
+		return x.BindAddr
+	}
+	return ""
+}
+
+func (x *JoinClusterRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type JoinClusterResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Volume        *Volume                `protobuf:"bytes,1,opt,name=volume,proto3" json:"volume,omitempty"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	LeaderAddr    string                 `protobuf:"bytes,2,opt,name=leader_addr,json=leaderAddr,proto3" json:"leader_addr,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetVolumeByNameResponse) Reset() {
-	*x = GetVolumeByNameResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[62]
+func (x *JoinClusterResponse) Reset() {
+	*x = JoinClusterResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[113]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetVolumeByNameResponse) String() string {
+func (x *JoinClusterResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetVolumeByNameResponse) ProtoMessage() {}
+func (*JoinClusterResponse) ProtoMessage() {}
 
-func (x *GetVolumeByNameResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[62]
+func (x *JoinClusterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[113]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3909,39 +7143,46 @@ func (x *GetVolumeByNameResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetVolumeByNameResponse.ProtoReflect.Descriptor instead.
-func (*GetVolumeByNameResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{62}
+// Deprecated: Use JoinClusterResponse.ProtoReflect.Descriptor instead.
+func (*JoinClusterResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{113}
 }
 
-func (x *GetVolumeByNameResponse) GetVolume() *Volume {
+func (x *JoinClusterResponse) GetStatus() string {
 	if x != nil {
-		return x.Volume
+		return x.Status
 	}
-	return nil
+	return ""
 }
 
-type ListVolumesRequest struct {
+func (x *JoinClusterResponse) GetLeaderAddr() string {
+	if x != nil {
+		return x.LeaderAddr
+	}
+	return ""
+}
+
+type GetClusterInfoRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListVolumesRequest) Reset() {
-	*x = ListVolumesRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[63]
+func (x *GetClusterInfoRequest) Reset() {
+	*x = GetClusterInfoRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[114]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListVolumesRequest) String() string {
+func (x *GetClusterInfoRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListVolumesRequest) ProtoMessage() {}
+func (*GetClusterInfoRequest) ProtoMessage() {}
 
-func (x *ListVolumesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[63]
+func (x *GetClusterInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[114]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3952,33 +7193,35 @@ func (x *ListVolumesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListVolumesRequest.ProtoReflect.Descriptor instead.
-func (*ListVolumesRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{63}
+// Deprecated: Use GetClusterInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetClusterInfoRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{114}
 }
 
-type ListVolumesResponse struct {
+type GetClusterInfoResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Volumes       []*Volume              `protobuf:"bytes,1,rep,name=volumes,proto3" json:"volumes,omitempty"`
+	LeaderId      string                 `protobuf:"bytes,1,opt,name=leader_id,json=leaderId,proto3" json:"leader_id,omitempty"`
+	LeaderAddr    string                 `protobuf:"bytes,2,opt,name=leader_addr,json=leaderAddr,proto3" json:"leader_addr,omitempty"`
+	Servers       []*ClusterServer       `protobuf:"bytes,3,rep,name=servers,proto3" json:"servers,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListVolumesResponse) Reset() {
-	*x = ListVolumesResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[64]
+func (x *GetClusterInfoResponse) Reset() {
+	*x = GetClusterInfoResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[115]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListVolumesResponse) String() string {
+func (x *GetClusterInfoResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListVolumesResponse) ProtoMessage() {}
+func (*GetClusterInfoResponse) ProtoMessage() {}
 
-func (x *ListVolumesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[64]
+func (x *GetClusterInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[115]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3989,41 +7232,56 @@ func (x *ListVolumesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListVolumesResponse.ProtoReflect.Descriptor instead.
-func (*ListVolumesResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{64}
+// Deprecated: Use GetClusterInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetClusterInfoResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{115}
 }
 
-func (x *ListVolumesResponse) GetVolumes() []*Volume {
+func (x *GetClusterInfoResponse) GetLeaderId() string {
 	if x != nil {
-		return x.Volumes
+		return x.LeaderId
+	}
+	return ""
+}
+
+func (x *GetClusterInfoResponse) GetLeaderAddr() string {
+	if x != nil {
+		return x.LeaderAddr
+	}
+	return ""
+}
+
+func (x *GetClusterInfoResponse) GetServers() []*ClusterServer {
+	if x != nil {
+		return x.Servers
 	}
 	return nil
 }
 
-// Cluster messages
-type GenerateJoinTokenRequest struct {
+type ClusterServer struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Role          string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"` // "manager" or "worker"
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Suffrage      string                 `protobuf:"bytes,3,opt,name=suffrage,proto3" json:"suffrage,omitempty"` // "Voter", "Nonvoter", "Staging"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GenerateJoinTokenRequest) Reset() {
-	*x = GenerateJoinTokenRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[65]
+func (x *ClusterServer) Reset() {
+	*x = ClusterServer{}
+	mi := &file_api_proto_warren_proto_msgTypes[116]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GenerateJoinTokenRequest) String() string {
+func (x *ClusterServer) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenerateJoinTokenRequest) ProtoMessage() {}
+func (*ClusterServer) ProtoMessage() {}
 
-func (x *GenerateJoinTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[65]
+func (x *ClusterServer) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[116]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4034,42 +7292,60 @@ func (x *GenerateJoinTokenRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenerateJoinTokenRequest.ProtoReflect.Descriptor instead.
-func (*GenerateJoinTokenRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{65}
+// Deprecated: Use ClusterServer.ProtoReflect.Descriptor instead.
+func (*ClusterServer) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{116}
 }
 
-func (x *GenerateJoinTokenRequest) GetRole() string {
+func (x *ClusterServer) GetId() string {
 	if x != nil {
-		return x.Role
+		return x.Id
 	}
 	return ""
 }
 
-type GenerateJoinTokenResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
-	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ClusterServer) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ClusterServer) GetSuffrage() string {
+	if x != nil {
+		return x.Suffrage
+	}
+	return ""
+}
+
+// Health check messages
+type ReportContainerHealthRequest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	ContainerId          string                 `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	Healthy              bool                   `protobuf:"varint,2,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	Message              string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	CheckedAt            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	ConsecutiveFailures  int32                  `protobuf:"varint,5,opt,name=consecutive_failures,json=consecutiveFailures,proto3" json:"consecutive_failures,omitempty"`
+	ConsecutiveSuccesses int32                  `protobuf:"varint,6,opt,name=consecutive_successes,json=consecutiveSuccesses,proto3" json:"consecutive_successes,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
-func (x *GenerateJoinTokenResponse) Reset() {
-	*x = GenerateJoinTokenResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[66]
+func (x *ReportContainerHealthRequest) Reset() {
+	*x = ReportContainerHealthRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[117]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GenerateJoinTokenResponse) String() string {
+func (x *ReportContainerHealthRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenerateJoinTokenResponse) ProtoMessage() {}
+func (*ReportContainerHealthRequest) ProtoMessage() {}
 
-func (x *GenerateJoinTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[66]
+func (x *ReportContainerHealthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[117]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4080,56 +7356,75 @@ func (x *GenerateJoinTokenResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenerateJoinTokenResponse.ProtoReflect.Descriptor instead.
-func (*GenerateJoinTokenResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{66}
+// Deprecated: Use ReportContainerHealthRequest.ProtoReflect.Descriptor instead.
+func (*ReportContainerHealthRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{117}
 }
 
-func (x *GenerateJoinTokenResponse) GetToken() string {
+func (x *ReportContainerHealthRequest) GetContainerId() string {
 	if x != nil {
-		return x.Token
+		return x.ContainerId
 	}
 	return ""
 }
 
-func (x *GenerateJoinTokenResponse) GetRole() string {
+func (x *ReportContainerHealthRequest) GetHealthy() bool {
 	if x != nil {
-		return x.Role
+		return x.Healthy
+	}
+	return false
+}
+
+func (x *ReportContainerHealthRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-func (x *GenerateJoinTokenResponse) GetExpiresAt() *timestamppb.Timestamp {
+func (x *ReportContainerHealthRequest) GetCheckedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.ExpiresAt
+		return x.CheckedAt
 	}
 	return nil
 }
 
-type JoinClusterRequest struct {
+func (x *ReportContainerHealthRequest) GetConsecutiveFailures() int32 {
+	if x != nil {
+		return x.ConsecutiveFailures
+	}
+	return 0
+}
+
+func (x *ReportContainerHealthRequest) GetConsecutiveSuccesses() int32 {
+	if x != nil {
+		return x.ConsecutiveSuccesses
+	}
+	return 0
+}
+
+type ReportContainerHealthResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	NodeId        string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
-	BindAddr      string                 `protobuf:"bytes,2,opt,name=bind_addr,json=bindAddr,proto3" json:"bind_addr,omitempty"`
-	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *JoinClusterRequest) Reset() {
-	*x = JoinClusterRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[67]
+func (x *ReportContainerHealthResponse) Reset() {
+	*x = ReportContainerHealthResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[118]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *JoinClusterRequest) String() string {
+func (x *ReportContainerHealthResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JoinClusterRequest) ProtoMessage() {}
+func (*ReportContainerHealthResponse) ProtoMessage() {}
 
-func (x *JoinClusterRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[67]
+func (x *ReportContainerHealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[118]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4140,55 +7435,45 @@ func (x *JoinClusterRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JoinClusterRequest.ProtoReflect.Descriptor instead.
-func (*JoinClusterRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{67}
-}
-
-func (x *JoinClusterRequest) GetNodeId() string {
-	if x != nil {
-		return x.NodeId
-	}
-	return ""
-}
-
-func (x *JoinClusterRequest) GetBindAddr() string {
-	if x != nil {
-		return x.BindAddr
-	}
-	return ""
+// Deprecated: Use ReportContainerHealthResponse.ProtoReflect.Descriptor instead.
+func (*ReportContainerHealthResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{118}
 }
 
-func (x *JoinClusterRequest) GetToken() string {
+func (x *ReportContainerHealthResponse) GetStatus() string {
 	if x != nil {
-		return x.Token
+		return x.Status
 	}
 	return ""
 }
 
-type JoinClusterResponse struct {
+// Event streaming messages
+type Event struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	LeaderAddr    string                 `protobuf:"bytes,2,opt,name=leader_addr,json=leaderAddr,proto3" json:"leader_addr,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *JoinClusterResponse) Reset() {
-	*x = JoinClusterResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[68]
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_api_proto_warren_proto_msgTypes[119]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *JoinClusterResponse) String() string {
+func (x *Event) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JoinClusterResponse) ProtoMessage() {}
+func (*Event) ProtoMessage() {}
 
-func (x *JoinClusterResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[68]
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[119]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4199,85 +7484,72 @@ func (x *JoinClusterResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JoinClusterResponse.ProtoReflect.Descriptor instead.
-func (*JoinClusterResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{68}
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{119}
 }
 
-func (x *JoinClusterResponse) GetStatus() string {
+func (x *Event) GetId() string {
 	if x != nil {
-		return x.Status
+		return x.Id
 	}
 	return ""
 }
 
-func (x *JoinClusterResponse) GetLeaderAddr() string {
+func (x *Event) GetType() string {
 	if x != nil {
-		return x.LeaderAddr
+		return x.Type
 	}
 	return ""
 }
 
-type GetClusterInfoRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetClusterInfoRequest) Reset() {
-	*x = GetClusterInfoRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[69]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetClusterInfoRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Event) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
 }
 
-func (*GetClusterInfoRequest) ProtoMessage() {}
-
-func (x *GetClusterInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[69]
+func (x *Event) GetMessage() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Message
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use GetClusterInfoRequest.ProtoReflect.Descriptor instead.
-func (*GetClusterInfoRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{69}
+func (x *Event) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
 }
 
-type GetClusterInfoResponse struct {
+type StreamEventsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	LeaderId      string                 `protobuf:"bytes,1,opt,name=leader_id,json=leaderId,proto3" json:"leader_id,omitempty"`
-	LeaderAddr    string                 `protobuf:"bytes,2,opt,name=leader_addr,json=leaderAddr,proto3" json:"leader_addr,omitempty"`
-	Servers       []*ClusterServer       `protobuf:"bytes,3,rep,name=servers,proto3" json:"servers,omitempty"`
+	EventTypes    []string               `protobuf:"bytes,1,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`         // Filter by event types (empty = all events)
+	Service       string                 `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`                                 // Filter by service ID or name (empty = all services)
+	NodeId        string                 `protobuf:"bytes,3,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`                     // Filter by node ID (empty = all nodes)
+	SinceEventId  string                 `protobuf:"bytes,4,opt,name=since_event_id,json=sinceEventId,proto3" json:"since_event_id,omitempty"` // Resume after this event ID (replays buffered events)
+	Follow        bool                   `protobuf:"varint,5,opt,name=follow,proto3" json:"follow,omitempty"`                                  // Keep streaming new events (false = replay buffered events and return)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetClusterInfoResponse) Reset() {
-	*x = GetClusterInfoResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[70]
+func (x *StreamEventsRequest) Reset() {
+	*x = StreamEventsRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[120]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetClusterInfoResponse) String() string {
+func (x *StreamEventsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetClusterInfoResponse) ProtoMessage() {}
+func (*StreamEventsRequest) ProtoMessage() {}
 
-func (x *GetClusterInfoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[70]
+func (x *StreamEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[120]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4288,56 +7560,76 @@ func (x *GetClusterInfoResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetClusterInfoResponse.ProtoReflect.Descriptor instead.
-func (*GetClusterInfoResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{70}
+// Deprecated: Use StreamEventsRequest.ProtoReflect.Descriptor instead.
+func (*StreamEventsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{120}
 }
 
-func (x *GetClusterInfoResponse) GetLeaderId() string {
+func (x *StreamEventsRequest) GetEventTypes() []string {
 	if x != nil {
-		return x.LeaderId
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *StreamEventsRequest) GetService() string {
+	if x != nil {
+		return x.Service
 	}
 	return ""
 }
 
-func (x *GetClusterInfoResponse) GetLeaderAddr() string {
+func (x *StreamEventsRequest) GetNodeId() string {
 	if x != nil {
-		return x.LeaderAddr
+		return x.NodeId
 	}
 	return ""
 }
 
-func (x *GetClusterInfoResponse) GetServers() []*ClusterServer {
+func (x *StreamEventsRequest) GetSinceEventId() string {
 	if x != nil {
-		return x.Servers
+		return x.SinceEventId
 	}
-	return nil
+	return ""
 }
 
-type ClusterServer struct {
+func (x *StreamEventsRequest) GetFollow() bool {
+	if x != nil {
+		return x.Follow
+	}
+	return false
+}
+
+// Log messages
+type LogEntry struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
-	Suffrage      string                 `protobuf:"bytes,3,opt,name=suffrage,proto3" json:"suffrage,omitempty"` // "Voter", "Nonvoter", "Staging"
+	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"` // Log request this entry answers (worker -> manager)
+	ContainerId   string                 `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	ServiceName   string                 `protobuf:"bytes,3,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	NodeId        string                 `protobuf:"bytes,4,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Replica       string                 `protobuf:"bytes,5,opt,name=replica,proto3" json:"replica,omitempty"` // Replica label, e.g. "web.2"
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Stream        string                 `protobuf:"bytes,7,opt,name=stream,proto3" json:"stream,omitempty"` // "stdout", "stderr" or "warren" for notices from the manager
+	Line          string                 `protobuf:"bytes,8,opt,name=line,proto3" json:"line,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ClusterServer) Reset() {
-	*x = ClusterServer{}
-	mi := &file_api_proto_warren_proto_msgTypes[71]
+func (x *LogEntry) Reset() {
+	*x = LogEntry{}
+	mi := &file_api_proto_warren_proto_msgTypes[121]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ClusterServer) String() string {
+func (x *LogEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClusterServer) ProtoMessage() {}
+func (*LogEntry) ProtoMessage() {}
 
-func (x *ClusterServer) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[71]
+func (x *LogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[121]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4348,60 +7640,93 @@ func (x *ClusterServer) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClusterServer.ProtoReflect.Descriptor instead.
-func (*ClusterServer) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{71}
+// Deprecated: Use LogEntry.ProtoReflect.Descriptor instead.
+func (*LogEntry) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{121}
+}
+
+func (x *LogEntry) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *LogEntry) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+func (x *LogEntry) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *LogEntry) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *LogEntry) GetReplica() string {
+	if x != nil {
+		return x.Replica
+	}
+	return ""
 }
 
-func (x *ClusterServer) GetId() string {
+func (x *LogEntry) GetTimestamp() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Id
+		return x.Timestamp
 	}
-	return ""
+	return nil
 }
 
-func (x *ClusterServer) GetAddress() string {
+func (x *LogEntry) GetStream() string {
 	if x != nil {
-		return x.Address
+		return x.Stream
 	}
 	return ""
 }
 
-func (x *ClusterServer) GetSuffrage() string {
+func (x *LogEntry) GetLine() string {
 	if x != nil {
-		return x.Suffrage
+		return x.Line
 	}
 	return ""
 }
 
-// Health check messages
-type ReportContainerHealthRequest struct {
-	state                protoimpl.MessageState `protogen:"open.v1"`
-	ContainerId          string                 `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
-	Healthy              bool                   `protobuf:"varint,2,opt,name=healthy,proto3" json:"healthy,omitempty"`
-	Message              string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
-	CheckedAt            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
-	ConsecutiveFailures  int32                  `protobuf:"varint,5,opt,name=consecutive_failures,json=consecutiveFailures,proto3" json:"consecutive_failures,omitempty"`
-	ConsecutiveSuccesses int32                  `protobuf:"varint,6,opt,name=consecutive_successes,json=consecutiveSuccesses,proto3" json:"consecutive_successes,omitempty"`
-	unknownFields        protoimpl.UnknownFields
-	sizeCache            protoimpl.SizeCache
+type StreamServiceLogsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServiceName   string                 `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Follow        bool                   `protobuf:"varint,2,opt,name=follow,proto3" json:"follow,omitempty"`                                // Keep streaming new log lines
+	Since         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`                                   // Only lines at or after this time (optional)
+	Tail          int32                  `protobuf:"varint,4,opt,name=tail,proto3" json:"tail,omitempty"`                                    // Only the last N lines of each replica (0 = all)
+	ContainerIds  []string               `protobuf:"bytes,5,rep,name=container_ids,json=containerIds,proto3" json:"container_ids,omitempty"` // Only these replicas; set when a manager forwards a request to the manager their workers are connected to
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReportContainerHealthRequest) Reset() {
-	*x = ReportContainerHealthRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[72]
+func (x *StreamServiceLogsRequest) Reset() {
+	*x = StreamServiceLogsRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[122]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReportContainerHealthRequest) String() string {
+func (x *StreamServiceLogsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReportContainerHealthRequest) ProtoMessage() {}
+func (*StreamServiceLogsRequest) ProtoMessage() {}
 
-func (x *ReportContainerHealthRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[72]
+func (x *StreamServiceLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[122]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4412,75 +7737,68 @@ func (x *ReportContainerHealthRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReportContainerHealthRequest.ProtoReflect.Descriptor instead.
-func (*ReportContainerHealthRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{72}
+// Deprecated: Use StreamServiceLogsRequest.ProtoReflect.Descriptor instead.
+func (*StreamServiceLogsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{122}
 }
 
-func (x *ReportContainerHealthRequest) GetContainerId() string {
+func (x *StreamServiceLogsRequest) GetServiceName() string {
 	if x != nil {
-		return x.ContainerId
+		return x.ServiceName
 	}
 	return ""
 }
 
-func (x *ReportContainerHealthRequest) GetHealthy() bool {
+func (x *StreamServiceLogsRequest) GetFollow() bool {
 	if x != nil {
-		return x.Healthy
+		return x.Follow
 	}
 	return false
 }
 
-func (x *ReportContainerHealthRequest) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
-}
-
-func (x *ReportContainerHealthRequest) GetCheckedAt() *timestamppb.Timestamp {
+func (x *StreamServiceLogsRequest) GetSince() *timestamppb.Timestamp {
 	if x != nil {
-		return x.CheckedAt
+		return x.Since
 	}
 	return nil
 }
 
-func (x *ReportContainerHealthRequest) GetConsecutiveFailures() int32 {
+func (x *StreamServiceLogsRequest) GetTail() int32 {
 	if x != nil {
-		return x.ConsecutiveFailures
+		return x.Tail
 	}
 	return 0
 }
 
-func (x *ReportContainerHealthRequest) GetConsecutiveSuccesses() int32 {
+func (x *StreamServiceLogsRequest) GetContainerIds() []string {
 	if x != nil {
-		return x.ConsecutiveSuccesses
+		return x.ContainerIds
 	}
-	return 0
+	return nil
 }
 
-type ReportContainerHealthResponse struct {
+type WatchLogRequestsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	NodeId        string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReportContainerHealthResponse) Reset() {
-	*x = ReportContainerHealthResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[73]
+func (x *WatchLogRequestsRequest) Reset() {
+	*x = WatchLogRequestsRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[123]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReportContainerHealthResponse) String() string {
+func (x *WatchLogRequestsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReportContainerHealthResponse) ProtoMessage() {}
+func (*WatchLogRequestsRequest) ProtoMessage() {}
 
-func (x *ReportContainerHealthResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[73]
+func (x *WatchLogRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[123]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4491,45 +7809,45 @@ func (x *ReportContainerHealthResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReportContainerHealthResponse.ProtoReflect.Descriptor instead.
-func (*ReportContainerHealthResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{73}
+// Deprecated: Use WatchLogRequestsRequest.ProtoReflect.Descriptor instead.
+func (*WatchLogRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{123}
 }
 
-func (x *ReportContainerHealthResponse) GetStatus() string {
+func (x *WatchLogRequestsRequest) GetNodeId() string {
 	if x != nil {
-		return x.Status
+		return x.NodeId
 	}
 	return ""
 }
 
-// Event streaming messages
-type Event struct {
+type LogRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
-	Metadata      map[string]string      `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	ContainerId   string                 `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	Since         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`
+	Tail          int32                  `protobuf:"varint,4,opt,name=tail,proto3" json:"tail,omitempty"`
+	Follow        bool                   `protobuf:"varint,5,opt,name=follow,proto3" json:"follow,omitempty"`
+	Cancel        bool                   `protobuf:"varint,6,opt,name=cancel,proto3" json:"cancel,omitempty"` // Stop serving a followed request (client went away)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Event) Reset() {
-	*x = Event{}
-	mi := &file_api_proto_warren_proto_msgTypes[74]
+func (x *LogRequest) Reset() {
+	*x = LogRequest{}
+	mi := &file_api_proto_warren_proto_msgTypes[124]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Event) String() string {
+func (x *LogRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Event) ProtoMessage() {}
+func (*LogRequest) ProtoMessage() {}
 
-func (x *Event) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[74]
+func (x *LogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[124]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4540,68 +7858,74 @@ func (x *Event) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Event.ProtoReflect.Descriptor instead.
-func (*Event) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{74}
+// Deprecated: Use LogRequest.ProtoReflect.Descriptor instead.
+func (*LogRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{124}
 }
 
-func (x *Event) GetId() string {
+func (x *LogRequest) GetRequestId() string {
 	if x != nil {
-		return x.Id
+		return x.RequestId
 	}
 	return ""
 }
 
-func (x *Event) GetType() string {
+func (x *LogRequest) GetContainerId() string {
 	if x != nil {
-		return x.Type
+		return x.ContainerId
 	}
 	return ""
 }
 
-func (x *Event) GetTimestamp() *timestamppb.Timestamp {
+func (x *LogRequest) GetSince() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Timestamp
+		return x.Since
 	}
 	return nil
 }
 
-func (x *Event) GetMessage() string {
+func (x *LogRequest) GetTail() int32 {
 	if x != nil {
-		return x.Message
+		return x.Tail
 	}
-	return ""
+	return 0
 }
 
-func (x *Event) GetMetadata() map[string]string {
+func (x *LogRequest) GetFollow() bool {
 	if x != nil {
-		return x.Metadata
+		return x.Follow
 	}
-	return nil
+	return false
 }
 
-type StreamEventsRequest struct {
+func (x *LogRequest) GetCancel() bool {
+	if x != nil {
+		return x.Cancel
+	}
+	return false
+}
+
+type PushContainerLogsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	EventTypes    []string               `protobuf:"bytes,1,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"` // Filter by event types (empty = all events)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StreamEventsRequest) Reset() {
-	*x = StreamEventsRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[75]
+func (x *PushContainerLogsResponse) Reset() {
+	*x = PushContainerLogsResponse{}
+	mi := &file_api_proto_warren_proto_msgTypes[125]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StreamEventsRequest) String() string {
+func (x *PushContainerLogsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StreamEventsRequest) ProtoMessage() {}
+func (*PushContainerLogsResponse) ProtoMessage() {}
 
-func (x *StreamEventsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[75]
+func (x *PushContainerLogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_warren_proto_msgTypes[125]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4612,16 +7936,9 @@ func (x *StreamEventsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StreamEventsRequest.ProtoReflect.Descriptor instead.
-func (*StreamEventsRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{75}
-}
-
-func (x *StreamEventsRequest) GetEventTypes() []string {
-	if x != nil {
-		return x.EventTypes
-	}
-	return nil
+// Deprecated: Use PushContainerLogsResponse.ProtoReflect.Descriptor instead.
+func (*PushContainerLogsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{125}
 }
 
 // Certificate messages
@@ -4635,7 +7952,7 @@ type RequestCertificateRequest struct {
 
 func (x *RequestCertificateRequest) Reset() {
 	*x = RequestCertificateRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[76]
+	mi := &file_api_proto_warren_proto_msgTypes[126]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4647,7 +7964,7 @@ func (x *RequestCertificateRequest) String() string {
 func (*RequestCertificateRequest) ProtoMessage() {}
 
 func (x *RequestCertificateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[76]
+	mi := &file_api_proto_warren_proto_msgTypes[126]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4660,7 +7977,7 @@ func (x *RequestCertificateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestCertificateRequest.ProtoReflect.Descriptor instead.
 func (*RequestCertificateRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{76}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{126}
 }
 
 func (x *RequestCertificateRequest) GetNodeId() string {
@@ -4688,7 +8005,7 @@ type RequestCertificateResponse struct {
 
 func (x *RequestCertificateResponse) Reset() {
 	*x = RequestCertificateResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[77]
+	mi := &file_api_proto_warren_proto_msgTypes[127]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4700,7 +8017,7 @@ func (x *RequestCertificateResponse) String() string {
 func (*RequestCertificateResponse) ProtoMessage() {}
 
 func (x *RequestCertificateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[77]
+	mi := &file_api_proto_warren_proto_msgTypes[127]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4713,7 +8030,7 @@ func (x *RequestCertificateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestCertificateResponse.ProtoReflect.Descriptor instead.
 func (*RequestCertificateResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{77}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{127}
 }
 
 func (x *RequestCertificateResponse) GetCertificate() []byte {
@@ -4753,7 +8070,7 @@ type Ingress struct {
 
 func (x *Ingress) Reset() {
 	*x = Ingress{}
-	mi := &file_api_proto_warren_proto_msgTypes[78]
+	mi := &file_api_proto_warren_proto_msgTypes[128]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4765,7 +8082,7 @@ func (x *Ingress) String() string {
 func (*Ingress) ProtoMessage() {}
 
 func (x *Ingress) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[78]
+	mi := &file_api_proto_warren_proto_msgTypes[128]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4778,7 +8095,7 @@ func (x *Ingress) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Ingress.ProtoReflect.Descriptor instead.
 func (*Ingress) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{78}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{128}
 }
 
 func (x *Ingress) GetId() string {
@@ -4840,7 +8157,7 @@ type IngressRule struct {
 
 func (x *IngressRule) Reset() {
 	*x = IngressRule{}
-	mi := &file_api_proto_warren_proto_msgTypes[79]
+	mi := &file_api_proto_warren_proto_msgTypes[129]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4852,7 +8169,7 @@ func (x *IngressRule) String() string {
 func (*IngressRule) ProtoMessage() {}
 
 func (x *IngressRule) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[79]
+	mi := &file_api_proto_warren_proto_msgTypes[129]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4865,7 +8182,7 @@ func (x *IngressRule) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IngressRule.ProtoReflect.Descriptor instead.
 func (*IngressRule) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{79}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{129}
 }
 
 func (x *IngressRule) GetHost() string {
@@ -4893,7 +8210,7 @@ type IngressPath struct {
 
 func (x *IngressPath) Reset() {
 	*x = IngressPath{}
-	mi := &file_api_proto_warren_proto_msgTypes[80]
+	mi := &file_api_proto_warren_proto_msgTypes[130]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4905,7 +8222,7 @@ func (x *IngressPath) String() string {
 func (*IngressPath) ProtoMessage() {}
 
 func (x *IngressPath) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[80]
+	mi := &file_api_proto_warren_proto_msgTypes[130]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4918,7 +8235,7 @@ func (x *IngressPath) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IngressPath.ProtoReflect.Descriptor instead.
 func (*IngressPath) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{80}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{130}
 }
 
 func (x *IngressPath) GetPath() string {
@@ -4952,7 +8269,7 @@ type IngressBackend struct {
 
 func (x *IngressBackend) Reset() {
 	*x = IngressBackend{}
-	mi := &file_api_proto_warren_proto_msgTypes[81]
+	mi := &file_api_proto_warren_proto_msgTypes[131]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4964,7 +8281,7 @@ func (x *IngressBackend) String() string {
 func (*IngressBackend) ProtoMessage() {}
 
 func (x *IngressBackend) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[81]
+	mi := &file_api_proto_warren_proto_msgTypes[131]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4977,7 +8294,7 @@ func (x *IngressBackend) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IngressBackend.ProtoReflect.Descriptor instead.
 func (*IngressBackend) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{81}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{131}
 }
 
 func (x *IngressBackend) GetServiceName() string {
@@ -5007,7 +8324,7 @@ type IngressTLS struct {
 
 func (x *IngressTLS) Reset() {
 	*x = IngressTLS{}
-	mi := &file_api_proto_warren_proto_msgTypes[82]
+	mi := &file_api_proto_warren_proto_msgTypes[132]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5019,7 +8336,7 @@ func (x *IngressTLS) String() string {
 func (*IngressTLS) ProtoMessage() {}
 
 func (x *IngressTLS) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[82]
+	mi := &file_api_proto_warren_proto_msgTypes[132]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5032,7 +8349,7 @@ func (x *IngressTLS) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IngressTLS.ProtoReflect.Descriptor instead.
 func (*IngressTLS) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{82}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{132}
 }
 
 func (x *IngressTLS) GetEnabled() bool {
@@ -5082,7 +8399,7 @@ type CreateIngressRequest struct {
 
 func (x *CreateIngressRequest) Reset() {
 	*x = CreateIngressRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[83]
+	mi := &file_api_proto_warren_proto_msgTypes[133]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5094,7 +8411,7 @@ func (x *CreateIngressRequest) String() string {
 func (*CreateIngressRequest) ProtoMessage() {}
 
 func (x *CreateIngressRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[83]
+	mi := &file_api_proto_warren_proto_msgTypes[133]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5107,7 +8424,7 @@ func (x *CreateIngressRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateIngressRequest.ProtoReflect.Descriptor instead.
 func (*CreateIngressRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{83}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{133}
 }
 
 func (x *CreateIngressRequest) GetName() string {
@@ -5147,7 +8464,7 @@ type CreateIngressResponse struct {
 
 func (x *CreateIngressResponse) Reset() {
 	*x = CreateIngressResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[84]
+	mi := &file_api_proto_warren_proto_msgTypes[134]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5159,7 +8476,7 @@ func (x *CreateIngressResponse) String() string {
 func (*CreateIngressResponse) ProtoMessage() {}
 
 func (x *CreateIngressResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[84]
+	mi := &file_api_proto_warren_proto_msgTypes[134]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5172,7 +8489,7 @@ func (x *CreateIngressResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateIngressResponse.ProtoReflect.Descriptor instead.
 func (*CreateIngressResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{84}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{134}
 }
 
 func (x *CreateIngressResponse) GetIngress() *Ingress {
@@ -5195,7 +8512,7 @@ type UpdateIngressRequest struct {
 
 func (x *UpdateIngressRequest) Reset() {
 	*x = UpdateIngressRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[85]
+	mi := &file_api_proto_warren_proto_msgTypes[135]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5207,7 +8524,7 @@ func (x *UpdateIngressRequest) String() string {
 func (*UpdateIngressRequest) ProtoMessage() {}
 
 func (x *UpdateIngressRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[85]
+	mi := &file_api_proto_warren_proto_msgTypes[135]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5220,7 +8537,7 @@ func (x *UpdateIngressRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateIngressRequest.ProtoReflect.Descriptor instead.
 func (*UpdateIngressRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{85}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{135}
 }
 
 func (x *UpdateIngressRequest) GetId() string {
@@ -5267,7 +8584,7 @@ type UpdateIngressResponse struct {
 
 func (x *UpdateIngressResponse) Reset() {
 	*x = UpdateIngressResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[86]
+	mi := &file_api_proto_warren_proto_msgTypes[136]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5279,7 +8596,7 @@ func (x *UpdateIngressResponse) String() string {
 func (*UpdateIngressResponse) ProtoMessage() {}
 
 func (x *UpdateIngressResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[86]
+	mi := &file_api_proto_warren_proto_msgTypes[136]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5292,7 +8609,7 @@ func (x *UpdateIngressResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateIngressResponse.ProtoReflect.Descriptor instead.
 func (*UpdateIngressResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{86}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{136}
 }
 
 func (x *UpdateIngressResponse) GetIngress() *Ingress {
@@ -5312,7 +8629,7 @@ type DeleteIngressRequest struct {
 
 func (x *DeleteIngressRequest) Reset() {
 	*x = DeleteIngressRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[87]
+	mi := &file_api_proto_warren_proto_msgTypes[137]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5324,7 +8641,7 @@ func (x *DeleteIngressRequest) String() string {
 func (*DeleteIngressRequest) ProtoMessage() {}
 
 func (x *DeleteIngressRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[87]
+	mi := &file_api_proto_warren_proto_msgTypes[137]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5337,7 +8654,7 @@ func (x *DeleteIngressRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteIngressRequest.ProtoReflect.Descriptor instead.
 func (*DeleteIngressRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{87}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{137}
 }
 
 func (x *DeleteIngressRequest) GetId() string {
@@ -5363,7 +8680,7 @@ type DeleteIngressResponse struct {
 
 func (x *DeleteIngressResponse) Reset() {
 	*x = DeleteIngressResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[88]
+	mi := &file_api_proto_warren_proto_msgTypes[138]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5375,7 +8692,7 @@ func (x *DeleteIngressResponse) String() string {
 func (*DeleteIngressResponse) ProtoMessage() {}
 
 func (x *DeleteIngressResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[88]
+	mi := &file_api_proto_warren_proto_msgTypes[138]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5388,7 +8705,7 @@ func (x *DeleteIngressResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteIngressResponse.ProtoReflect.Descriptor instead.
 func (*DeleteIngressResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{88}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{138}
 }
 
 func (x *DeleteIngressResponse) GetStatus() string {
@@ -5408,7 +8725,7 @@ type GetIngressRequest struct {
 
 func (x *GetIngressRequest) Reset() {
 	*x = GetIngressRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[89]
+	mi := &file_api_proto_warren_proto_msgTypes[139]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5420,7 +8737,7 @@ func (x *GetIngressRequest) String() string {
 func (*GetIngressRequest) ProtoMessage() {}
 
 func (x *GetIngressRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[89]
+	mi := &file_api_proto_warren_proto_msgTypes[139]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5433,7 +8750,7 @@ func (x *GetIngressRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetIngressRequest.ProtoReflect.Descriptor instead.
 func (*GetIngressRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{89}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{139}
 }
 
 func (x *GetIngressRequest) GetId() string {
@@ -5459,7 +8776,7 @@ type GetIngressResponse struct {
 
 func (x *GetIngressResponse) Reset() {
 	*x = GetIngressResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[90]
+	mi := &file_api_proto_warren_proto_msgTypes[140]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5471,7 +8788,7 @@ func (x *GetIngressResponse) String() string {
 func (*GetIngressResponse) ProtoMessage() {}
 
 func (x *GetIngressResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[90]
+	mi := &file_api_proto_warren_proto_msgTypes[140]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5484,7 +8801,7 @@ func (x *GetIngressResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetIngressResponse.ProtoReflect.Descriptor instead.
 func (*GetIngressResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{90}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{140}
 }
 
 func (x *GetIngressResponse) GetIngress() *Ingress {
@@ -5502,7 +8819,7 @@ type ListIngressesRequest struct {
 
 func (x *ListIngressesRequest) Reset() {
 	*x = ListIngressesRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[91]
+	mi := &file_api_proto_warren_proto_msgTypes[141]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5514,7 +8831,7 @@ func (x *ListIngressesRequest) String() string {
 func (*ListIngressesRequest) ProtoMessage() {}
 
 func (x *ListIngressesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[91]
+	mi := &file_api_proto_warren_proto_msgTypes[141]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5527,7 +8844,7 @@ func (x *ListIngressesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListIngressesRequest.ProtoReflect.Descriptor instead.
 func (*ListIngressesRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{91}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{141}
 }
 
 type ListIngressesResponse struct {
@@ -5539,7 +8856,7 @@ type ListIngressesResponse struct {
 
 func (x *ListIngressesResponse) Reset() {
 	*x = ListIngressesResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[92]
+	mi := &file_api_proto_warren_proto_msgTypes[142]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5551,7 +8868,7 @@ func (x *ListIngressesResponse) String() string {
 func (*ListIngressesResponse) ProtoMessage() {}
 
 func (x *ListIngressesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[92]
+	mi := &file_api_proto_warren_proto_msgTypes[142]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5564,7 +8881,7 @@ func (x *ListIngressesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListIngressesResponse.ProtoReflect.Descriptor instead.
 func (*ListIngressesResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{92}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{142}
 }
 
 func (x *ListIngressesResponse) GetIngresses() []*Ingress {
@@ -5594,7 +8911,7 @@ type TLSCertificate struct {
 
 func (x *TLSCertificate) Reset() {
 	*x = TLSCertificate{}
-	mi := &file_api_proto_warren_proto_msgTypes[93]
+	mi := &file_api_proto_warren_proto_msgTypes[143]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5606,7 +8923,7 @@ func (x *TLSCertificate) String() string {
 func (*TLSCertificate) ProtoMessage() {}
 
 func (x *TLSCertificate) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[93]
+	mi := &file_api_proto_warren_proto_msgTypes[143]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5619,7 +8936,7 @@ func (x *TLSCertificate) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TLSCertificate.ProtoReflect.Descriptor instead.
 func (*TLSCertificate) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{93}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{143}
 }
 
 func (x *TLSCertificate) GetId() string {
@@ -5719,7 +9036,7 @@ type CreateTLSCertificateRequest struct {
 
 func (x *CreateTLSCertificateRequest) Reset() {
 	*x = CreateTLSCertificateRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[94]
+	mi := &file_api_proto_warren_proto_msgTypes[144]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5731,7 +9048,7 @@ func (x *CreateTLSCertificateRequest) String() string {
 func (*CreateTLSCertificateRequest) ProtoMessage() {}
 
 func (x *CreateTLSCertificateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[94]
+	mi := &file_api_proto_warren_proto_msgTypes[144]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5744,7 +9061,7 @@ func (x *CreateTLSCertificateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateTLSCertificateRequest.ProtoReflect.Descriptor instead.
 func (*CreateTLSCertificateRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{94}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{144}
 }
 
 func (x *CreateTLSCertificateRequest) GetName() string {
@@ -5791,7 +9108,7 @@ type CreateTLSCertificateResponse struct {
 
 func (x *CreateTLSCertificateResponse) Reset() {
 	*x = CreateTLSCertificateResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[95]
+	mi := &file_api_proto_warren_proto_msgTypes[145]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5803,7 +9120,7 @@ func (x *CreateTLSCertificateResponse) String() string {
 func (*CreateTLSCertificateResponse) ProtoMessage() {}
 
 func (x *CreateTLSCertificateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[95]
+	mi := &file_api_proto_warren_proto_msgTypes[145]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5816,7 +9133,7 @@ func (x *CreateTLSCertificateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateTLSCertificateResponse.ProtoReflect.Descriptor instead.
 func (*CreateTLSCertificateResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{95}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{145}
 }
 
 func (x *CreateTLSCertificateResponse) GetCertificate() *TLSCertificate {
@@ -5836,7 +9153,7 @@ type GetTLSCertificateRequest struct {
 
 func (x *GetTLSCertificateRequest) Reset() {
 	*x = GetTLSCertificateRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[96]
+	mi := &file_api_proto_warren_proto_msgTypes[146]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5848,7 +9165,7 @@ func (x *GetTLSCertificateRequest) String() string {
 func (*GetTLSCertificateRequest) ProtoMessage() {}
 
 func (x *GetTLSCertificateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[96]
+	mi := &file_api_proto_warren_proto_msgTypes[146]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5861,7 +9178,7 @@ func (x *GetTLSCertificateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTLSCertificateRequest.ProtoReflect.Descriptor instead.
 func (*GetTLSCertificateRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{96}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{146}
 }
 
 func (x *GetTLSCertificateRequest) GetId() string {
@@ -5887,7 +9204,7 @@ type GetTLSCertificateResponse struct {
 
 func (x *GetTLSCertificateResponse) Reset() {
 	*x = GetTLSCertificateResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[97]
+	mi := &file_api_proto_warren_proto_msgTypes[147]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5899,7 +9216,7 @@ func (x *GetTLSCertificateResponse) String() string {
 func (*GetTLSCertificateResponse) ProtoMessage() {}
 
 func (x *GetTLSCertificateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[97]
+	mi := &file_api_proto_warren_proto_msgTypes[147]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5912,7 +9229,7 @@ func (x *GetTLSCertificateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTLSCertificateResponse.ProtoReflect.Descriptor instead.
 func (*GetTLSCertificateResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{97}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{147}
 }
 
 func (x *GetTLSCertificateResponse) GetCertificate() *TLSCertificate {
@@ -5930,7 +9247,7 @@ type ListTLSCertificatesRequest struct {
 
 func (x *ListTLSCertificatesRequest) Reset() {
 	*x = ListTLSCertificatesRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[98]
+	mi := &file_api_proto_warren_proto_msgTypes[148]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5942,7 +9259,7 @@ func (x *ListTLSCertificatesRequest) String() string {
 func (*ListTLSCertificatesRequest) ProtoMessage() {}
 
 func (x *ListTLSCertificatesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[98]
+	mi := &file_api_proto_warren_proto_msgTypes[148]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5955,7 +9272,7 @@ func (x *ListTLSCertificatesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListTLSCertificatesRequest.ProtoReflect.Descriptor instead.
 func (*ListTLSCertificatesRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{98}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{148}
 }
 
 type ListTLSCertificatesResponse struct {
@@ -5967,7 +9284,7 @@ type ListTLSCertificatesResponse struct {
 
 func (x *ListTLSCertificatesResponse) Reset() {
 	*x = ListTLSCertificatesResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[99]
+	mi := &file_api_proto_warren_proto_msgTypes[149]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5979,7 +9296,7 @@ func (x *ListTLSCertificatesResponse) String() string {
 func (*ListTLSCertificatesResponse) ProtoMessage() {}
 
 func (x *ListTLSCertificatesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[99]
+	mi := &file_api_proto_warren_proto_msgTypes[149]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5992,7 +9309,7 @@ func (x *ListTLSCertificatesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListTLSCertificatesResponse.ProtoReflect.Descriptor instead.
 func (*ListTLSCertificatesResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{99}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{149}
 }
 
 func (x *ListTLSCertificatesResponse) GetCertificates() []*TLSCertificate {
@@ -6012,7 +9329,7 @@ type DeleteTLSCertificateRequest struct {
 
 func (x *DeleteTLSCertificateRequest) Reset() {
 	*x = DeleteTLSCertificateRequest{}
-	mi := &file_api_proto_warren_proto_msgTypes[100]
+	mi := &file_api_proto_warren_proto_msgTypes[150]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6024,7 +9341,7 @@ func (x *DeleteTLSCertificateRequest) String() string {
 func (*DeleteTLSCertificateRequest) ProtoMessage() {}
 
 func (x *DeleteTLSCertificateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[100]
+	mi := &file_api_proto_warren_proto_msgTypes[150]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6037,7 +9354,7 @@ func (x *DeleteTLSCertificateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteTLSCertificateRequest.ProtoReflect.Descriptor instead.
 func (*DeleteTLSCertificateRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{100}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{150}
 }
 
 func (x *DeleteTLSCertificateRequest) GetId() string {
@@ -6063,7 +9380,7 @@ type DeleteTLSCertificateResponse struct {
 
 func (x *DeleteTLSCertificateResponse) Reset() {
 	*x = DeleteTLSCertificateResponse{}
-	mi := &file_api_proto_warren_proto_msgTypes[101]
+	mi := &file_api_proto_warren_proto_msgTypes[151]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6075,7 +9392,7 @@ func (x *DeleteTLSCertificateResponse) String() string {
 func (*DeleteTLSCertificateResponse) ProtoMessage() {}
 
 func (x *DeleteTLSCertificateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_warren_proto_msgTypes[101]
+	mi := &file_api_proto_warren_proto_msgTypes[151]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6088,7 +9405,7 @@ func (x *DeleteTLSCertificateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteTLSCertificateResponse.ProtoReflect.Descriptor instead.
 func (*DeleteTLSCertificateResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_warren_proto_rawDescGZIP(), []int{101}
+	return file_api_proto_warren_proto_rawDescGZIP(), []int{151}
 }
 
 func (x *DeleteTLSCertificateResponse) GetStatus() string {
@@ -6102,7 +9419,7 @@ var File_api_proto_warren_proto protoreflect.FileDescriptor
 
 const file_api_proto_warren_proto_rawDesc = "" +
 	"\n" +
-	"\x16api/proto/warren.proto\x12\twarren.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa1\x03\n" +
+	"\x16api/proto/warren.proto\x12\twarren.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb8\x04\n" +
 	"\x04Node\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04role\x18\x02 \x01(\tR\x04role\x12\x18\n" +
@@ -6114,28 +9431,43 @@ const file_api_proto_warren_proto_rawDesc = "" +
 	"\x0elast_heartbeat\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\rlastHeartbeat\x129\n" +
 	"\n" +
 	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x123\n" +
-	"\x06labels\x18\t \x03(\v2\x1b.warren.v1.Node.LabelsEntryR\x06labels\x1a9\n" +
+	"\x06labels\x18\t \x03(\v2\x1b.warren.v1.Node.LabelsEntryR\x06labels\x12,\n" +
+	"\x12overlay_public_key\x18\n" +
+	" \x01(\tR\x10overlayPublicKey\x12!\n" +
+	"\foverlay_port\x18\v \x01(\x05R\voverlayPort\x12\x1a\n" +
+	"\bhostname\x18\f \x01(\tR\bhostname\x12(\n" +
+	"\x06taints\x18\r \x03(\v2\x10.warren.v1.TaintR\x06taints\x1a9\n" +
 	"\vLabelsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"n\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"G\n" +
+	"\x05Taint\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x16\n" +
+	"\x06effect\x18\x03 \x01(\tR\x06effect\"\xbe\x01\n" +
 	"\rNodeResources\x12\x1b\n" +
 	"\tcpu_cores\x18\x01 \x01(\x03R\bcpuCores\x12!\n" +
 	"\fmemory_bytes\x18\x02 \x01(\x03R\vmemoryBytes\x12\x1d\n" +
 	"\n" +
-	"disk_bytes\x18\x03 \x01(\x03R\tdiskBytes\"\x8a\x02\n" +
+	"disk_bytes\x18\x03 \x01(\x03R\tdiskBytes\x12#\n" +
+	"\rcpu_allocated\x18\x04 \x01(\x01R\fcpuAllocated\x12)\n" +
+	"\x10memory_allocated\x18\x05 \x01(\x03R\x0fmemoryAllocated\"\xf7\x02\n" +
 	"\x13RegisterNodeRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04role\x18\x02 \x01(\tR\x04role\x12\x18\n" +
 	"\aaddress\x18\x03 \x01(\tR\aaddress\x126\n" +
 	"\tresources\x18\x04 \x01(\v2\x18.warren.v1.NodeResourcesR\tresources\x12B\n" +
-	"\x06labels\x18\x05 \x03(\v2*.warren.v1.RegisterNodeRequest.LabelsEntryR\x06labels\x1a9\n" +
+	"\x06labels\x18\x05 \x03(\v2*.warren.v1.RegisterNodeRequest.LabelsEntryR\x06labels\x12,\n" +
+	"\x12overlay_public_key\x18\x06 \x01(\tR\x10overlayPublicKey\x12!\n" +
+	"\foverlay_port\x18\a \x01(\x05R\voverlayPort\x12\x1a\n" +
+	"\bhostname\x18\b \x01(\tR\bhostname\x1a9\n" +
 	"\vLabelsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"Z\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x81\x01\n" +
 	"\x14RegisterNodeResponse\x12#\n" +
 	"\x04node\x18\x01 \x01(\v2\x0f.warren.v1.NodeR\x04node\x12\x1d\n" +
 	"\n" +
-	"overlay_ip\x18\x02 \x01(\tR\toverlayIp\"\xc1\x01\n" +
+	"overlay_ip\x18\x02 \x01(\tR\toverlayIp\x12%\n" +
+	"\x0eoverlay_subnet\x18\x03 \x01(\tR\roverlaySubnet\"\xc1\x01\n" +
 	"\x10HeartbeatRequest\x12\x17\n" +
 	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12I\n" +
 	"\x13available_resources\x18\x02 \x01(\v2\x18.warren.v1.NodeResourcesR\x12availableResources\x12I\n" +
@@ -6159,7 +9491,18 @@ const file_api_proto_warren_proto_rawDesc = "" +
 	"\x11RemoveNodeRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\",\n" +
 	"\x12RemoveNodeResponse\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status\"\xab\x06\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\"\xa0\x02\n" +
+	"\x11UpdateNodeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12G\n" +
+	"\tlabel_add\x18\x02 \x03(\v2*.warren.v1.UpdateNodeRequest.LabelAddEntryR\blabelAdd\x12\x19\n" +
+	"\blabel_rm\x18\x03 \x03(\tR\alabelRm\x12-\n" +
+	"\ttaint_add\x18\x04 \x03(\v2\x10.warren.v1.TaintR\btaintAdd\x12+\n" +
+	"\btaint_rm\x18\x05 \x03(\v2\x10.warren.v1.TaintR\ataintRm\x1a;\n" +
+	"\rLabelAddEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"9\n" +
+	"\x12UpdateNodeResponse\x12#\n" +
+	"\x04node\x18\x01 \x01(\v2\x0f.warren.v1.NodeR\x04node\"\xf1\v\n" +
 	"\aService\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
@@ -6181,10 +9524,70 @@ const file_api_proto_warren_proto_rawDesc = "" +
 	"\n" +
 	"updated_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12,\n" +
 	"\x05ports\x18\x11 \x03(\v2\x16.warren.v1.PortMappingR\x05ports\x12!\n" +
-	"\fstop_timeout\x18\x12 \x01(\x05R\vstopTimeout\x1a6\n" +
+	"\fstop_timeout\x18\x12 \x01(\x05R\vstopTimeout\x12\x10\n" +
+	"\x03vip\x18\x13 \x01(\tR\x03vip\x122\n" +
+	"\tplacement\x18\x14 \x01(\v2\x14.warren.v1.PlacementR\tplacement\x126\n" +
+	"\x06labels\x18\x15 \x03(\v2\x1e.warren.v1.Service.LabelsEntryR\x06labels\x12/\n" +
+	"\baffinity\x18\x16 \x01(\v2\x13.warren.v1.AffinityR\baffinity\x128\n" +
+	"\ranti_affinity\x18\x17 \x01(\v2\x13.warren.v1.AffinityR\fantiAffinity\x127\n" +
+	"\vtolerations\x18\x18 \x03(\v2\x15.warren.v1.TolerationR\vtolerations\x12+\n" +
+	"\x11disruption_budget\x18\x19 \x01(\x05R\x10disruptionBudget\x12\x1a\n" +
+	"\bpriority\x18\x1a \x01(\x05R\bpriority\x12&\n" +
+	"\x03job\x18\x1b \x01(\v2\x14.warren.v1.JobConfigR\x03job\x123\n" +
+	"\n" +
+	"job_status\x18\x1c \x01(\v2\x14.warren.v1.JobStatusR\tjobStatus\x123\n" +
+	"\bcron_job\x18\x1d \x01(\v2\x18.warren.v1.CronJobConfigR\acronJob\x12H\n" +
+	"\x12last_schedule_time\x18\x1e \x01(\v2\x1a.google.protobuf.TimestampR\x10lastScheduleTime\x12B\n" +
+	"\x0fcanary_analysis\x18\x1f \x01(\v2\x19.warren.v1.CanaryAnalysisR\x0ecanaryAnalysis\x1a6\n" +
 	"\bEnvEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd1\x04\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc3\x01\n" +
+	"\tJobConfig\x12 \n" +
+	"\vcompletions\x18\x01 \x01(\x05R\vcompletions\x12 \n" +
+	"\vparallelism\x18\x02 \x01(\x05R\vparallelism\x12(\n" +
+	"\rbackoff_limit\x18\x03 \x01(\x05H\x00R\fbackoffLimit\x88\x01\x01\x126\n" +
+	"\x17active_deadline_seconds\x18\x04 \x01(\x03R\x15activeDeadlineSecondsB\x10\n" +
+	"\x0e_backoff_limit\"\x87\x02\n" +
+	"\tJobStatus\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\tR\x05state\x12\x16\n" +
+	"\x06active\x18\x02 \x01(\x05R\x06active\x12\x1c\n" +
+	"\tsucceeded\x18\x03 \x01(\x05R\tsucceeded\x12\x16\n" +
+	"\x06failed\x18\x04 \x01(\x05R\x06failed\x129\n" +
+	"\n" +
+	"start_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x12C\n" +
+	"\x0fcompletion_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x0ecompletionTime\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\"\xa2\x02\n" +
+	"\rCronJobConfig\x12\x1a\n" +
+	"\bschedule\x18\x01 \x01(\tR\bschedule\x12-\n" +
+	"\x12concurrency_policy\x18\x02 \x01(\tR\x11concurrencyPolicy\x12F\n" +
+	"\x1dsuccessful_jobs_history_limit\x18\x03 \x01(\x05H\x00R\x1asuccessfulJobsHistoryLimit\x88\x01\x01\x12>\n" +
+	"\x19failed_jobs_history_limit\x18\x04 \x01(\x05H\x01R\x16failedJobsHistoryLimit\x88\x01\x01B \n" +
+	"\x1e_successful_jobs_history_limitB\x1c\n" +
+	"\x1a_failed_jobs_history_limit\"o\n" +
+	"\tPlacement\x12 \n" +
+	"\vconstraints\x18\x01 \x03(\tR\vconstraints\x12@\n" +
+	"\vpreferences\x18\x02 \x03(\v2\x1e.warren.v1.PlacementPreferenceR\vpreferences\"-\n" +
+	"\x13PlacementPreference\x12\x16\n" +
+	"\x06spread\x18\x01 \x01(\tR\x06spread\"v\n" +
+	"\bAffinity\x123\n" +
+	"\brequired\x18\x01 \x03(\v2\x17.warren.v1.AffinityTermR\brequired\x125\n" +
+	"\tpreferred\x18\x02 \x03(\v2\x17.warren.v1.AffinityTermR\tpreferred\"\xb8\x01\n" +
+	"\fAffinityTerm\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x12;\n" +
+	"\x06labels\x18\x02 \x03(\v2#.warren.v1.AffinityTerm.LabelsEntryR\x06labels\x12\x16\n" +
+	"\x06weight\x18\x03 \x01(\x05R\x06weight\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"h\n" +
+	"\n" +
+	"Toleration\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1a\n" +
+	"\boperator\x18\x02 \x01(\tR\boperator\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12\x16\n" +
+	"\x06effect\x18\x04 \x01(\tR\x06effect\"\x85\x05\n" +
 	"\fUpdateConfig\x12 \n" +
 	"\vparallelism\x18\x01 \x01(\x05R\vparallelism\x12#\n" +
 	"\rdelay_seconds\x18\x02 \x01(\x05R\fdelaySeconds\x12%\n" +
@@ -6198,7 +9601,23 @@ const file_api_proto_warren_proto_rawDesc = "" +
 	"\x1fblue_green_grace_period_seconds\x18\n" +
 	" \x01(\x05R\x1bblueGreenGracePeriodSeconds\x122\n" +
 	"\x15auto_rollback_enabled\x18\v \x01(\bR\x13autoRollbackEnabled\x12:\n" +
-	"\x19failure_threshold_percent\x18\f \x01(\x05R\x17failureThresholdPercent\"\x90\x03\n" +
+	"\x19failure_threshold_percent\x18\f \x01(\x05R\x17failureThresholdPercent\x122\n" +
+	"\x15p99_threshold_percent\x18\r \x01(\x05R\x13p99ThresholdPercent\"[\n" +
+	"\x0eTrafficSummary\x12\x1a\n" +
+	"\brequests\x18\x01 \x01(\x05R\brequests\x12\x16\n" +
+	"\x06errors\x18\x02 \x01(\x05R\x06errors\x12\x15\n" +
+	"\x06p99_ms\x18\x03 \x01(\x03R\x05p99Ms\"\xb2\x02\n" +
+	"\x0eCanaryAnalysis\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\tR\x06result\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05image\x18\x03 \x01(\tR\x05image\x12\x16\n" +
+	"\x06weight\x18\x04 \x01(\x05R\x06weight\x121\n" +
+	"\x06canary\x18\x05 \x01(\v2\x19.warren.v1.TrafficSummaryR\x06canary\x121\n" +
+	"\x06stable\x18\x06 \x01(\v2\x19.warren.v1.TrafficSummaryR\x06stable\x12\x1f\n" +
+	"\vrolled_back\x18\a \x01(\bR\n" +
+	"rolledBack\x12;\n" +
+	"\vanalyzed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"analyzedAt\"\x90\x03\n" +
 	"\vHealthCheck\x12/\n" +
 	"\x04type\x18\x01 \x01(\x0e2\x1b.warren.v1.HealthCheck.TypeR\x04type\x12.\n" +
 	"\x04http\x18\x02 \x01(\v2\x1a.warren.v1.HTTPHealthCheckR\x04http\x12+\n" +
@@ -6229,12 +9648,13 @@ const file_api_proto_warren_proto_rawDesc = "" +
 	"\rRestartPolicy\x12\x1c\n" +
 	"\tcondition\x18\x01 \x01(\tR\tcondition\x12!\n" +
 	"\fmax_attempts\x18\x02 \x01(\x05R\vmaxAttempts\x12#\n" +
-	"\rdelay_seconds\x18\x03 \x01(\x05R\fdelaySeconds\"\x92\x01\n" +
+	"\rdelay_seconds\x18\x03 \x01(\x05R\fdelaySeconds\"\xc8\x01\n" +
 	"\x14ResourceRequirements\x12\x1d\n" +
 	"\n" +
 	"cpu_shares\x18\x01 \x01(\x03R\tcpuShares\x12!\n" +
 	"\fmemory_bytes\x18\x02 \x01(\x03R\vmemoryBytes\x128\n" +
-	"\x18memory_reservation_bytes\x18\x03 \x01(\x03R\x16memoryReservationBytes\"Z\n" +
+	"\x18memory_reservation_bytes\x18\x03 \x01(\x03R\x16memoryReservationBytes\x124\n" +
+	"\x16cpu_reservation_shares\x18\x04 \x01(\x03R\x14cpuReservationShares\"Z\n" +
 	"\vVolumeMount\x12\x16\n" +
 	"\x06source\x18\x01 \x01(\tR\x06source\x12\x16\n" +
 	"\x06target\x18\x02 \x01(\tR\x06target\x12\x1b\n" +
@@ -6247,7 +9667,7 @@ const file_api_proto_warren_proto_rawDesc = "" +
 	"\fpublish_mode\x18\x05 \x01(\x0e2\".warren.v1.PortMapping.PublishModeR\vpublishMode\"$\n" +
 	"\vPublishMode\x12\b\n" +
 	"\x04HOST\x10\x00\x12\v\n" +
-	"\aINGRESS\x10\x01\"\xbf\x05\n" +
+	"\aINGRESS\x10\x01\"\xbd\t\n" +
 	"\x14CreateServiceRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
 	"\x05image\x18\x02 \x01(\tR\x05image\x12\x1a\n" +
@@ -6264,33 +9684,67 @@ const file_api_proto_warren_proto_rawDesc = "" +
 	"\x03env\x18\f \x03(\v2(.warren.v1.CreateServiceRequest.EnvEntryR\x03env\x12\x18\n" +
 	"\acommand\x18\r \x03(\tR\acommand\x12,\n" +
 	"\x05ports\x18\x0e \x03(\v2\x16.warren.v1.PortMappingR\x05ports\x12!\n" +
-	"\fstop_timeout\x18\x0f \x01(\x05R\vstopTimeout\x1a6\n" +
+	"\fstop_timeout\x18\x0f \x01(\x05R\vstopTimeout\x122\n" +
+	"\tplacement\x18\x10 \x01(\v2\x14.warren.v1.PlacementR\tplacement\x12C\n" +
+	"\x06labels\x18\x11 \x03(\v2+.warren.v1.CreateServiceRequest.LabelsEntryR\x06labels\x12/\n" +
+	"\baffinity\x18\x12 \x01(\v2\x13.warren.v1.AffinityR\baffinity\x128\n" +
+	"\ranti_affinity\x18\x13 \x01(\v2\x13.warren.v1.AffinityR\fantiAffinity\x127\n" +
+	"\vtolerations\x18\x14 \x03(\v2\x15.warren.v1.TolerationR\vtolerations\x12+\n" +
+	"\x11disruption_budget\x18\x15 \x01(\x05R\x10disruptionBudget\x12\x1a\n" +
+	"\bpriority\x18\x16 \x01(\x05R\bpriority\x12&\n" +
+	"\x03job\x18\x17 \x01(\v2\x14.warren.v1.JobConfigR\x03job\x123\n" +
+	"\bcron_job\x18\x18 \x01(\v2\x18.warren.v1.CronJobConfigR\acronJob\x1a6\n" +
 	"\bEnvEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"E\n" +
 	"\x15CreateServiceResponse\x12,\n" +
-	"\aservice\x18\x01 \x01(\v2\x12.warren.v1.ServiceR\aservice\"\xcc\x01\n" +
+	"\aservice\x18\x01 \x01(\v2\x12.warren.v1.ServiceR\aservice\"\xef\x01\n" +
 	"\x14UpdateServiceRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
 	"\breplicas\x18\x02 \x01(\x05R\breplicas\x12\x14\n" +
 	"\x05image\x18\x03 \x01(\tR\x05image\x12:\n" +
-	"\x03env\x18\x04 \x03(\v2(.warren.v1.UpdateServiceRequest.EnvEntryR\x03env\x1a6\n" +
+	"\x03env\x18\x04 \x03(\v2(.warren.v1.UpdateServiceRequest.EnvEntryR\x03env\x12!\n" +
+	"\fchange_cause\x18\x05 \x01(\tR\vchangeCause\x1a6\n" +
 	"\bEnvEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"E\n" +
 	"\x15UpdateServiceResponse\x12,\n" +
-	"\aservice\x18\x01 \x01(\v2\x12.warren.v1.ServiceR\aservice\"\x9b\x01\n" +
+	"\aservice\x18\x01 \x01(\v2\x12.warren.v1.ServiceR\aservice\"\xb7\x02\n" +
 	"\x19UpdateServiceImageRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05image\x18\x02 \x01(\tR\x05image\x12\x1a\n" +
 	"\bstrategy\x18\x03 \x01(\tR\bstrategy\x12<\n" +
-	"\rupdate_config\x18\x04 \x01(\v2\x17.warren.v1.UpdateConfigR\fupdateConfig\"4\n" +
+	"\rupdate_config\x18\x04 \x01(\v2\x17.warren.v1.UpdateConfigR\fupdateConfig\x12!\n" +
+	"\fchange_cause\x18\x05 \x01(\tR\vchangeCause\x12?\n" +
+	"\x03env\x18\x06 \x03(\v2-.warren.v1.UpdateServiceImageRequest.EnvEntryR\x03env\x1a6\n" +
+	"\bEnvEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"Y\n" +
 	"\x1aUpdateServiceImageResponse\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status\"(\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12#\n" +
+	"\rdeployment_id\x18\x02 \x01(\tR\fdeploymentId\"I\n" +
 	"\x16RollbackServiceRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"1\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vto_revision\x18\x02 \x01(\x05R\n" +
+	"toRevision\"r\n" +
 	"\x17RollbackServiceResponse\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status\"&\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12#\n" +
+	"\rdeployment_id\x18\x02 \x01(\tR\fdeploymentId\x12\x1a\n" +
+	"\brevision\x18\x03 \x01(\x05R\brevision\"\xb3\x01\n" +
+	"\x0fServiceRevision\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\x05R\brevision\x12!\n" +
+	"\fchange_cause\x18\x02 \x01(\tR\vchangeCause\x12&\n" +
+	"\x04spec\x18\x03 \x01(\v2\x12.warren.v1.ServiceR\x04spec\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"<\n" +
+	"\x1bListServiceRevisionsRequest\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x01 \x01(\tR\tserviceId\"X\n" +
+	"\x1cListServiceRevisionsResponse\x128\n" +
+	"\trevisions\x18\x01 \x03(\v2\x1a.warren.v1.ServiceRevisionR\trevisions\"&\n" +
 	"\x14DeleteServiceRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"/\n" +
 	"\x15DeleteServiceResponse\x12\x16\n" +
@@ -6302,7 +9756,107 @@ const file_api_proto_warren_proto_rawDesc = "" +
 	"\aservice\x18\x01 \x01(\v2\x12.warren.v1.ServiceR\aservice\"\x15\n" +
 	"\x13ListServicesRequest\"F\n" +
 	"\x14ListServicesResponse\x12.\n" +
-	"\bservices\x18\x01 \x03(\v2\x12.warren.v1.ServiceR\bservices\"\xbf\x06\n" +
+	"\bservices\x18\x01 \x03(\v2\x12.warren.v1.ServiceR\bservices\"B\n" +
+	"\x1bListServiceEndpointsRequest\x12#\n" +
+	"\rservice_names\x18\x01 \x03(\tR\fserviceNames\"~\n" +
+	"\x1cListServiceEndpointsResponse\x127\n" +
+	"\bservices\x18\x01 \x03(\v2\x1b.warren.v1.ServiceEndpointsR\bservices\x12%\n" +
+	"\x0eservice_subnet\x18\x02 \x01(\tR\rserviceSubnet\"=\n" +
+	"\x1cGetPlacementDecisionsRequest\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x01 \x01(\tR\tserviceId\"[\n" +
+	"\x1dGetPlacementDecisionsResponse\x12:\n" +
+	"\tdecisions\x18\x01 \x03(\v2\x1c.warren.v1.PlacementDecisionR\tdecisions\"8\n" +
+	"\x17RebalanceServiceRequest\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x01 \x01(\tR\tserviceId\"D\n" +
+	"\x18RebalanceServiceResponse\x12\x12\n" +
+	"\x04skew\x18\x01 \x01(\x05R\x04skew\x12\x14\n" +
+	"\x05moves\x18\x02 \x01(\x05R\x05moves\"\xd9\x04\n" +
+	"\n" +
+	"Deployment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x02 \x01(\tR\tserviceId\x12!\n" +
+	"\fservice_name\x18\x03 \x01(\tR\vserviceName\x12\x1a\n" +
+	"\bstrategy\x18\x04 \x01(\tR\bstrategy\x12\x14\n" +
+	"\x05image\x18\x05 \x01(\tR\x05image\x12%\n" +
+	"\x0eprevious_image\x18\x06 \x01(\tR\rpreviousImage\x12\x14\n" +
+	"\x05phase\x18\a \x01(\tR\x05phase\x12\x12\n" +
+	"\x04step\x18\b \x01(\tR\x04step\x12\x1d\n" +
+	"\n" +
+	"step_index\x18\t \x01(\x05R\tstepIndex\x12B\n" +
+	"\x0fstep_started_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\rstepStartedAt\x12(\n" +
+	"\x10clone_service_id\x18\v \x01(\tR\x0ecloneServiceId\x12\x18\n" +
+	"\amessage\x18\f \x01(\tR\amessage\x129\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12=\n" +
+	"\fcompleted_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12\x1a\n" +
+	"\bfailures\x18\x10 \x01(\x05R\bfailures\"7\n" +
+	"\x16ListDeploymentsRequest\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x01 \x01(\tR\tserviceId\"R\n" +
+	"\x17ListDeploymentsResponse\x127\n" +
+	"\vdeployments\x18\x01 \x03(\v2\x15.warren.v1.DeploymentR\vdeployments\"&\n" +
+	"\x14GetDeploymentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"N\n" +
+	"\x15GetDeploymentResponse\x125\n" +
+	"\n" +
+	"deployment\x18\x01 \x01(\v2\x15.warren.v1.DeploymentR\n" +
+	"deployment\"(\n" +
+	"\x16PauseDeploymentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"P\n" +
+	"\x17PauseDeploymentResponse\x125\n" +
+	"\n" +
+	"deployment\x18\x01 \x01(\v2\x15.warren.v1.DeploymentR\n" +
+	"deployment\")\n" +
+	"\x17ResumeDeploymentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"Q\n" +
+	"\x18ResumeDeploymentResponse\x125\n" +
+	"\n" +
+	"deployment\x18\x01 \x01(\v2\x15.warren.v1.DeploymentR\n" +
+	"deployment\"(\n" +
+	"\x16AbortDeploymentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"P\n" +
+	"\x17AbortDeploymentResponse\x125\n" +
+	"\n" +
+	"deployment\x18\x01 \x01(\v2\x15.warren.v1.DeploymentR\n" +
+	"deployment\"\xc8\x01\n" +
+	"\x11PlacementDecision\x12!\n" +
+	"\fcontainer_id\x18\x01 \x01(\tR\vcontainerId\x12\x17\n" +
+	"\anode_id\x18\x02 \x01(\tR\x06nodeId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12.\n" +
+	"\x04time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12/\n" +
+	"\x05nodes\x18\x05 \x03(\v2\x19.warren.v1.NodeEvaluationR\x05nodes\"\xf2\x01\n" +
+	"\x0eNodeEvaluation\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\x1f\n" +
+	"\vfiltered_by\x18\x02 \x01(\tR\n" +
+	"filteredBy\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12=\n" +
+	"\x06scores\x18\x04 \x03(\v2%.warren.v1.NodeEvaluation.ScoresEntryR\x06scores\x12\x14\n" +
+	"\x05score\x18\x05 \x01(\x01R\x05score\x1a9\n" +
+	"\vScoresEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\x9d\x01\n" +
+	"\x10ServiceEndpoints\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x01 \x01(\tR\tserviceId\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12\x10\n" +
+	"\x03vip\x18\x03 \x01(\tR\x03vip\x125\n" +
+	"\x05ports\x18\x04 \x03(\v2\x1f.warren.v1.ServicePortEndpointsR\x05ports\"\xca\x01\n" +
+	"\x14ServicePortEndpoints\x12\x1a\n" +
+	"\bprotocol\x18\x01 \x01(\tR\bprotocol\x12\x12\n" +
+	"\x04port\x18\x02 \x01(\x05R\x04port\x128\n" +
+	"\tendpoints\x18\x03 \x03(\v2\x1a.warren.v1.ServiceEndpointR\tendpoints\x12%\n" +
+	"\x0epublished_port\x18\x04 \x01(\x05R\rpublishedPort\x12!\n" +
+	"\fforward_port\x18\x05 \x01(\x05R\vforwardPort\"g\n" +
+	"\x0fServiceEndpoint\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12!\n" +
+	"\fcontainer_id\x18\x02 \x01(\tR\vcontainerId\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\"\x90\a\n" +
 	"\tContainer\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
@@ -6326,7 +9880,9 @@ const file_api_proto_warren_proto_rawDesc = "" +
 	"updated_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x14\n" +
 	"\x05error\x18\x11 \x01(\tR\x05error\x12\x18\n" +
 	"\asecrets\x18\x12 \x03(\tR\asecrets\x12!\n" +
-	"\fstop_timeout\x18\x13 \x01(\x05R\vstopTimeout\x1a6\n" +
+	"\fstop_timeout\x18\x13 \x01(\x05R\vstopTimeout\x12,\n" +
+	"\x05ports\x18\x14 \x03(\v2\x16.warren.v1.PortMappingR\x05ports\x12!\n" +
+	"\fpreempted_by\x18\x15 \x01(\tR\vpreemptedBy\x1a6\n" +
 	"\bEnvEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc5\x01\n" +
@@ -6421,12 +9977,34 @@ const file_api_proto_warren_proto_rawDesc = "" +
 	"\x13ListVolumesResponse\x12+\n" +
 	"\avolumes\x18\x01 \x03(\v2\x11.warren.v1.VolumeR\avolumes\".\n" +
 	"\x18GenerateJoinTokenRequest\x12\x12\n" +
-	"\x04role\x18\x01 \x01(\tR\x04role\"\x80\x01\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\"\x90\x01\n" +
 	"\x19GenerateJoinTokenResponse\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12\x12\n" +
 	"\x04role\x18\x02 \x01(\tR\x04role\x129\n" +
 	"\n" +
-	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"`\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x0e\n" +
+	"\x02id\x18\x04 \x01(\tR\x02id\"\xa5\x01\n" +
+	"\tJoinToken\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\x17\n" +
+	"\x15ListJoinTokensRequest\"F\n" +
+	"\x16ListJoinTokensResponse\x12,\n" +
+	"\x06tokens\x18\x01 \x03(\v2\x14.warren.v1.JoinTokenR\x06tokens\"(\n" +
+	"\x16RevokeJoinTokenRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x19\n" +
+	"\x17RevokeJoinTokenResponse\",\n" +
+	"\x16RotateJoinTokenRequest\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\"\x8e\x01\n" +
+	"\x17RotateJoinTokenResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x0e\n" +
+	"\x02id\x18\x04 \x01(\tR\x02id\"`\n" +
 	"\x12JoinClusterRequest\x12\x17\n" +
 	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\x1b\n" +
 	"\tbind_addr\x18\x02 \x01(\tR\bbindAddr\x12\x14\n" +
@@ -6463,10 +10041,42 @@ const file_api_proto_warren_proto_rawDesc = "" +
 	"\bmetadata\x18\x05 \x03(\v2\x1e.warren.v1.Event.MetadataEntryR\bmetadata\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"6\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa7\x01\n" +
 	"\x13StreamEventsRequest\x12\x1f\n" +
 	"\vevent_types\x18\x01 \x03(\tR\n" +
-	"eventTypes\"J\n" +
+	"eventTypes\x12\x18\n" +
+	"\aservice\x18\x02 \x01(\tR\aservice\x12\x17\n" +
+	"\anode_id\x18\x03 \x01(\tR\x06nodeId\x12$\n" +
+	"\x0esince_event_id\x18\x04 \x01(\tR\fsinceEventId\x12\x16\n" +
+	"\x06follow\x18\x05 \x01(\bR\x06follow\"\x88\x02\n" +
+	"\bLogEntry\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12!\n" +
+	"\fcontainer_id\x18\x02 \x01(\tR\vcontainerId\x12!\n" +
+	"\fservice_name\x18\x03 \x01(\tR\vserviceName\x12\x17\n" +
+	"\anode_id\x18\x04 \x01(\tR\x06nodeId\x12\x18\n" +
+	"\areplica\x18\x05 \x01(\tR\areplica\x128\n" +
+	"\ttimestamp\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x16\n" +
+	"\x06stream\x18\a \x01(\tR\x06stream\x12\x12\n" +
+	"\x04line\x18\b \x01(\tR\x04line\"\xc0\x01\n" +
+	"\x18StreamServiceLogsRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x16\n" +
+	"\x06follow\x18\x02 \x01(\bR\x06follow\x120\n" +
+	"\x05since\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x12\x12\n" +
+	"\x04tail\x18\x04 \x01(\x05R\x04tail\x12#\n" +
+	"\rcontainer_ids\x18\x05 \x03(\tR\fcontainerIds\"2\n" +
+	"\x17WatchLogRequestsRequest\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\"\xc4\x01\n" +
+	"\n" +
+	"LogRequest\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12!\n" +
+	"\fcontainer_id\x18\x02 \x01(\tR\vcontainerId\x120\n" +
+	"\x05since\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x12\x12\n" +
+	"\x04tail\x18\x04 \x01(\x05R\x04tail\x12\x16\n" +
+	"\x06follow\x18\x05 \x01(\bR\x06follow\x12\x16\n" +
+	"\x06cancel\x18\x06 \x01(\bR\x06cancel\"\x1b\n" +
+	"\x19PushContainerLogsResponse\"J\n" +
 	"\x19RequestCertificateRequest\x12\x17\n" +
 	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\x14\n" +
 	"\x05token\x18\x02 \x01(\tR\x05token\"x\n" +
@@ -6584,22 +10194,33 @@ const file_api_proto_warren_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\"6\n" +
 	"\x1cDeleteTLSCertificateResponse\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status2\x94\x1a\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status2\xb8%\n" +
 	"\tWarrenAPI\x12O\n" +
 	"\fRegisterNode\x12\x1e.warren.v1.RegisterNodeRequest\x1a\x1f.warren.v1.RegisterNodeResponse\x12F\n" +
 	"\tHeartbeat\x12\x1b.warren.v1.HeartbeatRequest\x1a\x1c.warren.v1.HeartbeatResponse\x12F\n" +
 	"\tListNodes\x12\x1b.warren.v1.ListNodesRequest\x1a\x1c.warren.v1.ListNodesResponse\x12@\n" +
 	"\aGetNode\x12\x19.warren.v1.GetNodeRequest\x1a\x1a.warren.v1.GetNodeResponse\x12I\n" +
 	"\n" +
-	"RemoveNode\x12\x1c.warren.v1.RemoveNodeRequest\x1a\x1d.warren.v1.RemoveNodeResponse\x12R\n" +
+	"RemoveNode\x12\x1c.warren.v1.RemoveNodeRequest\x1a\x1d.warren.v1.RemoveNodeResponse\x12I\n" +
+	"\n" +
+	"UpdateNode\x12\x1c.warren.v1.UpdateNodeRequest\x1a\x1d.warren.v1.UpdateNodeResponse\x12R\n" +
 	"\rCreateService\x12\x1f.warren.v1.CreateServiceRequest\x1a .warren.v1.CreateServiceResponse\x12R\n" +
 	"\rUpdateService\x12\x1f.warren.v1.UpdateServiceRequest\x1a .warren.v1.UpdateServiceResponse\x12a\n" +
 	"\x12UpdateServiceImage\x12$.warren.v1.UpdateServiceImageRequest\x1a%.warren.v1.UpdateServiceImageResponse\x12X\n" +
-	"\x0fRollbackService\x12!.warren.v1.RollbackServiceRequest\x1a\".warren.v1.RollbackServiceResponse\x12R\n" +
+	"\x0fRollbackService\x12!.warren.v1.RollbackServiceRequest\x1a\".warren.v1.RollbackServiceResponse\x12g\n" +
+	"\x14ListServiceRevisions\x12&.warren.v1.ListServiceRevisionsRequest\x1a'.warren.v1.ListServiceRevisionsResponse\x12R\n" +
 	"\rDeleteService\x12\x1f.warren.v1.DeleteServiceRequest\x1a .warren.v1.DeleteServiceResponse\x12I\n" +
 	"\n" +
 	"GetService\x12\x1c.warren.v1.GetServiceRequest\x1a\x1d.warren.v1.GetServiceResponse\x12O\n" +
-	"\fListServices\x12\x1e.warren.v1.ListServicesRequest\x1a\x1f.warren.v1.ListServicesResponse\x12j\n" +
+	"\fListServices\x12\x1e.warren.v1.ListServicesRequest\x1a\x1f.warren.v1.ListServicesResponse\x12g\n" +
+	"\x14ListServiceEndpoints\x12&.warren.v1.ListServiceEndpointsRequest\x1a'.warren.v1.ListServiceEndpointsResponse\x12j\n" +
+	"\x15GetPlacementDecisions\x12'.warren.v1.GetPlacementDecisionsRequest\x1a(.warren.v1.GetPlacementDecisionsResponse\x12[\n" +
+	"\x10RebalanceService\x12\".warren.v1.RebalanceServiceRequest\x1a#.warren.v1.RebalanceServiceResponse\x12X\n" +
+	"\x0fListDeployments\x12!.warren.v1.ListDeploymentsRequest\x1a\".warren.v1.ListDeploymentsResponse\x12R\n" +
+	"\rGetDeployment\x12\x1f.warren.v1.GetDeploymentRequest\x1a .warren.v1.GetDeploymentResponse\x12X\n" +
+	"\x0fPauseDeployment\x12!.warren.v1.PauseDeploymentRequest\x1a\".warren.v1.PauseDeploymentResponse\x12[\n" +
+	"\x10ResumeDeployment\x12\".warren.v1.ResumeDeploymentRequest\x1a#.warren.v1.ResumeDeploymentResponse\x12X\n" +
+	"\x0fAbortDeployment\x12!.warren.v1.AbortDeploymentRequest\x1a\".warren.v1.AbortDeploymentResponse\x12j\n" +
 	"\x15UpdateContainerStatus\x12'.warren.v1.UpdateContainerStatusRequest\x1a(.warren.v1.UpdateContainerStatusResponse\x12U\n" +
 	"\x0eListContainers\x12 .warren.v1.ListContainersRequest\x1a!.warren.v1.ListContainersResponse\x12O\n" +
 	"\fGetContainer\x12\x1e.warren.v1.GetContainerRequest\x1a\x1f.warren.v1.GetContainerResponse\x12Q\n" +
@@ -6613,7 +10234,10 @@ const file_api_proto_warren_proto_rawDesc = "" +
 	"\x0fGetVolumeByName\x12!.warren.v1.GetVolumeByNameRequest\x1a\".warren.v1.GetVolumeByNameResponse\x12O\n" +
 	"\fDeleteVolume\x12\x1e.warren.v1.DeleteVolumeRequest\x1a\x1f.warren.v1.DeleteVolumeResponse\x12L\n" +
 	"\vListVolumes\x12\x1d.warren.v1.ListVolumesRequest\x1a\x1e.warren.v1.ListVolumesResponse\x12^\n" +
-	"\x11GenerateJoinToken\x12#.warren.v1.GenerateJoinTokenRequest\x1a$.warren.v1.GenerateJoinTokenResponse\x12L\n" +
+	"\x11GenerateJoinToken\x12#.warren.v1.GenerateJoinTokenRequest\x1a$.warren.v1.GenerateJoinTokenResponse\x12U\n" +
+	"\x0eListJoinTokens\x12 .warren.v1.ListJoinTokensRequest\x1a!.warren.v1.ListJoinTokensResponse\x12X\n" +
+	"\x0fRevokeJoinToken\x12!.warren.v1.RevokeJoinTokenRequest\x1a\".warren.v1.RevokeJoinTokenResponse\x12X\n" +
+	"\x0fRotateJoinToken\x12!.warren.v1.RotateJoinTokenRequest\x1a\".warren.v1.RotateJoinTokenResponse\x12L\n" +
 	"\vJoinCluster\x12\x1d.warren.v1.JoinClusterRequest\x1a\x1e.warren.v1.JoinClusterResponse\x12U\n" +
 	"\x0eGetClusterInfo\x12 .warren.v1.GetClusterInfoRequest\x1a!.warren.v1.GetClusterInfoResponse\x12a\n" +
 	"\x12RequestCertificate\x12$.warren.v1.RequestCertificateRequest\x1a%.warren.v1.RequestCertificateResponse\x12R\n" +
@@ -6627,7 +10251,10 @@ const file_api_proto_warren_proto_rawDesc = "" +
 	"\x11GetTLSCertificate\x12#.warren.v1.GetTLSCertificateRequest\x1a$.warren.v1.GetTLSCertificateResponse\x12d\n" +
 	"\x13ListTLSCertificates\x12%.warren.v1.ListTLSCertificatesRequest\x1a&.warren.v1.ListTLSCertificatesResponse\x12g\n" +
 	"\x14DeleteTLSCertificate\x12&.warren.v1.DeleteTLSCertificateRequest\x1a'.warren.v1.DeleteTLSCertificateResponse\x12B\n" +
-	"\fStreamEvents\x12\x1e.warren.v1.StreamEventsRequest\x1a\x10.warren.v1.Event0\x01B$Z\"github.com/cuemby/warren/api/protob\x06proto3"
+	"\fStreamEvents\x12\x1e.warren.v1.StreamEventsRequest\x1a\x10.warren.v1.Event0\x01\x12O\n" +
+	"\x11StreamServiceLogs\x12#.warren.v1.StreamServiceLogsRequest\x1a\x13.warren.v1.LogEntry0\x01\x12O\n" +
+	"\x10WatchLogRequests\x12\".warren.v1.WatchLogRequestsRequest\x1a\x15.warren.v1.LogRequest0\x01\x12P\n" +
+	"\x11PushContainerLogs\x12\x13.warren.v1.LogEntry\x1a$.warren.v1.PushContainerLogsResponse(\x01B$Z\"github.com/cuemby/warren/api/protob\x06proto3"
 
 var (
 	file_api_proto_warren_proto_rawDescOnce sync.Once
@@ -6642,306 +10269,453 @@ func file_api_proto_warren_proto_rawDescGZIP() []byte {
 }
 
 var file_api_proto_warren_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_api_proto_warren_proto_msgTypes = make([]protoimpl.MessageInfo, 118)
+var file_api_proto_warren_proto_msgTypes = make([]protoimpl.MessageInfo, 174)
 var file_api_proto_warren_proto_goTypes = []any{
 	(HealthCheck_Type)(0),                 // 0: warren.v1.HealthCheck.Type
 	(PortMapping_PublishMode)(0),          // 1: warren.v1.PortMapping.PublishMode
 	(*Node)(nil),                          // 2: warren.v1.Node
-	(*NodeResources)(nil),                 // 3: warren.v1.NodeResources
-	(*RegisterNodeRequest)(nil),           // 4: warren.v1.RegisterNodeRequest
-	(*RegisterNodeResponse)(nil),          // 5: warren.v1.RegisterNodeResponse
-	(*HeartbeatRequest)(nil),              // 6: warren.v1.HeartbeatRequest
-	(*HeartbeatResponse)(nil),             // 7: warren.v1.HeartbeatResponse
-	(*ContainerStatus)(nil),               // 8: warren.v1.ContainerStatus
-	(*ListNodesRequest)(nil),              // 9: warren.v1.ListNodesRequest
-	(*ListNodesResponse)(nil),             // 10: warren.v1.ListNodesResponse
-	(*GetNodeRequest)(nil),                // 11: warren.v1.GetNodeRequest
-	(*GetNodeResponse)(nil),               // 12: warren.v1.GetNodeResponse
-	(*RemoveNodeRequest)(nil),             // 13: warren.v1.RemoveNodeRequest
-	(*RemoveNodeResponse)(nil),            // 14: warren.v1.RemoveNodeResponse
-	(*Service)(nil),                       // 15: warren.v1.Service
-	(*UpdateConfig)(nil),                  // 16: warren.v1.UpdateConfig
-	(*HealthCheck)(nil),                   // 17: warren.v1.HealthCheck
-	(*HTTPHealthCheck)(nil),               // 18: warren.v1.HTTPHealthCheck
-	(*Header)(nil),                        // 19: warren.v1.Header
-	(*TCPHealthCheck)(nil),                // 20: warren.v1.TCPHealthCheck
-	(*ExecHealthCheck)(nil),               // 21: warren.v1.ExecHealthCheck
-	(*RestartPolicy)(nil),                 // 22: warren.v1.RestartPolicy
-	(*ResourceRequirements)(nil),          // 23: warren.v1.ResourceRequirements
-	(*VolumeMount)(nil),                   // 24: warren.v1.VolumeMount
-	(*PortMapping)(nil),                   // 25: warren.v1.PortMapping
-	(*CreateServiceRequest)(nil),          // 26: warren.v1.CreateServiceRequest
-	(*CreateServiceResponse)(nil),         // 27: warren.v1.CreateServiceResponse
-	(*UpdateServiceRequest)(nil),          // 28: warren.v1.UpdateServiceRequest
-	(*UpdateServiceResponse)(nil),         // 29: warren.v1.UpdateServiceResponse
-	(*UpdateServiceImageRequest)(nil),     // 30: warren.v1.UpdateServiceImageRequest
-	(*UpdateServiceImageResponse)(nil),    // 31: warren.v1.UpdateServiceImageResponse
-	(*RollbackServiceRequest)(nil),        // 32: warren.v1.RollbackServiceRequest
-	(*RollbackServiceResponse)(nil),       // 33: warren.v1.RollbackServiceResponse
-	(*DeleteServiceRequest)(nil),          // 34: warren.v1.DeleteServiceRequest
-	(*DeleteServiceResponse)(nil),         // 35: warren.v1.DeleteServiceResponse
-	(*GetServiceRequest)(nil),             // 36: warren.v1.GetServiceRequest
-	(*GetServiceResponse)(nil),            // 37: warren.v1.GetServiceResponse
-	(*ListServicesRequest)(nil),           // 38: warren.v1.ListServicesRequest
-	(*ListServicesResponse)(nil),          // 39: warren.v1.ListServicesResponse
-	(*Container)(nil),                     // 40: warren.v1.Container
-	(*UpdateContainerStatusRequest)(nil),  // 41: warren.v1.UpdateContainerStatusRequest
-	(*UpdateContainerStatusResponse)(nil), // 42: warren.v1.UpdateContainerStatusResponse
-	(*ListContainersRequest)(nil),         // 43: warren.v1.ListContainersRequest
-	(*ListContainersResponse)(nil),        // 44: warren.v1.ListContainersResponse
-	(*GetContainerRequest)(nil),           // 45: warren.v1.GetContainerRequest
-	(*GetContainerResponse)(nil),          // 46: warren.v1.GetContainerResponse
-	(*WatchContainersRequest)(nil),        // 47: warren.v1.WatchContainersRequest
-	(*ContainerEvent)(nil),                // 48: warren.v1.ContainerEvent
-	(*Secret)(nil),                        // 49: warren.v1.Secret
-	(*CreateSecretRequest)(nil),           // 50: warren.v1.CreateSecretRequest
-	(*CreateSecretResponse)(nil),          // 51: warren.v1.CreateSecretResponse
-	(*DeleteSecretRequest)(nil),           // 52: warren.v1.DeleteSecretRequest
-	(*DeleteSecretResponse)(nil),          // 53: warren.v1.DeleteSecretResponse
-	(*GetSecretByNameRequest)(nil),        // 54: warren.v1.GetSecretByNameRequest
-	(*GetSecretByNameResponse)(nil),       // 55: warren.v1.GetSecretByNameResponse
-	(*ListSecretsRequest)(nil),            // 56: warren.v1.ListSecretsRequest
-	(*ListSecretsResponse)(nil),           // 57: warren.v1.ListSecretsResponse
-	(*Volume)(nil),                        // 58: warren.v1.Volume
-	(*CreateVolumeRequest)(nil),           // 59: warren.v1.CreateVolumeRequest
-	(*CreateVolumeResponse)(nil),          // 60: warren.v1.CreateVolumeResponse
-	(*DeleteVolumeRequest)(nil),           // 61: warren.v1.DeleteVolumeRequest
-	(*DeleteVolumeResponse)(nil),          // 62: warren.v1.DeleteVolumeResponse
-	(*GetVolumeByNameRequest)(nil),        // 63: warren.v1.GetVolumeByNameRequest
-	(*GetVolumeByNameResponse)(nil),       // 64: warren.v1.GetVolumeByNameResponse
-	(*ListVolumesRequest)(nil),            // 65: warren.v1.ListVolumesRequest
-	(*ListVolumesResponse)(nil),           // 66: warren.v1.ListVolumesResponse
-	(*GenerateJoinTokenRequest)(nil),      // 67: warren.v1.GenerateJoinTokenRequest
-	(*GenerateJoinTokenResponse)(nil),     // 68: warren.v1.GenerateJoinTokenResponse
-	(*JoinClusterRequest)(nil),            // 69: warren.v1.JoinClusterRequest
-	(*JoinClusterResponse)(nil),           // 70: warren.v1.JoinClusterResponse
-	(*GetClusterInfoRequest)(nil),         // 71: warren.v1.GetClusterInfoRequest
-	(*GetClusterInfoResponse)(nil),        // 72: warren.v1.GetClusterInfoResponse
-	(*ClusterServer)(nil),                 // 73: warren.v1.ClusterServer
-	(*ReportContainerHealthRequest)(nil),  // 74: warren.v1.ReportContainerHealthRequest
-	(*ReportContainerHealthResponse)(nil), // 75: warren.v1.ReportContainerHealthResponse
-	(*Event)(nil),                         // 76: warren.v1.Event
-	(*StreamEventsRequest)(nil),           // 77: warren.v1.StreamEventsRequest
-	(*RequestCertificateRequest)(nil),     // 78: warren.v1.RequestCertificateRequest
-	(*RequestCertificateResponse)(nil),    // 79: warren.v1.RequestCertificateResponse
-	(*Ingress)(nil),                       // 80: warren.v1.Ingress
-	(*IngressRule)(nil),                   // 81: warren.v1.IngressRule
-	(*IngressPath)(nil),                   // 82: warren.v1.IngressPath
-	(*IngressBackend)(nil),                // 83: warren.v1.IngressBackend
-	(*IngressTLS)(nil),                    // 84: warren.v1.IngressTLS
-	(*CreateIngressRequest)(nil),          // 85: warren.v1.CreateIngressRequest
-	(*CreateIngressResponse)(nil),         // 86: warren.v1.CreateIngressResponse
-	(*UpdateIngressRequest)(nil),          // 87: warren.v1.UpdateIngressRequest
-	(*UpdateIngressResponse)(nil),         // 88: warren.v1.UpdateIngressResponse
-	(*DeleteIngressRequest)(nil),          // 89: warren.v1.DeleteIngressRequest
-	(*DeleteIngressResponse)(nil),         // 90: warren.v1.DeleteIngressResponse
-	(*GetIngressRequest)(nil),             // 91: warren.v1.GetIngressRequest
-	(*GetIngressResponse)(nil),            // 92: warren.v1.GetIngressResponse
-	(*ListIngressesRequest)(nil),          // 93: warren.v1.ListIngressesRequest
-	(*ListIngressesResponse)(nil),         // 94: warren.v1.ListIngressesResponse
-	(*TLSCertificate)(nil),                // 95: warren.v1.TLSCertificate
-	(*CreateTLSCertificateRequest)(nil),   // 96: warren.v1.CreateTLSCertificateRequest
-	(*CreateTLSCertificateResponse)(nil),  // 97: warren.v1.CreateTLSCertificateResponse
-	(*GetTLSCertificateRequest)(nil),      // 98: warren.v1.GetTLSCertificateRequest
-	(*GetTLSCertificateResponse)(nil),     // 99: warren.v1.GetTLSCertificateResponse
-	(*ListTLSCertificatesRequest)(nil),    // 100: warren.v1.ListTLSCertificatesRequest
-	(*ListTLSCertificatesResponse)(nil),   // 101: warren.v1.ListTLSCertificatesResponse
-	(*DeleteTLSCertificateRequest)(nil),   // 102: warren.v1.DeleteTLSCertificateRequest
-	(*DeleteTLSCertificateResponse)(nil),  // 103: warren.v1.DeleteTLSCertificateResponse
-	nil,                                   // 104: warren.v1.Node.LabelsEntry
-	nil,                                   // 105: warren.v1.RegisterNodeRequest.LabelsEntry
-	nil,                                   // 106: warren.v1.Service.EnvEntry
-	nil,                                   // 107: warren.v1.CreateServiceRequest.EnvEntry
-	nil,                                   // 108: warren.v1.UpdateServiceRequest.EnvEntry
-	nil,                                   // 109: warren.v1.Container.EnvEntry
-	nil,                                   // 110: warren.v1.Volume.DriverOptsEntry
-	nil,                                   // 111: warren.v1.Volume.LabelsEntry
-	nil,                                   // 112: warren.v1.CreateVolumeRequest.DriverOptsEntry
-	nil,                                   // 113: warren.v1.CreateVolumeRequest.LabelsEntry
-	nil,                                   // 114: warren.v1.Event.MetadataEntry
-	nil,                                   // 115: warren.v1.Ingress.LabelsEntry
-	nil,                                   // 116: warren.v1.CreateIngressRequest.LabelsEntry
-	nil,                                   // 117: warren.v1.UpdateIngressRequest.LabelsEntry
-	nil,                                   // 118: warren.v1.TLSCertificate.LabelsEntry
-	nil,                                   // 119: warren.v1.CreateTLSCertificateRequest.LabelsEntry
-	(*timestamppb.Timestamp)(nil),         // 120: google.protobuf.Timestamp
+	(*Taint)(nil),                         // 3: warren.v1.Taint
+	(*NodeResources)(nil),                 // 4: warren.v1.NodeResources
+	(*RegisterNodeRequest)(nil),           // 5: warren.v1.RegisterNodeRequest
+	(*RegisterNodeResponse)(nil),          // 6: warren.v1.RegisterNodeResponse
+	(*HeartbeatRequest)(nil),              // 7: warren.v1.HeartbeatRequest
+	(*HeartbeatResponse)(nil),             // 8: warren.v1.HeartbeatResponse
+	(*ContainerStatus)(nil),               // 9: warren.v1.ContainerStatus
+	(*ListNodesRequest)(nil),              // 10: warren.v1.ListNodesRequest
+	(*ListNodesResponse)(nil),             // 11: warren.v1.ListNodesResponse
+	(*GetNodeRequest)(nil),                // 12: warren.v1.GetNodeRequest
+	(*GetNodeResponse)(nil),               // 13: warren.v1.GetNodeResponse
+	(*RemoveNodeRequest)(nil),             // 14: warren.v1.RemoveNodeRequest
+	(*RemoveNodeResponse)(nil),            // 15: warren.v1.RemoveNodeResponse
+	(*UpdateNodeRequest)(nil),             // 16: warren.v1.UpdateNodeRequest
+	(*UpdateNodeResponse)(nil),            // 17: warren.v1.UpdateNodeResponse
+	(*Service)(nil),                       // 18: warren.v1.Service
+	(*JobConfig)(nil),                     // 19: warren.v1.JobConfig
+	(*JobStatus)(nil),                     // 20: warren.v1.JobStatus
+	(*CronJobConfig)(nil),                 // 21: warren.v1.CronJobConfig
+	(*Placement)(nil),                     // 22: warren.v1.Placement
+	(*PlacementPreference)(nil),           // 23: warren.v1.PlacementPreference
+	(*Affinity)(nil),                      // 24: warren.v1.Affinity
+	(*AffinityTerm)(nil),                  // 25: warren.v1.AffinityTerm
+	(*Toleration)(nil),                    // 26: warren.v1.Toleration
+	(*UpdateConfig)(nil),                  // 27: warren.v1.UpdateConfig
+	(*TrafficSummary)(nil),                // 28: warren.v1.TrafficSummary
+	(*CanaryAnalysis)(nil),                // 29: warren.v1.CanaryAnalysis
+	(*HealthCheck)(nil),                   // 30: warren.v1.HealthCheck
+	(*HTTPHealthCheck)(nil),               // 31: warren.v1.HTTPHealthCheck
+	(*Header)(nil),                        // 32: warren.v1.Header
+	(*TCPHealthCheck)(nil),                // 33: warren.v1.TCPHealthCheck
+	(*ExecHealthCheck)(nil),               // 34: warren.v1.ExecHealthCheck
+	(*RestartPolicy)(nil),                 // 35: warren.v1.RestartPolicy
+	(*ResourceRequirements)(nil),          // 36: warren.v1.ResourceRequirements
+	(*VolumeMount)(nil),                   // 37: warren.v1.VolumeMount
+	(*PortMapping)(nil),                   // 38: warren.v1.PortMapping
+	(*CreateServiceRequest)(nil),          // 39: warren.v1.CreateServiceRequest
+	(*CreateServiceResponse)(nil),         // 40: warren.v1.CreateServiceResponse
+	(*UpdateServiceRequest)(nil),          // 41: warren.v1.UpdateServiceRequest
+	(*UpdateServiceResponse)(nil),         // 42: warren.v1.UpdateServiceResponse
+	(*UpdateServiceImageRequest)(nil),     // 43: warren.v1.UpdateServiceImageRequest
+	(*UpdateServiceImageResponse)(nil),    // 44: warren.v1.UpdateServiceImageResponse
+	(*RollbackServiceRequest)(nil),        // 45: warren.v1.RollbackServiceRequest
+	(*RollbackServiceResponse)(nil),       // 46: warren.v1.RollbackServiceResponse
+	(*ServiceRevision)(nil),               // 47: warren.v1.ServiceRevision
+	(*ListServiceRevisionsRequest)(nil),   // 48: warren.v1.ListServiceRevisionsRequest
+	(*ListServiceRevisionsResponse)(nil),  // 49: warren.v1.ListServiceRevisionsResponse
+	(*DeleteServiceRequest)(nil),          // 50: warren.v1.DeleteServiceRequest
+	(*DeleteServiceResponse)(nil),         // 51: warren.v1.DeleteServiceResponse
+	(*GetServiceRequest)(nil),             // 52: warren.v1.GetServiceRequest
+	(*GetServiceResponse)(nil),            // 53: warren.v1.GetServiceResponse
+	(*ListServicesRequest)(nil),           // 54: warren.v1.ListServicesRequest
+	(*ListServicesResponse)(nil),          // 55: warren.v1.ListServicesResponse
+	(*ListServiceEndpointsRequest)(nil),   // 56: warren.v1.ListServiceEndpointsRequest
+	(*ListServiceEndpointsResponse)(nil),  // 57: warren.v1.ListServiceEndpointsResponse
+	(*GetPlacementDecisionsRequest)(nil),  // 58: warren.v1.GetPlacementDecisionsRequest
+	(*GetPlacementDecisionsResponse)(nil), // 59: warren.v1.GetPlacementDecisionsResponse
+	(*RebalanceServiceRequest)(nil),       // 60: warren.v1.RebalanceServiceRequest
+	(*RebalanceServiceResponse)(nil),      // 61: warren.v1.RebalanceServiceResponse
+	(*Deployment)(nil),                    // 62: warren.v1.Deployment
+	(*ListDeploymentsRequest)(nil),        // 63: warren.v1.ListDeploymentsRequest
+	(*ListDeploymentsResponse)(nil),       // 64: warren.v1.ListDeploymentsResponse
+	(*GetDeploymentRequest)(nil),          // 65: warren.v1.GetDeploymentRequest
+	(*GetDeploymentResponse)(nil),         // 66: warren.v1.GetDeploymentResponse
+	(*PauseDeploymentRequest)(nil),        // 67: warren.v1.PauseDeploymentRequest
+	(*PauseDeploymentResponse)(nil),       // 68: warren.v1.PauseDeploymentResponse
+	(*ResumeDeploymentRequest)(nil),       // 69: warren.v1.ResumeDeploymentRequest
+	(*ResumeDeploymentResponse)(nil),      // 70: warren.v1.ResumeDeploymentResponse
+	(*AbortDeploymentRequest)(nil),        // 71: warren.v1.AbortDeploymentRequest
+	(*AbortDeploymentResponse)(nil),       // 72: warren.v1.AbortDeploymentResponse
+	(*PlacementDecision)(nil),             // 73: warren.v1.PlacementDecision
+	(*NodeEvaluation)(nil),                // 74: warren.v1.NodeEvaluation
+	(*ServiceEndpoints)(nil),              // 75: warren.v1.ServiceEndpoints
+	(*ServicePortEndpoints)(nil),          // 76: warren.v1.ServicePortEndpoints
+	(*ServiceEndpoint)(nil),               // 77: warren.v1.ServiceEndpoint
+	(*Container)(nil),                     // 78: warren.v1.Container
+	(*UpdateContainerStatusRequest)(nil),  // 79: warren.v1.UpdateContainerStatusRequest
+	(*UpdateContainerStatusResponse)(nil), // 80: warren.v1.UpdateContainerStatusResponse
+	(*ListContainersRequest)(nil),         // 81: warren.v1.ListContainersRequest
+	(*ListContainersResponse)(nil),        // 82: warren.v1.ListContainersResponse
+	(*GetContainerRequest)(nil),           // 83: warren.v1.GetContainerRequest
+	(*GetContainerResponse)(nil),          // 84: warren.v1.GetContainerResponse
+	(*WatchContainersRequest)(nil),        // 85: warren.v1.WatchContainersRequest
+	(*ContainerEvent)(nil),                // 86: warren.v1.ContainerEvent
+	(*Secret)(nil),                        // 87: warren.v1.Secret
+	(*CreateSecretRequest)(nil),           // 88: warren.v1.CreateSecretRequest
+	(*CreateSecretResponse)(nil),          // 89: warren.v1.CreateSecretResponse
+	(*DeleteSecretRequest)(nil),           // 90: warren.v1.DeleteSecretRequest
+	(*DeleteSecretResponse)(nil),          // 91: warren.v1.DeleteSecretResponse
+	(*GetSecretByNameRequest)(nil),        // 92: warren.v1.GetSecretByNameRequest
+	(*GetSecretByNameResponse)(nil),       // 93: warren.v1.GetSecretByNameResponse
+	(*ListSecretsRequest)(nil),            // 94: warren.v1.ListSecretsRequest
+	(*ListSecretsResponse)(nil),           // 95: warren.v1.ListSecretsResponse
+	(*Volume)(nil),                        // 96: warren.v1.Volume
+	(*CreateVolumeRequest)(nil),           // 97: warren.v1.CreateVolumeRequest
+	(*CreateVolumeResponse)(nil),          // 98: warren.v1.CreateVolumeResponse
+	(*DeleteVolumeRequest)(nil),           // 99: warren.v1.DeleteVolumeRequest
+	(*DeleteVolumeResponse)(nil),          // 100: warren.v1.DeleteVolumeResponse
+	(*GetVolumeByNameRequest)(nil),        // 101: warren.v1.GetVolumeByNameRequest
+	(*GetVolumeByNameResponse)(nil),       // 102: warren.v1.GetVolumeByNameResponse
+	(*ListVolumesRequest)(nil),            // 103: warren.v1.ListVolumesRequest
+	(*ListVolumesResponse)(nil),           // 104: warren.v1.ListVolumesResponse
+	(*GenerateJoinTokenRequest)(nil),      // 105: warren.v1.GenerateJoinTokenRequest
+	(*GenerateJoinTokenResponse)(nil),     // 106: warren.v1.GenerateJoinTokenResponse
+	(*JoinToken)(nil),                     // 107: warren.v1.JoinToken
+	(*ListJoinTokensRequest)(nil),         // 108: warren.v1.ListJoinTokensRequest
+	(*ListJoinTokensResponse)(nil),        // 109: warren.v1.ListJoinTokensResponse
+	(*RevokeJoinTokenRequest)(nil),        // 110: warren.v1.RevokeJoinTokenRequest
+	(*RevokeJoinTokenResponse)(nil),       // 111: warren.v1.RevokeJoinTokenResponse
+	(*RotateJoinTokenRequest)(nil),        // 112: warren.v1.RotateJoinTokenRequest
+	(*RotateJoinTokenResponse)(nil),       // 113: warren.v1.RotateJoinTokenResponse
+	(*JoinClusterRequest)(nil),            // 114: warren.v1.JoinClusterRequest
+	(*JoinClusterResponse)(nil),           // 115: warren.v1.JoinClusterResponse
+	(*GetClusterInfoRequest)(nil),         // 116: warren.v1.GetClusterInfoRequest
+	(*GetClusterInfoResponse)(nil),        // 117: warren.v1.GetClusterInfoResponse
+	(*ClusterServer)(nil),                 // 118: warren.v1.ClusterServer
+	(*ReportContainerHealthRequest)(nil),  // 119: warren.v1.ReportContainerHealthRequest
+	(*ReportContainerHealthResponse)(nil), // 120: warren.v1.ReportContainerHealthResponse
+	(*Event)(nil),                         // 121: warren.v1.Event
+	(*StreamEventsRequest)(nil),           // 122: warren.v1.StreamEventsRequest
+	(*LogEntry)(nil),                      // 123: warren.v1.LogEntry
+	(*StreamServiceLogsRequest)(nil),      // 124: warren.v1.StreamServiceLogsRequest
+	(*WatchLogRequestsRequest)(nil),       // 125: warren.v1.WatchLogRequestsRequest
+	(*LogRequest)(nil),                    // 126: warren.v1.LogRequest
+	(*PushContainerLogsResponse)(nil),     // 127: warren.v1.PushContainerLogsResponse
+	(*RequestCertificateRequest)(nil),     // 128: warren.v1.RequestCertificateRequest
+	(*RequestCertificateResponse)(nil),    // 129: warren.v1.RequestCertificateResponse
+	(*Ingress)(nil),                       // 130: warren.v1.Ingress
+	(*IngressRule)(nil),                   // 131: warren.v1.IngressRule
+	(*IngressPath)(nil),                   // 132: warren.v1.IngressPath
+	(*IngressBackend)(nil),                // 133: warren.v1.IngressBackend
+	(*IngressTLS)(nil),                    // 134: warren.v1.IngressTLS
+	(*CreateIngressRequest)(nil),          // 135: warren.v1.CreateIngressRequest
+	(*CreateIngressResponse)(nil),         // 136: warren.v1.CreateIngressResponse
+	(*UpdateIngressRequest)(nil),          // 137: warren.v1.UpdateIngressRequest
+	(*UpdateIngressResponse)(nil),         // 138: warren.v1.UpdateIngressResponse
+	(*DeleteIngressRequest)(nil),          // 139: warren.v1.DeleteIngressRequest
+	(*DeleteIngressResponse)(nil),         // 140: warren.v1.DeleteIngressResponse
+	(*GetIngressRequest)(nil),             // 141: warren.v1.GetIngressRequest
+	(*GetIngressResponse)(nil),            // 142: warren.v1.GetIngressResponse
+	(*ListIngressesRequest)(nil),          // 143: warren.v1.ListIngressesRequest
+	(*ListIngressesResponse)(nil),         // 144: warren.v1.ListIngressesResponse
+	(*TLSCertificate)(nil),                // 145: warren.v1.TLSCertificate
+	(*CreateTLSCertificateRequest)(nil),   // 146: warren.v1.CreateTLSCertificateRequest
+	(*CreateTLSCertificateResponse)(nil),  // 147: warren.v1.CreateTLSCertificateResponse
+	(*GetTLSCertificateRequest)(nil),      // 148: warren.v1.GetTLSCertificateRequest
+	(*GetTLSCertificateResponse)(nil),     // 149: warren.v1.GetTLSCertificateResponse
+	(*ListTLSCertificatesRequest)(nil),    // 150: warren.v1.ListTLSCertificatesRequest
+	(*ListTLSCertificatesResponse)(nil),   // 151: warren.v1.ListTLSCertificatesResponse
+	(*DeleteTLSCertificateRequest)(nil),   // 152: warren.v1.DeleteTLSCertificateRequest
+	(*DeleteTLSCertificateResponse)(nil),  // 153: warren.v1.DeleteTLSCertificateResponse
+	nil,                                   // 154: warren.v1.Node.LabelsEntry
+	nil,                                   // 155: warren.v1.RegisterNodeRequest.LabelsEntry
+	nil,                                   // 156: warren.v1.UpdateNodeRequest.LabelAddEntry
+	nil,                                   // 157: warren.v1.Service.EnvEntry
+	nil,                                   // 158: warren.v1.Service.LabelsEntry
+	nil,                                   // 159: warren.v1.AffinityTerm.LabelsEntry
+	nil,                                   // 160: warren.v1.CreateServiceRequest.EnvEntry
+	nil,                                   // 161: warren.v1.CreateServiceRequest.LabelsEntry
+	nil,                                   // 162: warren.v1.UpdateServiceRequest.EnvEntry
+	nil,                                   // 163: warren.v1.UpdateServiceImageRequest.EnvEntry
+	nil,                                   // 164: warren.v1.NodeEvaluation.ScoresEntry
+	nil,                                   // 165: warren.v1.Container.EnvEntry
+	nil,                                   // 166: warren.v1.Volume.DriverOptsEntry
+	nil,                                   // 167: warren.v1.Volume.LabelsEntry
+	nil,                                   // 168: warren.v1.CreateVolumeRequest.DriverOptsEntry
+	nil,                                   // 169: warren.v1.CreateVolumeRequest.LabelsEntry
+	nil,                                   // 170: warren.v1.Event.MetadataEntry
+	nil,                                   // 171: warren.v1.Ingress.LabelsEntry
+	nil,                                   // 172: warren.v1.CreateIngressRequest.LabelsEntry
+	nil,                                   // 173: warren.v1.UpdateIngressRequest.LabelsEntry
+	nil,                                   // 174: warren.v1.TLSCertificate.LabelsEntry
+	nil,                                   // 175: warren.v1.CreateTLSCertificateRequest.LabelsEntry
+	(*timestamppb.Timestamp)(nil),         // 176: google.protobuf.Timestamp
 }
 var file_api_proto_warren_proto_depIdxs = []int32{
-	3,   // 0: warren.v1.Node.resources:type_name -> warren.v1.NodeResources
-	120, // 1: warren.v1.Node.last_heartbeat:type_name -> google.protobuf.Timestamp
-	120, // 2: warren.v1.Node.created_at:type_name -> google.protobuf.Timestamp
-	104, // 3: warren.v1.Node.labels:type_name -> warren.v1.Node.LabelsEntry
-	3,   // 4: warren.v1.RegisterNodeRequest.resources:type_name -> warren.v1.NodeResources
-	105, // 5: warren.v1.RegisterNodeRequest.labels:type_name -> warren.v1.RegisterNodeRequest.LabelsEntry
-	2,   // 6: warren.v1.RegisterNodeResponse.node:type_name -> warren.v1.Node
-	3,   // 7: warren.v1.HeartbeatRequest.available_resources:type_name -> warren.v1.NodeResources
-	8,   // 8: warren.v1.HeartbeatRequest.container_statuses:type_name -> warren.v1.ContainerStatus
-	2,   // 9: warren.v1.ListNodesResponse.nodes:type_name -> warren.v1.Node
-	2,   // 10: warren.v1.GetNodeResponse.node:type_name -> warren.v1.Node
-	16,  // 11: warren.v1.Service.update_config:type_name -> warren.v1.UpdateConfig
-	17,  // 12: warren.v1.Service.health_check:type_name -> warren.v1.HealthCheck
-	22,  // 13: warren.v1.Service.restart_policy:type_name -> warren.v1.RestartPolicy
-	23,  // 14: warren.v1.Service.resources:type_name -> warren.v1.ResourceRequirements
-	24,  // 15: warren.v1.Service.volumes:type_name -> warren.v1.VolumeMount
-	106, // 16: warren.v1.Service.env:type_name -> warren.v1.Service.EnvEntry
-	120, // 17: warren.v1.Service.created_at:type_name -> google.protobuf.Timestamp
-	120, // 18: warren.v1.Service.updated_at:type_name -> google.protobuf.Timestamp
-	25,  // 19: warren.v1.Service.ports:type_name -> warren.v1.PortMapping
-	0,   // 20: warren.v1.HealthCheck.type:type_name -> warren.v1.HealthCheck.Type
-	18,  // 21: warren.v1.HealthCheck.http:type_name -> warren.v1.HTTPHealthCheck
-	20,  // 22: warren.v1.HealthCheck.tcp:type_name -> warren.v1.TCPHealthCheck
-	21,  // 23: warren.v1.HealthCheck.exec:type_name -> warren.v1.ExecHealthCheck
-	19,  // 24: warren.v1.HTTPHealthCheck.headers:type_name -> warren.v1.Header
-	1,   // 25: warren.v1.PortMapping.publish_mode:type_name -> warren.v1.PortMapping.PublishMode
-	16,  // 26: warren.v1.CreateServiceRequest.update_config:type_name -> warren.v1.UpdateConfig
-	17,  // 27: warren.v1.CreateServiceRequest.health_check:type_name -> warren.v1.HealthCheck
-	22,  // 28: warren.v1.CreateServiceRequest.restart_policy:type_name -> warren.v1.RestartPolicy
-	23,  // 29: warren.v1.CreateServiceRequest.resources:type_name -> warren.v1.ResourceRequirements
-	24,  // 30: warren.v1.CreateServiceRequest.volumes:type_name -> warren.v1.VolumeMount
-	107, // 31: warren.v1.CreateServiceRequest.env:type_name -> warren.v1.CreateServiceRequest.EnvEntry
-	25,  // 32: warren.v1.CreateServiceRequest.ports:type_name -> warren.v1.PortMapping
-	15,  // 33: warren.v1.CreateServiceResponse.service:type_name -> warren.v1.Service
-	108, // 34: warren.v1.UpdateServiceRequest.env:type_name -> warren.v1.UpdateServiceRequest.EnvEntry
-	15,  // 35: warren.v1.UpdateServiceResponse.service:type_name -> warren.v1.Service
-	16,  // 36: warren.v1.UpdateServiceImageRequest.update_config:type_name -> warren.v1.UpdateConfig
-	15,  // 37: warren.v1.GetServiceResponse.service:type_name -> warren.v1.Service
-	15,  // 38: warren.v1.ListServicesResponse.services:type_name -> warren.v1.Service
-	109, // 39: warren.v1.Container.env:type_name -> warren.v1.Container.EnvEntry
-	23,  // 40: warren.v1.Container.resources:type_name -> warren.v1.ResourceRequirements
-	24,  // 41: warren.v1.Container.volumes:type_name -> warren.v1.VolumeMount
-	17,  // 42: warren.v1.Container.health_check:type_name -> warren.v1.HealthCheck
-	22,  // 43: warren.v1.Container.restart_policy:type_name -> warren.v1.RestartPolicy
-	120, // 44: warren.v1.Container.created_at:type_name -> google.protobuf.Timestamp
-	120, // 45: warren.v1.Container.updated_at:type_name -> google.protobuf.Timestamp
-	40,  // 46: warren.v1.ListContainersResponse.containers:type_name -> warren.v1.Container
-	40,  // 47: warren.v1.GetContainerResponse.container:type_name -> warren.v1.Container
-	40,  // 48: warren.v1.ContainerEvent.container:type_name -> warren.v1.Container
-	120, // 49: warren.v1.Secret.created_at:type_name -> google.protobuf.Timestamp
-	49,  // 50: warren.v1.CreateSecretResponse.secret:type_name -> warren.v1.Secret
-	49,  // 51: warren.v1.GetSecretByNameResponse.secret:type_name -> warren.v1.Secret
-	49,  // 52: warren.v1.ListSecretsResponse.secrets:type_name -> warren.v1.Secret
-	110, // 53: warren.v1.Volume.driver_opts:type_name -> warren.v1.Volume.DriverOptsEntry
-	111, // 54: warren.v1.Volume.labels:type_name -> warren.v1.Volume.LabelsEntry
-	120, // 55: warren.v1.Volume.created_at:type_name -> google.protobuf.Timestamp
-	112, // 56: warren.v1.CreateVolumeRequest.driver_opts:type_name -> warren.v1.CreateVolumeRequest.DriverOptsEntry
-	113, // 57: warren.v1.CreateVolumeRequest.labels:type_name -> warren.v1.CreateVolumeRequest.LabelsEntry
-	58,  // 58: warren.v1.CreateVolumeResponse.volume:type_name -> warren.v1.Volume
-	58,  // 59: warren.v1.GetVolumeByNameResponse.volume:type_name -> warren.v1.Volume
-	58,  // 60: warren.v1.ListVolumesResponse.volumes:type_name -> warren.v1.Volume
-	120, // 61: warren.v1.GenerateJoinTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
-	73,  // 62: warren.v1.GetClusterInfoResponse.servers:type_name -> warren.v1.ClusterServer
-	120, // 63: warren.v1.ReportContainerHealthRequest.checked_at:type_name -> google.protobuf.Timestamp
-	120, // 64: warren.v1.Event.timestamp:type_name -> google.protobuf.Timestamp
-	114, // 65: warren.v1.Event.metadata:type_name -> warren.v1.Event.MetadataEntry
-	81,  // 66: warren.v1.Ingress.rules:type_name -> warren.v1.IngressRule
-	84,  // 67: warren.v1.Ingress.tls:type_name -> warren.v1.IngressTLS
-	115, // 68: warren.v1.Ingress.labels:type_name -> warren.v1.Ingress.LabelsEntry
-	120, // 69: warren.v1.Ingress.created_at:type_name -> google.protobuf.Timestamp
-	120, // 70: warren.v1.Ingress.updated_at:type_name -> google.protobuf.Timestamp
-	82,  // 71: warren.v1.IngressRule.paths:type_name -> warren.v1.IngressPath
-	83,  // 72: warren.v1.IngressPath.backend:type_name -> warren.v1.IngressBackend
-	81,  // 73: warren.v1.CreateIngressRequest.rules:type_name -> warren.v1.IngressRule
-	84,  // 74: warren.v1.CreateIngressRequest.tls:type_name -> warren.v1.IngressTLS
-	116, // 75: warren.v1.CreateIngressRequest.labels:type_name -> warren.v1.CreateIngressRequest.LabelsEntry
-	80,  // 76: warren.v1.CreateIngressResponse.ingress:type_name -> warren.v1.Ingress
-	81,  // 77: warren.v1.UpdateIngressRequest.rules:type_name -> warren.v1.IngressRule
-	84,  // 78: warren.v1.UpdateIngressRequest.tls:type_name -> warren.v1.IngressTLS
-	117, // 79: warren.v1.UpdateIngressRequest.labels:type_name -> warren.v1.UpdateIngressRequest.LabelsEntry
-	80,  // 80: warren.v1.UpdateIngressResponse.ingress:type_name -> warren.v1.Ingress
-	80,  // 81: warren.v1.GetIngressResponse.ingress:type_name -> warren.v1.Ingress
-	80,  // 82: warren.v1.ListIngressesResponse.ingresses:type_name -> warren.v1.Ingress
-	120, // 83: warren.v1.TLSCertificate.not_before:type_name -> google.protobuf.Timestamp
-	120, // 84: warren.v1.TLSCertificate.not_after:type_name -> google.protobuf.Timestamp
-	118, // 85: warren.v1.TLSCertificate.labels:type_name -> warren.v1.TLSCertificate.LabelsEntry
-	120, // 86: warren.v1.TLSCertificate.created_at:type_name -> google.protobuf.Timestamp
-	120, // 87: warren.v1.TLSCertificate.updated_at:type_name -> google.protobuf.Timestamp
-	119, // 88: warren.v1.CreateTLSCertificateRequest.labels:type_name -> warren.v1.CreateTLSCertificateRequest.LabelsEntry
-	95,  // 89: warren.v1.CreateTLSCertificateResponse.certificate:type_name -> warren.v1.TLSCertificate
-	95,  // 90: warren.v1.GetTLSCertificateResponse.certificate:type_name -> warren.v1.TLSCertificate
-	95,  // 91: warren.v1.ListTLSCertificatesResponse.certificates:type_name -> warren.v1.TLSCertificate
-	4,   // 92: warren.v1.WarrenAPI.RegisterNode:input_type -> warren.v1.RegisterNodeRequest
-	6,   // 93: warren.v1.WarrenAPI.Heartbeat:input_type -> warren.v1.HeartbeatRequest
-	9,   // 94: warren.v1.WarrenAPI.ListNodes:input_type -> warren.v1.ListNodesRequest
-	11,  // 95: warren.v1.WarrenAPI.GetNode:input_type -> warren.v1.GetNodeRequest
-	13,  // 96: warren.v1.WarrenAPI.RemoveNode:input_type -> warren.v1.RemoveNodeRequest
-	26,  // 97: warren.v1.WarrenAPI.CreateService:input_type -> warren.v1.CreateServiceRequest
-	28,  // 98: warren.v1.WarrenAPI.UpdateService:input_type -> warren.v1.UpdateServiceRequest
-	30,  // 99: warren.v1.WarrenAPI.UpdateServiceImage:input_type -> warren.v1.UpdateServiceImageRequest
-	32,  // 100: warren.v1.WarrenAPI.RollbackService:input_type -> warren.v1.RollbackServiceRequest
-	34,  // 101: warren.v1.WarrenAPI.DeleteService:input_type -> warren.v1.DeleteServiceRequest
-	36,  // 102: warren.v1.WarrenAPI.GetService:input_type -> warren.v1.GetServiceRequest
-	38,  // 103: warren.v1.WarrenAPI.ListServices:input_type -> warren.v1.ListServicesRequest
-	41,  // 104: warren.v1.WarrenAPI.UpdateContainerStatus:input_type -> warren.v1.UpdateContainerStatusRequest
-	43,  // 105: warren.v1.WarrenAPI.ListContainers:input_type -> warren.v1.ListContainersRequest
-	45,  // 106: warren.v1.WarrenAPI.GetContainer:input_type -> warren.v1.GetContainerRequest
-	47,  // 107: warren.v1.WarrenAPI.WatchContainers:input_type -> warren.v1.WatchContainersRequest
-	74,  // 108: warren.v1.WarrenAPI.ReportContainerHealth:input_type -> warren.v1.ReportContainerHealthRequest
-	50,  // 109: warren.v1.WarrenAPI.CreateSecret:input_type -> warren.v1.CreateSecretRequest
-	54,  // 110: warren.v1.WarrenAPI.GetSecretByName:input_type -> warren.v1.GetSecretByNameRequest
-	52,  // 111: warren.v1.WarrenAPI.DeleteSecret:input_type -> warren.v1.DeleteSecretRequest
-	56,  // 112: warren.v1.WarrenAPI.ListSecrets:input_type -> warren.v1.ListSecretsRequest
-	59,  // 113: warren.v1.WarrenAPI.CreateVolume:input_type -> warren.v1.CreateVolumeRequest
-	63,  // 114: warren.v1.WarrenAPI.GetVolumeByName:input_type -> warren.v1.GetVolumeByNameRequest
-	61,  // 115: warren.v1.WarrenAPI.DeleteVolume:input_type -> warren.v1.DeleteVolumeRequest
-	65,  // 116: warren.v1.WarrenAPI.ListVolumes:input_type -> warren.v1.ListVolumesRequest
-	67,  // 117: warren.v1.WarrenAPI.GenerateJoinToken:input_type -> warren.v1.GenerateJoinTokenRequest
-	69,  // 118: warren.v1.WarrenAPI.JoinCluster:input_type -> warren.v1.JoinClusterRequest
-	71,  // 119: warren.v1.WarrenAPI.GetClusterInfo:input_type -> warren.v1.GetClusterInfoRequest
-	78,  // 120: warren.v1.WarrenAPI.RequestCertificate:input_type -> warren.v1.RequestCertificateRequest
-	85,  // 121: warren.v1.WarrenAPI.CreateIngress:input_type -> warren.v1.CreateIngressRequest
-	87,  // 122: warren.v1.WarrenAPI.UpdateIngress:input_type -> warren.v1.UpdateIngressRequest
-	89,  // 123: warren.v1.WarrenAPI.DeleteIngress:input_type -> warren.v1.DeleteIngressRequest
-	91,  // 124: warren.v1.WarrenAPI.GetIngress:input_type -> warren.v1.GetIngressRequest
-	93,  // 125: warren.v1.WarrenAPI.ListIngresses:input_type -> warren.v1.ListIngressesRequest
-	96,  // 126: warren.v1.WarrenAPI.CreateTLSCertificate:input_type -> warren.v1.CreateTLSCertificateRequest
-	98,  // 127: warren.v1.WarrenAPI.GetTLSCertificate:input_type -> warren.v1.GetTLSCertificateRequest
-	100, // 128: warren.v1.WarrenAPI.ListTLSCertificates:input_type -> warren.v1.ListTLSCertificatesRequest
-	102, // 129: warren.v1.WarrenAPI.DeleteTLSCertificate:input_type -> warren.v1.DeleteTLSCertificateRequest
-	77,  // 130: warren.v1.WarrenAPI.StreamEvents:input_type -> warren.v1.StreamEventsRequest
-	5,   // 131: warren.v1.WarrenAPI.RegisterNode:output_type -> warren.v1.RegisterNodeResponse
-	7,   // 132: warren.v1.WarrenAPI.Heartbeat:output_type -> warren.v1.HeartbeatResponse
-	10,  // 133: warren.v1.WarrenAPI.ListNodes:output_type -> warren.v1.ListNodesResponse
-	12,  // 134: warren.v1.WarrenAPI.GetNode:output_type -> warren.v1.GetNodeResponse
-	14,  // 135: warren.v1.WarrenAPI.RemoveNode:output_type -> warren.v1.RemoveNodeResponse
-	27,  // 136: warren.v1.WarrenAPI.CreateService:output_type -> warren.v1.CreateServiceResponse
-	29,  // 137: warren.v1.WarrenAPI.UpdateService:output_type -> warren.v1.UpdateServiceResponse
-	31,  // 138: warren.v1.WarrenAPI.UpdateServiceImage:output_type -> warren.v1.UpdateServiceImageResponse
-	33,  // 139: warren.v1.WarrenAPI.RollbackService:output_type -> warren.v1.RollbackServiceResponse
-	35,  // 140: warren.v1.WarrenAPI.DeleteService:output_type -> warren.v1.DeleteServiceResponse
-	37,  // 141: warren.v1.WarrenAPI.GetService:output_type -> warren.v1.GetServiceResponse
-	39,  // 142: warren.v1.WarrenAPI.ListServices:output_type -> warren.v1.ListServicesResponse
-	42,  // 143: warren.v1.WarrenAPI.UpdateContainerStatus:output_type -> warren.v1.UpdateContainerStatusResponse
-	44,  // 144: warren.v1.WarrenAPI.ListContainers:output_type -> warren.v1.ListContainersResponse
-	46,  // 145: warren.v1.WarrenAPI.GetContainer:output_type -> warren.v1.GetContainerResponse
-	48,  // 146: warren.v1.WarrenAPI.WatchContainers:output_type -> warren.v1.ContainerEvent
-	75,  // 147: warren.v1.WarrenAPI.ReportContainerHealth:output_type -> warren.v1.ReportContainerHealthResponse
-	51,  // 148: warren.v1.WarrenAPI.CreateSecret:output_type -> warren.v1.CreateSecretResponse
-	55,  // 149: warren.v1.WarrenAPI.GetSecretByName:output_type -> warren.v1.GetSecretByNameResponse
-	53,  // 150: warren.v1.WarrenAPI.DeleteSecret:output_type -> warren.v1.DeleteSecretResponse
-	57,  // 151: warren.v1.WarrenAPI.ListSecrets:output_type -> warren.v1.ListSecretsResponse
-	60,  // 152: warren.v1.WarrenAPI.CreateVolume:output_type -> warren.v1.CreateVolumeResponse
-	64,  // 153: warren.v1.WarrenAPI.GetVolumeByName:output_type -> warren.v1.GetVolumeByNameResponse
-	62,  // 154: warren.v1.WarrenAPI.DeleteVolume:output_type -> warren.v1.DeleteVolumeResponse
-	66,  // 155: warren.v1.WarrenAPI.ListVolumes:output_type -> warren.v1.ListVolumesResponse
-	68,  // 156: warren.v1.WarrenAPI.GenerateJoinToken:output_type -> warren.v1.GenerateJoinTokenResponse
-	70,  // 157: warren.v1.WarrenAPI.JoinCluster:output_type -> warren.v1.JoinClusterResponse
-	72,  // 158: warren.v1.WarrenAPI.GetClusterInfo:output_type -> warren.v1.GetClusterInfoResponse
-	79,  // 159: warren.v1.WarrenAPI.RequestCertificate:output_type -> warren.v1.RequestCertificateResponse
-	86,  // 160: warren.v1.WarrenAPI.CreateIngress:output_type -> warren.v1.CreateIngressResponse
-	88,  // 161: warren.v1.WarrenAPI.UpdateIngress:output_type -> warren.v1.UpdateIngressResponse
-	90,  // 162: warren.v1.WarrenAPI.DeleteIngress:output_type -> warren.v1.DeleteIngressResponse
-	92,  // 163: warren.v1.WarrenAPI.GetIngress:output_type -> warren.v1.GetIngressResponse
-	94,  // 164: warren.v1.WarrenAPI.ListIngresses:output_type -> warren.v1.ListIngressesResponse
-	97,  // 165: warren.v1.WarrenAPI.CreateTLSCertificate:output_type -> warren.v1.CreateTLSCertificateResponse
-	99,  // 166: warren.v1.WarrenAPI.GetTLSCertificate:output_type -> warren.v1.GetTLSCertificateResponse
-	101, // 167: warren.v1.WarrenAPI.ListTLSCertificates:output_type -> warren.v1.ListTLSCertificatesResponse
-	103, // 168: warren.v1.WarrenAPI.DeleteTLSCertificate:output_type -> warren.v1.DeleteTLSCertificateResponse
-	76,  // 169: warren.v1.WarrenAPI.StreamEvents:output_type -> warren.v1.Event
-	131, // [131:170] is the sub-list for method output_type
-	92,  // [92:131] is the sub-list for method input_type
-	92,  // [92:92] is the sub-list for extension type_name
-	92,  // [92:92] is the sub-list for extension extendee
-	0,   // [0:92] is the sub-list for field type_name
+	4,   // 0: warren.v1.Node.resources:type_name -> warren.v1.NodeResources
+	176, // 1: warren.v1.Node.last_heartbeat:type_name -> google.protobuf.Timestamp
+	176, // 2: warren.v1.Node.created_at:type_name -> google.protobuf.Timestamp
+	154, // 3: warren.v1.Node.labels:type_name -> warren.v1.Node.LabelsEntry
+	3,   // 4: warren.v1.Node.taints:type_name -> warren.v1.Taint
+	4,   // 5: warren.v1.RegisterNodeRequest.resources:type_name -> warren.v1.NodeResources
+	155, // 6: warren.v1.RegisterNodeRequest.labels:type_name -> warren.v1.RegisterNodeRequest.LabelsEntry
+	2,   // 7: warren.v1.RegisterNodeResponse.node:type_name -> warren.v1.Node
+	4,   // 8: warren.v1.HeartbeatRequest.available_resources:type_name -> warren.v1.NodeResources
+	9,   // 9: warren.v1.HeartbeatRequest.container_statuses:type_name -> warren.v1.ContainerStatus
+	2,   // 10: warren.v1.ListNodesResponse.nodes:type_name -> warren.v1.Node
+	2,   // 11: warren.v1.GetNodeResponse.node:type_name -> warren.v1.Node
+	156, // 12: warren.v1.UpdateNodeRequest.label_add:type_name -> warren.v1.UpdateNodeRequest.LabelAddEntry
+	3,   // 13: warren.v1.UpdateNodeRequest.taint_add:type_name -> warren.v1.Taint
+	3,   // 14: warren.v1.UpdateNodeRequest.taint_rm:type_name -> warren.v1.Taint
+	2,   // 15: warren.v1.UpdateNodeResponse.node:type_name -> warren.v1.Node
+	27,  // 16: warren.v1.Service.update_config:type_name -> warren.v1.UpdateConfig
+	30,  // 17: warren.v1.Service.health_check:type_name -> warren.v1.HealthCheck
+	35,  // 18: warren.v1.Service.restart_policy:type_name -> warren.v1.RestartPolicy
+	36,  // 19: warren.v1.Service.resources:type_name -> warren.v1.ResourceRequirements
+	37,  // 20: warren.v1.Service.volumes:type_name -> warren.v1.VolumeMount
+	157, // 21: warren.v1.Service.env:type_name -> warren.v1.Service.EnvEntry
+	176, // 22: warren.v1.Service.created_at:type_name -> google.protobuf.Timestamp
+	176, // 23: warren.v1.Service.updated_at:type_name -> google.protobuf.Timestamp
+	38,  // 24: warren.v1.Service.ports:type_name -> warren.v1.PortMapping
+	22,  // 25: warren.v1.Service.placement:type_name -> warren.v1.Placement
+	158, // 26: warren.v1.Service.labels:type_name -> warren.v1.Service.LabelsEntry
+	24,  // 27: warren.v1.Service.affinity:type_name -> warren.v1.Affinity
+	24,  // 28: warren.v1.Service.anti_affinity:type_name -> warren.v1.Affinity
+	26,  // 29: warren.v1.Service.tolerations:type_name -> warren.v1.Toleration
+	19,  // 30: warren.v1.Service.job:type_name -> warren.v1.JobConfig
+	20,  // 31: warren.v1.Service.job_status:type_name -> warren.v1.JobStatus
+	21,  // 32: warren.v1.Service.cron_job:type_name -> warren.v1.CronJobConfig
+	176, // 33: warren.v1.Service.last_schedule_time:type_name -> google.protobuf.Timestamp
+	29,  // 34: warren.v1.Service.canary_analysis:type_name -> warren.v1.CanaryAnalysis
+	176, // 35: warren.v1.JobStatus.start_time:type_name -> google.protobuf.Timestamp
+	176, // 36: warren.v1.JobStatus.completion_time:type_name -> google.protobuf.Timestamp
+	23,  // 37: warren.v1.Placement.preferences:type_name -> warren.v1.PlacementPreference
+	25,  // 38: warren.v1.Affinity.required:type_name -> warren.v1.AffinityTerm
+	25,  // 39: warren.v1.Affinity.preferred:type_name -> warren.v1.AffinityTerm
+	159, // 40: warren.v1.AffinityTerm.labels:type_name -> warren.v1.AffinityTerm.LabelsEntry
+	28,  // 41: warren.v1.CanaryAnalysis.canary:type_name -> warren.v1.TrafficSummary
+	28,  // 42: warren.v1.CanaryAnalysis.stable:type_name -> warren.v1.TrafficSummary
+	176, // 43: warren.v1.CanaryAnalysis.analyzed_at:type_name -> google.protobuf.Timestamp
+	0,   // 44: warren.v1.HealthCheck.type:type_name -> warren.v1.HealthCheck.Type
+	31,  // 45: warren.v1.HealthCheck.http:type_name -> warren.v1.HTTPHealthCheck
+	33,  // 46: warren.v1.HealthCheck.tcp:type_name -> warren.v1.TCPHealthCheck
+	34,  // 47: warren.v1.HealthCheck.exec:type_name -> warren.v1.ExecHealthCheck
+	32,  // 48: warren.v1.HTTPHealthCheck.headers:type_name -> warren.v1.Header
+	1,   // 49: warren.v1.PortMapping.publish_mode:type_name -> warren.v1.PortMapping.PublishMode
+	27,  // 50: warren.v1.CreateServiceRequest.update_config:type_name -> warren.v1.UpdateConfig
+	30,  // 51: warren.v1.CreateServiceRequest.health_check:type_name -> warren.v1.HealthCheck
+	35,  // 52: warren.v1.CreateServiceRequest.restart_policy:type_name -> warren.v1.RestartPolicy
+	36,  // 53: warren.v1.CreateServiceRequest.resources:type_name -> warren.v1.ResourceRequirements
+	37,  // 54: warren.v1.CreateServiceRequest.volumes:type_name -> warren.v1.VolumeMount
+	160, // 55: warren.v1.CreateServiceRequest.env:type_name -> warren.v1.CreateServiceRequest.EnvEntry
+	38,  // 56: warren.v1.CreateServiceRequest.ports:type_name -> warren.v1.PortMapping
+	22,  // 57: warren.v1.CreateServiceRequest.placement:type_name -> warren.v1.Placement
+	161, // 58: warren.v1.CreateServiceRequest.labels:type_name -> warren.v1.CreateServiceRequest.LabelsEntry
+	24,  // 59: warren.v1.CreateServiceRequest.affinity:type_name -> warren.v1.Affinity
+	24,  // 60: warren.v1.CreateServiceRequest.anti_affinity:type_name -> warren.v1.Affinity
+	26,  // 61: warren.v1.CreateServiceRequest.tolerations:type_name -> warren.v1.Toleration
+	19,  // 62: warren.v1.CreateServiceRequest.job:type_name -> warren.v1.JobConfig
+	21,  // 63: warren.v1.CreateServiceRequest.cron_job:type_name -> warren.v1.CronJobConfig
+	18,  // 64: warren.v1.CreateServiceResponse.service:type_name -> warren.v1.Service
+	162, // 65: warren.v1.UpdateServiceRequest.env:type_name -> warren.v1.UpdateServiceRequest.EnvEntry
+	18,  // 66: warren.v1.UpdateServiceResponse.service:type_name -> warren.v1.Service
+	27,  // 67: warren.v1.UpdateServiceImageRequest.update_config:type_name -> warren.v1.UpdateConfig
+	163, // 68: warren.v1.UpdateServiceImageRequest.env:type_name -> warren.v1.UpdateServiceImageRequest.EnvEntry
+	18,  // 69: warren.v1.ServiceRevision.spec:type_name -> warren.v1.Service
+	176, // 70: warren.v1.ServiceRevision.created_at:type_name -> google.protobuf.Timestamp
+	47,  // 71: warren.v1.ListServiceRevisionsResponse.revisions:type_name -> warren.v1.ServiceRevision
+	18,  // 72: warren.v1.GetServiceResponse.service:type_name -> warren.v1.Service
+	18,  // 73: warren.v1.ListServicesResponse.services:type_name -> warren.v1.Service
+	75,  // 74: warren.v1.ListServiceEndpointsResponse.services:type_name -> warren.v1.ServiceEndpoints
+	73,  // 75: warren.v1.GetPlacementDecisionsResponse.decisions:type_name -> warren.v1.PlacementDecision
+	176, // 76: warren.v1.Deployment.step_started_at:type_name -> google.protobuf.Timestamp
+	176, // 77: warren.v1.Deployment.created_at:type_name -> google.protobuf.Timestamp
+	176, // 78: warren.v1.Deployment.updated_at:type_name -> google.protobuf.Timestamp
+	176, // 79: warren.v1.Deployment.completed_at:type_name -> google.protobuf.Timestamp
+	62,  // 80: warren.v1.ListDeploymentsResponse.deployments:type_name -> warren.v1.Deployment
+	62,  // 81: warren.v1.GetDeploymentResponse.deployment:type_name -> warren.v1.Deployment
+	62,  // 82: warren.v1.PauseDeploymentResponse.deployment:type_name -> warren.v1.Deployment
+	62,  // 83: warren.v1.ResumeDeploymentResponse.deployment:type_name -> warren.v1.Deployment
+	62,  // 84: warren.v1.AbortDeploymentResponse.deployment:type_name -> warren.v1.Deployment
+	176, // 85: warren.v1.PlacementDecision.time:type_name -> google.protobuf.Timestamp
+	74,  // 86: warren.v1.PlacementDecision.nodes:type_name -> warren.v1.NodeEvaluation
+	164, // 87: warren.v1.NodeEvaluation.scores:type_name -> warren.v1.NodeEvaluation.ScoresEntry
+	76,  // 88: warren.v1.ServiceEndpoints.ports:type_name -> warren.v1.ServicePortEndpoints
+	77,  // 89: warren.v1.ServicePortEndpoints.endpoints:type_name -> warren.v1.ServiceEndpoint
+	165, // 90: warren.v1.Container.env:type_name -> warren.v1.Container.EnvEntry
+	36,  // 91: warren.v1.Container.resources:type_name -> warren.v1.ResourceRequirements
+	37,  // 92: warren.v1.Container.volumes:type_name -> warren.v1.VolumeMount
+	30,  // 93: warren.v1.Container.health_check:type_name -> warren.v1.HealthCheck
+	35,  // 94: warren.v1.Container.restart_policy:type_name -> warren.v1.RestartPolicy
+	176, // 95: warren.v1.Container.created_at:type_name -> google.protobuf.Timestamp
+	176, // 96: warren.v1.Container.updated_at:type_name -> google.protobuf.Timestamp
+	38,  // 97: warren.v1.Container.ports:type_name -> warren.v1.PortMapping
+	78,  // 98: warren.v1.ListContainersResponse.containers:type_name -> warren.v1.Container
+	78,  // 99: warren.v1.GetContainerResponse.container:type_name -> warren.v1.Container
+	78,  // 100: warren.v1.ContainerEvent.container:type_name -> warren.v1.Container
+	176, // 101: warren.v1.Secret.created_at:type_name -> google.protobuf.Timestamp
+	87,  // 102: warren.v1.CreateSecretResponse.secret:type_name -> warren.v1.Secret
+	87,  // 103: warren.v1.GetSecretByNameResponse.secret:type_name -> warren.v1.Secret
+	87,  // 104: warren.v1.ListSecretsResponse.secrets:type_name -> warren.v1.Secret
+	166, // 105: warren.v1.Volume.driver_opts:type_name -> warren.v1.Volume.DriverOptsEntry
+	167, // 106: warren.v1.Volume.labels:type_name -> warren.v1.Volume.LabelsEntry
+	176, // 107: warren.v1.Volume.created_at:type_name -> google.protobuf.Timestamp
+	168, // 108: warren.v1.CreateVolumeRequest.driver_opts:type_name -> warren.v1.CreateVolumeRequest.DriverOptsEntry
+	169, // 109: warren.v1.CreateVolumeRequest.labels:type_name -> warren.v1.CreateVolumeRequest.LabelsEntry
+	96,  // 110: warren.v1.CreateVolumeResponse.volume:type_name -> warren.v1.Volume
+	96,  // 111: warren.v1.GetVolumeByNameResponse.volume:type_name -> warren.v1.Volume
+	96,  // 112: warren.v1.ListVolumesResponse.volumes:type_name -> warren.v1.Volume
+	176, // 113: warren.v1.GenerateJoinTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
+	176, // 114: warren.v1.JoinToken.created_at:type_name -> google.protobuf.Timestamp
+	176, // 115: warren.v1.JoinToken.expires_at:type_name -> google.protobuf.Timestamp
+	107, // 116: warren.v1.ListJoinTokensResponse.tokens:type_name -> warren.v1.JoinToken
+	176, // 117: warren.v1.RotateJoinTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
+	118, // 118: warren.v1.GetClusterInfoResponse.servers:type_name -> warren.v1.ClusterServer
+	176, // 119: warren.v1.ReportContainerHealthRequest.checked_at:type_name -> google.protobuf.Timestamp
+	176, // 120: warren.v1.Event.timestamp:type_name -> google.protobuf.Timestamp
+	170, // 121: warren.v1.Event.metadata:type_name -> warren.v1.Event.MetadataEntry
+	176, // 122: warren.v1.LogEntry.timestamp:type_name -> google.protobuf.Timestamp
+	176, // 123: warren.v1.StreamServiceLogsRequest.since:type_name -> google.protobuf.Timestamp
+	176, // 124: warren.v1.LogRequest.since:type_name -> google.protobuf.Timestamp
+	131, // 125: warren.v1.Ingress.rules:type_name -> warren.v1.IngressRule
+	134, // 126: warren.v1.Ingress.tls:type_name -> warren.v1.IngressTLS
+	171, // 127: warren.v1.Ingress.labels:type_name -> warren.v1.Ingress.LabelsEntry
+	176, // 128: warren.v1.Ingress.created_at:type_name -> google.protobuf.Timestamp
+	176, // 129: warren.v1.Ingress.updated_at:type_name -> google.protobuf.Timestamp
+	132, // 130: warren.v1.IngressRule.paths:type_name -> warren.v1.IngressPath
+	133, // 131: warren.v1.IngressPath.backend:type_name -> warren.v1.IngressBackend
+	131, // 132: warren.v1.CreateIngressRequest.rules:type_name -> warren.v1.IngressRule
+	134, // 133: warren.v1.CreateIngressRequest.tls:type_name -> warren.v1.IngressTLS
+	172, // 134: warren.v1.CreateIngressRequest.labels:type_name -> warren.v1.CreateIngressRequest.LabelsEntry
+	130, // 135: warren.v1.CreateIngressResponse.ingress:type_name -> warren.v1.Ingress
+	131, // 136: warren.v1.UpdateIngressRequest.rules:type_name -> warren.v1.IngressRule
+	134, // 137: warren.v1.UpdateIngressRequest.tls:type_name -> warren.v1.IngressTLS
+	173, // 138: warren.v1.UpdateIngressRequest.labels:type_name -> warren.v1.UpdateIngressRequest.LabelsEntry
+	130, // 139: warren.v1.UpdateIngressResponse.ingress:type_name -> warren.v1.Ingress
+	130, // 140: warren.v1.GetIngressResponse.ingress:type_name -> warren.v1.Ingress
+	130, // 141: warren.v1.ListIngressesResponse.ingresses:type_name -> warren.v1.Ingress
+	176, // 142: warren.v1.TLSCertificate.not_before:type_name -> google.protobuf.Timestamp
+	176, // 143: warren.v1.TLSCertificate.not_after:type_name -> google.protobuf.Timestamp
+	174, // 144: warren.v1.TLSCertificate.labels:type_name -> warren.v1.TLSCertificate.LabelsEntry
+	176, // 145: warren.v1.TLSCertificate.created_at:type_name -> google.protobuf.Timestamp
+	176, // 146: warren.v1.TLSCertificate.updated_at:type_name -> google.protobuf.Timestamp
+	175, // 147: warren.v1.CreateTLSCertificateRequest.labels:type_name -> warren.v1.CreateTLSCertificateRequest.LabelsEntry
+	145, // 148: warren.v1.CreateTLSCertificateResponse.certificate:type_name -> warren.v1.TLSCertificate
+	145, // 149: warren.v1.GetTLSCertificateResponse.certificate:type_name -> warren.v1.TLSCertificate
+	145, // 150: warren.v1.ListTLSCertificatesResponse.certificates:type_name -> warren.v1.TLSCertificate
+	5,   // 151: warren.v1.WarrenAPI.RegisterNode:input_type -> warren.v1.RegisterNodeRequest
+	7,   // 152: warren.v1.WarrenAPI.Heartbeat:input_type -> warren.v1.HeartbeatRequest
+	10,  // 153: warren.v1.WarrenAPI.ListNodes:input_type -> warren.v1.ListNodesRequest
+	12,  // 154: warren.v1.WarrenAPI.GetNode:input_type -> warren.v1.GetNodeRequest
+	14,  // 155: warren.v1.WarrenAPI.RemoveNode:input_type -> warren.v1.RemoveNodeRequest
+	16,  // 156: warren.v1.WarrenAPI.UpdateNode:input_type -> warren.v1.UpdateNodeRequest
+	39,  // 157: warren.v1.WarrenAPI.CreateService:input_type -> warren.v1.CreateServiceRequest
+	41,  // 158: warren.v1.WarrenAPI.UpdateService:input_type -> warren.v1.UpdateServiceRequest
+	43,  // 159: warren.v1.WarrenAPI.UpdateServiceImage:input_type -> warren.v1.UpdateServiceImageRequest
+	45,  // 160: warren.v1.WarrenAPI.RollbackService:input_type -> warren.v1.RollbackServiceRequest
+	48,  // 161: warren.v1.WarrenAPI.ListServiceRevisions:input_type -> warren.v1.ListServiceRevisionsRequest
+	50,  // 162: warren.v1.WarrenAPI.DeleteService:input_type -> warren.v1.DeleteServiceRequest
+	52,  // 163: warren.v1.WarrenAPI.GetService:input_type -> warren.v1.GetServiceRequest
+	54,  // 164: warren.v1.WarrenAPI.ListServices:input_type -> warren.v1.ListServicesRequest
+	56,  // 165: warren.v1.WarrenAPI.ListServiceEndpoints:input_type -> warren.v1.ListServiceEndpointsRequest
+	58,  // 166: warren.v1.WarrenAPI.GetPlacementDecisions:input_type -> warren.v1.GetPlacementDecisionsRequest
+	60,  // 167: warren.v1.WarrenAPI.RebalanceService:input_type -> warren.v1.RebalanceServiceRequest
+	63,  // 168: warren.v1.WarrenAPI.ListDeployments:input_type -> warren.v1.ListDeploymentsRequest
+	65,  // 169: warren.v1.WarrenAPI.GetDeployment:input_type -> warren.v1.GetDeploymentRequest
+	67,  // 170: warren.v1.WarrenAPI.PauseDeployment:input_type -> warren.v1.PauseDeploymentRequest
+	69,  // 171: warren.v1.WarrenAPI.ResumeDeployment:input_type -> warren.v1.ResumeDeploymentRequest
+	71,  // 172: warren.v1.WarrenAPI.AbortDeployment:input_type -> warren.v1.AbortDeploymentRequest
+	79,  // 173: warren.v1.WarrenAPI.UpdateContainerStatus:input_type -> warren.v1.UpdateContainerStatusRequest
+	81,  // 174: warren.v1.WarrenAPI.ListContainers:input_type -> warren.v1.ListContainersRequest
+	83,  // 175: warren.v1.WarrenAPI.GetContainer:input_type -> warren.v1.GetContainerRequest
+	85,  // 176: warren.v1.WarrenAPI.WatchContainers:input_type -> warren.v1.WatchContainersRequest
+	119, // 177: warren.v1.WarrenAPI.ReportContainerHealth:input_type -> warren.v1.ReportContainerHealthRequest
+	88,  // 178: warren.v1.WarrenAPI.CreateSecret:input_type -> warren.v1.CreateSecretRequest
+	92,  // 179: warren.v1.WarrenAPI.GetSecretByName:input_type -> warren.v1.GetSecretByNameRequest
+	90,  // 180: warren.v1.WarrenAPI.DeleteSecret:input_type -> warren.v1.DeleteSecretRequest
+	94,  // 181: warren.v1.WarrenAPI.ListSecrets:input_type -> warren.v1.ListSecretsRequest
+	97,  // 182: warren.v1.WarrenAPI.CreateVolume:input_type -> warren.v1.CreateVolumeRequest
+	101, // 183: warren.v1.WarrenAPI.GetVolumeByName:input_type -> warren.v1.GetVolumeByNameRequest
+	99,  // 184: warren.v1.WarrenAPI.DeleteVolume:input_type -> warren.v1.DeleteVolumeRequest
+	103, // 185: warren.v1.WarrenAPI.ListVolumes:input_type -> warren.v1.ListVolumesRequest
+	105, // 186: warren.v1.WarrenAPI.GenerateJoinToken:input_type -> warren.v1.GenerateJoinTokenRequest
+	108, // 187: warren.v1.WarrenAPI.ListJoinTokens:input_type -> warren.v1.ListJoinTokensRequest
+	110, // 188: warren.v1.WarrenAPI.RevokeJoinToken:input_type -> warren.v1.RevokeJoinTokenRequest
+	112, // 189: warren.v1.WarrenAPI.RotateJoinToken:input_type -> warren.v1.RotateJoinTokenRequest
+	114, // 190: warren.v1.WarrenAPI.JoinCluster:input_type -> warren.v1.JoinClusterRequest
+	116, // 191: warren.v1.WarrenAPI.GetClusterInfo:input_type -> warren.v1.GetClusterInfoRequest
+	128, // 192: warren.v1.WarrenAPI.RequestCertificate:input_type -> warren.v1.RequestCertificateRequest
+	135, // 193: warren.v1.WarrenAPI.CreateIngress:input_type -> warren.v1.CreateIngressRequest
+	137, // 194: warren.v1.WarrenAPI.UpdateIngress:input_type -> warren.v1.UpdateIngressRequest
+	139, // 195: warren.v1.WarrenAPI.DeleteIngress:input_type -> warren.v1.DeleteIngressRequest
+	141, // 196: warren.v1.WarrenAPI.GetIngress:input_type -> warren.v1.GetIngressRequest
+	143, // 197: warren.v1.WarrenAPI.ListIngresses:input_type -> warren.v1.ListIngressesRequest
+	146, // 198: warren.v1.WarrenAPI.CreateTLSCertificate:input_type -> warren.v1.CreateTLSCertificateRequest
+	148, // 199: warren.v1.WarrenAPI.GetTLSCertificate:input_type -> warren.v1.GetTLSCertificateRequest
+	150, // 200: warren.v1.WarrenAPI.ListTLSCertificates:input_type -> warren.v1.ListTLSCertificatesRequest
+	152, // 201: warren.v1.WarrenAPI.DeleteTLSCertificate:input_type -> warren.v1.DeleteTLSCertificateRequest
+	122, // 202: warren.v1.WarrenAPI.StreamEvents:input_type -> warren.v1.StreamEventsRequest
+	124, // 203: warren.v1.WarrenAPI.StreamServiceLogs:input_type -> warren.v1.StreamServiceLogsRequest
+	125, // 204: warren.v1.WarrenAPI.WatchLogRequests:input_type -> warren.v1.WatchLogRequestsRequest
+	123, // 205: warren.v1.WarrenAPI.PushContainerLogs:input_type -> warren.v1.LogEntry
+	6,   // 206: warren.v1.WarrenAPI.RegisterNode:output_type -> warren.v1.RegisterNodeResponse
+	8,   // 207: warren.v1.WarrenAPI.Heartbeat:output_type -> warren.v1.HeartbeatResponse
+	11,  // 208: warren.v1.WarrenAPI.ListNodes:output_type -> warren.v1.ListNodesResponse
+	13,  // 209: warren.v1.WarrenAPI.GetNode:output_type -> warren.v1.GetNodeResponse
+	15,  // 210: warren.v1.WarrenAPI.RemoveNode:output_type -> warren.v1.RemoveNodeResponse
+	17,  // 211: warren.v1.WarrenAPI.UpdateNode:output_type -> warren.v1.UpdateNodeResponse
+	40,  // 212: warren.v1.WarrenAPI.CreateService:output_type -> warren.v1.CreateServiceResponse
+	42,  // 213: warren.v1.WarrenAPI.UpdateService:output_type -> warren.v1.UpdateServiceResponse
+	44,  // 214: warren.v1.WarrenAPI.UpdateServiceImage:output_type -> warren.v1.UpdateServiceImageResponse
+	46,  // 215: warren.v1.WarrenAPI.RollbackService:output_type -> warren.v1.RollbackServiceResponse
+	49,  // 216: warren.v1.WarrenAPI.ListServiceRevisions:output_type -> warren.v1.ListServiceRevisionsResponse
+	51,  // 217: warren.v1.WarrenAPI.DeleteService:output_type -> warren.v1.DeleteServiceResponse
+	53,  // 218: warren.v1.WarrenAPI.GetService:output_type -> warren.v1.GetServiceResponse
+	55,  // 219: warren.v1.WarrenAPI.ListServices:output_type -> warren.v1.ListServicesResponse
+	57,  // 220: warren.v1.WarrenAPI.ListServiceEndpoints:output_type -> warren.v1.ListServiceEndpointsResponse
+	59,  // 221: warren.v1.WarrenAPI.GetPlacementDecisions:output_type -> warren.v1.GetPlacementDecisionsResponse
+	61,  // 222: warren.v1.WarrenAPI.RebalanceService:output_type -> warren.v1.RebalanceServiceResponse
+	64,  // 223: warren.v1.WarrenAPI.ListDeployments:output_type -> warren.v1.ListDeploymentsResponse
+	66,  // 224: warren.v1.WarrenAPI.GetDeployment:output_type -> warren.v1.GetDeploymentResponse
+	68,  // 225: warren.v1.WarrenAPI.PauseDeployment:output_type -> warren.v1.PauseDeploymentResponse
+	70,  // 226: warren.v1.WarrenAPI.ResumeDeployment:output_type -> warren.v1.ResumeDeploymentResponse
+	72,  // 227: warren.v1.WarrenAPI.AbortDeployment:output_type -> warren.v1.AbortDeploymentResponse
+	80,  // 228: warren.v1.WarrenAPI.UpdateContainerStatus:output_type -> warren.v1.UpdateContainerStatusResponse
+	82,  // 229: warren.v1.WarrenAPI.ListContainers:output_type -> warren.v1.ListContainersResponse
+	84,  // 230: warren.v1.WarrenAPI.GetContainer:output_type -> warren.v1.GetContainerResponse
+	86,  // 231: warren.v1.WarrenAPI.WatchContainers:output_type -> warren.v1.ContainerEvent
+	120, // 232: warren.v1.WarrenAPI.ReportContainerHealth:output_type -> warren.v1.ReportContainerHealthResponse
+	89,  // 233: warren.v1.WarrenAPI.CreateSecret:output_type -> warren.v1.CreateSecretResponse
+	93,  // 234: warren.v1.WarrenAPI.GetSecretByName:output_type -> warren.v1.GetSecretByNameResponse
+	91,  // 235: warren.v1.WarrenAPI.DeleteSecret:output_type -> warren.v1.DeleteSecretResponse
+	95,  // 236: warren.v1.WarrenAPI.ListSecrets:output_type -> warren.v1.ListSecretsResponse
+	98,  // 237: warren.v1.WarrenAPI.CreateVolume:output_type -> warren.v1.CreateVolumeResponse
+	102, // 238: warren.v1.WarrenAPI.GetVolumeByName:output_type -> warren.v1.GetVolumeByNameResponse
+	100, // 239: warren.v1.WarrenAPI.DeleteVolume:output_type -> warren.v1.DeleteVolumeResponse
+	104, // 240: warren.v1.WarrenAPI.ListVolumes:output_type -> warren.v1.ListVolumesResponse
+	106, // 241: warren.v1.WarrenAPI.GenerateJoinToken:output_type -> warren.v1.GenerateJoinTokenResponse
+	109, // 242: warren.v1.WarrenAPI.ListJoinTokens:output_type -> warren.v1.ListJoinTokensResponse
+	111, // 243: warren.v1.WarrenAPI.RevokeJoinToken:output_type -> warren.v1.RevokeJoinTokenResponse
+	113, // 244: warren.v1.WarrenAPI.RotateJoinToken:output_type -> warren.v1.RotateJoinTokenResponse
+	115, // 245: warren.v1.WarrenAPI.JoinCluster:output_type -> warren.v1.JoinClusterResponse
+	117, // 246: warren.v1.WarrenAPI.GetClusterInfo:output_type -> warren.v1.GetClusterInfoResponse
+	129, // 247: warren.v1.WarrenAPI.RequestCertificate:output_type -> warren.v1.RequestCertificateResponse
+	136, // 248: warren.v1.WarrenAPI.CreateIngress:output_type -> warren.v1.CreateIngressResponse
+	138, // 249: warren.v1.WarrenAPI.UpdateIngress:output_type -> warren.v1.UpdateIngressResponse
+	140, // 250: warren.v1.WarrenAPI.DeleteIngress:output_type -> warren.v1.DeleteIngressResponse
+	142, // 251: warren.v1.WarrenAPI.GetIngress:output_type -> warren.v1.GetIngressResponse
+	144, // 252: warren.v1.WarrenAPI.ListIngresses:output_type -> warren.v1.ListIngressesResponse
+	147, // 253: warren.v1.WarrenAPI.CreateTLSCertificate:output_type -> warren.v1.CreateTLSCertificateResponse
+	149, // 254: warren.v1.WarrenAPI.GetTLSCertificate:output_type -> warren.v1.GetTLSCertificateResponse
+	151, // 255: warren.v1.WarrenAPI.ListTLSCertificates:output_type -> warren.v1.ListTLSCertificatesResponse
+	153, // 256: warren.v1.WarrenAPI.DeleteTLSCertificate:output_type -> warren.v1.DeleteTLSCertificateResponse
+	121, // 257: warren.v1.WarrenAPI.StreamEvents:output_type -> warren.v1.Event
+	123, // 258: warren.v1.WarrenAPI.StreamServiceLogs:output_type -> warren.v1.LogEntry
+	126, // 259: warren.v1.WarrenAPI.WatchLogRequests:output_type -> warren.v1.LogRequest
+	127, // 260: warren.v1.WarrenAPI.PushContainerLogs:output_type -> warren.v1.PushContainerLogsResponse
+	206, // [206:261] is the sub-list for method output_type
+	151, // [151:206] is the sub-list for method input_type
+	151, // [151:151] is the sub-list for extension type_name
+	151, // [151:151] is the sub-list for extension extendee
+	0,   // [0:151] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_warren_proto_init() }
@@ -6949,13 +10723,15 @@ func file_api_proto_warren_proto_init() {
 	if File_api_proto_warren_proto != nil {
 		return
 	}
+	file_api_proto_warren_proto_msgTypes[17].OneofWrappers = []any{}
+	file_api_proto_warren_proto_msgTypes[19].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_warren_proto_rawDesc), len(file_api_proto_warren_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   118,
+			NumMessages:   174,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
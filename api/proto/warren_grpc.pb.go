@@ -24,13 +24,23 @@ const (
 	WarrenAPI_ListNodes_FullMethodName             = "/warren.v1.WarrenAPI/ListNodes"
 	WarrenAPI_GetNode_FullMethodName               = "/warren.v1.WarrenAPI/GetNode"
 	WarrenAPI_RemoveNode_FullMethodName            = "/warren.v1.WarrenAPI/RemoveNode"
+	WarrenAPI_UpdateNode_FullMethodName            = "/warren.v1.WarrenAPI/UpdateNode"
 	WarrenAPI_CreateService_FullMethodName         = "/warren.v1.WarrenAPI/CreateService"
 	WarrenAPI_UpdateService_FullMethodName         = "/warren.v1.WarrenAPI/UpdateService"
 	WarrenAPI_UpdateServiceImage_FullMethodName    = "/warren.v1.WarrenAPI/UpdateServiceImage"
 	WarrenAPI_RollbackService_FullMethodName       = "/warren.v1.WarrenAPI/RollbackService"
+	WarrenAPI_ListServiceRevisions_FullMethodName  = "/warren.v1.WarrenAPI/ListServiceRevisions"
 	WarrenAPI_DeleteService_FullMethodName         = "/warren.v1.WarrenAPI/DeleteService"
 	WarrenAPI_GetService_FullMethodName            = "/warren.v1.WarrenAPI/GetService"
 	WarrenAPI_ListServices_FullMethodName          = "/warren.v1.WarrenAPI/ListServices"
+	WarrenAPI_ListServiceEndpoints_FullMethodName  = "/warren.v1.WarrenAPI/ListServiceEndpoints"
+	WarrenAPI_GetPlacementDecisions_FullMethodName = "/warren.v1.WarrenAPI/GetPlacementDecisions"
+	WarrenAPI_RebalanceService_FullMethodName      = "/warren.v1.WarrenAPI/RebalanceService"
+	WarrenAPI_ListDeployments_FullMethodName       = "/warren.v1.WarrenAPI/ListDeployments"
+	WarrenAPI_GetDeployment_FullMethodName         = "/warren.v1.WarrenAPI/GetDeployment"
+	WarrenAPI_PauseDeployment_FullMethodName       = "/warren.v1.WarrenAPI/PauseDeployment"
+	WarrenAPI_ResumeDeployment_FullMethodName      = "/warren.v1.WarrenAPI/ResumeDeployment"
+	WarrenAPI_AbortDeployment_FullMethodName       = "/warren.v1.WarrenAPI/AbortDeployment"
 	WarrenAPI_UpdateContainerStatus_FullMethodName = "/warren.v1.WarrenAPI/UpdateContainerStatus"
 	WarrenAPI_ListContainers_FullMethodName        = "/warren.v1.WarrenAPI/ListContainers"
 	WarrenAPI_GetContainer_FullMethodName          = "/warren.v1.WarrenAPI/GetContainer"
@@ -45,6 +55,9 @@ const (
 	WarrenAPI_DeleteVolume_FullMethodName          = "/warren.v1.WarrenAPI/DeleteVolume"
 	WarrenAPI_ListVolumes_FullMethodName           = "/warren.v1.WarrenAPI/ListVolumes"
 	WarrenAPI_GenerateJoinToken_FullMethodName     = "/warren.v1.WarrenAPI/GenerateJoinToken"
+	WarrenAPI_ListJoinTokens_FullMethodName        = "/warren.v1.WarrenAPI/ListJoinTokens"
+	WarrenAPI_RevokeJoinToken_FullMethodName       = "/warren.v1.WarrenAPI/RevokeJoinToken"
+	WarrenAPI_RotateJoinToken_FullMethodName       = "/warren.v1.WarrenAPI/RotateJoinToken"
 	WarrenAPI_JoinCluster_FullMethodName           = "/warren.v1.WarrenAPI/JoinCluster"
 	WarrenAPI_GetClusterInfo_FullMethodName        = "/warren.v1.WarrenAPI/GetClusterInfo"
 	WarrenAPI_RequestCertificate_FullMethodName    = "/warren.v1.WarrenAPI/RequestCertificate"
@@ -58,6 +71,9 @@ const (
 	WarrenAPI_ListTLSCertificates_FullMethodName   = "/warren.v1.WarrenAPI/ListTLSCertificates"
 	WarrenAPI_DeleteTLSCertificate_FullMethodName  = "/warren.v1.WarrenAPI/DeleteTLSCertificate"
 	WarrenAPI_StreamEvents_FullMethodName          = "/warren.v1.WarrenAPI/StreamEvents"
+	WarrenAPI_StreamServiceLogs_FullMethodName     = "/warren.v1.WarrenAPI/StreamServiceLogs"
+	WarrenAPI_WatchLogRequests_FullMethodName      = "/warren.v1.WarrenAPI/WatchLogRequests"
+	WarrenAPI_PushContainerLogs_FullMethodName     = "/warren.v1.WarrenAPI/PushContainerLogs"
 )
 
 // WarrenAPIClient is the client API for WarrenAPI service.
@@ -72,14 +88,25 @@ type WarrenAPIClient interface {
 	ListNodes(ctx context.Context, in *ListNodesRequest, opts ...grpc.CallOption) (*ListNodesResponse, error)
 	GetNode(ctx context.Context, in *GetNodeRequest, opts ...grpc.CallOption) (*GetNodeResponse, error)
 	RemoveNode(ctx context.Context, in *RemoveNodeRequest, opts ...grpc.CallOption) (*RemoveNodeResponse, error)
+	UpdateNode(ctx context.Context, in *UpdateNodeRequest, opts ...grpc.CallOption) (*UpdateNodeResponse, error)
 	// Service operations
 	CreateService(ctx context.Context, in *CreateServiceRequest, opts ...grpc.CallOption) (*CreateServiceResponse, error)
 	UpdateService(ctx context.Context, in *UpdateServiceRequest, opts ...grpc.CallOption) (*UpdateServiceResponse, error)
 	UpdateServiceImage(ctx context.Context, in *UpdateServiceImageRequest, opts ...grpc.CallOption) (*UpdateServiceImageResponse, error)
 	RollbackService(ctx context.Context, in *RollbackServiceRequest, opts ...grpc.CallOption) (*RollbackServiceResponse, error)
+	ListServiceRevisions(ctx context.Context, in *ListServiceRevisionsRequest, opts ...grpc.CallOption) (*ListServiceRevisionsResponse, error)
 	DeleteService(ctx context.Context, in *DeleteServiceRequest, opts ...grpc.CallOption) (*DeleteServiceResponse, error)
 	GetService(ctx context.Context, in *GetServiceRequest, opts ...grpc.CallOption) (*GetServiceResponse, error)
 	ListServices(ctx context.Context, in *ListServicesRequest, opts ...grpc.CallOption) (*ListServicesResponse, error)
+	ListServiceEndpoints(ctx context.Context, in *ListServiceEndpointsRequest, opts ...grpc.CallOption) (*ListServiceEndpointsResponse, error)
+	GetPlacementDecisions(ctx context.Context, in *GetPlacementDecisionsRequest, opts ...grpc.CallOption) (*GetPlacementDecisionsResponse, error)
+	RebalanceService(ctx context.Context, in *RebalanceServiceRequest, opts ...grpc.CallOption) (*RebalanceServiceResponse, error)
+	// Deployment operations
+	ListDeployments(ctx context.Context, in *ListDeploymentsRequest, opts ...grpc.CallOption) (*ListDeploymentsResponse, error)
+	GetDeployment(ctx context.Context, in *GetDeploymentRequest, opts ...grpc.CallOption) (*GetDeploymentResponse, error)
+	PauseDeployment(ctx context.Context, in *PauseDeploymentRequest, opts ...grpc.CallOption) (*PauseDeploymentResponse, error)
+	ResumeDeployment(ctx context.Context, in *ResumeDeploymentRequest, opts ...grpc.CallOption) (*ResumeDeploymentResponse, error)
+	AbortDeployment(ctx context.Context, in *AbortDeploymentRequest, opts ...grpc.CallOption) (*AbortDeploymentResponse, error)
 	// Container operations
 	UpdateContainerStatus(ctx context.Context, in *UpdateContainerStatusRequest, opts ...grpc.CallOption) (*UpdateContainerStatusResponse, error)
 	ListContainers(ctx context.Context, in *ListContainersRequest, opts ...grpc.CallOption) (*ListContainersResponse, error)
@@ -100,6 +127,9 @@ type WarrenAPIClient interface {
 	ListVolumes(ctx context.Context, in *ListVolumesRequest, opts ...grpc.CallOption) (*ListVolumesResponse, error)
 	// Cluster operations
 	GenerateJoinToken(ctx context.Context, in *GenerateJoinTokenRequest, opts ...grpc.CallOption) (*GenerateJoinTokenResponse, error)
+	ListJoinTokens(ctx context.Context, in *ListJoinTokensRequest, opts ...grpc.CallOption) (*ListJoinTokensResponse, error)
+	RevokeJoinToken(ctx context.Context, in *RevokeJoinTokenRequest, opts ...grpc.CallOption) (*RevokeJoinTokenResponse, error)
+	RotateJoinToken(ctx context.Context, in *RotateJoinTokenRequest, opts ...grpc.CallOption) (*RotateJoinTokenResponse, error)
 	JoinCluster(ctx context.Context, in *JoinClusterRequest, opts ...grpc.CallOption) (*JoinClusterResponse, error)
 	GetClusterInfo(ctx context.Context, in *GetClusterInfoRequest, opts ...grpc.CallOption) (*GetClusterInfoResponse, error)
 	// Certificate operations
@@ -117,6 +147,10 @@ type WarrenAPIClient interface {
 	DeleteTLSCertificate(ctx context.Context, in *DeleteTLSCertificateRequest, opts ...grpc.CallOption) (*DeleteTLSCertificateResponse, error)
 	// Event streaming
 	StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+	// Log operations
+	StreamServiceLogs(ctx context.Context, in *StreamServiceLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogEntry], error)
+	WatchLogRequests(ctx context.Context, in *WatchLogRequestsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogRequest], error)
+	PushContainerLogs(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[LogEntry, PushContainerLogsResponse], error)
 }
 
 type warrenAPIClient struct {
@@ -177,6 +211,16 @@ func (c *warrenAPIClient) RemoveNode(ctx context.Context, in *RemoveNodeRequest,
 	return out, nil
 }
 
+func (c *warrenAPIClient) UpdateNode(ctx context.Context, in *UpdateNodeRequest, opts ...grpc.CallOption) (*UpdateNodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateNodeResponse)
+	err := c.cc.Invoke(ctx, WarrenAPI_UpdateNode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *warrenAPIClient) CreateService(ctx context.Context, in *CreateServiceRequest, opts ...grpc.CallOption) (*CreateServiceResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CreateServiceResponse)
@@ -217,6 +261,16 @@ func (c *warrenAPIClient) RollbackService(ctx context.Context, in *RollbackServi
 	return out, nil
 }
 
+func (c *warrenAPIClient) ListServiceRevisions(ctx context.Context, in *ListServiceRevisionsRequest, opts ...grpc.CallOption) (*ListServiceRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListServiceRevisionsResponse)
+	err := c.cc.Invoke(ctx, WarrenAPI_ListServiceRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *warrenAPIClient) DeleteService(ctx context.Context, in *DeleteServiceRequest, opts ...grpc.CallOption) (*DeleteServiceResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DeleteServiceResponse)
@@ -247,6 +301,86 @@ func (c *warrenAPIClient) ListServices(ctx context.Context, in *ListServicesRequ
 	return out, nil
 }
 
+func (c *warrenAPIClient) ListServiceEndpoints(ctx context.Context, in *ListServiceEndpointsRequest, opts ...grpc.CallOption) (*ListServiceEndpointsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListServiceEndpointsResponse)
+	err := c.cc.Invoke(ctx, WarrenAPI_ListServiceEndpoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warrenAPIClient) GetPlacementDecisions(ctx context.Context, in *GetPlacementDecisionsRequest, opts ...grpc.CallOption) (*GetPlacementDecisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPlacementDecisionsResponse)
+	err := c.cc.Invoke(ctx, WarrenAPI_GetPlacementDecisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warrenAPIClient) RebalanceService(ctx context.Context, in *RebalanceServiceRequest, opts ...grpc.CallOption) (*RebalanceServiceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RebalanceServiceResponse)
+	err := c.cc.Invoke(ctx, WarrenAPI_RebalanceService_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warrenAPIClient) ListDeployments(ctx context.Context, in *ListDeploymentsRequest, opts ...grpc.CallOption) (*ListDeploymentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeploymentsResponse)
+	err := c.cc.Invoke(ctx, WarrenAPI_ListDeployments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warrenAPIClient) GetDeployment(ctx context.Context, in *GetDeploymentRequest, opts ...grpc.CallOption) (*GetDeploymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDeploymentResponse)
+	err := c.cc.Invoke(ctx, WarrenAPI_GetDeployment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warrenAPIClient) PauseDeployment(ctx context.Context, in *PauseDeploymentRequest, opts ...grpc.CallOption) (*PauseDeploymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PauseDeploymentResponse)
+	err := c.cc.Invoke(ctx, WarrenAPI_PauseDeployment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warrenAPIClient) ResumeDeployment(ctx context.Context, in *ResumeDeploymentRequest, opts ...grpc.CallOption) (*ResumeDeploymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResumeDeploymentResponse)
+	err := c.cc.Invoke(ctx, WarrenAPI_ResumeDeployment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warrenAPIClient) AbortDeployment(ctx context.Context, in *AbortDeploymentRequest, opts ...grpc.CallOption) (*AbortDeploymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AbortDeploymentResponse)
+	err := c.cc.Invoke(ctx, WarrenAPI_AbortDeployment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *warrenAPIClient) UpdateContainerStatus(ctx context.Context, in *UpdateContainerStatusRequest, opts ...grpc.CallOption) (*UpdateContainerStatusResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateContainerStatusResponse)
@@ -396,6 +530,36 @@ func (c *warrenAPIClient) GenerateJoinToken(ctx context.Context, in *GenerateJoi
 	return out, nil
 }
 
+func (c *warrenAPIClient) ListJoinTokens(ctx context.Context, in *ListJoinTokensRequest, opts ...grpc.CallOption) (*ListJoinTokensResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListJoinTokensResponse)
+	err := c.cc.Invoke(ctx, WarrenAPI_ListJoinTokens_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warrenAPIClient) RevokeJoinToken(ctx context.Context, in *RevokeJoinTokenRequest, opts ...grpc.CallOption) (*RevokeJoinTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeJoinTokenResponse)
+	err := c.cc.Invoke(ctx, WarrenAPI_RevokeJoinToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warrenAPIClient) RotateJoinToken(ctx context.Context, in *RotateJoinTokenRequest, opts ...grpc.CallOption) (*RotateJoinTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RotateJoinTokenResponse)
+	err := c.cc.Invoke(ctx, WarrenAPI_RotateJoinToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *warrenAPIClient) JoinCluster(ctx context.Context, in *JoinClusterRequest, opts ...grpc.CallOption) (*JoinClusterResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(JoinClusterResponse)
@@ -535,6 +699,57 @@ func (c *warrenAPIClient) StreamEvents(ctx context.Context, in *StreamEventsRequ
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type WarrenAPI_StreamEventsClient = grpc.ServerStreamingClient[Event]
 
+func (c *warrenAPIClient) StreamServiceLogs(ctx context.Context, in *StreamServiceLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogEntry], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &WarrenAPI_ServiceDesc.Streams[2], WarrenAPI_StreamServiceLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamServiceLogsRequest, LogEntry]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WarrenAPI_StreamServiceLogsClient = grpc.ServerStreamingClient[LogEntry]
+
+func (c *warrenAPIClient) WatchLogRequests(ctx context.Context, in *WatchLogRequestsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogRequest], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &WarrenAPI_ServiceDesc.Streams[3], WarrenAPI_WatchLogRequests_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchLogRequestsRequest, LogRequest]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WarrenAPI_WatchLogRequestsClient = grpc.ServerStreamingClient[LogRequest]
+
+func (c *warrenAPIClient) PushContainerLogs(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[LogEntry, PushContainerLogsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &WarrenAPI_ServiceDesc.Streams[4], WarrenAPI_PushContainerLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[LogEntry, PushContainerLogsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WarrenAPI_PushContainerLogsClient = grpc.ClientStreamingClient[LogEntry, PushContainerLogsResponse]
+
 // WarrenAPIServer is the server API for WarrenAPI service.
 // All implementations must embed UnimplementedWarrenAPIServer
 // for forward compatibility.
@@ -547,14 +762,25 @@ type WarrenAPIServer interface {
 	ListNodes(context.Context, *ListNodesRequest) (*ListNodesResponse, error)
 	GetNode(context.Context, *GetNodeRequest) (*GetNodeResponse, error)
 	RemoveNode(context.Context, *RemoveNodeRequest) (*RemoveNodeResponse, error)
+	UpdateNode(context.Context, *UpdateNodeRequest) (*UpdateNodeResponse, error)
 	// Service operations
 	CreateService(context.Context, *CreateServiceRequest) (*CreateServiceResponse, error)
 	UpdateService(context.Context, *UpdateServiceRequest) (*UpdateServiceResponse, error)
 	UpdateServiceImage(context.Context, *UpdateServiceImageRequest) (*UpdateServiceImageResponse, error)
 	RollbackService(context.Context, *RollbackServiceRequest) (*RollbackServiceResponse, error)
+	ListServiceRevisions(context.Context, *ListServiceRevisionsRequest) (*ListServiceRevisionsResponse, error)
 	DeleteService(context.Context, *DeleteServiceRequest) (*DeleteServiceResponse, error)
 	GetService(context.Context, *GetServiceRequest) (*GetServiceResponse, error)
 	ListServices(context.Context, *ListServicesRequest) (*ListServicesResponse, error)
+	ListServiceEndpoints(context.Context, *ListServiceEndpointsRequest) (*ListServiceEndpointsResponse, error)
+	GetPlacementDecisions(context.Context, *GetPlacementDecisionsRequest) (*GetPlacementDecisionsResponse, error)
+	RebalanceService(context.Context, *RebalanceServiceRequest) (*RebalanceServiceResponse, error)
+	// Deployment operations
+	ListDeployments(context.Context, *ListDeploymentsRequest) (*ListDeploymentsResponse, error)
+	GetDeployment(context.Context, *GetDeploymentRequest) (*GetDeploymentResponse, error)
+	PauseDeployment(context.Context, *PauseDeploymentRequest) (*PauseDeploymentResponse, error)
+	ResumeDeployment(context.Context, *ResumeDeploymentRequest) (*ResumeDeploymentResponse, error)
+	AbortDeployment(context.Context, *AbortDeploymentRequest) (*AbortDeploymentResponse, error)
 	// Container operations
 	UpdateContainerStatus(context.Context, *UpdateContainerStatusRequest) (*UpdateContainerStatusResponse, error)
 	ListContainers(context.Context, *ListContainersRequest) (*ListContainersResponse, error)
@@ -575,6 +801,9 @@ type WarrenAPIServer interface {
 	ListVolumes(context.Context, *ListVolumesRequest) (*ListVolumesResponse, error)
 	// Cluster operations
 	GenerateJoinToken(context.Context, *GenerateJoinTokenRequest) (*GenerateJoinTokenResponse, error)
+	ListJoinTokens(context.Context, *ListJoinTokensRequest) (*ListJoinTokensResponse, error)
+	RevokeJoinToken(context.Context, *RevokeJoinTokenRequest) (*RevokeJoinTokenResponse, error)
+	RotateJoinToken(context.Context, *RotateJoinTokenRequest) (*RotateJoinTokenResponse, error)
 	JoinCluster(context.Context, *JoinClusterRequest) (*JoinClusterResponse, error)
 	GetClusterInfo(context.Context, *GetClusterInfoRequest) (*GetClusterInfoResponse, error)
 	// Certificate operations
@@ -592,6 +821,10 @@ type WarrenAPIServer interface {
 	DeleteTLSCertificate(context.Context, *DeleteTLSCertificateRequest) (*DeleteTLSCertificateResponse, error)
 	// Event streaming
 	StreamEvents(*StreamEventsRequest, grpc.ServerStreamingServer[Event]) error
+	// Log operations
+	StreamServiceLogs(*StreamServiceLogsRequest, grpc.ServerStreamingServer[LogEntry]) error
+	WatchLogRequests(*WatchLogRequestsRequest, grpc.ServerStreamingServer[LogRequest]) error
+	PushContainerLogs(grpc.ClientStreamingServer[LogEntry, PushContainerLogsResponse]) error
 	mustEmbedUnimplementedWarrenAPIServer()
 }
 
@@ -617,6 +850,9 @@ func (UnimplementedWarrenAPIServer) GetNode(context.Context, *GetNodeRequest) (*
 func (UnimplementedWarrenAPIServer) RemoveNode(context.Context, *RemoveNodeRequest) (*RemoveNodeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveNode not implemented")
 }
+func (UnimplementedWarrenAPIServer) UpdateNode(context.Context, *UpdateNodeRequest) (*UpdateNodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateNode not implemented")
+}
 func (UnimplementedWarrenAPIServer) CreateService(context.Context, *CreateServiceRequest) (*CreateServiceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateService not implemented")
 }
@@ -629,6 +865,9 @@ func (UnimplementedWarrenAPIServer) UpdateServiceImage(context.Context, *UpdateS
 func (UnimplementedWarrenAPIServer) RollbackService(context.Context, *RollbackServiceRequest) (*RollbackServiceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RollbackService not implemented")
 }
+func (UnimplementedWarrenAPIServer) ListServiceRevisions(context.Context, *ListServiceRevisionsRequest) (*ListServiceRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListServiceRevisions not implemented")
+}
 func (UnimplementedWarrenAPIServer) DeleteService(context.Context, *DeleteServiceRequest) (*DeleteServiceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteService not implemented")
 }
@@ -638,6 +877,30 @@ func (UnimplementedWarrenAPIServer) GetService(context.Context, *GetServiceReque
 func (UnimplementedWarrenAPIServer) ListServices(context.Context, *ListServicesRequest) (*ListServicesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListServices not implemented")
 }
+func (UnimplementedWarrenAPIServer) ListServiceEndpoints(context.Context, *ListServiceEndpointsRequest) (*ListServiceEndpointsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListServiceEndpoints not implemented")
+}
+func (UnimplementedWarrenAPIServer) GetPlacementDecisions(context.Context, *GetPlacementDecisionsRequest) (*GetPlacementDecisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPlacementDecisions not implemented")
+}
+func (UnimplementedWarrenAPIServer) RebalanceService(context.Context, *RebalanceServiceRequest) (*RebalanceServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RebalanceService not implemented")
+}
+func (UnimplementedWarrenAPIServer) ListDeployments(context.Context, *ListDeploymentsRequest) (*ListDeploymentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeployments not implemented")
+}
+func (UnimplementedWarrenAPIServer) GetDeployment(context.Context, *GetDeploymentRequest) (*GetDeploymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeployment not implemented")
+}
+func (UnimplementedWarrenAPIServer) PauseDeployment(context.Context, *PauseDeploymentRequest) (*PauseDeploymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseDeployment not implemented")
+}
+func (UnimplementedWarrenAPIServer) ResumeDeployment(context.Context, *ResumeDeploymentRequest) (*ResumeDeploymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeDeployment not implemented")
+}
+func (UnimplementedWarrenAPIServer) AbortDeployment(context.Context, *AbortDeploymentRequest) (*AbortDeploymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AbortDeployment not implemented")
+}
 func (UnimplementedWarrenAPIServer) UpdateContainerStatus(context.Context, *UpdateContainerStatusRequest) (*UpdateContainerStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateContainerStatus not implemented")
 }
@@ -680,6 +943,15 @@ func (UnimplementedWarrenAPIServer) ListVolumes(context.Context, *ListVolumesReq
 func (UnimplementedWarrenAPIServer) GenerateJoinToken(context.Context, *GenerateJoinTokenRequest) (*GenerateJoinTokenResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GenerateJoinToken not implemented")
 }
+func (UnimplementedWarrenAPIServer) ListJoinTokens(context.Context, *ListJoinTokensRequest) (*ListJoinTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJoinTokens not implemented")
+}
+func (UnimplementedWarrenAPIServer) RevokeJoinToken(context.Context, *RevokeJoinTokenRequest) (*RevokeJoinTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeJoinToken not implemented")
+}
+func (UnimplementedWarrenAPIServer) RotateJoinToken(context.Context, *RotateJoinTokenRequest) (*RotateJoinTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateJoinToken not implemented")
+}
 func (UnimplementedWarrenAPIServer) JoinCluster(context.Context, *JoinClusterRequest) (*JoinClusterResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method JoinCluster not implemented")
 }
@@ -719,6 +991,15 @@ func (UnimplementedWarrenAPIServer) DeleteTLSCertificate(context.Context, *Delet
 func (UnimplementedWarrenAPIServer) StreamEvents(*StreamEventsRequest, grpc.ServerStreamingServer[Event]) error {
 	return status.Errorf(codes.Unimplemented, "method StreamEvents not implemented")
 }
+func (UnimplementedWarrenAPIServer) StreamServiceLogs(*StreamServiceLogsRequest, grpc.ServerStreamingServer[LogEntry]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamServiceLogs not implemented")
+}
+func (UnimplementedWarrenAPIServer) WatchLogRequests(*WatchLogRequestsRequest, grpc.ServerStreamingServer[LogRequest]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchLogRequests not implemented")
+}
+func (UnimplementedWarrenAPIServer) PushContainerLogs(grpc.ClientStreamingServer[LogEntry, PushContainerLogsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method PushContainerLogs not implemented")
+}
 func (UnimplementedWarrenAPIServer) mustEmbedUnimplementedWarrenAPIServer() {}
 func (UnimplementedWarrenAPIServer) testEmbeddedByValue()                   {}
 
@@ -830,6 +1111,24 @@ func _WarrenAPI_RemoveNode_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WarrenAPI_UpdateNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarrenAPIServer).UpdateNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarrenAPI_UpdateNode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarrenAPIServer).UpdateNode(ctx, req.(*UpdateNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WarrenAPI_CreateService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateServiceRequest)
 	if err := dec(in); err != nil {
@@ -902,6 +1201,24 @@ func _WarrenAPI_RollbackService_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WarrenAPI_ListServiceRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListServiceRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarrenAPIServer).ListServiceRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarrenAPI_ListServiceRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarrenAPIServer).ListServiceRevisions(ctx, req.(*ListServiceRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WarrenAPI_DeleteService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteServiceRequest)
 	if err := dec(in); err != nil {
@@ -956,6 +1273,150 @@ func _WarrenAPI_ListServices_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WarrenAPI_ListServiceEndpoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListServiceEndpointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarrenAPIServer).ListServiceEndpoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarrenAPI_ListServiceEndpoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarrenAPIServer).ListServiceEndpoints(ctx, req.(*ListServiceEndpointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarrenAPI_GetPlacementDecisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPlacementDecisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarrenAPIServer).GetPlacementDecisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarrenAPI_GetPlacementDecisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarrenAPIServer).GetPlacementDecisions(ctx, req.(*GetPlacementDecisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarrenAPI_RebalanceService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebalanceServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarrenAPIServer).RebalanceService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarrenAPI_RebalanceService_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarrenAPIServer).RebalanceService(ctx, req.(*RebalanceServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarrenAPI_ListDeployments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeploymentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarrenAPIServer).ListDeployments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarrenAPI_ListDeployments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarrenAPIServer).ListDeployments(ctx, req.(*ListDeploymentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarrenAPI_GetDeployment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeploymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarrenAPIServer).GetDeployment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarrenAPI_GetDeployment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarrenAPIServer).GetDeployment(ctx, req.(*GetDeploymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarrenAPI_PauseDeployment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseDeploymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarrenAPIServer).PauseDeployment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarrenAPI_PauseDeployment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarrenAPIServer).PauseDeployment(ctx, req.(*PauseDeploymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarrenAPI_ResumeDeployment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeDeploymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarrenAPIServer).ResumeDeployment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarrenAPI_ResumeDeployment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarrenAPIServer).ResumeDeployment(ctx, req.(*ResumeDeploymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarrenAPI_AbortDeployment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbortDeploymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarrenAPIServer).AbortDeployment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarrenAPI_AbortDeployment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarrenAPIServer).AbortDeployment(ctx, req.(*AbortDeploymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WarrenAPI_UpdateContainerStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateContainerStatusRequest)
 	if err := dec(in); err != nil {
@@ -1201,6 +1662,60 @@ func _WarrenAPI_GenerateJoinToken_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WarrenAPI_ListJoinTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJoinTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarrenAPIServer).ListJoinTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarrenAPI_ListJoinTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarrenAPIServer).ListJoinTokens(ctx, req.(*ListJoinTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarrenAPI_RevokeJoinToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeJoinTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarrenAPIServer).RevokeJoinToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarrenAPI_RevokeJoinToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarrenAPIServer).RevokeJoinToken(ctx, req.(*RevokeJoinTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarrenAPI_RotateJoinToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateJoinTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarrenAPIServer).RotateJoinToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarrenAPI_RotateJoinToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarrenAPIServer).RotateJoinToken(ctx, req.(*RotateJoinTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WarrenAPI_JoinCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(JoinClusterRequest)
 	if err := dec(in); err != nil {
@@ -1428,6 +1943,35 @@ func _WarrenAPI_StreamEvents_Handler(srv interface{}, stream grpc.ServerStream)
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type WarrenAPI_StreamEventsServer = grpc.ServerStreamingServer[Event]
 
+func _WarrenAPI_StreamServiceLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamServiceLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WarrenAPIServer).StreamServiceLogs(m, &grpc.GenericServerStream[StreamServiceLogsRequest, LogEntry]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WarrenAPI_StreamServiceLogsServer = grpc.ServerStreamingServer[LogEntry]
+
+func _WarrenAPI_WatchLogRequests_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchLogRequestsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WarrenAPIServer).WatchLogRequests(m, &grpc.GenericServerStream[WatchLogRequestsRequest, LogRequest]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WarrenAPI_WatchLogRequestsServer = grpc.ServerStreamingServer[LogRequest]
+
+func _WarrenAPI_PushContainerLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(WarrenAPIServer).PushContainerLogs(&grpc.GenericServerStream[LogEntry, PushContainerLogsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WarrenAPI_PushContainerLogsServer = grpc.ClientStreamingServer[LogEntry, PushContainerLogsResponse]
+
 // WarrenAPI_ServiceDesc is the grpc.ServiceDesc for WarrenAPI service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1455,6 +1999,10 @@ var WarrenAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveNode",
 			Handler:    _WarrenAPI_RemoveNode_Handler,
 		},
+		{
+			MethodName: "UpdateNode",
+			Handler:    _WarrenAPI_UpdateNode_Handler,
+		},
 		{
 			MethodName: "CreateService",
 			Handler:    _WarrenAPI_CreateService_Handler,
@@ -1471,6 +2019,10 @@ var WarrenAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RollbackService",
 			Handler:    _WarrenAPI_RollbackService_Handler,
 		},
+		{
+			MethodName: "ListServiceRevisions",
+			Handler:    _WarrenAPI_ListServiceRevisions_Handler,
+		},
 		{
 			MethodName: "DeleteService",
 			Handler:    _WarrenAPI_DeleteService_Handler,
@@ -1483,6 +2035,38 @@ var WarrenAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListServices",
 			Handler:    _WarrenAPI_ListServices_Handler,
 		},
+		{
+			MethodName: "ListServiceEndpoints",
+			Handler:    _WarrenAPI_ListServiceEndpoints_Handler,
+		},
+		{
+			MethodName: "GetPlacementDecisions",
+			Handler:    _WarrenAPI_GetPlacementDecisions_Handler,
+		},
+		{
+			MethodName: "RebalanceService",
+			Handler:    _WarrenAPI_RebalanceService_Handler,
+		},
+		{
+			MethodName: "ListDeployments",
+			Handler:    _WarrenAPI_ListDeployments_Handler,
+		},
+		{
+			MethodName: "GetDeployment",
+			Handler:    _WarrenAPI_GetDeployment_Handler,
+		},
+		{
+			MethodName: "PauseDeployment",
+			Handler:    _WarrenAPI_PauseDeployment_Handler,
+		},
+		{
+			MethodName: "ResumeDeployment",
+			Handler:    _WarrenAPI_ResumeDeployment_Handler,
+		},
+		{
+			MethodName: "AbortDeployment",
+			Handler:    _WarrenAPI_AbortDeployment_Handler,
+		},
 		{
 			MethodName: "UpdateContainerStatus",
 			Handler:    _WarrenAPI_UpdateContainerStatus_Handler,
@@ -1535,6 +2119,18 @@ var WarrenAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GenerateJoinToken",
 			Handler:    _WarrenAPI_GenerateJoinToken_Handler,
 		},
+		{
+			MethodName: "ListJoinTokens",
+			Handler:    _WarrenAPI_ListJoinTokens_Handler,
+		},
+		{
+			MethodName: "RevokeJoinToken",
+			Handler:    _WarrenAPI_RevokeJoinToken_Handler,
+		},
+		{
+			MethodName: "RotateJoinToken",
+			Handler:    _WarrenAPI_RotateJoinToken_Handler,
+		},
 		{
 			MethodName: "JoinCluster",
 			Handler:    _WarrenAPI_JoinCluster_Handler,
@@ -1595,6 +2191,21 @@ var WarrenAPI_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _WarrenAPI_StreamEvents_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamServiceLogs",
+			Handler:       _WarrenAPI_StreamServiceLogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchLogRequests",
+			Handler:       _WarrenAPI_WatchLogRequests_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "PushContainerLogs",
+			Handler:       _WarrenAPI_PushContainerLogs_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "api/proto/warren.proto",
 }
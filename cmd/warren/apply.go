@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/cuemby/warren/api/proto"
 	"github.com/cuemby/warren/pkg/client"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -110,7 +111,37 @@ func applyService(c *client.Client, resource *WarrenResource) error {
 			}
 		}
 
-		service, err := c.CreateService(name, image, int32(replicas), env)
+		placement, err := getPlacement(resource.Spec)
+		if err != nil {
+			return err
+		}
+		affinity, err := getAffinity(resource.Spec, "affinity")
+		if err != nil {
+			return err
+		}
+		antiAffinity, err := getAffinity(resource.Spec, "antiAffinity")
+		if err != nil {
+			return err
+		}
+		tolerations, err := getTolerations(resource.Spec)
+		if err != nil {
+			return err
+		}
+
+		service, err := c.CreateServiceWithOptions(&proto.CreateServiceRequest{
+			Name:             name,
+			Image:            image,
+			Replicas:         int32(replicas),
+			Mode:             "replicated",
+			Env:              env,
+			Placement:        placement,
+			Labels:           resource.Metadata.Labels,
+			Affinity:         affinity,
+			AntiAffinity:     antiAffinity,
+			Tolerations:      tolerations,
+			DisruptionBudget: int32(getInt(resource.Spec, "disruptionBudget", 0)),
+			Priority:         int32(getInt(resource.Spec, "priority", 0)),
+		})
 		if err != nil {
 			return fmt.Errorf("failed to create service: %v", err)
 		}
@@ -195,3 +226,113 @@ func getInt(m map[string]interface{}, key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+// getPlacement reads a service's placement from its spec:
+//
+//	placement:
+//	  constraints:
+//	    - node.labels.zone==eu-1
+//	  preferences:
+//	    - spread: node.labels.rack
+func getPlacement(spec map[string]interface{}) (*proto.Placement, error) {
+	placementSpec, ok := spec["placement"].(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	var constraints, prefs []string
+	if list, ok := placementSpec["constraints"].([]interface{}); ok {
+		for _, c := range list {
+			constraints = append(constraints, fmt.Sprintf("%v", c))
+		}
+	}
+	if list, ok := placementSpec["preferences"].([]interface{}); ok {
+		for _, p := range list {
+			pref, ok := p.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("invalid placement preference: expected spread: node.labels.KEY")
+			}
+			prefs = append(prefs, "spread="+getString(pref, "spread", ""))
+		}
+	}
+
+	return parsePlacement(constraints, prefs)
+}
+
+// getAffinity reads affinity or anti-affinity terms from a service spec:
+//
+//	affinity:
+//	  required:
+//	    - service: app
+//	  preferred:
+//	    - labels:
+//	        tier: cache
+//	      weight: 5
+func getAffinity(spec map[string]interface{}, key string) (*proto.Affinity, error) {
+	affinitySpec, ok := spec[key].(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	affinity := &proto.Affinity{}
+	for _, kind := range []string{"required", "preferred"} {
+		list, ok := affinitySpec[kind].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, item := range list {
+			termSpec, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("invalid %s term: expected service or labels", key)
+			}
+			term := &proto.AffinityTerm{
+				Service: getString(termSpec, "service", ""),
+				Weight:  int32(getInt(termSpec, "weight", 0)),
+			}
+			if labels, ok := termSpec["labels"].(map[string]interface{}); ok {
+				term.Labels = make(map[string]string, len(labels))
+				for k, v := range labels {
+					term.Labels[k] = fmt.Sprintf("%v", v)
+				}
+			}
+			if kind == "required" {
+				affinity.Required = append(affinity.Required, term)
+			} else {
+				affinity.Preferred = append(affinity.Preferred, term)
+			}
+		}
+	}
+
+	return affinity, nil
+}
+
+// getTolerations reads the node taints a service tolerates from its spec:
+//
+//	tolerations:
+//	  - key: node-role
+//	    value: manager
+//	    effect: NoSchedule
+//	  - key: gpu
+//	    operator: Exists
+func getTolerations(spec map[string]interface{}) ([]*proto.Toleration, error) {
+	list, ok := spec["tolerations"].([]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	var tolerations []*proto.Toleration
+	for _, item := range list {
+		tolSpec, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid toleration: expected key, operator, value and effect")
+		}
+		tolerations = append(tolerations, &proto.Toleration{
+			Key:      getString(tolSpec, "key", ""),
+			Operator: getString(tolSpec, "operator", ""),
+			Value:    getString(tolSpec, "value", ""),
+			Effect:   getString(tolSpec, "effect", ""),
+		})
+	}
+
+	return tolerations, nil
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cuemby/warren/api/proto"
+	"github.com/cuemby/warren/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+var deploymentCmd = &cobra.Command{
+	Use:   "deployment",
+	Short: "Manage service deployments",
+	Long: `Manage the deployments that update services to a new image.
+
+Every 'warren service update' starts a deployment. The cluster leader
+carries it out step by step and records each step through Raft, so a
+deployment survives the loss of the manager that started it. Deployments
+can be referred to by ID or by a unique prefix of it.`,
+}
+
+var deploymentListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List deployments, newest first",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manager, _ := cmd.Flags().GetString("manager")
+		serviceName, _ := cmd.Flags().GetString("service")
+
+		c, err := client.NewClientAuto(manager)
+		if err != nil {
+			return fmt.Errorf("failed to connect to manager: %v", err)
+		}
+		defer c.Close()
+
+		serviceID := ""
+		if serviceName != "" {
+			service, err := c.GetService(serviceName)
+			if err != nil {
+				return fmt.Errorf("failed to get service: %v", err)
+			}
+			serviceID = service.Id
+		}
+
+		deployments, err := c.ListDeployments(serviceID)
+		if err != nil {
+			return fmt.Errorf("failed to list deployments: %v", err)
+		}
+		if len(deployments) == 0 {
+			fmt.Println("No deployments found")
+			return nil
+		}
+
+		fmt.Printf("%-12s %-20s %-10s %-10s %-15s %-30s %s\n", "ID", "SERVICE", "STRATEGY", "PHASE", "STEP", "IMAGE", "CREATED")
+		for _, d := range deployments {
+			fmt.Printf("%-12s %-20s %-10s %-10s %-15s %-30s %s\n",
+				truncate(d.Id, 12),
+				truncate(d.ServiceName, 20),
+				d.Strategy,
+				d.Phase,
+				deploymentStep(d),
+				truncate(d.Image, 30),
+				d.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
+			)
+		}
+		return nil
+	},
+}
+
+var deploymentInspectCmd = &cobra.Command{
+	Use:   "inspect ID",
+	Short: "Show the progress of a deployment",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manager, _ := cmd.Flags().GetString("manager")
+
+		c, err := client.NewClientAuto(manager)
+		if err != nil {
+			return fmt.Errorf("failed to connect to manager: %v", err)
+		}
+		defer c.Close()
+
+		d, err := findDeployment(c, args[0])
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Deployment: %s\n", d.Id)
+		fmt.Printf("  Service: %s\n", d.ServiceName)
+		fmt.Printf("  Strategy: %s\n", d.Strategy)
+		fmt.Printf("  Image: %s -> %s\n", d.PreviousImage, d.Image)
+		fmt.Printf("  Phase: %s\n", d.Phase)
+		fmt.Printf("  Step: %s\n", deploymentStep(d))
+		if d.Phase == "running" || d.Phase == "paused" {
+			fmt.Printf("  Step Started: %s\n", d.StepStartedAt.AsTime().Format(time.RFC3339))
+		}
+		if d.CloneServiceId != "" {
+			fmt.Printf("  New Version Service: %s\n", d.CloneServiceId)
+		}
+		fmt.Printf("  Created: %s\n", d.CreatedAt.AsTime().Format(time.RFC3339))
+		if d.CompletedAt != nil {
+			fmt.Printf("  Finished: %s\n", d.CompletedAt.AsTime().Format(time.RFC3339))
+		}
+		if d.Failures > 0 {
+			fmt.Printf("  Failed Containers: %d\n", d.Failures)
+		}
+		if d.Message != "" {
+			fmt.Printf("  Message: %s\n", d.Message)
+		}
+		return nil
+	},
+}
+
+var deploymentPauseCmd = &cobra.Command{
+	Use:   "pause ID",
+	Short: "Pause a running deployment at its current step",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return changeDeployment(cmd, args[0], "pause", (*client.Client).PauseDeployment)
+	},
+}
+
+var deploymentResumeCmd = &cobra.Command{
+	Use:   "resume ID",
+	Short: "Resume a paused deployment",
+	Long: `Resume a paused deployment.
+
+The wait of the current step starts over: a canary gets a full stability
+window again before it is analyzed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return changeDeployment(cmd, args[0], "resume", (*client.Client).ResumeDeployment)
+	},
+}
+
+var deploymentAbortCmd = &cobra.Command{
+	Use:   "abort ID",
+	Short: "Abort a deployment and return the service to its previous version",
+	Long: `Abort a running or paused deployment.
+
+A blue-green or canary deployment removes the new version and sends all
+traffic back to the stable service. A rolling update restores the
+previous image and replaces the containers already updated.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return changeDeployment(cmd, args[0], "abort", (*client.Client).AbortDeployment)
+	},
+}
+
+// changeDeployment finds a deployment and applies a pause, resume or abort to it
+func changeDeployment(cmd *cobra.Command, idOrPrefix, action string, apply func(*client.Client, string) (*proto.Deployment, error)) error {
+	manager, _ := cmd.Flags().GetString("manager")
+
+	c, err := client.NewClientAuto(manager)
+	if err != nil {
+		return fmt.Errorf("failed to connect to manager: %v", err)
+	}
+	defer c.Close()
+
+	d, err := findDeployment(c, idOrPrefix)
+	if err != nil {
+		return err
+	}
+
+	d, err = apply(c, d.Id)
+	if err != nil {
+		return fmt.Errorf("failed to %s deployment: %v", action, err)
+	}
+
+	fmt.Printf("✓ Deployment %s of %s is %s\n", truncate(d.Id, 12), d.ServiceName, d.Phase)
+	return nil
+}
+
+// findDeployment returns the deployment with an ID, or the only one whose
+// ID starts with it
+func findDeployment(c *client.Client, idOrPrefix string) (*proto.Deployment, error) {
+	if d, err := c.GetDeployment(idOrPrefix); err == nil {
+		return d, nil
+	}
+
+	deployments, err := c.ListDeployments("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %v", err)
+	}
+
+	var found *proto.Deployment
+	for _, d := range deployments {
+		if !strings.HasPrefix(d.Id, idOrPrefix) {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("deployment ID %s is ambiguous", idOrPrefix)
+		}
+		found = d
+	}
+	if found == nil {
+		return nil, fmt.Errorf("deployment not found: %s", idOrPrefix)
+	}
+	return found, nil
+}
+
+// deploymentStep describes the current step of a deployment
+func deploymentStep(d *proto.Deployment) string {
+	switch d.Phase {
+	case "completed", "failed", "aborted":
+		return "-"
+	}
+	switch d.Step {
+	case "replace-batch":
+		return fmt.Sprintf("%s %d", d.Step, d.StepIndex+1)
+	case "scale-canary", "wait-healthy", "stabilize", "switch-traffic":
+		if d.Strategy == "canary" {
+			return fmt.Sprintf("%s %d", d.Step, d.StepIndex+1)
+		}
+	}
+	return d.Step
+}
+
+func init() {
+	rootCmd.AddCommand(deploymentCmd)
+	deploymentCmd.AddCommand(deploymentListCmd)
+	deploymentCmd.AddCommand(deploymentInspectCmd)
+	deploymentCmd.AddCommand(deploymentPauseCmd)
+	deploymentCmd.AddCommand(deploymentResumeCmd)
+	deploymentCmd.AddCommand(deploymentAbortCmd)
+
+	for _, cmd := range []*cobra.Command{deploymentListCmd, deploymentInspectCmd, deploymentPauseCmd, deploymentResumeCmd, deploymentAbortCmd} {
+		cmd.Flags().String("manager", "127.0.0.1:8080", "Manager address")
+	}
+
+	deploymentListCmd.Flags().String("service", "", "Only list deployments of this service")
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/cuemby/warren/api/proto"
+	"github.com/cuemby/warren/pkg/client"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Show cluster events",
+	Long: `Show recent cluster events, optionally following new events as they happen.
+
+Events are kept in a bounded in-memory buffer on each manager. When following,
+the stream is automatically resumed from the last received event after a
+reconnect, so no buffered events are missed. If that event is no longer
+buffered, for example because the manager restarted, a warning is printed and
+only new events are shown.
+
+Examples:
+  # Show recent events
+  warren events
+
+  # Follow events for a single service
+  warren events --follow --service web
+
+  # Follow container failures on a node
+  warren events --follow --type task.failed --node worker-1
+
+  # Resume from a known event ID
+  warren events --follow --since m4x2k9qz1c-1042`,
+	RunE: runEvents,
+}
+
+func init() {
+	eventsCmd.Flags().String("manager", "127.0.0.1:8080", "Manager address")
+	eventsCmd.Flags().BoolP("follow", "f", false, "Keep streaming new events")
+	eventsCmd.Flags().StringSlice("type", []string{}, "Filter by event type (e.g., service.created, task.failed)")
+	eventsCmd.Flags().String("service", "", "Filter by service name or ID")
+	eventsCmd.Flags().String("node", "", "Filter by node ID")
+	eventsCmd.Flags().String("since", "", "Only show events after this event ID")
+
+	rootCmd.AddCommand(eventsCmd)
+}
+
+func runEvents(cmd *cobra.Command, args []string) error {
+	managerAddr, _ := cmd.Flags().GetString("manager")
+	follow, _ := cmd.Flags().GetBool("follow")
+	eventTypes, _ := cmd.Flags().GetStringSlice("type")
+	service, _ := cmd.Flags().GetString("service")
+	nodeID, _ := cmd.Flags().GetString("node")
+	since, _ := cmd.Flags().GetString("since")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	lastID := since
+	printEvent := func(e *proto.Event) error {
+		lastID = e.Id
+		fmt.Println(formatEvent(e))
+		return nil
+	}
+
+	backoff := time.Second
+	for {
+		err := streamEventsOnce(ctx, managerAddr, &proto.StreamEventsRequest{
+			EventTypes:   eventTypes,
+			Service:      service,
+			NodeId:       nodeID,
+			SinceEventId: lastID,
+			Follow:       follow,
+		}, printEvent)
+
+		if !follow || ctx.Err() != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		// The manager no longer has the events since lastID
+		if status.Code(err) == codes.OutOfRange {
+			fmt.Fprintf(os.Stderr, "Warning: cannot resume after event %s, events may have been missed; showing new events\n", lastID)
+			lastID = ""
+			continue
+		}
+
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Event stream interrupted: %v (reconnecting in %s)\n", err, backoff)
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil
+		}
+		if backoff < 30*time.Second {
+			backoff *= 2
+		}
+	}
+}
+
+// streamEventsOnce opens a single event stream and consumes it until it ends
+func streamEventsOnce(ctx context.Context, managerAddr string, req *proto.StreamEventsRequest, handler func(*proto.Event) error) error {
+	c, err := client.NewClientAuto(managerAddr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to manager: %v", err)
+	}
+	defer c.Close()
+
+	if err := c.StreamEvents(ctx, req, handler); err != nil {
+		return fmt.Errorf("failed to stream events: %w", err)
+	}
+	return nil
+}
+
+// formatEvent renders an event as a single line
+func formatEvent(e *proto.Event) string {
+	keys := make([]string, 0, len(e.Metadata))
+	for k := range e.Metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	attrs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if e.Metadata[k] == "" {
+			continue
+		}
+		attrs = append(attrs, fmt.Sprintf("%s=%s", k, e.Metadata[k]))
+	}
+
+	return fmt.Sprintf("%s [%s] %-18s %s (%s)",
+		e.Timestamp.AsTime().Local().Format(time.RFC3339),
+		e.Id,
+		e.Type,
+		e.Message,
+		strings.Join(attrs, ", "))
+}
@@ -0,0 +1,338 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cuemby/warren/api/proto"
+	"github.com/cuemby/warren/pkg/client"
+	"github.com/cuemby/warren/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var jobCmd = &cobra.Command{
+	Use:   "job",
+	Short: "Manage jobs and cronjobs",
+	Long: `Manage services that run to completion.
+
+A job runs containers until enough of them exit successfully. A cronjob
+starts a new job on a schedule (five cron fields, in UTC).`,
+}
+
+var jobCreateCmd = &cobra.Command{
+	Use:   "create NAME",
+	Short: "Create a job, or a cronjob with --schedule",
+	Long: `Create a job, or a cronjob with --schedule.
+
+Examples:
+  # Run a database migration once
+  warren job create migrate --image myapp:1.4 --env DB_HOST=db
+
+  # Process a queue with 3 containers at a time until 10 succeed
+  warren job create import --image importer:2.0 --completions 10 --parallelism 3
+
+  # Run a report every night at 02:00 UTC, never two at once
+  warren job create report --image report:1.0 --schedule "0 2 * * *" --concurrency-policy Forbid`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		manager, _ := cmd.Flags().GetString("manager")
+		image, _ := cmd.Flags().GetString("image")
+		envVars, _ := cmd.Flags().GetStringSlice("env")
+		restart, _ := cmd.Flags().GetString("restart")
+		completions, _ := cmd.Flags().GetInt("completions")
+		parallelism, _ := cmd.Flags().GetInt("parallelism")
+		activeDeadline, _ := cmd.Flags().GetDuration("active-deadline")
+		schedule, _ := cmd.Flags().GetString("schedule")
+		concurrencyPolicy, _ := cmd.Flags().GetString("concurrency-policy")
+
+		env := make(map[string]string)
+		for _, e := range envVars {
+			parts := splitEnv(e)
+			if len(parts) == 2 {
+				env[parts[0]] = parts[1]
+			}
+		}
+
+		req := &proto.CreateServiceRequest{
+			Name:  name,
+			Image: image,
+			Mode:  string(types.ServiceModeJob),
+			Env:   env,
+			Job: &proto.JobConfig{
+				Completions:           int32(completions),
+				Parallelism:           int32(parallelism),
+				BackoffLimit:          flagInt32(cmd, "backoff-limit"),
+				ActiveDeadlineSeconds: int64(activeDeadline / time.Second),
+			},
+		}
+		switch restart {
+		case "never", "":
+		case string(types.RestartOnFailure):
+			req.RestartPolicy = &proto.RestartPolicy{Condition: restart}
+		default:
+			return fmt.Errorf("invalid restart policy '%s': use never or on-failure", restart)
+		}
+		if schedule != "" {
+			req.Mode = string(types.ServiceModeCronJob)
+			req.CronJob = &proto.CronJobConfig{
+				Schedule:                   schedule,
+				ConcurrencyPolicy:          concurrencyPolicy,
+				SuccessfulJobsHistoryLimit: flagInt32(cmd, "successful-history"),
+				FailedJobsHistoryLimit:     flagInt32(cmd, "failed-history"),
+			}
+		}
+
+		c, err := client.NewClientAuto(manager)
+		if err != nil {
+			return fmt.Errorf("failed to connect to manager: %v", err)
+		}
+		defer c.Close()
+
+		job, err := c.CreateServiceWithOptions(req)
+		if err != nil {
+			return fmt.Errorf("failed to create job: %v", err)
+		}
+
+		if job.CronJob != nil {
+			fmt.Printf("✓ Cronjob created: %s\n", job.Name)
+			fmt.Printf("  Schedule: %s (UTC)\n", job.CronJob.Schedule)
+		} else {
+			fmt.Printf("✓ Job created: %s\n", job.Name)
+		}
+		fmt.Printf("  ID: %s\n", job.Id)
+		fmt.Printf("  Image: %s\n", job.Image)
+		return nil
+	},
+}
+
+var jobListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List jobs and cronjobs",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manager, _ := cmd.Flags().GetString("manager")
+
+		c, err := client.NewClientAuto(manager)
+		if err != nil {
+			return fmt.Errorf("failed to connect to manager: %v", err)
+		}
+		defer c.Close()
+
+		services, err := c.ListServices()
+		if err != nil {
+			return fmt.Errorf("failed to list services: %v", err)
+		}
+
+		var jobs []*proto.Service
+		for _, svc := range services {
+			if svc.Mode == string(types.ServiceModeJob) || svc.Mode == string(types.ServiceModeCronJob) {
+				jobs = append(jobs, svc)
+			}
+		}
+		if len(jobs) == 0 {
+			fmt.Println("No jobs found")
+			return nil
+		}
+
+		fmt.Printf("%-30s %-8s %-15s %-10s %-12s %s\n", "NAME", "TYPE", "SCHEDULE", "STATUS", "COMPLETIONS", "CREATED")
+		for _, job := range jobs {
+			schedule, status, completions := "-", "-", "-"
+			if job.CronJob != nil {
+				schedule = job.CronJob.Schedule
+			}
+			if job.Mode == string(types.ServiceModeJob) {
+				status = string(types.JobStateRunning)
+				succeeded := int32(0)
+				if job.JobStatus != nil {
+					status = job.JobStatus.State
+					succeeded = job.JobStatus.Succeeded
+				}
+				completions = fmt.Sprintf("%d/%d", succeeded, jobCompletions(job))
+			}
+			fmt.Printf("%-30s %-8s %-15s %-10s %-12s %s\n",
+				truncate(job.Name, 30),
+				job.Mode,
+				truncate(schedule, 15),
+				status,
+				completions,
+				job.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
+			)
+		}
+		return nil
+	},
+}
+
+var jobInspectCmd = &cobra.Command{
+	Use:   "inspect NAME",
+	Short: "Inspect a job or cronjob",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		manager, _ := cmd.Flags().GetString("manager")
+
+		c, err := client.NewClientAuto(manager)
+		if err != nil {
+			return fmt.Errorf("failed to connect to manager: %v", err)
+		}
+		defer c.Close()
+
+		job, err := c.GetService(name)
+		if err != nil {
+			return fmt.Errorf("failed to get job: %v", err)
+		}
+		if job.Mode != string(types.ServiceModeJob) && job.Mode != string(types.ServiceModeCronJob) {
+			return fmt.Errorf("%s is a %s service, not a job", job.Name, job.Mode)
+		}
+
+		fmt.Printf("Job: %s\n", job.Name)
+		fmt.Printf("  ID: %s\n", job.Id)
+		fmt.Printf("  Image: %s\n", job.Image)
+		fmt.Printf("  Mode: %s\n", job.Mode)
+		if cronJob := job.Labels[types.LabelCronJob]; cronJob != "" {
+			fmt.Printf("  Cronjob: %s\n", cronJob)
+		}
+
+		cfg := job.Job
+		if cfg == nil {
+			cfg = &proto.JobConfig{}
+		}
+		fmt.Printf("  Completions: %d\n", jobCompletions(job))
+		if cfg.Parallelism > 0 {
+			fmt.Printf("  Parallelism: %d\n", cfg.Parallelism)
+		}
+		if cfg.BackoffLimit != nil {
+			fmt.Printf("  Backoff Limit: %d\n", *cfg.BackoffLimit)
+		}
+		if cfg.ActiveDeadlineSeconds > 0 {
+			fmt.Printf("  Active Deadline: %s\n", time.Duration(cfg.ActiveDeadlineSeconds)*time.Second)
+		}
+
+		if cron := job.CronJob; cron != nil {
+			policy := cron.ConcurrencyPolicy
+			if policy == "" {
+				policy = string(types.ConcurrencyAllow)
+			}
+			fmt.Printf("  Schedule: %s (UTC)\n", cron.Schedule)
+			fmt.Printf("  Concurrency Policy: %s\n", policy)
+			if job.LastScheduleTime != nil {
+				fmt.Printf("  Last Schedule: %s\n", job.LastScheduleTime.AsTime().Format(time.RFC3339))
+			}
+
+			services, err := c.ListServices()
+			if err != nil {
+				return fmt.Errorf("failed to list services: %v", err)
+			}
+			var runs []string
+			for _, svc := range services {
+				if svc.Labels[types.LabelCronJob] == job.Name {
+					state := string(types.JobStateRunning)
+					if svc.JobStatus != nil {
+						state = svc.JobStatus.State
+					}
+					runs = append(runs, fmt.Sprintf("    %s (%s)", svc.Name, state))
+				}
+			}
+			if len(runs) > 0 {
+				fmt.Println("  Jobs:")
+				fmt.Println(strings.Join(runs, "\n"))
+			}
+			return nil
+		}
+
+		if status := job.JobStatus; status != nil {
+			fmt.Printf("  Status: %s\n", status.State)
+			fmt.Printf("  Active: %d, Succeeded: %d, Failed: %d\n", status.Active, status.Succeeded, status.Failed)
+			if status.StartTime != nil {
+				fmt.Printf("  Started: %s\n", status.StartTime.AsTime().Format(time.RFC3339))
+			}
+			if status.CompletionTime != nil {
+				fmt.Printf("  Finished: %s\n", status.CompletionTime.AsTime().Format(time.RFC3339))
+			}
+			if status.Reason != "" {
+				fmt.Printf("  Reason: %s\n", status.Reason)
+			}
+		} else {
+			fmt.Printf("  Status: %s\n", types.JobStateRunning)
+		}
+		return nil
+	},
+}
+
+var jobDeleteCmd = &cobra.Command{
+	Use:   "delete NAME",
+	Short: "Delete a job, or a cronjob and the jobs it started",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		manager, _ := cmd.Flags().GetString("manager")
+
+		c, err := client.NewClientAuto(manager)
+		if err != nil {
+			return fmt.Errorf("failed to connect to manager: %v", err)
+		}
+		defer c.Close()
+
+		job, err := c.GetService(name)
+		if err != nil {
+			return fmt.Errorf("failed to find job: %v", err)
+		}
+		if job.Mode != string(types.ServiceModeJob) && job.Mode != string(types.ServiceModeCronJob) {
+			return fmt.Errorf("%s is a %s service, not a job: use warren service delete", job.Name, job.Mode)
+		}
+
+		if err := c.DeleteService(job.Id); err != nil {
+			return fmt.Errorf("failed to delete job: %v", err)
+		}
+
+		fmt.Printf("✓ Job deleted: %s\n", name)
+		return nil
+	},
+}
+
+// jobCompletions returns the successful containers a job needs
+func jobCompletions(job *proto.Service) int32 {
+	if job.Job != nil && job.Job.Completions > 0 {
+		return job.Job.Completions
+	}
+	return 1
+}
+
+// flagInt32 returns the value of an int flag, or nil if it was not set, so
+// that an explicit 0 is told apart from the default
+func flagInt32(cmd *cobra.Command, name string) *int32 {
+	if !cmd.Flags().Changed(name) {
+		return nil
+	}
+	value, _ := cmd.Flags().GetInt(name)
+	v := int32(value)
+	return &v
+}
+
+func init() {
+	rootCmd.AddCommand(jobCmd)
+	jobCmd.AddCommand(jobCreateCmd)
+	jobCmd.AddCommand(jobListCmd)
+	jobCmd.AddCommand(jobInspectCmd)
+	jobCmd.AddCommand(jobDeleteCmd)
+
+	for _, cmd := range []*cobra.Command{jobCreateCmd, jobListCmd, jobInspectCmd, jobDeleteCmd} {
+		cmd.Flags().String("manager", "127.0.0.1:8080", "Manager address")
+	}
+
+	jobCreateCmd.Flags().String("image", "", "Container image")
+	jobCreateCmd.Flags().StringSlice("env", []string{}, "Environment variables (KEY=VALUE)")
+	jobCreateCmd.Flags().String("restart", "never", "Restart a failing container in place: never or on-failure")
+	jobCreateCmd.Flags().Int("completions", 0, "Successful containers needed (default: 1)")
+	jobCreateCmd.Flags().Int("parallelism", 0, "Containers running at once (default: 1)")
+	jobCreateCmd.Flags().Int("backoff-limit", 0, "Failed containers tolerated before the job fails (default: 6)")
+	jobCreateCmd.Flags().Duration("active-deadline", 0, "Longest the job may run before it fails (e.g., 30m)")
+
+	// Cronjob flags
+	jobCreateCmd.Flags().String("schedule", "", "Run as a cronjob on this schedule, in UTC (e.g., \"0 2 * * *\", @hourly)")
+	jobCreateCmd.Flags().String("concurrency-policy", "", "When the previous job still runs: Allow (default), Forbid or Replace")
+	jobCreateCmd.Flags().Int("successful-history", 0, "Completed jobs a cronjob keeps (default: 3)")
+	jobCreateCmd.Flags().Int("failed-history", 0, "Failed jobs a cronjob keeps (default: 1)")
+
+	_ = jobCreateCmd.MarkFlagRequired("image")
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"github.com/cuemby/warren/pkg/runtime"
+	"github.com/spf13/cobra"
+)
+
+// containerLoggerCmd is started by containerd's shim for each task to write
+// the task's output to its log file (see runtime.LoggerURI)
+var containerLoggerCmd = &cobra.Command{
+	Use:    runtime.LoggerCommand + " <config>",
+	Short:  "Write a container's output to its log file",
+	Hidden: true,
+	Args:   cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := runtime.ParseLoggerConfig(args[0])
+		if err != nil {
+			return err
+		}
+		runtime.RunLogger(config)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(containerLoggerCmd)
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/cuemby/warren/api/proto"
+	"github.com/cuemby/warren/pkg/client"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var serviceLogsCmd = &cobra.Command{
+	Use:   "logs NAME",
+	Short: "Show the logs of a service",
+	Long: `Show the output of every replica of a service, interleaved and prefixed
+with the replica it came from.
+
+Examples:
+  # Show all captured logs
+  warren service logs web
+
+  # Follow new output, starting with the last 20 lines of each replica
+  warren service logs web --follow --tail 20
+
+  # Show output from the last 10 minutes with timestamps
+  warren service logs web --since 10m --timestamps`,
+	Args: cobra.ExactArgs(1),
+	RunE: runServiceLogs,
+}
+
+func init() {
+	serviceLogsCmd.Flags().String("manager", "127.0.0.1:8080", "Manager address")
+	serviceLogsCmd.Flags().BoolP("follow", "f", false, "Keep streaming new output")
+	serviceLogsCmd.Flags().String("since", "", "Only show logs since a duration (e.g., 10m) or RFC3339 timestamp")
+	serviceLogsCmd.Flags().Int("tail", 0, "Number of lines to show from the end of each replica's logs (0 = all)")
+	serviceLogsCmd.Flags().BoolP("timestamps", "t", false, "Show timestamps")
+
+	serviceCmd.AddCommand(serviceLogsCmd)
+}
+
+func runServiceLogs(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	managerAddr, _ := cmd.Flags().GetString("manager")
+	follow, _ := cmd.Flags().GetBool("follow")
+	sinceStr, _ := cmd.Flags().GetString("since")
+	tail, _ := cmd.Flags().GetInt("tail")
+	timestamps, _ := cmd.Flags().GetBool("timestamps")
+
+	req := &proto.StreamServiceLogsRequest{
+		ServiceName: name,
+		Follow:      follow,
+		Tail:        int32(tail),
+	}
+	if sinceStr != "" {
+		since, err := parseSince(sinceStr)
+		if err != nil {
+			return err
+		}
+		req.Since = timestamppb.New(since)
+	}
+
+	c, err := client.NewClientAuto(managerAddr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to manager: %v", err)
+	}
+	defer c.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err = c.StreamServiceLogs(ctx, req, func(entry *proto.LogEntry) error {
+		fmt.Println(formatLogEntry(entry, timestamps))
+		return nil
+	})
+	if err != nil && ctx.Err() == nil {
+		return fmt.Errorf("failed to stream logs: %v", err)
+	}
+	return nil
+}
+
+// parseSince accepts a duration relative to now or an RFC3339 timestamp
+func parseSince(value string) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --since value %q: use a duration (10m) or RFC3339 timestamp", value)
+	}
+	return t, nil
+}
+
+// formatLogEntry renders a log line prefixed with its replica
+func formatLogEntry(entry *proto.LogEntry, timestamps bool) string {
+	prefix := entry.Replica
+	if prefix == "" {
+		prefix = entry.ContainerId
+	}
+	if entry.NodeId != "" {
+		prefix = fmt.Sprintf("%s@%s", prefix, entry.NodeId)
+	}
+
+	line := entry.Line
+	if entry.Stream == "warren" {
+		line = "[warren] " + line
+	}
+
+	if timestamps && entry.Timestamp != nil {
+		return fmt.Sprintf("%s | %s %s", prefix, entry.Timestamp.AsTime().Local().Format(time.RFC3339Nano), line)
+	}
+	return fmt.Sprintf("%s | %s", prefix, line)
+}
@@ -3,12 +3,14 @@ package main
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	_ "net/http/pprof" // Import pprof for profiling endpoints
 	"os"
 	"os/signal"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"syscall"
 	"time"
@@ -16,10 +18,14 @@ import (
 	"github.com/cuemby/warren/api/proto"
 	"github.com/cuemby/warren/pkg/api"
 	"github.com/cuemby/warren/pkg/client"
+	"github.com/cuemby/warren/pkg/convert"
+	"github.com/cuemby/warren/pkg/deploy"
 	"github.com/cuemby/warren/pkg/embedded"
+	"github.com/cuemby/warren/pkg/jobs"
 	"github.com/cuemby/warren/pkg/log"
 	"github.com/cuemby/warren/pkg/manager"
 	"github.com/cuemby/warren/pkg/metrics"
+	"github.com/cuemby/warren/pkg/network"
 	"github.com/cuemby/warren/pkg/reconciler"
 	"github.com/cuemby/warren/pkg/scheduler"
 	"github.com/cuemby/warren/pkg/types"
@@ -140,10 +146,21 @@ automatically form a Raft quorum once additional managers join.`,
 		}
 
 		// Create manager
+		clusterSubnet, _ := cmd.Flags().GetString("cluster-subnet")
+		serviceSubnet, _ := cmd.Flags().GetString("service-subnet")
+		if err := network.ValidateServiceSubnet(serviceSubnet); err != nil {
+			return err
+		}
+		schedulerCfg, err := schedulerConfig(cmd)
+		if err != nil {
+			return err
+		}
 		mgr, err := manager.NewManager(&manager.Config{
-			NodeID:   nodeID,
-			BindAddr: bindAddr,
-			DataDir:  dataDir,
+			NodeID:        nodeID,
+			BindAddr:      bindAddr,
+			DataDir:       dataDir,
+			ClusterSubnet: clusterSubnet,
+			ServiceSubnet: serviceSubnet,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to create manager: %v", err)
@@ -162,6 +179,9 @@ automatically form a Raft quorum once additional managers join.`,
 
 		// Start scheduler
 		sched := scheduler.NewScheduler(mgr)
+		if err := sched.Configure(schedulerCfg); err != nil {
+			return fmt.Errorf("failed to configure scheduler: %v", err)
+		}
 		sched.Start()
 		fmt.Println("✓ Scheduler started")
 
@@ -170,6 +190,16 @@ automatically form a Raft quorum once additional managers join.`,
 		recon.Start()
 		fmt.Println("✓ Reconciler started")
 
+		// Start job controller
+		jobController := jobs.NewController(mgr)
+		jobController.Start()
+		fmt.Println("✓ Job controller started")
+
+		// Start deployment controller
+		deployController := deploy.NewController(mgr.GetDeployer(), mgr)
+		deployController.Start()
+		fmt.Println("✓ Deployment controller started")
+
 		// Start metrics collector
 		metricsCollector := manager.NewMetricsCollector(mgr)
 		metricsCollector.Start()
@@ -214,6 +244,8 @@ automatically form a Raft quorum once additional managers join.`,
 		if err != nil {
 			return fmt.Errorf("failed to create API server: %v", err)
 		}
+		apiServer.SetScheduler(sched)
+		apiServer.SetAdvertiseAddr(advertisedAPIAddr(apiAddr, bindAddr))
 		errCh := make(chan error, 2) // Buffered for both servers
 
 		// Start TCP listener (mTLS)
@@ -260,11 +292,15 @@ automatically form a Raft quorum once additional managers join.`,
 			}
 
 			// Create embedded worker
+			// Other nodes reach the embedded worker at the Raft bind host
+			advertiseAddr, _, _ := net.SplitHostPort(bindAddr)
+
 			embeddedWorker, err = worker.NewEmbeddedWorker(&worker.Config{
 				NodeID:           nodeID,
 				ManagerAddr:      localManagerAddr,
 				DataDir:          filepath.Join(dataDir, "worker"),
 				ContainerdSocket: containerdMgr.GetSocketPath(),
+				AdvertiseAddr:    advertiseAddr,
 			})
 			if err != nil {
 				return fmt.Errorf("failed to create embedded worker: %w", err)
@@ -359,6 +395,8 @@ automatically form a Raft quorum once additional managers join.`,
 		// Shutdown
 		sched.Stop()
 		recon.Stop()
+		jobController.Stop()
+		deployController.Stop()
 		metricsCollector.Stop()
 		mgr.StopIngress()
 		apiServer.Stop()
@@ -396,19 +434,126 @@ var clusterJoinTokenCmd = &cobra.Command{
 			return fmt.Errorf("failed to generate token: %v", err)
 		}
 
-		fmt.Printf("Join token for %s:\n\n", role)
-		fmt.Printf("    %s\n\n", token)
-		fmt.Println("This token expires in 24 hours.")
-		fmt.Printf("\nTo join a %s to the cluster, run:\n", role)
-		if role == "manager" {
-			fmt.Printf("    warren manager join --token %s --leader %s\n", token, manager)
-		} else {
-			fmt.Printf("    warren worker start --manager %s --token %s\n", manager, token)
+		printJoinToken(role, token, manager)
+		return nil
+	},
+}
+
+var clusterJoinTokenListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List join tokens",
+	Long: `List the cluster's join tokens.
+
+Tokens are stored hashed, so only their IDs are shown. Use an ID with
+'warren cluster join-token revoke' to invalidate a token.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manager, _ := cmd.Flags().GetString("manager")
+
+		// Connect to manager
+		c, err := client.NewClientAuto(manager)
+		if err != nil {
+			return fmt.Errorf("failed to connect to manager: %v", err)
+		}
+		defer c.Close()
+
+		tokens, err := c.ListJoinTokens()
+		if err != nil {
+			return fmt.Errorf("failed to list join tokens: %v", err)
+		}
+
+		if len(tokens) == 0 {
+			fmt.Println("No join tokens found")
+			return nil
+		}
+
+		fmt.Printf("%-14s %-10s %-22s %-22s %s\n", "ID", "ROLE", "CREATED", "EXPIRES", "STATUS")
+		for _, token := range tokens {
+			status := "active"
+			if time.Now().After(token.ExpiresAt.AsTime()) {
+				status = "expired"
+			}
+			fmt.Printf("%-14s %-10s %-22s %-22s %s\n",
+				token.Id,
+				token.Role,
+				token.CreatedAt.AsTime().Local().Format("2006-01-02 15:04:05"),
+				token.ExpiresAt.AsTime().Local().Format("2006-01-02 15:04:05"),
+				status)
+		}
+		return nil
+	},
+}
+
+var clusterJoinTokenRevokeCmd = &cobra.Command{
+	Use:   "revoke ID",
+	Short: "Revoke a join token",
+	Long: `Revoke a join token so it can no longer be used to join the cluster.
+
+ID is a token ID from 'warren cluster join-token list', or the token itself.
+Nodes that already joined are not affected.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manager, _ := cmd.Flags().GetString("manager")
+
+		// Connect to manager
+		c, err := client.NewClientAuto(manager)
+		if err != nil {
+			return fmt.Errorf("failed to connect to manager: %v", err)
+		}
+		defer c.Close()
+
+		if err := c.RevokeJoinToken(args[0]); err != nil {
+			return fmt.Errorf("failed to revoke join token: %v", err)
+		}
+
+		fmt.Printf("✓ Join token %s revoked\n", args[0])
+		return nil
+	},
+}
+
+var clusterJoinTokenRotateCmd = &cobra.Command{
+	Use:   "rotate [worker|manager]",
+	Short: "Revoke all join tokens for a role and generate a new one",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		role := args[0]
+		if role != "worker" && role != "manager" {
+			return fmt.Errorf("role must be 'worker' or 'manager'")
+		}
+
+		manager, _ := cmd.Flags().GetString("manager")
+
+		// Connect to manager
+		c, err := client.NewClientAuto(manager)
+		if err != nil {
+			return fmt.Errorf("failed to connect to manager: %v", err)
+		}
+		defer c.Close()
+
+		resp, err := c.RotateJoinToken(role)
+		if err != nil {
+			return fmt.Errorf("failed to rotate token: %v", err)
 		}
+
+		fmt.Printf("✓ Previous %s join tokens revoked\n\n", role)
+		printJoinToken(role, resp.Token, manager)
 		return nil
 	},
 }
 
+// printJoinToken prints a newly generated join token with join instructions
+func printJoinToken(role, token, manager string) {
+	fmt.Printf("Join token for %s:\n\n", role)
+	fmt.Printf("    %s\n\n", token)
+	fmt.Println("This token expires in 24 hours.")
+	fmt.Printf("\nTo join a %s to the cluster, run:\n", role)
+	if role == "manager" {
+		fmt.Printf("    warren manager join --token %s --leader %s\n", token, manager)
+	} else {
+		fmt.Printf("    warren worker start --manager %s --token %s\n", manager, token)
+	}
+}
+
 var clusterJoinCmd = &cobra.Command{
 	Use:   "join --token TOKEN",
 	Short: "Join this node to an existing cluster",
@@ -497,12 +642,20 @@ func init() {
 
 	clusterCmd.AddCommand(clusterInitCmd)
 	clusterCmd.AddCommand(clusterJoinTokenCmd)
+	clusterJoinTokenCmd.AddCommand(clusterJoinTokenListCmd)
+	clusterJoinTokenCmd.AddCommand(clusterJoinTokenRevokeCmd)
+	clusterJoinTokenCmd.AddCommand(clusterJoinTokenRotateCmd)
 	clusterCmd.AddCommand(clusterJoinCmd)
 	clusterCmd.AddCommand(clusterInfoCmd)
 
 	// Flags for init command
 	clusterInitCmd.Flags().String("node-id", "manager-1", "Unique node ID")
 	clusterInitCmd.Flags().String("bind-addr", "127.0.0.1:7946", "Address for Raft communication")
+	clusterInitCmd.Flags().String("cluster-subnet", network.DefaultClusterSubnet, "Overlay subnet for node IPs (must match on all managers)")
+	clusterInitCmd.Flags().String("service-subnet", network.DefaultServiceSubnet, "Subnet reserved for service VIPs, at most a /23 (must match on all managers)")
+	clusterInitCmd.Flags().String("scheduler-strategy", string(scheduler.StrategySpread), "How to rank nodes that fit a container: spread, binpack or least-allocated")
+	clusterInitCmd.Flags().String("scheduler-config", "", "Scheduler plugin configuration file (YAML, must match on all managers)")
+	clusterInitCmd.Flags().Bool("auto-rebalance", false, "Move replicas off overloaded nodes to even out every service's spread (must match on all managers)")
 	clusterInitCmd.Flags().String("api-addr", "127.0.0.1:8080", "Address for gRPC API")
 	clusterInitCmd.Flags().String("data-dir", "./warren-data", "Data directory for cluster state")
 	clusterInitCmd.Flags().Bool("manager-only", false, "Start as manager-only (no workloads). Default is hybrid mode (manager+worker)")
@@ -510,6 +663,9 @@ func init() {
 
 	// Flags for join-token and info commands
 	clusterJoinTokenCmd.Flags().String("manager", "127.0.0.1:8080", "Manager address")
+	clusterJoinTokenListCmd.Flags().String("manager", "127.0.0.1:8080", "Manager address")
+	clusterJoinTokenRevokeCmd.Flags().String("manager", "127.0.0.1:8080", "Manager address")
+	clusterJoinTokenRotateCmd.Flags().String("manager", "127.0.0.1:8080", "Manager address")
 	clusterInfoCmd.Flags().String("manager", "127.0.0.1:8080", "Manager address")
 
 	// Flags for join command
@@ -565,11 +721,13 @@ var workerStartCmd = &cobra.Command{
 		}
 
 		// Create worker
+		advertiseAddr, _ := cmd.Flags().GetString("advertise-addr")
 		w, err := worker.NewWorker(&worker.Config{
 			NodeID:           nodeID,
 			ManagerAddr:      managerAddr,
 			DataDir:          dataDir,
 			ContainerdSocket: containerdMgr.GetSocketPath(),
+			AdvertiseAddr:    advertiseAddr,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to create worker: %v", err)
@@ -628,6 +786,7 @@ func init() {
 	workerStartCmd.Flags().Int("cpu", 4, "CPU cores")
 	workerStartCmd.Flags().Int("memory", 8, "Memory in GB")
 	workerStartCmd.Flags().String("token", "", "Join token from manager (required for first connection)")
+	workerStartCmd.Flags().String("advertise-addr", "", "Address other nodes use to reach this worker's overlay endpoint (default: as seen by the manager)")
 	workerStartCmd.Flags().Bool("enable-pprof", false, "Enable pprof profiling endpoints")
 }
 
@@ -663,10 +822,21 @@ var managerJoinCmd = &cobra.Command{
 		fmt.Println()
 
 		// Create manager
+		clusterSubnet, _ := cmd.Flags().GetString("cluster-subnet")
+		serviceSubnet, _ := cmd.Flags().GetString("service-subnet")
+		if err := network.ValidateServiceSubnet(serviceSubnet); err != nil {
+			return err
+		}
+		schedulerCfg, err := schedulerConfig(cmd)
+		if err != nil {
+			return err
+		}
 		mgr, err := manager.NewManager(&manager.Config{
-			NodeID:   nodeID,
-			BindAddr: bindAddr,
-			DataDir:  dataDir,
+			NodeID:        nodeID,
+			BindAddr:      bindAddr,
+			DataDir:       dataDir,
+			ClusterSubnet: clusterSubnet,
+			ServiceSubnet: serviceSubnet,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to create manager: %v", err)
@@ -681,6 +851,9 @@ var managerJoinCmd = &cobra.Command{
 
 		// Start scheduler
 		sched := scheduler.NewScheduler(mgr)
+		if err := sched.Configure(schedulerCfg); err != nil {
+			return fmt.Errorf("failed to configure scheduler: %v", err)
+		}
 		sched.Start()
 		fmt.Println("✓ Scheduler started")
 
@@ -689,6 +862,16 @@ var managerJoinCmd = &cobra.Command{
 		recon.Start()
 		fmt.Println("✓ Reconciler started")
 
+		// Start job controller
+		jobController := jobs.NewController(mgr)
+		jobController.Start()
+		fmt.Println("✓ Job controller started")
+
+		// Start deployment controller
+		deployController := deploy.NewController(mgr.GetDeployer(), mgr)
+		deployController.Start()
+		fmt.Println("✓ Deployment controller started")
+
 		// Start metrics collector
 		metricsCollector := manager.NewMetricsCollector(mgr)
 		metricsCollector.Start()
@@ -733,6 +916,8 @@ var managerJoinCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("failed to create API server: %v", err)
 		}
+		apiServer.SetScheduler(sched)
+		apiServer.SetAdvertiseAddr(advertisedAPIAddr(apiAddr, bindAddr))
 		errCh := make(chan error, 2) // Buffered for both servers
 
 		// Start TCP listener (mTLS)
@@ -819,6 +1004,8 @@ var managerJoinCmd = &cobra.Command{
 		// Shutdown
 		sched.Stop()
 		recon.Stop()
+		jobController.Stop()
+		deployController.Stop()
 		metricsCollector.Stop()
 		mgr.StopIngress()
 		apiServer.Stop()
@@ -836,6 +1023,11 @@ func init() {
 
 	managerJoinCmd.Flags().String("node-id", "manager-2", "Unique node ID")
 	managerJoinCmd.Flags().String("bind-addr", "127.0.0.1:7947", "Address for Raft communication")
+	managerJoinCmd.Flags().String("cluster-subnet", network.DefaultClusterSubnet, "Overlay subnet for node IPs (must match on all managers)")
+	managerJoinCmd.Flags().String("service-subnet", network.DefaultServiceSubnet, "Subnet reserved for service VIPs, at most a /23 (must match on all managers)")
+	managerJoinCmd.Flags().String("scheduler-strategy", string(scheduler.StrategySpread), "How to rank nodes that fit a container: spread, binpack or least-allocated")
+	managerJoinCmd.Flags().String("scheduler-config", "", "Scheduler plugin configuration file (YAML, must match on all managers)")
+	managerJoinCmd.Flags().Bool("auto-rebalance", false, "Move replicas off overloaded nodes to even out every service's spread (must match on all managers)")
 	managerJoinCmd.Flags().String("api-addr", "127.0.0.1:8081", "Address for gRPC API")
 	managerJoinCmd.Flags().String("data-dir", "./warren-data-2", "Data directory for cluster state")
 	managerJoinCmd.Flags().String("leader", "", "Leader manager address")
@@ -877,6 +1069,21 @@ var serviceCreateCmd = &cobra.Command{
 		// Resource limit flags
 		cpus, _ := cmd.Flags().GetFloat64("cpus")
 		memory, _ := cmd.Flags().GetString("memory")
+		reserveCPUs, _ := cmd.Flags().GetFloat64("reserve-cpus")
+		reserveMemory, _ := cmd.Flags().GetString("reserve-memory")
+
+		// Placement flags
+		constraints, _ := cmd.Flags().GetStringSlice("constraint")
+		placementPrefs, _ := cmd.Flags().GetStringSlice("placement-pref")
+		labels, _ := cmd.Flags().GetStringSlice("label")
+		affinity, _ := cmd.Flags().GetStringSlice("affinity")
+		affinityPreferred, _ := cmd.Flags().GetStringSlice("affinity-preferred")
+		antiAffinity, _ := cmd.Flags().GetStringSlice("anti-affinity")
+		antiAffinityPreferred, _ := cmd.Flags().GetStringSlice("anti-affinity-preferred")
+		tolerationSpecs, _ := cmd.Flags().GetStringSlice("toleration")
+
+		disruptionBudget, _ := cmd.Flags().GetInt("disruption-budget")
+		priority, _ := cmd.Flags().GetInt("priority")
 
 		// Graceful shutdown flags
 		stopTimeout, _ := cmd.Flags().GetInt("stop-timeout")
@@ -897,6 +1104,37 @@ var serviceCreateCmd = &cobra.Command{
 			return fmt.Errorf("failed to parse port mappings: %v", err)
 		}
 
+		placement, err := parsePlacement(constraints, placementPrefs)
+		if err != nil {
+			return err
+		}
+
+		serviceLabels := make(map[string]string)
+		for _, label := range labels {
+			parts := strings.SplitN(label, "=", 2)
+			if len(parts) != 2 || parts[0] == "" {
+				return fmt.Errorf("invalid label '%s': expected KEY=VALUE", label)
+			}
+			serviceLabels[parts[0]] = parts[1]
+		}
+
+		affinityTerms, err := parseAffinity(affinity, affinityPreferred)
+		if err != nil {
+			return err
+		}
+		antiAffinityTerms, err := parseAffinity(antiAffinity, antiAffinityPreferred)
+		if err != nil {
+			return err
+		}
+		var tolerations []types.Toleration
+		for _, expr := range tolerationSpecs {
+			tol, err := scheduler.ParseToleration(expr)
+			if err != nil {
+				return err
+			}
+			tolerations = append(tolerations, tol)
+		}
+
 		// Connect to manager
 		c, err := client.NewClientAuto(manager)
 		if err != nil {
@@ -906,12 +1144,17 @@ var serviceCreateCmd = &cobra.Command{
 
 		// Build service request
 		req := &proto.CreateServiceRequest{
-			Name:     name,
-			Image:    image,
-			Replicas: int32(replicas),
-			Mode:     "replicated",
-			Env:      env,
-			Ports:    ports,
+			Name:         name,
+			Image:        image,
+			Replicas:     int32(replicas),
+			Mode:         "replicated",
+			Env:          env,
+			Ports:        ports,
+			Placement:    placement,
+			Labels:       serviceLabels,
+			Affinity:     affinityTerms,
+			AntiAffinity: antiAffinityTerms,
+			Tolerations:  convert.TolerationsToProto(tolerations),
 		}
 
 		// Add health check if specified
@@ -920,7 +1163,7 @@ var serviceCreateCmd = &cobra.Command{
 		}
 
 		// Add resource limits if specified
-		if cpus > 0 || memory != "" {
+		if cpus > 0 || memory != "" || reserveCPUs > 0 || reserveMemory != "" {
 			resources := &proto.ResourceRequirements{}
 
 			if cpus > 0 {
@@ -935,6 +1178,18 @@ var serviceCreateCmd = &cobra.Command{
 				resources.MemoryBytes = memBytes
 			}
 
+			if reserveCPUs > 0 {
+				resources.CpuReservationShares = int64(reserveCPUs * 1024)
+			}
+
+			if reserveMemory != "" {
+				memBytes, err := parseMemory(reserveMemory)
+				if err != nil {
+					return fmt.Errorf("invalid reserve-memory format: %v", err)
+				}
+				resources.MemoryReservationBytes = memBytes
+			}
+
 			req.Resources = resources
 		}
 
@@ -942,6 +1197,8 @@ var serviceCreateCmd = &cobra.Command{
 		if stopTimeout > 0 {
 			req.StopTimeout = int32(stopTimeout)
 		}
+		req.DisruptionBudget = int32(disruptionBudget)
+		req.Priority = int32(priority)
 
 		// Create service
 		service, err := c.CreateServiceWithOptions(req)
@@ -953,6 +1210,9 @@ var serviceCreateCmd = &cobra.Command{
 		fmt.Printf("  ID: %s\n", service.Id)
 		fmt.Printf("  Image: %s\n", service.Image)
 		fmt.Printf("  Replicas: %d\n", service.Replicas)
+		if service.Vip != "" {
+			fmt.Printf("  VIP: %s\n", service.Vip)
+		}
 		if len(service.Ports) > 0 {
 			fmt.Printf("  Published Ports:\n")
 			for _, port := range service.Ports {
@@ -974,7 +1234,17 @@ var serviceCreateCmd = &cobra.Command{
 			if service.Resources.MemoryBytes > 0 {
 				fmt.Printf("  Memory Limit: %s\n", formatBytes(service.Resources.MemoryBytes))
 			}
+			if service.Resources.CpuReservationShares > 0 {
+				fmt.Printf("  CPU Reservation: %.2f cores\n", float64(service.Resources.CpuReservationShares)/1024.0)
+			}
+			if service.Resources.MemoryReservationBytes > 0 {
+				fmt.Printf("  Memory Reservation: %s\n", formatBytes(service.Resources.MemoryReservationBytes))
+			}
 		}
+		printPlacement(service.Placement)
+		printAffinity("Affinity", service.Affinity)
+		printAffinity("Anti-affinity", service.AntiAffinity)
+		printTolerations(service.Tolerations)
 		return nil
 	},
 }
@@ -1015,6 +1285,69 @@ var serviceListCmd = &cobra.Command{
 	},
 }
 
+var servicePsCmd = &cobra.Command{
+	Use:   "ps NAME",
+	Short: "List the containers of a service",
+	Long: `List the containers of a service and the node each runs on.
+
+With --why, also explain each placement: the chosen node's score from each
+scheduler plugin, and the plugin that ruled out every other node. Decisions
+are kept in memory by the leader, so they start over after a failover.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		manager, _ := cmd.Flags().GetString("manager")
+		why, _ := cmd.Flags().GetBool("why")
+
+		c, err := client.NewClientAuto(manager)
+		if err != nil {
+			return fmt.Errorf("failed to connect to manager: %v", err)
+		}
+		defer c.Close()
+
+		service, err := c.GetService(name)
+		if err != nil {
+			return fmt.Errorf("failed to get service: %v", err)
+		}
+
+		containers, err := c.ListContainers(service.Id, "")
+		if err != nil {
+			return fmt.Errorf("failed to list containers: %v", err)
+		}
+
+		decisions := make(map[string]*proto.PlacementDecision)
+		if why {
+			list, err := c.GetPlacementDecisions(service.Id)
+			if err != nil {
+				return fmt.Errorf("failed to get placement decisions: %v", err)
+			}
+			for _, d := range list {
+				decisions[d.ContainerId] = d // Latest decision wins
+			}
+		}
+
+		fmt.Printf("%-20s %-20s %-10s %-10s %s\n", "ID", "NODE", "DESIRED", "STATE", "ERROR")
+		for _, container := range containers {
+			node := container.NodeId
+			if node == "" {
+				node = "-"
+			}
+			fmt.Printf("%-20s %-20s %-10s %-10s %s\n",
+				truncate(container.Id, 20),
+				truncate(node, 20),
+				container.DesiredState,
+				container.ActualState,
+				container.Error,
+			)
+			if why {
+				printPlacementDecision(decisions[container.Id])
+			}
+		}
+
+		return nil
+	},
+}
+
 var serviceInspectCmd = &cobra.Command{
 	Use:   "inspect NAME",
 	Short: "Inspect a service",
@@ -1041,6 +1374,35 @@ var serviceInspectCmd = &cobra.Command{
 		fmt.Printf("  Image: %s\n", service.Image)
 		fmt.Printf("  Replicas: %d\n", service.Replicas)
 		fmt.Printf("  Mode: %s\n", service.Mode)
+
+		// Containers the scheduler could not place yet
+		containers, err := c.ListContainers(service.Id, "")
+		if err != nil {
+			return fmt.Errorf("failed to list containers: %v", err)
+		}
+		pending := make(map[string]int)
+		for _, container := range containers {
+			if container.NodeId == "" && container.DesiredState == "running" {
+				pending[container.Error]++
+			}
+		}
+		for reason, count := range pending {
+			fmt.Printf("  Pending: %d replica(s): %s\n", count, reason)
+		}
+		if service.Vip != "" {
+			fmt.Printf("  VIP: %s\n", service.Vip)
+		}
+		printPlacement(service.Placement)
+		printAffinity("Affinity", service.Affinity)
+		printAffinity("Anti-affinity", service.AntiAffinity)
+		printTolerations(service.Tolerations)
+		if service.DisruptionBudget > 0 {
+			fmt.Printf("  Disruption Budget: %d\n", service.DisruptionBudget)
+		}
+		if service.Priority != 0 {
+			fmt.Printf("  Priority: %d\n", service.Priority)
+		}
+		printCanaryAnalysis(service.CanaryAnalysis)
 		if len(service.Env) > 0 {
 			fmt.Println("  Environment:")
 			for k, v := range service.Env {
@@ -1117,12 +1479,12 @@ var serviceScaleCmd = &cobra.Command{
 }
 
 var serviceUpdateCmd = &cobra.Command{
-	Use:   "update NAME --image IMAGE",
-	Short: "Update a service with a new image",
+	Use:   "update NAME [--image IMAGE] [--env KEY=VALUE]",
+	Short: "Update a service with a new image or environment",
 	Long: `Update a service using different deployment strategies.
 
 Deployment strategies:
-  rolling:     Update containers one at a time (default)
+  rolling:     Replace containers in batches, surging new ones first (default)
   blue-green:  Create full new version, switch traffic instantly
   canary:      Gradually migrate traffic (10% → 25% → 50% → 100%)
 
@@ -1134,20 +1496,43 @@ Examples:
   warren service update web --image nginx:1.21 --strategy blue-green
 
   # Canary deployment with custom steps
-  warren service update web --image nginx:1.21 --strategy canary --canary-steps 10,50,100`,
+  warren service update web --image nginx:1.21 --strategy canary --canary-steps 10,50,100
+
+  # Canary deployment that rolls back when the canary's ingress traffic
+  # errors or slows down more than the stable version's
+  warren service update web --image nginx:1.21 --strategy canary --auto-rollback \
+    --failure-threshold 5 --p99-threshold 30
+
+  # Rolling update that starts two replacements ahead of the old containers
+  # and pauses if a new container fails
+  warren service update web --image nginx:1.21 --max-surge 2 --failure-action pause
+
+  # Replace the environment, recording why in the service's history
+  warren service update web --env LOG_LEVEL=debug --env POOL_SIZE=20 --change-cause "debug pool exhaustion"`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
 		image, _ := cmd.Flags().GetString("image")
 		strategy, _ := cmd.Flags().GetString("strategy")
 		manager, _ := cmd.Flags().GetString("manager")
+		envVars, _ := cmd.Flags().GetStringSlice("env")
+		changeCause, _ := cmd.Flags().GetString("change-cause")
 
 		// Canary-specific flags
 		canaryStepsStr, _ := cmd.Flags().GetString("canary-steps")
 		canaryWindow, _ := cmd.Flags().GetInt("canary-window")
+		autoRollback, _ := cmd.Flags().GetBool("auto-rollback")
+		failureThreshold, _ := cmd.Flags().GetInt("failure-threshold")
+		p99Threshold, _ := cmd.Flags().GetInt("p99-threshold")
+
+		// Rolling update flags
+		maxSurge, _ := cmd.Flags().GetInt("max-surge")
+		maxUnavailable, _ := cmd.Flags().GetInt("max-unavailable")
+		failureAction, _ := cmd.Flags().GetString("failure-action")
+		gracePeriod, _ := cmd.Flags().GetInt("health-grace-period")
 
-		if image == "" {
-			return fmt.Errorf("--image is required")
+		if image == "" && len(envVars) == 0 {
+			return fmt.Errorf("--image or --env is required")
 		}
 
 		// Parse canary steps if provided
@@ -1180,10 +1565,19 @@ Examples:
 			return fmt.Errorf("failed to find service: %v", err)
 		}
 
-		// Build update config if canary options provided
+		// Build update config if rolling or canary options provided
 		var updateConfig *proto.UpdateConfig
-		if len(canarySteps) > 0 || canaryWindow > 0 {
-			updateConfig = &proto.UpdateConfig{}
+		if len(canarySteps) > 0 || canaryWindow > 0 || autoRollback || failureThreshold > 0 || p99Threshold > 0 ||
+			maxSurge > 0 || maxUnavailable > 0 || failureAction != "" || gracePeriod > 0 {
+			updateConfig = &proto.UpdateConfig{
+				AutoRollbackEnabled:           autoRollback,
+				FailureThresholdPercent:       int32(failureThreshold),
+				P99ThresholdPercent:           int32(p99Threshold),
+				MaxSurge:                      int32(maxSurge),
+				MaxUnavailable:                int32(maxUnavailable),
+				FailureAction:                 failureAction,
+				HealthCheckGracePeriodSeconds: int32(gracePeriod),
+			}
 			if len(canarySteps) > 0 {
 				updateConfig.CanarySteps = make([]int32, len(canarySteps))
 				for i, s := range canarySteps {
@@ -1195,18 +1589,42 @@ Examples:
 			}
 		}
 
+		req := &proto.UpdateServiceImageRequest{
+			Id:           service.Id,
+			Image:        image,
+			Strategy:     strategy,
+			UpdateConfig: updateConfig,
+			ChangeCause:  changeCause,
+		}
+		if len(envVars) > 0 {
+			req.Env = make(map[string]string)
+			for _, e := range envVars {
+				parts := splitEnv(e)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid env %q: use KEY=VALUE", e)
+				}
+				req.Env[parts[0]] = parts[1]
+			}
+		}
+
 		fmt.Printf("Updating service %s...\n", name)
 		fmt.Printf("  Current image: %s\n", service.Image)
-		fmt.Printf("  New image:     %s\n", image)
+		if image != "" {
+			fmt.Printf("  New image:     %s\n", image)
+		}
+		if len(envVars) > 0 {
+			fmt.Printf("  New env:       %s\n", strings.Join(envVars, " "))
+		}
 		fmt.Printf("  Strategy:      %s\n", strategy)
 
 		// Update service with new image and strategy
-		err = c.UpdateServiceImage(service.Id, image, strategy, updateConfig)
+		deploymentID, err := c.UpdateServiceImageWithOptions(req)
 		if err != nil {
 			return fmt.Errorf("failed to update service: %v", err)
 		}
 
 		fmt.Printf("\n✓ Service update initiated\n")
+		fmt.Printf("Deployment: %s\n", deploymentID)
 		if strategy == "blue-green" {
 			fmt.Println("Blue-green deployment in progress - traffic will switch once new version is healthy")
 		} else if strategy == "canary" {
@@ -1217,6 +1635,7 @@ Examples:
 		} else {
 			fmt.Println("Rolling update in progress - containers will be updated one at a time")
 		}
+		fmt.Printf("\nFollow it with: warren deployment inspect %s\n", deploymentID)
 
 		return nil
 	},
@@ -1224,15 +1643,26 @@ Examples:
 
 var serviceRollbackCmd = &cobra.Command{
 	Use:   "rollback NAME",
-	Short: "Rollback a service to the previous version",
-	Long: `Rollback a service deployment to the previous version.
+	Short: "Rollback a service to a previous revision",
+	Long: `Rollback a service to a revision from its history (see 'warren service history').
+
+The revision's image, env, resources, health check and other settings are
+restored and rolled out as a new deployment, which becomes the newest
+revision. Without --to-revision the revision before the current one is
+restored. A service without history switches traffic back to the standby
+version from a previous blue-green or canary deployment.
 
-This command switches traffic back to the standby version from a previous
-blue-green or canary deployment.`,
+Examples:
+  # Undo the last change
+  warren service rollback web
+
+  # Restore revision 3
+  warren service rollback web --to-revision 3`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
 		manager, _ := cmd.Flags().GetString("manager")
+		toRevision, _ := cmd.Flags().GetInt("to-revision")
 
 		// Connect to manager
 		c, err := client.NewClientAuto(manager)
@@ -1250,12 +1680,154 @@ blue-green or canary deployment.`,
 		fmt.Printf("Rolling back service %s...\n", name)
 
 		// Rollback service
-		err = c.RollbackService(service.Id)
+		resp, err := c.RollbackService(service.Id, int32(toRevision))
 		if err != nil {
 			return fmt.Errorf("failed to rollback service: %v", err)
 		}
 
-		fmt.Printf("\n✓ Service rolled back successfully\n")
+		if resp.DeploymentId == "" {
+			fmt.Printf("\n✓ Service rolled back successfully\n")
+			return nil
+		}
+		fmt.Printf("\n✓ Rolling back to revision %d\n", resp.Revision)
+		fmt.Printf("Deployment: %s\n", resp.DeploymentId)
+		return nil
+	},
+}
+
+var serviceHistoryCmd = &cobra.Command{
+	Use:   "history NAME",
+	Short: "Show the revision history of a service",
+	Long: `Show the revisions recorded for a service, oldest first.
+
+A revision is recorded whenever a service's spec changes: its image, env,
+resources, health check or other settings, but not its replica count.
+The last 10 revisions are kept. Use --revision to show one in full, and
+'warren service rollback NAME --to-revision N' to restore it.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		manager, _ := cmd.Flags().GetString("manager")
+		number, _ := cmd.Flags().GetInt("revision")
+
+		c, err := client.NewClientAuto(manager)
+		if err != nil {
+			return fmt.Errorf("failed to connect to manager: %v", err)
+		}
+		defer c.Close()
+
+		service, err := c.GetService(name)
+		if err != nil {
+			return fmt.Errorf("failed to find service: %v", err)
+		}
+
+		revisions, err := c.ListServiceRevisions(service.Id)
+		if err != nil {
+			return fmt.Errorf("failed to list revisions: %v", err)
+		}
+		if len(revisions) == 0 {
+			fmt.Println("No revisions found")
+			return nil
+		}
+
+		if number > 0 {
+			for _, revision := range revisions {
+				if int(revision.Revision) == number {
+					printServiceRevision(service.Name, revision)
+					return nil
+				}
+			}
+			return fmt.Errorf("revision %d not found", number)
+		}
+
+		fmt.Printf("%-10s %-30s %-40s %s\n", "REVISION", "IMAGE", "CHANGE-CAUSE", "CREATED")
+		for _, revision := range revisions {
+			fmt.Printf("%-10d %-30s %-40s %s\n",
+				revision.Revision,
+				truncate(revision.Spec.GetImage(), 30),
+				truncate(revision.ChangeCause, 40),
+				revision.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
+			)
+		}
+		return nil
+	},
+}
+
+// printServiceRevision prints the spec recorded in a revision
+func printServiceRevision(serviceName string, revision *proto.ServiceRevision) {
+	spec := revision.Spec
+	fmt.Printf("Service: %s, revision %d\n", serviceName, revision.Revision)
+	fmt.Printf("  Change Cause: %s\n", revision.ChangeCause)
+	fmt.Printf("  Created: %s\n", revision.CreatedAt.AsTime().Format(time.RFC3339))
+	fmt.Printf("  Image: %s\n", spec.Image)
+	if spec.DeployStrategy != "" {
+		fmt.Printf("  Strategy: %s\n", spec.DeployStrategy)
+	}
+	if r := spec.Resources; r != nil {
+		fmt.Printf("  Resources: cpu-shares=%d memory=%d bytes\n", r.CpuShares, r.MemoryBytes)
+	}
+	if hc := spec.HealthCheck; hc != nil {
+		target := ""
+		switch {
+		case hc.Http != nil:
+			target = fmt.Sprintf(" %s :%d", hc.Http.Path, hc.Http.Port)
+		case hc.Tcp != nil:
+			target = fmt.Sprintf(" :%d", hc.Tcp.Port)
+		case hc.Exec != nil:
+			target = " " + strings.Join(hc.Exec.Command, " ")
+		}
+		fmt.Printf("  Health Check: %s%s every %ds\n", hc.Type, target, hc.IntervalSeconds)
+	}
+	if len(spec.Env) > 0 {
+		keys := make([]string, 0, len(spec.Env))
+		for k := range spec.Env {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		fmt.Println("  Environment:")
+		for _, k := range keys {
+			fmt.Printf("    %s=%s\n", k, spec.Env[k])
+		}
+	}
+}
+
+var serviceRebalanceCmd = &cobra.Command{
+	Use:   "rebalance NAME",
+	Short: "Spread a service's replicas evenly again",
+	Long: `Move a replicated service's replicas off its most loaded nodes, e.g. after
+a failed node returns and the replicas it lost are still piled onto the others.
+
+The leader's scheduler moves replicas in batches of the service's update
+parallelism, starting each replacement on the node the scheduler would pick
+before stopping the old replica. A batch only starts while fewer replicas
+than the service's disruption budget (default: 1) are unavailable. To
+rebalance every service automatically, start managers with --auto-rebalance.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		manager, _ := cmd.Flags().GetString("manager")
+
+		c, err := client.NewClientAuto(manager)
+		if err != nil {
+			return fmt.Errorf("failed to connect to manager: %v", err)
+		}
+		defer c.Close()
+
+		service, err := c.GetService(name)
+		if err != nil {
+			return fmt.Errorf("failed to find service: %v", err)
+		}
+
+		resp, err := c.RebalanceService(service.Id)
+		if err != nil {
+			return fmt.Errorf("failed to rebalance service: %v", err)
+		}
+
+		if resp.Moves == 0 {
+			fmt.Printf("Service %s is balanced (skew %d)\n", name, resp.Skew)
+			return nil
+		}
+		fmt.Printf("✓ Rebalancing service %s: skew %d, moving %d replica(s)\n", name, resp.Skew, resp.Moves)
 		return nil
 	},
 }
@@ -1264,13 +1836,16 @@ func init() {
 	serviceCmd.AddCommand(serviceCreateCmd)
 	serviceCmd.AddCommand(serviceListCmd)
 	serviceCmd.AddCommand(serviceInspectCmd)
+	serviceCmd.AddCommand(servicePsCmd)
 	serviceCmd.AddCommand(serviceDeleteCmd)
 	serviceCmd.AddCommand(serviceScaleCmd)
 	serviceCmd.AddCommand(serviceUpdateCmd)
 	serviceCmd.AddCommand(serviceRollbackCmd)
+	serviceCmd.AddCommand(serviceHistoryCmd)
+	serviceCmd.AddCommand(serviceRebalanceCmd)
 
 	// Common flag
-	for _, cmd := range []*cobra.Command{serviceCreateCmd, serviceListCmd, serviceInspectCmd, serviceDeleteCmd, serviceScaleCmd, serviceUpdateCmd, serviceRollbackCmd} {
+	for _, cmd := range []*cobra.Command{serviceCreateCmd, serviceListCmd, serviceInspectCmd, servicePsCmd, serviceDeleteCmd, serviceScaleCmd, serviceUpdateCmd, serviceRollbackCmd, serviceHistoryCmd, serviceRebalanceCmd} {
 		cmd.Flags().String("manager", "127.0.0.1:8080", "Manager address")
 	}
 
@@ -1293,21 +1868,53 @@ func init() {
 	// Resource limit flags
 	serviceCreateCmd.Flags().Float64("cpus", 0, "CPU limit in cores (e.g., 0.5, 1.0, 2.0)")
 	serviceCreateCmd.Flags().String("memory", "", "Memory limit (e.g., 512m, 1g, 2g)")
+	serviceCreateCmd.Flags().Float64("reserve-cpus", 0, "CPU cores reserved on the node for scheduling (e.g., 0.5, 1.0)")
+	serviceCreateCmd.Flags().String("reserve-memory", "", "Memory reserved on the node for scheduling (e.g., 256m, 1g)")
+
+	// Placement flags
+	serviceCreateCmd.Flags().StringSlice("constraint", []string{}, "Placement constraints (e.g., node.labels.zone==eu-1, node.role!=manager)")
+	serviceCreateCmd.Flags().StringSlice("placement-pref", []string{}, "Placement preferences (e.g., spread=node.labels.zone)")
+
+	// Affinity flags: terms select services by name (service=NAME) or label (label.KEY=VALUE)
+	serviceCreateCmd.Flags().StringSlice("label", []string{}, "Service labels (KEY=VALUE)")
+	serviceCreateCmd.Flags().StringSlice("affinity", []string{}, "Only run on nodes running a matching service (e.g., service=app, label.tier=db)")
+	serviceCreateCmd.Flags().StringSlice("affinity-preferred", []string{}, "Prefer nodes running a matching service")
+	serviceCreateCmd.Flags().StringSlice("anti-affinity", []string{}, "Never run on nodes running a matching service (e.g., service=web to keep replicas apart)")
+	serviceCreateCmd.Flags().StringSlice("anti-affinity-preferred", []string{}, "Avoid nodes running a matching service")
+
+	// Tolerations: KEY=VALUE[:EFFECT] matches the value, KEY[:EFFECT] any value, * every taint
+	serviceCreateCmd.Flags().StringSlice("toleration", []string{}, "Tolerate a node taint (e.g., node-role=manager:NoSchedule, gpu, *)")
+	serviceCreateCmd.Flags().Int("disruption-budget", 0, "Most replicas rebalancing may leave unavailable at once (default: 1)")
+	serviceCreateCmd.Flags().Int("priority", 0, "Scheduling priority; when the cluster is full, a positive priority preempts lower-priority containers")
 
 	// Graceful shutdown flags
 	serviceCreateCmd.Flags().Int("stop-timeout", 10, "Seconds to wait before force-killing container (default: 10)")
 
 	_ = serviceCreateCmd.MarkFlagRequired("image")
 
+	servicePsCmd.Flags().Bool("why", false, "Explain why each container was placed where it is")
+
 	serviceScaleCmd.Flags().Int("replicas", 0, "Number of replicas")
 	_ = serviceScaleCmd.MarkFlagRequired("replicas")
 
 	// service update flags
-	serviceUpdateCmd.Flags().String("image", "", "New container image (required)")
+	serviceUpdateCmd.Flags().String("image", "", "New container image")
+	serviceUpdateCmd.Flags().StringSlice("env", []string{}, "Replace the environment variables (KEY=VALUE)")
+	serviceUpdateCmd.Flags().String("change-cause", "", "Why the service changed, recorded in its history")
+
+	// Service rollback and history flags
+	serviceRollbackCmd.Flags().Int("to-revision", 0, "Revision to restore (default: the one before the current one)")
+	serviceHistoryCmd.Flags().Int("revision", 0, "Show this revision in full")
 	serviceUpdateCmd.Flags().String("strategy", "rolling", "Deployment strategy: rolling, blue-green, canary")
 	serviceUpdateCmd.Flags().String("canary-steps", "", "Canary deployment steps (e.g., '10,25,50,100')")
 	serviceUpdateCmd.Flags().Int("canary-window", 300, "Seconds to wait between canary steps (default: 300)")
-	_ = serviceUpdateCmd.MarkFlagRequired("image")
+	serviceUpdateCmd.Flags().Bool("auto-rollback", false, "Roll a canary back when its analysis fails")
+	serviceUpdateCmd.Flags().Int("failure-threshold", 0, "Canary error rate points over stable that fail analysis (default: 10)")
+	serviceUpdateCmd.Flags().Int("p99-threshold", 0, "Canary p99 latency percent over stable that fails analysis (default: 50)")
+	serviceUpdateCmd.Flags().Int("max-surge", 0, "Rolling: new containers started ahead of the old ones (default: 1)")
+	serviceUpdateCmd.Flags().Int("max-unavailable", 0, "Rolling: replicas that may be unavailable at once (default: 0)")
+	serviceUpdateCmd.Flags().String("failure-action", "", "Rolling: pause, rollback or continue when new containers fail (default: rollback)")
+	serviceUpdateCmd.Flags().Int("health-grace-period", 0, "Seconds new containers have to pass their health checks (default: 30)")
 }
 
 // Node commands
@@ -1340,13 +1947,119 @@ var nodeListCmd = &cobra.Command{
 			return nil
 		}
 
-		fmt.Printf("%-15s %-10s %-15s %-10s\n", "ID", "ROLE", "STATUS", "CPU")
+		// CPU and memory are shown as reserved/total
+		fmt.Printf("%-15s %-10s %-15s %-12s %-20s %s\n", "ID", "ROLE", "STATUS", "CPU", "MEMORY", "TAINTS")
 		for _, node := range nodes {
-			fmt.Printf("%-15s %-10s %-15s %-10d\n",
+			taints := strings.Join(formatTaints(node.Taints), ",")
+			if taints == "" {
+				taints = "-"
+			}
+			fmt.Printf("%-15s %-10s %-15s %-12s %-20s %s\n",
 				truncate(node.Id, 15),
 				node.Role,
 				node.Status,
-				node.Resources.CpuCores)
+				fmt.Sprintf("%.1f/%d", node.Resources.CpuAllocated, node.Resources.CpuCores),
+				fmt.Sprintf("%s/%s", formatBytes(node.Resources.MemoryAllocated), formatBytes(node.Resources.MemoryBytes)),
+				taints)
+		}
+		return nil
+	},
+}
+
+var nodeUpdateCmd = &cobra.Command{
+	Use:   "update NODE_ID",
+	Short: "Update node labels and taints",
+	Long: `Add or remove node labels and taints. Services select nodes by label with
+placement constraints (--constraint node.labels.KEY==VALUE) and spread
+across them with placement preferences (--placement-pref spread=node.labels.KEY).
+
+Taints (KEY[=VALUE]:EFFECT) keep services that do not tolerate them off a
+node. NoSchedule stops new containers, PreferNoSchedule avoids the node while
+others fit, and NoExecute also evicts running containers.
+
+Examples:
+  # Label a node with its zone
+  warren node update node-1 --label-add zone=eu-1
+
+  # Remove a label
+  warren node update node-1 --label-rm zone
+
+  # Keep a hybrid manager for system services only
+  warren node update manager-1 --taint-add node-role=manager:NoSchedule
+
+  # Drain a node of everything that does not tolerate maintenance
+  warren node update node-1 --taint-add maintenance:NoExecute
+
+  # Remove a taint (all effects, or one with KEY:EFFECT)
+  warren node update node-1 --taint-rm maintenance`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manager, _ := cmd.Flags().GetString("manager")
+		labelAdd, _ := cmd.Flags().GetStringSlice("label-add")
+		labelRm, _ := cmd.Flags().GetStringSlice("label-rm")
+		taintAdd, _ := cmd.Flags().GetStringSlice("taint-add")
+		taintRm, _ := cmd.Flags().GetStringSlice("taint-rm")
+
+		if len(labelAdd) == 0 && len(labelRm) == 0 && len(taintAdd) == 0 && len(taintRm) == 0 {
+			return fmt.Errorf("nothing to update: use --label-add, --label-rm, --taint-add or --taint-rm")
+		}
+
+		add := make(map[string]string)
+		for _, label := range labelAdd {
+			parts := strings.SplitN(label, "=", 2)
+			if parts[0] == "" {
+				return fmt.Errorf("invalid label '%s': expected KEY=VALUE", label)
+			}
+			if len(parts) == 2 {
+				add[parts[0]] = parts[1]
+			} else {
+				add[parts[0]] = ""
+			}
+		}
+
+		req := &proto.UpdateNodeRequest{Id: args[0], LabelAdd: add, LabelRm: labelRm}
+		for _, expr := range taintAdd {
+			taint, err := scheduler.ParseTaint(expr)
+			if err != nil {
+				return err
+			}
+			req.TaintAdd = append(req.TaintAdd, convert.TaintsToProto([]types.Taint{taint})...)
+		}
+		for _, expr := range taintRm {
+			taint, err := scheduler.ParseTaintKey(expr)
+			if err != nil {
+				return err
+			}
+			req.TaintRm = append(req.TaintRm, convert.TaintsToProto([]types.Taint{taint})...)
+		}
+
+		// Connect to manager
+		c, err := client.NewClientAuto(manager)
+		if err != nil {
+			return fmt.Errorf("failed to connect to manager: %v", err)
+		}
+		defer c.Close()
+
+		node, err := c.UpdateNode(req)
+		if err != nil {
+			return fmt.Errorf("failed to update node: %v", err)
+		}
+
+		fmt.Printf("✓ Node updated: %s\n", node.Id)
+		if len(node.Labels) > 0 {
+			keys := make([]string, 0, len(node.Labels))
+			for k := range node.Labels {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			fmt.Println("  Labels:")
+			for _, k := range keys {
+				fmt.Printf("    %s=%s\n", k, node.Labels[k])
+			}
+		}
+		if len(node.Taints) > 0 {
+			fmt.Println("  Taints:")
+			fmt.Printf("    %s\n", strings.Join(formatTaints(node.Taints), "\n    "))
 		}
 		return nil
 	},
@@ -1354,8 +2067,15 @@ var nodeListCmd = &cobra.Command{
 
 func init() {
 	nodeCmd.AddCommand(nodeListCmd)
+	nodeCmd.AddCommand(nodeUpdateCmd)
 
 	nodeListCmd.Flags().String("manager", "127.0.0.1:8080", "Manager address")
+
+	nodeUpdateCmd.Flags().String("manager", "127.0.0.1:8080", "Manager address")
+	nodeUpdateCmd.Flags().StringSlice("label-add", []string{}, "Add or update a node label (KEY=VALUE)")
+	nodeUpdateCmd.Flags().StringSlice("label-rm", []string{}, "Remove a node label (KEY)")
+	nodeUpdateCmd.Flags().StringSlice("taint-add", []string{}, "Add a node taint (KEY[=VALUE]:NoSchedule|PreferNoSchedule|NoExecute)")
+	nodeUpdateCmd.Flags().StringSlice("taint-rm", []string{}, "Remove a node taint (KEY or KEY:EFFECT)")
 }
 
 // Secret commands
@@ -1714,6 +2434,49 @@ func init() {
 
 // Helper functions
 
+// schedulerConfig loads --scheduler-config, or the defaults without it. An
+// explicit --scheduler-strategy or --auto-rebalance overrides the file.
+func schedulerConfig(cmd *cobra.Command) (scheduler.Config, error) {
+	cfg := scheduler.DefaultConfig()
+	if path, _ := cmd.Flags().GetString("scheduler-config"); path != "" {
+		var err error
+		if cfg, err = scheduler.LoadConfig(path); err != nil {
+			return cfg, err
+		}
+	}
+
+	if cmd.Flags().Changed("scheduler-strategy") {
+		name, _ := cmd.Flags().GetString("scheduler-strategy")
+		strategy, err := scheduler.ParseStrategy(name)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.Strategy = strategy
+	}
+	if cmd.Flags().Changed("auto-rebalance") {
+		cfg.AutoRebalance, _ = cmd.Flags().GetBool("auto-rebalance")
+	}
+	return cfg, nil
+}
+
+// advertisedAPIAddr returns the API address other managers reach this one
+// at: apiAddr, or the Raft bind host with the API port if apiAddr listens on
+// every interface
+func advertisedAPIAddr(apiAddr, bindAddr string) string {
+	host, port, err := net.SplitHostPort(apiAddr)
+	if err != nil {
+		return apiAddr
+	}
+	if ip := net.ParseIP(host); host != "" && (ip == nil || !ip.IsUnspecified()) {
+		return apiAddr
+	}
+	bindHost, _, err := net.SplitHostPort(bindAddr)
+	if err != nil {
+		return apiAddr
+	}
+	return net.JoinHostPort(bindHost, port)
+}
+
 func truncate(s string, max int) string {
 	if len(s) <= max {
 		return s
@@ -1808,6 +2571,214 @@ func parsePortMappings(portSpecs []string, defaultMode string) ([]*proto.PortMap
 	return ports, nil
 }
 
+// parsePlacement builds service placement from --constraint and
+// --placement-pref flags. Preferences have the form "spread=node.labels.KEY".
+func parsePlacement(constraints, prefs []string) (*proto.Placement, error) {
+	if len(constraints) == 0 && len(prefs) == 0 {
+		return nil, nil
+	}
+
+	placement := &proto.Placement{Constraints: constraints}
+	for _, pref := range prefs {
+		parts := strings.SplitN(pref, "=", 2)
+		if len(parts) != 2 || parts[0] != "spread" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid placement preference '%s': expected spread=node.labels.KEY", pref)
+		}
+		placement.Preferences = append(placement.Preferences, &proto.PlacementPreference{Spread: parts[1]})
+	}
+
+	return placement, nil
+}
+
+// printPlacement prints a service's placement constraints and preferences
+func printPlacement(placement *proto.Placement) {
+	if placement == nil {
+		return
+	}
+	if len(placement.Constraints) > 0 {
+		fmt.Println("  Constraints:")
+		for _, c := range placement.Constraints {
+			fmt.Printf("    %s\n", c)
+		}
+	}
+	if len(placement.Preferences) > 0 {
+		fmt.Println("  Preferences:")
+		for _, p := range placement.Preferences {
+			fmt.Printf("    spread=%s\n", p.Spread)
+		}
+	}
+}
+
+// formatTaints formats node taints as KEY=VALUE:EFFECT
+func formatTaints(taints []*proto.Taint) []string {
+	formatted := make([]string, 0, len(taints))
+	for _, taint := range convert.ProtoToTaints(taints) {
+		formatted = append(formatted, scheduler.FormatTaint(taint))
+	}
+	return formatted
+}
+
+// printTolerations prints the node taints a service tolerates
+func printTolerations(tolerations []*proto.Toleration) {
+	if len(tolerations) == 0 {
+		return
+	}
+	fmt.Println("  Tolerations:")
+	for _, tol := range tolerations {
+		expr := tol.Key
+		switch {
+		case tol.Key == "" && tol.Operator == string(types.TolerationOpExists):
+			expr = "*"
+		case tol.Operator != string(types.TolerationOpExists):
+			expr += "=" + tol.Value
+		}
+		if tol.Effect != "" {
+			expr += ":" + tol.Effect
+		}
+		fmt.Printf("    %s\n", expr)
+	}
+}
+
+// printCanaryAnalysis prints the last comparison of a canary update with
+// the service
+func printCanaryAnalysis(analysis *proto.CanaryAnalysis) {
+	if analysis == nil {
+		return
+	}
+	fmt.Printf("  Canary Analysis: %s (%s at %d%%)\n", analysis.Result, analysis.Image, analysis.Weight)
+	if analysis.Reason != "" {
+		fmt.Printf("    Reason: %s\n", analysis.Reason)
+	}
+	for _, side := range []struct {
+		name    string
+		summary *proto.TrafficSummary
+	}{{"Canary", analysis.Canary}, {"Stable", analysis.Stable}} {
+		fmt.Printf("    %s: %d requests, %d errors, p99 %dms\n",
+			side.name, side.summary.GetRequests(), side.summary.GetErrors(), side.summary.GetP99Ms())
+	}
+	if analysis.RolledBack {
+		fmt.Println("    Rolled back: yes")
+	}
+	if analysis.AnalyzedAt != nil {
+		fmt.Printf("    Analyzed: %s\n", analysis.AnalyzedAt.AsTime().Format(time.RFC3339))
+	}
+}
+
+// printPlacementDecision prints why the scheduler placed a container on
+// its node: the chosen node's scores, then every node it ruled out
+func printPlacementDecision(d *proto.PlacementDecision) {
+	if d == nil {
+		fmt.Println("    no decision recorded on this manager")
+		return
+	}
+
+	if d.NodeId == "" {
+		fmt.Printf("    unscheduled: %s\n", d.Reason)
+	}
+	for _, eval := range d.Nodes {
+		switch {
+		case eval.FilteredBy != "":
+			fmt.Printf("    %-20s rejected by %s: %s\n", truncate(eval.NodeId, 20), eval.FilteredBy, eval.Reason)
+		case eval.NodeId == d.NodeId:
+			fmt.Printf("    %-20s chosen, score %.2f%s\n", truncate(eval.NodeId, 20), eval.Score, formatScores(eval.Scores))
+		default:
+			fmt.Printf("    %-20s score %.2f%s\n", truncate(eval.NodeId, 20), eval.Score, formatScores(eval.Scores))
+		}
+	}
+}
+
+// formatScores formats the per-plugin scores of a node, sorted by plugin
+func formatScores(scores map[string]float64) string {
+	if len(scores) == 0 {
+		return ""
+	}
+	plugins := make([]string, 0, len(scores))
+	for plugin := range scores {
+		plugins = append(plugins, plugin)
+	}
+	sort.Strings(plugins)
+
+	parts := make([]string, 0, len(plugins))
+	for _, plugin := range plugins {
+		parts = append(parts, fmt.Sprintf("%s=%.2f", plugin, scores[plugin]))
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
+
+// parseAffinity builds affinity from required and preferred terms of the
+// form "service=NAME" or "label.KEY=VALUE"
+func parseAffinity(required, preferred []string) (*proto.Affinity, error) {
+	if len(required) == 0 && len(preferred) == 0 {
+		return nil, nil
+	}
+
+	affinity := &proto.Affinity{}
+	for _, spec := range required {
+		term, err := parseAffinityTerm(spec)
+		if err != nil {
+			return nil, err
+		}
+		affinity.Required = append(affinity.Required, term)
+	}
+	for _, spec := range preferred {
+		term, err := parseAffinityTerm(spec)
+		if err != nil {
+			return nil, err
+		}
+		affinity.Preferred = append(affinity.Preferred, term)
+	}
+
+	return affinity, nil
+}
+
+// parseAffinityTerm parses "service=NAME" or "label.KEY=VALUE"
+func parseAffinityTerm(spec string) (*proto.AffinityTerm, error) {
+	parts := strings.SplitN(spec, "=", 2)
+	if len(parts) == 2 && parts[1] != "" {
+		if parts[0] == "service" {
+			return &proto.AffinityTerm{Service: parts[1]}, nil
+		}
+		if key := strings.TrimPrefix(parts[0], "label."); key != parts[0] && key != "" {
+			return &proto.AffinityTerm{Labels: map[string]string{key: parts[1]}}, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid affinity term '%s': expected service=NAME or label.KEY=VALUE", spec)
+}
+
+// printAffinity prints a service's affinity or anti-affinity terms
+func printAffinity(title string, affinity *proto.Affinity) {
+	if affinity == nil {
+		return
+	}
+	fmt.Printf("  %s:\n", title)
+	for _, term := range affinity.Required {
+		fmt.Printf("    required: %s\n", formatAffinityTerm(term))
+	}
+	for _, term := range affinity.Preferred {
+		fmt.Printf("    preferred: %s\n", formatAffinityTerm(term))
+	}
+}
+
+// formatAffinityTerm formats a term the way it is given on the command line
+func formatAffinityTerm(term *proto.AffinityTerm) string {
+	var parts []string
+	if term.Service != "" {
+		parts = append(parts, "service="+term.Service)
+	}
+	keys := make([]string, 0, len(term.Labels))
+	for k := range term.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("label.%s=%s", k, term.Labels[k]))
+	}
+	if term.Weight > 1 {
+		parts = append(parts, fmt.Sprintf("(weight %d)", term.Weight))
+	}
+	return strings.Join(parts, " ")
+}
+
 // parsePortSpec parses a single port specification
 // Formats supported:
 //   - "8080:80"       -> host:container, tcp
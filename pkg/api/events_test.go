@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cuemby/warren/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestFollowEventsEndsOnOverflow tests that an event stream that falls
+// behind ends at the gap, and that resuming from its last event replays
+// every missed one
+func TestFollowEventsEndsOnOverflow(t *testing.T) {
+	broker := events.NewBroker()
+	broker.Start()
+	defer broker.Stop()
+
+	buffered := func() int {
+		history, err := broker.History("")
+		require.NoError(t, err)
+		return len(history)
+	}
+	publish := func(n int) {
+		total := buffered() + n
+		for i := 0; i < n; i++ {
+			broker.Publish(&events.Event{Type: events.EventTaskUpdated})
+		}
+		require.Eventually(t, func() bool {
+			return buffered() == total
+		}, time.Second, 5*time.Millisecond)
+	}
+
+	sub, _, err := broker.SubscribeFrom("")
+	require.NoError(t, err)
+	defer broker.Unsubscribe(sub)
+	publish(3)
+	history, err := broker.History("")
+	require.NoError(t, err)
+	third := history[2].ID
+
+	// The client stalls after the third event, long enough to overflow
+	var sent []string
+	err = followEvents(context.Background(), broker, sub, nil, func(event *events.Event) error {
+		sent = append(sent, event.ID)
+		if event.ID == third {
+			publish(cap(sub) + 10)
+		}
+		return nil
+	})
+	require.Error(t, err)
+	assert.Equal(t, []string{history[0].ID, history[1].ID, third}, sent)
+
+	resumed, backlog, err := broker.SubscribeFrom(third)
+	require.NoError(t, err)
+	defer broker.Unsubscribe(resumed)
+	require.Len(t, backlog, cap(sub)+10)
+	history, err = broker.History("")
+	require.NoError(t, err)
+	assert.Equal(t, history[3].ID, backlog[0].ID)
+}
+
+// TestResumeError tests that a lost resume point is reported as out of range
+func TestResumeError(t *testing.T) {
+	broker := events.NewBroker()
+	_, _, err := broker.SubscribeFrom("missing")
+	assert.Equal(t, codes.OutOfRange, status.Code(resumeError(err)))
+	assert.Equal(t, codes.Unknown, status.Code(resumeError(errors.New("other"))))
+}
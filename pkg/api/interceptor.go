@@ -56,9 +56,10 @@ func isReadOnlyMethod(method string) bool {
 		}
 	}
 
-	// Special cases: StreamEvents is read-only (event streaming)
+	// Special cases: StreamEvents and StreamServiceLogs are read-only (streaming)
 	readOnlyMethods := []string{
 		"StreamEvents",
+		"StreamServiceLogs",
 		"GetClusterInfo",
 		"GetNodeInfo",
 		"GetServiceInfo",
@@ -0,0 +1,467 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cuemby/warren/api/proto"
+	"github.com/cuemby/warren/pkg/log"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// logIdleTimeout bounds how long a non-followed log request waits for a
+// worker that has stopped answering
+const logIdleTimeout = 10 * time.Second
+
+// logRouter connects log requests from clients with the workers hosting the
+// containers. Workers hold a WatchLogRequests stream open to receive
+// requests and answer each one on its own PushContainerLogs stream.
+type logRouter struct {
+	mu       sync.Mutex
+	workers  map[string]chan *proto.LogRequest // node ID -> pending requests
+	sessions map[string]*logSession            // request ID -> client session
+}
+
+// logSession collects the answers to the log requests of one client
+type logSession struct {
+	entries chan *proto.LogEntry
+	done    chan string // request IDs whose worker finished answering
+	closed  chan struct{}
+}
+
+func newLogRouter() *logRouter {
+	return &logRouter{
+		workers:  make(map[string]chan *proto.LogRequest),
+		sessions: make(map[string]*logSession),
+	}
+}
+
+// registerWorker returns the request queue of a worker and a function to
+// release it when the worker disconnects
+func (r *logRouter) registerWorker(nodeID string) (chan *proto.LogRequest, func()) {
+	ch := make(chan *proto.LogRequest, 64)
+
+	r.mu.Lock()
+	r.workers[nodeID] = ch
+	r.mu.Unlock()
+
+	return ch, func() {
+		r.mu.Lock()
+		if r.workers[nodeID] == ch {
+			delete(r.workers, nodeID)
+		}
+		r.mu.Unlock()
+	}
+}
+
+// connected reports whether a worker holds a request stream on this manager
+func (r *logRouter) connected(nodeID string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.workers[nodeID]
+	return ok
+}
+
+// dispatch queues a request for a worker; it reports false if the worker
+// is not connected to this manager or is not keeping up
+func (r *logRouter) dispatch(nodeID string, req *proto.LogRequest) bool {
+	r.mu.Lock()
+	ch, ok := r.workers[nodeID]
+	r.mu.Unlock()
+	if !ok {
+		return false
+	}
+
+	select {
+	case ch <- req:
+		return true
+	default:
+		return false
+	}
+}
+
+func (r *logRouter) openSession(requestIDs []string) *logSession {
+	session := &logSession{
+		entries: make(chan *proto.LogEntry, 256),
+		done:    make(chan string, len(requestIDs)),
+		closed:  make(chan struct{}),
+	}
+
+	r.mu.Lock()
+	for _, id := range requestIDs {
+		r.sessions[id] = session
+	}
+	r.mu.Unlock()
+
+	return session
+}
+
+func (r *logRouter) closeSession(requestIDs []string, session *logSession) {
+	r.mu.Lock()
+	for _, id := range requestIDs {
+		delete(r.sessions, id)
+	}
+	r.mu.Unlock()
+	close(session.closed)
+}
+
+func (r *logRouter) session(requestID string) (*logSession, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session, ok := r.sessions[requestID]
+	return session, ok
+}
+
+// deliver hands a worker's log entry to the waiting client; it reports
+// false once the client has gone away
+func (r *logRouter) deliver(entry *proto.LogEntry) bool {
+	session, ok := r.session(entry.RequestId)
+	if !ok {
+		return false
+	}
+
+	select {
+	case session.entries <- entry:
+		return true
+	case <-session.closed:
+		return false
+	}
+}
+
+// finish records that a worker has sent all output for a request
+func (r *logRouter) finish(requestID string) {
+	if session, ok := r.session(requestID); ok {
+		select {
+		case session.done <- requestID:
+		case <-session.closed:
+		}
+	}
+}
+
+// logTarget is a container whose logs were requested
+type logTarget struct {
+	containerID string
+	nodeID      string
+	replica     string
+}
+
+// StreamServiceLogs streams the output of every replica of a service,
+// fanning the request out to the workers that host them. Replicas whose
+// workers are connected to another manager are requested from that
+// manager.
+func (s *Server) StreamServiceLogs(req *proto.StreamServiceLogsRequest, stream proto.WarrenAPI_StreamServiceLogsServer) error {
+	service, err := s.manager.GetServiceByName(req.ServiceName)
+	if err != nil {
+		return fmt.Errorf("service not found: %s", req.ServiceName)
+	}
+
+	containers, err := s.manager.ListContainersByService(service.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list containers: %v", err)
+	}
+
+	// Number replicas in creation order so labels are stable across calls,
+	// and across the managers a request is forwarded to
+	sort.Slice(containers, func(i, j int) bool {
+		return containers[i].CreatedAt.Before(containers[j].CreatedAt)
+	})
+
+	wanted := make(map[string]bool, len(req.ContainerIds))
+	for _, id := range req.ContainerIds {
+		wanted[id] = true
+	}
+
+	targets := make(map[string]*logTarget)
+	requestIDs := make([]string, 0, len(containers))
+	forwards := make(map[string][]*logTarget) // manager address -> targets
+	for i, container := range containers {
+		if container.NodeID == "" || (len(wanted) > 0 && !wanted[container.ID]) {
+			continue
+		}
+		target := &logTarget{
+			containerID: container.ID,
+			nodeID:      container.NodeID,
+			replica:     fmt.Sprintf("%s.%d", service.Name, i+1),
+		}
+
+		// A forwarded request is answered here or not at all, so that
+		// requests never go around in circles
+		if len(wanted) == 0 && !s.logs.connected(target.nodeID) {
+			if addr := s.workerManager(target.nodeID); addr != "" {
+				forwards[addr] = append(forwards[addr], target)
+				continue
+			}
+		}
+
+		id := uuid.New().String()
+		targets[id] = target
+		requestIDs = append(requestIDs, id)
+	}
+
+	// Each forwarded request is answered under its own request ID
+	forwardIDs := make(map[string]string, len(forwards)) // manager address -> request ID
+	sessionIDs := append([]string(nil), requestIDs...)
+	for addr := range forwards {
+		forwardIDs[addr] = uuid.New().String()
+		sessionIDs = append(sessionIDs, forwardIDs[addr])
+	}
+
+	session := s.logs.openSession(sessionIDs)
+	defer s.logs.closeSession(sessionIDs, session)
+
+	// Dispatch one request per container to its worker
+	pending := 0
+	for _, id := range requestIDs {
+		target := targets[id]
+		dispatched := s.logs.dispatch(target.nodeID, &proto.LogRequest{
+			RequestId:   id,
+			ContainerId: target.containerID,
+			Since:       req.Since,
+			Tail:        req.Tail,
+			Follow:      req.Follow,
+		})
+		if !dispatched {
+			notice := logNotice(target, fmt.Sprintf("logs unavailable: node %s is not connected to this manager", target.nodeID))
+			if err := s.sendLogEntry(stream, service.Name, target, notice); err != nil {
+				return err
+			}
+			continue
+		}
+		pending++
+	}
+
+	ctx := stream.Context()
+	for addr, forwarded := range forwards {
+		go s.forwardLogs(ctx, addr, forwardIDs[addr], req, forwarded)
+		pending++
+	}
+
+	if req.Follow {
+		defer func() {
+			for _, id := range requestIDs {
+				s.logs.dispatch(targets[id].nodeID, &proto.LogRequest{RequestId: id, Cancel: true})
+			}
+		}()
+	}
+
+	// Without follow, gather every replica's output and send it in time order
+	var collected []*proto.LogEntry
+	idle := time.NewTimer(logIdleTimeout)
+	defer idle.Stop()
+
+	for pending > 0 {
+		select {
+		case entry := <-session.entries:
+			if entry.Timestamp == nil {
+				// Handshake message opening the worker's push stream
+				continue
+			}
+			if req.Follow {
+				if err := s.sendLogEntry(stream, service.Name, targets[entry.RequestId], entry); err != nil {
+					return err
+				}
+				continue
+			}
+			collected = append(collected, entry)
+			idle.Reset(logIdleTimeout)
+		case <-session.done:
+			pending--
+		case <-idle.C:
+			if req.Follow {
+				continue
+			}
+			log.Logger.Warn().
+				Str("service", service.Name).
+				Int("pending", pending).
+				Msg("Timed out waiting for worker logs")
+			pending = 0
+		case <-ctx.Done():
+			return nil
+		}
+	}
+
+	sort.SliceStable(collected, func(i, j int) bool {
+		return collected[i].Timestamp.AsTime().Before(collected[j].Timestamp.AsTime())
+	})
+	for _, entry := range collected {
+		if err := s.sendLogEntry(stream, service.Name, targets[entry.RequestId], entry); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// workerManager returns the API address of the manager a node's worker is
+// connected to, or "" if it is not known
+func (s *Server) workerManager(nodeID string) string {
+	node, err := s.manager.GetNode(nodeID)
+	if err != nil {
+		return ""
+	}
+	return node.ManagerAddr
+}
+
+// recordWorkerManager records this manager as the one a node's worker is
+// connected to. Only the leader can write it; the address recorded when the
+// worker registered (with the leader it dialled) stays valid otherwise.
+func (s *Server) recordWorkerManager(nodeID string) {
+	if s.apiAddr == "" || !s.manager.IsLeader() {
+		return
+	}
+
+	node, err := s.manager.GetNode(nodeID)
+	if err != nil || node.ManagerAddr == s.apiAddr {
+		return
+	}
+	node.ManagerAddr = s.apiAddr
+	if err := s.manager.UpdateNode(node); err != nil {
+		log.Logger.Warn().Err(err).Str("node_id", nodeID).Msg("Failed to record the manager of a worker")
+	}
+}
+
+// forwardLogs requests the logs of replicas whose workers are connected to
+// the manager at addr from that manager, and delivers its answer under
+// requestID
+func (s *Server) forwardLogs(ctx context.Context, addr, requestID string, req *proto.StreamServiceLogsRequest, targets []*logTarget) {
+	defer s.logs.finish(requestID)
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(s.peerCreds))
+	if err != nil {
+		s.logs.deliverNotices(requestID, targets, fmt.Sprintf("logs unavailable: failed to connect to manager %s: %v", addr, err))
+		return
+	}
+	defer func() { _ = conn.Close() }()
+
+	s.logs.forward(ctx, proto.NewWarrenAPIClient(conn), addr, requestID, req, targets)
+}
+
+// forward streams the answer of another manager to a forwarded log request
+// into the session waiting for requestID
+func (r *logRouter) forward(ctx context.Context, client proto.WarrenAPIClient, addr, requestID string, req *proto.StreamServiceLogsRequest, targets []*logTarget) {
+	containerIDs := make([]string, 0, len(targets))
+	for _, target := range targets {
+		containerIDs = append(containerIDs, target.containerID)
+	}
+
+	stream, err := client.StreamServiceLogs(ctx, &proto.StreamServiceLogsRequest{
+		ServiceName:  req.ServiceName,
+		Follow:       req.Follow,
+		Since:        req.Since,
+		Tail:         req.Tail,
+		ContainerIds: containerIDs,
+	})
+	if err != nil {
+		r.deliverNotices(requestID, targets, fmt.Sprintf("logs unavailable: manager %s: %v", addr, err))
+		return
+	}
+
+	for {
+		entry, err := stream.Recv()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			if ctx.Err() == nil {
+				r.deliverNotices(requestID, targets, fmt.Sprintf("logs unavailable: manager %s: %v", addr, err))
+			}
+			return
+		}
+		entry.RequestId = requestID
+		if !r.deliver(entry) {
+			return
+		}
+	}
+}
+
+// deliverNotices tells the client waiting for requestID why the logs of
+// the given replicas are missing
+func (r *logRouter) deliverNotices(requestID string, targets []*logTarget, message string) {
+	for _, target := range targets {
+		notice := logNotice(target, message)
+		notice.RequestId = requestID
+		if !r.deliver(notice) {
+			return
+		}
+	}
+}
+
+// logNotice returns a Warren message in place of a replica's logs
+func logNotice(target *logTarget, message string) *proto.LogEntry {
+	return &proto.LogEntry{
+		ContainerId: target.containerID,
+		NodeId:      target.nodeID,
+		Replica:     target.replica,
+		Timestamp:   timestamppb.Now(),
+		Stream:      "warren",
+		Line:        message,
+	}
+}
+
+// sendLogEntry labels an entry with its service and replica and sends it
+func (s *Server) sendLogEntry(stream proto.WarrenAPI_StreamServiceLogsServer, serviceName string, target *logTarget, entry *proto.LogEntry) error {
+	entry.RequestId = ""
+	entry.ServiceName = serviceName
+	if target != nil {
+		entry.Replica = target.replica
+		entry.ContainerId = target.containerID
+		entry.NodeId = target.nodeID
+	}
+	return stream.Send(entry)
+}
+
+// WatchLogRequests streams log requests to a worker node
+func (s *Server) WatchLogRequests(req *proto.WatchLogRequestsRequest, stream proto.WarrenAPI_WatchLogRequestsServer) error {
+	if req.NodeId == "" {
+		return fmt.Errorf("node_id is required")
+	}
+
+	requests, unregister := s.logs.registerWorker(req.NodeId)
+	defer unregister()
+	s.recordWorkerManager(req.NodeId)
+
+	ctx := stream.Context()
+	for {
+		select {
+		case logReq := <-requests:
+			if err := stream.Send(logReq); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// PushContainerLogs receives a worker's answer to a log request
+func (s *Server) PushContainerLogs(stream proto.WarrenAPI_PushContainerLogsServer) error {
+	var requestID string
+	for {
+		entry, err := stream.Recv()
+		if err == io.EOF {
+			if requestID != "" {
+				s.logs.finish(requestID)
+			}
+			return stream.SendAndClose(&proto.PushContainerLogsResponse{})
+		}
+		if err != nil {
+			if requestID != "" {
+				s.logs.finish(requestID)
+			}
+			return err
+		}
+
+		requestID = entry.RequestId
+		if !s.logs.deliver(entry) {
+			return status.Error(codes.Canceled, "log request is no longer active")
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cuemby/warren/api/proto"
+	"github.com/cuemby/warren/pkg/manager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+)
+
+// TestLogRouterDispatch tests that requests only reach connected workers
+func TestLogRouterDispatch(t *testing.T) {
+	r := newLogRouter()
+
+	assert.False(t, r.dispatch("worker-1", &proto.LogRequest{RequestId: "r1"}), "worker not connected")
+	assert.False(t, r.connected("worker-1"))
+
+	requests, unregister := r.registerWorker("worker-1")
+	assert.True(t, r.connected("worker-1"))
+	require.True(t, r.dispatch("worker-1", &proto.LogRequest{RequestId: "r1"}))
+	assert.Equal(t, "r1", (<-requests).RequestId)
+
+	unregister()
+	assert.False(t, r.dispatch("worker-1", &proto.LogRequest{RequestId: "r2"}), "worker disconnected")
+}
+
+// TestLogRouterSession tests delivery of worker output to the waiting client
+func TestLogRouterSession(t *testing.T) {
+	r := newLogRouter()
+	session := r.openSession([]string{"r1", "r2"})
+
+	require.True(t, r.deliver(&proto.LogEntry{RequestId: "r1", Line: "hello"}))
+	assert.Equal(t, "hello", (<-session.entries).Line)
+
+	r.finish("r2")
+	select {
+	case id := <-session.done:
+		assert.Equal(t, "r2", id)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for finish")
+	}
+
+	// Once the client is gone, workers are told to stop
+	r.closeSession([]string{"r1", "r2"}, session)
+	assert.False(t, r.deliver(&proto.LogEntry{RequestId: "r1", Line: "late"}))
+	assert.False(t, r.deliver(&proto.LogEntry{RequestId: "unknown"}))
+}
+
+// fakeLogsClient answers StreamServiceLogs as another manager would
+type fakeLogsClient struct {
+	proto.WarrenAPIClient
+	req     *proto.StreamServiceLogsRequest
+	entries []*proto.LogEntry
+	err     error
+}
+
+func (f *fakeLogsClient) StreamServiceLogs(ctx context.Context, req *proto.StreamServiceLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[proto.LogEntry], error) {
+	f.req = req
+	return &fakeLogStream{entries: f.entries, err: f.err}, nil
+}
+
+type fakeLogStream struct {
+	grpc.ClientStream
+	entries []*proto.LogEntry
+	err     error
+}
+
+func (s *fakeLogStream) Recv() (*proto.LogEntry, error) {
+	if len(s.entries) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+	entry := s.entries[0]
+	s.entries = s.entries[1:]
+	return entry, nil
+}
+
+// TestLogRouterForward tests that replicas on workers connected to another
+// manager are requested from it, keeping the labels it gives them
+func TestLogRouterForward(t *testing.T) {
+	targets := []*logTarget{
+		{containerID: "c2", nodeID: "worker-2", replica: "web.2"},
+		{containerID: "c3", nodeID: "worker-2", replica: "web.3"},
+	}
+	req := &proto.StreamServiceLogsRequest{ServiceName: "web", Tail: 10, Follow: true}
+
+	t.Run("answered", func(t *testing.T) {
+		r := newLogRouter()
+		session := r.openSession([]string{"fwd"})
+		defer r.closeSession([]string{"fwd"}, session)
+
+		client := &fakeLogsClient{entries: []*proto.LogEntry{
+			{ContainerId: "c2", NodeId: "worker-2", Replica: "web.2", Line: "hello"},
+		}}
+		r.forward(context.Background(), client, "10.0.0.2:8080", "fwd", req, targets)
+
+		assert.Equal(t, []string{"c2", "c3"}, client.req.ContainerIds)
+		assert.Equal(t, "web", client.req.ServiceName)
+		assert.Equal(t, int32(10), client.req.Tail)
+		assert.True(t, client.req.Follow)
+
+		entry := <-session.entries
+		assert.Equal(t, "fwd", entry.RequestId)
+		assert.Equal(t, "web.2", entry.Replica)
+		assert.Equal(t, "hello", entry.Line)
+	})
+
+	t.Run("manager unreachable", func(t *testing.T) {
+		r := newLogRouter()
+		session := r.openSession([]string{"fwd"})
+		defer r.closeSession([]string{"fwd"}, session)
+
+		client := &fakeLogsClient{err: errors.New("connection refused")}
+		r.forward(context.Background(), client, "10.0.0.2:8080", "fwd", req, targets)
+
+		for _, replica := range []string{"web.2", "web.3"} {
+			notice := <-session.entries
+			assert.Equal(t, replica, notice.Replica)
+			assert.Equal(t, "warren", notice.Stream)
+			assert.Contains(t, notice.Line, "manager 10.0.0.2:8080")
+		}
+	})
+}
+
+// newTestManager returns a bootstrapped single-node manager
+func newTestManager(t *testing.T) *manager.Manager {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	mgr, err := manager.NewManager(&manager.Config{
+		NodeID:   "test-manager",
+		BindAddr: "127.0.0.1:0",
+		DataDir:  t.TempDir(),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = mgr.Shutdown() })
+
+	require.NoError(t, mgr.Bootstrap())
+	for i := 0; i < 50 && !mgr.IsLeader(); i++ {
+		time.Sleep(100 * time.Millisecond)
+	}
+	require.True(t, mgr.IsLeader(), "manager failed to become leader")
+	return mgr
+}
+
+// TestWorkerManagerAddr tests that a hybrid node's embedded worker, which
+// registers over loopback, is found at its manager's advertised address by
+// the other managers
+func TestWorkerManagerAddr(t *testing.T) {
+	// Both API servers read the same replicated cluster state
+	mgr := newTestManager(t)
+	first := &Server{manager: mgr, logs: newLogRouter(), apiAddr: "10.0.0.1:8080"}
+	second := &Server{manager: mgr, logs: newLogRouter(), apiAddr: "10.0.0.2:8080"}
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 51234}})
+	_, err := first.RegisterNode(ctx, &proto.RegisterNodeRequest{
+		Id:        "hybrid",
+		Role:      "worker",
+		Address:   "10.0.0.1",
+		Resources: &proto.NodeResources{CpuCores: 2},
+	})
+	require.NoError(t, err)
+
+	assert.False(t, second.logs.connected("hybrid"))
+	assert.Equal(t, "10.0.0.1:8080", second.workerManager("hybrid"))
+
+	// A worker watching for log requests on another manager is found there
+	second.recordWorkerManager("hybrid")
+	assert.Equal(t, "10.0.0.2:8080", first.workerManager("hybrid"))
+}
@@ -5,21 +5,32 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"net"
 	"os"
-	"strings"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/cuemby/warren/api/proto"
+	"github.com/cuemby/warren/pkg/convert"
+	"github.com/cuemby/warren/pkg/deploy"
+	"github.com/cuemby/warren/pkg/events"
+	"github.com/cuemby/warren/pkg/jobs"
 	"github.com/cuemby/warren/pkg/log"
 	"github.com/cuemby/warren/pkg/manager"
 	"github.com/cuemby/warren/pkg/metrics"
+	"github.com/cuemby/warren/pkg/network"
+	"github.com/cuemby/warren/pkg/scheduler"
 	"github.com/cuemby/warren/pkg/security"
 	"github.com/cuemby/warren/pkg/types"
 	"github.com/google/uuid"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -37,6 +48,10 @@ type Server struct {
 	grpcTCP    *grpc.Server // TCP listener with mTLS
 	grpcUnix   *grpc.Server // Unix socket listener (no mTLS, read-only)
 	unixSocket string       // Path to Unix socket
+	logs       *logRouter   // Routes log requests to workers
+	scheduler  *scheduler.Scheduler
+	peerCreds  credentials.TransportCredentials // Dials other managers to forward log requests
+	apiAddr    string                           // API address other managers reach this one at
 }
 
 // NewServer creates a new API server with mTLS
@@ -93,9 +108,28 @@ func NewServer(mgr *manager.Manager) (*Server, error) {
 		grpcTCP:    grpcTCP,
 		grpcUnix:   grpcUnix,
 		unixSocket: DefaultUnixSocket,
+		logs:       newLogRouter(),
+		peerCreds: credentials.NewTLS(&tls.Config{
+			Certificates: []tls.Certificate{*cert},
+			RootCAs:      certPool,
+			MinVersion:   tls.VersionTLS13,
+		}),
 	}, nil
 }
 
+// SetScheduler gives the server the scheduler it asks for placement
+// decisions and rebalancing
+func (s *Server) SetScheduler(sched *scheduler.Scheduler) {
+	s.scheduler = sched
+}
+
+// SetAdvertiseAddr sets the API address other managers reach this one at.
+// It is recorded on the nodes whose workers connect here, so log requests
+// for them can be forwarded from any manager.
+func (s *Server) SetAdvertiseAddr(addr string) {
+	s.apiAddr = addr
+}
+
 // ensureLeader checks if this node is the leader and returns an error if not
 // This should be called for all write operations
 func (s *Server) ensureLeader() error {
@@ -179,22 +213,40 @@ func (s *Server) RegisterNode(ctx context.Context, req *proto.RegisterNodeReques
 			MemoryBytes: req.Resources.MemoryBytes,
 			DiskBytes:   req.Resources.DiskBytes,
 		},
-		Status:        types.NodeStatusReady,
-		LastHeartbeat: time.Now(),
-		CreatedAt:     time.Now(),
-		Labels:        req.Labels,
+		Status:           types.NodeStatusReady,
+		LastHeartbeat:    time.Now(),
+		CreatedAt:        time.Now(),
+		Hostname:         req.Hostname,
+		Labels:           req.Labels,
+		OverlayPublicKey: req.OverlayPublicKey,
+		OverlayPort:      int(req.OverlayPort),
+		ManagerAddr:      s.apiAddr,
+	}
+
+	// Workers that don't know their own address are reachable at the
+	// address they connected from
+	if node.Address == "" || node.Address == "localhost" {
+		if p, ok := peer.FromContext(ctx); ok {
+			if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+				node.Address = host
+			}
+		}
 	}
 
-	// TODO: Allocate overlay IP from IP pool
-	node.OverlayIP = net.ParseIP("10.0.0.1")
-
-	if err := s.manager.CreateNode(node); err != nil {
+	// Allocates the overlay IP (or keeps the one from a previous registration)
+	if err := s.manager.RegisterNode(node); err != nil {
 		return nil, fmt.Errorf("failed to create node: %w", err)
 	}
 
+	networkConfig, err := s.manager.NetworkConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	return &proto.RegisterNodeResponse{
-		Node:      nodeToProto(node),
-		OverlayIp: node.OverlayIP.String(),
+		Node:          nodeToProto(node),
+		OverlayIp:     node.OverlayIP.String(),
+		OverlaySubnet: networkConfig.ClusterSubnet,
 	}, nil
 }
 
@@ -296,6 +348,44 @@ func (s *Server) RemoveNode(ctx context.Context, req *proto.RemoveNodeRequest) (
 	}, nil
 }
 
+// UpdateNode adds and removes node labels and taints
+func (s *Server) UpdateNode(ctx context.Context, req *proto.UpdateNodeRequest) (*proto.UpdateNodeResponse, error) {
+	// Ensure we're the leader for write operations
+	if err := s.ensureLeader(); err != nil {
+		return nil, err
+	}
+
+	for key := range req.LabelAdd {
+		if key == "" {
+			return nil, fmt.Errorf("label key cannot be empty")
+		}
+	}
+	taintAdd := convert.ProtoToTaints(req.TaintAdd)
+	if err := scheduler.ValidateTaints(taintAdd); err != nil {
+		return nil, err
+	}
+	taintRm := convert.ProtoToTaints(req.TaintRm)
+	for _, taint := range taintRm {
+		if taint.Key == "" {
+			return nil, fmt.Errorf("taint key cannot be empty")
+		}
+	}
+
+	node, err := s.manager.UpdateNodeLabels(req.Id, req.LabelAdd, req.LabelRm)
+	if err != nil {
+		return nil, err
+	}
+	if len(taintAdd) > 0 || len(taintRm) > 0 {
+		if node, err = s.manager.UpdateNodeTaints(req.Id, taintAdd, taintRm); err != nil {
+			return nil, err
+		}
+	}
+
+	return &proto.UpdateNodeResponse{
+		Node: nodeToProto(node),
+	}, nil
+}
+
 // CreateService creates a new service
 func (s *Server) CreateService(ctx context.Context, req *proto.CreateServiceRequest) (*proto.CreateServiceResponse, error) {
 	// Start timing service creation
@@ -326,56 +416,60 @@ func (s *Server) CreateService(ctx context.Context, req *proto.CreateServiceRequ
 		Networks:       req.Networks,
 	}
 
-	if req.UpdateConfig != nil {
-		service.UpdateConfig = &types.UpdateConfig{
-			Parallelism:   int(req.UpdateConfig.Parallelism),
-			Delay:         time.Duration(req.UpdateConfig.DelaySeconds) * time.Second,
-			FailureAction: req.UpdateConfig.FailureAction,
-		}
-	}
+	service.UpdateConfig = convert.ProtoToUpdateConfig(req.UpdateConfig)
 
 	if req.HealthCheck != nil {
-		service.HealthCheck = protoToHealthCheck(req.HealthCheck)
+		service.HealthCheck = convert.ProtoToHealthCheck(req.HealthCheck)
+	}
+
+	service.RestartPolicy = convert.ProtoToRestartPolicy(req.RestartPolicy)
+	service.Resources = convert.ProtoToResources(req.Resources)
+	service.Ports = convert.ProtoToPorts(req.Ports)
+	service.Placement = convert.ProtoToPlacement(req.Placement)
+	service.Labels = req.Labels
+	service.Affinity = convert.ProtoToAffinity(req.Affinity)
+	service.AntiAffinity = convert.ProtoToAffinity(req.AntiAffinity)
+	service.Tolerations = convert.ProtoToTolerations(req.Tolerations)
+	service.DisruptionBudget = int(req.DisruptionBudget)
+	service.Priority = int(req.Priority)
+	service.Job = convert.ProtoToJobConfig(req.Job)
+	service.CronJob = convert.ProtoToCronJobConfig(req.CronJob)
+
+	if service.DisruptionBudget < 0 {
+		return nil, fmt.Errorf("disruption budget cannot be negative")
+	}
+	if err := jobs.Validate(service); err != nil {
+		return nil, err
 	}
-
-	if req.RestartPolicy != nil {
-		service.RestartPolicy = &types.RestartPolicy{
-			Condition:   types.RestartCondition(req.RestartPolicy.Condition),
-			MaxAttempts: int(req.RestartPolicy.MaxAttempts),
-			Delay:       time.Duration(req.RestartPolicy.DelaySeconds) * time.Second,
-		}
+	if err := deploy.ValidateUpdateConfig(service.UpdateConfig); err != nil {
+		return nil, err
 	}
 
-	if req.Resources != nil {
-		service.Resources = &types.ResourceRequirements{
-			CPULimit:          float64(req.Resources.CpuShares) / 1024.0, // Convert shares to cores
-			MemoryLimit:       req.Resources.MemoryBytes,
-			MemoryReservation: req.Resources.MemoryReservationBytes,
-		}
+	if err := scheduler.ValidatePlacement(service.Placement); err != nil {
+		return nil, err
+	}
+	if err := scheduler.ValidateAffinity(service.Affinity); err != nil {
+		return nil, err
+	}
+	if err := scheduler.ValidateAffinity(service.AntiAffinity); err != nil {
+		return nil, err
+	}
+	if err := scheduler.ValidateTolerations(service.Tolerations); err != nil {
+		return nil, err
 	}
 
-	// Convert port mappings from proto to types
-	if len(req.Ports) > 0 {
-		service.Ports = make([]*types.PortMapping, 0, len(req.Ports))
-		for _, protoPort := range req.Ports {
-			publishMode := types.PublishModeHost
-			if protoPort.PublishMode == proto.PortMapping_INGRESS {
-				publishMode = types.PublishModeIngress
-			}
-
-			service.Ports = append(service.Ports, &types.PortMapping{
-				Name:          protoPort.Name,
-				ContainerPort: int(protoPort.ContainerPort),
-				HostPort:      int(protoPort.HostPort),
-				Protocol:      protoPort.Protocol,
-				PublishMode:   publishMode,
-			})
-		}
+	existing, err := s.manager.ListServices()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+	if err := scheduler.ValidatePorts(service, existing); err != nil {
+		return nil, err
 	}
 
 	if err := s.manager.CreateService(service); err != nil {
 		return nil, fmt.Errorf("failed to create service: %w", err)
 	}
+	s.recordRevision(service, "created")
 
 	return &proto.CreateServiceResponse{
 		Service: serviceToProto(service),
@@ -406,17 +500,14 @@ func (s *Server) UpdateService(ctx context.Context, req *proto.UpdateServiceRequ
 		service.Image = req.Image
 	}
 	if req.Env != nil {
-		var envSlice []string
-		for k, v := range req.Env {
-			envSlice = append(envSlice, fmt.Sprintf("%s=%s", k, v))
-		}
-		service.Env = envSlice
+		service.Env = convert.ProtoToEnv(req.Env)
 	}
 	service.UpdatedAt = time.Now()
 
 	if err := s.manager.UpdateService(service); err != nil {
 		return nil, fmt.Errorf("failed to update service: %w", err)
 	}
+	s.recordRevision(service, changeCause(req.ChangeCause, req.Image, req.Env != nil))
 
 	return &proto.UpdateServiceResponse{
 		Service: serviceToProto(service),
@@ -434,7 +525,18 @@ func (s *Server) DeleteService(ctx context.Context, req *proto.DeleteServiceRequ
 		return nil, err
 	}
 
-	if err := s.manager.DeleteService(req.Id); err != nil {
+	service, err := s.manager.GetService(req.Id)
+	if err != nil {
+		return nil, fmt.Errorf("service not found: %w", err)
+	}
+
+	// Jobs stop their containers, and cronjobs delete the jobs they started
+	if jobs.IsJob(service) {
+		err = jobs.Delete(s.manager, service)
+	} else {
+		err = s.manager.DeleteService(req.Id)
+	}
+	if err != nil {
 		return nil, fmt.Errorf("failed to delete service: %w", err)
 	}
 
@@ -458,8 +560,8 @@ func (s *Server) UpdateServiceImage(ctx context.Context, req *proto.UpdateServic
 	if req.Id == "" {
 		return nil, fmt.Errorf("service id is required")
 	}
-	if req.Image == "" {
-		return nil, fmt.Errorf("image is required")
+	if req.Image == "" && req.Env == nil {
+		return nil, fmt.Errorf("image or env is required")
 	}
 
 	// Get deployer from manager
@@ -482,32 +584,99 @@ func (s *Server) UpdateServiceImage(ctx context.Context, req *proto.UpdateServic
 		return nil, fmt.Errorf("invalid deployment strategy: %s", strategy)
 	}
 
+	service, err := s.manager.GetService(req.Id)
+	if err != nil {
+		return nil, fmt.Errorf("service not found: %w", err)
+	}
+	image := req.Image
+	if image == "" {
+		image = service.Image
+	}
+
 	log.Logger.Info().
 		Str("service_id", req.Id).
-		Str("image", req.Image).
+		Str("image", image).
 		Str("strategy", string(strategy)).
 		Msg("Starting service update via API")
 
-	// Perform the update (this will be async in a real deployment)
-	// For now, we'll call it directly
-	go func() {
-		if err := deployer.UpdateService(req.Id, req.Image, strategy); err != nil {
-			log.Logger.Error().
-				Err(err).
-				Str("service_id", req.Id).
-				Msg("Deployment failed")
-		} else {
-			log.Logger.Info().
-				Str("service_id", req.Id).
-				Msg("Deployment completed successfully")
+	// Options and env given with the update apply to this and later
+	// updates. The deployer applies them only if no other deployment is in
+	// progress, then starts this one; the deployment controller carries it out.
+	var spec types.Service
+	deployment, err := deployer.UpdateServiceSpec(req.Id, image, strategy, func(service *types.Service) error {
+		if req.UpdateConfig != nil {
+			service.UpdateConfig = applyUpdateOptions(service.UpdateConfig, req.UpdateConfig)
+			if err := deploy.ValidateUpdateConfig(service.UpdateConfig); err != nil {
+				return err
+			}
+		}
+		if req.Env != nil {
+			service.Env = convert.ProtoToEnv(req.Env)
 		}
-	}()
+		spec = *service
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start deployment: %w", err)
+	}
+
+	// Record the spec being rolled out
+	spec.Image = image
+	s.recordRevision(&spec, changeCause(req.ChangeCause, req.Image, req.Env != nil))
 
 	return &proto.UpdateServiceImageResponse{
-		Status: "deployment initiated",
+		Status:       "deployment initiated",
+		DeploymentId: deployment.ID,
 	}, nil
 }
 
+// applyUpdateOptions copies the options set in opts onto an update config;
+// options left unset keep their current value
+func applyUpdateOptions(cfg *types.UpdateConfig, opts *proto.UpdateConfig) *types.UpdateConfig {
+	merged := &types.UpdateConfig{}
+	if cfg != nil {
+		*merged = *cfg
+	}
+
+	if opts.Parallelism > 0 {
+		merged.Parallelism = int(opts.Parallelism)
+	}
+	if opts.DelaySeconds > 0 {
+		merged.Delay = time.Duration(opts.DelaySeconds) * time.Second
+	}
+	if opts.FailureAction != "" {
+		merged.FailureAction = opts.FailureAction
+	}
+	if opts.MaxSurge > 0 {
+		merged.MaxSurge = int(opts.MaxSurge)
+	}
+	if opts.MaxUnavailable > 0 {
+		merged.MaxUnavailable = int(opts.MaxUnavailable)
+	}
+	if opts.HealthCheckGracePeriodSeconds > 0 {
+		merged.HealthCheckGracePeriod = time.Duration(opts.HealthCheckGracePeriodSeconds) * time.Second
+	}
+	if len(opts.CanarySteps) > 0 {
+		merged.CanarySteps = nil
+		for _, step := range opts.CanarySteps {
+			merged.CanarySteps = append(merged.CanarySteps, int(step))
+		}
+	}
+	if opts.CanaryStabilityWindowSeconds > 0 {
+		merged.CanaryStabilityWindow = time.Duration(opts.CanaryStabilityWindowSeconds) * time.Second
+	}
+	if opts.AutoRollbackEnabled {
+		merged.AutoRollbackEnabled = true
+	}
+	if opts.FailureThresholdPercent > 0 {
+		merged.FailureThresholdPercent = int(opts.FailureThresholdPercent)
+	}
+	if opts.P99ThresholdPercent > 0 {
+		merged.P99ThresholdPercent = int(opts.P99ThresholdPercent)
+	}
+	return merged
+}
+
 // RollbackService rolls back a service to the previous version
 func (s *Server) RollbackService(ctx context.Context, req *proto.RollbackServiceRequest) (*proto.RollbackServiceResponse, error) {
 	// Start timing rollback
@@ -532,18 +701,96 @@ func (s *Server) RollbackService(ctx context.Context, req *proto.RollbackService
 
 	log.Logger.Info().
 		Str("service_id", req.Id).
+		Int32("to_revision", req.ToRevision).
 		Msg("Starting service rollback via API")
 
-	// Perform the rollback
-	if err := deployer.RollbackDeployment(req.Id); err != nil {
+	revisions, err := s.manager.ListServiceRevisions(req.Id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service revisions: %w", err)
+	}
+
+	// Services without history fall back to their standby version
+	if req.ToRevision == 0 && len(revisions) < 2 {
+		if err := deployer.RollbackDeployment(req.Id); err != nil {
+			return nil, fmt.Errorf("rollback failed: %w", err)
+		}
+		return &proto.RollbackServiceResponse{
+			Status: "rollback completed",
+		}, nil
+	}
+
+	var revision *types.ServiceRevision
+	if req.ToRevision == 0 {
+		revision = revisions[len(revisions)-2]
+	} else {
+		revision, err = s.manager.GetServiceRevision(req.Id, int(req.ToRevision))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	deployment, err := deployer.RollbackToRevision(req.Id, revision)
+	if err != nil {
 		return nil, fmt.Errorf("rollback failed: %w", err)
 	}
 
+	if service, err := s.manager.GetService(req.Id); err == nil {
+		service.Image = revision.Spec.Image
+		s.recordRevision(service, fmt.Sprintf("rolled back to revision %d", revision.Revision))
+	}
+
 	return &proto.RollbackServiceResponse{
-		Status: "rollback completed",
+		Status:       "rollback initiated",
+		DeploymentId: deployment.ID,
+		Revision:     int32(revision.Revision),
 	}, nil
 }
 
+// ListServiceRevisions returns the recorded revisions of a service, oldest first
+func (s *Server) ListServiceRevisions(ctx context.Context, req *proto.ListServiceRevisionsRequest) (*proto.ListServiceRevisionsResponse, error) {
+	revisions, err := s.manager.ListServiceRevisions(req.ServiceId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service revisions: %w", err)
+	}
+
+	resp := &proto.ListServiceRevisionsResponse{}
+	for _, revision := range revisions {
+		resp.Revisions = append(resp.Revisions, &proto.ServiceRevision{
+			Revision:    int32(revision.Revision),
+			ChangeCause: revision.ChangeCause,
+			Spec:        serviceToProto(revision.Spec),
+			CreatedAt:   timestamppb.New(revision.CreatedAt),
+		})
+	}
+	return resp, nil
+}
+
+// recordRevision records a service's spec as a new revision if it changed.
+// Failing to record history does not fail the change itself.
+func (s *Server) recordRevision(service *types.Service, cause string) {
+	if _, err := s.manager.RecordServiceRevision(service, cause); err != nil {
+		log.Logger.Warn().
+			Err(err).
+			Str("service_id", service.ID).
+			Msg("Failed to record service revision")
+	}
+}
+
+// changeCause returns the cause given with a change, or describes the change
+func changeCause(cause, image string, env bool) string {
+	switch {
+	case cause != "":
+		return cause
+	case image != "" && env:
+		return fmt.Sprintf("image updated to %s, env updated", image)
+	case image != "":
+		return fmt.Sprintf("image updated to %s", image)
+	case env:
+		return "env updated"
+	}
+	return "service updated"
+}
+
 // GetService returns a specific service
 func (s *Server) GetService(ctx context.Context, req *proto.GetServiceRequest) (*proto.GetServiceResponse, error) {
 	var service *types.Service
@@ -583,6 +830,207 @@ func (s *Server) ListServices(ctx context.Context, req *proto.ListServicesReques
 	}, nil
 }
 
+// ListServiceEndpoints returns the healthy replicas behind every service VIP
+// and ingress published port, which workers program into their kernel load
+// balancer. Only the requested services are listed, if any are named.
+func (s *Server) ListServiceEndpoints(ctx context.Context, req *proto.ListServiceEndpointsRequest) (*proto.ListServiceEndpointsResponse, error) {
+	services, err := s.manager.ListServices()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+
+	var names map[string]bool
+	if len(req.ServiceNames) > 0 {
+		names = make(map[string]bool, len(req.ServiceNames))
+		for _, name := range req.ServiceNames {
+			names[name] = true
+		}
+	}
+
+	networkConfig, err := s.manager.NetworkConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network config: %w", err)
+	}
+
+	resp := &proto.ListServiceEndpointsResponse{
+		ServiceSubnet: networkConfig.ServiceSubnet,
+	}
+
+	for _, svc := range services {
+		if names != nil && !names[svc.Name] {
+			continue
+		}
+		if svc.VIP == nil && !hasIngressPorts(svc) {
+			continue
+		}
+
+		se := &proto.ServiceEndpoints{
+			ServiceId:   svc.ID,
+			ServiceName: svc.Name,
+		}
+		if svc.VIP != nil {
+			se.Vip = svc.VIP.String()
+		}
+
+		for _, port := range svc.Ports {
+			protocol := port.Protocol
+			if protocol == "" {
+				protocol = "tcp"
+			}
+
+			backends, err := s.manager.ServiceEndpoints(svc.Name, port.ContainerPort, protocol)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve endpoints for %s: %w", svc.Name, err)
+			}
+
+			pe := &proto.ServicePortEndpoints{
+				Protocol:    protocol,
+				Port:        int32(port.ContainerPort),
+				ForwardPort: int32(network.ForwardPort(svc.VIP, svc.Ports, port.ContainerPort, protocol)),
+				Endpoints:   make([]*proto.ServiceEndpoint, 0, len(backends)),
+			}
+			if port.PublishMode == types.PublishModeIngress && port.HostPort > 0 {
+				pe.PublishedPort = int32(port.HostPort)
+			}
+			for _, b := range backends {
+				pe.Endpoints = append(pe.Endpoints, &proto.ServiceEndpoint{
+					NodeId:      b.NodeID,
+					ContainerId: b.ContainerID,
+					Address:     net.JoinHostPort(b.IP, strconv.Itoa(b.Port)),
+				})
+			}
+			se.Ports = append(se.Ports, pe)
+		}
+
+		resp.Services = append(resp.Services, se)
+	}
+
+	return resp, nil
+}
+
+// GetPlacementDecisions returns the scheduler's latest placement decisions
+// for a service. Only the leader schedules, so only it has them.
+func (s *Server) GetPlacementDecisions(ctx context.Context, req *proto.GetPlacementDecisionsRequest) (*proto.GetPlacementDecisionsResponse, error) {
+	if err := s.ensureLeader(); err != nil {
+		return nil, err
+	}
+	if s.scheduler == nil {
+		return nil, fmt.Errorf("scheduler not running on this manager")
+	}
+
+	resp := &proto.GetPlacementDecisionsResponse{}
+	for _, d := range s.scheduler.Decisions(req.ServiceId) {
+		resp.Decisions = append(resp.Decisions, decisionToProto(d))
+	}
+	return resp, nil
+}
+
+// RebalanceService asks the scheduler to move a service's replicas off its
+// most loaded nodes
+func (s *Server) RebalanceService(ctx context.Context, req *proto.RebalanceServiceRequest) (*proto.RebalanceServiceResponse, error) {
+	if err := s.ensureLeader(); err != nil {
+		return nil, err
+	}
+	if s.scheduler == nil {
+		return nil, fmt.Errorf("scheduler not running on this manager")
+	}
+
+	status, err := s.scheduler.Rebalance(req.ServiceId)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.RebalanceServiceResponse{
+		Skew:  int32(status.Skew),
+		Moves: int32(status.Moves),
+	}, nil
+}
+
+// ListDeployments lists deployments, optionally of one service
+func (s *Server) ListDeployments(ctx context.Context, req *proto.ListDeploymentsRequest) (*proto.ListDeploymentsResponse, error) {
+	deployments, err := s.manager.ListDeployments()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	sort.Slice(deployments, func(i, j int) bool {
+		return deployments[i].CreatedAt.After(deployments[j].CreatedAt)
+	})
+
+	resp := &proto.ListDeploymentsResponse{}
+	for _, deployment := range deployments {
+		if req.ServiceId != "" && deployment.ServiceID != req.ServiceId {
+			continue
+		}
+		resp.Deployments = append(resp.Deployments, convert.DeploymentToProto(deployment))
+	}
+	return resp, nil
+}
+
+// GetDeployment returns a deployment by ID
+func (s *Server) GetDeployment(ctx context.Context, req *proto.GetDeploymentRequest) (*proto.GetDeploymentResponse, error) {
+	deployment, err := s.manager.GetDeployment(req.Id)
+	if err != nil {
+		return nil, fmt.Errorf("deployment not found: %w", err)
+	}
+	return &proto.GetDeploymentResponse{
+		Deployment: convert.DeploymentToProto(deployment),
+	}, nil
+}
+
+// PauseDeployment stops a running deployment at its current step
+func (s *Server) PauseDeployment(ctx context.Context, req *proto.PauseDeploymentRequest) (*proto.PauseDeploymentResponse, error) {
+	if err := s.ensureLeader(); err != nil {
+		return nil, err
+	}
+
+	deployment, err := s.manager.GetDeployer().Pause(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.PauseDeploymentResponse{
+		Deployment: convert.DeploymentToProto(deployment),
+	}, nil
+}
+
+// ResumeDeployment continues a paused deployment
+func (s *Server) ResumeDeployment(ctx context.Context, req *proto.ResumeDeploymentRequest) (*proto.ResumeDeploymentResponse, error) {
+	if err := s.ensureLeader(); err != nil {
+		return nil, err
+	}
+
+	deployment, err := s.manager.GetDeployer().Resume(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.ResumeDeploymentResponse{
+		Deployment: convert.DeploymentToProto(deployment),
+	}, nil
+}
+
+// AbortDeployment stops a deployment and returns its service to the previous version
+func (s *Server) AbortDeployment(ctx context.Context, req *proto.AbortDeploymentRequest) (*proto.AbortDeploymentResponse, error) {
+	if err := s.ensureLeader(); err != nil {
+		return nil, err
+	}
+
+	deployment, err := s.manager.GetDeployer().Abort(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.AbortDeploymentResponse{
+		Deployment: convert.DeploymentToProto(deployment),
+	}, nil
+}
+
+// hasIngressPorts reports whether a service publishes a port on every node
+func hasIngressPorts(svc *types.Service) bool {
+	for _, port := range svc.Ports {
+		if port.PublishMode == types.PublishModeIngress && port.HostPort > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // UpdateTaskStatus updates the status of a task
 func (s *Server) UpdateContainerStatus(ctx context.Context, req *proto.UpdateContainerStatusRequest) (*proto.UpdateContainerStatusResponse, error) {
 	container, err := s.manager.GetContainer(req.ContainerId)
@@ -649,7 +1097,7 @@ func (s *Server) ListContainers(ctx context.Context, req *proto.ListContainersRe
 
 	protoContainers := make([]*proto.Container, len(containers))
 	for i, container := range containers {
-		protoContainers[i] = containerToProto(container)
+		protoContainers[i] = convert.ContainerToProto(container)
 	}
 
 	return &proto.ListContainersResponse{
@@ -665,15 +1113,122 @@ func (s *Server) GetContainer(ctx context.Context, req *proto.GetContainerReques
 	}
 
 	return &proto.GetContainerResponse{
-		Container: containerToProto(container),
+		Container: convert.ContainerToProto(container),
 	}, nil
 }
 
-// WatchContainers streams container events to a worker node
+// WatchContainers streams container assignment changes to a worker node
+// The node's current assignments are sent first, followed by changes as
+// they are applied to the FSM. If the stream falls behind and drops events,
+// the full assignment set is sent again.
 func (s *Server) WatchContainers(req *proto.WatchContainersRequest, stream proto.WarrenAPI_WatchContainersServer) error {
-	// TODO: Implement container watch stream
-	// For now, return unimplemented
-	return fmt.Errorf("WatchContainers not yet implemented")
+	if req.NodeId == "" {
+		return fmt.Errorf("node_id is required")
+	}
+
+	broker := s.manager.GetEventBroker()
+	if broker == nil {
+		return fmt.Errorf("event broker not available")
+	}
+
+	// Subscribe before reading current state so no change is missed in between
+	sub := broker.Subscribe()
+	defer broker.Unsubscribe(sub)
+
+	if err := s.sendNodeContainers(req.NodeId, stream); err != nil {
+		return err
+	}
+
+	var lastSeq uint64
+	ctx := stream.Context()
+	for {
+		select {
+		case event, ok := <-sub:
+			if !ok {
+				return nil
+			}
+
+			// Broker IDs are sequential, so a gap means our buffer overflowed
+			if seq, err := strconv.ParseUint(event.ID, 10, 64); err == nil {
+				if lastSeq != 0 && seq != lastSeq+1 {
+					log.Logger.Warn().
+						Str("node_id", req.NodeId).
+						Uint64("last_event", lastSeq).
+						Uint64("event", seq).
+						Msg("Container watch missed events, resending assignments")
+					if err := s.sendNodeContainers(req.NodeId, stream); err != nil {
+						return err
+					}
+				}
+				lastSeq = seq
+			}
+
+			if event.Metadata["node_id"] != req.NodeId {
+				continue
+			}
+
+			containerEvent := s.containerEventFor(event)
+			if containerEvent == nil {
+				continue
+			}
+			if err := stream.Send(containerEvent); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// sendNodeContainers sends every container assigned to a node as an "add" event
+func (s *Server) sendNodeContainers(nodeID string, stream proto.WarrenAPI_WatchContainersServer) error {
+	containers, err := s.manager.ListContainersByNode(nodeID)
+	if err != nil {
+		return fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	for _, container := range containers {
+		if err := stream.Send(&proto.ContainerEvent{
+			Type:      "add",
+			Container: convert.ContainerToProto(container),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// containerEventFor converts a broker task event into a container watch event
+// Returns nil for events that don't change the node's assignments
+func (s *Server) containerEventFor(event *events.Event) *proto.ContainerEvent {
+	containerID := event.Metadata["container_id"]
+
+	switch event.Type {
+	case events.EventTaskCreated, events.EventTaskUpdated:
+		container, err := s.manager.GetContainer(containerID)
+		if err != nil {
+			return nil // Already deleted; a delete event follows
+		}
+		eventType := "update"
+		if event.Type == events.EventTaskCreated {
+			eventType = "add"
+		}
+		return &proto.ContainerEvent{
+			Type:      eventType,
+			Container: convert.ContainerToProto(container),
+		}
+	case events.EventTaskDeleted:
+		return &proto.ContainerEvent{
+			Type: "delete",
+			Container: &proto.Container{
+				Id:           containerID,
+				NodeId:       event.Metadata["node_id"],
+				DesiredState: string(types.ContainerStateShutdown),
+			},
+		}
+	default:
+		return nil
+	}
 }
 
 // CreateSecret creates a new secret
@@ -837,6 +1392,69 @@ func (s *Server) GenerateJoinToken(ctx context.Context, req *proto.GenerateJoinT
 		Token:     token.Token,
 		Role:      token.Role,
 		ExpiresAt: timestamppb.New(token.ExpiresAt),
+		Id:        token.ID,
+	}, nil
+}
+
+// ListJoinTokens lists the cluster's join tokens without their secret values
+func (s *Server) ListJoinTokens(ctx context.Context, req *proto.ListJoinTokensRequest) (*proto.ListJoinTokensResponse, error) {
+	tokens, err := s.manager.ListJoinTokens()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list join tokens: %w", err)
+	}
+
+	protoTokens := make([]*proto.JoinToken, len(tokens))
+	for i, token := range tokens {
+		protoTokens[i] = &proto.JoinToken{
+			Id:        token.ID,
+			Role:      token.Role,
+			CreatedAt: timestamppb.New(token.CreatedAt),
+			ExpiresAt: timestamppb.New(token.ExpiresAt),
+		}
+	}
+
+	return &proto.ListJoinTokensResponse{
+		Tokens: protoTokens,
+	}, nil
+}
+
+// RevokeJoinToken revokes a join token by ID or by the token itself
+func (s *Server) RevokeJoinToken(ctx context.Context, req *proto.RevokeJoinTokenRequest) (*proto.RevokeJoinTokenResponse, error) {
+	if err := s.ensureLeader(); err != nil {
+		return nil, err
+	}
+
+	if req.Id == "" {
+		return nil, fmt.Errorf("token ID is required")
+	}
+
+	if err := s.manager.RevokeJoinToken(req.Id); err != nil {
+		return nil, fmt.Errorf("failed to revoke join token: %w", err)
+	}
+
+	return &proto.RevokeJoinTokenResponse{}, nil
+}
+
+// RotateJoinToken revokes every join token for a role and issues a new one
+func (s *Server) RotateJoinToken(ctx context.Context, req *proto.RotateJoinTokenRequest) (*proto.RotateJoinTokenResponse, error) {
+	if err := s.ensureLeader(); err != nil {
+		return nil, err
+	}
+
+	if req.Role != "worker" && req.Role != "manager" {
+		return nil, fmt.Errorf("role must be 'worker' or 'manager'")
+	}
+
+	token, err := s.manager.RotateJoinToken(req.Role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate join token: %w", err)
+	}
+
+	return &proto.RotateJoinTokenResponse{
+		Token:     token.Token,
+		Role:      token.Role,
+		ExpiresAt: timestamppb.New(token.ExpiresAt),
+		Id:        token.ID,
 	}, nil
 }
 
@@ -904,6 +1522,26 @@ func (s *Server) GetClusterInfo(ctx context.Context, req *proto.GetClusterInfoRe
 
 // Helper functions to convert between internal types and protobuf
 
+// decisionToProto converts a scheduler placement decision to protobuf
+func decisionToProto(d *scheduler.Decision) *proto.PlacementDecision {
+	pd := &proto.PlacementDecision{
+		ContainerId: d.ContainerID,
+		NodeId:      d.NodeID,
+		Reason:      d.Reason,
+		Time:        timestamppb.New(d.Time),
+	}
+	for _, eval := range d.Nodes {
+		pd.Nodes = append(pd.Nodes, &proto.NodeEvaluation{
+			NodeId:     eval.NodeID,
+			FilteredBy: eval.FilteredBy,
+			Reason:     eval.Reason,
+			Scores:     eval.Scores,
+			Score:      eval.Score,
+		})
+	}
+	return pd
+}
+
 func nodeToProto(n *types.Node) *proto.Node {
 	return &proto.Node{
 		Id:      n.ID,
@@ -916,27 +1554,24 @@ func nodeToProto(n *types.Node) *proto.Node {
 			return ""
 		}(),
 		Resources: &proto.NodeResources{
-			CpuCores:    int64(n.Resources.CPUCores),
-			MemoryBytes: n.Resources.MemoryBytes,
-			DiskBytes:   n.Resources.DiskBytes,
+			CpuCores:        int64(n.Resources.CPUCores),
+			MemoryBytes:     n.Resources.MemoryBytes,
+			DiskBytes:       n.Resources.DiskBytes,
+			CpuAllocated:    n.Resources.CPUAllocated,
+			MemoryAllocated: n.Resources.MemoryAllocated,
 		},
-		Status:        string(n.Status),
-		LastHeartbeat: timestamppb.New(n.LastHeartbeat),
-		CreatedAt:     timestamppb.New(n.CreatedAt),
-		Labels:        n.Labels,
+		Status:           string(n.Status),
+		LastHeartbeat:    timestamppb.New(n.LastHeartbeat),
+		CreatedAt:        timestamppb.New(n.CreatedAt),
+		Labels:           n.Labels,
+		OverlayPublicKey: n.OverlayPublicKey,
+		OverlayPort:      int32(n.OverlayPort),
+		Hostname:         n.Hostname,
+		Taints:           convert.TaintsToProto(n.Taints),
 	}
 }
 
 func serviceToProto(s *types.Service) *proto.Service {
-	// Convert env slice to map
-	envMap := make(map[string]string)
-	for _, e := range s.Env {
-		parts := strings.SplitN(e, "=", 2)
-		if len(parts) == 2 {
-			envMap[parts[0]] = parts[1]
-		}
-	}
-
 	ps := &proto.Service{
 		Id:             s.ID,
 		Name:           s.Name,
@@ -944,116 +1579,41 @@ func serviceToProto(s *types.Service) *proto.Service {
 		Replicas:       int32(s.Replicas),
 		Mode:           string(s.Mode),
 		DeployStrategy: string(s.DeployStrategy),
-		Env:            envMap,
+		Env:            convert.EnvToProto(s.Env),
 		Networks:       s.Networks,
+		Volumes:        convert.VolumeMountsToProto(s.Volumes),
 		CreatedAt:      timestamppb.New(s.CreatedAt),
 		UpdatedAt:      timestamppb.New(s.UpdatedAt),
+		StopTimeout:    int32(s.StopTimeout),
 	}
+	ps.DisruptionBudget = int32(s.DisruptionBudget)
+	ps.Priority = int32(s.Priority)
 
-	if s.UpdateConfig != nil {
-		ps.UpdateConfig = &proto.UpdateConfig{
-			Parallelism:   int32(s.UpdateConfig.Parallelism),
-			DelaySeconds:  int32(s.UpdateConfig.Delay / time.Second),
-			FailureAction: s.UpdateConfig.FailureAction,
-		}
-	}
-
-	if s.HealthCheck != nil {
-		ps.HealthCheck = healthCheckToProto(s.HealthCheck)
+	if s.VIP != nil {
+		ps.Vip = s.VIP.String()
 	}
 
-	if s.RestartPolicy != nil {
-		ps.RestartPolicy = &proto.RestartPolicy{
-			Condition:    string(s.RestartPolicy.Condition),
-			MaxAttempts:  int32(s.RestartPolicy.MaxAttempts),
-			DelaySeconds: int32(s.RestartPolicy.Delay / time.Second),
-		}
-	}
-
-	if s.Resources != nil {
-		ps.Resources = &proto.ResourceRequirements{
-			CpuShares:              int64(s.Resources.CPULimit * 1024), // Convert cores to shares
-			MemoryBytes:            s.Resources.MemoryLimit,
-			MemoryReservationBytes: s.Resources.MemoryReservation,
-		}
-	}
-
-	// Convert port mappings from types to proto
-	if len(s.Ports) > 0 {
-		ps.Ports = make([]*proto.PortMapping, 0, len(s.Ports))
-		for _, typePort := range s.Ports {
-			publishMode := proto.PortMapping_HOST
-			if typePort.PublishMode == types.PublishModeIngress {
-				publishMode = proto.PortMapping_INGRESS
-			}
-
-			ps.Ports = append(ps.Ports, &proto.PortMapping{
-				Name:          typePort.Name,
-				ContainerPort: int32(typePort.ContainerPort),
-				HostPort:      int32(typePort.HostPort),
-				Protocol:      typePort.Protocol,
-				PublishMode:   publishMode,
-			})
-		}
+	ps.UpdateConfig = convert.UpdateConfigToProto(s.UpdateConfig)
+	ps.HealthCheck = convert.HealthCheckToProto(s.HealthCheck)
+	ps.RestartPolicy = convert.RestartPolicyToProto(s.RestartPolicy)
+	ps.Resources = convert.ResourcesToProto(s.Resources)
+	ps.Placement = convert.PlacementToProto(s.Placement)
+	ps.Labels = s.Labels
+	ps.Affinity = convert.AffinityToProto(s.Affinity)
+	ps.AntiAffinity = convert.AffinityToProto(s.AntiAffinity)
+	ps.Tolerations = convert.TolerationsToProto(s.Tolerations)
+	ps.Ports = convert.PortsToProto(s.Ports)
+	ps.Job = convert.JobConfigToProto(s.Job)
+	ps.JobStatus = convert.JobStatusToProto(s.JobStatus)
+	ps.CronJob = convert.CronJobConfigToProto(s.CronJob)
+	ps.CanaryAnalysis = convert.CanaryAnalysisToProto(s.CanaryAnalysis)
+	if !s.LastScheduleTime.IsZero() {
+		ps.LastScheduleTime = timestamppb.New(s.LastScheduleTime)
 	}
 
 	return ps
 }
 
-func containerToProto(t *types.Container) *proto.Container {
-	// Convert env slice to map
-	envMap := make(map[string]string)
-	for _, e := range t.Env {
-		parts := strings.SplitN(e, "=", 2)
-		if len(parts) == 2 {
-			envMap[parts[0]] = parts[1]
-		}
-	}
-
-	pt := &proto.Container{
-		Id:                 t.ID,
-		ServiceId:          t.ServiceID,
-		ServiceName:        t.ServiceName,
-		NodeId:             t.NodeID,
-		RuntimeContainerId: t.ContainerID,
-		DesiredState:       string(t.DesiredState),
-		ActualState:        string(t.ActualState),
-		Image:              t.Image,
-		Env:                envMap,
-		CreatedAt:          timestamppb.New(t.CreatedAt),
-		Error:              t.Error,
-	}
-
-	// Use StartedAt for UpdatedAt if available, otherwise CreatedAt
-	if !t.StartedAt.IsZero() {
-		pt.UpdatedAt = timestamppb.New(t.StartedAt)
-	} else {
-		pt.UpdatedAt = timestamppb.New(t.CreatedAt)
-	}
-
-	if t.Resources != nil {
-		pt.Resources = &proto.ResourceRequirements{
-			CpuShares:              int64(t.Resources.CPULimit * 1024),
-			MemoryBytes:            t.Resources.MemoryLimit,
-			MemoryReservationBytes: t.Resources.MemoryReservation,
-		}
-	}
-
-	if t.HealthCheck != nil {
-		pt.HealthCheck = healthCheckToProto(t.HealthCheck)
-	}
-
-	if t.RestartPolicy != nil {
-		pt.RestartPolicy = &proto.RestartPolicy{
-			Condition:    string(t.RestartPolicy.Condition),
-			MaxAttempts:  int32(t.RestartPolicy.MaxAttempts),
-			DelaySeconds: int32(t.RestartPolicy.Delay / time.Second),
-		}
-	}
-
-	return pt
-}
-
 func secretToProto(s *types.Secret) *proto.Secret {
 	return &proto.Secret{
 		Id:        s.ID,
@@ -1085,88 +1645,104 @@ func volumeToProto(v *types.Volume) *proto.Volume {
 	}
 }
 
-// protoToHealthCheck converts proto HealthCheck to types.HealthCheck
-func protoToHealthCheck(ph *proto.HealthCheck) *types.HealthCheck {
-	if ph == nil {
-		return nil
+// StreamEvents streams cluster events to the client
+// Buffered events after SinceEventId are replayed first, so a client can
+// resume a stream after reconnecting without missing events.
+func (s *Server) StreamEvents(req *proto.StreamEventsRequest, stream proto.WarrenAPI_StreamEventsServer) error {
+	broker := s.manager.GetEventBroker()
+	if broker == nil {
+		return fmt.Errorf("event broker not available")
 	}
 
-	hc := &types.HealthCheck{
-		Interval: time.Duration(ph.IntervalSeconds) * time.Second,
-		Timeout:  time.Duration(ph.TimeoutSeconds) * time.Second,
-		Retries:  int(ph.Retries),
+	filter := &events.Filter{
+		ServiceID: req.Service,
+		NodeID:    req.NodeId,
+	}
+	for _, t := range req.EventTypes {
+		filter.Types = append(filter.Types, events.EventType(t))
 	}
 
-	switch ph.Type {
-	case proto.HealthCheck_HTTP:
-		hc.Type = types.HealthCheckHTTP
-		if ph.Http != nil {
-			// Construct endpoint from HTTP config
-			scheme := ph.Http.Scheme
-			if scheme == "" {
-				scheme = "http"
+	// Without follow, replay the buffered history and return
+	if !req.Follow {
+		history, err := broker.History(req.SinceEventId)
+		if err != nil {
+			return resumeError(err)
+		}
+		for _, event := range history {
+			if !filter.Matches(event) {
+				continue
+			}
+			if err := stream.Send(eventToProto(event)); err != nil {
+				return err
 			}
-			hc.Endpoint = fmt.Sprintf("%s://:%d%s", scheme, ph.Http.Port, ph.Http.Path)
 		}
-	case proto.HealthCheck_TCP:
-		hc.Type = types.HealthCheckTCP
-		if ph.Tcp != nil {
-			hc.Endpoint = fmt.Sprintf(":%d", ph.Tcp.Port)
+		return nil
+	}
+
+	sub, backlog, err := broker.SubscribeFrom(req.SinceEventId)
+	if err != nil {
+		return resumeError(err)
+	}
+	defer broker.Unsubscribe(sub)
+
+	for _, event := range backlog {
+		if !filter.Matches(event) {
+			continue
 		}
-	case proto.HealthCheck_EXEC:
-		hc.Type = types.HealthCheckExec
-		if ph.Exec != nil {
-			hc.Command = ph.Exec.Command
+		if err := stream.Send(eventToProto(event)); err != nil {
+			return err
 		}
 	}
 
-	return hc
+	return followEvents(stream.Context(), broker, sub, filter, func(event *events.Event) error {
+		return stream.Send(eventToProto(event))
+	})
 }
 
-// healthCheckToProto converts types.HealthCheck to proto HealthCheck
-func healthCheckToProto(hc *types.HealthCheck) *proto.HealthCheck {
-	if hc == nil {
-		return nil
-	}
-
-	ph := &proto.HealthCheck{
-		IntervalSeconds: int32(hc.Interval / time.Second),
-		TimeoutSeconds:  int32(hc.Timeout / time.Second),
-		Retries:         int32(hc.Retries),
+// resumeError reports a stream that cannot resume where the client left
+// off as codes.OutOfRange, so that the client can tell it from a failure
+func resumeError(err error) error {
+	if errors.Is(err, events.ErrResumePointLost) {
+		return status.Error(codes.OutOfRange, err.Error())
 	}
+	return err
+}
 
-	switch hc.Type {
-	case types.HealthCheckHTTP:
-		ph.Type = proto.HealthCheck_HTTP
-		// Parse endpoint to extract path and port
-		// For now, keep it simple - just set basic HTTP config
-		ph.Http = &proto.HTTPHealthCheck{
-			Path:          "/health",
-			Port:          8080,
-			Scheme:        "http",
-			StatusCodeMin: 200,
-			StatusCodeMax: 399,
-		}
-	case types.HealthCheckTCP:
-		ph.Type = proto.HealthCheck_TCP
-		// Parse endpoint to extract port
-		ph.Tcp = &proto.TCPHealthCheck{
-			Port: 8080,
-		}
-	case types.HealthCheckExec:
-		ph.Type = proto.HealthCheck_EXEC
-		ph.Exec = &proto.ExecHealthCheck{
-			Command: hc.Command,
+// followEvents sends the events of a subscription that pass the filter
+// until ctx is cancelled. If the subscription missed events, the stream
+// ends before anything past the gap is sent, so that the client resumes
+// from the last event it received and the missed ones are replayed.
+func followEvents(ctx context.Context, broker *events.Broker, sub events.Subscriber, filter *events.Filter, send func(*events.Event) error) error {
+	for {
+		select {
+		case event, ok := <-sub:
+			if !ok {
+				return nil
+			}
+			if broker.Overflowed(sub) {
+				return fmt.Errorf("event stream fell behind at event %s, resume from the last event received", event.ID)
+			}
+			if !filter.Matches(event) {
+				continue
+			}
+			if err := send(event); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
 		}
 	}
-
-	return ph
 }
 
-// StreamEvents streams cluster events to the client
-// TODO: Complete implementation
-func (s *Server) StreamEvents(req *proto.StreamEventsRequest, stream proto.WarrenAPI_StreamEventsServer) error {
-	return fmt.Errorf("event streaming not yet implemented")
+// eventToProto converts a broker event to its protobuf representation
+func eventToProto(e *events.Event) *proto.Event {
+	return &proto.Event{
+		Id:        e.ID,
+		Type:      string(e.Type),
+		Timestamp: timestamppb.New(e.Timestamp),
+		Message:   e.Message,
+		Metadata:  e.Metadata,
+	}
 }
 
 // RequestCertificate issues a certificate for a node joining the cluster
@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"io"
 	"os"
 	"time"
 
@@ -228,10 +229,7 @@ func (c *Client) DeleteService(id string) error {
 }
 
 // UpdateServiceImage updates a service with a new image using specified deployment strategy
-func (c *Client) UpdateServiceImage(id string, image string, strategy string, updateConfig *proto.UpdateConfig) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute) // Longer timeout for deployments
-	defer cancel()
-
+func (c *Client) UpdateServiceImage(id string, image string, strategy string, updateConfig *proto.UpdateConfig) (string, error) {
 	req := &proto.UpdateServiceImageRequest{
 		Id:       id,
 		Image:    image,
@@ -242,20 +240,48 @@ func (c *Client) UpdateServiceImage(id string, image string, strategy string, up
 		req.UpdateConfig = updateConfig
 	}
 
-	_, err := c.client.UpdateServiceImage(ctx, req)
-	return err
+	return c.UpdateServiceImageWithOptions(req)
 }
 
-// RollbackService rolls back a service to the previous version
-func (c *Client) RollbackService(id string) error {
+// UpdateServiceImageWithOptions starts a deployment of a service with all
+// update options, and returns the deployment's ID
+func (c *Client) UpdateServiceImageWithOptions(req *proto.UpdateServiceImageRequest) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	resp, err := c.client.UpdateServiceImage(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.DeploymentId, nil
+}
+
+// RollbackService rolls back a service to a revision, or to the revision
+// before the current one if toRevision is 0
+func (c *Client) RollbackService(id string, toRevision int32) (*proto.RollbackServiceResponse, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 	defer cancel()
 
-	_, err := c.client.RollbackService(ctx, &proto.RollbackServiceRequest{
-		Id: id,
+	return c.client.RollbackService(ctx, &proto.RollbackServiceRequest{
+		Id:         id,
+		ToRevision: toRevision,
 	})
+}
 
-	return err
+// ListServiceRevisions returns the recorded revisions of a service, oldest first
+func (c *Client) ListServiceRevisions(serviceID string) ([]*proto.ServiceRevision, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := c.client.ListServiceRevisions(ctx, &proto.ListServiceRevisionsRequest{
+		ServiceId: serviceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Revisions, nil
 }
 
 // ListNodes lists all nodes
@@ -286,6 +312,19 @@ func (c *Client) GetNode(id string) (*proto.Node, error) {
 	return resp.Node, nil
 }
 
+// UpdateNode adds and removes labels and taints on a node
+func (c *Client) UpdateNode(req *proto.UpdateNodeRequest) (*proto.Node, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := c.client.UpdateNode(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Node, nil
+}
+
 // ListContainers lists all containers (optionally filtered)
 func (c *Client) ListContainers(serviceID, nodeID string) ([]*proto.Container, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -302,6 +341,99 @@ func (c *Client) ListContainers(serviceID, nodeID string) ([]*proto.Container, e
 	return resp.Containers, nil
 }
 
+// GetPlacementDecisions returns the scheduler's latest placement decisions for a service
+func (c *Client) GetPlacementDecisions(serviceID string) ([]*proto.PlacementDecision, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := c.client.GetPlacementDecisions(ctx, &proto.GetPlacementDecisionsRequest{
+		ServiceId: serviceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Decisions, nil
+}
+
+// RebalanceService starts moving a service's replicas off its most loaded
+// nodes, returning the current skew and how many replicas will move
+func (c *Client) RebalanceService(serviceID string) (*proto.RebalanceServiceResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	return c.client.RebalanceService(ctx, &proto.RebalanceServiceRequest{
+		ServiceId: serviceID,
+	})
+}
+
+// ListDeployments lists deployments, newest first, optionally of one service
+func (c *Client) ListDeployments(serviceID string) ([]*proto.Deployment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := c.client.ListDeployments(ctx, &proto.ListDeploymentsRequest{
+		ServiceId: serviceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Deployments, nil
+}
+
+// GetDeployment returns a deployment by ID
+func (c *Client) GetDeployment(id string) (*proto.Deployment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := c.client.GetDeployment(ctx, &proto.GetDeploymentRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Deployment, nil
+}
+
+// PauseDeployment pauses a running deployment
+func (c *Client) PauseDeployment(id string) (*proto.Deployment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := c.client.PauseDeployment(ctx, &proto.PauseDeploymentRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Deployment, nil
+}
+
+// ResumeDeployment resumes a paused deployment
+func (c *Client) ResumeDeployment(id string) (*proto.Deployment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := c.client.ResumeDeployment(ctx, &proto.ResumeDeploymentRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Deployment, nil
+}
+
+// AbortDeployment aborts a deployment, returning its service to the previous version
+func (c *Client) AbortDeployment(id string) (*proto.Deployment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	resp, err := c.client.AbortDeployment(ctx, &proto.AbortDeploymentRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Deployment, nil
+}
+
 // CreateSecret creates a new secret
 func (c *Client) CreateSecret(name string, data []byte) (*proto.Secret, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -430,6 +562,40 @@ func (c *Client) GenerateJoinToken(role string) (string, error) {
 	return resp.Token, nil
 }
 
+// ListJoinTokens lists the cluster's join tokens
+func (c *Client) ListJoinTokens() ([]*proto.JoinToken, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := c.client.ListJoinTokens(ctx, &proto.ListJoinTokensRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Tokens, nil
+}
+
+// RevokeJoinToken revokes a join token by ID or by the token itself
+func (c *Client) RevokeJoinToken(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := c.client.RevokeJoinToken(ctx, &proto.RevokeJoinTokenRequest{
+		Id: id,
+	})
+	return err
+}
+
+// RotateJoinToken revokes all join tokens for a role and returns a new one
+func (c *Client) RotateJoinToken(role string) (*proto.RotateJoinTokenResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	return c.client.RotateJoinToken(ctx, &proto.RotateJoinTokenRequest{
+		Role: role,
+	})
+}
+
 // GetClusterInfo returns information about the cluster
 func (c *Client) GetClusterInfo() (*proto.GetClusterInfoResponse, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -670,3 +836,52 @@ func (c *Client) DeleteTLSCertificate(req *proto.DeleteTLSCertificateRequest) er
 	_, err := c.client.DeleteTLSCertificate(ctx, req)
 	return err
 }
+
+// --- Event Operations ---
+
+// StreamEvents streams cluster events matching req, calling handler for each one.
+// It returns when the stream ends, ctx is cancelled, or handler returns an error.
+func (c *Client) StreamEvents(ctx context.Context, req *proto.StreamEventsRequest, handler func(*proto.Event) error) error {
+	stream, err := c.client.StreamEvents(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := handler(event); err != nil {
+			return err
+		}
+	}
+}
+
+// --- Log Operations ---
+
+// StreamServiceLogs streams the logs of every replica of a service, calling
+// handler for each line. It returns when the stream ends, ctx is cancelled,
+// or handler returns an error.
+func (c *Client) StreamServiceLogs(ctx context.Context, req *proto.StreamServiceLogsRequest, handler func(*proto.LogEntry) error) error {
+	stream, err := c.client.StreamServiceLogs(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		entry, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := handler(entry); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,742 @@
+package convert
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cuemby/warren/api/proto"
+	"github.com/cuemby/warren/pkg/types"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// cpuSharesPerCore is the number of CPU shares that make up one core
+const cpuSharesPerCore = 1024
+
+// ContainerToProto converts a container to its protobuf form
+func ContainerToProto(t *types.Container) *proto.Container {
+	if t == nil {
+		return nil
+	}
+
+	pt := &proto.Container{
+		Id:                 t.ID,
+		ServiceId:          t.ServiceID,
+		ServiceName:        t.ServiceName,
+		NodeId:             t.NodeID,
+		RuntimeContainerId: t.ContainerID,
+		DesiredState:       string(t.DesiredState),
+		ActualState:        string(t.ActualState),
+		Image:              t.Image,
+		Env:                EnvToProto(t.Env),
+		Resources:          ResourcesToProto(t.Resources),
+		Volumes:            VolumeMountsToProto(t.Mounts),
+		HealthCheck:        HealthCheckToProto(t.HealthCheck),
+		RestartPolicy:      RestartPolicyToProto(t.RestartPolicy),
+		CreatedAt:          timestamppb.New(t.CreatedAt),
+		Error:              t.Error,
+		Secrets:            t.Secrets,
+		StopTimeout:        int32(t.StopTimeout),
+		Ports:              PortsToProto(t.Ports),
+		PreemptedBy:        t.PreemptedBy,
+	}
+
+	// Use StartedAt for UpdatedAt if available, otherwise CreatedAt
+	if !t.StartedAt.IsZero() {
+		pt.UpdatedAt = timestamppb.New(t.StartedAt)
+	} else {
+		pt.UpdatedAt = timestamppb.New(t.CreatedAt)
+	}
+
+	return pt
+}
+
+// ProtoToContainer converts a protobuf container to the internal type
+func ProtoToContainer(pt *proto.Container) *types.Container {
+	if pt == nil {
+		return nil
+	}
+
+	t := &types.Container{
+		ID:            pt.Id,
+		ServiceID:     pt.ServiceId,
+		ServiceName:   pt.ServiceName,
+		NodeID:        pt.NodeId,
+		ContainerID:   pt.RuntimeContainerId,
+		DesiredState:  types.ContainerState(pt.DesiredState),
+		ActualState:   types.ContainerState(pt.ActualState),
+		Image:         pt.Image,
+		Env:           ProtoToEnv(pt.Env),
+		Ports:         ProtoToPorts(pt.Ports),
+		Mounts:        ProtoToVolumeMounts(pt.Volumes),
+		Secrets:       pt.Secrets,
+		HealthCheck:   ProtoToHealthCheck(pt.HealthCheck),
+		RestartPolicy: ProtoToRestartPolicy(pt.RestartPolicy),
+		Resources:     ProtoToResources(pt.Resources),
+		StopTimeout:   int(pt.StopTimeout),
+		Error:         pt.Error,
+		PreemptedBy:   pt.PreemptedBy,
+	}
+
+	if pt.CreatedAt != nil {
+		t.CreatedAt = pt.CreatedAt.AsTime()
+	}
+
+	return t
+}
+
+// EnvToProto converts KEY=VALUE pairs to a map
+func EnvToProto(env []string) map[string]string {
+	envMap := make(map[string]string)
+	for _, e := range env {
+		parts := strings.SplitN(e, "=", 2)
+		if len(parts) == 2 {
+			envMap[parts[0]] = parts[1]
+		}
+	}
+	return envMap
+}
+
+// ProtoToEnv converts an environment map to KEY=VALUE pairs sorted by key
+func ProtoToEnv(envMap map[string]string) []string {
+	if len(envMap) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(envMap))
+	for k := range envMap {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	env := make([]string, 0, len(keys))
+	for _, k := range keys {
+		env = append(env, fmt.Sprintf("%s=%s", k, envMap[k]))
+	}
+	return env
+}
+
+// ResourcesToProto converts resource requirements, expressing CPU in shares
+func ResourcesToProto(r *types.ResourceRequirements) *proto.ResourceRequirements {
+	if r == nil {
+		return nil
+	}
+	return &proto.ResourceRequirements{
+		CpuShares:              int64(r.CPULimit * cpuSharesPerCore), // Convert cores to shares
+		MemoryBytes:            r.MemoryLimit,
+		MemoryReservationBytes: r.MemoryReservation,
+		CpuReservationShares:   int64(r.CPUReservation * cpuSharesPerCore),
+	}
+}
+
+// ProtoToResources converts protobuf resource requirements to the internal type
+func ProtoToResources(pr *proto.ResourceRequirements) *types.ResourceRequirements {
+	if pr == nil {
+		return nil
+	}
+	return &types.ResourceRequirements{
+		CPULimit:          float64(pr.CpuShares) / cpuSharesPerCore, // Convert shares to cores
+		MemoryLimit:       pr.MemoryBytes,
+		CPUReservation:    float64(pr.CpuReservationShares) / cpuSharesPerCore,
+		MemoryReservation: pr.MemoryReservationBytes,
+	}
+}
+
+// RestartPolicyToProto converts a restart policy to its protobuf form
+func RestartPolicyToProto(rp *types.RestartPolicy) *proto.RestartPolicy {
+	if rp == nil {
+		return nil
+	}
+	return &proto.RestartPolicy{
+		Condition:    string(rp.Condition),
+		MaxAttempts:  int32(rp.MaxAttempts),
+		DelaySeconds: int32(rp.Delay / time.Second),
+	}
+}
+
+// ProtoToRestartPolicy converts a protobuf restart policy to the internal type
+func ProtoToRestartPolicy(prp *proto.RestartPolicy) *types.RestartPolicy {
+	if prp == nil {
+		return nil
+	}
+	return &types.RestartPolicy{
+		Condition:   types.RestartCondition(prp.Condition),
+		MaxAttempts: int(prp.MaxAttempts),
+		Delay:       time.Duration(prp.DelaySeconds) * time.Second,
+	}
+}
+
+// PlacementToProto converts service placement to its protobuf form
+func PlacementToProto(p *types.Placement) *proto.Placement {
+	if p == nil {
+		return nil
+	}
+	pp := &proto.Placement{Constraints: p.Constraints}
+	for _, pref := range p.Preferences {
+		pp.Preferences = append(pp.Preferences, &proto.PlacementPreference{Spread: pref.Spread})
+	}
+	return pp
+}
+
+// ProtoToPlacement converts protobuf placement to the internal type. Empty
+// placement converts to nil.
+func ProtoToPlacement(pp *proto.Placement) *types.Placement {
+	if pp == nil || (len(pp.Constraints) == 0 && len(pp.Preferences) == 0) {
+		return nil
+	}
+	p := &types.Placement{Constraints: pp.Constraints}
+	for _, pref := range pp.Preferences {
+		p.Preferences = append(p.Preferences, types.PlacementPreference{Spread: pref.Spread})
+	}
+	return p
+}
+
+// AffinityToProto converts affinity terms to their protobuf form
+func AffinityToProto(a *types.Affinity) *proto.Affinity {
+	if a == nil {
+		return nil
+	}
+	return &proto.Affinity{
+		Required:  affinityTermsToProto(a.Required),
+		Preferred: affinityTermsToProto(a.Preferred),
+	}
+}
+
+// ProtoToAffinity converts protobuf affinity to the internal type. Empty
+// affinity converts to nil.
+func ProtoToAffinity(pa *proto.Affinity) *types.Affinity {
+	if pa == nil || (len(pa.Required) == 0 && len(pa.Preferred) == 0) {
+		return nil
+	}
+	return &types.Affinity{
+		Required:  protoToAffinityTerms(pa.Required),
+		Preferred: protoToAffinityTerms(pa.Preferred),
+	}
+}
+
+func affinityTermsToProto(terms []types.AffinityTerm) []*proto.AffinityTerm {
+	var pts []*proto.AffinityTerm
+	for _, term := range terms {
+		pts = append(pts, &proto.AffinityTerm{
+			Service: term.Service,
+			Labels:  term.Labels,
+			Weight:  int32(term.Weight),
+		})
+	}
+	return pts
+}
+
+func protoToAffinityTerms(pts []*proto.AffinityTerm) []types.AffinityTerm {
+	var terms []types.AffinityTerm
+	for _, pt := range pts {
+		terms = append(terms, types.AffinityTerm{
+			Service: pt.Service,
+			Labels:  pt.Labels,
+			Weight:  int(pt.Weight),
+		})
+	}
+	return terms
+}
+
+// PortsToProto converts port mappings to their protobuf form
+func PortsToProto(ports []*types.PortMapping) []*proto.PortMapping {
+	if len(ports) == 0 {
+		return nil
+	}
+
+	protoPorts := make([]*proto.PortMapping, 0, len(ports))
+	for _, port := range ports {
+		publishMode := proto.PortMapping_HOST
+		if port.PublishMode == types.PublishModeIngress {
+			publishMode = proto.PortMapping_INGRESS
+		}
+
+		protoPorts = append(protoPorts, &proto.PortMapping{
+			Name:          port.Name,
+			ContainerPort: int32(port.ContainerPort),
+			HostPort:      int32(port.HostPort),
+			Protocol:      port.Protocol,
+			PublishMode:   publishMode,
+		})
+	}
+	return protoPorts
+}
+
+// ProtoToPorts converts protobuf port mappings to the internal type
+func ProtoToPorts(protoPorts []*proto.PortMapping) []*types.PortMapping {
+	if len(protoPorts) == 0 {
+		return nil
+	}
+
+	ports := make([]*types.PortMapping, 0, len(protoPorts))
+	for _, protoPort := range protoPorts {
+		publishMode := types.PublishModeHost
+		if protoPort.PublishMode == proto.PortMapping_INGRESS {
+			publishMode = types.PublishModeIngress
+		}
+
+		ports = append(ports, &types.PortMapping{
+			Name:          protoPort.Name,
+			ContainerPort: int(protoPort.ContainerPort),
+			HostPort:      int(protoPort.HostPort),
+			Protocol:      protoPort.Protocol,
+			PublishMode:   publishMode,
+		})
+	}
+	return ports
+}
+
+// VolumeMountsToProto converts volume mounts to their protobuf form
+func VolumeMountsToProto(mounts []*types.VolumeMount) []*proto.VolumeMount {
+	if len(mounts) == 0 {
+		return nil
+	}
+
+	protoMounts := make([]*proto.VolumeMount, 0, len(mounts))
+	for _, m := range mounts {
+		protoMounts = append(protoMounts, &proto.VolumeMount{
+			Source:   m.Source,
+			Target:   m.Target,
+			ReadOnly: m.ReadOnly,
+		})
+	}
+	return protoMounts
+}
+
+// ProtoToVolumeMounts converts protobuf volume mounts to the internal type
+func ProtoToVolumeMounts(protoMounts []*proto.VolumeMount) []*types.VolumeMount {
+	if len(protoMounts) == 0 {
+		return nil
+	}
+
+	mounts := make([]*types.VolumeMount, 0, len(protoMounts))
+	for _, pm := range protoMounts {
+		mounts = append(mounts, &types.VolumeMount{
+			Source:   pm.Source,
+			Target:   pm.Target,
+			ReadOnly: pm.ReadOnly,
+		})
+	}
+	return mounts
+}
+
+// ProtoToHealthCheck converts a protobuf health check to the internal type.
+// HTTP checks become a "scheme://:port/path" endpoint and TCP checks a
+// ":port" endpoint.
+func ProtoToHealthCheck(ph *proto.HealthCheck) *types.HealthCheck {
+	if ph == nil {
+		return nil
+	}
+
+	hc := &types.HealthCheck{
+		Interval: time.Duration(ph.IntervalSeconds) * time.Second,
+		Timeout:  time.Duration(ph.TimeoutSeconds) * time.Second,
+		Retries:  int(ph.Retries),
+	}
+
+	switch ph.Type {
+	case proto.HealthCheck_HTTP:
+		hc.Type = types.HealthCheckHTTP
+		if ph.Http != nil {
+			// Construct endpoint from HTTP config
+			scheme := ph.Http.Scheme
+			if scheme == "" {
+				scheme = "http"
+			}
+			hc.Endpoint = fmt.Sprintf("%s://:%d%s", scheme, ph.Http.Port, ph.Http.Path)
+		}
+	case proto.HealthCheck_TCP:
+		hc.Type = types.HealthCheckTCP
+		if ph.Tcp != nil {
+			hc.Endpoint = fmt.Sprintf(":%d", ph.Tcp.Port)
+		}
+	case proto.HealthCheck_EXEC:
+		hc.Type = types.HealthCheckExec
+		if ph.Exec != nil {
+			hc.Command = ph.Exec.Command
+		}
+	}
+
+	return hc
+}
+
+// HealthCheckToProto converts a health check to its protobuf form, parsing
+// the port and path back out of the endpoint
+func HealthCheckToProto(hc *types.HealthCheck) *proto.HealthCheck {
+	if hc == nil {
+		return nil
+	}
+
+	ph := &proto.HealthCheck{
+		IntervalSeconds: int32(hc.Interval / time.Second),
+		TimeoutSeconds:  int32(hc.Timeout / time.Second),
+		Retries:         int32(hc.Retries),
+	}
+
+	switch hc.Type {
+	case types.HealthCheckHTTP:
+		ph.Type = proto.HealthCheck_HTTP
+		ph.Http = &proto.HTTPHealthCheck{
+			Scheme:        "http",
+			StatusCodeMin: 200,
+			StatusCodeMax: 399,
+		}
+		if u, err := url.Parse(hc.Endpoint); err == nil {
+			if u.Scheme != "" {
+				ph.Http.Scheme = u.Scheme
+			}
+			ph.Http.Path = u.Path
+			ph.Http.Port = parsePort(u.Port())
+		}
+	case types.HealthCheckTCP:
+		ph.Type = proto.HealthCheck_TCP
+		ph.Tcp = &proto.TCPHealthCheck{
+			Port: parsePort(hc.Endpoint[strings.LastIndex(hc.Endpoint, ":")+1:]),
+		}
+	case types.HealthCheckExec:
+		ph.Type = proto.HealthCheck_EXEC
+		ph.Exec = &proto.ExecHealthCheck{
+			Command: hc.Command,
+		}
+	}
+
+	return ph
+}
+
+// parsePort parses a port number, returning 0 if it is not valid
+func parsePort(s string) int32 {
+	port, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return 0
+	}
+	return int32(port)
+}
+
+// TaintsToProto converts node taints to their protobuf form
+func TaintsToProto(taints []types.Taint) []*proto.Taint {
+	var pts []*proto.Taint
+	for _, taint := range taints {
+		pts = append(pts, &proto.Taint{
+			Key:    taint.Key,
+			Value:  taint.Value,
+			Effect: string(taint.Effect),
+		})
+	}
+	return pts
+}
+
+// ProtoToTaints converts protobuf taints to the internal type
+func ProtoToTaints(pts []*proto.Taint) []types.Taint {
+	var taints []types.Taint
+	for _, pt := range pts {
+		taints = append(taints, types.Taint{
+			Key:    pt.Key,
+			Value:  pt.Value,
+			Effect: types.TaintEffect(pt.Effect),
+		})
+	}
+	return taints
+}
+
+// TolerationsToProto converts service tolerations to their protobuf form
+func TolerationsToProto(tolerations []types.Toleration) []*proto.Toleration {
+	var pts []*proto.Toleration
+	for _, tol := range tolerations {
+		pts = append(pts, &proto.Toleration{
+			Key:      tol.Key,
+			Operator: string(tol.Operator),
+			Value:    tol.Value,
+			Effect:   string(tol.Effect),
+		})
+	}
+	return pts
+}
+
+// ProtoToTolerations converts protobuf tolerations to the internal type
+func ProtoToTolerations(pts []*proto.Toleration) []types.Toleration {
+	var tolerations []types.Toleration
+	for _, pt := range pts {
+		tolerations = append(tolerations, types.Toleration{
+			Key:      pt.Key,
+			Operator: types.TolerationOperator(pt.Operator),
+			Value:    pt.Value,
+			Effect:   types.TaintEffect(pt.Effect),
+		})
+	}
+	return tolerations
+}
+
+// JobConfigToProto converts job settings to their protobuf form
+func JobConfigToProto(cfg *types.JobConfig) *proto.JobConfig {
+	if cfg == nil {
+		return nil
+	}
+	return &proto.JobConfig{
+		Completions:           int32(cfg.Completions),
+		Parallelism:           int32(cfg.Parallelism),
+		BackoffLimit:          int32PtrToProto(cfg.BackoffLimit),
+		ActiveDeadlineSeconds: int64(cfg.ActiveDeadline / time.Second),
+	}
+}
+
+// ProtoToJobConfig converts protobuf job settings to the internal type
+func ProtoToJobConfig(pc *proto.JobConfig) *types.JobConfig {
+	if pc == nil {
+		return nil
+	}
+	return &types.JobConfig{
+		Completions:    int(pc.Completions),
+		Parallelism:    int(pc.Parallelism),
+		BackoffLimit:   protoToIntPtr(pc.BackoffLimit),
+		ActiveDeadline: time.Duration(pc.ActiveDeadlineSeconds) * time.Second,
+	}
+}
+
+// JobStatusToProto converts a job status to its protobuf form
+func JobStatusToProto(status *types.JobStatus) *proto.JobStatus {
+	if status == nil {
+		return nil
+	}
+	ps := &proto.JobStatus{
+		State:     string(status.State),
+		Active:    int32(status.Active),
+		Succeeded: int32(status.Succeeded),
+		Failed:    int32(status.Failed),
+		Reason:    status.Reason,
+		StartTime: timestamppb.New(status.StartTime),
+	}
+	if !status.CompletionTime.IsZero() {
+		ps.CompletionTime = timestamppb.New(status.CompletionTime)
+	}
+	return ps
+}
+
+// ProtoToJobStatus converts a protobuf job status to the internal type
+func ProtoToJobStatus(ps *proto.JobStatus) *types.JobStatus {
+	if ps == nil {
+		return nil
+	}
+	status := &types.JobStatus{
+		State:     types.JobState(ps.State),
+		Active:    int(ps.Active),
+		Succeeded: int(ps.Succeeded),
+		Failed:    int(ps.Failed),
+		Reason:    ps.Reason,
+	}
+	if ps.StartTime != nil {
+		status.StartTime = ps.StartTime.AsTime()
+	}
+	if ps.CompletionTime != nil {
+		status.CompletionTime = ps.CompletionTime.AsTime()
+	}
+	return status
+}
+
+// CronJobConfigToProto converts cronjob settings to their protobuf form
+func CronJobConfigToProto(cfg *types.CronJobConfig) *proto.CronJobConfig {
+	if cfg == nil {
+		return nil
+	}
+	return &proto.CronJobConfig{
+		Schedule:                   cfg.Schedule,
+		ConcurrencyPolicy:          string(cfg.ConcurrencyPolicy),
+		SuccessfulJobsHistoryLimit: int32PtrToProto(cfg.SuccessfulJobsHistoryLimit),
+		FailedJobsHistoryLimit:     int32PtrToProto(cfg.FailedJobsHistoryLimit),
+	}
+}
+
+// ProtoToCronJobConfig converts protobuf cronjob settings to the internal type
+func ProtoToCronJobConfig(pc *proto.CronJobConfig) *types.CronJobConfig {
+	if pc == nil {
+		return nil
+	}
+	return &types.CronJobConfig{
+		Schedule:                   pc.Schedule,
+		ConcurrencyPolicy:          types.ConcurrencyPolicy(pc.ConcurrencyPolicy),
+		SuccessfulJobsHistoryLimit: protoToIntPtr(pc.SuccessfulJobsHistoryLimit),
+		FailedJobsHistoryLimit:     protoToIntPtr(pc.FailedJobsHistoryLimit),
+	}
+}
+
+// int32PtrToProto converts an optional setting to its protobuf form
+func int32PtrToProto(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	p := int32(*v)
+	return &p
+}
+
+// protoToIntPtr converts an optional protobuf setting to the internal type
+func protoToIntPtr(p *int32) *int {
+	if p == nil {
+		return nil
+	}
+	v := int(*p)
+	return &v
+}
+
+// UpdateConfigToProto converts an update config to protobuf
+func UpdateConfigToProto(cfg *types.UpdateConfig) *proto.UpdateConfig {
+	if cfg == nil {
+		return nil
+	}
+	pc := &proto.UpdateConfig{
+		Parallelism:                   int32(cfg.Parallelism),
+		DelaySeconds:                  int32(cfg.Delay / time.Second),
+		FailureAction:                 cfg.FailureAction,
+		MaxSurge:                      int32(cfg.MaxSurge),
+		MaxUnavailable:                int32(cfg.MaxUnavailable),
+		HealthCheckGracePeriodSeconds: int32(cfg.HealthCheckGracePeriod / time.Second),
+		CanaryWeight:                  int32(cfg.CanaryWeight),
+		CanaryStabilityWindowSeconds:  int32(cfg.CanaryStabilityWindow / time.Second),
+		BlueGreenGracePeriodSeconds:   int32(cfg.BlueGreenGracePeriod / time.Second),
+		AutoRollbackEnabled:           cfg.AutoRollbackEnabled,
+		FailureThresholdPercent:       int32(cfg.FailureThresholdPercent),
+		P99ThresholdPercent:           int32(cfg.P99ThresholdPercent),
+	}
+	for _, step := range cfg.CanarySteps {
+		pc.CanarySteps = append(pc.CanarySteps, int32(step))
+	}
+	return pc
+}
+
+// ProtoToUpdateConfig converts a protobuf update config to the internal type
+func ProtoToUpdateConfig(pc *proto.UpdateConfig) *types.UpdateConfig {
+	if pc == nil {
+		return nil
+	}
+	cfg := &types.UpdateConfig{
+		Parallelism:             int(pc.Parallelism),
+		Delay:                   time.Duration(pc.DelaySeconds) * time.Second,
+		FailureAction:           pc.FailureAction,
+		MaxSurge:                int(pc.MaxSurge),
+		MaxUnavailable:          int(pc.MaxUnavailable),
+		HealthCheckGracePeriod:  time.Duration(pc.HealthCheckGracePeriodSeconds) * time.Second,
+		CanaryWeight:            int(pc.CanaryWeight),
+		CanaryStabilityWindow:   time.Duration(pc.CanaryStabilityWindowSeconds) * time.Second,
+		BlueGreenGracePeriod:    time.Duration(pc.BlueGreenGracePeriodSeconds) * time.Second,
+		AutoRollbackEnabled:     pc.AutoRollbackEnabled,
+		FailureThresholdPercent: int(pc.FailureThresholdPercent),
+		P99ThresholdPercent:     int(pc.P99ThresholdPercent),
+	}
+	for _, step := range pc.CanarySteps {
+		cfg.CanarySteps = append(cfg.CanarySteps, int(step))
+	}
+	return cfg
+}
+
+// CanaryAnalysisToProto converts a canary analysis to protobuf
+func CanaryAnalysisToProto(analysis *types.CanaryAnalysis) *proto.CanaryAnalysis {
+	if analysis == nil {
+		return nil
+	}
+	return &proto.CanaryAnalysis{
+		Result:     string(analysis.Result),
+		Reason:     analysis.Reason,
+		Image:      analysis.Image,
+		Weight:     int32(analysis.Weight),
+		Canary:     trafficSummaryToProto(analysis.Canary),
+		Stable:     trafficSummaryToProto(analysis.Stable),
+		RolledBack: analysis.RolledBack,
+		AnalyzedAt: timestamppb.New(analysis.AnalyzedAt),
+	}
+}
+
+// ProtoToCanaryAnalysis converts a protobuf canary analysis to the internal type
+func ProtoToCanaryAnalysis(pa *proto.CanaryAnalysis) *types.CanaryAnalysis {
+	if pa == nil {
+		return nil
+	}
+	return &types.CanaryAnalysis{
+		Result:     types.CanaryAnalysisResult(pa.Result),
+		Reason:     pa.Reason,
+		Image:      pa.Image,
+		Weight:     int(pa.Weight),
+		Canary:     protoToTrafficSummary(pa.Canary),
+		Stable:     protoToTrafficSummary(pa.Stable),
+		RolledBack: pa.RolledBack,
+		AnalyzedAt: pa.AnalyzedAt.AsTime(),
+	}
+}
+
+// DeploymentToProto converts a deployment to its protobuf form
+func DeploymentToProto(deployment *types.Deployment) *proto.Deployment {
+	if deployment == nil {
+		return nil
+	}
+	pd := &proto.Deployment{
+		Id:             deployment.ID,
+		ServiceId:      deployment.ServiceID,
+		ServiceName:    deployment.ServiceName,
+		Strategy:       string(deployment.Strategy),
+		Image:          deployment.Image,
+		PreviousImage:  deployment.PreviousImage,
+		Phase:          string(deployment.Phase),
+		Step:           string(deployment.Step),
+		StepIndex:      int32(deployment.StepIndex),
+		StepStartedAt:  timestamppb.New(deployment.StepStartedAt),
+		CloneServiceId: deployment.CloneServiceID,
+		Message:        deployment.Message,
+		Failures:       int32(deployment.Failures),
+		CreatedAt:      timestamppb.New(deployment.CreatedAt),
+		UpdatedAt:      timestamppb.New(deployment.UpdatedAt),
+	}
+	if !deployment.CompletedAt.IsZero() {
+		pd.CompletedAt = timestamppb.New(deployment.CompletedAt)
+	}
+	return pd
+}
+
+// ProtoToDeployment converts a protobuf deployment to the internal type
+func ProtoToDeployment(pd *proto.Deployment) *types.Deployment {
+	if pd == nil {
+		return nil
+	}
+	deployment := &types.Deployment{
+		ID:             pd.Id,
+		ServiceID:      pd.ServiceId,
+		ServiceName:    pd.ServiceName,
+		Strategy:       types.DeployStrategy(pd.Strategy),
+		Image:          pd.Image,
+		PreviousImage:  pd.PreviousImage,
+		Phase:          types.DeploymentPhase(pd.Phase),
+		Step:           types.DeploymentStep(pd.Step),
+		StepIndex:      int(pd.StepIndex),
+		CloneServiceID: pd.CloneServiceId,
+		Message:        pd.Message,
+		Failures:       int(pd.Failures),
+	}
+	if pd.StepStartedAt != nil {
+		deployment.StepStartedAt = pd.StepStartedAt.AsTime()
+	}
+	if pd.CreatedAt != nil {
+		deployment.CreatedAt = pd.CreatedAt.AsTime()
+	}
+	if pd.UpdatedAt != nil {
+		deployment.UpdatedAt = pd.UpdatedAt.AsTime()
+	}
+	if pd.CompletedAt != nil {
+		deployment.CompletedAt = pd.CompletedAt.AsTime()
+	}
+	return deployment
+}
+
+func trafficSummaryToProto(summary types.TrafficSummary) *proto.TrafficSummary {
+	return &proto.TrafficSummary{
+		Requests: int32(summary.Requests),
+		Errors:   int32(summary.Errors),
+		P99Ms:    summary.P99.Milliseconds(),
+	}
+}
+
+func protoToTrafficSummary(ps *proto.TrafficSummary) types.TrafficSummary {
+	return types.TrafficSummary{
+		Requests: int(ps.GetRequests()),
+		Errors:   int(ps.GetErrors()),
+		P99:      time.Duration(ps.GetP99Ms()) * time.Millisecond,
+	}
+}
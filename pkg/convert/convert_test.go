@@ -0,0 +1,279 @@
+package convert
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cuemby/warren/api/proto"
+	"github.com/cuemby/warren/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// containerStatusFields are reported by workers through status RPCs and are
+// intentionally not part of the container spec sent over the wire
+var containerStatusFields = map[string]bool{
+	"HealthStatus": true,
+	"StartedAt":    true,
+	"FinishedAt":   true,
+	"ExitCode":     true,
+}
+
+// fullContainer returns a container with every field set
+func fullContainer() *types.Container {
+	return &types.Container{
+		ID:           "c-1",
+		ServiceID:    "svc-1",
+		ServiceName:  "db",
+		NodeID:       "worker-1",
+		ContainerID:  "c-1",
+		DesiredState: types.ContainerStateRunning,
+		ActualState:  types.ContainerStatePending,
+		Image:        "postgres:16",
+		Env:          []string{"PGDATA=/var/lib/postgresql/data", "POSTGRES_USER=app"},
+		Ports: []*types.PortMapping{
+			{Name: "pg", ContainerPort: 5432, HostPort: 15432, Protocol: "tcp", PublishMode: types.PublishModeHost},
+			{ContainerPort: 9187, HostPort: 9187, Protocol: "tcp", PublishMode: types.PublishModeIngress},
+		},
+		Mounts:  []*types.VolumeMount{{Source: "pgdata", Target: "/var/lib/postgresql/data", ReadOnly: false}},
+		Secrets: []string{"db-password"},
+		HealthCheck: &types.HealthCheck{
+			Type:     types.HealthCheckExec,
+			Command:  []string{"pg_isready", "-U", "app"},
+			Interval: 10 * time.Second,
+			Timeout:  3 * time.Second,
+			Retries:  5,
+		},
+		HealthStatus:  &types.HealthStatus{Healthy: true},
+		RestartPolicy: &types.RestartPolicy{Condition: types.RestartOnFailure, MaxAttempts: 3, Delay: 5 * time.Second},
+		Resources: &types.ResourceRequirements{
+			CPULimit:          1.5,
+			MemoryLimit:       512 * 1024 * 1024,
+			CPUReservation:    0.5,
+			MemoryReservation: 256 * 1024 * 1024,
+		},
+		StopTimeout: 30,
+		CreatedAt:   time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		StartedAt:   time.Date(2024, 5, 1, 12, 0, 5, 0, time.UTC),
+		FinishedAt:  time.Date(2024, 5, 1, 13, 0, 0, 0, time.UTC),
+		ExitCode:    1,
+		Error:       "previous attempt failed",
+		PreemptedBy: "log-shipper",
+	}
+}
+
+// TestFullContainerSetsEveryField guards the fixture: a new field on
+// types.Container must be added here and to the conversions
+func TestFullContainerSetsEveryField(t *testing.T) {
+	v := reflect.ValueOf(fullContainer()).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		assert.False(t, v.Field(i).IsZero(), "fixture must set types.Container.%s", v.Type().Field(i).Name)
+	}
+}
+
+// TestContainerRoundTrip tests that the container spec survives types -> proto -> types
+func TestContainerRoundTrip(t *testing.T) {
+	original := fullContainer()
+
+	roundTripped := ProtoToContainer(ContainerToProto(original))
+	require.NotNil(t, roundTripped)
+
+	ov := reflect.ValueOf(original).Elem()
+	rv := reflect.ValueOf(roundTripped).Elem()
+	for i := 0; i < ov.NumField(); i++ {
+		name := ov.Type().Field(i).Name
+		if containerStatusFields[name] {
+			continue
+		}
+		assert.Equal(t, ov.Field(i).Interface(), rv.Field(i).Interface(), "field %s was not carried across", name)
+	}
+}
+
+// TestContainerToProtoSetsEveryField tests that every proto field is populated,
+// so a field added to the proto message without a conversion is caught
+func TestContainerToProtoSetsEveryField(t *testing.T) {
+	pc := ContainerToProto(fullContainer())
+
+	fields := pc.ProtoReflect().Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if field.Name() == "command" {
+			continue // Containers run the image entrypoint; no command override yet
+		}
+		assert.True(t, pc.ProtoReflect().Has(field), "proto field %s is not set", field.Name())
+	}
+}
+
+// TestHealthCheckRoundTrip tests health check endpoints for every check type
+func TestHealthCheckRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		hc   *types.HealthCheck
+	}{
+		{
+			name: "http",
+			hc:   &types.HealthCheck{Type: types.HealthCheckHTTP, Endpoint: "http://:8080/healthz", Interval: 30 * time.Second, Timeout: 5 * time.Second, Retries: 3},
+		},
+		{
+			name: "https",
+			hc:   &types.HealthCheck{Type: types.HealthCheckHTTP, Endpoint: "https://:8443/ready", Interval: 10 * time.Second, Timeout: time.Second, Retries: 1},
+		},
+		{
+			name: "tcp",
+			hc:   &types.HealthCheck{Type: types.HealthCheckTCP, Endpoint: ":6379", Interval: 5 * time.Second, Timeout: time.Second, Retries: 2},
+		},
+		{
+			name: "exec",
+			hc:   &types.HealthCheck{Type: types.HealthCheckExec, Command: []string{"redis-cli", "ping"}, Interval: 5 * time.Second, Timeout: time.Second, Retries: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.hc, ProtoToHealthCheck(HealthCheckToProto(tt.hc)))
+		})
+	}
+
+	assert.Nil(t, HealthCheckToProto(nil))
+	assert.Nil(t, ProtoToHealthCheck(nil))
+}
+
+// TestEnvRoundTrip tests environment conversion, including values containing '='
+func TestEnvRoundTrip(t *testing.T) {
+	env := []string{"A=1", "B=x=y", "C="}
+	assert.Equal(t, env, ProtoToEnv(EnvToProto(env)))
+	assert.Nil(t, ProtoToEnv(nil))
+}
+
+// TestPlacementRoundTrip tests placement conversion
+func TestPlacementRoundTrip(t *testing.T) {
+	placement := &types.Placement{
+		Constraints: []string{"node.labels.zone==eu-1", "node.role!=manager"},
+		Preferences: []types.PlacementPreference{{Spread: "node.labels.rack"}},
+	}
+	assert.Equal(t, placement, ProtoToPlacement(PlacementToProto(placement)))
+	assert.Nil(t, ProtoToPlacement(&proto.Placement{}), "empty placement is nil")
+}
+
+// TestAffinityRoundTrip tests affinity conversion
+func TestAffinityRoundTrip(t *testing.T) {
+	affinity := &types.Affinity{
+		Required:  []types.AffinityTerm{{Service: "app"}},
+		Preferred: []types.AffinityTerm{{Labels: map[string]string{"tier": "cache"}, Weight: 5}},
+	}
+	assert.Equal(t, affinity, ProtoToAffinity(AffinityToProto(affinity)))
+	assert.Nil(t, ProtoToAffinity(&proto.Affinity{}), "empty affinity is nil")
+}
+
+// TestTaintsAndTolerationsRoundTrip tests taint and toleration conversion
+func TestTaintsAndTolerationsRoundTrip(t *testing.T) {
+	taints := []types.Taint{{Key: "dedicated", Value: "build", Effect: types.TaintEffectNoSchedule}}
+	assert.Equal(t, taints, ProtoToTaints(TaintsToProto(taints)))
+
+	tolerations := []types.Toleration{
+		{Key: "dedicated", Operator: types.TolerationOpEqual, Value: "build"},
+		{Operator: types.TolerationOpExists, Effect: types.TaintEffectNoExecute},
+	}
+	assert.Equal(t, tolerations, ProtoToTolerations(TolerationsToProto(tolerations)))
+}
+
+// TestJobRoundTrip tests job and cronjob conversion
+func TestJobRoundTrip(t *testing.T) {
+	backoffLimit := 0
+	cfg := &types.JobConfig{Completions: 5, Parallelism: 2, BackoffLimit: &backoffLimit, ActiveDeadline: 10 * time.Minute}
+	assert.Equal(t, cfg, ProtoToJobConfig(JobConfigToProto(cfg)))
+
+	unset := &types.JobConfig{Completions: 1}
+	assert.Equal(t, unset, ProtoToJobConfig(JobConfigToProto(unset)), "unset backoff limit stays unset")
+
+	start := time.Date(2024, 1, 15, 2, 0, 0, 0, time.UTC)
+	status := &types.JobStatus{State: types.JobStateFailed, Succeeded: 1, Failed: 4, StartTime: start, CompletionTime: start.Add(time.Hour), Reason: "backoff limit exceeded"}
+	assert.Equal(t, status, ProtoToJobStatus(JobStatusToProto(status)))
+
+	running := &types.JobStatus{State: types.JobStateRunning, Active: 2, StartTime: start}
+	assert.Equal(t, running, ProtoToJobStatus(JobStatusToProto(running)), "no completion time while running")
+
+	successful, failed := 5, 0
+	cron := &types.CronJobConfig{Schedule: "0 2 * * *", ConcurrencyPolicy: types.ConcurrencyForbid, SuccessfulJobsHistoryLimit: &successful, FailedJobsHistoryLimit: &failed}
+	assert.Equal(t, cron, ProtoToCronJobConfig(CronJobConfigToProto(cron)))
+}
+
+// TestUpdateConfigRoundTrip tests update config conversion
+func TestUpdateConfigRoundTrip(t *testing.T) {
+	cfg := &types.UpdateConfig{
+		Parallelism: 2, Delay: 10 * time.Second, FailureAction: "rollback",
+		CanaryWeight: 25, CanarySteps: []int{10, 50, 100}, CanaryStabilityWindow: time.Minute,
+		AutoRollbackEnabled: true, FailureThresholdPercent: 5, P99ThresholdPercent: 20,
+	}
+	assert.Equal(t, cfg, ProtoToUpdateConfig(UpdateConfigToProto(cfg)))
+}
+
+// TestCanaryAnalysisRoundTrip tests canary analysis conversion
+func TestCanaryAnalysisRoundTrip(t *testing.T) {
+	analysis := &types.CanaryAnalysis{
+		Result:     types.CanaryAnalysisFailed,
+		Reason:     "canary p99 latency 900ms exceeds stable 300ms by more than 50%",
+		Image:      "nginx:1.21",
+		Weight:     25,
+		Canary:     types.TrafficSummary{Requests: 250, Errors: 3, P99: 900 * time.Millisecond},
+		Stable:     types.TrafficSummary{Requests: 750, Errors: 6, P99: 300 * time.Millisecond},
+		RolledBack: true,
+		AnalyzedAt: time.Date(2024, 1, 15, 2, 0, 0, 0, time.UTC),
+	}
+	assert.Equal(t, analysis, ProtoToCanaryAnalysis(CanaryAnalysisToProto(analysis)))
+}
+
+// TestDeploymentRoundTrip tests deployment conversion, with and without a completion time
+func TestDeploymentRoundTrip(t *testing.T) {
+	started := time.Date(2024, 1, 15, 2, 0, 0, 0, time.UTC)
+	deployment := &types.Deployment{
+		ID:             "dep-1",
+		ServiceID:      "svc-1",
+		ServiceName:    "web",
+		Strategy:       types.DeployStrategyCanary,
+		Image:          "nginx:1.22",
+		PreviousImage:  "nginx:1.21",
+		Phase:          types.DeploymentPhaseRunning,
+		Step:           types.DeploymentStepStabilize,
+		StepIndex:      1,
+		StepStartedAt:  started.Add(time.Minute),
+		CloneServiceID: "svc-2",
+		Failures:       2,
+		CreatedAt:      started,
+		UpdatedAt:      started.Add(time.Minute),
+	}
+	assert.Equal(t, deployment, ProtoToDeployment(DeploymentToProto(deployment)))
+
+	deployment.Phase = types.DeploymentPhaseAborted
+	deployment.Message = "aborted by operator"
+	deployment.CompletedAt = started.Add(2 * time.Minute)
+	assert.Equal(t, deployment, ProtoToDeployment(DeploymentToProto(deployment)))
+}
+
+// TestNilConversions tests that optional fields stay nil
+func TestNilConversions(t *testing.T) {
+	assert.Nil(t, ContainerToProto(nil))
+	assert.Nil(t, ProtoToContainer(nil))
+	assert.Nil(t, ResourcesToProto(nil))
+	assert.Nil(t, ProtoToResources(nil))
+	assert.Nil(t, RestartPolicyToProto(nil))
+	assert.Nil(t, ProtoToRestartPolicy(nil))
+	assert.Nil(t, PortsToProto(nil))
+	assert.Nil(t, ProtoToPorts(nil))
+	assert.Nil(t, VolumeMountsToProto(nil))
+	assert.Nil(t, ProtoToVolumeMounts(nil))
+	assert.Nil(t, PlacementToProto(nil))
+	assert.Nil(t, ProtoToPlacement(nil))
+	assert.Nil(t, AffinityToProto(nil))
+	assert.Nil(t, ProtoToAffinity(nil))
+	assert.Nil(t, JobConfigToProto(nil))
+	assert.Nil(t, ProtoToJobStatus(nil))
+	assert.Nil(t, CronJobConfigToProto(nil))
+	assert.Nil(t, UpdateConfigToProto(nil))
+	assert.Nil(t, ProtoToUpdateConfig(nil))
+	assert.Nil(t, CanaryAnalysisToProto(nil))
+	assert.Nil(t, ProtoToCanaryAnalysis(nil))
+	assert.Nil(t, DeploymentToProto(nil))
+	assert.Nil(t, ProtoToDeployment(nil))
+}
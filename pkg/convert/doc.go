@@ -0,0 +1,29 @@
+/*
+Package convert translates between Warren's internal types (pkg/types) and
+their protobuf representations (api/proto).
+
+Both sides of the gRPC boundary use these functions: the API server when it
+sends containers and services to workers and clients, and workers when they
+turn a container assignment back into a types.Container. Keeping the
+conversions in one place, with round-trip tests, ensures a field added to
+the spec is carried across in both directions.
+
+# Conversions
+
+	ContainerToProto / ProtoToContainer       full container spec
+	ResourcesToProto / ProtoToResources       CPU in shares (1024 per core)
+	HealthCheckToProto / ProtoToHealthCheck   endpoint ↔ port/path
+	RestartPolicyToProto / ProtoToRestartPolicy
+	PortsToProto / ProtoToPorts
+	VolumeMountsToProto / ProtoToVolumeMounts
+	EnvToProto / ProtoToEnv                   KEY=VALUE ↔ map (sorted by key)
+	JobConfigToProto / ProtoToJobConfig       active deadline in seconds
+	JobStatusToProto / ProtoToJobStatus
+	CronJobConfigToProto / ProtoToCronJobConfig
+	UpdateConfigToProto / ProtoToUpdateConfig durations in seconds
+	CanaryAnalysisToProto / ProtoToCanaryAnalysis p99 latency in milliseconds
+
+Runtime status that only the worker reports (health status, exit code, start
+and finish times) travels in dedicated status RPCs, not in the container spec.
+*/
+package convert
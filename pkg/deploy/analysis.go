@@ -0,0 +1,155 @@
+package deploy
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cuemby/warren/pkg/log"
+	"github.com/cuemby/warren/pkg/types"
+)
+
+// Default canary analysis thresholds
+const (
+	defaultFailureThresholdPercent = 10
+	defaultP99ThresholdPercent     = 50
+
+	// minCanaryRequests is the least canary traffic a step needs before its
+	// error rate and latency are judged
+	minCanaryRequests = 20
+)
+
+// TrafficSource reports the ingress traffic a service served since a given
+// time, which canary analysis compares between canary and stable. It
+// returns an error when it cannot see all of the service's traffic, so
+// that a canary is never judged on part of it.
+type TrafficSource interface {
+	ServiceTraffic(serviceName string, since time.Time) (types.TrafficSummary, error)
+}
+
+// SetTrafficSource enables canary analysis against the traffic of src
+func (d *Deployer) SetTrafficSource(src TrafficSource) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.traffic = src
+}
+
+// trafficSource returns the traffic source, or nil if none is set
+func (d *Deployer) trafficSource() TrafficSource {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.traffic
+}
+
+// analyzeCanary compares the traffic of a canary step with the stable
+// service. The canary fails when its error rate exceeds the stable one by
+// more than FailureThresholdPercent points, or its p99 latency exceeds the
+// stable one by more than P99ThresholdPercent.
+func analyzeCanary(config *types.UpdateConfig, weight int, canary, stable types.TrafficSummary) *types.CanaryAnalysis {
+	analysis := &types.CanaryAnalysis{
+		Result:     types.CanaryAnalysisPassed,
+		Weight:     weight,
+		Canary:     canary,
+		Stable:     stable,
+		AnalyzedAt: time.Now(),
+	}
+
+	if canary.Requests < minCanaryRequests {
+		analysis.Result = types.CanaryAnalysisInconclusive
+		analysis.Reason = fmt.Sprintf("canary served %d requests, %d needed", canary.Requests, minCanaryRequests)
+		return analysis
+	}
+
+	errorThreshold := defaultFailureThresholdPercent
+	p99Threshold := defaultP99ThresholdPercent
+	if config != nil && config.FailureThresholdPercent > 0 {
+		errorThreshold = config.FailureThresholdPercent
+	}
+	if config != nil && config.P99ThresholdPercent > 0 {
+		p99Threshold = config.P99ThresholdPercent
+	}
+
+	canaryRate := errorRate(canary)
+	stableRate := errorRate(stable)
+	if canaryRate > stableRate+float64(errorThreshold) {
+		analysis.Result = types.CanaryAnalysisFailed
+		analysis.Reason = fmt.Sprintf("canary error rate %.1f%% exceeds stable %.1f%% by more than %d points",
+			canaryRate, stableRate, errorThreshold)
+		return analysis
+	}
+
+	if stable.P99 > 0 && canary.P99 > stable.P99*time.Duration(100+p99Threshold)/100 {
+		analysis.Result = types.CanaryAnalysisFailed
+		analysis.Reason = fmt.Sprintf("canary p99 latency %s exceeds stable %s by more than %d%%",
+			canary.P99, stable.P99, p99Threshold)
+		return analysis
+	}
+
+	return analysis
+}
+
+// errorRate returns the percentage of requests that failed
+func errorRate(summary types.TrafficSummary) float64 {
+	if summary.Requests == 0 {
+		return 0
+	}
+	return float64(summary.Errors) * 100 / float64(summary.Requests)
+}
+
+// analyzeCanaryStep compares the traffic canary and stable served since
+// the current step started and records the result on the stable service.
+// The analysis is marked RolledBack when it failed and AutoRollbackEnabled
+// is set; it is nil without a traffic source, and inconclusive when the
+// source cannot report all of the traffic.
+func (d *Deployer) analyzeCanaryStep(deployment *types.Deployment, service *types.Service) *types.CanaryAnalysis {
+	traffic := d.trafficSource()
+	if traffic == nil {
+		return nil
+	}
+	canaryService, err := d.manager.GetService(deployment.CloneServiceID)
+	if err != nil {
+		return nil
+	}
+
+	weight := canarySteps(service)[deployment.StepIndex]
+	var analysis *types.CanaryAnalysis
+	canary, err := traffic.ServiceTraffic(canaryService.Name, deployment.StepStartedAt)
+	if err == nil {
+		var stable types.TrafficSummary
+		if stable, err = traffic.ServiceTraffic(service.Name, deployment.StepStartedAt); err == nil {
+			analysis = analyzeCanary(service.UpdateConfig, weight, canary, stable)
+		}
+	}
+	if err != nil {
+		analysis = &types.CanaryAnalysis{
+			Result:     types.CanaryAnalysisInconclusive,
+			Reason:     err.Error(),
+			Weight:     weight,
+			AnalyzedAt: time.Now(),
+		}
+	}
+	analysis.Image = canaryService.Image
+	analysis.RolledBack = analysis.Result == types.CanaryAnalysisFailed &&
+		service.UpdateConfig != nil && service.UpdateConfig.AutoRollbackEnabled
+	service.CanaryAnalysis = analysis
+
+	log.Logger.Info().
+		Str("canary_service_id", canaryService.ID).
+		Int("weight", weight).
+		Str("result", string(analysis.Result)).
+		Str("reason", analysis.Reason).
+		Int("canary_requests", analysis.Canary.Requests).
+		Int("canary_errors", analysis.Canary.Errors).
+		Dur("canary_p99", analysis.Canary.P99).
+		Int("stable_requests", analysis.Stable.Requests).
+		Int("stable_errors", analysis.Stable.Errors).
+		Dur("stable_p99", analysis.Stable.P99).
+		Msg("Canary analysis complete")
+
+	if analysis.Result == types.CanaryAnalysisFailed && !analysis.RolledBack {
+		log.Logger.Warn().Str("reason", analysis.Reason).Msg("Canary analysis failed, continuing without auto-rollback")
+	}
+	if err := d.manager.UpdateService(service); err != nil {
+		log.Logger.Warn().Err(err).Msg("Failed to record canary analysis")
+	}
+	return analysis
+}
@@ -0,0 +1,119 @@
+package deploy
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cuemby/warren/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAnalyzeCanary tests the error rate and p99 latency thresholds
+func TestAnalyzeCanary(t *testing.T) {
+	stable := types.TrafficSummary{Requests: 900, Errors: 9, P99: 200 * time.Millisecond}
+
+	tests := []struct {
+		name     string
+		config   *types.UpdateConfig
+		canary   types.TrafficSummary
+		expected types.CanaryAnalysisResult
+	}{
+		{
+			name:     "healthy canary",
+			canary:   types.TrafficSummary{Requests: 100, Errors: 2, P99: 250 * time.Millisecond},
+			expected: types.CanaryAnalysisPassed,
+		},
+		{
+			name:     "too little traffic",
+			canary:   types.TrafficSummary{Requests: 10, Errors: 10, P99: time.Second},
+			expected: types.CanaryAnalysisInconclusive,
+		},
+		{
+			name:     "error rate over default threshold",
+			canary:   types.TrafficSummary{Requests: 100, Errors: 12, P99: 200 * time.Millisecond},
+			expected: types.CanaryAnalysisFailed,
+		},
+		{
+			name:     "error rate under configured threshold",
+			config:   &types.UpdateConfig{FailureThresholdPercent: 20},
+			canary:   types.TrafficSummary{Requests: 100, Errors: 12, P99: 200 * time.Millisecond},
+			expected: types.CanaryAnalysisPassed,
+		},
+		{
+			name:     "p99 over default threshold",
+			canary:   types.TrafficSummary{Requests: 100, P99: 301 * time.Millisecond},
+			expected: types.CanaryAnalysisFailed,
+		},
+		{
+			name:     "p99 over configured threshold",
+			config:   &types.UpdateConfig{P99ThresholdPercent: 10},
+			canary:   types.TrafficSummary{Requests: 100, P99: 250 * time.Millisecond},
+			expected: types.CanaryAnalysisFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysis := analyzeCanary(tt.config, 10, tt.canary, stable)
+			assert.Equal(t, tt.expected, analysis.Result, analysis.Reason)
+			assert.Equal(t, 10, analysis.Weight)
+			if tt.expected != types.CanaryAnalysisPassed {
+				assert.NotEmpty(t, analysis.Reason)
+			}
+		})
+	}
+}
+
+// TestAnalyzeCanaryWithoutStableTraffic tests a canary judged on its own error rate
+func TestAnalyzeCanaryWithoutStableTraffic(t *testing.T) {
+	canary := types.TrafficSummary{Requests: 50, Errors: 1, P99: time.Second}
+	analysis := analyzeCanary(nil, 100, canary, types.TrafficSummary{})
+	assert.Equal(t, types.CanaryAnalysisPassed, analysis.Result, "no stable p99 to compare with")
+
+	canary.Errors = 10
+	analysis = analyzeCanary(nil, 100, canary, types.TrafficSummary{})
+	assert.Equal(t, types.CanaryAnalysisFailed, analysis.Result)
+}
+
+// fakeTraffic is a TrafficSource with fixed traffic per service
+type fakeTraffic struct {
+	services map[string]types.TrafficSummary
+	err      error
+}
+
+func (f *fakeTraffic) ServiceTraffic(serviceName string, since time.Time) (types.TrafficSummary, error) {
+	return f.services[serviceName], f.err
+}
+
+// TestAnalyzeCanaryStepPartialTraffic tests that a canary is not judged,
+// or rolled back, on traffic the source cannot fully see
+func TestAnalyzeCanaryStepPartialTraffic(t *testing.T) {
+	mgr := newFakeManager()
+	mgr.addService("svc-1", 4, "nginx:1.21", &types.UpdateConfig{AutoRollbackEnabled: true})
+	mgr.addService("svc-1-canary", 1, "nginx:1.22", nil)
+	mgr.services["svc-1-canary"].Name = "web-canary"
+	deployment := &types.Deployment{ID: "dep-1", ServiceID: "svc-1", CloneServiceID: "svc-1-canary"}
+	traffic := &fakeTraffic{services: map[string]types.TrafficSummary{
+		"web":        {Requests: 900},
+		"web-canary": {Requests: 100, Errors: 50},
+	}}
+	d := NewDeployer(mgr)
+	d.SetTrafficSource(traffic)
+
+	service, err := mgr.GetService("svc-1")
+	require.NoError(t, err)
+	analysis := d.analyzeCanaryStep(deployment, service)
+	require.NotNil(t, analysis)
+	assert.Equal(t, types.CanaryAnalysisFailed, analysis.Result)
+	assert.True(t, analysis.RolledBack)
+
+	traffic.err = fmt.Errorf("traffic stats need a single manager serving ingress, the cluster has 3")
+	analysis = d.analyzeCanaryStep(deployment, service)
+	require.NotNil(t, analysis)
+	assert.Equal(t, types.CanaryAnalysisInconclusive, analysis.Result)
+	assert.Contains(t, analysis.Reason, "single manager")
+	assert.False(t, analysis.RolledBack)
+	assert.Equal(t, analysis, mgr.services["svc-1"].CanaryAnalysis)
+}
@@ -0,0 +1,127 @@
+package deploy
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cuemby/warren/pkg/log"
+	"github.com/cuemby/warren/pkg/types"
+	"github.com/rs/zerolog"
+)
+
+// syncInterval is how often running deployments are advanced
+const syncInterval = 5 * time.Second
+
+// maxFinishedDeployments is how many finished deployments are kept per service
+const maxFinishedDeployments = 10
+
+// LeaderChecker reports whether this manager is the Raft leader
+type LeaderChecker interface {
+	IsLeader() bool
+}
+
+// Controller advances running deployments. It runs on every manager but
+// only acts on the leader, so a new leader picks up every deployment where
+// the previous one left it.
+type Controller struct {
+	deployer *Deployer
+	leader   LeaderChecker
+	logger   zerolog.Logger
+	stopCh   chan struct{}
+}
+
+// NewController creates a new deployment controller
+func NewController(deployer *Deployer, leader LeaderChecker) *Controller {
+	return &Controller{
+		deployer: deployer,
+		leader:   leader,
+		logger:   log.WithComponent("deploy"),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Start begins the controller loop
+func (c *Controller) Start() {
+	go c.run()
+}
+
+// Stop stops the controller
+func (c *Controller) Stop() {
+	close(c.stopCh)
+}
+
+// run is the main controller loop
+func (c *Controller) run() {
+	ticker := time.NewTicker(syncInterval)
+	defer ticker.Stop()
+
+	c.logger.Info().Msg("Deployment controller started")
+
+	for {
+		select {
+		case <-ticker.C:
+			if !c.leader.IsLeader() {
+				continue
+			}
+			if err := c.sync(); err != nil {
+				c.logger.Error().Err(err).Msg("Deployment sync failed")
+			}
+		case <-c.stopCh:
+			c.logger.Info().Msg("Deployment controller stopped")
+			return
+		}
+	}
+}
+
+// sync advances every running deployment, oldest first, and prunes the
+// history of finished ones
+func (c *Controller) sync() error {
+	deployments, err := c.deployer.manager.ListDeployments()
+	if err != nil {
+		return fmt.Errorf("failed to list deployments: %w", err)
+	}
+	sort.Slice(deployments, func(i, j int) bool {
+		return deployments[i].CreatedAt.Before(deployments[j].CreatedAt)
+	})
+
+	for _, deployment := range deployments {
+		if deployment.Phase != types.DeploymentPhaseRunning {
+			continue
+		}
+		if err := c.deployer.Advance(deployment.ID); err != nil {
+			c.logger.Error().
+				Err(err).
+				Str("deployment_id", deployment.ID).
+				Msg("Failed to advance deployment")
+		}
+	}
+
+	c.prune(deployments)
+	return nil
+}
+
+// prune deletes finished deployments of deleted services, and all but the
+// newest maxFinishedDeployments of every other service. deployments must
+// be sorted oldest first.
+func (c *Controller) prune(deployments []*types.Deployment) {
+	finished := make(map[string][]*types.Deployment)
+	for _, deployment := range deployments {
+		if Finished(deployment) {
+			finished[deployment.ServiceID] = append(finished[deployment.ServiceID], deployment)
+		}
+	}
+
+	for serviceID, list := range finished {
+		keep := maxFinishedDeployments
+		if _, err := c.deployer.manager.GetService(serviceID); err != nil {
+			keep = 0
+		}
+		for len(list) > keep {
+			if err := c.deployer.manager.DeleteDeployment(list[0].ID); err != nil {
+				c.logger.Warn().Err(err).Str("deployment_id", list[0].ID).Msg("Failed to prune deployment")
+			}
+			list = list[1:]
+		}
+	}
+}
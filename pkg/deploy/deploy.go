@@ -2,6 +2,8 @@ package deploy
 
 import (
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/cuemby/warren/pkg/log"
@@ -10,9 +12,30 @@ import (
 	"github.com/google/uuid"
 )
 
-// Deployer handles service deployment strategies
+// Defaults for update timing
+const (
+	defaultHealthCheckGracePeriod = 30 * time.Second
+	defaultStabilityWindow        = 5 * time.Minute
+
+	// healthCheckTimeout is how long after the grace period a new version
+	// may take to become healthy before the update is rolled back
+	healthCheckTimeout = 5 * time.Minute
+)
+
+// defaultCanarySteps are the canary weights used without UpdateConfig.CanarySteps
+var defaultCanarySteps = []int{10, 25, 50, 100}
+
+// Deployer runs rolling, blue-green and canary updates of services. Every
+// update is a types.Deployment replicated through Raft, which the deployer
+// moves forward one step at a time; waits are measured from the time the
+// step started, so whichever manager leads can carry an update on.
 type Deployer struct {
 	manager types.ServiceManager
+
+	stepMu sync.Mutex // Serializes changes to deployments
+
+	mu      sync.Mutex
+	traffic TrafficSource // Ingress traffic for canary analysis, if any
 }
 
 // NewDeployer creates a new deployer
@@ -22,16 +45,66 @@ func NewDeployer(mgr types.ServiceManager) *Deployer {
 	}
 }
 
-// UpdateService updates a service with the specified strategy
-func (d *Deployer) UpdateService(serviceID string, newImage string, strategy types.DeployStrategy) error {
-	// Start deployment timer
-	startTime := time.Now()
+// Finished reports whether a deployment has stopped for good
+func Finished(deployment *types.Deployment) bool {
+	switch deployment.Phase {
+	case types.DeploymentPhaseCompleted, types.DeploymentPhaseFailed, types.DeploymentPhaseAborted:
+		return true
+	}
+	return false
+}
+
+// UpdateService starts updating a service to newImage with the specified
+// strategy, or the service's own. The update runs in the background,
+// tracked by the returned deployment.
+func (d *Deployer) UpdateService(serviceID string, newImage string, strategy types.DeployStrategy) (*types.Deployment, error) {
+	d.stepMu.Lock()
+	defer d.stepMu.Unlock()
 
 	service, err := d.manager.GetService(serviceID)
 	if err != nil {
-		return fmt.Errorf("failed to get service: %w", err)
+		return nil, fmt.Errorf("failed to get service: %w", err)
+	}
+	return d.start(service, ServiceSpec(service), newImage, strategy)
+}
+
+// UpdateServiceSpec applies change to a service and starts deploying it
+// with newImage, like UpdateService. The service is only changed when no
+// other deployment is in progress, so a running update never picks up a
+// change meant for the next one.
+func (d *Deployer) UpdateServiceSpec(serviceID string, newImage string, strategy types.DeployStrategy, change func(service *types.Service) error) (*types.Deployment, error) {
+	d.stepMu.Lock()
+	defer d.stepMu.Unlock()
+
+	service, err := d.manager.GetService(serviceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service: %w", err)
+	}
+
+	active, err := d.activeDeployment(service.ID)
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		return nil, fmt.Errorf("service %s already has deployment %s in progress", service.Name, active.ID)
 	}
 
+	previous := ServiceSpec(service)
+	if err := change(service); err != nil {
+		return nil, err
+	}
+	service.UpdatedAt = time.Now()
+	if err := d.manager.UpdateService(service); err != nil {
+		return nil, fmt.Errorf("failed to update service: %w", err)
+	}
+	return d.start(service, previous, newImage, strategy)
+}
+
+// start creates the deployment of newImage to a service whose spec was
+// previous before the deployment changed it. Deploying the image the
+// service already runs replaces all of its containers, so that they pick
+// up other changes to the service's spec.
+func (d *Deployer) start(service *types.Service, previous *types.Service, newImage string, strategy types.DeployStrategy) (*types.Deployment, error) {
 	// Use specified strategy or service's default
 	if strategy == "" {
 		strategy = service.DeployStrategy
@@ -40,457 +113,898 @@ func (d *Deployer) UpdateService(serviceID string, newImage string, strategy typ
 		strategy = types.DeployStrategyRolling // Default to rolling
 	}
 
+	step := types.DeploymentStepCreateClone
+	switch strategy {
+	case types.DeployStrategyRolling:
+		step = types.DeploymentStepUpdateImage
+	case types.DeployStrategyBlueGreen, types.DeployStrategyCanary:
+	default:
+		return nil, fmt.Errorf("unknown deployment strategy: %s", strategy)
+	}
+
+	active, err := d.activeDeployment(service.ID)
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		return nil, fmt.Errorf("service %s already has deployment %s in progress", service.Name, active.ID)
+	}
+
+	now := time.Now()
+	deployment := &types.Deployment{
+		ID:            uuid.New().String(),
+		ServiceID:     service.ID,
+		ServiceName:   service.Name,
+		Strategy:      strategy,
+		Image:         newImage,
+		PreviousImage: service.Image,
+		PreviousSpec:  previous,
+		ReplaceAll:    newImage == service.Image,
+		Phase:         types.DeploymentPhaseRunning,
+		Step:          step,
+		StepStartedAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := d.manager.CreateDeployment(deployment); err != nil {
+		return nil, fmt.Errorf("failed to create deployment: %w", err)
+	}
+
 	log.Logger.Info().
+		Str("deployment_id", deployment.ID).
 		Str("service", service.Name).
 		Str("strategy", string(strategy)).
 		Str("old_image", service.Image).
 		Str("new_image", newImage).
 		Msg("Starting service update")
 
-	var deployErr error
-	switch strategy {
-	case types.DeployStrategyBlueGreen:
-		deployErr = d.blueGreenUpdate(service, newImage)
-	case types.DeployStrategyCanary:
-		deployErr = d.canaryUpdate(service, newImage)
-	case types.DeployStrategyRolling:
-		deployErr = d.rollingUpdate(service, newImage)
-	default:
-		deployErr = fmt.Errorf("unknown deployment strategy: %s", strategy)
+	return deployment, nil
+}
+
+// activeDeployment returns the running or paused deployment of a service, if any
+func (d *Deployer) activeDeployment(serviceID string) (*types.Deployment, error) {
+	deployments, err := d.manager.ListDeployments()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
 	}
+	for _, deployment := range deployments {
+		if deployment.ServiceID == serviceID && !Finished(deployment) {
+			return deployment, nil
+		}
+	}
+	return nil, nil
+}
 
-	// Record deployment metrics
-	duration := time.Since(startTime).Seconds()
-	status := "success"
-	if deployErr != nil {
-		status = "failed"
+// Advance carries a running deployment forward as far as it can go without
+// waiting. Every step can safely run again, so a step cut short by a
+// failover is repeated by the next leader.
+func (d *Deployer) Advance(deploymentID string) error {
+	d.stepMu.Lock()
+	defer d.stepMu.Unlock()
+
+	deployment, err := d.manager.GetDeployment(deploymentID)
+	if err != nil {
+		return err
 	}
 
-	metrics.DeploymentsTotal.WithLabelValues(string(strategy), status).Inc()
-	metrics.DeploymentDuration.WithLabelValues(string(strategy)).Observe(duration)
+	for deployment.Phase == types.DeploymentPhaseRunning {
+		service, err := d.manager.GetService(deployment.ServiceID)
+		if err != nil {
+			return d.fail(deployment, nil, "service_deleted", "service no longer exists")
+		}
 
-	return deployErr
+		progressed, err := d.step(deployment, service)
+		if err != nil {
+			return fmt.Errorf("deployment %s step %s: %w", deployment.ID, deployment.Step, err)
+		}
+		if !progressed {
+			return nil
+		}
+	}
+	return nil
 }
 
-// rollingUpdate performs a rolling update of the service
-func (d *Deployer) rollingUpdate(service *types.Service, newImage string) error {
-	// Get all containers for the service
-	containers, err := d.manager.ListContainersByService(service.ID)
+// step runs the current step of a deployment. It reports whether the
+// deployment moved on, or has to wait.
+func (d *Deployer) step(deployment *types.Deployment, service *types.Service) (bool, error) {
+	switch deployment.Step {
+	case types.DeploymentStepUpdateImage:
+		return d.updateImage(deployment, service)
+	case types.DeploymentStepReplaceBatch:
+		return d.replaceBatch(deployment, service)
+	case types.DeploymentStepCreateClone:
+		return d.createClone(deployment, service)
+	case types.DeploymentStepScaleCanary:
+		return d.scaleCanary(deployment, service)
+	case types.DeploymentStepWaitHealthy:
+		return d.waitHealthy(deployment, service)
+	case types.DeploymentStepStabilize:
+		return d.stabilize(deployment, service)
+	case types.DeploymentStepSwitchTraffic:
+		return d.switchTraffic(deployment, service)
+	default:
+		return false, d.fail(deployment, service, "unknown_step", fmt.Sprintf("unknown step %q", deployment.Step))
+	}
+}
+
+// Pause stops a running deployment where it is
+func (d *Deployer) Pause(deploymentID string) (*types.Deployment, error) {
+	d.stepMu.Lock()
+	defer d.stepMu.Unlock()
+
+	deployment, err := d.manager.GetDeployment(deploymentID)
 	if err != nil {
-		return fmt.Errorf("failed to list containers: %w", err)
+		return nil, err
+	}
+	if deployment.Phase != types.DeploymentPhaseRunning {
+		return nil, fmt.Errorf("deployment %s is %s, not running", deployment.ID, deployment.Phase)
 	}
 
-	// Filter running containers
-	var runningContainers []*types.Container
-	for _, container := range containers {
-		if container.DesiredState == types.ContainerStateRunning {
-			runningContainers = append(runningContainers, container)
-		}
+	deployment.Phase = types.DeploymentPhasePaused
+	if err := d.save(deployment); err != nil {
+		return nil, err
 	}
+	log.Logger.Info().Str("deployment_id", deployment.ID).Msg("Deployment paused")
+	return deployment, nil
+}
+
+// Resume continues a paused deployment. The wait of the current step,
+// such as a canary stability window, starts over.
+func (d *Deployer) Resume(deploymentID string) (*types.Deployment, error) {
+	d.stepMu.Lock()
+	defer d.stepMu.Unlock()
 
-	if len(runningContainers) == 0 {
-		return fmt.Errorf("no running containers to update")
+	deployment, err := d.manager.GetDeployment(deploymentID)
+	if err != nil {
+		return nil, err
+	}
+	if deployment.Phase != types.DeploymentPhasePaused {
+		return nil, fmt.Errorf("deployment %s is %s, not paused", deployment.ID, deployment.Phase)
 	}
 
-	// Determine update parallelism
-	parallelism := 1
-	if service.UpdateConfig != nil && service.UpdateConfig.Parallelism > 0 {
-		parallelism = service.UpdateConfig.Parallelism
+	deployment.Phase = types.DeploymentPhaseRunning
+	deployment.StepStartedAt = time.Now()
+	deployment.Message = ""
+	if err := d.save(deployment); err != nil {
+		return nil, err
 	}
+	log.Logger.Info().Str("deployment_id", deployment.ID).Msg("Deployment resumed")
+	return deployment, nil
+}
 
-	// Determine delay between batches
-	delay := 0 * time.Second
-	if service.UpdateConfig != nil {
-		delay = service.UpdateConfig.Delay
+// Abort stops a running or paused deployment and returns the service to
+// its previous version. Blue-green and canary updates remove the new
+// version's service; a rolling update restores the previous image and
+// replaces the containers already updated, all at once.
+func (d *Deployer) Abort(deploymentID string) (*types.Deployment, error) {
+	d.stepMu.Lock()
+	defer d.stepMu.Unlock()
+
+	deployment, err := d.manager.GetDeployment(deploymentID)
+	if err != nil {
+		return nil, err
+	}
+	if Finished(deployment) {
+		return nil, fmt.Errorf("deployment %s is already %s", deployment.ID, deployment.Phase)
 	}
 
-	log.Logger.Info().
-		Str("service", service.Name).
-		Str("service_id", service.ID).
-		Str("current_image", service.Image).
-		Str("new_image", newImage).
-		Int("containers_to_update", len(runningContainers)).
-		Int("parallelism", parallelism).
-		Dur("delay", delay).
-		Msg("Starting rolling update")
+	service, err := d.manager.GetService(deployment.ServiceID)
+	if err != nil {
+		service = nil
+	}
+	if err := d.rollback(deployment, service, "aborted"); err != nil {
+		return nil, err
+	}
+	if err := d.finish(deployment, types.DeploymentPhaseAborted, "aborted by operator"); err != nil {
+		return nil, err
+	}
+	return deployment, nil
+}
 
-	// Update service image
-	service.Image = newImage
-	service.UpdatedAt = time.Now()
-	if err := d.manager.UpdateService(service); err != nil {
-		return fmt.Errorf("failed to update service: %w", err)
+// --- Rolling update ---
+
+// updateImage sets the new image on the service, so that the scheduler
+// starts replacements with it
+func (d *Deployer) updateImage(deployment *types.Deployment, service *types.Service) (bool, error) {
+	if service.Image != deployment.Image {
+		service.Image = deployment.Image
+		service.UpdatedAt = time.Now()
+		if err := d.manager.UpdateService(service); err != nil {
+			return false, fmt.Errorf("failed to update service: %w", err)
+		}
+	}
+
+	return true, d.moveTo(deployment, types.DeploymentStepReplaceBatch, 0)
+}
+
+// replaceBatch replaces the next batch of containers still running the
+// old version, waiting UpdateConfig.Delay between batches. Up to MaxSurge
+// new containers are started ahead of the old ones, and an old container
+// is only shut down while at least Replicas-MaxUnavailable others serve;
+// the scheduler replaces the ones shut down. The update completes once
+// every replica runs the new version and serves. New containers that fail,
+// or do not serve within the health check grace period and timeout,
+// trigger the FailureAction. With FailureActionContinue, a batch that
+// waited that long for new containers is replaced anyway, so the update
+// ends even if the new version never serves.
+func (d *Deployer) replaceBatch(deployment *types.Deployment, service *types.Service) (bool, error) {
+	limits := rollingLimitsFor(service)
+	progress, err := d.rollingProgress(deployment, limits.gracePeriod)
+	if err != nil {
+		return false, err
 	}
 
-	// Update containers in batches
-	for i := 0; i < len(runningContainers); i += parallelism {
-		end := i + parallelism
-		if end > len(runningContainers) {
-			end = len(runningContainers)
+	if len(progress.failed) > deployment.Failures {
+		deployment.Failures = len(progress.failed)
+		failed := progress.failed[len(progress.failed)-1]
+		message := fmt.Sprintf("new container %s failed", failed.ID)
+		if failed.Error != "" {
+			message += ": " + failed.Error
+		}
+		if stop, err := d.rollingFailure(deployment, service, limits.failureAction, "container_failed", message); stop || err != nil {
+			return false, err
 		}
+	}
 
-		batch := runningContainers[i:end]
-		batchNum := (i / parallelism) + 1
-		totalBatches := (len(runningContainers) + parallelism - 1) / parallelism
+	// Done once every replica runs the new version and serves
+	if len(progress.outdated) == 0 && progress.available >= service.Replicas {
+		if err := d.setSurge(service, 0); err != nil {
+			return false, err
+		}
 		log.Logger.Info().
-			Int("batch", batchNum).
-			Int("total_batches", totalBatches).
-			Int("containers", len(batch)).
-			Msg("Updating batch")
-
-		// Shutdown old containers
-		for _, container := range batch {
-			container.DesiredState = types.ContainerStateShutdown
-			if err := d.manager.UpdateContainer(container); err != nil {
-				log.Logger.Warn().
-					Err(err).
-					Str("container_id", container.ID).
-					Msg("Failed to shutdown container")
-				continue
+			Str("service", service.Name).
+			Str("service_id", service.ID).
+			Msg("Rolling update complete")
+		return true, d.finish(deployment, types.DeploymentPhaseCompleted, "")
+	}
+
+	if deployment.StepIndex > 0 && time.Since(deployment.StepStartedAt) < limits.delay {
+		return false, nil
+	}
+
+	// Old containers that do not serve cost no availability, so they go first
+	budget := progress.available - (service.Replicas - limits.maxUnavailable)
+	var batch []*types.Container
+	for _, container := range progress.outdated {
+		if len(batch) == limits.parallelism {
+			break
+		}
+		if serving(container, limits.gracePeriod) {
+			if budget <= 0 {
+				break
 			}
-			log.Logger.Info().
-				Str("container_id", container.ID[:8]).
-				Str("node_id", container.NodeID).
-				Msg("Shutting down container")
+			budget--
+		}
+		batch = append(batch, container)
+	}
+
+	// Keep the surge for the old containers left, so that replacements
+	// never take the service over Replicas+MaxSurge
+	if err := d.setSurge(service, min(limits.maxSurge, len(progress.outdated)-len(batch))); err != nil {
+		return false, err
+	}
+
+	if len(batch) == 0 {
+		// Waiting for new containers to serve
+		if time.Since(deployment.StepStartedAt) <= limits.gracePeriod+healthCheckTimeout {
+			return false, nil
+		}
+		log.Logger.Error().Str("deployment_id", deployment.ID).Msg("New containers failed to become healthy")
+		deployment.StepStartedAt = time.Now()
+		message := "new containers did not become healthy"
+		if stop, err := d.rollingFailure(deployment, service, limits.failureAction, "health_check_failed", message); stop || err != nil {
+			return false, err
 		}
 
-		// The scheduler will automatically create new containers with the updated image
-		// Wait for the delay before processing next batch
-		if delay > 0 && end < len(runningContainers) {
-			log.Logger.Info().Dur("delay", delay).Msg("Waiting before next batch")
-			time.Sleep(delay)
+		// Continuing: the new containers may never serve, so the next old
+		// ones are replaced regardless of MaxUnavailable, and the update
+		// ends once none are left
+		if len(progress.outdated) == 0 {
+			if err := d.setSurge(service, 0); err != nil {
+				return false, err
+			}
+			return true, d.finish(deployment, types.DeploymentPhaseCompleted, message)
+		}
+		batch = progress.outdated[:min(limits.parallelism, len(progress.outdated))]
+		if err := d.setSurge(service, min(limits.maxSurge, len(progress.outdated)-len(batch))); err != nil {
+			return false, err
 		}
 	}
 
 	log.Logger.Info().
-		Str("service", service.Name).
-		Str("service_id", service.ID).
-		Msg("Rolling update complete")
+		Str("deployment_id", deployment.ID).
+		Int("batch", deployment.StepIndex+1).
+		Int("containers", len(batch)).
+		Int("surge", service.SurgeReplicas).
+		Msg("Updating batch")
+
+	for _, container := range batch {
+		container.DesiredState = types.ContainerStateShutdown
+		if err := d.manager.UpdateContainer(container); err != nil {
+			log.Logger.Warn().
+				Err(err).
+				Str("container_id", container.ID).
+				Msg("Failed to shutdown container")
+			continue
+		}
+		log.Logger.Info().
+			Str("container_id", container.ID).
+			Str("node_id", container.NodeID).
+			Msg("Shutting down container")
+	}
+
+	return true, d.moveTo(deployment, types.DeploymentStepReplaceBatch, deployment.StepIndex+1)
+}
+
+// ValidateUpdateConfig checks the rolling update settings of a service
+func ValidateUpdateConfig(cfg *types.UpdateConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	switch cfg.FailureAction {
+	case "", types.FailureActionPause, types.FailureActionRollback, types.FailureActionContinue:
+	default:
+		return fmt.Errorf("invalid failure action %q: use pause, rollback or continue", cfg.FailureAction)
+	}
+	if cfg.MaxSurge < 0 || cfg.MaxUnavailable < 0 {
+		return fmt.Errorf("max surge and max unavailable cannot be negative")
+	}
 	return nil
 }
 
-// blueGreenUpdate performs a blue-green deployment of the service
-func (d *Deployer) blueGreenUpdate(service *types.Service, newImage string) error {
-	log.Logger.Info().
-		Str("service", service.Name).
-		Str("service_id", service.ID).
-		Str("current_image", service.Image).
-		Str("new_image", newImage).
-		Msg("Starting blue-green deployment")
+// rollingLimits are the bounds a rolling update of a service keeps to
+type rollingLimits struct {
+	parallelism    int
+	delay          time.Duration
+	maxSurge       int
+	maxUnavailable int
+	gracePeriod    time.Duration
+	failureAction  string
+}
 
-	// Generate version identifier
-	version := uuid.New().String()[:8]
+// rollingLimitsFor returns the rolling update bounds of a service. Without
+// MaxSurge or MaxUnavailable, one new container is surged at a time, as no
+// container could be replaced otherwise.
+func rollingLimitsFor(service *types.Service) rollingLimits {
+	limits := rollingLimits{
+		parallelism:   1,
+		gracePeriod:   defaultHealthCheckGracePeriod,
+		failureAction: types.FailureActionRollback,
+	}
+	if cfg := service.UpdateConfig; cfg != nil {
+		if cfg.Parallelism > 0 {
+			limits.parallelism = cfg.Parallelism
+		}
+		limits.delay = cfg.Delay
+		limits.maxSurge = max(cfg.MaxSurge, 0)
+		limits.maxUnavailable = max(cfg.MaxUnavailable, 0)
+		if cfg.HealthCheckGracePeriod > 0 {
+			limits.gracePeriod = cfg.HealthCheckGracePeriod
+		}
+		if cfg.FailureAction != "" {
+			limits.failureAction = cfg.FailureAction
+		}
+	}
+	if limits.maxSurge == 0 && limits.maxUnavailable == 0 {
+		limits.maxSurge = 1
+	}
+	return limits
+}
 
-	// Clone service as "green" version with new image
-	greenService := d.cloneServiceForDeployment(service, newImage, version, types.DeploymentStateStandby)
+// rollingProgress is where the containers of a rolling update stand
+type rollingProgress struct {
+	outdated  []*types.Container // Meant to run the old version, those not serving first
+	available int                // Containers of either version that serve
+	failed    []*types.Container // New containers that failed, oldest first
+}
 
-	// Create the green service
-	if err := d.manager.CreateService(greenService); err != nil {
-		return fmt.Errorf("failed to create green service: %w", err)
+// rollingProgress sorts the containers of a deployment's service into
+// those still to replace, those serving and new ones that failed
+func (d *Deployer) rollingProgress(deployment *types.Deployment, gracePeriod time.Duration) (*rollingProgress, error) {
+	containers, err := d.manager.ListContainersByService(deployment.ServiceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
 	}
 
-	log.Logger.Info().
-		Str("green_service_id", greenService.ID).
-		Str("version", version).
-		Msg("Created green service")
+	var createdBefore time.Time
+	if deployment.ReplaceAll {
+		createdBefore = deployment.CreatedAt
+	}
 
-	// Wait for green containers to become healthy
-	if err := d.waitForHealthyContainers(greenService); err != nil {
-		// Cleanup green service on failure
-		_ = d.manager.DeleteService(greenService.ID)
-		return fmt.Errorf("green service failed health checks: %w", err)
+	progress := &rollingProgress{}
+	for _, container := range containers {
+		updated := container.Image == deployment.Image && !container.CreatedAt.Before(createdBefore)
+		if container.ActualState == types.ContainerStateFailed {
+			if updated && !container.CreatedAt.Before(deployment.CreatedAt) {
+				progress.failed = append(progress.failed, container)
+			}
+			continue
+		}
+		if container.DesiredState != types.ContainerStateRunning {
+			continue
+		}
+		if serving(container, gracePeriod) {
+			progress.available++
+		}
+		if !updated {
+			progress.outdated = append(progress.outdated, container)
+		}
 	}
 
-	log.Logger.Info().
-		Str("green_service_id", greenService.ID).
-		Msg("Green service is healthy, switching traffic")
+	sort.Slice(progress.outdated, func(i, j int) bool {
+		a, b := progress.outdated[i], progress.outdated[j]
+		if servingA, servingB := serving(a, gracePeriod), serving(b, gracePeriod); servingA != servingB {
+			return servingB
+		}
+		return a.ID < b.ID
+	})
+	sort.Slice(progress.failed, func(i, j int) bool {
+		return progress.failed[i].CreatedAt.Before(progress.failed[j].CreatedAt)
+	})
+	return progress, nil
+}
 
-	// Switch traffic: mark green as active, blue as standby
-	greenService.Labels[types.LabelDeploymentState] = string(types.DeploymentStateActive)
-	if err := d.manager.UpdateService(greenService); err != nil {
-		return fmt.Errorf("failed to activate green service: %w", err)
+// serving reports whether a container can take traffic: it is running and,
+// where it has a health check, reported healthy. A container whose health
+// has not been reported yet serves once it has run for the grace period.
+func serving(container *types.Container, gracePeriod time.Duration) bool {
+	if container.ActualState != types.ContainerStateRunning {
+		return false
 	}
-
-	service.Labels[types.LabelDeploymentState] = string(types.DeploymentStateStandby)
-	service.UpdatedAt = time.Now()
-	if err := d.manager.UpdateService(service); err != nil {
-		log.Logger.Warn().Err(err).Msg("Failed to mark blue service as standby")
+	if container.HealthCheck == nil {
+		return true
 	}
+	if container.HealthStatus != nil {
+		return container.HealthStatus.Healthy
+	}
+	started := container.StartedAt
+	if started.IsZero() {
+		started = container.CreatedAt
+	}
+	return time.Since(started) >= gracePeriod
+}
 
-	log.Logger.Info().
-		Str("service", service.Name).
-		Str("blue_service_id", service.ID).
-		Str("green_service_id", greenService.ID).
-		Str("version", version).
-		Msg("Blue-green deployment complete")
-
-	// TODO: After grace period, cleanup blue service
-	// This should be handled by a background cleanup job
+// rollingFailure carries out a rolling update's FailureAction. It reports
+// whether the deployment stopped: paused, or rolled back and failed.
+func (d *Deployer) rollingFailure(deployment *types.Deployment, service *types.Service, action, reason, message string) (bool, error) {
+	switch action {
+	case types.FailureActionContinue:
+		log.Logger.Warn().
+			Str("deployment_id", deployment.ID).
+			Str("reason", reason).
+			Str("failure", message).
+			Msg("Rolling update continuing despite failure")
+		return false, d.save(deployment)
+	case types.FailureActionPause:
+		log.Logger.Warn().
+			Str("deployment_id", deployment.ID).
+			Str("reason", reason).
+			Str("failure", message).
+			Msg("Rolling update paused")
+		deployment.Phase = types.DeploymentPhasePaused
+		deployment.Message = message
+		return true, d.save(deployment)
+	default:
+		return true, d.fail(deployment, service, reason, message)
+	}
+}
 
+// setSurge sets the replicas the scheduler starts on top of a service's
+// own during a rolling update
+func (d *Deployer) setSurge(service *types.Service, surge int) error {
+	if service.SurgeReplicas == surge {
+		return nil
+	}
+	service.SurgeReplicas = surge
+	if err := d.manager.UpdateService(service); err != nil {
+		return fmt.Errorf("failed to update service surge: %w", err)
+	}
 	return nil
 }
 
-// cloneServiceForDeployment creates a copy of a service for deployment
-func (d *Deployer) cloneServiceForDeployment(original *types.Service, newImage string, version string, state types.DeploymentState) *types.Service {
-	clone := &types.Service{
-		ID:             uuid.New().String(),
-		Name:           original.Name + "-" + version,
-		Image:          newImage,
-		Replicas:       original.Replicas,
-		Mode:           original.Mode,
-		DeployStrategy: original.DeployStrategy,
-		UpdateConfig:   original.UpdateConfig,
-		Env:            original.Env,
-		Ports:          original.Ports,
-		Networks:       original.Networks,
-		Secrets:        original.Secrets,
-		Volumes:        original.Volumes,
-		Labels:         make(map[string]string),
-		HealthCheck:    original.HealthCheck,
-		RestartPolicy:  original.RestartPolicy,
-		Resources:      original.Resources,
-		StopTimeout:    original.StopTimeout,
-		CreatedAt:      time.Now(),
-		UpdatedAt:      time.Now(),
+// outdatedContainers returns the containers of a service meant to be
+// running that do not run image, in a stable order
+func (d *Deployer) outdatedContainers(serviceID, image string) ([]*types.Container, error) {
+	containers, err := d.manager.ListContainersByService(serviceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
 	}
 
-	// Copy original labels
-	for k, v := range original.Labels {
-		clone.Labels[k] = v
+	var outdated []*types.Container
+	for _, container := range containers {
+		if container.DesiredState != types.ContainerStateRunning {
+			continue
+		}
+		if container.Image != image {
+			outdated = append(outdated, container)
+		}
 	}
+	sort.Slice(outdated, func(i, j int) bool { return outdated[i].ID < outdated[j].ID })
+	return outdated, nil
+}
 
-	// Add deployment tracking labels
-	clone.Labels[types.LabelDeploymentVersion] = version
-	clone.Labels[types.LabelDeploymentState] = string(state)
-	clone.Labels[types.LabelDeploymentStrategy] = string(original.DeployStrategy)
-	clone.Labels[types.LabelOriginalService] = original.ID
+// --- Blue-green and canary updates ---
+
+// createClone creates the service running the new version: the green
+// service of a blue-green update, or the canary. The clone's ID is
+// recorded first, so a repeated step finds the clone instead of making a
+// second one.
+func (d *Deployer) createClone(deployment *types.Deployment, service *types.Service) (bool, error) {
+	if deployment.CloneServiceID == "" {
+		deployment.CloneServiceID = uuid.New().String()
+		if err := d.save(deployment); err != nil {
+			return false, err
+		}
+	}
 
-	return clone
-}
+	if _, err := d.manager.GetService(deployment.CloneServiceID); err != nil {
+		state := types.DeploymentStateStandby
+		if deployment.Strategy == types.DeployStrategyCanary {
+			state = types.DeploymentStateCanary
+		}
 
-// waitForHealthyContainers waits for all containers of a service to become healthy
-func (d *Deployer) waitForHealthyContainers(service *types.Service) error {
-	gracePeriod := 30 * time.Second
-	if service.UpdateConfig != nil && service.UpdateConfig.HealthCheckGracePeriod > 0 {
-		gracePeriod = service.UpdateConfig.HealthCheckGracePeriod
+		version := deployment.ID[:8]
+		clone := d.cloneServiceForDeployment(service, deployment.Image, version, state)
+		clone.ID = deployment.CloneServiceID
+		clone.Labels[types.LabelDeploymentStrategy] = string(deployment.Strategy)
+		if deployment.Strategy == types.DeployStrategyCanary {
+			clone.Replicas = canaryReplicas(service.Replicas, canarySteps(service)[0])
+		}
+
+		if err := d.manager.CreateService(clone); err != nil {
+			return false, fmt.Errorf("failed to create %s service: %w", state, err)
+		}
+		log.Logger.Info().
+			Str("deployment_id", deployment.ID).
+			Str("clone_service_id", clone.ID).
+			Str("version", version).
+			Msg("Created new version service")
+	}
+
+	if deployment.Strategy == types.DeployStrategyCanary {
+		return true, d.moveTo(deployment, types.DeploymentStepScaleCanary, 0)
 	}
+	return true, d.moveTo(deployment, types.DeploymentStepWaitHealthy, 0)
+}
 
-	timeout := time.After(5 * time.Minute) // Overall timeout
-	ticker := time.NewTicker(5 * time.Second)
-	defer ticker.Stop()
+// scaleCanary gives the canary the replicas it needs for the weight of the
+// current step. The ingress sends the canary only its weight of the
+// traffic; the stable service keeps all of its replicas until the canary
+// is promoted.
+func (d *Deployer) scaleCanary(deployment *types.Deployment, service *types.Service) (bool, error) {
+	canary, err := d.manager.GetService(deployment.CloneServiceID)
+	if err != nil {
+		return false, d.fail(deployment, service, "canary_deleted", "canary service no longer exists")
+	}
 
+	weight := canarySteps(service)[deployment.StepIndex]
 	log.Logger.Info().
-		Str("service_id", service.ID).
-		Dur("grace_period", gracePeriod).
-		Msg("Waiting for containers to become healthy")
-
-	// Initial grace period
-	time.Sleep(gracePeriod)
-
-	for {
-		select {
-		case <-timeout:
-			return fmt.Errorf("timeout waiting for healthy containers")
-		case <-ticker.C:
-			containers, err := d.manager.ListContainersByService(service.ID)
-			if err != nil {
-				return fmt.Errorf("failed to list containers: %w", err)
-			}
+		Str("deployment_id", deployment.ID).
+		Int("step", deployment.StepIndex+1).
+		Int("weight", weight).
+		Msg("Progressing canary deployment")
+
+	replicas := canaryReplicas(service.Replicas, weight)
+	if canary.Replicas != replicas {
+		canary.Replicas = replicas
+		if err := d.manager.UpdateService(canary); err != nil {
+			return false, fmt.Errorf("failed to scale canary service: %w", err)
+		}
+	}
 
-			if len(containers) == 0 {
-				continue
-			}
+	return true, d.moveTo(deployment, types.DeploymentStepWaitHealthy, deployment.StepIndex)
+}
 
-			// Check if all containers are running and healthy
-			allHealthy := true
-			runningCount := 0
-
-			for _, container := range containers {
-				if container.DesiredState != types.ContainerStateRunning {
-					continue
-				}
-
-				if container.ActualState != types.ContainerStateRunning {
-					allHealthy = false
-					break
-				}
-
-				runningCount++
-
-				// If health check is configured, verify health status
-				if container.HealthCheck != nil && container.HealthStatus != nil {
-					if !container.HealthStatus.Healthy {
-						allHealthy = false
-						break
-					}
-				}
-			}
+// waitHealthy waits for the new version's containers to become healthy,
+// after the health check grace period. A canary then gets its weight of
+// the traffic. A new version still unhealthy healthCheckTimeout after the
+// grace period is rolled back.
+func (d *Deployer) waitHealthy(deployment *types.Deployment, service *types.Service) (bool, error) {
+	clone, err := d.manager.GetService(deployment.CloneServiceID)
+	if err != nil {
+		return false, d.fail(deployment, service, "clone_deleted", "new version's service no longer exists")
+	}
 
-			if allHealthy && runningCount == service.Replicas {
-				log.Logger.Info().
-					Str("service_id", service.ID).
-					Int("healthy_containers", runningCount).
-					Msg("All containers are healthy")
-				return nil
-			}
+	gracePeriod := defaultHealthCheckGracePeriod
+	if service.UpdateConfig != nil && service.UpdateConfig.HealthCheckGracePeriod > 0 {
+		gracePeriod = service.UpdateConfig.HealthCheckGracePeriod
+	}
+	elapsed := time.Since(deployment.StepStartedAt)
+	if elapsed < gracePeriod {
+		return false, nil
+	}
 
-			log.Logger.Debug().
-				Str("service_id", service.ID).
-				Int("running", runningCount).
-				Int("desired", service.Replicas).
-				Bool("all_healthy", allHealthy).
-				Msg("Waiting for containers to become healthy")
+	healthy, err := d.containersHealthy(clone)
+	if err != nil {
+		return false, err
+	}
+	if !healthy {
+		if elapsed > gracePeriod+healthCheckTimeout {
+			log.Logger.Error().Str("deployment_id", deployment.ID).Msg("New version failed health checks, initiating rollback")
+			return false, d.fail(deployment, service, "health_check_failed", "new version failed health checks")
 		}
+		return false, nil
 	}
-}
 
-// canaryUpdate performs a canary deployment of the service
-func (d *Deployer) canaryUpdate(service *types.Service, newImage string) error {
-	log.Logger.Info().
-		Str("service", service.Name).
-		Str("service_id", service.ID).
-		Str("current_image", service.Image).
-		Str("new_image", newImage).
-		Msg("Starting canary deployment")
+	if deployment.Strategy != types.DeployStrategyCanary {
+		log.Logger.Info().
+			Str("green_service_id", clone.ID).
+			Msg("Green service is healthy, switching traffic")
+		return true, d.moveTo(deployment, types.DeploymentStepSwitchTraffic, 0)
+	}
 
-	// Generate version identifier
-	version := uuid.New().String()[:8]
+	// Shift the step's share of traffic to the canary
+	steps := canarySteps(service)
+	if service.UpdateConfig == nil {
+		service.UpdateConfig = &types.UpdateConfig{}
+	}
+	service.UpdateConfig.CanaryWeight = steps[deployment.StepIndex]
+	if err := d.manager.UpdateService(service); err != nil {
+		return false, fmt.Errorf("failed to set canary weight: %w", err)
+	}
 
-	// Determine canary steps (default: [10, 25, 50, 100])
-	canarySteps := []int{10, 25, 50, 100}
-	if service.UpdateConfig != nil && len(service.UpdateConfig.CanarySteps) > 0 {
-		canarySteps = service.UpdateConfig.CanarySteps
+	if deployment.StepIndex == len(steps)-1 {
+		return true, d.moveTo(deployment, types.DeploymentStepSwitchTraffic, deployment.StepIndex)
 	}
+	return true, d.moveTo(deployment, types.DeploymentStepStabilize, deployment.StepIndex)
+}
 
-	// Determine stability window between steps
-	stabilityWindow := 5 * time.Minute
+// stabilize waits out the canary stability window, then compares the
+// traffic canary and stable served during it before the next step
+func (d *Deployer) stabilize(deployment *types.Deployment, service *types.Service) (bool, error) {
+	stabilityWindow := defaultStabilityWindow
 	if service.UpdateConfig != nil && service.UpdateConfig.CanaryStabilityWindow > 0 {
 		stabilityWindow = service.UpdateConfig.CanaryStabilityWindow
 	}
+	if time.Since(deployment.StepStartedAt) < stabilityWindow {
+		return false, nil
+	}
 
-	// Clone service as canary version
-	canaryService := d.cloneServiceForDeployment(service, newImage, version, types.DeploymentStateCanary)
+	if analysis := d.analyzeCanaryStep(deployment, service); analysis != nil && analysis.RolledBack {
+		log.Logger.Error().Str("reason", analysis.Reason).Msg("Canary analysis failed, initiating rollback")
+		return false, d.fail(deployment, service, "analysis_failed", "failed canary analysis: "+analysis.Reason)
+	}
 
-	// Start with 1 replica for canary
-	canaryService.Replicas = 1
+	return true, d.moveTo(deployment, types.DeploymentStepScaleCanary, deployment.StepIndex+1)
+}
 
-	if err := d.manager.CreateService(canaryService); err != nil {
-		return fmt.Errorf("failed to create canary service: %w", err)
+// switchTraffic promotes the new version: the green service or canary
+// becomes active and the old service stands by with no traffic
+func (d *Deployer) switchTraffic(deployment *types.Deployment, service *types.Service) (bool, error) {
+	clone, err := d.manager.GetService(deployment.CloneServiceID)
+	if err != nil {
+		return false, d.fail(deployment, service, "clone_deleted", "new version's service no longer exists")
 	}
 
-	log.Logger.Info().
-		Str("canary_service_id", canaryService.ID).
-		Str("version", version).
-		Msg("Created canary service")
+	clone.Labels[types.LabelDeploymentState] = string(types.DeploymentStateActive)
+	if deployment.Strategy == types.DeployStrategyCanary {
+		clone.Name = service.Name // Restore original name
+		clone.CanaryAnalysis = service.CanaryAnalysis
+	}
+	if err := d.manager.UpdateService(clone); err != nil {
+		return false, fmt.Errorf("failed to activate new version: %w", err)
+	}
 
-	// Wait for initial canary to become healthy
-	if err := d.waitForHealthyContainers(canaryService); err != nil {
-		_ = d.manager.DeleteService(canaryService.ID)
-		return fmt.Errorf("canary service failed initial health checks: %w", err)
+	if service.Labels == nil {
+		service.Labels = make(map[string]string)
+	}
+	service.Labels[types.LabelDeploymentState] = string(types.DeploymentStateStandby)
+	service.UpdatedAt = time.Now()
+	if deployment.Strategy == types.DeployStrategyCanary {
+		service.Replicas = 0
+		service.UpdateConfig.CanaryWeight = 0
+	}
+	if err := d.manager.UpdateService(service); err != nil {
+		log.Logger.Warn().Err(err).Msg("Failed to mark old service as standby")
 	}
 
-	// Progressive canary rollout
-	for i, weight := range canarySteps {
-		log.Logger.Info().
-			Str("canary_service_id", canaryService.ID).
-			Int("step", i+1).
-			Int("weight", weight).
-			Msg("Progressing canary deployment")
+	log.Logger.Info().
+		Str("service", service.Name).
+		Str("old_service_id", service.ID).
+		Str("new_service_id", clone.ID).
+		Str("strategy", string(deployment.Strategy)).
+		Msg("Deployment complete")
 
-		// Calculate desired replicas based on weight
-		totalReplicas := service.Replicas
-		canaryReplicas := (totalReplicas * weight) / 100
-		if canaryReplicas < 1 {
-			canaryReplicas = 1
-		}
-		stableReplicas := totalReplicas - canaryReplicas
+	// TODO: After grace period, cleanup the old service
+	// This should be handled by a background cleanup job
 
-		// Update canary service replicas
-		canaryService.Replicas = canaryReplicas
-		if err := d.manager.UpdateService(canaryService); err != nil {
-			return fmt.Errorf("failed to scale canary service: %w", err)
-		}
+	return true, d.finish(deployment, types.DeploymentPhaseCompleted, "")
+}
 
-		// Update stable service replicas
-		service.Replicas = stableReplicas
-		if err := d.manager.UpdateService(service); err != nil {
-			return fmt.Errorf("failed to scale stable service: %w", err)
-		}
+// canarySteps returns the canary weights of a service's updates
+func canarySteps(service *types.Service) []int {
+	if service.UpdateConfig != nil && len(service.UpdateConfig.CanarySteps) > 0 {
+		return service.UpdateConfig.CanarySteps
+	}
+	return defaultCanarySteps
+}
 
-		// Wait for new canary replicas to become healthy
-		if err := d.waitForHealthyContainers(canaryService); err != nil {
-			log.Logger.Error().Err(err).Msg("Canary health check failed, initiating rollback")
-			return d.rollbackCanary(service, canaryService, totalReplicas)
-		}
+// canaryReplicas returns the replicas a canary needs to serve weight
+// percent of a service's traffic: at least one, at most all of them
+func canaryReplicas(total, weight int) int {
+	replicas := (total*weight + 99) / 100
+	if replicas < 1 {
+		return 1
+	}
+	if replicas > total && total > 0 {
+		return total
+	}
+	return replicas
+}
 
-		// Update canary weight in config
-		if service.UpdateConfig == nil {
-			service.UpdateConfig = &types.UpdateConfig{}
-		}
-		service.UpdateConfig.CanaryWeight = weight
-		_ = d.manager.UpdateService(service)
+// containersHealthy reports whether all replicas of a service are running
+// and, where they have a health check, healthy
+func (d *Deployer) containersHealthy(service *types.Service) (bool, error) {
+	containers, err := d.manager.ListContainersByService(service.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to list containers: %w", err)
+	}
 
-		// Wait for stability window before next step (unless this is the final step)
-		if i < len(canarySteps)-1 {
-			log.Logger.Info().
-				Dur("stability_window", stabilityWindow).
-				Msg("Waiting for stability window before next canary step")
-			time.Sleep(stabilityWindow)
+	running := 0
+	for _, container := range containers {
+		if container.DesiredState != types.ContainerStateRunning {
+			continue
 		}
+		if container.ActualState != types.ContainerStateRunning {
+			return false, nil
+		}
+		if container.HealthCheck != nil && container.HealthStatus != nil && !container.HealthStatus.Healthy {
+			return false, nil
+		}
+		running++
 	}
 
-	// Canary is fully rolled out, mark it as active
-	canaryService.Labels[types.LabelDeploymentState] = string(types.DeploymentStateActive)
-	canaryService.Name = service.Name // Restore original name
-	if err := d.manager.UpdateService(canaryService); err != nil {
-		return fmt.Errorf("failed to activate canary service: %w", err)
+	log.Logger.Debug().
+		Str("service_id", service.ID).
+		Int("running", running).
+		Int("desired", service.Replicas).
+		Msg("Checked new version health")
+	return running == service.Replicas, nil
+}
+
+// --- Deployment state ---
+
+// moveTo records that a deployment entered a step
+func (d *Deployer) moveTo(deployment *types.Deployment, step types.DeploymentStep, index int) error {
+	deployment.Step = step
+	deployment.StepIndex = index
+	deployment.StepStartedAt = time.Now()
+	return d.save(deployment)
+}
+
+// save records the progress of a deployment
+func (d *Deployer) save(deployment *types.Deployment) error {
+	deployment.UpdatedAt = time.Now()
+	if err := d.manager.UpdateDeployment(deployment); err != nil {
+		return fmt.Errorf("failed to update deployment: %w", err)
 	}
+	return nil
+}
 
-	// Mark old service as standby
-	service.Labels[types.LabelDeploymentState] = string(types.DeploymentStateStandby)
-	service.Replicas = 0
-	if err := d.manager.UpdateService(service); err != nil {
-		log.Logger.Warn().Err(err).Msg("Failed to mark old service as standby")
+// finish records that a deployment stopped in phase, and its metrics
+func (d *Deployer) finish(deployment *types.Deployment, phase types.DeploymentPhase, message string) error {
+	deployment.Phase = phase
+	deployment.Message = message
+	deployment.CompletedAt = time.Now()
+
+	status := "success"
+	if phase != types.DeploymentPhaseCompleted {
+		status = string(phase)
 	}
+	metrics.DeploymentsTotal.WithLabelValues(string(deployment.Strategy), status).Inc()
+	metrics.DeploymentDuration.WithLabelValues(string(deployment.Strategy)).Observe(deployment.CompletedAt.Sub(deployment.CreatedAt).Seconds())
 
-	log.Logger.Info().
-		Str("service", service.Name).
-		Str("old_service_id", service.ID).
-		Str("new_service_id", canaryService.ID).
-		Str("version", version).
-		Msg("Canary deployment complete")
+	return d.save(deployment)
+}
 
-	return nil
+// fail rolls a deployment back and records why it failed. service is nil
+// if it no longer exists.
+func (d *Deployer) fail(deployment *types.Deployment, service *types.Service, reason, message string) error {
+	if err := d.rollback(deployment, service, reason); err != nil {
+		return err
+	}
+	return d.finish(deployment, types.DeploymentPhaseFailed, message)
 }
 
-// rollbackCanary rolls back a failed canary deployment
-func (d *Deployer) rollbackCanary(stableService, canaryService *types.Service, originalReplicas int) error {
+// rollback returns a service to the version it ran before a deployment,
+// counting the rollback under reason in the metrics
+func (d *Deployer) rollback(deployment *types.Deployment, service *types.Service, reason string) error {
 	log.Logger.Warn().
-		Str("stable_service_id", stableService.ID).
-		Str("canary_service_id", canaryService.ID).
-		Msg("Rolling back canary deployment")
+		Str("deployment_id", deployment.ID).
+		Str("service_id", deployment.ServiceID).
+		Str("reason", reason).
+		Msg("Rolling back deployment")
+
+	metrics.RolledBackDeploymentsTotal.WithLabelValues(string(deployment.Strategy), reason).Inc()
 
-	// Track rollback metric
-	metrics.RolledBackDeploymentsTotal.WithLabelValues("canary", "health_check_failed").Inc()
+	if deployment.Strategy == types.DeployStrategyRolling {
+		if service == nil {
+			return nil
+		}
+		return d.rollbackRolling(deployment, service)
+	}
 
-	// Restore stable service to full replicas
-	stableService.Replicas = originalReplicas
-	if err := d.manager.UpdateService(stableService); err != nil {
-		return fmt.Errorf("failed to restore stable service replicas: %w", err)
+	// Return all of the traffic to the stable service
+	if service != nil && service.UpdateConfig != nil && service.UpdateConfig.CanaryWeight != 0 {
+		service.UpdateConfig.CanaryWeight = 0
+		if err := d.manager.UpdateService(service); err != nil {
+			return fmt.Errorf("failed to restore stable service: %w", err)
+		}
 	}
 
-	// Delete canary service
-	if err := d.manager.DeleteService(canaryService.ID); err != nil {
-		log.Logger.Error().Err(err).Str("canary_service_id", canaryService.ID).Msg("Failed to delete canary service")
+	// Delete the new version's service
+	if deployment.CloneServiceID != "" {
+		if _, err := d.manager.GetService(deployment.CloneServiceID); err == nil {
+			if err := d.manager.DeleteService(deployment.CloneServiceID); err != nil {
+				return fmt.Errorf("failed to delete new version's service: %w", err)
+			}
+		}
 	}
+	return nil
+}
 
-	// Mark as rolled back
-	canaryService.Labels[types.LabelDeploymentState] = string(types.DeploymentStateRolledBack)
+// rollbackRolling restores the spec a service had before a rolling update
+// and replaces the containers the update started. Those are the ones
+// created between the deployment and the restore, whatever their image:
+// an update may only have changed env or resources. A repeated rollback
+// finds the spec already restored and keeps the containers started since.
+func (d *Deployer) rollbackRolling(deployment *types.Deployment, service *types.Service) error {
+	current := ServiceSpec(service)
+	if deployment.PreviousSpec != nil {
+		restoreSpec(service, deployment.PreviousSpec)
+	}
+	service.Image = deployment.PreviousImage
+	if !SameSpec(current, service) || service.SurgeReplicas != 0 {
+		service.SurgeReplicas = 0
+		service.UpdatedAt = time.Now()
+		if err := d.manager.UpdateService(service); err != nil {
+			return fmt.Errorf("failed to restore previous spec: %w", err)
+		}
+	}
 
-	log.Logger.Info().
-		Str("stable_service_id", stableService.ID).
-		Msg("Canary rollback complete")
+	containers, err := d.manager.ListContainersByService(service.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list containers: %w", err)
+	}
+	for _, container := range containers {
+		if container.DesiredState != types.ContainerStateRunning ||
+			container.CreatedAt.Before(deployment.CreatedAt) || container.CreatedAt.After(service.UpdatedAt) {
+			continue
+		}
+		container.DesiredState = types.ContainerStateShutdown
+		if err := d.manager.UpdateContainer(container); err != nil {
+			log.Logger.Warn().Err(err).Str("container_id", container.ID).Msg("Failed to shutdown container")
+		}
+	}
+	return nil
+}
+
+// cloneServiceForDeployment creates a copy of a service for deployment. The
+// copy has the original's spec and replica count, with the new image.
+func (d *Deployer) cloneServiceForDeployment(original *types.Service, newImage string, version string, state types.DeploymentState) *types.Service {
+	// ServiceSpec also copies the update config, so that canary weights set
+	// on the original are not shared with the clone
+	clone := ServiceSpec(original)
+	clone.ID = uuid.New().String()
+	clone.Name = original.Name + "-" + version
+	clone.Image = newImage
+	clone.Replicas = original.Replicas
+	clone.Labels = make(map[string]string)
+	clone.CreatedAt = time.Now()
+	clone.UpdatedAt = time.Now()
+
+	// Copy original labels
+	for k, v := range original.Labels {
+		clone.Labels[k] = v
+	}
+
+	// Add deployment tracking labels
+	clone.Labels[types.LabelDeploymentVersion] = version
+	clone.Labels[types.LabelDeploymentState] = string(state)
+	clone.Labels[types.LabelDeploymentStrategy] = string(original.DeployStrategy)
+	clone.Labels[types.LabelOriginalService] = original.ID
 
-	return fmt.Errorf("canary deployment rolled back due to health check failures")
+	return clone
 }
 
 // RollbackDeployment rolls back a deployment to the previous version
@@ -0,0 +1,462 @@
+package deploy
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/cuemby/warren/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeManager keeps services, containers and deployments in memory
+type fakeManager struct {
+	services    map[string]*types.Service
+	containers  map[string]*types.Container
+	deployments map[string]*types.Deployment
+	scheduled   int // Containers started by schedule
+}
+
+func newFakeManager() *fakeManager {
+	return &fakeManager{
+		services:    make(map[string]*types.Service),
+		containers:  make(map[string]*types.Container),
+		deployments: make(map[string]*types.Deployment),
+	}
+}
+
+func (f *fakeManager) GetService(id string) (*types.Service, error) {
+	service, ok := f.services[id]
+	if !ok {
+		return nil, fmt.Errorf("service not found: %s", id)
+	}
+	copied := *service
+	return &copied, nil
+}
+
+func (f *fakeManager) ListServices() ([]*types.Service, error) {
+	var services []*types.Service
+	for id := range f.services {
+		service, _ := f.GetService(id)
+		services = append(services, service)
+	}
+	return services, nil
+}
+
+func (f *fakeManager) CreateService(service *types.Service) error {
+	copied := *service
+	f.services[service.ID] = &copied
+	return nil
+}
+
+func (f *fakeManager) UpdateService(service *types.Service) error {
+	return f.CreateService(service)
+}
+
+func (f *fakeManager) DeleteService(id string) error {
+	delete(f.services, id)
+	return nil
+}
+
+func (f *fakeManager) ListContainersByService(serviceID string) ([]*types.Container, error) {
+	var containers []*types.Container
+	for _, container := range f.containers {
+		if container.ServiceID == serviceID {
+			copied := *container
+			containers = append(containers, &copied)
+		}
+	}
+	return containers, nil
+}
+
+func (f *fakeManager) UpdateContainer(container *types.Container) error {
+	copied := *container
+	f.containers[container.ID] = &copied
+	return nil
+}
+
+func (f *fakeManager) CreateDeployment(deployment *types.Deployment) error {
+	copied := *deployment
+	f.deployments[deployment.ID] = &copied
+	return nil
+}
+
+func (f *fakeManager) UpdateDeployment(deployment *types.Deployment) error {
+	return f.CreateDeployment(deployment)
+}
+
+func (f *fakeManager) DeleteDeployment(id string) error {
+	delete(f.deployments, id)
+	return nil
+}
+
+func (f *fakeManager) GetDeployment(id string) (*types.Deployment, error) {
+	deployment, ok := f.deployments[id]
+	if !ok {
+		return nil, fmt.Errorf("deployment not found: %s", id)
+	}
+	copied := *deployment
+	return &copied, nil
+}
+
+func (f *fakeManager) ListDeployments() ([]*types.Deployment, error) {
+	var deployments []*types.Deployment
+	for id := range f.deployments {
+		deployment, _ := f.GetDeployment(id)
+		deployments = append(deployments, deployment)
+	}
+	return deployments, nil
+}
+
+// addService creates a service with replicas running containers of image
+func (f *fakeManager) addService(id string, replicas int, image string, config *types.UpdateConfig) {
+	f.services[id] = &types.Service{
+		ID:           id,
+		Name:         "web",
+		Image:        image,
+		Replicas:     replicas,
+		UpdateConfig: config,
+		Labels:       map[string]string{},
+	}
+	f.addContainers(id, replicas, image)
+}
+
+// addContainers starts replicas running containers of image for a service
+func (f *fakeManager) addContainers(serviceID string, replicas int, image string) {
+	for i := 0; i < replicas; i++ {
+		id := fmt.Sprintf("%s-%d", serviceID, i)
+		f.containers[id] = &types.Container{
+			ID:           id,
+			ServiceID:    serviceID,
+			Image:        image,
+			DesiredState: types.ContainerStateRunning,
+			ActualState:  types.ContainerStateRunning,
+		}
+	}
+}
+
+// schedule does what the scheduler would for a service: it starts
+// containers of the service's image in state until Replicas+SurgeReplicas
+// are pending or running, and shuts down any over that
+func (f *fakeManager) schedule(serviceID string, state types.ContainerState) {
+	service := f.services[serviceID]
+
+	var active []*types.Container
+	for _, container := range f.containers {
+		if container.ServiceID == serviceID && container.DesiredState == types.ContainerStateRunning &&
+			(container.ActualState == types.ContainerStatePending || container.ActualState == types.ContainerStateRunning) {
+			active = append(active, container)
+		}
+	}
+	sort.Slice(active, func(i, j int) bool { return active[i].ID < active[j].ID })
+
+	desired := service.Replicas + service.SurgeReplicas
+	for i := len(active); i < desired; i++ {
+		f.scheduled++
+		id := fmt.Sprintf("%s-new-%02d", serviceID, f.scheduled)
+		f.containers[id] = &types.Container{
+			ID:           id,
+			ServiceID:    serviceID,
+			Image:        service.Image,
+			HealthCheck:  service.HealthCheck,
+			DesiredState: types.ContainerStateRunning,
+			ActualState:  state,
+			CreatedAt:    time.Now(),
+		}
+	}
+	for i := desired; i < len(active); i++ {
+		active[i].DesiredState = types.ContainerStateShutdown
+	}
+}
+
+// backdate moves the start of a deployment's current step into the past
+func (f *fakeManager) backdate(deploymentID string, d time.Duration) {
+	f.deployments[deploymentID].StepStartedAt = f.deployments[deploymentID].StepStartedAt.Add(-d)
+}
+
+// shutdownCount counts a service's containers told to shut down
+func (f *fakeManager) shutdownCount(serviceID string) int {
+	count := 0
+	for _, container := range f.containers {
+		if container.ServiceID == serviceID && container.DesiredState == types.ContainerStateShutdown {
+			count++
+		}
+	}
+	return count
+}
+
+// TestRollingUpdateBatches tests that a rolling update replaces a batch per step, waiting the delay in between
+func TestRollingUpdateBatches(t *testing.T) {
+	mgr := newFakeManager()
+	mgr.addService("svc-1", 3, "nginx:1.21", &types.UpdateConfig{Parallelism: 2, Delay: time.Minute, MaxUnavailable: 2})
+	d := NewDeployer(mgr)
+
+	deployment, err := d.UpdateService("svc-1", "nginx:1.22", types.DeployStrategyRolling)
+	require.NoError(t, err)
+	assert.Equal(t, "nginx:1.21", deployment.PreviousImage)
+	assert.Equal(t, types.DeploymentStepUpdateImage, deployment.Step)
+
+	require.NoError(t, d.Advance(deployment.ID))
+	assert.Equal(t, "nginx:1.22", mgr.services["svc-1"].Image)
+	assert.Equal(t, 2, mgr.shutdownCount("svc-1"))
+	assert.Equal(t, types.DeploymentStepReplaceBatch, mgr.deployments[deployment.ID].Step)
+	assert.Equal(t, 1, mgr.deployments[deployment.ID].StepIndex)
+
+	// The next batch waits for the delay
+	mgr.schedule("svc-1", types.ContainerStateRunning)
+	require.NoError(t, d.Advance(deployment.ID))
+	assert.Equal(t, 2, mgr.shutdownCount("svc-1"))
+
+	mgr.backdate(deployment.ID, time.Minute)
+	require.NoError(t, d.Advance(deployment.ID))
+	assert.Equal(t, 3, mgr.shutdownCount("svc-1"))
+	assert.Equal(t, types.DeploymentPhaseRunning, mgr.deployments[deployment.ID].Phase)
+
+	mgr.schedule("svc-1", types.ContainerStateRunning)
+	mgr.backdate(deployment.ID, time.Minute)
+	require.NoError(t, d.Advance(deployment.ID))
+	assert.Equal(t, types.DeploymentPhaseCompleted, mgr.deployments[deployment.ID].Phase)
+	assert.False(t, mgr.deployments[deployment.ID].CompletedAt.IsZero())
+}
+
+// TestUpdateServiceRejectsConcurrentDeployment tests that a service has at most one active deployment
+func TestUpdateServiceRejectsConcurrentDeployment(t *testing.T) {
+	mgr := newFakeManager()
+	mgr.addService("svc-1", 2, "nginx:1.21", nil)
+	d := NewDeployer(mgr)
+
+	deployment, err := d.UpdateService("svc-1", "nginx:1.22", types.DeployStrategyCanary)
+	require.NoError(t, err)
+
+	_, err = d.UpdateService("svc-1", "nginx:1.23", types.DeployStrategyRolling)
+	assert.Error(t, err)
+
+	_, err = d.Pause(deployment.ID)
+	require.NoError(t, err)
+	_, err = d.UpdateService("svc-1", "nginx:1.23", types.DeployStrategyRolling)
+	assert.Error(t, err, "a paused deployment is still active")
+
+	_, err = d.Abort(deployment.ID)
+	require.NoError(t, err)
+	_, err = d.UpdateService("svc-1", "nginx:1.23", types.DeployStrategyRolling)
+	assert.NoError(t, err)
+}
+
+// TestUpdateServiceSpecWaitsForActiveDeployment tests that a spec change is not applied while another deployment runs
+func TestUpdateServiceSpecWaitsForActiveDeployment(t *testing.T) {
+	mgr := newFakeManager()
+	mgr.addService("svc-1", 2, "nginx:1.21", nil)
+	d := NewDeployer(mgr)
+
+	setEnv := func(service *types.Service) error {
+		service.Env = []string{"POOL_SIZE=0"}
+		return nil
+	}
+
+	first, err := d.UpdateService("svc-1", "nginx:1.22", types.DeployStrategyRolling)
+	require.NoError(t, err)
+	_, err = d.UpdateServiceSpec("svc-1", "nginx:1.22", types.DeployStrategyRolling, setEnv)
+	assert.Error(t, err)
+	assert.Nil(t, mgr.services["svc-1"].Env, "the running update must not pick up the change")
+
+	_, err = d.Abort(first.ID)
+	require.NoError(t, err)
+	deployment, err := d.UpdateServiceSpec("svc-1", "nginx:1.21", types.DeployStrategyRolling, setEnv)
+	require.NoError(t, err)
+	assert.True(t, deployment.ReplaceAll)
+	assert.Equal(t, []string{"POOL_SIZE=0"}, mgr.services["svc-1"].Env)
+
+	// Aborting restores the env, and a change that fails leaves the service alone
+	_, err = d.Abort(deployment.ID)
+	require.NoError(t, err)
+	assert.Nil(t, mgr.services["svc-1"].Env)
+	_, err = d.UpdateServiceSpec("svc-1", "nginx:1.21", types.DeployStrategyRolling, func(service *types.Service) error {
+		service.Env = []string{"POOL_SIZE=1"}
+		return fmt.Errorf("invalid")
+	})
+	assert.Error(t, err)
+	assert.Nil(t, mgr.services["svc-1"].Env)
+}
+
+// TestBlueGreenResumesOnNewDeployer tests that a deployer that did not start a deployment carries it on
+func TestBlueGreenResumesOnNewDeployer(t *testing.T) {
+	mgr := newFakeManager()
+	mgr.addService("svc-1", 2, "nginx:1.21", nil)
+
+	deployment, err := NewDeployer(mgr).UpdateService("svc-1", "nginx:1.22", types.DeployStrategyBlueGreen)
+	require.NoError(t, err)
+	require.NoError(t, NewDeployer(mgr).Advance(deployment.ID))
+
+	cloneID := mgr.deployments[deployment.ID].CloneServiceID
+	require.Contains(t, mgr.services, cloneID)
+	assert.Equal(t, types.DeploymentStepWaitHealthy, mgr.deployments[deployment.ID].Step)
+
+	// Repeating the step after a failover does not create a second clone
+	mgr.deployments[deployment.ID].Step = types.DeploymentStepCreateClone
+	require.NoError(t, NewDeployer(mgr).Advance(deployment.ID))
+	assert.Len(t, mgr.services, 2)
+
+	// The green service becomes healthy after the grace period
+	mgr.addContainers(cloneID, 2, "nginx:1.22")
+	mgr.backdate(deployment.ID, defaultHealthCheckGracePeriod)
+	require.NoError(t, NewDeployer(mgr).Advance(deployment.ID))
+
+	assert.Equal(t, types.DeploymentPhaseCompleted, mgr.deployments[deployment.ID].Phase)
+	assert.Equal(t, string(types.DeploymentStateActive), mgr.services[cloneID].Labels[types.LabelDeploymentState])
+	assert.Equal(t, string(types.DeploymentStateStandby), mgr.services["svc-1"].Labels[types.LabelDeploymentState])
+}
+
+// TestCloneServiceCopiesSpec tests that green and canary services get every
+// spec field of their original
+func TestCloneServiceCopiesSpec(t *testing.T) {
+	original := fullService()
+	original.UpdateConfig.CanaryWeight = 25
+
+	clone := NewDeployer(newFakeManager()).cloneServiceForDeployment(original, "nginx:1.22", "v2", types.DeploymentStateCanary)
+
+	assert.Equal(t, "nginx:1.22", clone.Image)
+	clone.Image = original.Image
+	assert.Equal(t, 0, clone.UpdateConfig.CanaryWeight)
+	clone.UpdateConfig.CanaryWeight = 25
+	assertSpecFieldsEqual(t, original, clone)
+
+	assert.NotEqual(t, original.ID, clone.ID)
+	assert.Equal(t, "web-v2", clone.Name)
+	assert.Equal(t, original.Replicas, clone.Replicas)
+	assert.Equal(t, "web", clone.Labels["team"])
+	assert.Equal(t, original.ID, clone.Labels[types.LabelOriginalService])
+	assert.Equal(t, 25, original.UpdateConfig.CanaryWeight, "clone shares the original's update config")
+}
+
+// TestCanaryPauseResumeAbort tests that a paused canary waits, and that aborting it removes the canary
+func TestCanaryPauseResumeAbort(t *testing.T) {
+	mgr := newFakeManager()
+	mgr.addService("svc-1", 4, "nginx:1.21", &types.UpdateConfig{CanarySteps: []int{25, 100}})
+	d := NewDeployer(mgr)
+
+	deployment, err := d.UpdateService("svc-1", "nginx:1.22", types.DeployStrategyCanary)
+	require.NoError(t, err)
+	require.NoError(t, d.Advance(deployment.ID))
+
+	cloneID := mgr.deployments[deployment.ID].CloneServiceID
+	assert.Equal(t, 1, mgr.services[cloneID].Replicas)
+	assert.Equal(t, types.DeploymentStepWaitHealthy, mgr.deployments[deployment.ID].Step)
+
+	// A healthy canary gets its weight and waits out the stability window
+	mgr.addContainers(cloneID, 1, "nginx:1.22")
+	mgr.backdate(deployment.ID, defaultHealthCheckGracePeriod)
+	require.NoError(t, d.Advance(deployment.ID))
+	assert.Equal(t, 25, mgr.services["svc-1"].UpdateConfig.CanaryWeight)
+	assert.Equal(t, types.DeploymentStepStabilize, mgr.deployments[deployment.ID].Step)
+
+	// A paused deployment does not move, even when its wait is over
+	_, err = d.Pause(deployment.ID)
+	require.NoError(t, err)
+	mgr.backdate(deployment.ID, defaultStabilityWindow)
+	require.NoError(t, d.Advance(deployment.ID))
+	assert.Equal(t, types.DeploymentStepStabilize, mgr.deployments[deployment.ID].Step)
+
+	// Resuming starts the stability window over
+	_, err = d.Resume(deployment.ID)
+	require.NoError(t, err)
+	require.NoError(t, d.Advance(deployment.ID))
+	assert.Equal(t, types.DeploymentStepStabilize, mgr.deployments[deployment.ID].Step)
+
+	aborted, err := d.Abort(deployment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, types.DeploymentPhaseAborted, aborted.Phase)
+	assert.NotContains(t, mgr.services, cloneID)
+	assert.Equal(t, 0, mgr.services["svc-1"].UpdateConfig.CanaryWeight)
+	assert.Equal(t, "nginx:1.21", mgr.services["svc-1"].Image)
+
+	_, err = d.Abort(deployment.ID)
+	assert.Error(t, err, "a finished deployment cannot be aborted")
+}
+
+// TestAbortRollingUpdate tests that aborting a rolling update restores the previous image
+func TestAbortRollingUpdate(t *testing.T) {
+	mgr := newFakeManager()
+	mgr.addService("svc-1", 2, "nginx:1.21", &types.UpdateConfig{Delay: time.Hour, MaxUnavailable: 1})
+	d := NewDeployer(mgr)
+
+	deployment, err := d.UpdateService("svc-1", "nginx:1.22", types.DeployStrategyRolling)
+	require.NoError(t, err)
+	require.NoError(t, d.Advance(deployment.ID))
+	assert.Equal(t, 1, mgr.shutdownCount("svc-1"))
+
+	// The scheduler started a replacement with the new image
+	mgr.containers["svc-1-new"] = &types.Container{
+		ID: "svc-1-new", ServiceID: "svc-1", Image: "nginx:1.22",
+		DesiredState: types.ContainerStateRunning, ActualState: types.ContainerStateRunning,
+		CreatedAt: time.Now(),
+	}
+
+	_, err = d.Abort(deployment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "nginx:1.21", mgr.services["svc-1"].Image)
+	assert.Equal(t, types.ContainerStateShutdown, mgr.containers["svc-1-new"].DesiredState)
+	assert.Equal(t, types.DeploymentPhaseAborted, mgr.deployments[deployment.ID].Phase)
+}
+
+// TestDeploymentFailsWhenUnhealthy tests that a new version that never becomes healthy is rolled back
+func TestDeploymentFailsWhenUnhealthy(t *testing.T) {
+	mgr := newFakeManager()
+	mgr.addService("svc-1", 2, "nginx:1.21", nil)
+	d := NewDeployer(mgr)
+
+	deployment, err := d.UpdateService("svc-1", "nginx:broken", types.DeployStrategyBlueGreen)
+	require.NoError(t, err)
+	require.NoError(t, d.Advance(deployment.ID))
+	cloneID := mgr.deployments[deployment.ID].CloneServiceID
+
+	mgr.backdate(deployment.ID, defaultHealthCheckGracePeriod+healthCheckTimeout+time.Second)
+	require.NoError(t, d.Advance(deployment.ID))
+
+	failed := mgr.deployments[deployment.ID]
+	assert.Equal(t, types.DeploymentPhaseFailed, failed.Phase)
+	assert.NotEmpty(t, failed.Message)
+	assert.NotContains(t, mgr.services, cloneID)
+}
+
+// TestPruneFinishedDeployments tests that old finished deployments are pruned
+func TestPruneFinishedDeployments(t *testing.T) {
+	mgr := newFakeManager()
+	mgr.addService("svc-1", 1, "nginx:1.21", nil)
+	c := NewController(NewDeployer(mgr), nil)
+
+	var deployments []*types.Deployment
+	start := time.Now().Add(-time.Hour)
+	for i := 0; i < maxFinishedDeployments+2; i++ {
+		deployments = append(deployments, &types.Deployment{
+			ID: fmt.Sprintf("dep-%02d", i), ServiceID: "svc-1",
+			Phase: types.DeploymentPhaseCompleted, CreatedAt: start.Add(time.Duration(i) * time.Minute),
+		})
+	}
+	deployments = append(deployments,
+		&types.Deployment{ID: "running", ServiceID: "svc-1", Phase: types.DeploymentPhaseRunning, CreatedAt: start},
+		&types.Deployment{ID: "orphan", ServiceID: "svc-gone", Phase: types.DeploymentPhaseFailed, CreatedAt: start},
+	)
+	for _, deployment := range deployments {
+		require.NoError(t, mgr.CreateDeployment(deployment))
+	}
+
+	c.prune(deployments)
+
+	assert.Len(t, mgr.deployments, maxFinishedDeployments+1)
+	assert.NotContains(t, mgr.deployments, "dep-00")
+	assert.NotContains(t, mgr.deployments, "dep-01")
+	assert.NotContains(t, mgr.deployments, "orphan")
+	assert.Contains(t, mgr.deployments, "running")
+}
+
+// TestValidateUpdateConfig tests which rolling update settings are accepted
+func TestValidateUpdateConfig(t *testing.T) {
+	assert.NoError(t, ValidateUpdateConfig(nil))
+	assert.NoError(t, ValidateUpdateConfig(&types.UpdateConfig{}))
+	assert.NoError(t, ValidateUpdateConfig(&types.UpdateConfig{FailureAction: types.FailureActionPause, MaxSurge: 2, MaxUnavailable: 1}))
+	assert.Error(t, ValidateUpdateConfig(&types.UpdateConfig{FailureAction: "retry"}))
+	assert.Error(t, ValidateUpdateConfig(&types.UpdateConfig{MaxSurge: -1}))
+	assert.Error(t, ValidateUpdateConfig(&types.UpdateConfig{MaxUnavailable: -1}))
+}
@@ -34,10 +34,10 @@ Warren's deployment system coordinates safe service updates across the cluster:
 	│  │    - Instant rollback capability            │          │
 	│  │    - Zero downtime                          │          │
 	│  │                                              │          │
-	│  │  Canary (Future):                           │          │
+	│  │  Canary:                                    │          │
 	│  │    Old + New (weighted) → Gradual          │          │
 	│  │    - Traffic split: 10% → 50% → 100%       │          │
-	│  │    - Metrics-driven progression             │          │
+	│  │    - Weighted by the ingress proxy          │          │
 	│  │    - Automatic rollback on errors           │          │
 	│  └──────────────────┬─────────────────────────┘          │
 	│                     │                                      │
@@ -62,14 +62,57 @@ Warren's deployment system coordinates safe service updates across the cluster:
 	│  └────────────────────────────────────────────┘           │
 	└────────────────────────────────────────────────────────┘
 
+# Durable Deployments
+
+Every update is a types.Deployment stored through Raft, like services and
+containers. It records the strategy, the new and previous image, the
+service's spec before the update, a phase (running, paused, completed,
+failed or aborted) and the current step:
+
+	rolling:    update-image → replace-batch (one per batch)
+	blue-green: create-clone → wait-healthy → switch-traffic
+	canary:     create-clone → scale-canary → wait-healthy → stabilize → ... → switch-traffic
+
+The deployment Controller runs on every manager but acts only on the
+leader. Every few seconds it advances each running deployment as far as
+it can go without waiting. Waits such as the batch delay, health check
+grace period and canary stability window are measured from the time the
+step started, which is stored with the deployment, so a manager that
+becomes leader after a failover carries every deployment on where the
+old leader left it. Every step can safely run twice: the ID of the clone
+service is stored before the clone is created, and a repeated batch only
+shuts down containers still running the old image.
+
+A deployment can be paused, resumed and aborted (warren deployment
+pause/resume/abort). Resuming starts the wait of the current step over.
+Aborting, or a failure such as a new version that never becomes healthy,
+rolls the service back: the clone service is deleted and the canary
+weight reset, or a rolling update's previous spec is restored and the
+containers it started, even with an unchanged image, are replaced. The
+controller keeps the last 10 finished deployments of each service.
+
+# Revisions
+
+The manager records a types.ServiceRevision whenever a service's spec
+changes, keeping the last 10. ServiceSpec is the part of a service a
+revision holds; replicas, labels and the canary weight are left out, so
+scaling and canary steps do not make revisions. RollbackToRevision
+restores a revision's spec and starts a deployment of its image. When the
+image is unchanged, the deployment replaces every container created
+before it, so restored env and resources take effect.
+
 # Core Components
 
 Deployer:
   - Main orchestrator for service updates
   - Holds reference to manager for state access
-  - Implements deployment strategy selection
+  - Starts, advances, pauses, resumes and aborts deployments
   - Provides status reporting
 
+Controller:
+  - Advances running deployments on the leader
+  - Prunes the history of finished deployments
+
 DeploymentStatus:
   - Current deployment progress information
   - Task counts by state
@@ -80,30 +123,43 @@ UpdateConfig:
   - Parallelism: How many tasks to update simultaneously
   - Delay: Wait time between update batches
   - FailureAction: pause, rollback, or continue
-  - CanaryWeight: Percentage of traffic to new version (future)
+  - CanaryWeight: Percentage of ingress traffic sent to the canary
 
 # Deployment Strategies
 
 Rolling Update:
 
 Strategy:
-  - Update tasks one by one (or in batches)
-  - Old task shutdown → Scheduler creates new task
-  - Wait for health check before next batch
-  - Configurable parallelism and delay
+  - Update tasks in batches of up to Parallelism
+  - Surge new tasks before shutting down old ones
+  - Shut down old tasks only while enough others serve
+  - Configurable parallelism, delay, surge and unavailability
 
 Flow:
- 1. Get all running tasks for service
- 2. Determine batch size (parallelism)
- 3. For each batch:
-    a. Shutdown old tasks (set DesiredState=Shutdown)
-    b. Scheduler automatically creates replacements
-    c. Wait for configured delay
- 4. Monitor until all tasks updated
+ 1. Set the new image on the service
+ 2. For each batch:
+    a. Surge: the scheduler runs up to MaxSurge tasks over Replicas
+    b. Shutdown old tasks (set DesiredState=Shutdown) while enough serve
+    c. Scheduler automatically creates replacements
+    d. Wait for configured delay
+ 3. Complete once every replica runs the new image and serves
+
+A task serves when it is running and its health check, if any, reports
+healthy; one whose health was not reported yet serves after the health
+check grace period. Without MaxSurge or MaxUnavailable, MaxSurge is 1, so
+a default update never has fewer than Replicas tasks serving. The surge
+is Service.SurgeReplicas; old tasks not serving are shut down first, and
+others only while at least Replicas-MaxUnavailable tasks serve.
+
+A new task that fails, or new tasks that do not serve healthCheckTimeout
+after the grace period, trigger the FailureAction. The deployment counts
+failed tasks in Failures, so each is acted on once.
 
 Configuration:
   - Parallelism: 1 (serial), 2-N (parallel batches)
   - Delay: 0s (immediate), 10s, 30s, etc.
+  - MaxSurge: 1 (default; 0 when MaxUnavailable is set)
+  - MaxUnavailable: 0 (default)
   - FailureAction: rollback (default), pause, continue
 
 Advantages:
@@ -146,25 +202,55 @@ Disadvantages:
   - Longer deployment time (full parallel deployment)
   - Database migrations require coordination
 
-Canary Deployment (Future - Milestone 4):
+Canary Deployment:
 
 Strategy:
   - Deploy new version with small percentage of traffic
-  - Gradually increase traffic weight (10% → 50% → 100%)
-  - Monitor metrics and automatically rollback on errors
+  - Gradually increase traffic weight (10% → 25% → 50% → 100%)
+  - Rollback when canary tasks fail health checks or canary analysis
   - Final step removes old version
 
 Flow:
- 1. Deploy canary tasks (e.g., 10% of replicas)
- 2. Route 10% of traffic to canary
- 3. Monitor error rates, latency, etc.
- 4. If healthy, increase to 50%, then 100%
- 5. Remove old version tasks
+ 1. Deploy canary tasks (enough replicas for the step's weight)
+ 2. Set CanaryWeight on the stable service once the canary is healthy
+ 3. The ingress routes that percentage of requests to the canary
+ 4. After the stability window, compare canary and stable traffic
+ 5. Move on to the next weight, or roll back if the analysis failed
+ 6. Promote the canary and scale the old version to zero
+
+The stable service keeps all of its replicas until the canary is promoted,
+so it can take back the traffic at once on rollback. Requests can pin
+themselves to either version with the X-Warren-Canary header or the
+warren-canary cookie (see package ingress).
+
+Canary analysis:
+
+The ingress proxy records the requests, 5xx errors and latency of every
+backend service. After each step's stability window, the deployer compares
+the canary's share of that traffic with the stable service's. The canary
+fails when its error rate exceeds the stable one by more than
+FailureThresholdPercent points, or its p99 latency exceeds the stable one
+by more than P99ThresholdPercent. A step with fewer than 20 canary requests
+is inconclusive and does not stop the rollout.
+
+The result is recorded on the service as CanaryAnalysis and shown by
+"warren service inspect". A failed analysis rolls the update back only
+when AutoRollbackEnabled is set; otherwise it is logged and the rollout
+continues. Analysis needs the ingress proxy on the manager running the
+update and is skipped without it.
+
+Traffic stats are kept in memory by each manager's ingress proxy, and the
+leader only sees the requests it proxied itself. Analysis therefore needs
+a cluster with a single manager serving ingress: with more managers, every
+step's analysis is inconclusive and names the reason, and the rollout
+carries on as if no analysis were configured.
 
 Configuration:
-  - CanaryWeight: 10, 25, 50, 100 (progression)
-  - HealthThreshold: Error rate < 1%, p95 latency < 500ms
-  - AutoPromote: Automatic or manual progression
+  - CanaryWeight: 10, 25, 50, 100 (progression, set by the deployer)
+  - HealthCheckGracePeriod: How long each step waits for healthy tasks
+  - AutoRollbackEnabled: Roll back when canary analysis fails
+  - FailureThresholdPercent: Error rate points over stable (default: 10)
+  - P99ThresholdPercent: p99 latency increase over stable (default: 50)
 
 Advantages:
   - Minimal risk (small traffic percentage)
@@ -193,12 +279,19 @@ Creating a Deployer:
 
 Rolling Update:
 
-	// Update service to new image
-	err := deployer.UpdateService("service-xyz789", "nginx:1.21")
+	// Start updating the service to a new image
+	deployment, err := deployer.UpdateService("service-xyz789", "nginx:1.21", types.DeployStrategyRolling)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	// The controller advances it; Advance does one round by hand
+	controller := deploy.NewController(deployer, mgr)
+	controller.Start()
+	defer controller.Stop()
+
+	fmt.Printf("Deployment %s: %s\n", deployment.ID, deployment.Step)
+
 Getting Deployment Status:
 
 	status, err := deployer.GetDeploymentStatus("service-xyz789")
@@ -250,25 +343,22 @@ Complete Example:
 		fmt.Println("Service created, waiting for stable state...")
 		time.Sleep(30 * time.Second)
 
+		controller := deploy.NewController(deployer, mgr)
+		controller.Start()
+		defer controller.Stop()
+
 		// Update to new version
 		fmt.Println("Starting rolling update to nginx:1.21")
-		err = deployer.UpdateService(service.ID, "nginx:1.21")
+		deployment, err := deployer.UpdateService(service.ID, "nginx:1.21", "")
 		if err != nil {
 			panic(err)
 		}
 
-		// Monitor deployment status
-		for i := 0; i < 30; i++ {
-			status, _ := deployer.GetDeploymentStatus(service.ID)
-			fmt.Printf("Progress: %d/%d ready\n",
-				status.ReadyTasks, status.DesiredTasks)
-
-			if status.ReadyTasks == status.DesiredTasks {
-				fmt.Println("Deployment complete!")
-				break
-			}
-
+		// Monitor the deployment
+		for !deploy.Finished(deployment) {
 			time.Sleep(2 * time.Second)
+			deployment, _ = mgr.GetDeployment(deployment.ID)
+			fmt.Printf("Phase: %s, step: %s\n", deployment.Phase, deployment.Step)
 		}
 	}
 
@@ -287,10 +377,13 @@ This package integrates with:
 UpdateConfig Structure:
 
 	type UpdateConfig struct {
-		Parallelism   int           // Concurrent updates (default: 1)
-		Delay         time.Duration // Delay between batches (default: 0s)
-		FailureAction string        // "pause", "rollback", "continue"
-		CanaryWeight  int           // 0-100 for canary strategy
+		Parallelism            int           // Concurrent updates (default: 1)
+		Delay                  time.Duration // Delay between batches (default: 0s)
+		FailureAction          string        // "pause", "rollback", "continue"
+		MaxSurge               int           // Extra tasks during an update (default: 1)
+		MaxUnavailable         int           // Tasks that may not serve (default: 0)
+		HealthCheckGracePeriod time.Duration // Time new tasks get to pass health checks (default: 30s)
+		CanaryWeight           int           // 0-100 for canary strategy
 	}
 
 Parallelism:
@@ -306,9 +399,11 @@ Delay:
   - Recommendation: 10-30s for production
 
 FailureAction:
-  - "pause": Stop deployment for manual investigation
-  - "rollback": Automatically revert to previous version
-  - "continue": Proceed despite failures
+  - "pause": Pause the deployment for manual investigation
+  - "rollback": Automatically revert to previous version (default)
+  - "continue": Proceed despite failures; a batch that waited out the
+    health check timeout is replaced even past MaxUnavailable, so the
+    update completes even if the new version never becomes healthy
   - Recommendation: "rollback" for production
 
 # Design Patterns
@@ -353,12 +448,12 @@ Example (10 replicas, parallelism=2, delay=10s):
 Resource Usage:
   - Rolling: No additional resources (1:1 replacement)
   - Blue/Green: 2x resources temporarily
-  - Canary: 1.1-1.5x resources during rollout
+  - Canary: 1.1-2x resources, growing with the canary weight
 
 Rollback Speed:
   - Rolling: Same as forward (restart old tasks)
   - Blue/Green: Instant (switch VIP)
-  - Canary: Gradual decrease of new version
+  - Canary: Instant (weight reset to 0, canary removed)
 
 # Troubleshooting
 
@@ -409,24 +504,25 @@ Deployment Outcomes:
 
 # Rollback Procedure
 
-Manual Rollback:
-
-	// Get previous image version (from service history)
-	previousImage := "nginx:1.20"
+Aborting a Deployment:
 
-	// Update back to previous version
-	err := deployer.UpdateService(serviceID, previousImage)
+	// Return the service to the version it ran before the deployment
+	deployment, err := deployer.Abort(deploymentID)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-Automatic Rollback (Future):
-
-	service.UpdateConfig.FailureAction = "rollback"
+Manual Rollback:
 
-	// If new tasks fail, automatic rollback triggered
-	err := deployer.UpdateService(serviceID, newImage)
-	// Returns error but rollback already in progress
+	// Restore an earlier revision of the service
+	revision, err := mgr.GetServiceRevision(serviceID, 3)
+	if err != nil {
+		log.Fatal(err)
+	}
+	_, err = deployer.RollbackToRevision(serviceID, revision)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 # Best Practices
 
@@ -474,10 +570,7 @@ Post-Deployment:
 
 Planned for Milestone 4:
   - Blue/green deployment implementation
-  - Canary deployment with traffic splitting
-  - Automatic rollback on metrics threshold
   - Deployment history and versioning
-  - Pause/resume deployment capability
 
 Planned for Milestone 5:
   - Multi-phase canary (10% → 50% → 100%)
@@ -0,0 +1,153 @@
+package deploy
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/cuemby/warren/pkg/log"
+	"github.com/cuemby/warren/pkg/metrics"
+	"github.com/cuemby/warren/pkg/types"
+)
+
+// ServiceSpec returns the part of a service that a revision records: its
+// image, env, resources, health check and other settings, without its
+// identity, replica count, labels or status
+func ServiceSpec(service *types.Service) *types.Service {
+	spec := &types.Service{
+		Image:            service.Image,
+		Mode:             service.Mode,
+		DeployStrategy:   service.DeployStrategy,
+		Ports:            service.Ports,
+		Networks:         service.Networks,
+		Secrets:          service.Secrets,
+		Volumes:          service.Volumes,
+		HealthCheck:      service.HealthCheck,
+		RestartPolicy:    service.RestartPolicy,
+		Resources:        service.Resources,
+		StopTimeout:      service.StopTimeout,
+		Placement:        service.Placement,
+		Affinity:         service.Affinity,
+		AntiAffinity:     service.AntiAffinity,
+		Tolerations:      service.Tolerations,
+		DisruptionBudget: service.DisruptionBudget,
+		Priority:         service.Priority,
+		Job:              service.Job,
+		CronJob:          service.CronJob,
+	}
+
+	// Env is kept sorted, so that reordering it is not a change
+	if len(service.Env) > 0 {
+		spec.Env = append([]string(nil), service.Env...)
+		sort.Strings(spec.Env)
+	}
+
+	// The canary weight is deployment progress, not configuration
+	if service.UpdateConfig != nil {
+		updateConfig := *service.UpdateConfig
+		updateConfig.CanaryWeight = 0
+		spec.UpdateConfig = &updateConfig
+	}
+
+	// Empty and missing lists are the same spec
+	if len(spec.Ports) == 0 {
+		spec.Ports = nil
+	}
+	if len(spec.Networks) == 0 {
+		spec.Networks = nil
+	}
+	if len(spec.Secrets) == 0 {
+		spec.Secrets = nil
+	}
+	if len(spec.Volumes) == 0 {
+		spec.Volumes = nil
+	}
+	if len(spec.Tolerations) == 0 {
+		spec.Tolerations = nil
+	}
+	return spec
+}
+
+// SameSpec reports whether two services have the same spec
+func SameSpec(a, b *types.Service) bool {
+	return reflect.DeepEqual(ServiceSpec(a), ServiceSpec(b))
+}
+
+// restoreSpec sets a service's spec, except its image, to that of a revision
+func restoreSpec(service *types.Service, spec *types.Service) {
+	if spec.UpdateConfig != nil {
+		updateConfig := *spec.UpdateConfig
+		if service.UpdateConfig != nil {
+			updateConfig.CanaryWeight = service.UpdateConfig.CanaryWeight
+		}
+		service.UpdateConfig = &updateConfig
+	} else {
+		service.UpdateConfig = nil
+	}
+
+	service.Mode = spec.Mode
+	service.DeployStrategy = spec.DeployStrategy
+	service.Env = spec.Env
+	service.Ports = spec.Ports
+	service.Networks = spec.Networks
+	service.Secrets = spec.Secrets
+	service.Volumes = spec.Volumes
+	service.HealthCheck = spec.HealthCheck
+	service.RestartPolicy = spec.RestartPolicy
+	service.Resources = spec.Resources
+	service.StopTimeout = spec.StopTimeout
+	service.Placement = spec.Placement
+	service.Affinity = spec.Affinity
+	service.AntiAffinity = spec.AntiAffinity
+	service.Tolerations = spec.Tolerations
+	service.DisruptionBudget = spec.DisruptionBudget
+	service.Priority = spec.Priority
+	service.Job = spec.Job
+	service.CronJob = spec.CronJob
+}
+
+// RollbackToRevision restores a service's spec to that of one of its
+// revisions and deploys it with the revision's strategy. Containers are
+// replaced even if the image did not change, so that they pick up the
+// restored env, resources and health check.
+func (d *Deployer) RollbackToRevision(serviceID string, revision *types.ServiceRevision) (*types.Deployment, error) {
+	d.stepMu.Lock()
+	defer d.stepMu.Unlock()
+
+	service, err := d.manager.GetService(serviceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service: %w", err)
+	}
+	if revision.ServiceID != service.ID || revision.Spec == nil {
+		return nil, fmt.Errorf("revision %d does not belong to service %s", revision.Revision, service.Name)
+	}
+
+	active, err := d.activeDeployment(service.ID)
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		return nil, fmt.Errorf("service %s already has deployment %s in progress", service.Name, active.ID)
+	}
+
+	previous := ServiceSpec(service)
+	restoreSpec(service, revision.Spec)
+	service.UpdatedAt = time.Now()
+	if err := d.manager.UpdateService(service); err != nil {
+		return nil, fmt.Errorf("failed to restore service spec: %w", err)
+	}
+
+	log.Logger.Info().
+		Str("service", service.Name).
+		Int("revision", revision.Revision).
+		Str("image", revision.Spec.Image).
+		Msg("Rolling back service to revision")
+
+	deployment, err := d.start(service, previous, revision.Spec.Image, "")
+	if err != nil {
+		return nil, err
+	}
+	metrics.RolledBackDeploymentsTotal.WithLabelValues(string(deployment.Strategy), "manual").Inc()
+	return deployment, nil
+}
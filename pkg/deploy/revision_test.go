@@ -0,0 +1,180 @@
+package deploy
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cuemby/warren/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSameSpec tests which service changes make a new revision
+func TestSameSpec(t *testing.T) {
+	base := &types.Service{
+		ID: "svc-1", Name: "web", Image: "nginx:1.21", Replicas: 3,
+		Env:          []string{"A=1", "B=2"},
+		UpdateConfig: &types.UpdateConfig{Parallelism: 2},
+	}
+
+	tests := []struct {
+		name   string
+		change func(s *types.Service)
+		same   bool
+	}{
+		{name: "replicas", change: func(s *types.Service) { s.Replicas = 5 }, same: true},
+		{name: "env order", change: func(s *types.Service) { s.Env = []string{"B=2", "A=1"} }, same: true},
+		{name: "empty lists", change: func(s *types.Service) { s.Ports = []*types.PortMapping{} }, same: true},
+		{name: "canary weight", change: func(s *types.Service) { s.UpdateConfig = &types.UpdateConfig{Parallelism: 2, CanaryWeight: 25} }, same: true},
+		{name: "labels", change: func(s *types.Service) { s.Labels = map[string]string{"a": "b"} }, same: true},
+		{name: "image", change: func(s *types.Service) { s.Image = "nginx:1.22" }},
+		{name: "env value", change: func(s *types.Service) { s.Env = []string{"A=1", "B=3"} }},
+		{name: "resources", change: func(s *types.Service) { s.Resources = &types.ResourceRequirements{MemoryLimit: 1 << 20} }},
+		{name: "health check", change: func(s *types.Service) { s.HealthCheck = &types.HealthCheck{Type: types.HealthCheckHTTP} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed := *base
+			tt.change(&changed)
+			assert.Equal(t, tt.same, SameSpec(base, &changed))
+		})
+	}
+}
+
+// TestRollbackToRevision tests that a rollback restores the spec and replaces every container
+func TestRollbackToRevision(t *testing.T) {
+	mgr := newFakeManager()
+	mgr.addService("svc-1", 2, "nginx:1.21", nil)
+	for _, container := range mgr.containers {
+		container.CreatedAt = time.Now().Add(-time.Hour)
+	}
+
+	revision := &types.ServiceRevision{
+		ServiceID: "svc-1",
+		Revision:  1,
+		Spec:      ServiceSpec(mgr.services["svc-1"]),
+	}
+
+	// A bad env change, with the same image
+	mgr.services["svc-1"].Env = []string{"POOL_SIZE=0"}
+	mgr.services["svc-1"].UpdateConfig = &types.UpdateConfig{Parallelism: 2}
+
+	d := NewDeployer(mgr)
+	deployment, err := d.RollbackToRevision("svc-1", revision)
+	require.NoError(t, err)
+	assert.True(t, deployment.ReplaceAll)
+	assert.Nil(t, mgr.services["svc-1"].Env)
+	assert.Nil(t, mgr.services["svc-1"].UpdateConfig)
+
+	// Same image, but every container predates the rollback
+	for i := 0; i < 10 && mgr.deployments[deployment.ID].Phase == types.DeploymentPhaseRunning; i++ {
+		require.NoError(t, d.Advance(deployment.ID))
+		mgr.schedule("svc-1", types.ContainerStateRunning)
+	}
+	assert.Equal(t, types.DeploymentPhaseCompleted, mgr.deployments[deployment.ID].Phase)
+	assert.Equal(t, 2, mgr.shutdownCount("svc-1"))
+	for _, container := range mgr.containers {
+		if container.DesiredState == types.ContainerStateRunning {
+			assert.True(t, container.CreatedAt.After(deployment.CreatedAt))
+		}
+	}
+
+	// Another service's revision is refused
+	_, err = d.RollbackToRevision("svc-1", &types.ServiceRevision{ServiceID: "svc-2", Spec: revision.Spec})
+	assert.Error(t, err)
+}
+
+// serviceStatusFields are the service fields that are identity, replica
+// count, labels or status rather than spec
+var serviceStatusFields = map[string]bool{
+	"ID":               true,
+	"Name":             true,
+	"Replicas":         true,
+	"SurgeReplicas":    true,
+	"Labels":           true,
+	"VIP":              true,
+	"JobStatus":        true,
+	"LastScheduleTime": true,
+	"CanaryAnalysis":   true,
+	"CreatedAt":        true,
+	"UpdatedAt":        true,
+}
+
+// fullService returns a service with every field set. Env is sorted and the
+// canary weight is 0, as ServiceSpec records them.
+func fullService() *types.Service {
+	now := time.Now().Truncate(time.Second)
+	return &types.Service{
+		ID:             "svc-1",
+		Name:           "web",
+		Image:          "nginx:1.21",
+		Replicas:       3,
+		SurgeReplicas:  1,
+		Mode:           types.ServiceModeReplicated,
+		DeployStrategy: types.DeployStrategyCanary,
+		UpdateConfig:   &types.UpdateConfig{Parallelism: 2, MaxSurge: 1, CanarySteps: []int{10, 50, 100}},
+		Env:            []string{"A=1", "B=2"},
+		Ports:          []*types.PortMapping{{ContainerPort: 80, HostPort: 8080, Protocol: "tcp", PublishMode: types.PublishModeIngress}},
+		Networks:       []string{"backend"},
+		Secrets:        []string{"db-password"},
+		Volumes:        []*types.VolumeMount{{Source: "data", Target: "/data"}},
+		Labels:         map[string]string{"team": "web"},
+		HealthCheck:    &types.HealthCheck{Type: types.HealthCheckHTTP, Endpoint: "/health"},
+		RestartPolicy:  &types.RestartPolicy{Condition: types.RestartOnFailure, MaxAttempts: 3},
+		Resources:      &types.ResourceRequirements{CPULimit: 0.5, MemoryLimit: 1 << 28},
+		StopTimeout:    20,
+		VIP:            net.ParseIP("10.0.0.2"),
+		Placement:      &types.Placement{Constraints: []string{"node.labels.zone==eu-1"}},
+		Affinity:       &types.Affinity{Required: []types.AffinityTerm{{Service: "cache"}}},
+		AntiAffinity:   &types.Affinity{Required: []types.AffinityTerm{{Service: "web"}}},
+		Tolerations: []types.Toleration{
+			{Key: "dedicated", Operator: types.TolerationOpEqual, Value: "web", Effect: types.TaintEffectNoSchedule},
+		},
+		DisruptionBudget: 2,
+		Priority:         10,
+		Job:              &types.JobConfig{Completions: 1},
+		JobStatus:        &types.JobStatus{State: types.JobStateRunning},
+		CronJob:          &types.CronJobConfig{Schedule: "@daily"},
+		LastScheduleTime: now,
+		CanaryAnalysis:   &types.CanaryAnalysis{Result: types.CanaryAnalysisPassed},
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+}
+
+// TestFullServiceSetsEveryField guards fullService against new Service fields
+func TestFullServiceSetsEveryField(t *testing.T) {
+	v := reflect.ValueOf(*fullService())
+	for i := 0; i < v.NumField(); i++ {
+		assert.False(t, v.Field(i).IsZero(), "fullService does not set %s", v.Type().Field(i).Name)
+	}
+}
+
+// assertSpecFieldsEqual asserts that every spec field of got equals that of want
+func assertSpecFieldsEqual(t *testing.T, want, got *types.Service) {
+	t.Helper()
+	wantValue, gotValue := reflect.ValueOf(*want), reflect.ValueOf(*got)
+	for i := 0; i < wantValue.NumField(); i++ {
+		name := wantValue.Type().Field(i).Name
+		if serviceStatusFields[name] {
+			continue
+		}
+		assert.Equal(t, wantValue.Field(i).Interface(), gotValue.Field(i).Interface(), "field %s", name)
+	}
+}
+
+// TestServiceSpecRecordsEveryField tests that a revision records, and a
+// rollback restores, every field of a service's spec
+func TestServiceSpecRecordsEveryField(t *testing.T) {
+	service := fullService()
+	spec := ServiceSpec(service)
+	assertSpecFieldsEqual(t, service, spec)
+
+	restored := &types.Service{}
+	restoreSpec(restored, spec)
+	restored.Image = spec.Image
+	assertSpecFieldsEqual(t, service, restored)
+}
@@ -0,0 +1,317 @@
+package deploy
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cuemby/warren/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startPending marks a service's pending containers running, as a worker would
+func (f *fakeManager) startPending(serviceID string) {
+	for _, container := range f.containers {
+		if container.ServiceID == serviceID && container.ActualState == types.ContainerStatePending {
+			container.ActualState = types.ContainerStateRunning
+			container.StartedAt = time.Now()
+		}
+	}
+}
+
+// checkRollingInvariants asserts that a service has at most
+// Replicas+maxSurge containers meant to run, and at least
+// Replicas-maxUnavailable serving
+func checkRollingInvariants(t *testing.T, mgr *fakeManager, serviceID string, maxSurge, maxUnavailable int, when string) {
+	t.Helper()
+	service := mgr.services[serviceID]
+
+	active, serving := 0, 0
+	for _, container := range mgr.containers {
+		if container.ServiceID != serviceID || container.DesiredState != types.ContainerStateRunning {
+			continue
+		}
+		switch container.ActualState {
+		case types.ContainerStatePending:
+			active++
+		case types.ContainerStateRunning:
+			active++
+			if container.HealthStatus == nil || container.HealthStatus.Healthy {
+				serving++
+			}
+		}
+	}
+
+	assert.LessOrEqual(t, service.SurgeReplicas, maxSurge, "%s: surge over MaxSurge", when)
+	assert.LessOrEqual(t, active, service.Replicas+maxSurge, "%s: containers over Replicas+MaxSurge", when)
+	assert.GreaterOrEqual(t, serving, service.Replicas-maxUnavailable, "%s: serving under Replicas-MaxUnavailable", when)
+}
+
+// runRollingUpdate drives a deployment with a fake scheduler and workers
+// until it stops, checking the invariants after each of them acts
+func runRollingUpdate(t *testing.T, mgr *fakeManager, d *Deployer, deploymentID string, maxSurge, maxUnavailable int) {
+	t.Helper()
+	serviceID := mgr.deployments[deploymentID].ServiceID
+
+	for round := 1; round <= 50; round++ {
+		if mgr.deployments[deploymentID].Phase != types.DeploymentPhaseRunning {
+			return
+		}
+		require.NoError(t, d.Advance(deploymentID))
+		checkRollingInvariants(t, mgr, serviceID, maxSurge, maxUnavailable, fmt.Sprintf("round %d, deployer", round))
+
+		mgr.schedule(serviceID, types.ContainerStatePending)
+		checkRollingInvariants(t, mgr, serviceID, maxSurge, maxUnavailable, fmt.Sprintf("round %d, scheduler", round))
+
+		mgr.startPending(serviceID)
+		checkRollingInvariants(t, mgr, serviceID, maxSurge, maxUnavailable, fmt.Sprintf("round %d, workers", round))
+	}
+	t.Fatalf("deployment %s still running after 50 rounds", deploymentID)
+}
+
+// TestRollingUpdateInvariants tests that a rolling update never exceeds
+// MaxSurge or MaxUnavailable, and replaces every container
+func TestRollingUpdateInvariants(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         *types.UpdateConfig
+		image          string
+		maxSurge       int
+		maxUnavailable int
+	}{
+		{name: "defaults", image: "nginx:1.22", maxSurge: 1},
+		{name: "surge 2", config: &types.UpdateConfig{MaxSurge: 2, Parallelism: 2}, image: "nginx:1.22", maxSurge: 2},
+		{name: "unavailable 1", config: &types.UpdateConfig{MaxUnavailable: 1}, image: "nginx:1.22", maxUnavailable: 1},
+		{name: "surge and unavailable", config: &types.UpdateConfig{MaxSurge: 1, MaxUnavailable: 2, Parallelism: 3}, image: "nginx:1.22", maxSurge: 1, maxUnavailable: 2},
+		{name: "parallelism over limits", config: &types.UpdateConfig{MaxSurge: 1, Parallelism: 5}, image: "nginx:1.22", maxSurge: 1},
+		{name: "same image", image: "nginx:1.21", maxSurge: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := newFakeManager()
+			mgr.addService("svc-1", 5, "nginx:1.21", tt.config)
+			for _, container := range mgr.containers {
+				container.CreatedAt = time.Now().Add(-time.Hour)
+			}
+			d := NewDeployer(mgr)
+
+			deployment, err := d.UpdateService("svc-1", tt.image, types.DeployStrategyRolling)
+			require.NoError(t, err)
+			runRollingUpdate(t, mgr, d, deployment.ID, tt.maxSurge, tt.maxUnavailable)
+
+			assert.Equal(t, types.DeploymentPhaseCompleted, mgr.deployments[deployment.ID].Phase)
+			assert.Equal(t, 0, mgr.services["svc-1"].SurgeReplicas)
+
+			running := 0
+			for _, container := range mgr.containers {
+				if container.DesiredState == types.ContainerStateRunning {
+					running++
+					assert.Equal(t, tt.image, container.Image)
+					assert.True(t, container.CreatedAt.After(deployment.CreatedAt), "container %s was not replaced", container.ID)
+				}
+			}
+			assert.Equal(t, 5, running)
+		})
+	}
+}
+
+// TestRollingUpdateFailureActions tests that a failed new container pauses,
+// rolls back or continues the update as configured
+func TestRollingUpdateFailureActions(t *testing.T) {
+	tests := []struct {
+		action string
+		phase  types.DeploymentPhase
+	}{
+		{action: "", phase: types.DeploymentPhaseFailed},
+		{action: types.FailureActionRollback, phase: types.DeploymentPhaseFailed},
+		{action: types.FailureActionPause, phase: types.DeploymentPhasePaused},
+		{action: types.FailureActionContinue, phase: types.DeploymentPhaseRunning},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("action %q", tt.action), func(t *testing.T) {
+			mgr := newFakeManager()
+			mgr.addService("svc-1", 3, "nginx:1.21", &types.UpdateConfig{FailureAction: tt.action})
+			d := NewDeployer(mgr)
+
+			deployment, err := d.UpdateService("svc-1", "nginx:1.22", types.DeployStrategyRolling)
+			require.NoError(t, err)
+			require.NoError(t, d.Advance(deployment.ID))
+			mgr.schedule("svc-1", types.ContainerStatePending)
+
+			// The surged container crashes
+			for _, container := range mgr.containers {
+				if container.ActualState == types.ContainerStatePending {
+					container.ActualState = types.ContainerStateFailed
+					container.Error = "exit code 1"
+				}
+			}
+			require.NoError(t, d.Advance(deployment.ID))
+			checkRollingInvariants(t, mgr, "svc-1", 1, 0, "after failure")
+
+			failed := mgr.deployments[deployment.ID]
+			assert.Equal(t, tt.phase, failed.Phase)
+			assert.Equal(t, 1, failed.Failures)
+
+			switch tt.phase {
+			case types.DeploymentPhaseFailed:
+				assert.Contains(t, failed.Message, "exit code 1")
+				assert.Equal(t, "nginx:1.21", mgr.services["svc-1"].Image)
+				assert.Equal(t, 0, mgr.services["svc-1"].SurgeReplicas)
+				for _, container := range mgr.containers {
+					if container.Image == "nginx:1.21" {
+						assert.Equal(t, types.ContainerStateRunning, container.DesiredState, "old container %s stopped", container.ID)
+					}
+				}
+				return
+			case types.DeploymentPhasePaused:
+				assert.Contains(t, failed.Message, "exit code 1")
+
+				// A paused update holds until resumed
+				require.NoError(t, d.Advance(deployment.ID))
+				assert.Equal(t, types.DeploymentPhasePaused, mgr.deployments[deployment.ID].Phase)
+				resumed, err := d.Resume(deployment.ID)
+				require.NoError(t, err)
+				assert.Empty(t, resumed.Message)
+			}
+
+			// The same failure is not acted on twice
+			runRollingUpdate(t, mgr, d, deployment.ID, 1, 0)
+			assert.Equal(t, types.DeploymentPhaseCompleted, mgr.deployments[deployment.ID].Phase)
+			assert.Equal(t, 1, mgr.deployments[deployment.ID].Failures)
+		})
+	}
+
+	// Continuing with a new version that never serves replaces the old
+	// containers anyway, even with MaxUnavailable 0, and ends
+	t.Run("action continue, never healthy", func(t *testing.T) {
+		mgr := newFakeManager()
+		mgr.addService("svc-1", 3, "nginx:1.21", &types.UpdateConfig{FailureAction: types.FailureActionContinue})
+		mgr.services["svc-1"].HealthCheck = &types.HealthCheck{Type: types.HealthCheckHTTP}
+		d := NewDeployer(mgr)
+
+		deployment, err := d.UpdateService("svc-1", "nginx:1.22", types.DeployStrategyRolling)
+		require.NoError(t, err)
+		for round := 1; round <= 20 && mgr.deployments[deployment.ID].Phase == types.DeploymentPhaseRunning; round++ {
+			require.NoError(t, d.Advance(deployment.ID))
+			mgr.schedule("svc-1", types.ContainerStateRunning)
+			mgr.backdate(deployment.ID, defaultHealthCheckGracePeriod+healthCheckTimeout+time.Second)
+		}
+
+		finished := mgr.deployments[deployment.ID]
+		require.Equal(t, types.DeploymentPhaseCompleted, finished.Phase)
+		assert.Contains(t, finished.Message, "did not become healthy")
+		assert.Equal(t, 0, mgr.services["svc-1"].SurgeReplicas)
+		for _, container := range mgr.containers {
+			if container.DesiredState == types.ContainerStateRunning {
+				assert.Equal(t, "nginx:1.22", container.Image, "old container %s left running", container.ID)
+			}
+		}
+	})
+}
+
+// TestRollingUpdateEnvChangeFails tests that a failed update that only
+// changed env restores the old env and replaces the containers it started
+func TestRollingUpdateEnvChangeFails(t *testing.T) {
+	mgr := newFakeManager()
+	mgr.addService("svc-1", 3, "nginx:1.21", nil)
+	mgr.services["svc-1"].Env = []string{"POOL_SIZE=10"}
+	d := NewDeployer(mgr)
+
+	deployment, err := d.UpdateServiceSpec("svc-1", "nginx:1.21", types.DeployStrategyRolling, func(service *types.Service) error {
+		service.Env = []string{"POOL_SIZE=0"}
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, d.Advance(deployment.ID))
+	mgr.schedule("svc-1", types.ContainerStateRunning)
+	require.NoError(t, d.Advance(deployment.ID))
+	mgr.schedule("svc-1", types.ContainerStatePending)
+
+	// A container started with the new env crashes
+	var crashed *types.Container
+	for _, container := range mgr.containers {
+		if container.ActualState == types.ContainerStatePending {
+			container.ActualState = types.ContainerStateFailed
+			container.Error = "exit code 1"
+			crashed = container
+		}
+	}
+	require.NotNil(t, crashed)
+	require.NoError(t, d.Advance(deployment.ID))
+
+	failed := mgr.deployments[deployment.ID]
+	assert.Equal(t, types.DeploymentPhaseFailed, failed.Phase)
+	assert.Equal(t, []string{"POOL_SIZE=10"}, mgr.services["svc-1"].Env)
+	assert.Equal(t, 0, mgr.services["svc-1"].SurgeReplicas)
+	for _, container := range mgr.containers {
+		if container.CreatedAt.IsZero() {
+			continue
+		}
+		assert.Equal(t, types.ContainerStateShutdown, container.DesiredState, "new container %s kept", container.ID)
+	}
+
+	// Containers started after the rollback are kept by a repeated one
+	mgr.schedule("svc-1", types.ContainerStateRunning)
+	service, err := mgr.GetService("svc-1")
+	require.NoError(t, err)
+	require.NoError(t, d.rollback(failed, service, "test"))
+	running := 0
+	for _, container := range mgr.containers {
+		if container.DesiredState == types.ContainerStateRunning {
+			running++
+		}
+	}
+	assert.Equal(t, 3, running)
+}
+
+// TestRollingUpdateWaitsForHealthyContainers tests that old containers are
+// only stopped once new ones pass their health checks, and that the update
+// fails when they never do
+func TestRollingUpdateWaitsForHealthyContainers(t *testing.T) {
+	mgr := newFakeManager()
+	mgr.addService("svc-1", 2, "nginx:1.21", &types.UpdateConfig{HealthCheckGracePeriod: time.Minute})
+	mgr.services["svc-1"].HealthCheck = &types.HealthCheck{Type: types.HealthCheckHTTP}
+	d := NewDeployer(mgr)
+
+	deployment, err := d.UpdateService("svc-1", "nginx:1.22", types.DeployStrategyRolling)
+	require.NoError(t, err)
+	require.NoError(t, d.Advance(deployment.ID))
+	assert.Equal(t, 1, mgr.services["svc-1"].SurgeReplicas)
+	mgr.schedule("svc-1", types.ContainerStateRunning)
+
+	// Not yet checked, and within the grace period
+	require.NoError(t, d.Advance(deployment.ID))
+	assert.Equal(t, 0, mgr.shutdownCount("svc-1"))
+
+	// Healthy: an old container makes way
+	var surged *types.Container
+	for _, container := range mgr.containers {
+		if container.Image == "nginx:1.22" {
+			surged = container
+		}
+	}
+	require.NotNil(t, surged)
+	surged.HealthStatus = &types.HealthStatus{Healthy: true}
+	require.NoError(t, d.Advance(deployment.ID))
+	assert.Equal(t, 1, mgr.shutdownCount("svc-1"))
+
+	// The next replacement never passes its health check
+	mgr.schedule("svc-1", types.ContainerStateRunning)
+	for _, container := range mgr.containers {
+		if container.Image == "nginx:1.22" && container.HealthStatus == nil {
+			container.HealthStatus = &types.HealthStatus{Healthy: false, Message: "connection refused"}
+		}
+	}
+	require.NoError(t, d.Advance(deployment.ID))
+	assert.Equal(t, 1, mgr.shutdownCount("svc-1"))
+	assert.Equal(t, types.DeploymentPhaseRunning, mgr.deployments[deployment.ID].Phase)
+
+	mgr.backdate(deployment.ID, time.Minute+healthCheckTimeout+time.Second)
+	require.NoError(t, d.Advance(deployment.ID))
+	assert.Equal(t, types.DeploymentPhaseFailed, mgr.deployments[deployment.ID].Phase)
+	assert.Equal(t, "nginx:1.21", mgr.services["svc-1"].Image)
+	assert.Equal(t, 0, mgr.services["svc-1"].SurgeReplicas)
+}
@@ -50,7 +50,16 @@ Warren's DNS system provides two layers of name resolution:
 
 ## Service Names
 
-Resolve to all healthy instances (round-robin):
+In VIP mode (the default), a service resolves to its virtual IP, which
+workers load balance across healthy replicas. Clients that cache the answer
+keep working as replicas come and go:
+
+	Query: nginx.warren
+	Response:
+	└── nginx.warren. 60 IN A 10.0.1.4
+
+In DNSRR mode (Config.Mode = ModeDNSRR), and for services without a VIP,
+a service resolves to all healthy instances (round-robin):
 
 	Query: nginx.warren
 	Response:
@@ -163,6 +172,7 @@ Short TTL (10 seconds) ensures clients get fresh IPs as instances scale.
 			"8.8.8.8:53",   // Google DNS
 			"1.1.1.1:53",   // Cloudflare DNS
 		},
+		Mode: dns.ModeVIP,             // Resolve services to their VIP
 	}
 
 	// Create and start server
@@ -13,11 +13,28 @@ import (
 	"github.com/miekg/dns"
 )
 
+// Mode selects what a service name resolves to
+type Mode string
+
+const (
+	// ModeVIP resolves a service to its virtual IP, which workers load
+	// balance across healthy replicas. Services without a VIP fall back to
+	// ModeDNSRR.
+	ModeVIP Mode = "vip"
+
+	// ModeDNSRR resolves a service to one A record per healthy replica
+	ModeDNSRR Mode = "dnsrr"
+)
+
+// vipTTL is the TTL of VIP answers; a VIP is stable for the service's lifetime
+const vipTTL = 60
+
 // Resolver handles DNS resolution for Warren services and instances
 type Resolver struct {
 	store    storage.Store
 	domain   string   // Search domain (e.g., "warren")
 	upstream []string // Upstream DNS servers for external queries
+	mode     Mode
 	rnd      *rand.Rand
 }
 
@@ -27,10 +44,16 @@ func NewResolver(store storage.Store, domain string, upstream []string) *Resolve
 		store:    store,
 		domain:   domain,
 		upstream: upstream,
+		mode:     ModeVIP,
 		rnd:      rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
 
+// SetMode sets what service names resolve to
+func (r *Resolver) SetMode(mode Mode) {
+	r.mode = mode
+}
+
 // Resolve resolves a DNS query name to DNS resource records
 func (r *Resolver) Resolve(queryName string) ([]dns.RR, error) {
 	// Normalize query name (remove trailing dot)
@@ -55,7 +78,8 @@ func (r *Resolver) Resolve(queryName string) ([]dns.RR, error) {
 	return nil, fmt.Errorf("query not resolvable by Warren DNS: %s", name)
 }
 
-// resolveService resolves a service name to A records for all healthy instances
+// resolveService resolves a service name to its VIP, or to A records for all
+// healthy instances in DNSRR mode
 // Supports:
 //   - nginx
 //   - nginx.warren
@@ -69,6 +93,20 @@ func (r *Resolver) resolveService(name string) ([]dns.RR, error) {
 		return nil, fmt.Errorf("service not found: %s", serviceName)
 	}
 
+	// A VIP stays valid as replicas come and go, so clients that cache the
+	// answer never hit a dead replica
+	if r.mode != ModeDNSRR && service.VIP != nil {
+		return []dns.RR{&dns.A{
+			Hdr: dns.RR_Header{
+				Name:   r.makeFQDN(name),
+				Rrtype: dns.TypeA,
+				Class:  dns.ClassINET,
+				Ttl:    vipTTL,
+			},
+			A: service.VIP,
+		}}, nil
+	}
+
 	// Get all containers for this service
 	containers, err := r.store.ListContainers()
 	if err != nil {
@@ -111,9 +111,23 @@ func (m *mockStore) ListTLSCertificatesByHost(host string) ([]*types.TLSCertific
 func (m *mockStore) GetTLSCertificatesByHost(host string) ([]*types.TLSCertificate, error) {
 	return nil, nil
 }
-func (m *mockStore) SaveCA(data []byte) error { return nil }
-func (m *mockStore) GetCA() ([]byte, error)   { return nil, nil }
-func (m *mockStore) Close() error             { return nil }
+func (m *mockStore) CreateJoinToken(t *types.JoinToken) error             { return nil }
+func (m *mockStore) GetJoinToken(id string) (*types.JoinToken, error)     { return nil, nil }
+func (m *mockStore) ListJoinTokens() ([]*types.JoinToken, error)          { return nil, nil }
+func (m *mockStore) DeleteJoinToken(id string) error                      { return nil }
+func (m *mockStore) SaveCA(data []byte) error                             { return nil }
+func (m *mockStore) GetCA() ([]byte, error)                               { return nil, nil }
+func (m *mockStore) Close() error                                         { return nil }
+func (m *mockStore) CreateDeployment(d *types.Deployment) error           { return nil }
+func (m *mockStore) GetDeployment(id string) (*types.Deployment, error)   { return nil, nil }
+func (m *mockStore) ListDeployments() ([]*types.Deployment, error)        { return nil, nil }
+func (m *mockStore) UpdateDeployment(d *types.Deployment) error           { return nil }
+func (m *mockStore) DeleteDeployment(id string) error                     { return nil }
+func (m *mockStore) CreateServiceRevision(r *types.ServiceRevision) error { return nil }
+func (m *mockStore) ListServiceRevisions(serviceID string) ([]*types.ServiceRevision, error) {
+	return nil, nil
+}
+func (m *mockStore) DeleteServiceRevision(id string) error { return nil }
 
 // TestResolverServiceResolutionWithMockStore tests service name resolution with mock data
 func TestResolverServiceResolutionWithMockStore(t *testing.T) {
@@ -205,6 +219,49 @@ func TestResolverServiceResolutionWithMockStore(t *testing.T) {
 	}
 }
 
+// TestResolverVIPMode tests that services with a VIP resolve to it unless
+// the resolver is in DNSRR mode
+func TestResolverVIPMode(t *testing.T) {
+	store := newMockStore()
+	r := NewResolver(store, "warren", []string{"8.8.8.8:53"})
+
+	store.services["svc-1"] = &types.Service{ID: "svc-1", Name: "web", VIP: net.ParseIP("10.0.1.2")}
+	store.services["svc-2"] = &types.Service{ID: "svc-2", Name: "legacy"}
+	for i, serviceID := range []string{"svc-1", "svc-1", "svc-2"} {
+		id := fmt.Sprintf("container-%d", i)
+		store.containers[id] = &types.Container{ID: id, ServiceID: serviceID, ActualState: types.ContainerStateRunning}
+	}
+
+	records, err := r.Resolve("web.warren")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("Resolve() got %d records, want 1", len(records))
+	}
+	if a, ok := records[0].(*dns.A); !ok || !a.A.Equal(net.ParseIP("10.0.1.2")) {
+		t.Errorf("Resolve() = %v, want the service VIP", records[0])
+	}
+
+	// A service without a VIP falls back to per-replica records
+	records, err = r.Resolve("legacy")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if len(records) != 1 || records[0].(*dns.A).A.Equal(net.ParseIP("10.0.1.2")) {
+		t.Errorf("Resolve() = %v, want the replica's record", records)
+	}
+
+	r.SetMode(ModeDNSRR)
+	records, err = r.Resolve("web")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if len(records) != 2 {
+		t.Errorf("Resolve() in DNSRR mode got %d records, want 2", len(records))
+	}
+}
+
 // TestResolverInstanceResolutionWithMockStore tests instance-specific resolution
 func TestResolverInstanceResolutionWithMockStore(t *testing.T) {
 	store := newMockStore()
@@ -37,6 +37,7 @@ type Config struct {
 	ListenAddr string   // Address to listen on (default: 127.0.0.11:53)
 	Domain     string   // Search domain (default: "warren")
 	Upstream   []string // Upstream DNS servers (default: [8.8.8.8:53])
+	Mode       Mode     // What service names resolve to (default: ModeVIP)
 }
 
 // NewServer creates a new DNS server
@@ -46,6 +47,7 @@ func NewServer(store storage.Store, config *Config) *Server {
 			ListenAddr: DefaultListenAddr,
 			Domain:     DefaultDomain,
 			Upstream:   []string{DefaultUpstream},
+			Mode:       ModeVIP,
 		}
 	}
 
@@ -58,6 +60,9 @@ func NewServer(store storage.Store, config *Config) *Server {
 	if len(config.Upstream) == 0 {
 		config.Upstream = []string{DefaultUpstream}
 	}
+	if config.Mode == "" {
+		config.Mode = ModeVIP
+	}
 
 	s := &Server{
 		store:      store,
@@ -67,6 +72,7 @@ func NewServer(store storage.Store, config *Config) *Server {
 
 	// Create resolver
 	s.resolver = NewResolver(store, config.Domain, config.Upstream)
+	s.resolver.SetMode(config.Mode)
 
 	return s
 }
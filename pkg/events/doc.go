@@ -47,6 +47,7 @@ channels:
 	│  │    - node.joined                            │          │
 	│  │    - node.left                              │          │
 	│  │    - node.down                              │          │
+	│  │    - node.updated                           │          │
 	│  │                                              │          │
 	│  │  Resource Events:                           │          │
 	│  │    - secret.created, secret.deleted         │          │
@@ -254,6 +255,11 @@ EventTaskCreated:
   - Metadata: task_id, service_id, node_id
   - Subscribers: Worker agents, metrics
 
+EventTaskStarted:
+  - Published when: Task reported running by its worker
+  - Metadata: container_id, service_id, service_name, node_id
+  - Subscribers: Ingress load balancer (endpoint cache)
+
 EventTaskFailed:
   - Published when: Task failed to start or crashed
   - Metadata: task_id, service_id, node_id, error
@@ -264,6 +270,17 @@ EventTaskCompleted:
   - Metadata: task_id, service_id, exit_code
   - Subscribers: Cleanup, metrics
 
+EventTaskHealthChanged:
+  - Published when: Task flips between healthy and unhealthy
+  - Metadata: container_id, service_id, service_name, node_id
+  - Subscribers: Ingress load balancer (endpoint cache)
+
+EventTaskPreempted:
+  - Published when: Scheduler stops a task to make room for a higher-priority service
+  - Metadata: container_id, service_id, service_name, node_id (the preempting
+    service is named in the message)
+  - Subscribers: Audit, alerting
+
 Node Events:
 
 EventNodeJoined:
@@ -281,6 +298,11 @@ EventNodeDown:
   - Metadata: node_id, last_seen
   - Subscribers: Reconciler (reschedule tasks), alerting
 
+EventNodeUpdated:
+  - Published when: A node's labels or taints change
+  - Metadata: node_id, node_role
+  - Subscribers: Scheduler (placement constraints and taints)
+
 Resource Events:
 
 EventSecretCreated:
@@ -438,24 +460,56 @@ Webhook Notifications (Future):
 
 Current Limitations:
   - In-memory only (no persistence)
-  - No event replay or history
+  - Replay limited to the bounded history buffer (DefaultHistorySize events)
   - No guaranteed delivery (best effort)
-  - No topic-based filtering (all events broadcast)
-  - No priority or ordering guarantees
+  - No topic-based subscriptions (all events broadcast)
+  - Event IDs are unique to a manager's run: a stream cannot resume on
+    another manager or after a restart
 
 Workarounds:
   - Persistence: Subscribe and write to database
-  - History: Store events in separate event store
   - Guaranteed delivery: Use separate message queue
-  - Filtering: Filter at subscriber side by event type
+  - Filtering: Use events.Filter at the subscriber side
 
 Future Enhancements:
   - Topic-based subscriptions
   - Event persistence (append-only log)
-  - Event replay from specific timestamp
   - Delivery acknowledgments
   - Event schema validation
 
+# History and Resume
+
+The broker keeps the most recent events in a ring buffer and assigns each
+event an ID when none is set: an epoch chosen when the broker is created,
+and a sequence number. A client that reconnects can resume from the last
+event it received:
+
+	sub, missed, err := broker.SubscribeFrom(lastID)
+	if errors.Is(err, events.ErrResumePointLost) {
+		// lastID was evicted, or is from another manager or an earlier run
+	}
+	defer broker.Unsubscribe(sub)
+
+	for _, event := range missed {
+		handle(event)
+	}
+	for event := range sub {
+		handle(event)
+	}
+
+SubscribeFrom registers the subscriber and snapshots the history atomically,
+so an event is never both replayed and delivered live. Resuming after an
+event that is no longer buffered fails with ErrResumePointLost instead of
+replaying the buffer, which may not follow on from lastID; StreamEvents
+reports it as codes.OutOfRange. The API server uses
+this to implement StreamEvents with type, service and node filters, which
+backs 'warren events --follow'.
+
+A subscriber that falls behind by more than its buffer misses events, and
+Overflowed reports it. StreamEvents then ends the stream before sending
+anything past the gap, and the client resumes from the last event it
+received.
+
 # Best Practices
 
 Do:
@@ -1,27 +1,44 @@
 package events
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
 	"sync"
 	"time"
 )
 
+// DefaultHistorySize is the number of recent events retained for resuming streams
+const DefaultHistorySize = 1000
+
+// ErrResumePointLost is returned when resuming after an event that is no
+// longer buffered: it was evicted, or published by another manager or
+// before a restart. The events since cannot be replayed.
+var ErrResumePointLost = errors.New("resume point lost")
+
 // EventType represents the type of event
 type EventType string
 
 const (
-	EventServiceCreated EventType = "service.created"
-	EventServiceUpdated EventType = "service.updated"
-	EventServiceDeleted EventType = "service.deleted"
-	EventTaskCreated    EventType = "task.created"
-	EventTaskFailed     EventType = "task.failed"
-	EventTaskCompleted  EventType = "task.completed"
-	EventNodeJoined     EventType = "node.joined"
-	EventNodeLeft       EventType = "node.left"
-	EventNodeDown       EventType = "node.down"
-	EventSecretCreated  EventType = "secret.created"
-	EventSecretDeleted  EventType = "secret.deleted"
-	EventVolumeCreated  EventType = "volume.created"
-	EventVolumeDeleted  EventType = "volume.deleted"
+	EventServiceCreated    EventType = "service.created"
+	EventServiceUpdated    EventType = "service.updated"
+	EventServiceDeleted    EventType = "service.deleted"
+	EventTaskCreated       EventType = "task.created"
+	EventTaskStarted       EventType = "task.started"
+	EventTaskFailed        EventType = "task.failed"
+	EventTaskCompleted     EventType = "task.completed"
+	EventTaskUpdated       EventType = "task.updated" // Desired state changed
+	EventTaskHealthChanged EventType = "task.health_changed"
+	EventTaskDeleted       EventType = "task.deleted"
+	EventTaskPreempted     EventType = "task.preempted" // Stopped for a higher-priority service
+	EventNodeJoined        EventType = "node.joined"
+	EventNodeLeft          EventType = "node.left"
+	EventNodeDown          EventType = "node.down"
+	EventNodeUpdated       EventType = "node.updated" // Labels or taints changed
+	EventSecretCreated     EventType = "secret.created"
+	EventSecretDeleted     EventType = "secret.deleted"
+	EventVolumeCreated     EventType = "volume.created"
+	EventVolumeDeleted     EventType = "volume.deleted"
 )
 
 // Event represents a cluster event
@@ -39,17 +56,38 @@ type Subscriber chan *Event
 // Broker manages event subscriptions and distribution
 type Broker struct {
 	subscribers map[Subscriber]bool
+	overflowed  map[Subscriber]bool // Subscribers that missed an event because their buffer was full
 	mu          sync.RWMutex
 	eventCh     chan *Event
 	stopCh      chan struct{}
+
+	// history is a bounded ring buffer of recently broadcast events,
+	// used to replay missed events when a client resumes a stream
+	history     []*Event
+	historyHead int // index of the oldest event
+	historyLen  int
+	lastSeq     uint64
+	epoch       string // Prefix of event IDs, unique to this broker
 }
 
 // NewBroker creates a new event broker
 func NewBroker() *Broker {
+	return NewBrokerWithHistory(DefaultHistorySize)
+}
+
+// NewBrokerWithHistory creates a new event broker that retains up to size
+// recent events for resuming streams
+func NewBrokerWithHistory(size int) *Broker {
+	if size < 1 {
+		size = 1
+	}
 	return &Broker{
 		subscribers: make(map[Subscriber]bool),
+		overflowed:  make(map[Subscriber]bool),
 		eventCh:     make(chan *Event, 100), // Buffer up to 100 events
 		stopCh:      make(chan struct{}),
+		history:     make([]*Event, size),
+		epoch:       strconv.FormatInt(time.Now().UnixNano(), 36),
 	}
 }
 
@@ -73,15 +111,104 @@ func (b *Broker) Subscribe() Subscriber {
 	return sub
 }
 
+// SubscribeFrom creates a new subscription and returns it together with the
+// buffered events published after lastID. Registration and the history
+// snapshot happen atomically, so no event is both replayed and delivered.
+// If lastID is empty, no history is returned. If lastID is no longer in the
+// buffer, ErrResumePointLost is returned and nothing is subscribed.
+func (b *Broker) SubscribeFrom(lastID string) (Subscriber, []*Event, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var backlog []*Event
+	if lastID != "" {
+		var err error
+		if backlog, err = b.historyAfter(lastID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	sub := make(Subscriber, 50) // Buffer per subscriber
+	b.subscribers[sub] = true
+	return sub, backlog, nil
+}
+
+// History returns the buffered events published after lastID, oldest first.
+// If lastID is empty, all buffered events are returned. If lastID is no
+// longer in the buffer, ErrResumePointLost is returned.
+func (b *Broker) History(lastID string) ([]*Event, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.historyAfter(lastID)
+}
+
+// historyAfter returns buffered events after lastID (caller must hold b.mu)
+func (b *Broker) historyAfter(lastID string) ([]*Event, error) {
+	start := 0
+	if lastID != "" {
+		start = -1
+		for i := b.historyLen - 1; i >= 0; i-- {
+			if b.historyAt(i).ID == lastID {
+				start = i + 1
+				break
+			}
+		}
+		if start < 0 {
+			return nil, fmt.Errorf("%w: event %s is no longer buffered", ErrResumePointLost, lastID)
+		}
+	}
+
+	result := make([]*Event, 0, b.historyLen-start)
+	for i := start; i < b.historyLen; i++ {
+		result = append(result, b.historyAt(i))
+	}
+	return result, nil
+}
+
+// historyAt returns the i-th oldest buffered event (caller must hold b.mu)
+func (b *Broker) historyAt(i int) *Event {
+	return b.history[(b.historyHead+i)%len(b.history)]
+}
+
+// record assigns an ID if needed and appends the event to the ring buffer
+// (caller must hold b.mu for writing). IDs are the broker's epoch and a
+// sequence number, so that an ID from before a restart is never mistaken
+// for a later event.
+func (b *Broker) record(event *Event) {
+	b.lastSeq++
+	if event.ID == "" {
+		event.ID = b.epoch + "-" + strconv.FormatUint(b.lastSeq, 10)
+	}
+
+	if b.historyLen < len(b.history) {
+		b.history[(b.historyHead+b.historyLen)%len(b.history)] = event
+		b.historyLen++
+		return
+	}
+	b.history[b.historyHead] = event
+	b.historyHead = (b.historyHead + 1) % len(b.history)
+}
+
 // Unsubscribe removes a subscription
 func (b *Broker) Unsubscribe(sub Subscriber) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
 	delete(b.subscribers, sub)
+	delete(b.overflowed, sub)
 	close(sub)
 }
 
+// Overflowed reports whether an event was dropped for a subscriber because
+// its buffer was full. Events received after the drop do not directly
+// follow the ones received before it; a stream that must not skip events
+// should end and resume from the last event it handled.
+func (b *Broker) Overflowed(sub Subscriber) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.overflowed[sub]
+}
+
 // Publish publishes an event to all subscribers
 func (b *Broker) Publish(event *Event) {
 	// Set timestamp if not set
@@ -107,14 +234,17 @@ func (b *Broker) run() {
 }
 
 func (b *Broker) broadcast(event *Event) {
-	b.mu.RLock()
-	defer b.mu.RUnlock()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.record(event)
 
 	for sub := range b.subscribers {
 		select {
 		case sub <- event:
 		default:
 			// Subscriber buffer full, skip
+			b.overflowed[sub] = true
 		}
 	}
 }
@@ -125,3 +255,42 @@ func (b *Broker) SubscriberCount() int {
 	defer b.mu.RUnlock()
 	return len(b.subscribers)
 }
+
+// Filter selects events by type, service and node. Empty fields match everything.
+type Filter struct {
+	Types     []EventType
+	ServiceID string // Matches the "service_id" or "service_name" metadata
+	NodeID    string // Matches the "node_id" metadata
+}
+
+// Matches reports whether the event passes the filter
+func (f *Filter) Matches(event *Event) bool {
+	if f == nil {
+		return true
+	}
+
+	if len(f.Types) > 0 {
+		found := false
+		for _, t := range f.Types {
+			if t == event.Type {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if f.ServiceID != "" &&
+		event.Metadata["service_id"] != f.ServiceID &&
+		event.Metadata["service_name"] != f.ServiceID {
+		return false
+	}
+
+	if f.NodeID != "" && event.Metadata["node_id"] != f.NodeID {
+		return false
+	}
+
+	return true
+}
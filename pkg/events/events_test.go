@@ -0,0 +1,189 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// waitForHistory waits until the broker has broadcast n events
+func waitForHistory(t *testing.T, b *Broker, n int) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		history, err := b.History("")
+		return err == nil && len(history) == n
+	}, time.Second, 5*time.Millisecond)
+}
+
+// eventID returns the ID the broker gives its n-th event
+func eventID(b *Broker, n int) string {
+	return fmt.Sprintf("%s-%d", b.epoch, n)
+}
+
+// TestBrokerAssignsSequentialIDs tests that events without an ID get increasing IDs
+func TestBrokerAssignsSequentialIDs(t *testing.T) {
+	b := NewBroker()
+	b.Start()
+	defer b.Stop()
+
+	for i := 0; i < 3; i++ {
+		b.Publish(&Event{Type: EventServiceCreated})
+	}
+	waitForHistory(t, b, 3)
+
+	history, err := b.History("")
+	require.NoError(t, err)
+	assert.Equal(t, eventID(b, 1), history[0].ID)
+	assert.Equal(t, eventID(b, 2), history[1].ID)
+	assert.Equal(t, eventID(b, 3), history[2].ID)
+
+	// A restarted broker does not reuse IDs
+	restarted := NewBroker()
+	assert.NotEqual(t, b.epoch, restarted.epoch)
+}
+
+// TestBrokerHistoryIsBounded tests that the ring buffer drops the oldest events
+func TestBrokerHistoryIsBounded(t *testing.T) {
+	b := NewBrokerWithHistory(3)
+	b.Start()
+	defer b.Stop()
+
+	sub := b.Subscribe()
+	for i := 0; i < 5; i++ {
+		b.Publish(&Event{Type: EventTaskCreated})
+	}
+	for i := 0; i < 5; i++ {
+		<-sub
+	}
+
+	history, err := b.History("")
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	assert.Equal(t, eventID(b, 3), history[0].ID)
+	assert.Equal(t, eventID(b, 5), history[2].ID)
+
+	// The evicted events cannot be resumed from
+	_, err = b.History(eventID(b, 1))
+	assert.True(t, errors.Is(err, ErrResumePointLost))
+}
+
+// TestSubscribeFromResumesAfterID tests replay of missed events on resume
+func TestSubscribeFromResumesAfterID(t *testing.T) {
+	b := NewBroker()
+	b.Start()
+	defer b.Stop()
+
+	for i := 0; i < 4; i++ {
+		b.Publish(&Event{Type: EventNodeJoined})
+	}
+	waitForHistory(t, b, 4)
+
+	tests := []struct {
+		name     string
+		lastID   string
+		expected []string
+	}{
+		{name: "empty ID replays nothing", lastID: "", expected: []string{}},
+		{name: "resume from middle", lastID: eventID(b, 2), expected: []string{eventID(b, 3), eventID(b, 4)}},
+		{name: "resume from latest", lastID: eventID(b, 4), expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, backlog, err := b.SubscribeFrom(tt.lastID)
+			require.NoError(t, err)
+			defer b.Unsubscribe(sub)
+
+			ids := []string{}
+			for _, e := range backlog {
+				ids = append(ids, e.ID)
+			}
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
+
+	// An ID from before a restart or from another manager is not resumed from
+	for _, lastID := range []string{"missing", "2", NewBroker().epoch + "-2"} {
+		sub, backlog, err := b.SubscribeFrom(lastID)
+		assert.True(t, errors.Is(err, ErrResumePointLost), lastID)
+		assert.Nil(t, sub)
+		assert.Nil(t, backlog)
+	}
+	assert.Equal(t, 0, b.SubscriberCount())
+
+	// New events are delivered live after the backlog
+	sub, _, err := b.SubscribeFrom(eventID(b, 4))
+	require.NoError(t, err)
+	defer b.Unsubscribe(sub)
+	b.Publish(&Event{Type: EventNodeLeft})
+
+	select {
+	case e := <-sub:
+		assert.Equal(t, eventID(b, 5), e.ID)
+		assert.Equal(t, EventNodeLeft, e.Type)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for live event")
+	}
+}
+
+// TestBrokerReportsOverflow tests that a subscriber that falls behind is
+// told it missed events, and that others are not
+func TestBrokerReportsOverflow(t *testing.T) {
+	b := NewBroker()
+	b.Start()
+	defer b.Stop()
+
+	slow := b.Subscribe()
+	defer b.Unsubscribe(slow)
+	fast := b.Subscribe()
+	defer b.Unsubscribe(fast)
+
+	for i := 0; i < cap(slow)+1; i++ {
+		b.Publish(&Event{Type: EventTaskUpdated})
+		<-fast
+	}
+	waitForHistory(t, b, cap(slow)+1)
+
+	assert.True(t, b.Overflowed(slow))
+	assert.False(t, b.Overflowed(fast))
+	assert.Len(t, slow, cap(slow))
+}
+
+// TestFilterMatches tests event filtering by type, service and node
+func TestFilterMatches(t *testing.T) {
+	event := &Event{
+		Type: EventTaskFailed,
+		Metadata: map[string]string{
+			"service_id":   "svc-1",
+			"service_name": "web",
+			"node_id":      "worker-1",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		filter   *Filter
+		expected bool
+	}{
+		{name: "nil filter", filter: nil, expected: true},
+		{name: "empty filter", filter: &Filter{}, expected: true},
+		{name: "matching type", filter: &Filter{Types: []EventType{EventTaskCreated, EventTaskFailed}}, expected: true},
+		{name: "other type", filter: &Filter{Types: []EventType{EventServiceCreated}}, expected: false},
+		{name: "service by ID", filter: &Filter{ServiceID: "svc-1"}, expected: true},
+		{name: "service by name", filter: &Filter{ServiceID: "web"}, expected: true},
+		{name: "other service", filter: &Filter{ServiceID: "api"}, expected: false},
+		{name: "matching node", filter: &Filter{NodeID: "worker-1"}, expected: true},
+		{name: "other node", filter: &Filter{NodeID: "worker-2"}, expected: false},
+		{name: "all fields", filter: &Filter{Types: []EventType{EventTaskFailed}, ServiceID: "web", NodeID: "worker-1"}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.filter.Matches(event))
+		})
+	}
+}
@@ -86,6 +86,13 @@ Exec checks run commands inside the container and check exit codes:
 	├── Exit code 0 → Healthy
 	└── Exit code != 0 → Unhealthy
 
+The command runs inside the container as an extra process of its containerd
+task (same user, environment and working directory), through the Executor
+interface implemented by runtime.ContainerdRuntime. Output is captured for
+the result message and the process is killed when the timeout expires. A
+checker with a container but no executor reports unhealthy rather than
+passing without running the command.
+
 Use cases:
   - Database-specific checks (pg_isready, mysqladmin ping)
   - Custom health scripts
@@ -200,6 +207,7 @@ Health checks are configured per service:
 	})
 	checker.WithTimeout(5 * time.Second)
 	checker.WithContainer("container-abc123")  // Run in this container
+	checker.WithExecutor(containerdRuntime)    // Exec backend (containerd task Exec)
 
 	// Check database
 	result := checker.Check(ctx)
@@ -3,11 +3,22 @@ package health
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os/exec"
 	"time"
 )
 
+// maxExecOutput is how much command output is kept in a result message
+const maxExecOutput = 100
+
+// Executor runs a command inside a container and returns its exit code.
+// The container runtime implements it (see runtime.ContainerdRuntime.Exec).
+type Executor interface {
+	Exec(ctx context.Context, containerID string, command []string, stdout, stderr io.Writer) (int, error)
+}
+
 // ExecChecker performs exec-based health checks by running a command
 type ExecChecker struct {
 	// Command is the command to execute (e.g., ["pg_isready", "-U", "postgres"])
@@ -19,6 +30,9 @@ type ExecChecker struct {
 	// ContainerID is the ID of the container to exec into
 	// If empty, runs on host (useful for testing)
 	ContainerID string
+
+	// Executor runs the command inside ContainerID
+	Executor Executor
 }
 
 // NewExecChecker creates a new exec health checker
@@ -46,33 +60,40 @@ func (e *ExecChecker) Check(ctx context.Context) Result {
 	execCtx, cancel := context.WithTimeout(ctx, e.Timeout)
 	defer cancel()
 
-	// Build command
-	var cmd *exec.Cmd
+	// Run command and capture output
+	var stdout, stderr bytes.Buffer
+	var exitCode int
+	var err error
 	if e.ContainerID != "" {
-		// Execute in container using containerd/docker exec
-		// For now, we'll use a placeholder - this will be updated when
-		// we integrate with containerd runtime
-		cmd = exec.CommandContext(execCtx, "echo", "exec-in-container-not-yet-implemented")
+		exitCode, err = e.execInContainer(execCtx, &stdout, &stderr)
 	} else {
 		// Execute on host (for testing)
-		cmd = exec.CommandContext(execCtx, e.Command[0], e.Command[1:]...)
+		cmd := exec.CommandContext(execCtx, e.Command[0], e.Command[1:]...)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		err = cmd.Run()
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitCode, err = exitErr.ExitCode(), nil
+		}
 	}
 
-	// Capture output
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	// Execute command
-	err := cmd.Run()
-
 	// Build result message
 	message := fmt.Sprintf("Command: %v", e.Command)
-	if err != nil {
+	if err == nil && execCtx.Err() == context.DeadlineExceeded {
+		err = fmt.Errorf("timed out after %v", e.Timeout)
+	}
+	if err != nil || exitCode != 0 {
 		// Command failed
-		message = fmt.Sprintf("%s, Error: %v", message, err)
+		if err != nil {
+			message = fmt.Sprintf("%s, Error: %v", message, err)
+		} else {
+			message = fmt.Sprintf("%s, Exit code: %d", message, exitCode)
+		}
 		if stderr.Len() > 0 {
-			message = fmt.Sprintf("%s, Stderr: %s", message, stderr.String())
+			message = fmt.Sprintf("%s, Stderr: %s", message, truncateOutput(stderr.String()))
+		} else if stdout.Len() > 0 {
+			message = fmt.Sprintf("%s, Output: %s", message, truncateOutput(stdout.String()))
 		}
 
 		return Result{
@@ -86,11 +107,7 @@ func (e *ExecChecker) Check(ctx context.Context) Result {
 	// Command succeeded (exit code 0)
 	if stdout.Len() > 0 {
 		// Include output in message (truncated if too long)
-		output := stdout.String()
-		if len(output) > 100 {
-			output = output[:100] + "..."
-		}
-		message = fmt.Sprintf("%s, Output: %s", message, output)
+		message = fmt.Sprintf("%s, Output: %s", message, truncateOutput(stdout.String()))
 	}
 
 	return Result{
@@ -101,6 +118,22 @@ func (e *ExecChecker) Check(ctx context.Context) Result {
 	}
 }
 
+// execInContainer runs the command inside the container through the executor
+func (e *ExecChecker) execInContainer(ctx context.Context, stdout, stderr io.Writer) (int, error) {
+	if e.Executor == nil {
+		return -1, fmt.Errorf("no exec backend configured for container %s", e.ContainerID)
+	}
+	return e.Executor.Exec(ctx, e.ContainerID, e.Command, stdout, stderr)
+}
+
+// truncateOutput shortens command output for result messages
+func truncateOutput(output string) string {
+	if len(output) > maxExecOutput {
+		return output[:maxExecOutput] + "..."
+	}
+	return output
+}
+
 // Type returns the health check type
 func (e *ExecChecker) Type() CheckType {
 	return CheckTypeExec
@@ -117,3 +150,9 @@ func (e *ExecChecker) WithContainer(containerID string) *ExecChecker {
 	e.ContainerID = containerID
 	return e
 }
+
+// WithExecutor sets the backend used to exec into the container
+func (e *ExecChecker) WithExecutor(executor Executor) *ExecChecker {
+	e.Executor = executor
+	return e
+}
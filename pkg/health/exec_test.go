@@ -0,0 +1,113 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeExecutor records the exec call and returns a canned result
+type fakeExecutor struct {
+	exitCode    int
+	stdout      string
+	stderr      string
+	err         error
+	block       bool
+	containerID string
+	command     []string
+}
+
+func (f *fakeExecutor) Exec(ctx context.Context, containerID string, command []string, stdout, stderr io.Writer) (int, error) {
+	f.containerID = containerID
+	f.command = command
+	if f.block {
+		<-ctx.Done()
+		return -1, ctx.Err()
+	}
+	_, _ = io.WriteString(stdout, f.stdout)
+	_, _ = io.WriteString(stderr, f.stderr)
+	return f.exitCode, f.err
+}
+
+func TestExecChecker_InContainer(t *testing.T) {
+	tests := []struct {
+		name            string
+		executor        *fakeExecutor
+		expectedHealthy bool
+		messageContains string
+	}{
+		{
+			name:            "exit code 0 is healthy",
+			executor:        &fakeExecutor{stdout: "accepting connections"},
+			expectedHealthy: true,
+			messageContains: "accepting connections",
+		},
+		{
+			name:            "non-zero exit code is unhealthy",
+			executor:        &fakeExecutor{exitCode: 2, stderr: "no response"},
+			expectedHealthy: false,
+			messageContains: "Exit code: 2, Stderr: no response",
+		},
+		{
+			name:            "exec error is unhealthy",
+			executor:        &fakeExecutor{err: fmt.Errorf("task not running")},
+			expectedHealthy: false,
+			messageContains: "task not running",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewExecChecker([]string{"pg_isready", "-U", "postgres"}).
+				WithContainer("container-1").
+				WithExecutor(tt.executor)
+
+			result := checker.Check(context.Background())
+
+			assert.Equal(t, tt.expectedHealthy, result.Healthy)
+			assert.Contains(t, result.Message, tt.messageContains)
+			assert.Equal(t, "container-1", tt.executor.containerID)
+			assert.Equal(t, []string{"pg_isready", "-U", "postgres"}, tt.executor.command)
+		})
+	}
+}
+
+func TestExecChecker_Timeout(t *testing.T) {
+	checker := NewExecChecker([]string{"sleep", "60"}).
+		WithContainer("container-1").
+		WithExecutor(&fakeExecutor{block: true}).
+		WithTimeout(50 * time.Millisecond)
+
+	result := checker.Check(context.Background())
+
+	assert.False(t, result.Healthy)
+	assert.Contains(t, result.Message, "deadline exceeded")
+	assert.Less(t, result.Duration, time.Second)
+}
+
+func TestExecChecker_NoExecutor(t *testing.T) {
+	checker := NewExecChecker([]string{"true"}).WithContainer("container-1")
+
+	result := checker.Check(context.Background())
+
+	assert.False(t, result.Healthy, "exec checks must not pass without running the command")
+	assert.Contains(t, result.Message, "no exec backend")
+}
+
+func TestExecChecker_Host(t *testing.T) {
+	healthy := NewExecChecker([]string{"sh", "-c", "echo ok"}).Check(context.Background())
+	assert.True(t, healthy.Healthy)
+	assert.Contains(t, healthy.Message, "Output: ok")
+
+	unhealthy := NewExecChecker([]string{"sh", "-c", "exit 3"}).Check(context.Background())
+	assert.False(t, unhealthy.Healthy)
+	assert.Contains(t, unhealthy.Message, "Exit code: 3")
+}
+
+func TestExecChecker_Type(t *testing.T) {
+	assert.Equal(t, CheckTypeExec, NewExecChecker([]string{"true"}).Type())
+}
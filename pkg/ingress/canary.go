@@ -0,0 +1,140 @@
+package ingress
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cuemby/warren/pkg/types"
+)
+
+// Requests can pin themselves to a service's canary, or keep off it, with
+// this header or cookie set to "always" or "never". The header wins.
+const (
+	CanaryHeader = "X-Warren-Canary"
+	CanaryCookie = "warren-canary"
+)
+
+// CanaryPin is how a request asked to be routed while a canary runs
+type CanaryPin string
+
+const (
+	CanaryPinNone   CanaryPin = ""       // Split by weight
+	CanaryPinAlways CanaryPin = "always" // Always the canary
+	CanaryPinNever  CanaryPin = "never"  // Always the stable service
+)
+
+// canarySplit is the canary taking part of a stable service's traffic
+type canarySplit struct {
+	canary     string // Canary service name, empty if none is running
+	weight     int    // Percent of requests the canary gets
+	resolvedAt time.Time
+}
+
+// RequestCanaryPin returns the pin a request carries in the canary header
+// or cookie. Unknown values are ignored.
+func RequestCanaryPin(r *http.Request) CanaryPin {
+	value := r.Header.Get(CanaryHeader)
+	if value == "" {
+		if cookie, err := r.Cookie(CanaryCookie); err == nil {
+			value = cookie.Value
+		}
+	}
+
+	switch pin := CanaryPin(strings.ToLower(value)); pin {
+	case CanaryPinAlways, CanaryPinNever:
+		return pin
+	}
+	return CanaryPinNone
+}
+
+// Target returns the service that should serve a request for serviceName.
+// While a canary of the service runs, it gets UpdateConfig.CanaryWeight
+// percent of the requests, spread evenly, unless the request is pinned.
+// Without a canary, the service serves everything.
+func (lb *LoadBalancer) Target(serviceName string, pin CanaryPin) string {
+	split := lb.getSplit(serviceName)
+	if split.canary == "" {
+		return serviceName
+	}
+
+	switch pin {
+	case CanaryPinAlways:
+		return split.canary
+	case CanaryPinNever:
+		return serviceName
+	}
+
+	lb.mu.Lock()
+	count := lb.canaryCounts[serviceName]
+	lb.canaryCounts[serviceName] = count + 1
+	lb.mu.Unlock()
+
+	if takesCanary(count, split.weight) {
+		return split.canary
+	}
+	return serviceName
+}
+
+// takesCanary reports whether the count-th request goes to the canary, so
+// that exactly weight of every 100 consecutive requests do
+func takesCanary(count uint64, weight int) bool {
+	if weight <= 0 {
+		return false
+	}
+	if weight >= 100 {
+		return true
+	}
+	w := uint64(weight)
+	return (count+1)*w/100 > count*w/100
+}
+
+// getSplit returns the cached canary split of a service, resolving it from
+// cluster state when the cache is empty or stale
+func (lb *LoadBalancer) getSplit(serviceName string) *canarySplit {
+	lb.mu.Lock()
+	split, ok := lb.splits[serviceName]
+	lb.mu.Unlock()
+
+	if ok && time.Since(split.resolvedAt) < endpointCacheTTL {
+		return split
+	}
+
+	split = lb.resolveSplit(serviceName)
+	lb.mu.Lock()
+	lb.splits[serviceName] = split
+	lb.mu.Unlock()
+	return split
+}
+
+// resolveSplit finds the canary the deployer created for a service and the
+// weight it set on the stable service
+func (lb *LoadBalancer) resolveSplit(serviceName string) *canarySplit {
+	split := &canarySplit{resolvedAt: time.Now()}
+
+	stable, err := lb.store.GetServiceByName(serviceName)
+	if err != nil || stable.UpdateConfig == nil || stable.UpdateConfig.CanaryWeight <= 0 {
+		return split
+	}
+
+	services, err := lb.store.ListServices()
+	if err != nil {
+		return split
+	}
+	for _, service := range services {
+		if service.Labels[types.LabelOriginalService] == stable.ID &&
+			service.Labels[types.LabelDeploymentState] == string(types.DeploymentStateCanary) {
+			split.canary = service.Name
+			split.weight = stable.UpdateConfig.CanaryWeight
+			break
+		}
+	}
+	return split
+}
+
+// invalidateSplits drops every cached canary split
+func (lb *LoadBalancer) invalidateSplits() {
+	lb.mu.Lock()
+	lb.splits = make(map[string]*canarySplit)
+	lb.mu.Unlock()
+}
@@ -0,0 +1,128 @@
+package ingress
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cuemby/warren/pkg/events"
+	"github.com/cuemby/warren/pkg/storage"
+	"github.com/cuemby/warren/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startCanary sets the canary weight of the test service and creates its canary
+func startCanary(t *testing.T, store storage.Store, weight int) {
+	t.Helper()
+
+	stable, err := store.GetService("svc-1")
+	require.NoError(t, err)
+	stable.UpdateConfig = &types.UpdateConfig{CanaryWeight: weight}
+	require.NoError(t, store.UpdateService(stable))
+
+	require.NoError(t, store.CreateService(&types.Service{
+		ID: "svc-2", Name: "web-abc123", Replicas: 1,
+		Labels: map[string]string{
+			types.LabelOriginalService: "svc-1",
+			types.LabelDeploymentState: string(types.DeploymentStateCanary),
+		},
+	}))
+}
+
+// countTargets routes n unpinned requests and counts them per service
+func countTargets(lb *LoadBalancer, n int) map[string]int {
+	counts := make(map[string]int)
+	for i := 0; i < n; i++ {
+		counts[lb.Target("web", CanaryPinNone)]++
+	}
+	return counts
+}
+
+// TestTakesCanary tests that a weight sends exactly that many of every 100 requests to the canary
+func TestTakesCanary(t *testing.T) {
+	for _, weight := range []int{0, 1, 10, 25, 50, 99, 100} {
+		taken := 0
+		for count := uint64(0); count < 100; count++ {
+			if takesCanary(count, weight) {
+				taken++
+			}
+		}
+		assert.Equal(t, weight, taken, "weight %d", weight)
+	}
+}
+
+// TestRequestCanaryPin tests reading the pin from the header and cookie
+func TestRequestCanaryPin(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		cookie   string
+		expected CanaryPin
+	}{
+		{name: "none", expected: CanaryPinNone},
+		{name: "header", header: "always", expected: CanaryPinAlways},
+		{name: "header is case-insensitive", header: "Never", expected: CanaryPinNever},
+		{name: "cookie", cookie: "always", expected: CanaryPinAlways},
+		{name: "header wins over cookie", header: "never", cookie: "always", expected: CanaryPinNever},
+		{name: "unknown value", header: "sometimes", expected: CanaryPinNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				r.Header.Set(CanaryHeader, tt.header)
+			}
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: CanaryCookie, Value: tt.cookie})
+			}
+			assert.Equal(t, tt.expected, RequestCanaryPin(r))
+		})
+	}
+}
+
+// TestTargetSplitsByWeight tests that requests are split between stable and canary by weight
+func TestTargetSplitsByWeight(t *testing.T) {
+	store := newTestStore(t)
+	lb := NewLoadBalancer(store)
+
+	// Without a canary every request goes to the stable service
+	assert.Equal(t, map[string]int{"web": 100}, countTargets(lb, 100))
+
+	startCanary(t, store, 25)
+	lb.handleEvent(&events.Event{Type: events.EventServiceUpdated})
+	assert.Equal(t, map[string]int{"web": 75, "web-abc123": 25}, countTargets(lb, 100))
+
+	// Pinned requests ignore the weight
+	assert.Equal(t, "web-abc123", lb.Target("web", CanaryPinAlways))
+	assert.Equal(t, "web", lb.Target("web", CanaryPinNever))
+}
+
+// TestTargetFollowsWeightUpdates tests that weight changes take effect on service events
+func TestTargetFollowsWeightUpdates(t *testing.T) {
+	store := newTestStore(t)
+	startCanary(t, store, 10)
+	lb := NewLoadBalancer(store)
+	assert.Equal(t, 10, countTargets(lb, 100)["web-abc123"])
+
+	stable, err := store.GetService("svc-1")
+	require.NoError(t, err)
+	stable.UpdateConfig.CanaryWeight = 50
+	require.NoError(t, store.UpdateService(stable))
+
+	// The cached split holds until the service event arrives
+	assert.Equal(t, 10, countTargets(lb, 100)["web-abc123"])
+
+	lb.handleEvent(&events.Event{
+		Type:     events.EventServiceUpdated,
+		Metadata: map[string]string{"service_name": "web"},
+	})
+	assert.Equal(t, 50, countTargets(lb, 100)["web-abc123"])
+
+	// A weight of zero stops the split, even with pinned requests
+	stable.UpdateConfig.CanaryWeight = 0
+	require.NoError(t, store.UpdateService(stable))
+	lb.handleEvent(&events.Event{Type: events.EventServiceUpdated})
+	assert.Equal(t, "web", lb.Target("web", CanaryPinAlways))
+}
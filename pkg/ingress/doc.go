@@ -53,7 +53,7 @@ controller routes to "tasks".
 
 The Proxy is the main ingress server that coordinates all operations:
 
-	proxy := NewProxy(store, managerAddr, grpcClient)
+	proxy := NewProxy(store)
 	err := proxy.Start(ctx)  // Starts HTTP and HTTPS servers
 
 The proxy handles:
@@ -92,10 +92,37 @@ The LoadBalancer selects backend tasks using round-robin:
 	Request 3 → 192.168.1.10:8080 (wraps around)
 
 Health-aware selection:
-  - Only routes to healthy tasks
+  - Only routes to running, healthy tasks on nodes that are not down
   - Automatically excludes failed health checks
   - Updates as tasks come and go
 
+Backend resolution:
+  - Tasks are looked up from cluster state (service → containers → nodes)
+  - A port published in host mode is reached on the node address and host port
+  - Otherwise the container port is reached over the node's overlay IP
+  - A service without healthy tasks returns 503 (no localhost fallback)
+
+Endpoint cache:
+
+	The resolved endpoint set of each service is cached and invalidated by
+	task, service and node events from the manager's event broker:
+
+	proxy := NewProxy(store)
+	go proxy.WatchEvents(ctx, broker)
+
+Canary traffic splitting:
+  - While a canary runs, CanaryWeight percent of requests go to the canary
+  - Requests are spread evenly: weight 25 sends 25 of every 100 requests
+  - Requests fall back to the stable service if the canary has no healthy tasks
+  - Only ingress traffic is split; VIP and DNS traffic is not weighted
+
+Canary pinning:
+
+	For QA, the X-Warren-Canary header or warren-canary cookie pins a
+	request to the canary ("always") or the stable service ("never"):
+
+	curl -H "X-Warren-Canary: always" https://api.example.com/
+
 ## Middleware
 
 The Middleware applies request transformations and policies:
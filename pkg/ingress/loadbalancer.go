@@ -3,120 +3,319 @@ package ingress
 import (
 	"context"
 	"fmt"
+	"net"
+	"strconv"
 	"sync"
+	"time"
 
+	"github.com/cuemby/warren/pkg/events"
 	"github.com/cuemby/warren/pkg/log"
+	"github.com/cuemby/warren/pkg/network"
+	"github.com/cuemby/warren/pkg/storage"
 	"github.com/cuemby/warren/pkg/types"
-	"google.golang.org/grpc"
 )
 
+// endpointCacheTTL bounds how long a cached endpoint set is trusted without
+// an invalidating event. Events normally keep the cache fresh; the TTL only
+// protects against events dropped for a slow subscriber.
+const endpointCacheTTL = 30 * time.Second
+
 // LoadBalancer handles backend selection and load balancing
 type LoadBalancer struct {
-	managerAddr string
-	grpcClient  *grpc.ClientConn
+	store storage.Store
 
 	// Round-robin state
 	mu      sync.Mutex
 	indexes map[string]int // service name -> current index
+
+	// Resolved endpoints per service, invalidated by cluster events. Every
+	// invalidation bumps generation, so that endpoints resolved before it
+	// are not cached after it.
+	endpoints  map[string]*endpointSet // service name -> endpoints
+	generation uint64
+
+	// Canary of each stable service, and requests split so far
+	splits       map[string]*canarySplit // service name -> canary
+	canaryCounts map[string]uint64       // service name -> requests
 }
 
-// NewLoadBalancer creates a new load balancer
-func NewLoadBalancer(managerAddr string, grpcClient *grpc.ClientConn) *LoadBalancer {
+// NewLoadBalancer creates a new load balancer that resolves backends from
+// the cluster state in store
+func NewLoadBalancer(store storage.Store) *LoadBalancer {
 	return &LoadBalancer{
-		managerAddr: managerAddr,
-		grpcClient:  grpcClient,
-		indexes:     make(map[string]int),
+		store:        store,
+		indexes:      make(map[string]int),
+		endpoints:    make(map[string]*endpointSet),
+		splits:       make(map[string]*canarySplit),
+		canaryCounts: make(map[string]uint64),
 	}
 }
 
 // Backend represents a backend endpoint
 type Backend struct {
 	ServiceName string
+	NodeID      string
+	ContainerID string
 	IP          string
 	Port        int
 	Healthy     bool
 }
 
+// endpoint is a healthy replica of a service together with the node it runs on
+type endpoint struct {
+	containerID  string
+	nodeID       string
+	nodeAddress  string
+	overlayIP    net.IP
+	ports        []*types.PortMapping
+	vip          net.IP               // Service VIP, which sets the forward ports
+	servicePorts []*types.PortMapping // Service ports, in order
+}
+
+// endpointSet is the cached list of healthy endpoints for a service
+type endpointSet struct {
+	endpoints  []*endpoint
+	resolvedAt time.Time
+}
+
 // SelectBackend selects a backend for the given service
 // Returns the backend IP:port or error
 func (lb *LoadBalancer) SelectBackend(ctx context.Context, serviceName string, port int) (string, error) {
-	// Get all containers for the service
-	containers, err := lb.getServiceContainers(ctx, serviceName)
+	endpoints, err := lb.getEndpoints(serviceName)
 	if err != nil {
-		return "", fmt.Errorf("failed to get service containers: %w", err)
+		return "", err
 	}
 
-	// M7.1 MVP: If no containers found (because getServiceContainers returns empty list),
-	// use localhost fallback for basic testing
-	if len(containers) == 0 {
-		log.Debug(fmt.Sprintf("No containers found for service %s, using localhost fallback", serviceName))
-		return fmt.Sprintf("127.0.0.1:%d", port), nil
+	if len(endpoints) == 0 {
+		return "", fmt.Errorf("no healthy containers found for service: %s", serviceName)
 	}
 
-	// Filter healthy containers
-	healthyContainers := make([]*types.Container, 0)
-	for _, container := range containers {
-		// Only include running and healthy containers
-		if container.ActualState == "running" {
-			// If health check is configured, check health status
-			if container.HealthCheck != nil {
-				if container.HealthStatus != nil && container.HealthStatus.Healthy {
-					healthyContainers = append(healthyContainers, container)
-				} else if container.HealthStatus == nil {
-					// Health check configured but not yet checked, include container
-					healthyContainers = append(healthyContainers, container)
-				}
-			} else {
-				// No health check, consider running containers as healthy
-				healthyContainers = append(healthyContainers, container)
+	// Round-robin selection
+	lb.mu.Lock()
+	index := lb.indexes[serviceName] % len(endpoints)
+	lb.indexes[serviceName] = (index + 1) % len(endpoints)
+	lb.mu.Unlock()
+
+	return endpoints[index].address(port, "tcp"), nil
+}
+
+// Backends returns the current healthy backends of a service for the given
+// port and protocol
+func (lb *LoadBalancer) Backends(serviceName string, port int, protocol string) ([]*Backend, error) {
+	endpoints, err := lb.getEndpoints(serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	backends := make([]*Backend, 0, len(endpoints))
+	for _, ep := range endpoints {
+		host, portStr, err := net.SplitHostPort(ep.address(port, protocol))
+		if err != nil {
+			continue
+		}
+		backendPort, _ := strconv.Atoi(portStr)
+		backends = append(backends, &Backend{
+			ServiceName: serviceName,
+			NodeID:      ep.nodeID,
+			ContainerID: ep.containerID,
+			IP:          host,
+			Port:        backendPort,
+			Healthy:     true,
+		})
+	}
+	return backends, nil
+}
+
+// Invalidate drops the cached endpoints of a service
+func (lb *LoadBalancer) Invalidate(serviceName string) {
+	lb.mu.Lock()
+	delete(lb.endpoints, serviceName)
+	lb.generation++
+	lb.mu.Unlock()
+}
+
+// InvalidateAll drops every cached endpoint set and canary split
+func (lb *LoadBalancer) InvalidateAll() {
+	lb.mu.Lock()
+	lb.endpoints = make(map[string]*endpointSet)
+	lb.splits = make(map[string]*canarySplit)
+	lb.generation++
+	lb.mu.Unlock()
+}
+
+// WatchEvents invalidates cached endpoints as tasks, services and nodes
+// change. It blocks until ctx is cancelled.
+func (lb *LoadBalancer) WatchEvents(ctx context.Context, broker *events.Broker) {
+	sub := broker.Subscribe()
+	defer broker.Unsubscribe(sub)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-sub:
+			if !ok {
+				return
 			}
+			lb.handleEvent(event)
 		}
 	}
+}
 
-	if len(healthyContainers) == 0 {
-		return "", fmt.Errorf("no healthy containers found for service: %s", serviceName)
+// handleEvent invalidates the endpoints and canary splits affected by a
+// cluster event
+func (lb *LoadBalancer) handleEvent(event *events.Event) {
+	switch event.Type {
+	case events.EventServiceCreated, events.EventServiceUpdated, events.EventServiceDeleted:
+		// Canaries are separate services, and canary weights change with
+		// updates of the stable service
+		lb.invalidateSplits()
 	}
 
-	// Round-robin selection
+	switch event.Type {
+	case events.EventTaskCreated, events.EventTaskStarted, events.EventTaskUpdated,
+		events.EventTaskHealthChanged, events.EventTaskFailed, events.EventTaskCompleted,
+		events.EventTaskDeleted, events.EventServiceCreated, events.EventServiceUpdated,
+		events.EventServiceDeleted:
+		if name := event.Metadata["service_name"]; name != "" {
+			lb.Invalidate(name)
+			return
+		}
+		lb.InvalidateAll()
+	case events.EventNodeJoined, events.EventNodeLeft, events.EventNodeDown:
+		// Node addresses and availability affect every service
+		lb.InvalidateAll()
+	}
+}
+
+// getEndpoints returns the cached endpoints of a service, resolving them
+// from cluster state when the cache is empty or stale
+func (lb *LoadBalancer) getEndpoints(serviceName string) ([]*endpoint, error) {
 	lb.mu.Lock()
-	index := lb.indexes[serviceName] % len(healthyContainers)
-	lb.indexes[serviceName] = (index + 1) % len(healthyContainers)
+	set, ok := lb.endpoints[serviceName]
+	generation := lb.generation
 	lb.mu.Unlock()
 
-	selectedContainer := healthyContainers[index]
+	if ok && time.Since(set.resolvedAt) < endpointCacheTTL {
+		return set.endpoints, nil
+	}
 
-	// Get container IP from node
-	// For now, use the node address (this works for host network mode)
-	// TODO: In Phase 7.3, support overlay networking with container IPs
-	node, err := lb.getNode(ctx, selectedContainer.NodeID)
+	endpoints, err := lb.resolveEndpoints(serviceName)
 	if err != nil {
-		log.Warn(fmt.Sprintf("Failed to get node %s: %v, using localhost", selectedContainer.NodeID, err))
-		// Fallback to localhost for development
-		return fmt.Sprintf("127.0.0.1:%d", port), nil
+		return nil, err
 	}
+	lb.cacheEndpoints(serviceName, endpoints, generation)
 
-	// Return node IP with the service port
-	return fmt.Sprintf("%s:%d", node.Address, port), nil
+	return endpoints, nil
 }
 
-// getServiceContainers queries the manager API for service containers
-func (lb *LoadBalancer) getServiceContainers(ctx context.Context, serviceName string) ([]*types.Container, error) {
-	log.Debug(fmt.Sprintf("LoadBalancer: Getting containers for service %s", serviceName))
+// cacheEndpoints caches the endpoints of a service resolved at generation,
+// unless the cache was invalidated since
+func (lb *LoadBalancer) cacheEndpoints(serviceName string, endpoints []*endpoint, generation uint64) {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	if generation != lb.generation {
+		return
+	}
+	lb.endpoints[serviceName] = &endpointSet{endpoints: endpoints, resolvedAt: time.Now()}
+}
+
+// resolveEndpoints looks up the running, healthy replicas of a service and
+// the nodes they are placed on
+func (lb *LoadBalancer) resolveEndpoints(serviceName string) ([]*endpoint, error) {
+	log.Debug(fmt.Sprintf("LoadBalancer: Resolving endpoints for service %s", serviceName))
+
+	service, err := lb.store.GetServiceByName(serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("service not found: %s", serviceName)
+	}
+
+	containers, err := lb.store.ListContainersByService(service.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service containers: %w", err)
+	}
+
+	endpoints := make([]*endpoint, 0, len(containers))
+	nodes := make(map[string]*types.Node)
+	for _, container := range containers {
+		if !isServing(container) {
+			continue
+		}
+
+		node, ok := nodes[container.NodeID]
+		if !ok {
+			node, err = lb.store.GetNode(container.NodeID)
+			if err != nil {
+				log.Warn(fmt.Sprintf("Skipping container %s: failed to get node %s: %v", container.ID, container.NodeID, err))
+				continue
+			}
+			nodes[container.NodeID] = node
+		}
+		if node.Status == types.NodeStatusDown {
+			continue
+		}
+
+		ports := container.Ports
+		if len(ports) == 0 {
+			ports = service.Ports
+		}
 
-	// TODO (M7 Phase 7.2): Implement full gRPC query to manager
-	// For M7 Phase 7.1 MVP, return empty list to allow basic functionality
-	// The proxy will fall back to localhost:port for development testing
+		endpoints = append(endpoints, &endpoint{
+			containerID:  container.ID,
+			nodeID:       node.ID,
+			nodeAddress:  hostOnly(node.Address),
+			overlayIP:    node.OverlayIP,
+			ports:        ports,
+			vip:          service.VIP,
+			servicePorts: service.Ports,
+		})
+	}
 
-	return []*types.Container{}, nil
+	return endpoints, nil
+}
+
+// isServing reports whether a container should receive traffic
+func isServing(container *types.Container) bool {
+	if container.ActualState != types.ContainerStateRunning {
+		return false
+	}
+	if container.DesiredState != "" && container.DesiredState != types.ContainerStateRunning {
+		return false
+	}
+	// Health check configured but not yet checked: include container
+	if container.HealthCheck != nil && container.HealthStatus != nil {
+		return container.HealthStatus.Healthy
+	}
+	return true
 }
 
-// getNode queries the manager API for node information
-func (lb *LoadBalancer) getNode(ctx context.Context, nodeID string) (*types.Node, error) {
-	log.Debug(fmt.Sprintf("LoadBalancer: Getting node %s", nodeID))
+// address returns the host:port to dial for the requested service port.
+// A port published in host mode is reached on the node address; otherwise
+// the replica is reached on the node's overlay IP at the port's forward
+// port, which that node translates to its local replicas.
+func (ep *endpoint) address(port int, protocol string) string {
+	for _, pm := range ep.ports {
+		if pm.PublishMode != types.PublishModeHost || pm.HostPort == 0 {
+			continue
+		}
+		if pm.ContainerPort == port || pm.HostPort == port {
+			return net.JoinHostPort(ep.nodeAddress, strconv.Itoa(pm.HostPort))
+		}
+	}
 
-	// TODO (M7 Phase 7.2): Implement full gRPC query to manager
-	// For M7 Phase 7.1 MVP, return error to trigger localhost fallback
-	// This allows basic testing without full cluster integration
+	if ep.overlayIP != nil {
+		if forward := network.ForwardPort(ep.vip, ep.servicePorts, port, protocol); forward > 0 {
+			return net.JoinHostPort(ep.overlayIP.String(), strconv.Itoa(forward))
+		}
+	}
+
+	return net.JoinHostPort(ep.nodeAddress, strconv.Itoa(port))
+}
 
-	return nil, fmt.Errorf("using localhost fallback for M7 Phase 7.1")
+// hostOnly strips an optional port from a node address
+func hostOnly(address string) string {
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		return host
+	}
+	return address
 }
@@ -0,0 +1,183 @@
+package ingress
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/cuemby/warren/pkg/events"
+	"github.com/cuemby/warren/pkg/storage"
+	"github.com/cuemby/warren/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestStore creates a BoltDB store with one service and two worker nodes
+func newTestStore(t *testing.T) storage.Store {
+	t.Helper()
+
+	store, err := storage.NewBoltStore(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	require.NoError(t, store.CreateNode(&types.Node{
+		ID: "node-1", Role: types.NodeRoleWorker, Address: "10.0.0.1:8080", Status: types.NodeStatusReady,
+	}))
+	require.NoError(t, store.CreateNode(&types.Node{
+		ID: "node-2", Role: types.NodeRoleWorker, Address: "10.0.0.2", OverlayIP: net.ParseIP("10.1.0.2"), Status: types.NodeStatusReady,
+	}))
+	require.NoError(t, store.CreateService(&types.Service{
+		ID: "svc-1", Name: "web", Replicas: 2, VIP: net.ParseIP("10.0.1.2"),
+		Ports: []*types.PortMapping{{ContainerPort: 80, Protocol: "tcp"}},
+	}))
+
+	return store
+}
+
+func runningContainer(id, nodeID string) *types.Container {
+	return &types.Container{
+		ID:           id,
+		ServiceID:    "svc-1",
+		ServiceName:  "web",
+		NodeID:       nodeID,
+		DesiredState: types.ContainerStateRunning,
+		ActualState:  types.ContainerStateRunning,
+	}
+}
+
+// TestSelectBackendResolvesEndpoints tests address resolution for published ports and overlay IPs.
+// Replicas behind an overlay IP are reached at the forward port their node translates.
+func TestSelectBackendResolvesEndpoints(t *testing.T) {
+	tests := []struct {
+		name      string
+		container *types.Container
+		port      int
+		expected  string
+	}{
+		{
+			name: "host published port on node address",
+			container: func() *types.Container {
+				c := runningContainer("c1", "node-1")
+				c.Ports = []*types.PortMapping{{ContainerPort: 80, HostPort: 30080, PublishMode: types.PublishModeHost}}
+				return c
+			}(),
+			port:     80,
+			expected: "10.0.0.1:30080",
+		},
+		{
+			name:      "overlay IP with the port's forward port",
+			container: runningContainer("c2", "node-2"),
+			port:      80,
+			expected:  "10.1.0.2:63064",
+		},
+		{
+			name:      "node address without overlay",
+			container: runningContainer("c3", "node-1"),
+			port:      8080,
+			expected:  "10.0.0.1:8080",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+			require.NoError(t, store.CreateContainer(tt.container))
+
+			lb := NewLoadBalancer(store)
+			addr, err := lb.SelectBackend(context.Background(), "web", tt.port)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, addr)
+		})
+	}
+}
+
+// TestSelectBackendSkipsUnavailable tests that only serving containers on live nodes are selected
+func TestSelectBackendSkipsUnavailable(t *testing.T) {
+	store := newTestStore(t)
+
+	unhealthy := runningContainer("unhealthy", "node-1")
+	unhealthy.HealthCheck = &types.HealthCheck{}
+	unhealthy.HealthStatus = &types.HealthStatus{Healthy: false}
+
+	pending := runningContainer("pending", "node-1")
+	pending.ActualState = types.ContainerStatePending
+
+	stopping := runningContainer("stopping", "node-1")
+	stopping.DesiredState = types.ContainerStateShutdown
+
+	for _, c := range []*types.Container{unhealthy, pending, stopping, runningContainer("ok", "node-2")} {
+		require.NoError(t, store.CreateContainer(c))
+	}
+
+	lb := NewLoadBalancer(store)
+	for i := 0; i < 3; i++ {
+		addr, err := lb.SelectBackend(context.Background(), "web", 80)
+		require.NoError(t, err)
+		assert.Equal(t, "10.1.0.2:63064", addr)
+	}
+
+	_, err := lb.SelectBackend(context.Background(), "missing", 80)
+	assert.Error(t, err)
+}
+
+// TestSelectBackendNoReplicas tests that a service without replicas is an error, not a localhost fallback
+func TestSelectBackendNoReplicas(t *testing.T) {
+	lb := NewLoadBalancer(newTestStore(t))
+
+	_, err := lb.SelectBackend(context.Background(), "web", 80)
+	assert.Error(t, err)
+}
+
+// TestEndpointCacheInvalidation tests that cached endpoints are refreshed on task events
+func TestEndpointCacheInvalidation(t *testing.T) {
+	store := newTestStore(t)
+	require.NoError(t, store.CreateContainer(runningContainer("c1", "node-2")))
+
+	lb := NewLoadBalancer(store)
+	backends, err := lb.Backends("web", 80, "tcp")
+	require.NoError(t, err)
+	require.Len(t, backends, 1)
+
+	// A new replica is not visible until the cache is invalidated
+	require.NoError(t, store.CreateContainer(runningContainer("c2", "node-1")))
+	backends, err = lb.Backends("web", 80, "tcp")
+	require.NoError(t, err)
+	assert.Len(t, backends, 1)
+
+	lb.handleEvent(&events.Event{
+		Type:     events.EventTaskStarted,
+		Metadata: map[string]string{"service_name": "web"},
+	})
+	backends, err = lb.Backends("web", 80, "tcp")
+	require.NoError(t, err)
+	assert.Len(t, backends, 2)
+
+	// Node events invalidate every service
+	require.NoError(t, store.CreateContainer(runningContainer("c3", "node-1")))
+	lb.handleEvent(&events.Event{Type: events.EventNodeDown})
+	backends, err = lb.Backends("web", 80, "tcp")
+	require.NoError(t, err)
+	assert.Len(t, backends, 3)
+}
+
+// TestEndpointCacheDropsStaleWrites tests that endpoints resolved before an invalidation are not cached
+func TestEndpointCacheDropsStaleWrites(t *testing.T) {
+	store := newTestStore(t)
+	require.NoError(t, store.CreateContainer(runningContainer("c1", "node-2")))
+	lb := NewLoadBalancer(store)
+
+	// Resolved, then invalidated before the result is stored
+	lb.mu.Lock()
+	generation := lb.generation
+	lb.mu.Unlock()
+	stale, err := lb.resolveEndpoints("web")
+	require.NoError(t, err)
+
+	require.NoError(t, store.CreateContainer(runningContainer("c2", "node-1")))
+	lb.Invalidate("web")
+	lb.cacheEndpoints("web", stale, generation)
+
+	backends, err := lb.Backends("web", 80, "tcp")
+	require.NoError(t, err)
+	assert.Len(t, backends, 2)
+}
@@ -10,10 +10,11 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/cuemby/warren/pkg/events"
 	"github.com/cuemby/warren/pkg/log"
+	"github.com/cuemby/warren/pkg/metrics"
 	"github.com/cuemby/warren/pkg/storage"
 	"github.com/cuemby/warren/pkg/types"
-	"google.golang.org/grpc"
 )
 
 // Proxy is the main HTTP reverse proxy
@@ -21,21 +22,18 @@ type Proxy struct {
 	store        storage.Store
 	router       *Router
 	lb           *LoadBalancer
+	stats        *TrafficStats
 	middleware   *Middleware
 	httpServer   *http.Server
 	httpsServer  *http.Server
 	tlsConfig    *tls.Config
 	acmeProvider *HTTP01Provider
-	managerAddr  string
-	grpcClient   *grpc.ClientConn
 }
 
 // NewProxy creates a new ingress proxy
-func NewProxy(store storage.Store, managerAddr string, grpcClient *grpc.ClientConn) *Proxy {
+func NewProxy(store storage.Store) *Proxy {
 	p := &Proxy{
-		store:       store,
-		managerAddr: managerAddr,
-		grpcClient:  grpcClient,
+		store: store,
 	}
 
 	// Initialize router with current ingresses
@@ -46,7 +44,8 @@ func NewProxy(store storage.Store, managerAddr string, grpcClient *grpc.ClientCo
 	}
 
 	p.router = NewRouter(ingresses)
-	p.lb = NewLoadBalancer(managerAddr, grpcClient)
+	p.lb = NewLoadBalancer(store)
+	p.stats = NewTrafficStats()
 	p.acmeProvider = NewHTTP01Provider(p)
 	p.middleware = NewMiddleware()
 
@@ -194,8 +193,16 @@ func (p *Proxy) handleRequest(w http.ResponseWriter, r *http.Request) {
 	// Apply path rewriting
 	p.middleware.ApplyPathRewrite(r, ingressPath.Rewrite)
 
-	// Select backend instance via load balancer
-	backendAddr, err := p.lb.SelectBackend(r.Context(), backend.ServiceName, backend.Port)
+	// Send the request to the service's canary if it runs one and the
+	// request is pinned to it or falls in its share; a canary without
+	// healthy replicas leaves the traffic with the stable service
+	target := p.lb.Target(backend.ServiceName, RequestCanaryPin(r))
+	backendAddr, err := p.lb.SelectBackend(r.Context(), target, backend.Port)
+	if err != nil && target != backend.ServiceName {
+		log.Warn(fmt.Sprintf("Canary %s unavailable, using %s: %v", target, backend.ServiceName, err))
+		target = backend.ServiceName
+		backendAddr, err = p.lb.SelectBackend(r.Context(), target, backend.Port)
+	}
 	if err != nil {
 		log.Error(fmt.Sprintf("Failed to select backend: %v", err))
 		http.Error(w, "Service temporarily unavailable", http.StatusServiceUnavailable)
@@ -204,12 +211,34 @@ func (p *Proxy) handleRequest(w http.ResponseWriter, r *http.Request) {
 
 	log.Debug(fmt.Sprintf("Selected backend address: %s", backendAddr))
 
-	// Proxy the request
-	if err := p.proxyRequest(w, r, backendAddr); err != nil {
+	// Proxy the request, recording its outcome for the backend service
+	start := time.Now()
+	recorder := &statusRecorder{ResponseWriter: w}
+	if err := p.proxyRequest(recorder, r, backendAddr); err != nil {
 		log.Error(fmt.Sprintf("Proxy error: %v", err))
-		http.Error(w, "Bad gateway", http.StatusBadGateway)
-		return
+		http.Error(recorder, "Bad gateway", http.StatusBadGateway)
 	}
+	p.recordRequest(host, target, recorder.status, time.Since(start))
+}
+
+// recordRequest counts a proxied request in the traffic stats and metrics
+func (p *Proxy) recordRequest(host, serviceName string, status int, latency time.Duration) {
+	if status == 0 {
+		status = http.StatusOK
+	}
+	p.stats.Record(serviceName, status, latency)
+
+	metrics.IngressRequestsTotal.WithLabelValues(host, serviceName).Inc()
+	metrics.IngressRequestDuration.WithLabelValues(host, serviceName).Observe(latency.Seconds())
+	if status >= http.StatusInternalServerError {
+		metrics.IngressRequestErrorsTotal.WithLabelValues(host, serviceName).Inc()
+	}
+}
+
+// ServiceTraffic returns the requests, errors and p99 latency the proxy
+// served for a service since the given time
+func (p *Proxy) ServiceTraffic(serviceName string, since time.Time) types.TrafficSummary {
+	return p.stats.Summary(serviceName, since)
 }
 
 // proxyRequest proxies the request to the backend
@@ -247,6 +276,12 @@ func (p *Proxy) proxyRequest(w http.ResponseWriter, r *http.Request, backendAddr
 	return nil
 }
 
+// WatchEvents keeps the load balancer's endpoint cache in sync with cluster
+// events until ctx is cancelled
+func (p *Proxy) WatchEvents(ctx context.Context, broker *events.Broker) {
+	p.lb.WatchEvents(ctx, broker)
+}
+
 // ReloadIngresses reloads the ingress rules from storage
 func (p *Proxy) ReloadIngresses() error {
 	ingresses, err := p.store.ListIngresses()
@@ -0,0 +1,124 @@
+package ingress
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cuemby/warren/pkg/types"
+)
+
+// maxTrafficSamples bounds the requests remembered per service. Older
+// requests are dropped first, so summaries cover at most this many.
+const maxTrafficSamples = 10000
+
+// trafficSample is one request the proxy sent to a service
+type trafficSample struct {
+	at      time.Time
+	latency time.Duration
+	failed  bool
+}
+
+// TrafficStats keeps the recent requests of each backend service, so that
+// canary analysis can compare the error rate and latency of two services
+type TrafficStats struct {
+	mu      sync.Mutex
+	samples map[string][]trafficSample // service name -> ring of requests
+	next    map[string]int             // service name -> next ring slot
+}
+
+// NewTrafficStats creates empty traffic stats
+func NewTrafficStats() *TrafficStats {
+	return &TrafficStats{
+		samples: make(map[string][]trafficSample),
+		next:    make(map[string]int),
+	}
+}
+
+// Record adds a request answered with status after latency. Requests
+// answered with a 5xx status count as errors.
+func (s *TrafficStats) Record(serviceName string, status int, latency time.Duration) {
+	sample := trafficSample{
+		at:      time.Now(),
+		latency: latency,
+		failed:  status >= http.StatusInternalServerError,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	samples := s.samples[serviceName]
+	if len(samples) < maxTrafficSamples {
+		s.samples[serviceName] = append(samples, sample)
+		return
+	}
+	next := s.next[serviceName]
+	samples[next] = sample
+	s.next[serviceName] = (next + 1) % maxTrafficSamples
+}
+
+// Summary returns the requests, errors and p99 latency of a service since
+// the given time
+func (s *TrafficStats) Summary(serviceName string, since time.Time) types.TrafficSummary {
+	var summary types.TrafficSummary
+	var latencies []time.Duration
+
+	s.mu.Lock()
+	for _, sample := range s.samples[serviceName] {
+		if sample.at.Before(since) {
+			continue
+		}
+		summary.Requests++
+		if sample.failed {
+			summary.Errors++
+		}
+		latencies = append(latencies, sample.latency)
+	}
+	s.mu.Unlock()
+
+	summary.P99 = percentile(latencies, 99)
+	return summary
+}
+
+// percentile returns the p-th percentile of latencies, which it sorts
+func percentile(latencies []time.Duration, p int) time.Duration {
+	if len(latencies) == 0 {
+		return 0
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	index := (len(latencies)*p+99)/100 - 1
+	if index < 0 {
+		index = 0
+	}
+	return latencies[index]
+}
+
+// statusRecorder remembers the status code a handler answered with
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader records the status before writing it
+func (r *statusRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write records an implicit 200 status
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+// Flush lets streamed responses through the recorder
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
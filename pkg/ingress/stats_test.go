@@ -0,0 +1,51 @@
+package ingress
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTrafficStatsSummary tests request, error and p99 latency accounting per service
+func TestTrafficStatsSummary(t *testing.T) {
+	stats := NewTrafficStats()
+	start := time.Now()
+
+	for i := 1; i <= 100; i++ {
+		status := http.StatusOK
+		if i%10 == 0 {
+			status = http.StatusBadGateway
+		}
+		stats.Record("web", status, time.Duration(i)*time.Millisecond)
+	}
+	stats.Record("web-abc123", http.StatusNotFound, time.Second)
+
+	summary := stats.Summary("web", start)
+	assert.Equal(t, 100, summary.Requests)
+	assert.Equal(t, 10, summary.Errors)
+	assert.Equal(t, 99*time.Millisecond, summary.P99)
+
+	canary := stats.Summary("web-abc123", start)
+	assert.Equal(t, 1, canary.Requests)
+	assert.Equal(t, 0, canary.Errors, "4xx responses are not errors")
+
+	// Only requests since the given time count
+	assert.Zero(t, stats.Summary("web", time.Now().Add(time.Second)).Requests)
+}
+
+// TestTrafficStatsBounded tests that old requests are dropped past the sample limit
+func TestTrafficStatsBounded(t *testing.T) {
+	stats := NewTrafficStats()
+	for i := 0; i < maxTrafficSamples; i++ {
+		stats.Record("web", http.StatusInternalServerError, time.Millisecond)
+	}
+	for i := 0; i < maxTrafficSamples/2; i++ {
+		stats.Record("web", http.StatusOK, time.Millisecond)
+	}
+
+	summary := stats.Summary("web", time.Time{})
+	assert.Equal(t, maxTrafficSamples, summary.Requests)
+	assert.Equal(t, maxTrafficSamples/2, summary.Errors)
+}
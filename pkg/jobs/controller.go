@@ -0,0 +1,339 @@
+package jobs
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cuemby/warren/pkg/log"
+	"github.com/cuemby/warren/pkg/manager"
+	"github.com/cuemby/warren/pkg/types"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// syncInterval is how often jobs and cronjobs are checked
+const syncInterval = 5 * time.Second
+
+// Controller records the status of jobs and starts the jobs of cronjobs
+// when they are due. It runs on every manager but only acts on the leader.
+type Controller struct {
+	manager *manager.Manager
+	logger  zerolog.Logger
+	stopCh  chan struct{}
+}
+
+// NewController creates a new job controller
+func NewController(mgr *manager.Manager) *Controller {
+	return &Controller{
+		manager: mgr,
+		logger:  log.WithComponent("jobs"),
+		stopCh:  make(chan struct{}),
+	}
+}
+
+// Start begins the controller loop
+func (c *Controller) Start() {
+	go c.run()
+}
+
+// Stop stops the controller
+func (c *Controller) Stop() {
+	close(c.stopCh)
+}
+
+// run is the main controller loop
+func (c *Controller) run() {
+	ticker := time.NewTicker(syncInterval)
+	defer ticker.Stop()
+
+	c.logger.Info().Msg("Job controller started")
+
+	for {
+		select {
+		case <-ticker.C:
+			if !c.manager.IsLeader() {
+				continue
+			}
+			if err := c.sync(time.Now()); err != nil {
+				c.logger.Error().Err(err).Msg("Job sync failed")
+			}
+		case <-c.stopCh:
+			c.logger.Info().Msg("Job controller stopped")
+			return
+		}
+	}
+}
+
+// sync updates every job's status and runs every cronjob's schedule
+func (c *Controller) sync(now time.Time) error {
+	services, err := c.manager.ListServices()
+	if err != nil {
+		return fmt.Errorf("failed to list services: %w", err)
+	}
+	containers, err := c.manager.ListContainers()
+	if err != nil {
+		return fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	byService := make(map[string][]*types.Container)
+	for _, container := range containers {
+		byService[container.ServiceID] = append(byService[container.ServiceID], container)
+	}
+
+	for _, service := range services {
+		switch service.Mode {
+		case types.ServiceModeJob:
+			c.syncJob(service, byService[service.ID], now)
+		case types.ServiceModeCronJob:
+			c.syncCronJob(service, children(service, services), now)
+		}
+	}
+	return nil
+}
+
+// syncJob records a job's status and, once it has finished, stops the
+// containers it still runs
+func (c *Controller) syncJob(job *types.Service, containers []*types.Container, now time.Time) {
+	if Finished(job) {
+		return
+	}
+
+	status := Evaluate(job, containers, now)
+	if !statusChanged(job.JobStatus, status) {
+		return
+	}
+
+	job.JobStatus = status
+	if err := c.manager.UpdateService(job); err != nil {
+		c.logger.Error().Err(err).Str("job", job.Name).Msg("Failed to update job status")
+		return
+	}
+	if status.State == types.JobStateRunning {
+		return
+	}
+
+	event := c.logger.Info()
+	if status.State == types.JobStateFailed {
+		event = c.logger.Warn().Str("reason", status.Reason)
+	}
+	event.Str("job", job.Name).Int("succeeded", status.Succeeded).Int("failed", status.Failed).Msg("Job finished")
+	c.stopActive(containers)
+}
+
+// syncCronJob starts a cronjob's job when one is due and prunes the
+// finished jobs beyond its history limits
+func (c *Controller) syncCronJob(cron *types.Service, jobs []*types.Service, now time.Time) {
+	if cron.CronJob == nil {
+		return
+	}
+	schedule, err := ParseSchedule(cron.CronJob.Schedule)
+	if err != nil {
+		c.logger.Error().Err(err).Str("cronjob", cron.Name).Msg("Invalid cronjob schedule")
+		return
+	}
+
+	last := cron.LastScheduleTime
+	if last.IsZero() {
+		last = cron.CreatedAt
+	}
+	if scheduled := dueTime(schedule, last, now); !scheduled.IsZero() {
+		if job := c.startJob(cron, jobs, scheduled, now); job != nil {
+			jobs = append(jobs, job)
+		}
+		cron.LastScheduleTime = scheduled
+		if err := c.manager.UpdateService(cron); err != nil {
+			c.logger.Error().Err(err).Str("cronjob", cron.Name).Msg("Failed to record cronjob schedule time")
+		}
+	}
+
+	cfg := cron.CronJob
+	for _, job := range pruneHistory(jobs, historyLimit(cfg.SuccessfulJobsHistoryLimit, DefaultSuccessfulJobsHistoryLimit),
+		historyLimit(cfg.FailedJobsHistoryLimit, DefaultFailedJobsHistoryLimit)) {
+		if err := Delete(c.manager, job); err != nil {
+			c.logger.Error().Err(err).Str("job", job.Name).Msg("Failed to prune finished job")
+		}
+	}
+}
+
+// startJob creates the job a cronjob runs at the scheduled time, applying
+// its concurrency policy to the jobs still running. It returns nil if no
+// job was started.
+func (c *Controller) startJob(cron *types.Service, jobs []*types.Service, scheduled, now time.Time) *types.Service {
+	job := newJob(cron, scheduled, now)
+
+	var active []*types.Service
+	for _, existing := range jobs {
+		if existing.Name == job.Name {
+			return nil // Already started before a failover
+		}
+		if !Finished(existing) {
+			active = append(active, existing)
+		}
+	}
+
+	if len(active) > 0 {
+		switch cron.CronJob.ConcurrencyPolicy {
+		case types.ConcurrencyForbid:
+			c.logger.Info().Str("cronjob", cron.Name).Time("scheduled", scheduled).Msg("Skipping cronjob run: previous job still running")
+			return nil
+		case types.ConcurrencyReplace:
+			for _, existing := range active {
+				if err := Delete(c.manager, existing); err != nil {
+					c.logger.Error().Err(err).Str("job", existing.Name).Msg("Failed to replace running job")
+					return nil
+				}
+			}
+		}
+	}
+
+	if err := c.manager.CreateService(job); err != nil {
+		c.logger.Error().Err(err).Str("cronjob", cron.Name).Msg("Failed to start cronjob run")
+		return nil
+	}
+	c.logger.Info().Str("cronjob", cron.Name).Str("job", job.Name).Time("scheduled", scheduled).Msg("Started cronjob run")
+	return job
+}
+
+// stopActive shuts down the containers still pending or running
+func (c *Controller) stopActive(containers []*types.Container) {
+	for _, container := range containers {
+		if !active(container) {
+			continue
+		}
+		container.DesiredState = types.ContainerStateShutdown
+		if err := c.manager.UpdateContainer(container); err != nil {
+			c.logger.Error().Err(err).Str("container_id", container.ID).Msg("Failed to stop job container")
+		}
+	}
+}
+
+// Delete removes a job after stopping its containers. Deleting a cronjob
+// also deletes the jobs it started. Finished containers are removed by
+// the reconciler once the job is gone.
+func Delete(mgr *manager.Manager, service *types.Service) error {
+	if service.Mode == types.ServiceModeCronJob {
+		services, err := mgr.ListServices()
+		if err != nil {
+			return fmt.Errorf("failed to list services: %w", err)
+		}
+		for _, job := range children(service, services) {
+			if err := Delete(mgr, job); err != nil {
+				return err
+			}
+		}
+	}
+
+	containers, err := mgr.ListContainersByService(service.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list containers: %w", err)
+	}
+	for _, container := range containers {
+		if !active(container) {
+			continue
+		}
+		container.DesiredState = types.ContainerStateShutdown
+		if err := mgr.UpdateContainer(container); err != nil {
+			return fmt.Errorf("failed to stop container %s: %w", container.ID, err)
+		}
+	}
+	return mgr.DeleteService(service.ID)
+}
+
+// active reports whether a container is pending or running and meant to run
+func active(container *types.Container) bool {
+	return container.DesiredState == types.ContainerStateRunning &&
+		(container.ActualState == types.ContainerStatePending || container.ActualState == types.ContainerStateRunning)
+}
+
+// children returns the jobs a cronjob started
+func children(cron *types.Service, services []*types.Service) []*types.Service {
+	var jobs []*types.Service
+	for _, service := range services {
+		if service.Mode == types.ServiceModeJob && service.Labels[types.LabelCronJob] == cron.Name {
+			jobs = append(jobs, service)
+		}
+	}
+	return jobs
+}
+
+// newJob copies a cronjob's spec into the job it runs at the scheduled
+// time, named after the cronjob and that time
+func newJob(cron *types.Service, scheduled, now time.Time) *types.Service {
+	job := *cron
+	job.ID = uuid.New().String()
+	job.Name = fmt.Sprintf("%s-%s", cron.Name, scheduled.UTC().Format("200601021504"))
+	job.Mode = types.ServiceModeJob
+	job.CronJob = nil
+	job.JobStatus = nil
+	job.LastScheduleTime = time.Time{}
+	job.VIP = nil
+	job.CreatedAt = now
+	job.UpdatedAt = now
+
+	job.Labels = make(map[string]string, len(cron.Labels)+1)
+	for k, v := range cron.Labels {
+		job.Labels[k] = v
+	}
+	job.Labels[types.LabelCronJob] = cron.Name
+	return &job
+}
+
+// dueTime returns the latest scheduled time after last that is not after
+// now, or the zero time if none is due. Missed runs collapse into one.
+func dueTime(schedule *Schedule, last, now time.Time) time.Time {
+	var due time.Time
+	for next := schedule.Next(last); !next.IsZero() && !next.After(now); next = schedule.Next(next) {
+		due = next
+	}
+	return due
+}
+
+// pruneHistory returns the finished jobs beyond the history limits,
+// oldest first
+func pruneHistory(jobs []*types.Service, successfulLimit, failedLimit int) []*types.Service {
+	var succeeded, failed []*types.Service
+	for _, job := range jobs {
+		if !Finished(job) {
+			continue
+		}
+		if job.JobStatus.State == types.JobStateComplete {
+			succeeded = append(succeeded, job)
+		} else {
+			failed = append(failed, job)
+		}
+	}
+
+	var pruned []*types.Service
+	for _, finished := range []struct {
+		jobs  []*types.Service
+		limit int
+	}{{succeeded, successfulLimit}, {failed, failedLimit}} {
+		sort.SliceStable(finished.jobs, func(i, j int) bool {
+			return finished.jobs[i].CreatedAt.Before(finished.jobs[j].CreatedAt)
+		})
+		if excess := len(finished.jobs) - finished.limit; excess > 0 {
+			pruned = append(pruned, finished.jobs[:excess]...)
+		}
+	}
+	return pruned
+}
+
+// historyLimit returns a history limit, or its default if unset. A limit
+// of 0 keeps no finished jobs.
+func historyLimit(limit *int, defaultLimit int) int {
+	if limit == nil {
+		return defaultLimit
+	}
+	return *limit
+}
+
+// statusChanged reports whether a job's status differs from the recorded one
+func statusChanged(old, status *types.JobStatus) bool {
+	return old == nil ||
+		old.State != status.State ||
+		old.Active != status.Active ||
+		old.Succeeded != status.Succeeded ||
+		old.Failed != status.Failed ||
+		old.Reason != status.Reason
+}
@@ -0,0 +1,288 @@
+package jobs
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/cuemby/warren/pkg/manager"
+	"github.com/cuemby/warren/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestController returns a controller on a bootstrapped single-node
+// manager
+func newTestController(t *testing.T) (*Controller, *manager.Manager) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	mgr, err := manager.NewManager(&manager.Config{
+		NodeID:   "test-manager",
+		BindAddr: "127.0.0.1:0",
+		DataDir:  t.TempDir(),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = mgr.Shutdown() })
+
+	require.NoError(t, mgr.Bootstrap())
+	for i := 0; i < 50 && !mgr.IsLeader(); i++ {
+		time.Sleep(100 * time.Millisecond)
+	}
+	require.True(t, mgr.IsLeader(), "manager failed to become leader")
+
+	return NewController(mgr), mgr
+}
+
+// createContainer adds a container of a service in the given states
+func createContainer(t *testing.T, mgr *manager.Manager, id string, service *types.Service, desired, actual types.ContainerState) {
+	t.Helper()
+	require.NoError(t, mgr.CreateContainer(&types.Container{
+		ID:           id,
+		ServiceID:    service.ID,
+		ServiceName:  service.Name,
+		DesiredState: desired,
+		ActualState:  actual,
+	}))
+}
+
+// createCronJob adds a cronjob due every hour, created at created
+func createCronJob(t *testing.T, mgr *manager.Manager, policy types.ConcurrencyPolicy, created time.Time) *types.Service {
+	t.Helper()
+	cron := &types.Service{
+		ID:        "cron",
+		Name:      "report",
+		Mode:      types.ServiceModeCronJob,
+		Image:     "report:1.0",
+		CronJob:   &types.CronJobConfig{Schedule: "@hourly", ConcurrencyPolicy: policy},
+		CreatedAt: created,
+	}
+	require.NoError(t, mgr.CreateService(cron))
+	return cron
+}
+
+// createRun adds a job started by a cronjob, finished in state unless it
+// is running
+func createRun(t *testing.T, mgr *manager.Manager, cron *types.Service, scheduled time.Time, state types.JobState) *types.Service {
+	t.Helper()
+	job := newJob(cron, scheduled, scheduled)
+	job.JobStatus = &types.JobStatus{State: state}
+	require.NoError(t, mgr.CreateService(job))
+	return job
+}
+
+// serviceNames returns the names of every service, sorted
+func serviceNames(t *testing.T, mgr *manager.Manager) []string {
+	t.Helper()
+	services, err := mgr.ListServices()
+	require.NoError(t, err)
+	var names []string
+	for _, service := range services {
+		names = append(names, service.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TestSyncJob tests that a finished job's status is recorded once and its
+// remaining containers are stopped
+func TestSyncJob(t *testing.T) {
+	c, mgr := newTestController(t)
+
+	job := &types.Service{ID: "job", Name: "migrate", Mode: types.ServiceModeJob, Job: &types.JobConfig{Parallelism: 2}, CreatedAt: jobCreated}
+	require.NoError(t, mgr.CreateService(job))
+	createContainer(t, mgr, "done", job, types.ContainerStateRunning, types.ContainerStateComplete)
+	createContainer(t, mgr, "extra", job, types.ContainerStateRunning, types.ContainerStateRunning)
+
+	now := jobCreated.Add(time.Minute)
+	require.NoError(t, c.sync(now))
+
+	stored, err := mgr.GetService("job")
+	require.NoError(t, err)
+	require.NotNil(t, stored.JobStatus)
+	assert.Equal(t, types.JobStateComplete, stored.JobStatus.State)
+	assert.Equal(t, 1, stored.JobStatus.Succeeded)
+
+	extra, err := mgr.GetContainer("extra")
+	require.NoError(t, err)
+	assert.Equal(t, types.ContainerStateShutdown, extra.DesiredState)
+
+	// A finished job's status is not rewritten
+	require.NoError(t, c.sync(now.Add(time.Hour)))
+	stored, err = mgr.GetService("job")
+	require.NoError(t, err)
+	assert.Equal(t, now, stored.JobStatus.CompletionTime.UTC())
+}
+
+// TestSyncJobBackoffLimitZero tests that a backoff limit of 0 fails a job
+// on its first failed container
+func TestSyncJobBackoffLimitZero(t *testing.T) {
+	c, mgr := newTestController(t)
+
+	job := &types.Service{ID: "job", Name: "migrate", Mode: types.ServiceModeJob, Job: &types.JobConfig{BackoffLimit: intPtr(0)}, CreatedAt: jobCreated}
+	require.NoError(t, mgr.CreateService(job))
+	createContainer(t, mgr, "failed", job, types.ContainerStateShutdown, types.ContainerStateFailed)
+
+	require.NoError(t, c.sync(jobCreated.Add(time.Minute)))
+
+	stored, err := mgr.GetService("job")
+	require.NoError(t, err)
+	require.NotNil(t, stored.JobStatus)
+	assert.Equal(t, types.JobStateFailed, stored.JobStatus.State)
+}
+
+// TestSyncCronJob tests that a due cronjob starts one job for its missed
+// runs and records when it was due
+func TestSyncCronJob(t *testing.T) {
+	c, mgr := newTestController(t)
+	createCronJob(t, mgr, "", jobCreated)
+
+	now := jobCreated.Add(150 * time.Minute)
+	require.NoError(t, c.sync(now))
+	assert.Equal(t, []string{"report", "report-202401151200"}, serviceNames(t, mgr))
+
+	cron, err := mgr.GetService("cron")
+	require.NoError(t, err)
+	assert.Equal(t, jobCreated.Add(2*time.Hour), cron.LastScheduleTime.UTC())
+
+	// Not due again until the next hour
+	require.NoError(t, c.sync(now.Add(time.Minute)))
+	assert.Len(t, serviceNames(t, mgr), 2)
+}
+
+// TestStartJobConcurrencyPolicies tests what each concurrency policy does
+// while the previous job still runs
+func TestStartJobConcurrencyPolicies(t *testing.T) {
+	previous := jobCreated.Add(time.Hour)
+	scheduled := jobCreated.Add(2 * time.Hour)
+
+	tests := []struct {
+		policy  types.ConcurrencyPolicy
+		started bool
+		names   []string
+	}{
+		{policy: types.ConcurrencyAllow, started: true, names: []string{"report", "report-202401151100", "report-202401151200"}},
+		{policy: types.ConcurrencyForbid, names: []string{"report", "report-202401151100"}},
+		{policy: types.ConcurrencyReplace, started: true, names: []string{"report", "report-202401151200"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			c, mgr := newTestController(t)
+			cron := createCronJob(t, mgr, tt.policy, jobCreated)
+			running := createRun(t, mgr, cron, previous, types.JobStateRunning)
+			createContainer(t, mgr, "running", running, types.ContainerStateRunning, types.ContainerStateRunning)
+
+			job := c.startJob(cron, []*types.Service{running}, scheduled, scheduled)
+			assert.Equal(t, tt.started, job != nil)
+			assert.Equal(t, tt.names, serviceNames(t, mgr))
+
+			if tt.policy == types.ConcurrencyReplace {
+				container, err := mgr.GetContainer("running")
+				require.NoError(t, err)
+				assert.Equal(t, types.ContainerStateShutdown, container.DesiredState)
+			}
+		})
+	}
+}
+
+// TestStartJobFinishedPrevious tests that Forbid only holds back a run
+// while the previous job is still running
+func TestStartJobFinishedPrevious(t *testing.T) {
+	c, mgr := newTestController(t)
+	cron := createCronJob(t, mgr, types.ConcurrencyForbid, jobCreated)
+	done := createRun(t, mgr, cron, jobCreated.Add(time.Hour), types.JobStateComplete)
+
+	scheduled := jobCreated.Add(2 * time.Hour)
+	require.NotNil(t, c.startJob(cron, []*types.Service{done}, scheduled, scheduled))
+	assert.Equal(t, []string{"report", "report-202401151100", "report-202401151200"}, serviceNames(t, mgr))
+}
+
+// TestStartJobAfterFailover tests that a run started before a leader
+// failover is not started again, nor replaced
+func TestStartJobAfterFailover(t *testing.T) {
+	for _, policy := range []types.ConcurrencyPolicy{types.ConcurrencyAllow, types.ConcurrencyReplace} {
+		t.Run(string(policy), func(t *testing.T) {
+			c, mgr := newTestController(t)
+			cron := createCronJob(t, mgr, policy, jobCreated)
+			scheduled := jobCreated.Add(time.Hour)
+			started := createRun(t, mgr, cron, scheduled, types.JobStateRunning)
+
+			assert.Nil(t, c.startJob(cron, []*types.Service{started}, scheduled, scheduled.Add(time.Minute)))
+			assert.Equal(t, []string{"report", "report-202401151100"}, serviceNames(t, mgr))
+
+			stored, err := mgr.GetServiceByName("report-202401151100")
+			require.NoError(t, err)
+			assert.Equal(t, started.ID, stored.ID)
+		})
+	}
+}
+
+// TestSyncCronJobPrunesHistory tests that finished jobs beyond the history
+// limits are deleted, and that a limit of 0 keeps none
+func TestSyncCronJobPrunesHistory(t *testing.T) {
+	tests := []struct {
+		name       string
+		successful *int
+		failed     *int
+		names      []string
+	}{
+		{
+			name:  "defaults",
+			names: []string{"report", "report-202401151100", "report-202401151200", "report-202401151300", "report-202401151500", "report-202401151600"},
+		},
+		{
+			name:       "keep none",
+			successful: intPtr(0),
+			failed:     intPtr(0),
+			names:      []string{"report", "report-202401151600"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mgr := newTestController(t)
+			cron := createCronJob(t, mgr, "", jobCreated)
+			cron.CronJob.SuccessfulJobsHistoryLimit = tt.successful
+			cron.CronJob.FailedJobsHistoryLimit = tt.failed
+			cron.LastScheduleTime = jobCreated.Add(6 * time.Hour)
+			require.NoError(t, mgr.UpdateService(cron))
+
+			for hour, state := range []types.JobState{
+				types.JobStateComplete, types.JobStateComplete, types.JobStateComplete,
+				types.JobStateComplete, types.JobStateFailed, types.JobStateFailed, types.JobStateRunning,
+			} {
+				createRun(t, mgr, cron, jobCreated.Add(time.Duration(hour)*time.Hour), state)
+			}
+
+			require.NoError(t, c.sync(jobCreated.Add(6*time.Hour+time.Minute)))
+			assert.Equal(t, tt.names, serviceNames(t, mgr))
+		})
+	}
+}
+
+// TestDeleteCronJob tests that deleting a cronjob deletes its jobs and
+// stops their containers
+func TestDeleteCronJob(t *testing.T) {
+	_, mgr := newTestController(t)
+	cron := createCronJob(t, mgr, "", jobCreated)
+	job := createRun(t, mgr, cron, jobCreated.Add(time.Hour), types.JobStateRunning)
+	createContainer(t, mgr, "running", job, types.ContainerStateRunning, types.ContainerStateRunning)
+	createContainer(t, mgr, "done", job, types.ContainerStateRunning, types.ContainerStateComplete)
+
+	other := &types.Service{ID: "other", Name: "web", Mode: types.ServiceModeReplicated}
+	require.NoError(t, mgr.CreateService(other))
+
+	require.NoError(t, Delete(mgr, cron))
+	assert.Equal(t, []string{"web"}, serviceNames(t, mgr))
+
+	running, err := mgr.GetContainer("running")
+	require.NoError(t, err)
+	assert.Equal(t, types.ContainerStateShutdown, running.DesiredState)
+
+	done, err := mgr.GetContainer("done")
+	require.NoError(t, err)
+	assert.Equal(t, types.ContainerStateRunning, done.DesiredState, "finished containers are left to the reconciler")
+}
@@ -0,0 +1,60 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule(t *testing.T) {
+	for _, expr := range []string{"* * * * *", "*/15 * * * *", "0 2 * * 1-5", "0,30 8-18/2 1 */3 7", "@daily", " @hourly "} {
+		_, err := ParseSchedule(expr)
+		assert.NoError(t, err, expr)
+	}
+
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "5-1 * * * *", "*/0 * * * *", "a * * * *", "@often"} {
+		_, err := ParseSchedule(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	base := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC) // Monday
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2024, 1, 15, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, 1, 15, 10, 45, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2024, 1, 16, 2, 0, 0, 0, time.UTC)},
+		{"0 9 * * 6", time.Date(2024, 1, 20, 9, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: the 1st of the month or any Friday
+		{"0 0 1 * 5", time.Date(2024, 1, 19, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, schedule.Next(base))
+		})
+	}
+}
+
+func TestDueTime(t *testing.T) {
+	schedule, err := ParseSchedule("0 * * * *")
+	require.NoError(t, err)
+	last := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	assert.True(t, dueTime(schedule, last, last.Add(59*time.Minute)).IsZero())
+	assert.Equal(t, last.Add(time.Hour), dueTime(schedule, last, last.Add(time.Hour)))
+
+	// Missed runs collapse into the latest one
+	assert.Equal(t, last.Add(5*time.Hour), dueTime(schedule, last, last.Add(5*time.Hour+30*time.Minute)))
+}
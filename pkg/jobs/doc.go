@@ -0,0 +1,92 @@
+/*
+Package jobs runs services that finish: jobs, which run containers until
+enough of them complete, and cronjobs, which start a job on a schedule.
+
+Replicated and global services keep their containers running forever; a
+container that exits is replaced. Database migrations, batch imports and
+nightly reports instead run to completion. A job-mode service tracks how
+many of its containers exited successfully and stops once it has enough.
+
+# Jobs
+
+A job is a service with Mode "job" and a JobConfig:
+
+	Completions     Successful containers needed (default: 1)
+	Parallelism     Containers running at once (default: 1)
+	BackoffLimit    Failed containers tolerated before the job fails (default: 6;
+	                0 fails the job on its first failed container)
+	ActiveDeadline  Longest the job may run before it fails (default: none)
+
+The scheduler keeps min(Parallelism, Completions - succeeded) containers
+running, replacing the ones that fail. A container succeeded when it
+exited with code 0 while it was meant to run; containers Warren stopped
+itself (preempted, evicted, replaced) count neither way:
+
+	warren job create migrate --image myapp:1.4 --completions 1
+	  container 1: exit 1  → failed (1/6)
+	  container 2: exit 0  → succeeded (1/1)
+	  job complete
+
+The restart policy still applies inside a container: with on-failure the
+worker restarts a failing container in place before it counts as failed.
+The always policy is rejected, since the container could never finish.
+
+# Cronjobs
+
+A cronjob is a service with Mode "cronjob" and a CronJobConfig. It runs no
+containers itself; at each scheduled time the controller copies its spec
+into a new job named after the cronjob and the time (e.g.
+report-202401150200) and labeled warren.cronjob=<cronjob>.
+
+Schedules use the standard five fields, matched in UTC:
+
+	┌───────────── minute (0-59)
+	│ ┌─────────── hour (0-23)
+	│ │ ┌───────── day of month (1-31)
+	│ │ │ ┌─────── month (1-12)
+	│ │ │ │ ┌───── day of week (0-7, 0 and 7 are Sunday)
+	│ │ │ │ │
+	0 2 * * 1-5     02:00 on weekdays
+
+Each field takes "*", values, ranges, steps ("0-59/15", "8-18/2") and lists.
+The descriptors @yearly, @monthly, @weekly, @daily and @hourly are also
+accepted. Runs missed while no manager was leader collapse into one.
+
+The concurrency policy decides what happens when a job is due while the
+previous one still runs:
+
+	Allow    Start the new job alongside (default)
+	Forbid   Skip this run
+	Replace  Delete the running job and start the new one
+
+Finished jobs are kept for inspection up to the history limits (default:
+3 successful, 1 failed; 0 keeps none); older ones are deleted.
+
+# Controller
+
+The controller runs on every manager and acts only on the Raft leader, so
+after a failover the new leader picks up where the old one stopped. Every
+5 seconds it:
+
+ 1. Evaluates each job from its containers and records the JobStatus
+ 2. Stops the remaining containers of jobs that just finished
+ 3. Starts the job of each cronjob that is due
+ 4. Deletes finished jobs beyond each cronjob's history limits
+
+Start it next to the scheduler and reconciler on every manager:
+
+	controller := jobs.NewController(manager)
+	controller.Start()
+	defer controller.Stop()
+
+A job's successful containers stay in the cluster while the job exists,
+as the record of its progress. Deleting the job (jobs.Delete) stops its
+running containers; the reconciler removes the rest.
+
+# See Also
+
+  - pkg/scheduler - Places job containers
+  - pkg/reconciler - Removes the containers of deleted jobs
+  - docs/cli-reference.md - warren job commands
+*/
+package jobs
@@ -0,0 +1,161 @@
+package jobs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cuemby/warren/pkg/types"
+)
+
+// Defaults for unset job and cronjob settings
+const (
+	DefaultCompletions                = 1
+	DefaultParallelism                = 1
+	DefaultBackoffLimit               = 6
+	DefaultSuccessfulJobsHistoryLimit = 3
+	DefaultFailedJobsHistoryLimit     = 1
+)
+
+// IsJob reports whether a service runs to completion, either itself or
+// through the jobs a cronjob creates
+func IsJob(service *types.Service) bool {
+	return service.Mode == types.ServiceModeJob || service.Mode == types.ServiceModeCronJob
+}
+
+// Config returns a job's settings with defaults applied
+func Config(service *types.Service) types.JobConfig {
+	var cfg types.JobConfig
+	if service.Job != nil {
+		cfg = *service.Job
+	}
+	if cfg.Completions <= 0 {
+		cfg.Completions = DefaultCompletions
+	}
+	if cfg.Parallelism <= 0 {
+		cfg.Parallelism = DefaultParallelism
+	}
+	return cfg
+}
+
+// BackoffLimit returns how many containers of a job may fail before it
+// fails. An unset limit defaults; a limit of 0 fails the job on its first
+// failed container.
+func BackoffLimit(cfg types.JobConfig) int {
+	if cfg.BackoffLimit == nil {
+		return DefaultBackoffLimit
+	}
+	return *cfg.BackoffLimit
+}
+
+// Finished reports whether a job has completed or failed
+func Finished(service *types.Service) bool {
+	return service.JobStatus != nil &&
+		(service.JobStatus.State == types.JobStateComplete || service.JobStatus.State == types.JobStateFailed)
+}
+
+// Evaluate returns a job's status from its containers. A container
+// succeeded if it exited by itself with code 0 while meant to run;
+// containers stopped by Warren (preempted, evicted, replaced) count
+// neither way. A finished job's status no longer changes.
+func Evaluate(job *types.Service, containers []*types.Container, now time.Time) *types.JobStatus {
+	status := &types.JobStatus{State: types.JobStateRunning, StartTime: job.CreatedAt}
+	if job.JobStatus != nil {
+		*status = *job.JobStatus
+		if Finished(job) {
+			return status
+		}
+	}
+	if status.State == "" {
+		status.State = types.JobStateRunning
+	}
+
+	status.Active, status.Succeeded, status.Failed = 0, 0, 0
+	for _, container := range containers {
+		switch {
+		case container.ActualState == types.ContainerStateFailed:
+			status.Failed++
+		case container.ActualState == types.ContainerStateComplete && container.DesiredState == types.ContainerStateRunning:
+			status.Succeeded++
+		case container.DesiredState == types.ContainerStateRunning &&
+			(container.ActualState == types.ContainerStatePending || container.ActualState == types.ContainerStateRunning):
+			status.Active++
+		}
+	}
+
+	cfg := Config(job)
+	switch {
+	case status.Succeeded >= cfg.Completions:
+		status.State = types.JobStateComplete
+	case status.Failed > BackoffLimit(cfg):
+		status.State = types.JobStateFailed
+		status.Reason = fmt.Sprintf("backoff limit exceeded: %d containers failed", status.Failed)
+	case cfg.ActiveDeadline > 0 && now.Sub(status.StartTime) >= cfg.ActiveDeadline:
+		status.State = types.JobStateFailed
+		status.Reason = fmt.Sprintf("active deadline of %s exceeded", cfg.ActiveDeadline)
+	default:
+		return status
+	}
+	status.Active = 0
+	status.CompletionTime = now
+	return status
+}
+
+// Wanted returns how many containers a job should have running now: its
+// parallelism, capped by the successes it still needs, or none once it
+// has finished
+func Wanted(job *types.Service, containers []*types.Container, now time.Time) int {
+	status := Evaluate(job, containers, now)
+	if status.State != types.JobStateRunning {
+		return 0
+	}
+	cfg := Config(job)
+	remaining := cfg.Completions - status.Succeeded
+	if remaining < cfg.Parallelism {
+		return remaining
+	}
+	return cfg.Parallelism
+}
+
+// Validate checks the job and cronjob settings of a service
+func Validate(service *types.Service) error {
+	if !IsJob(service) {
+		if service.Job != nil || service.CronJob != nil {
+			return fmt.Errorf("job settings need the job or cronjob mode")
+		}
+		return nil
+	}
+
+	if service.RestartPolicy != nil && service.RestartPolicy.Condition == types.RestartAlways {
+		return fmt.Errorf("job containers run to completion: use the on-failure or never restart policy")
+	}
+	if job := service.Job; job != nil {
+		if job.Completions < 0 || job.Parallelism < 0 || (job.BackoffLimit != nil && *job.BackoffLimit < 0) || job.ActiveDeadline < 0 {
+			return fmt.Errorf("job completions, parallelism, backoff limit and active deadline cannot be negative")
+		}
+	}
+
+	if service.Mode == types.ServiceModeJob {
+		if service.CronJob != nil {
+			return fmt.Errorf("a schedule needs the cronjob mode")
+		}
+		return nil
+	}
+
+	cron := service.CronJob
+	if cron == nil || cron.Schedule == "" {
+		return fmt.Errorf("a cronjob needs a schedule")
+	}
+	if _, err := ParseSchedule(cron.Schedule); err != nil {
+		return err
+	}
+	switch cron.ConcurrencyPolicy {
+	case "", types.ConcurrencyAllow, types.ConcurrencyForbid, types.ConcurrencyReplace:
+	default:
+		return fmt.Errorf("unknown concurrency policy %q (use Allow, Forbid or Replace)", cron.ConcurrencyPolicy)
+	}
+	if (cron.SuccessfulJobsHistoryLimit != nil && *cron.SuccessfulJobsHistoryLimit < 0) ||
+		(cron.FailedJobsHistoryLimit != nil && *cron.FailedJobsHistoryLimit < 0) {
+		return fmt.Errorf("job history limits cannot be negative")
+	}
+	return nil
+}
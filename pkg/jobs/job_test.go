@@ -0,0 +1,152 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cuemby/warren/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jobContainer returns a job container in the given states
+func jobContainer(desired, actual types.ContainerState) *types.Container {
+	return &types.Container{ServiceID: "job", DesiredState: desired, ActualState: actual}
+}
+
+var (
+	succeeded  = jobContainer(types.ContainerStateRunning, types.ContainerStateComplete)
+	failed     = jobContainer(types.ContainerStateShutdown, types.ContainerStateFailed)
+	running    = jobContainer(types.ContainerStateRunning, types.ContainerStateRunning)
+	preempted  = jobContainer(types.ContainerStateShutdown, types.ContainerStateComplete)
+	jobCreated = time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func newTestJob(cfg *types.JobConfig) *types.Service {
+	return &types.Service{ID: "job", Name: "migrate", Mode: types.ServiceModeJob, Job: cfg, CreatedAt: jobCreated}
+}
+
+func TestEvaluate(t *testing.T) {
+	now := jobCreated.Add(time.Minute)
+
+	tests := []struct {
+		name       string
+		cfg        *types.JobConfig
+		containers []*types.Container
+		now        time.Time
+		state      types.JobState
+		succeeded  int
+		wanted     int
+	}{
+		{"new job", nil, nil, now, types.JobStateRunning, 0, 1},
+		{"running", nil, []*types.Container{running}, now, types.JobStateRunning, 0, 1},
+		{"complete", nil, []*types.Container{failed, succeeded}, now, types.JobStateComplete, 1, 0},
+		{"stopped containers do not count", nil, []*types.Container{preempted}, now, types.JobStateRunning, 0, 1},
+		{"parallel", &types.JobConfig{Completions: 5, Parallelism: 3}, []*types.Container{succeeded, succeeded, succeeded}, now, types.JobStateRunning, 3, 2},
+		{"backoff limit", &types.JobConfig{BackoffLimit: intPtr(1)}, []*types.Container{failed, failed}, now, types.JobStateFailed, 0, 0},
+		{"backoff limit 0", &types.JobConfig{BackoffLimit: intPtr(0)}, []*types.Container{failed}, now, types.JobStateFailed, 0, 0},
+		{"default backoff limit", nil, []*types.Container{failed, failed}, now, types.JobStateRunning, 0, 1},
+		{"active deadline", &types.JobConfig{ActiveDeadline: time.Minute}, []*types.Container{running}, now, types.JobStateFailed, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := newTestJob(tt.cfg)
+			status := Evaluate(job, tt.containers, tt.now)
+			assert.Equal(t, tt.state, status.State)
+			assert.Equal(t, tt.succeeded, status.Succeeded)
+			assert.Equal(t, tt.wanted, Wanted(job, tt.containers, tt.now))
+			if tt.state != types.JobStateRunning {
+				assert.Equal(t, tt.now, status.CompletionTime)
+				assert.Zero(t, status.Active)
+			}
+		})
+	}
+
+	// A finished job keeps its status
+	job := newTestJob(nil)
+	job.JobStatus = Evaluate(job, []*types.Container{succeeded}, now)
+	status := Evaluate(job, []*types.Container{succeeded, failed}, now.Add(time.Hour))
+	assert.Equal(t, types.JobStateComplete, status.State)
+	assert.Equal(t, now, status.CompletionTime)
+	assert.Zero(t, status.Failed)
+}
+
+func TestValidate(t *testing.T) {
+	valid := []*types.Service{
+		{Mode: types.ServiceModeReplicated},
+		{Mode: types.ServiceModeJob, Job: &types.JobConfig{Completions: 3}},
+		{Mode: types.ServiceModeCronJob, CronJob: &types.CronJobConfig{Schedule: "@daily", ConcurrencyPolicy: types.ConcurrencyForbid}},
+		{Mode: types.ServiceModeCronJob, Job: &types.JobConfig{BackoffLimit: intPtr(0)},
+			CronJob: &types.CronJobConfig{Schedule: "@daily", SuccessfulJobsHistoryLimit: intPtr(0), FailedJobsHistoryLimit: intPtr(0)}},
+	}
+	for _, service := range valid {
+		assert.NoError(t, Validate(service), service.Mode)
+	}
+
+	invalid := []*types.Service{
+		{Mode: types.ServiceModeReplicated, Job: &types.JobConfig{}},
+		{Mode: types.ServiceModeJob, Job: &types.JobConfig{Completions: -1}},
+		{Mode: types.ServiceModeJob, Job: &types.JobConfig{BackoffLimit: intPtr(-1)}},
+		{Mode: types.ServiceModeCronJob, CronJob: &types.CronJobConfig{Schedule: "@daily", FailedJobsHistoryLimit: intPtr(-1)}},
+		{Mode: types.ServiceModeJob, RestartPolicy: &types.RestartPolicy{Condition: types.RestartAlways}},
+		{Mode: types.ServiceModeJob, CronJob: &types.CronJobConfig{Schedule: "@daily"}},
+		{Mode: types.ServiceModeCronJob},
+		{Mode: types.ServiceModeCronJob, CronJob: &types.CronJobConfig{Schedule: "every day"}},
+		{Mode: types.ServiceModeCronJob, CronJob: &types.CronJobConfig{Schedule: "@daily", ConcurrencyPolicy: "Queue"}},
+	}
+	for _, service := range invalid {
+		assert.Error(t, Validate(service), service.Mode)
+	}
+}
+
+func TestNewJob(t *testing.T) {
+	cron := &types.Service{
+		ID:        "cron",
+		Name:      "report",
+		Mode:      types.ServiceModeCronJob,
+		Image:     "report:1.0",
+		Labels:    map[string]string{"team": "data"},
+		CronJob:   &types.CronJobConfig{Schedule: "0 2 * * *"},
+		Job:       &types.JobConfig{Completions: 2},
+		CreatedAt: jobCreated,
+	}
+	scheduled := time.Date(2024, 1, 16, 2, 0, 0, 0, time.UTC)
+
+	job := newJob(cron, scheduled, scheduled.Add(time.Second))
+	assert.NotEqual(t, cron.ID, job.ID)
+	assert.Equal(t, "report-202401160200", job.Name)
+	assert.Equal(t, types.ServiceModeJob, job.Mode)
+	assert.Equal(t, "report:1.0", job.Image)
+	assert.Equal(t, 2, job.Job.Completions)
+	assert.Nil(t, job.CronJob)
+	assert.Equal(t, map[string]string{"team": "data", types.LabelCronJob: "report"}, job.Labels)
+	assert.Equal(t, map[string]string{"team": "data"}, cron.Labels)
+	require.NoError(t, Validate(job))
+
+	assert.Equal(t, []*types.Service{job}, children(cron, []*types.Service{cron, job}))
+}
+
+func TestPruneHistory(t *testing.T) {
+	finished := func(name string, state types.JobState, age time.Duration) *types.Service {
+		return &types.Service{Name: name, Mode: types.ServiceModeJob, CreatedAt: jobCreated.Add(-age), JobStatus: &types.JobStatus{State: state}}
+	}
+	jobs := []*types.Service{
+		finished("ok-new", types.JobStateComplete, time.Hour),
+		finished("ok-old", types.JobStateComplete, 3*time.Hour),
+		finished("ok-mid", types.JobStateComplete, 2*time.Hour),
+		finished("failed-new", types.JobStateFailed, time.Hour),
+		finished("failed-old", types.JobStateFailed, 2*time.Hour),
+		finished("running", types.JobStateRunning, 4*time.Hour),
+	}
+
+	var names []string
+	for _, job := range pruneHistory(jobs, 1, 1) {
+		names = append(names, job.Name)
+	}
+	assert.Equal(t, []string{"ok-old", "ok-mid", "failed-old"}, names)
+	assert.Empty(t, pruneHistory(jobs, 3, 2))
+}
@@ -0,0 +1,83 @@
+package manager
+
+import (
+	"github.com/cuemby/warren/pkg/types"
+)
+
+// reservesResources reports whether a container holds its resource
+// reservation on its node. A container reserves from placement until it
+// stops; a pending container that is already being shut down never started.
+func reservesResources(container *types.Container) bool {
+	if container.NodeID == "" || container.Resources == nil {
+		return false
+	}
+	switch container.ActualState {
+	case types.ContainerStateRunning:
+		return true
+	case types.ContainerStatePending:
+		return container.DesiredState == types.ContainerStateRunning
+	}
+	return false
+}
+
+// setAllocated sets the CPU and memory reserved on a node to the sum of the
+// reservations of the containers placed on it
+func setAllocated(node *types.Node, containers []*types.Container) {
+	if node.Resources == nil {
+		node.Resources = &types.NodeResources{}
+	}
+
+	var cpu float64
+	var memory int64
+	for _, container := range containers {
+		if container.NodeID != node.ID || !reservesResources(container) {
+			continue
+		}
+		cpu += container.Resources.CPUReservation
+		memory += container.Resources.MemoryReservation
+	}
+
+	node.Resources.CPUAllocated = cpu
+	node.Resources.MemoryAllocated = memory
+}
+
+// setNodeAllocation sets the allocation of a node about to be stored, so
+// node updates (e.g. heartbeats) never overwrite it with a stale value.
+// Callers must hold f.mu.
+func (f *WarrenFSM) setNodeAllocation(node *types.Node) error {
+	containers, err := f.store.ListContainersByNode(node.ID)
+	if err != nil {
+		return err
+	}
+	setAllocated(node, containers)
+	return nil
+}
+
+// refreshNodeAllocation recomputes and stores the allocation of a node after
+// a container placed on it changed. Callers must hold f.mu.
+func (f *WarrenFSM) refreshNodeAllocation(nodeID string) error {
+	if nodeID == "" {
+		return nil
+	}
+
+	node, err := f.store.GetNode(nodeID)
+	if err != nil {
+		return nil // Node already removed
+	}
+
+	containers, err := f.store.ListContainersByNode(nodeID)
+	if err != nil {
+		return err
+	}
+
+	cpu, memory := float64(0), int64(0)
+	if node.Resources != nil {
+		cpu, memory = node.Resources.CPUAllocated, node.Resources.MemoryAllocated
+	}
+	setAllocated(node, containers)
+	if node.Resources.CPUAllocated == cpu && node.Resources.MemoryAllocated == memory {
+		return nil
+	}
+
+	return f.store.UpdateNode(node)
+}
@@ -0,0 +1,27 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/cuemby/warren/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetAllocated(t *testing.T) {
+	req := &types.ResourceRequirements{CPUReservation: 0.5, MemoryReservation: 256}
+	node := &types.Node{ID: "node-1", Resources: &types.NodeResources{CPUCores: 4, MemoryBytes: 4096}}
+
+	containers := []*types.Container{
+		{ID: "running", NodeID: "node-1", Resources: req, DesiredState: types.ContainerStateRunning, ActualState: types.ContainerStateRunning},
+		{ID: "starting", NodeID: "node-1", Resources: req, DesiredState: types.ContainerStateRunning, ActualState: types.ContainerStatePending},
+		{ID: "cancelled", NodeID: "node-1", Resources: req, DesiredState: types.ContainerStateShutdown, ActualState: types.ContainerStatePending},
+		{ID: "stopped", NodeID: "node-1", Resources: req, DesiredState: types.ContainerStateShutdown, ActualState: types.ContainerStateComplete},
+		{ID: "failed", NodeID: "node-1", Resources: req, DesiredState: types.ContainerStateRunning, ActualState: types.ContainerStateFailed},
+		{ID: "unreserved", NodeID: "node-1", DesiredState: types.ContainerStateRunning, ActualState: types.ContainerStateRunning},
+		{ID: "other-node", NodeID: "node-2", Resources: req, DesiredState: types.ContainerStateRunning, ActualState: types.ContainerStateRunning},
+	}
+
+	setAllocated(node, containers)
+	assert.Equal(t, 1.0, node.Resources.CPUAllocated)
+	assert.Equal(t, int64(512), node.Resources.MemoryAllocated)
+}
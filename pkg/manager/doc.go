@@ -65,6 +65,7 @@ TokenManager:
   - Generates and validates join tokens
   - Separate tokens for workers and managers
   - Time-limited tokens with rotation support
+  - Stores only SHA-256 hashes, replicated through Raft and snapshots
 
 Command:
   - Encapsulates state change operations
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"time"
 
+	"github.com/cuemby/warren/pkg/events"
 	"github.com/cuemby/warren/pkg/metrics"
 	"github.com/cuemby/warren/pkg/storage"
 	"github.com/cuemby/warren/pkg/types"
@@ -15,23 +17,63 @@ import (
 // WarrenFSM implements the Raft Finite State Machine for Warren's cluster state
 // It applies log entries to the cluster state and handles snapshots
 type WarrenFSM struct {
-	mu    sync.RWMutex
-	store storage.Store
+	mu     sync.RWMutex
+	store  storage.Store
+	broker *events.Broker
+
+	// Entries up to replayIndex, or appended by the leader before started,
+	// are replayed history: applying them publishes no events
+	started     time.Time
+	replayIndex uint64
+	replaying   bool // The entry being applied is replayed history
 }
 
 // NewWarrenFSM creates a new FSM instance
-func NewWarrenFSM(store storage.Store) *WarrenFSM {
+// Applied state changes are published to broker (may be nil)
+func NewWarrenFSM(store storage.Store, broker *events.Broker) *WarrenFSM {
 	return &WarrenFSM{
-		store: store,
+		store:   store,
+		broker:  broker,
+		started: time.Now(),
 	}
 }
 
+// setReplayIndex sets the last entry of the Raft log when the manager
+// starts. Raft applies the entries up to it again, and a restarted
+// manager would otherwise publish their events a second time.
+func (f *WarrenFSM) setReplayIndex(index uint64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.replayIndex = index
+}
+
+// replayed reports whether a log entry was committed before this manager
+// started: it is in the local log already, or the leader appended it
+// before and is sending it to catch this manager up
+func (f *WarrenFSM) replayed(entry *raft.Log) bool {
+	if entry.Index > 0 && entry.Index <= f.replayIndex {
+		return true
+	}
+	return !entry.AppendedAt.IsZero() && entry.AppendedAt.Before(f.started)
+}
+
 // Command represents a state change operation in the Raft log
 type Command struct {
 	Op   string          `json:"op"`
 	Data json.RawMessage `json:"data"`
 }
 
+// NodeMetadataUpdate is the data of an update_node_metadata command. It is
+// applied to the node as stored when the command is committed, so that it
+// neither undoes nor is undone by a concurrent heartbeat.
+type NodeMetadataUpdate struct {
+	NodeID      string
+	LabelAdd    map[string]string
+	LabelRemove []string
+	TaintAdd    []types.Taint
+	TaintRemove []types.Taint
+}
+
 // Apply applies a Raft log entry to the FSM
 // This is called by Raft when a log entry is committed
 func (f *WarrenFSM) Apply(log *raft.Log) interface{} {
@@ -45,6 +87,7 @@ func (f *WarrenFSM) Apply(log *raft.Log) interface{} {
 
 	f.mu.Lock()
 	defer f.mu.Unlock()
+	f.replaying = f.replayed(log)
 
 	switch cmd.Op {
 	// Node operations
@@ -53,21 +96,67 @@ func (f *WarrenFSM) Apply(log *raft.Log) interface{} {
 		if err := json.Unmarshal(cmd.Data, &node); err != nil {
 			return err
 		}
-		return f.store.CreateNode(&node)
+		// Allocation is derived from the containers placed on the node
+		if err := f.setNodeAllocation(&node); err != nil {
+			return err
+		}
+		if err := f.store.CreateNode(&node); err != nil {
+			return err
+		}
+		f.publishNodeEvent(events.EventNodeJoined, &node, fmt.Sprintf("Node %s joined as %s", node.ID, node.Role))
+		return nil
 
 	case "update_node":
 		var node types.Node
 		if err := json.Unmarshal(cmd.Data, &node); err != nil {
 			return err
 		}
-		return f.store.UpdateNode(&node)
+		previous, _ := f.store.GetNode(node.ID)
+		if previous != nil {
+			// Labels and taints only change with update_node_metadata, so
+			// that a node read before such a change does not undo it
+			node.Labels = previous.Labels
+			node.Taints = previous.Taints
+		}
+		if err := f.setNodeAllocation(&node); err != nil {
+			return err
+		}
+		if err := f.store.UpdateNode(&node); err != nil {
+			return err
+		}
+		if node.Status == types.NodeStatusDown && (previous == nil || previous.Status != types.NodeStatusDown) {
+			f.publishNodeEvent(events.EventNodeDown, &node, fmt.Sprintf("Node %s is down", node.ID))
+		}
+		return nil
+
+	case "update_node_metadata":
+		var update NodeMetadataUpdate
+		if err := json.Unmarshal(cmd.Data, &update); err != nil {
+			return err
+		}
+		node, err := f.store.GetNode(update.NodeID)
+		if err != nil {
+			return fmt.Errorf("failed to get node: %w", err)
+		}
+		applyNodeMetadata(node, &update)
+		if err := f.store.UpdateNode(node); err != nil {
+			return err
+		}
+		f.publishNodeEvent(events.EventNodeUpdated, node, fmt.Sprintf("Node %s labels or taints updated", node.ID))
+		return nil
 
 	case "delete_node":
 		var nodeID string
 		if err := json.Unmarshal(cmd.Data, &nodeID); err != nil {
 			return err
 		}
-		return f.store.DeleteNode(nodeID)
+		if err := f.store.DeleteNode(nodeID); err != nil {
+			return err
+		}
+		f.publish(events.EventNodeLeft, fmt.Sprintf("Node %s left the cluster", nodeID), map[string]string{
+			"node_id": nodeID,
+		})
+		return nil
 
 	// Service operations
 	case "create_service":
@@ -75,21 +164,43 @@ func (f *WarrenFSM) Apply(log *raft.Log) interface{} {
 		if err := json.Unmarshal(cmd.Data, &service); err != nil {
 			return err
 		}
-		return f.store.CreateService(&service)
+		if err := f.store.CreateService(&service); err != nil {
+			return err
+		}
+		f.publishServiceEvent(events.EventServiceCreated, &service, fmt.Sprintf("Service %s created", service.Name))
+		return nil
 
 	case "update_service":
 		var service types.Service
 		if err := json.Unmarshal(cmd.Data, &service); err != nil {
 			return err
 		}
-		return f.store.UpdateService(&service)
+		if err := f.store.UpdateService(&service); err != nil {
+			return err
+		}
+		f.publishServiceEvent(events.EventServiceUpdated, &service, fmt.Sprintf("Service %s updated", service.Name))
+		return nil
 
 	case "delete_service":
 		var serviceID string
 		if err := json.Unmarshal(cmd.Data, &serviceID); err != nil {
 			return err
 		}
-		return f.store.DeleteService(serviceID)
+		service, _ := f.store.GetService(serviceID)
+		if err := f.store.DeleteService(serviceID); err != nil {
+			return err
+		}
+		revisions, _ := f.store.ListServiceRevisions(serviceID)
+		for _, revision := range revisions {
+			if err := f.store.DeleteServiceRevision(revision.ID); err != nil {
+				return err
+			}
+		}
+		if service == nil {
+			service = &types.Service{ID: serviceID}
+		}
+		f.publishServiceEvent(events.EventServiceDeleted, service, fmt.Sprintf("Service %s deleted", service.Name))
+		return nil
 
 	// Container operations
 	case "create_container":
@@ -97,21 +208,83 @@ func (f *WarrenFSM) Apply(log *raft.Log) interface{} {
 		if err := json.Unmarshal(cmd.Data, &container); err != nil {
 			return err
 		}
-		return f.store.CreateContainer(&container)
+		if err := f.store.CreateContainer(&container); err != nil {
+			return err
+		}
+		if err := f.refreshNodeAllocation(container.NodeID); err != nil {
+			return err
+		}
+		f.publishContainerEvent(events.EventTaskCreated, &container,
+			fmt.Sprintf("Container %s of service %s assigned to node %s", container.ID, container.ServiceName, container.NodeID))
+		return nil
 
 	case "update_container":
 		var container types.Container
 		if err := json.Unmarshal(cmd.Data, &container); err != nil {
 			return err
 		}
-		return f.store.UpdateContainer(&container)
+		previous, _ := f.store.GetContainer(container.ID)
+		if err := f.store.UpdateContainer(&container); err != nil {
+			return err
+		}
+		if err := f.refreshNodeAllocation(container.NodeID); err != nil {
+			return err
+		}
+		if previous != nil && previous.NodeID != container.NodeID {
+			if err := f.refreshNodeAllocation(previous.NodeID); err != nil {
+				return err
+			}
+			// A pending container placed on a node is a new assignment for it
+			if container.NodeID != "" {
+				f.publishContainerEvent(events.EventTaskCreated, &container,
+					fmt.Sprintf("Container %s of service %s assigned to node %s", container.ID, container.ServiceName, container.NodeID))
+			}
+		}
+		if previous == nil || previous.DesiredState != container.DesiredState {
+			f.publishContainerEvent(events.EventTaskUpdated, &container,
+				fmt.Sprintf("Container %s of service %s desired state is now %s", container.ID, container.ServiceName, container.DesiredState))
+		}
+		if container.PreemptedBy != "" && (previous == nil || previous.PreemptedBy == "") {
+			f.publishContainerEvent(events.EventTaskPreempted, &container,
+				fmt.Sprintf("Container %s of service %s preempted by service %s", container.ID, container.ServiceName, container.PreemptedBy))
+		}
+		if previous == nil || previous.ActualState != container.ActualState {
+			switch container.ActualState {
+			case types.ContainerStateRunning:
+				f.publishContainerEvent(events.EventTaskStarted, &container,
+					fmt.Sprintf("Container %s of service %s is running", container.ID, container.ServiceName))
+			case types.ContainerStateFailed:
+				f.publishContainerEvent(events.EventTaskFailed, &container,
+					fmt.Sprintf("Container %s of service %s failed: %s", container.ID, container.ServiceName, container.Error))
+			case types.ContainerStateComplete:
+				f.publishContainerEvent(events.EventTaskCompleted, &container,
+					fmt.Sprintf("Container %s of service %s completed", container.ID, container.ServiceName))
+			}
+		}
+		if previous != nil && healthChanged(previous.HealthStatus, container.HealthStatus) {
+			f.publishContainerEvent(events.EventTaskHealthChanged, &container,
+				fmt.Sprintf("Container %s of service %s health is now %s", container.ID, container.ServiceName, healthLabel(container.HealthStatus)))
+		}
+		return nil
 
 	case "delete_container":
 		var containerID string
 		if err := json.Unmarshal(cmd.Data, &containerID); err != nil {
 			return err
 		}
-		return f.store.DeleteContainer(containerID)
+		container, _ := f.store.GetContainer(containerID)
+		if err := f.store.DeleteContainer(containerID); err != nil {
+			return err
+		}
+		if container == nil {
+			container = &types.Container{ID: containerID}
+		}
+		if err := f.refreshNodeAllocation(container.NodeID); err != nil {
+			return err
+		}
+		f.publishContainerEvent(events.EventTaskDeleted, container,
+			fmt.Sprintf("Container %s of service %s removed", container.ID, container.ServiceName))
+		return nil
 
 	// Secret operations
 	case "create_secret":
@@ -119,14 +292,27 @@ func (f *WarrenFSM) Apply(log *raft.Log) interface{} {
 		if err := json.Unmarshal(cmd.Data, &secret); err != nil {
 			return err
 		}
-		return f.store.CreateSecret(&secret)
+		if err := f.store.CreateSecret(&secret); err != nil {
+			return err
+		}
+		f.publish(events.EventSecretCreated, fmt.Sprintf("Secret %s created", secret.Name), map[string]string{
+			"secret_id":   secret.ID,
+			"secret_name": secret.Name,
+		})
+		return nil
 
 	case "delete_secret":
 		var secretID string
 		if err := json.Unmarshal(cmd.Data, &secretID); err != nil {
 			return err
 		}
-		return f.store.DeleteSecret(secretID)
+		if err := f.store.DeleteSecret(secretID); err != nil {
+			return err
+		}
+		f.publish(events.EventSecretDeleted, fmt.Sprintf("Secret %s deleted", secretID), map[string]string{
+			"secret_id": secretID,
+		})
+		return nil
 
 	// Volume operations
 	case "create_volume":
@@ -134,14 +320,80 @@ func (f *WarrenFSM) Apply(log *raft.Log) interface{} {
 		if err := json.Unmarshal(cmd.Data, &volume); err != nil {
 			return err
 		}
-		return f.store.CreateVolume(&volume)
+		if err := f.store.CreateVolume(&volume); err != nil {
+			return err
+		}
+		f.publish(events.EventVolumeCreated, fmt.Sprintf("Volume %s created", volume.Name), map[string]string{
+			"volume_id":   volume.ID,
+			"volume_name": volume.Name,
+			"node_id":     volume.NodeID,
+		})
+		return nil
 
 	case "delete_volume":
 		var volumeID string
 		if err := json.Unmarshal(cmd.Data, &volumeID); err != nil {
 			return err
 		}
-		return f.store.DeleteVolume(volumeID)
+		if err := f.store.DeleteVolume(volumeID); err != nil {
+			return err
+		}
+		f.publish(events.EventVolumeDeleted, fmt.Sprintf("Volume %s deleted", volumeID), map[string]string{
+			"volume_id": volumeID,
+		})
+		return nil
+
+	// Join token operations
+	case "create_join_token":
+		var token types.JoinToken
+		if err := json.Unmarshal(cmd.Data, &token); err != nil {
+			return err
+		}
+		return f.store.CreateJoinToken(&token)
+
+	case "delete_join_token":
+		var tokenID string
+		if err := json.Unmarshal(cmd.Data, &tokenID); err != nil {
+			return err
+		}
+		return f.store.DeleteJoinToken(tokenID)
+
+	// Deployment operations
+	case "create_deployment":
+		var deployment types.Deployment
+		if err := json.Unmarshal(cmd.Data, &deployment); err != nil {
+			return err
+		}
+		return f.store.CreateDeployment(&deployment)
+
+	case "update_deployment":
+		var deployment types.Deployment
+		if err := json.Unmarshal(cmd.Data, &deployment); err != nil {
+			return err
+		}
+		return f.store.UpdateDeployment(&deployment)
+
+	case "delete_deployment":
+		var deploymentID string
+		if err := json.Unmarshal(cmd.Data, &deploymentID); err != nil {
+			return err
+		}
+		return f.store.DeleteDeployment(deploymentID)
+
+	// Service revision operations
+	case "create_service_revision":
+		var revision types.ServiceRevision
+		if err := json.Unmarshal(cmd.Data, &revision); err != nil {
+			return err
+		}
+		return f.store.CreateServiceRevision(&revision)
+
+	case "delete_service_revision":
+		var revisionID string
+		if err := json.Unmarshal(cmd.Data, &revisionID); err != nil {
+			return err
+		}
+		return f.store.DeleteServiceRevision(revisionID)
 
 	// Ingress operations
 	case "CreateIngress":
@@ -185,6 +437,61 @@ func (f *WarrenFSM) Apply(log *raft.Log) interface{} {
 	}
 }
 
+// publish sends an event for an applied state change to the broker
+func (f *WarrenFSM) publish(eventType events.EventType, message string, metadata map[string]string) {
+	if f.broker == nil || f.replaying {
+		return
+	}
+	f.broker.Publish(&events.Event{
+		Type:     eventType,
+		Message:  message,
+		Metadata: metadata,
+	})
+}
+
+func (f *WarrenFSM) publishNodeEvent(eventType events.EventType, node *types.Node, message string) {
+	f.publish(eventType, message, map[string]string{
+		"node_id":   node.ID,
+		"node_role": string(node.Role),
+	})
+}
+
+func (f *WarrenFSM) publishServiceEvent(eventType events.EventType, service *types.Service, message string) {
+	f.publish(eventType, message, map[string]string{
+		"service_id":   service.ID,
+		"service_name": service.Name,
+	})
+}
+
+func (f *WarrenFSM) publishContainerEvent(eventType events.EventType, container *types.Container, message string) {
+	f.publish(eventType, message, map[string]string{
+		"container_id":  container.ID,
+		"service_id":    container.ServiceID,
+		"service_name":  container.ServiceName,
+		"node_id":       container.NodeID,
+		"state":         string(container.ActualState),
+		"desired_state": string(container.DesiredState),
+	})
+}
+
+// healthChanged reports whether a container flipped between healthy and unhealthy
+func healthChanged(previous, current *types.HealthStatus) bool {
+	if current == nil {
+		return false
+	}
+	if previous == nil {
+		return !current.Healthy
+	}
+	return previous.Healthy != current.Healthy
+}
+
+func healthLabel(status *types.HealthStatus) string {
+	if status != nil && status.Healthy {
+		return "healthy"
+	}
+	return "unhealthy"
+}
+
 // Snapshot creates a point-in-time snapshot of the FSM
 // This is called periodically by Raft to compact the log
 func (f *WarrenFSM) Snapshot() (raft.FSMSnapshot, error) {
@@ -232,6 +539,25 @@ func (f *WarrenFSM) Snapshot() (raft.FSMSnapshot, error) {
 		return nil, fmt.Errorf("failed to list TLS certificates: %w", err)
 	}
 
+	joinTokens, err := f.store.ListJoinTokens()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list join tokens: %w", err)
+	}
+
+	deployments, err := f.store.ListDeployments()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+
+	var revisions []*types.ServiceRevision
+	for _, service := range services {
+		serviceRevisions, err := f.store.ListServiceRevisions(service.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list service revisions: %w", err)
+		}
+		revisions = append(revisions, serviceRevisions...)
+	}
+
 	snapshot := &WarrenSnapshot{
 		Nodes:           nodes,
 		Services:        services,
@@ -241,6 +567,9 @@ func (f *WarrenFSM) Snapshot() (raft.FSMSnapshot, error) {
 		Networks:        networks,
 		Ingresses:       ingresses,
 		TLSCertificates: tlsCerts,
+		JoinTokens:      joinTokens,
+		Deployments:     deployments,
+		Revisions:       revisions,
 	}
 
 	return snapshot, nil
@@ -308,6 +637,24 @@ func (f *WarrenFSM) Restore(rc io.ReadCloser) error {
 		}
 	}
 
+	for _, token := range snapshot.JoinTokens {
+		if err := f.store.CreateJoinToken(token); err != nil {
+			return fmt.Errorf("failed to restore join token: %w", err)
+		}
+	}
+
+	for _, deployment := range snapshot.Deployments {
+		if err := f.store.CreateDeployment(deployment); err != nil {
+			return fmt.Errorf("failed to restore deployment: %w", err)
+		}
+	}
+
+	for _, revision := range snapshot.Revisions {
+		if err := f.store.CreateServiceRevision(revision); err != nil {
+			return fmt.Errorf("failed to restore service revision: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -321,6 +668,9 @@ type WarrenSnapshot struct {
 	Networks        []*types.Network
 	Ingresses       []*types.Ingress
 	TLSCertificates []*types.TLSCertificate
+	JoinTokens      []*types.JoinToken // Hashed; plaintext tokens are never replicated
+	Deployments     []*types.Deployment
+	Revisions       []*types.ServiceRevision
 }
 
 // Persist writes the snapshot to the given SnapshotSink
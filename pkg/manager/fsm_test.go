@@ -0,0 +1,133 @@
+package manager
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/cuemby/warren/pkg/events"
+	"github.com/cuemby/warren/pkg/storage"
+	"github.com/cuemby/warren/pkg/types"
+	"github.com/hashicorp/raft"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// applyCommand applies a command to an FSM, as a committed Raft log entry would be
+func applyCommand(t *testing.T, fsm *WarrenFSM, op string, data interface{}) {
+	t.Helper()
+	raw, err := json.Marshal(data)
+	require.NoError(t, err)
+	cmd, err := json.Marshal(Command{Op: op, Data: raw})
+	require.NoError(t, err)
+	if resp := fsm.Apply(&raft.Log{Data: cmd}); resp != nil {
+		if err, ok := resp.(error); ok {
+			require.NoError(t, err)
+		}
+	}
+}
+
+// TestHeartbeatKeepsNodeMetadata tests that a node update built from a read
+// older than a label or taint change does not undo it
+func TestHeartbeatKeepsNodeMetadata(t *testing.T) {
+	store, err := storage.NewBoltStore(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	fsm := NewWarrenFSM(store, nil)
+
+	applyCommand(t, fsm, "create_node", &types.Node{
+		ID:     "node-1",
+		Status: types.NodeStatusReady,
+		Labels: map[string]string{"zone": "eu-1"},
+		Taints: []types.Taint{{Key: "gpu", Effect: types.TaintEffectNoSchedule}},
+	})
+
+	// A heartbeat reads the node...
+	stale, err := store.GetNode("node-1")
+	require.NoError(t, err)
+
+	// ...while an operator changes its labels and taints
+	applyCommand(t, fsm, "update_node_metadata", &NodeMetadataUpdate{
+		NodeID:      "node-1",
+		LabelAdd:    map[string]string{"disk": "ssd"},
+		LabelRemove: []string{"zone"},
+		TaintAdd:    []types.Taint{{Key: "dedicated", Value: "db", Effect: types.TaintEffectNoExecute}},
+		TaintRemove: []types.Taint{{Key: "gpu"}},
+	})
+
+	stale.Status = types.NodeStatusDown
+	applyCommand(t, fsm, "update_node", stale)
+
+	node, err := store.GetNode("node-1")
+	require.NoError(t, err)
+	assert.Equal(t, types.NodeStatusDown, node.Status)
+	assert.Equal(t, map[string]string{"disk": "ssd"}, node.Labels)
+	assert.Equal(t, []types.Taint{{Key: "dedicated", Value: "db", Effect: types.TaintEffectNoExecute}}, node.Taints)
+}
+
+// TestNodeMetadataPublishesEvent tests that a label or taint change is
+// published, so that the scheduler acts on it without waiting for a resync
+func TestNodeMetadataPublishesEvent(t *testing.T) {
+	store, err := storage.NewBoltStore(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	broker := events.NewBroker()
+	broker.Start()
+	defer broker.Stop()
+	fsm := NewWarrenFSM(store, nil)
+	applyCommand(t, fsm, "create_node", &types.Node{ID: "node-1", Role: types.NodeRoleWorker})
+
+	fsm.broker = broker
+	sub := broker.Subscribe()
+	defer broker.Unsubscribe(sub)
+	applyCommand(t, fsm, "update_node_metadata", &NodeMetadataUpdate{
+		NodeID:   "node-1",
+		LabelAdd: map[string]string{"disk": "ssd"},
+	})
+
+	select {
+	case event := <-sub:
+		assert.Equal(t, events.EventNodeUpdated, event.Type)
+		assert.Equal(t, "node-1", event.Metadata["node_id"])
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for node event")
+	}
+}
+
+// TestReplayPublishesNoEvents tests that entries committed before the
+// manager started, replayed from its log or sent by the leader to catch it
+// up, publish no events, and that new entries do
+func TestReplayPublishesNoEvents(t *testing.T) {
+	store, err := storage.NewBoltStore(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	broker := events.NewBroker()
+	broker.Start()
+	defer broker.Stop()
+	fsm := NewWarrenFSM(store, broker)
+	fsm.setReplayIndex(2)
+
+	apply := func(index uint64, appendedAt time.Time, name string) {
+		data, err := json.Marshal(&types.Service{ID: name, Name: name})
+		require.NoError(t, err)
+		cmd, err := json.Marshal(Command{Op: "create_service", Data: data})
+		require.NoError(t, err)
+		assert.Nil(t, fsm.Apply(&raft.Log{Index: index, AppendedAt: appendedAt, Data: cmd}))
+	}
+	apply(1, fsm.started.Add(-time.Hour), "replayed")
+	apply(2, time.Time{}, "replayed-unknown-age")
+	apply(3, fsm.started.Add(-time.Minute), "caught-up")
+	apply(4, time.Now(), "new")
+
+	require.Eventually(t, func() bool {
+		history, err := broker.History("")
+		return err == nil && len(history) == 1
+	}, time.Second, 5*time.Millisecond)
+	history, err := broker.History("")
+	require.NoError(t, err)
+	assert.Equal(t, "new", history[0].Metadata["service_name"])
+
+	services, err := store.ListServices()
+	require.NoError(t, err)
+	assert.Len(t, services, 4)
+}
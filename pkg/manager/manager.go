@@ -11,6 +11,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/cuemby/warren/pkg/client"
@@ -20,13 +21,12 @@ import (
 	"github.com/cuemby/warren/pkg/ingress"
 	"github.com/cuemby/warren/pkg/log"
 	"github.com/cuemby/warren/pkg/metrics"
+	"github.com/cuemby/warren/pkg/network"
 	"github.com/cuemby/warren/pkg/security"
 	"github.com/cuemby/warren/pkg/storage"
 	"github.com/cuemby/warren/pkg/types"
 	"github.com/hashicorp/raft"
 	raftboltdb "github.com/hashicorp/raft-boltdb"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 // Manager represents a Warren cluster manager node
@@ -51,6 +51,14 @@ type Manager struct {
 	acmeClient    *ingress.ACMEClient
 	acmeEmail     string
 	deployer      *deploy.Deployer
+	// revisionMu serializes numbering service revisions
+	revisionMu sync.Mutex
+	// overlay network settings; node IPs and service VIPs are allocated under ipamMu
+	networkConfig *types.NetworkConfig
+	ipamMu        sync.Mutex
+	// service endpoints behind VIPs, kept fresh by cluster events
+	endpoints       *ingress.LoadBalancer
+	endpointsCancel context.CancelFunc
 	// embeddedWorker tracking (lifecycle managed at cmd level)
 	embeddedWorker       interface{} // *worker.Worker (avoid import cycle)
 	embeddedWorkerCancel context.CancelFunc
@@ -58,9 +66,11 @@ type Manager struct {
 
 // Config holds configuration for creating a Manager
 type Config struct {
-	NodeID   string
-	BindAddr string
-	DataDir  string
+	NodeID        string
+	BindAddr      string
+	DataDir       string
+	ClusterSubnet string // Overlay subnet for node IPs (default network.DefaultClusterSubnet)
+	ServiceSubnet string // Subnet reserved for service VIPs (default network.DefaultServiceSubnet)
 }
 
 // NewManager creates a new Manager instance
@@ -75,11 +85,12 @@ func NewManager(cfg *Config) (*Manager, error) {
 		return nil, fmt.Errorf("failed to create store: %w", err)
 	}
 
-	// Create FSM
-	fsm := NewWarrenFSM(store)
+	// Create event broker
+	eventBroker := events.NewBroker()
+	eventBroker.Start()
 
-	// Create token manager
-	tokenManager := NewTokenManager()
+	// Create FSM (publishes applied state changes to the event broker)
+	fsm := NewWarrenFSM(store, eventBroker)
 
 	// Create secrets manager with cluster-derived key
 	clusterKey := security.DeriveKeyFromClusterID(cfg.NodeID) // Using node ID as cluster ID for now
@@ -96,10 +107,6 @@ func NewManager(cfg *Config) (*Manager, error) {
 	// Create Certificate Authority
 	ca := security.NewCertAuthority(store)
 
-	// Create event broker
-	eventBroker := events.NewBroker()
-	eventBroker.Start()
-
 	// Create DNS server
 	dnsServer := dns.NewServer(store, nil) // Use default config
 	dnsCtx, dnsCancel := context.WithCancel(context.Background())
@@ -112,13 +119,30 @@ func NewManager(cfg *Config) (*Manager, error) {
 		store:          store,
 		secretsManager: secretsManager,
 		ca:             ca,
-		tokenManager:   tokenManager,
 		eventBroker:    eventBroker,
 		dnsServer:      dnsServer,
 		dnsCtx:         dnsCtx,
 		dnsCancel:      dnsCancel,
 	}
 
+	// Overlay network configuration
+	m.networkConfig = network.DefaultNetworkConfig()
+	if cfg.ClusterSubnet != "" {
+		m.networkConfig.ClusterSubnet = cfg.ClusterSubnet
+	}
+	if cfg.ServiceSubnet != "" {
+		m.networkConfig.ServiceSubnet = cfg.ServiceSubnet
+	}
+
+	// Resolve service endpoints for VIP load balancing on workers
+	m.endpoints = ingress.NewLoadBalancer(store)
+	endpointsCtx, endpointsCancel := context.WithCancel(context.Background())
+	m.endpointsCancel = endpointsCancel
+	go m.endpoints.WatchEvents(endpointsCtx, eventBroker)
+
+	// Create token manager (tokens are replicated through Raft)
+	m.tokenManager = NewTokenManager(store, m.Apply)
+
 	// Create deployer (needs manager reference, so create after manager)
 	m.deployer = deploy.NewDeployer(m)
 
@@ -177,6 +201,14 @@ func (m *Manager) Bootstrap() error {
 		return fmt.Errorf("failed to create stable store: %w", err)
 	}
 
+	// Entries already in the log are replayed on start, and their events
+	// were published when they were first applied
+	lastIndex, err := logStore.LastIndex()
+	if err != nil {
+		return fmt.Errorf("failed to read log store: %w", err)
+	}
+	m.fsm.setReplayIndex(lastIndex)
+
 	// Create Raft instance
 	r, err := raft.NewRaft(config, m.fsm, logStore, stableStore, snapshotStore, transport)
 	if err != nil {
@@ -260,6 +292,14 @@ func (m *Manager) Join(leaderAddr string, token string) error {
 		return fmt.Errorf("failed to create stable store: %w", err)
 	}
 
+	// Entries already in the log are replayed on start, and their events
+	// were published when they were first applied
+	lastIndex, err := logStore.LastIndex()
+	if err != nil {
+		return fmt.Errorf("failed to read log store: %w", err)
+	}
+	m.fsm.setReplayIndex(lastIndex)
+
 	// Create Raft instance
 	r, err := raft.NewRaft(config, m.fsm, logStore, stableStore, snapshotStore, transport)
 	if err != nil {
@@ -398,6 +438,24 @@ func (m *Manager) GetRaftStats() map[string]interface{} {
 	return stats
 }
 
+// ServiceTraffic returns the ingress traffic a service served since a
+// given time. Each manager only counts the requests its own ingress proxy
+// served, so the traffic is only complete in a cluster with one manager;
+// with more, an error is returned rather than part of it.
+func (m *Manager) ServiceTraffic(serviceName string, since time.Time) (types.TrafficSummary, error) {
+	if m.ingressProxy == nil {
+		return types.TrafficSummary{}, fmt.Errorf("ingress proxy is not running")
+	}
+	servers, err := m.GetClusterServers()
+	if err != nil {
+		return types.TrafficSummary{}, err
+	}
+	if len(servers) > 1 {
+		return types.TrafficSummary{}, fmt.Errorf("traffic stats need a single manager serving ingress, the cluster has %d", len(servers))
+	}
+	return m.ingressProxy.ServiceTraffic(serviceName, since), nil
+}
+
 // GetEventBroker returns the event broker
 func (m *Manager) GetEventBroker() *events.Broker {
 	return m.eventBroker
@@ -454,6 +512,115 @@ func (m *Manager) CreateNode(node *types.Node) error {
 	return m.Apply(cmd)
 }
 
+// RegisterNode adds a node to the cluster, keeping the overlay IP it was
+// given before or allocating a free one from the cluster subnet. Labels set
+// on a previous registration (e.g. with "warren node update") are kept, and
+// so are taints unless the registration sets its own.
+func (m *Manager) RegisterNode(node *types.Node) error {
+	m.ipamMu.Lock()
+	defer m.ipamMu.Unlock()
+
+	if existing, err := m.store.GetNode(node.ID); err == nil {
+		if len(existing.Labels) > 0 {
+			labels := make(map[string]string, len(existing.Labels)+len(node.Labels))
+			for k, v := range existing.Labels {
+				labels[k] = v
+			}
+			for k, v := range node.Labels {
+				labels[k] = v
+			}
+			node.Labels = labels
+		}
+		if node.Taints == nil {
+			node.Taints = existing.Taints
+		}
+	}
+
+	config, err := m.NetworkConfig()
+	if err != nil {
+		return err
+	}
+
+	ip, err := network.AllocateNodeIP(config, node.ID)
+	if err != nil {
+		return fmt.Errorf("failed to allocate overlay IP: %w", err)
+	}
+	node.OverlayIP = ip
+
+	return m.CreateNode(node)
+}
+
+// NetworkConfig returns the cluster network configuration, with the overlay
+// IP of every registered node and the virtual IP of every service
+func (m *Manager) NetworkConfig() (*types.NetworkConfig, error) {
+	nodes, err := m.store.ListNodes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	services, err := m.store.ListServices()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+
+	config := &types.NetworkConfig{
+		ClusterSubnet: m.networkConfig.ClusterSubnet,
+		ServiceSubnet: m.networkConfig.ServiceSubnet,
+		NodeIPs:       make(map[string]net.IP, len(nodes)),
+		ServiceVIPs:   make(map[string]net.IP, len(services)),
+	}
+	for _, node := range nodes {
+		if node.OverlayIP != nil {
+			config.NodeIPs[node.ID] = node.OverlayIP
+		}
+	}
+	for _, service := range services {
+		if service.VIP != nil {
+			config.ServiceVIPs[service.ID] = service.VIP
+		}
+	}
+
+	return config, nil
+}
+
+// ServiceEndpoints returns the healthy replicas of a service reachable on
+// the given container port and protocol (read from local store)
+func (m *Manager) ServiceEndpoints(serviceName string, port int, protocol string) ([]*ingress.Backend, error) {
+	return m.endpoints.Backends(serviceName, port, protocol)
+}
+
+// assignServiceVIP keeps a service's VIP if no other service holds it, and
+// otherwise allocates a free one. Callers must hold ipamMu.
+func (m *Manager) assignServiceVIP(service *types.Service) error {
+	config, err := m.NetworkConfig()
+	if err != nil {
+		return err
+	}
+
+	// A copied service (e.g. a blue-green or canary version) must not
+	// share the original's VIP
+	delete(config.ServiceVIPs, service.ID)
+	if service.VIP != nil {
+		taken := false
+		for _, vip := range config.ServiceVIPs {
+			if vip.Equal(service.VIP) {
+				taken = true
+				break
+			}
+		}
+		if !taken {
+			return nil
+		}
+	}
+
+	vip, err := network.AllocateServiceVIP(config, service.ID)
+	if err != nil {
+		return fmt.Errorf("failed to allocate service VIP: %w", err)
+	}
+	service.VIP = vip
+	return nil
+}
+
 // UpdateNode updates a node in the cluster
 func (m *Manager) UpdateNode(node *types.Node) error {
 	data, err := json.Marshal(node)
@@ -484,8 +651,15 @@ func (m *Manager) DeleteNode(id string) error {
 	return m.Apply(cmd)
 }
 
-// CreateService creates a new service
+// CreateService creates a new service, allocating its virtual IP
 func (m *Manager) CreateService(service *types.Service) error {
+	m.ipamMu.Lock()
+	defer m.ipamMu.Unlock()
+
+	if err := m.assignServiceVIP(service); err != nil {
+		return err
+	}
+
 	data, err := json.Marshal(service)
 	if err != nil {
 		return err
@@ -499,8 +673,21 @@ func (m *Manager) CreateService(service *types.Service) error {
 	return m.Apply(cmd)
 }
 
-// UpdateService updates an existing service
+// UpdateService updates an existing service. The virtual IP is kept, or
+// allocated for services created before VIPs existed.
 func (m *Manager) UpdateService(service *types.Service) error {
+	m.ipamMu.Lock()
+	defer m.ipamMu.Unlock()
+
+	if service.VIP == nil {
+		if existing, err := m.store.GetService(service.ID); err == nil {
+			service.VIP = existing.VIP
+		}
+	}
+	if err := m.assignServiceVIP(service); err != nil {
+		return err
+	}
+
 	data, err := json.Marshal(service)
 	if err != nil {
 		return err
@@ -739,6 +926,30 @@ func (m *Manager) ValidateJoinToken(token string) (string, error) {
 	return m.tokenManager.ValidateToken(token)
 }
 
+// ListJoinTokens returns all join tokens (read from local store)
+func (m *Manager) ListJoinTokens() ([]*JoinToken, error) {
+	return m.tokenManager.ListTokens()
+}
+
+// RevokeJoinToken revokes a join token by ID or by the token itself
+func (m *Manager) RevokeJoinToken(idOrToken string) error {
+	if !m.IsLeader() {
+		return fmt.Errorf("not the leader, tokens can only be revoked by the leader")
+	}
+
+	return m.tokenManager.RevokeToken(idOrToken)
+}
+
+// RotateJoinToken revokes all join tokens for a role and generates a new one
+func (m *Manager) RotateJoinToken(role string) (*JoinToken, error) {
+	if !m.IsLeader() {
+		return nil, fmt.Errorf("not the leader, tokens can only be rotated by the leader")
+	}
+
+	// Token valid for 24 hours
+	return m.tokenManager.RotateToken(role, 24*time.Hour)
+}
+
 // Shutdown gracefully shuts down the manager
 func (m *Manager) Shutdown() error {
 	// Stop embedded worker first (if running)
@@ -758,6 +969,11 @@ func (m *Manager) Shutdown() error {
 		m.dnsCancel()
 	}
 
+	// Stop watching events for service endpoints
+	if m.endpointsCancel != nil {
+		m.endpointsCancel()
+	}
+
 	// Stop event broker
 	if m.eventBroker != nil {
 		m.eventBroker.Stop()
@@ -968,19 +1184,18 @@ func (m *Manager) DeleteIngress(ingressID string) error {
 
 // StartIngress starts the ingress HTTP proxy on port 80
 func (m *Manager) StartIngress() error {
-	// Create gRPC connection for the ingress proxy to query the manager
-	// For M7.1 MVP, we'll use a simple insecure connection (mTLS will be added in M7.2)
-	grpcConn, err := grpc.NewClient(m.bindAddr, grpc.WithTransportCredentials(insecure.NewCredentials())) // #nosec G402
-	if err != nil {
-		return fmt.Errorf("failed to create gRPC connection for ingress: %w", err)
-	}
-
 	// Create ingress proxy
-	m.ingressProxy = ingress.NewProxy(m.store, m.bindAddr, grpcConn)
+	m.ingressProxy = ingress.NewProxy(m.store)
 
 	// Create context for ingress proxy
 	m.ingressCtx, m.ingressCancel = context.WithCancel(context.Background())
 
+	// Keep backend endpoints in sync with task, service and node changes
+	go m.ingressProxy.WatchEvents(m.ingressCtx, m.eventBroker)
+
+	// Let canary updates compare the traffic of canary and stable services
+	m.deployer.SetTrafficSource(m)
+
 	// Start proxy in goroutine
 	go func() {
 		if err := m.ingressProxy.Start(m.ingressCtx); err != nil {
@@ -1145,6 +1360,137 @@ func (m *Manager) GetDeployer() *deploy.Deployer {
 	return m.deployer
 }
 
+// CreateDeployment records a new deployment
+func (m *Manager) CreateDeployment(deployment *types.Deployment) error {
+	data, err := json.Marshal(deployment)
+	if err != nil {
+		return err
+	}
+
+	cmd := Command{
+		Op:   "create_deployment",
+		Data: data,
+	}
+
+	return m.Apply(cmd)
+}
+
+// UpdateDeployment records the progress of a deployment
+func (m *Manager) UpdateDeployment(deployment *types.Deployment) error {
+	data, err := json.Marshal(deployment)
+	if err != nil {
+		return err
+	}
+
+	cmd := Command{
+		Op:   "update_deployment",
+		Data: data,
+	}
+
+	return m.Apply(cmd)
+}
+
+// DeleteDeployment removes a deployment
+func (m *Manager) DeleteDeployment(id string) error {
+	data, err := json.Marshal(id)
+	if err != nil {
+		return err
+	}
+
+	cmd := Command{
+		Op:   "delete_deployment",
+		Data: data,
+	}
+
+	return m.Apply(cmd)
+}
+
+// GetDeployment retrieves a deployment by ID
+func (m *Manager) GetDeployment(id string) (*types.Deployment, error) {
+	return m.store.GetDeployment(id)
+}
+
+// ListDeployments returns all deployments
+func (m *Manager) ListDeployments() ([]*types.Deployment, error) {
+	return m.store.ListDeployments()
+}
+
+// --- Service Revision Operations ---
+
+// maxServiceRevisions is how many revisions are kept per service
+const maxServiceRevisions = 10
+
+// RecordServiceRevision records a service's current spec as its next
+// revision, dropping the oldest beyond maxServiceRevisions. It returns nil
+// if the spec is the same as the latest revision's.
+func (m *Manager) RecordServiceRevision(service *types.Service, changeCause string) (*types.ServiceRevision, error) {
+	m.revisionMu.Lock()
+	defer m.revisionMu.Unlock()
+
+	revisions, err := m.store.ListServiceRevisions(service.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service revisions: %w", err)
+	}
+
+	number := 1
+	if len(revisions) > 0 {
+		latest := revisions[len(revisions)-1]
+		if deploy.SameSpec(latest.Spec, service) {
+			return nil, nil
+		}
+		number = latest.Revision + 1
+	}
+
+	revision := &types.ServiceRevision{
+		ID:          fmt.Sprintf("%s-%d", service.ID, number),
+		ServiceID:   service.ID,
+		Revision:    number,
+		Spec:        deploy.ServiceSpec(service),
+		ChangeCause: changeCause,
+		CreatedAt:   time.Now(),
+	}
+	data, err := json.Marshal(revision)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.Apply(Command{Op: "create_service_revision", Data: data}); err != nil {
+		return nil, err
+	}
+
+	revisions = append(revisions, revision)
+	for len(revisions) > maxServiceRevisions {
+		data, err := json.Marshal(revisions[0].ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := m.Apply(Command{Op: "delete_service_revision", Data: data}); err != nil {
+			return nil, err
+		}
+		revisions = revisions[1:]
+	}
+
+	return revision, nil
+}
+
+// ListServiceRevisions returns the revisions of a service, oldest first
+func (m *Manager) ListServiceRevisions(serviceID string) ([]*types.ServiceRevision, error) {
+	return m.store.ListServiceRevisions(serviceID)
+}
+
+// GetServiceRevision returns a revision of a service by number
+func (m *Manager) GetServiceRevision(serviceID string, number int) (*types.ServiceRevision, error) {
+	revisions, err := m.store.ListServiceRevisions(serviceID)
+	if err != nil {
+		return nil, err
+	}
+	for _, revision := range revisions {
+		if revision.Revision == number {
+			return revision, nil
+		}
+	}
+	return nil, fmt.Errorf("revision %d not found", number)
+}
+
 // --- Embedded Worker Operations (Hybrid Mode) ---
 
 // StartEmbeddedWorker starts an embedded worker in the same process (hybrid mode)
@@ -1197,3 +1543,82 @@ func (m *Manager) UpdateNodeRole(nodeID string, role types.NodeRole) error {
 
 	return nil
 }
+
+// UpdateNodeLabels sets the labels in add, overwriting existing values, and
+// removes the label keys in remove
+func (m *Manager) UpdateNodeLabels(nodeID string, add map[string]string, remove []string) (*types.Node, error) {
+	return m.updateNodeMetadata(&NodeMetadataUpdate{NodeID: nodeID, LabelAdd: add, LabelRemove: remove})
+}
+
+// UpdateNodeTaints removes the taints in remove, matching by key and, if
+// set, effect, then adds the taints in add, replacing any with the same key
+// and effect
+func (m *Manager) UpdateNodeTaints(nodeID string, add, remove []types.Taint) (*types.Node, error) {
+	return m.updateNodeMetadata(&NodeMetadataUpdate{NodeID: nodeID, TaintAdd: add, TaintRemove: remove})
+}
+
+// updateNodeMetadata applies a label and taint change to a node through Raft
+// and returns the updated node
+func (m *Manager) updateNodeMetadata(update *NodeMetadataUpdate) (*types.Node, error) {
+	if _, err := m.GetNode(update.NodeID); err != nil {
+		return nil, fmt.Errorf("failed to get node: %w", err)
+	}
+
+	data, err := json.Marshal(update)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.Apply(Command{Op: "update_node_metadata", Data: data}); err != nil {
+		return nil, fmt.Errorf("failed to update node: %w", err)
+	}
+
+	return m.GetNode(update.NodeID)
+}
+
+// applyNodeMetadata applies a label and taint change to a node
+func applyNodeMetadata(node *types.Node, update *NodeMetadataUpdate) {
+	if len(update.LabelAdd) > 0 || len(update.LabelRemove) > 0 {
+		if node.Labels == nil {
+			node.Labels = make(map[string]string)
+		}
+		for _, key := range update.LabelRemove {
+			delete(node.Labels, key)
+		}
+		for key, value := range update.LabelAdd {
+			node.Labels[key] = value
+		}
+	}
+
+	if len(update.TaintAdd) > 0 || len(update.TaintRemove) > 0 {
+		var taints []types.Taint
+		for _, taint := range node.Taints {
+			if !matchesTaint(taint, update.TaintRemove) && !replacesTaint(taint, update.TaintAdd) {
+				taints = append(taints, taint)
+			}
+		}
+		node.Taints = append(taints, update.TaintAdd...)
+	}
+}
+
+// matchesTaint reports whether a taint has the key and, if set, the effect
+// of any of the given taints
+func matchesTaint(taint types.Taint, list []types.Taint) bool {
+	for _, t := range list {
+		if t.Key == taint.Key && (t.Effect == "" || t.Effect == taint.Effect) {
+			return true
+		}
+	}
+	return false
+}
+
+// replacesTaint reports whether a taint has the key and effect of any of
+// the given taints
+func replacesTaint(taint types.Taint, list []types.Taint) bool {
+	for _, t := range list {
+		if t.Key == taint.Key && t.Effect == taint.Effect {
+			return true
+		}
+	}
+	return false
+}
@@ -2,34 +2,49 @@ package manager
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
-	"sync"
+	"sort"
 	"time"
+
+	"github.com/cuemby/warren/pkg/storage"
+	"github.com/cuemby/warren/pkg/types"
 )
 
-// TokenManager manages join tokens for the cluster
+// tokenIDLength is the number of hash characters used as a token's ID
+const tokenIDLength = 12
+
+// TokenManager manages join tokens for the cluster.
+// Tokens are stored hashed in the replicated store, so a token generated on
+// one manager stays valid after leader election or a restart.
 type TokenManager struct {
-	tokens map[string]*JoinToken
-	mu     sync.RWMutex
+	store storage.Store
+	apply func(Command) error
 }
 
-// JoinToken represents a token for joining the cluster
+// JoinToken represents a token for joining the cluster.
+// Token holds the plaintext and is only set when the token is generated.
 type JoinToken struct {
+	ID        string
 	Token     string
 	Role      string // "manager" or "worker"
 	CreatedAt time.Time
 	ExpiresAt time.Time
 }
 
-// NewTokenManager creates a new token manager
-func NewTokenManager() *TokenManager {
+// NewTokenManager creates a new token manager that reads tokens from store
+// and writes them through apply (normally Manager.Apply)
+func NewTokenManager(store storage.Store, apply func(Command) error) *TokenManager {
 	return &TokenManager{
-		tokens: make(map[string]*JoinToken),
+		store: store,
+		apply: apply,
 	}
 }
 
-// GenerateToken generates a new join token
+// GenerateToken generates a new join token and replicates its hash
 func (tm *TokenManager) GenerateToken(role string, duration time.Duration) (*JoinToken, error) {
 	// Generate a random token
 	bytes := make([]byte, 32)
@@ -38,67 +53,141 @@ func (tm *TokenManager) GenerateToken(role string, duration time.Duration) (*Joi
 	}
 
 	token := hex.EncodeToString(bytes)
+	hash := hashToken(token)
 
-	jt := &JoinToken{
-		Token:     token,
+	now := time.Now()
+	stored := &types.JoinToken{
+		ID:        hash[:tokenIDLength],
+		Hash:      hash,
 		Role:      role,
-		CreatedAt: time.Now(),
-		ExpiresAt: time.Now().Add(duration),
+		CreatedAt: now,
+		ExpiresAt: now.Add(duration),
+	}
+
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return nil, err
+	}
+	if err := tm.apply(Command{Op: "create_join_token", Data: data}); err != nil {
+		return nil, fmt.Errorf("failed to store join token: %w", err)
 	}
 
-	tm.mu.Lock()
-	tm.tokens[token] = jt
-	tm.mu.Unlock()
+	// Opportunistically drop expired tokens so the list stays short
+	_ = tm.CleanupExpiredTokens()
 
+	jt := toJoinToken(stored)
+	jt.Token = token
 	return jt, nil
 }
 
 // ValidateToken validates a join token and returns its role
 func (tm *TokenManager) ValidateToken(token string) (string, error) {
-	tm.mu.RLock()
-	defer tm.mu.RUnlock()
+	hash := hashToken(token)
 
-	jt, exists := tm.tokens[token]
-	if !exists {
+	stored, err := tm.store.GetJoinToken(hash[:tokenIDLength])
+	if err != nil || subtle.ConstantTimeCompare([]byte(stored.Hash), []byte(hash)) != 1 {
 		return "", fmt.Errorf("invalid token")
 	}
 
-	if time.Now().After(jt.ExpiresAt) {
+	if time.Now().After(stored.ExpiresAt) {
 		return "", fmt.Errorf("token expired")
 	}
 
-	return jt.Role, nil
+	return stored.Role, nil
 }
 
-// RevokeToken revokes a join token
-func (tm *TokenManager) RevokeToken(token string) {
-	tm.mu.Lock()
-	delete(tm.tokens, token)
-	tm.mu.Unlock()
+// RevokeToken revokes a join token, given either its ID or the token itself
+func (tm *TokenManager) RevokeToken(idOrToken string) error {
+	id := idOrToken
+	if len(idOrToken) != tokenIDLength {
+		id = hashToken(idOrToken)[:tokenIDLength]
+	}
+
+	if _, err := tm.store.GetJoinToken(id); err != nil {
+		return fmt.Errorf("join token not found: %s", idOrToken)
+	}
+
+	return tm.deleteToken(id)
+}
+
+// RotateToken revokes every token for role and generates a replacement
+func (tm *TokenManager) RotateToken(role string, duration time.Duration) (*JoinToken, error) {
+	tokens, err := tm.store.ListJoinTokens()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list join tokens: %w", err)
+	}
+
+	for _, jt := range tokens {
+		if jt.Role != role {
+			continue
+		}
+		if err := tm.deleteToken(jt.ID); err != nil {
+			return nil, fmt.Errorf("failed to revoke token %s: %w", jt.ID, err)
+		}
+	}
+
+	return tm.GenerateToken(role, duration)
 }
 
 // CleanupExpiredTokens removes expired tokens
-func (tm *TokenManager) CleanupExpiredTokens() {
-	tm.mu.Lock()
-	defer tm.mu.Unlock()
+func (tm *TokenManager) CleanupExpiredTokens() error {
+	tokens, err := tm.store.ListJoinTokens()
+	if err != nil {
+		return err
+	}
 
 	now := time.Now()
-	for token, jt := range tm.tokens {
+	for _, jt := range tokens {
 		if now.After(jt.ExpiresAt) {
-			delete(tm.tokens, token)
+			if err := tm.deleteToken(jt.ID); err != nil {
+				return err
+			}
 		}
 	}
+
+	return nil
 }
 
-// ListTokens returns all active tokens
-func (tm *TokenManager) ListTokens() []*JoinToken {
-	tm.mu.RLock()
-	defer tm.mu.RUnlock()
+// ListTokens returns all tokens, oldest first. The plaintext is not available.
+func (tm *TokenManager) ListTokens() ([]*JoinToken, error) {
+	stored, err := tm.store.ListJoinTokens()
+	if err != nil {
+		return nil, err
+	}
 
-	tokens := make([]*JoinToken, 0, len(tm.tokens))
-	for _, jt := range tm.tokens {
-		tokens = append(tokens, jt)
+	tokens := make([]*JoinToken, 0, len(stored))
+	for _, jt := range stored {
+		tokens = append(tokens, toJoinToken(jt))
 	}
 
-	return tokens
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].CreatedAt.Before(tokens[j].CreatedAt)
+	})
+
+	return tokens, nil
+}
+
+// deleteToken removes a token through Raft
+func (tm *TokenManager) deleteToken(id string) error {
+	data, err := json.Marshal(id)
+	if err != nil {
+		return err
+	}
+	return tm.apply(Command{Op: "delete_join_token", Data: data})
+}
+
+// hashToken returns the hex-encoded SHA-256 of a token
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// toJoinToken converts a stored token to its public form
+func toJoinToken(t *types.JoinToken) *JoinToken {
+	return &JoinToken{
+		ID:        t.ID,
+		Role:      t.Role,
+		CreatedAt: t.CreatedAt,
+		ExpiresAt: t.ExpiresAt,
+	}
 }
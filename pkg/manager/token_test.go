@@ -0,0 +1,144 @@
+package manager
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/cuemby/warren/pkg/storage"
+	"github.com/hashicorp/raft"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestTokenManager returns a token manager whose commands are applied
+// directly to an FSM, as a committed Raft log entry would be
+func newTestTokenManager(t *testing.T) (*TokenManager, *WarrenFSM) {
+	store, err := storage.NewBoltStore(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	fsm := NewWarrenFSM(store, nil)
+	apply := func(cmd Command) error {
+		data, err := json.Marshal(cmd)
+		if err != nil {
+			return err
+		}
+		if resp := fsm.Apply(&raft.Log{Data: data}); resp != nil {
+			if err, ok := resp.(error); ok {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return NewTokenManager(store, apply), fsm
+}
+
+func TestTokenManager_GenerateAndValidate(t *testing.T) {
+	tm, fsm := newTestTokenManager(t)
+
+	token, err := tm.GenerateToken("worker", time.Hour)
+	require.NoError(t, err)
+	assert.Len(t, token.ID, tokenIDLength)
+
+	role, err := tm.ValidateToken(token.Token)
+	require.NoError(t, err)
+	assert.Equal(t, "worker", role)
+
+	_, err = tm.ValidateToken("not-a-token")
+	assert.Error(t, err)
+
+	// Only the hash is stored
+	stored, err := fsm.store.GetJoinToken(token.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, token.Token, stored.Hash)
+	assert.Equal(t, hashToken(token.Token), stored.Hash)
+
+	tokens, err := tm.ListTokens()
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	assert.Empty(t, tokens[0].Token, "listed tokens must not carry the plaintext")
+}
+
+func TestTokenManager_Expired(t *testing.T) {
+	tm, _ := newTestTokenManager(t)
+
+	token, err := tm.GenerateToken("worker", -time.Minute)
+	require.NoError(t, err)
+
+	_, err = tm.ValidateToken(token.Token)
+	assert.Error(t, err)
+
+	require.NoError(t, tm.CleanupExpiredTokens())
+	tokens, err := tm.ListTokens()
+	require.NoError(t, err)
+	assert.Empty(t, tokens)
+}
+
+func TestTokenManager_Revoke(t *testing.T) {
+	tm, _ := newTestTokenManager(t)
+
+	byID, err := tm.GenerateToken("worker", time.Hour)
+	require.NoError(t, err)
+	byValue, err := tm.GenerateToken("manager", time.Hour)
+	require.NoError(t, err)
+
+	require.NoError(t, tm.RevokeToken(byID.ID))
+	require.NoError(t, tm.RevokeToken(byValue.Token))
+
+	_, err = tm.ValidateToken(byID.Token)
+	assert.Error(t, err)
+	_, err = tm.ValidateToken(byValue.Token)
+	assert.Error(t, err)
+
+	assert.Error(t, tm.RevokeToken(byID.ID), "revoking twice reports not found")
+}
+
+func TestTokenManager_Rotate(t *testing.T) {
+	tm, _ := newTestTokenManager(t)
+
+	oldWorker, err := tm.GenerateToken("worker", time.Hour)
+	require.NoError(t, err)
+	manager, err := tm.GenerateToken("manager", time.Hour)
+	require.NoError(t, err)
+
+	newWorker, err := tm.RotateToken("worker", time.Hour)
+	require.NoError(t, err)
+
+	_, err = tm.ValidateToken(oldWorker.Token)
+	assert.Error(t, err, "old worker token must be revoked")
+
+	role, err := tm.ValidateToken(newWorker.Token)
+	require.NoError(t, err)
+	assert.Equal(t, "worker", role)
+
+	role, err = tm.ValidateToken(manager.Token)
+	require.NoError(t, err, "manager tokens are untouched")
+	assert.Equal(t, "manager", role)
+}
+
+// TestTokenManager_SurvivesSnapshot tests that tokens are valid on a manager
+// restored from a snapshot, as after a restart or on a new leader
+func TestTokenManager_SurvivesSnapshot(t *testing.T) {
+	tm, fsm := newTestTokenManager(t)
+
+	token, err := tm.GenerateToken("manager", time.Hour)
+	require.NoError(t, err)
+
+	snapshot, err := fsm.Snapshot()
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, json.NewEncoder(&buf).Encode(snapshot))
+	assert.NotContains(t, buf.String(), token.Token, "snapshot must not contain the plaintext token")
+
+	restoredTM, restoredFSM := newTestTokenManager(t)
+	require.NoError(t, restoredFSM.Restore(io.NopCloser(&buf)))
+
+	role, err := restoredTM.ValidateToken(token.Token)
+	require.NoError(t, err)
+	assert.Equal(t, "manager", role)
+}
@@ -230,6 +230,11 @@ warren_ingress_requests_total{host, backend}:
   - Description: Total ingress requests by host and backend
   - Labels: host, backend
 
+warren_ingress_request_errors_total{host, backend}:
+  - Type: Counter
+  - Description: Ingress requests answered with a 5xx status by host and backend
+  - Labels: host, backend
+
 warren_ingress_request_duration_seconds{host, backend}:
   - Type: Histogram
   - Description: Ingress request duration by host and backend
@@ -103,6 +103,35 @@ var (
 		},
 	)
 
+	SchedulerQueueDepth = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "warren_scheduler_queue_depth",
+			Help: "Number of services waiting for a scheduling pass",
+		},
+	)
+
+	SchedulerPassesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "warren_scheduler_passes_total",
+			Help: "Total number of scheduling passes by scope (all services, or the queued ones)",
+		},
+		[]string{"scope"},
+	)
+
+	SchedulerRebalanceMoves = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "warren_scheduler_rebalance_moves_total",
+			Help: "Total number of replicas moved to even out a service's spread",
+		},
+	)
+
+	SchedulerPreemptions = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "warren_scheduler_preemptions_total",
+			Help: "Total number of containers stopped to make room for a higher-priority service",
+		},
+	)
+
 	ContainersScheduled = prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Name: "warren_containers_scheduled_total",
@@ -225,6 +254,14 @@ var (
 		[]string{"host", "backend"},
 	)
 
+	IngressRequestErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "warren_ingress_request_errors_total",
+			Help: "Total number of ingress requests answered with a 5xx status by host and backend",
+		},
+		[]string{"host", "backend"},
+	)
+
 	IngressRequestDuration = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "warren_ingress_request_duration_seconds",
@@ -275,6 +312,10 @@ func init() {
 	prometheus.MustRegister(APIRequestsTotal)
 	prometheus.MustRegister(APIRequestDuration)
 	prometheus.MustRegister(SchedulingLatency)
+	prometheus.MustRegister(SchedulerQueueDepth)
+	prometheus.MustRegister(SchedulerPassesTotal)
+	prometheus.MustRegister(SchedulerRebalanceMoves)
+	prometheus.MustRegister(SchedulerPreemptions)
 	prometheus.MustRegister(ContainersScheduled)
 	prometheus.MustRegister(ContainersFailed)
 
@@ -292,6 +333,7 @@ func init() {
 	prometheus.MustRegister(IngressCreateDuration)
 	prometheus.MustRegister(IngressUpdateDuration)
 	prometheus.MustRegister(IngressRequestsTotal)
+	prometheus.MustRegister(IngressRequestErrorsTotal)
 	prometheus.MustRegister(IngressRequestDuration)
 
 	// Register deployment metrics
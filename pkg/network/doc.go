@@ -1,5 +1,6 @@
 /*
-Package network provides host port publishing for Warren services using iptables.
+Package network provides host port publishing for Warren services using
+iptables, and the WireGuard overlay network that connects nodes.
 
 The network package implements host mode port publishing, allowing services to
 expose ports on the host's network interface. It uses iptables NAT rules to
@@ -86,12 +87,16 @@ Host Mode (PublishMode: "host"):
   - Best for single-replica services or sticky sessions
   - Example: Database with primary-replica setup
 
-Ingress Mode (PublishMode: "ingress" - future):
-  - Ports published on all manager nodes
-  - Load balanced across replicas via VIP
+Ingress Mode (PublishMode: "ingress"):
+  - Ports published on every node running a worker (routing mesh)
+  - Load balanced across replicas anywhere in the cluster by ServiceProxy
+  - An external load balancer can point at any node
   - Best for stateless web services
   - Example: HTTP/HTTPS services
 
+HostPortPublisher only handles host mode; ingress ports are programmed by
+ServiceProxy (see Service VIPs).
+
 Port Conflict Detection:
   - Check if host port already in use (future)
   - Return error on conflict
@@ -177,6 +182,101 @@ Complete Example:
 		}
 	}
 
+# Overlay Network
+
+Every node runs a WireGuard interface (warren0) with an IP from the cluster
+subnet (NetworkConfig.ClusterSubnet, default 10.0.0.0/16). The service
+subnet (default 10.0.1.0/24) is reserved for VIPs and never given to nodes.
+
+	Worker                               Manager (leader)
+	  │ LoadOrCreateKeyPair                 │
+	  │ RegisterNode(public key, port) ───▶ │ AllocateNodeIP → Node.OverlayIP
+	  │ ◀──────────── overlay IP, subnet    │ (replicated through Raft)
+	  │ Overlay.Up                          │
+	  │ node.* events / resync ───────────▶ │ ListNodes
+	  │ Overlay.SyncPeers                   │
+
+Keys:
+  - Curve25519 keypairs, base64-encoded as wg(8) expects
+  - The private key stays on the node (DataDir/wireguard/private.key, 0600)
+  - The public key is sent with RegisterNode and stored on the Node
+
+IP Allocation:
+  - AllocateNodeIP hands out the lowest free address
+  - Skips the network and broadcast addresses and the service subnet
+  - A node that registers again keeps its IP
+
+Peers:
+  - One peer per other node; AllowedIPs is that node's overlay IP (/32)
+  - Endpoint is the node's address and WireGuard port; loopback addresses
+    are left without an endpoint and reached when the peer connects
+  - SyncPeers only reconfigures the device when the peer set changes
+  - Peers are resynced on node.joined, node.left and node.down events
+
+Kernel Access:
+
+The Netlink interface isolates the kernel calls (link, address and device
+configuration). NewCommandNetlink implements it with ip(8) and wg(8); the
+full device configuration is passed to "wg setconf" on stdin, so removed
+peers are dropped and the private key is never written to a temp file.
+Tests use a fake implementation.
+
+	overlay, err := network.NewOverlay(network.OverlayConfig{
+		NodeID:  "worker-1",
+		KeyFile: "/var/lib/warren/wireguard/private.key",
+	}, network.NewCommandNetlink())
+
+	err = overlay.Up(net.ParseIP("10.0.0.2"), "10.0.0.0/16")
+	changed, err := overlay.SyncPeers(nodes)
+
+# Service VIPs
+
+Every service gets a stable virtual IP from the service subnet. The leader
+allocates it with AllocateServiceVIP when the service is created and
+records it on the Service through Raft, so it survives failover.
+
+Each worker runs a ServiceProxy that programs the nat table from the
+manager's endpoint list (ListServiceEndpoints), refreshing the services
+named by service and task events and every service on node events:
+
+	PREROUTING, OUTPUT → WARREN-SERVICES
+	  -d <own overlay IP> -p tcp --dport <forward port> -j DNAT --to <local replica>
+	  -d <vip> -p tcp --dport 80 -m statistic --probability 0.50000 -j DNAT --to <local replica>
+	  -d <vip> -p tcp --dport 80 -j DNAT --to <other node overlay IP>:<forward port>
+	  -m addrtype --dst-type LOCAL -p tcp --dport 8080 -j DNAT --to <replica>   (ingress port)
+	POSTROUTING → WARREN-POSTROUTING
+	  -m conntrack --ctstate DNAT --ctorigdst <service subnet> -j MASQUERADE
+	  -p tcp -m conntrack --ctstate DNAT --ctorigdstport 8080 -j MASQUERADE
+	  -p tcp -m conntrack --ctstate DNAT --ctorigdst <own overlay IP> --ctorigdstport <forward port> -j MASQUERADE
+	FORWARD (filter) → WARREN-FORWARD
+	  the same conntrack matches -j ACCEPT
+
+Rules:
+  - Replicas on the same node are reached on their container IP; others at
+    the address the manager resolved (published host port, or their node's
+    overlay IP and forward port)
+  - Containers have their own network namespace, so nothing listens on a
+    node's overlay IP for them. Each service port has a forward port
+    (ForwardPort) that every node translates to its own replicas only; the
+    forward rules come first, so traffic another node already balanced is
+    never balanced again
+  - Forward ports are 61000-65095, above the default ephemeral port range,
+    derived from the VIP and the port's position; the service subnet is
+    therefore at most a /23 (MaxServiceSubnetPrefix, checked at startup)
+    and only a service's first 8 ports are reachable across nodes
+  - Ingress published ports match any local address, so every node
+    forwards them to a replica (routing mesh); a replica on another node is
+    reached at its node's forward port, so the published port may differ
+    from the container port. Services publishing only ingress ports are
+    included even without a VIP
+  - A forward port on a node without local replicas returns early instead
+    of reaching a published port rule for the same port number
+  - All chains are replaced atomically with iptables-restore --noflush,
+    and only when the endpoints change
+  - Established connections stay on their replica through conntrack
+
+The IPTables interface isolates the iptables calls; tests use a fake.
+
 # Integration Points
 
 This package integrates with:
@@ -343,13 +443,12 @@ Current Limitations:
   - No port allocation (caller provides ports)
   - Cleanup requires container IP (must be tracked separately)
   - No IPv6 support (only IPv4)
-  - No ingress mode (only host mode)
+  - No ingress mode in the host port publisher (see Service VIPs)
 
 Future Enhancements:
   - Port pool allocation
   - Automatic conflict detection
   - IPv6 support via ip6tables
-  - Ingress mode with VIP and load balancing
   - Connection draining for graceful shutdown
 
 # Platform Support
@@ -28,7 +28,8 @@ func (p *HostPortPublisher) PublishPorts(taskID, containerIP string, ports []typ
 		return nil
 	}
 
-	// Filter for host mode ports only
+	// Filter for host mode ports only; ingress ports are published on every
+	// node by ServiceProxy
 	var hostPorts []types.PortMapping
 	for _, port := range ports {
 		if port.PublishMode == types.PublishModeHost {
@@ -0,0 +1,144 @@
+package network
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+
+	"github.com/cuemby/warren/pkg/types"
+)
+
+const (
+	// DefaultClusterSubnet is the overlay subnet node IPs are allocated from
+	DefaultClusterSubnet = "10.0.0.0/16"
+
+	// DefaultServiceSubnet is reserved for service VIPs inside the cluster subnet
+	DefaultServiceSubnet = "10.0.1.0/24"
+
+	// MaxServiceSubnetPrefix is the prefix length of the largest service
+	// subnet: every VIP in it needs its own block of forward ports
+	MaxServiceSubnetPrefix = 23
+)
+
+// DefaultNetworkConfig returns the default cluster network configuration
+func DefaultNetworkConfig() *types.NetworkConfig {
+	return &types.NetworkConfig{
+		ClusterSubnet: DefaultClusterSubnet,
+		ServiceSubnet: DefaultServiceSubnet,
+		NodeIPs:       make(map[string]net.IP),
+		ServiceVIPs:   make(map[string]net.IP),
+	}
+}
+
+// AllocateNodeIP returns the overlay IP for a node, allocating the lowest
+// free address in the cluster subnet if the node has none yet. The network
+// and broadcast addresses and the service subnet are never handed out.
+// The allocation is recorded in config.NodeIPs.
+func AllocateNodeIP(config *types.NetworkConfig, nodeID string) (net.IP, error) {
+	if ip, ok := config.NodeIPs[nodeID]; ok && ip != nil {
+		return ip, nil
+	}
+
+	cluster, err := parseIPv4Subnet("cluster", config.ClusterSubnet)
+	if err != nil {
+		return nil, err
+	}
+
+	var service *net.IPNet
+	if config.ServiceSubnet != "" {
+		if service, err = parseIPv4Subnet("service", config.ServiceSubnet); err != nil {
+			return nil, err
+		}
+	}
+
+	ip := allocateIP(cluster, service, config.NodeIPs)
+	if ip == nil {
+		return nil, fmt.Errorf("no free overlay IPs in %s", config.ClusterSubnet)
+	}
+
+	if config.NodeIPs == nil {
+		config.NodeIPs = make(map[string]net.IP)
+	}
+	config.NodeIPs[nodeID] = ip
+	return ip, nil
+}
+
+// AllocateServiceVIP returns the virtual IP for a service, allocating the
+// lowest free address in the service subnet if the service has none yet.
+// The allocation is recorded in config.ServiceVIPs.
+func AllocateServiceVIP(config *types.NetworkConfig, serviceID string) (net.IP, error) {
+	if ip, ok := config.ServiceVIPs[serviceID]; ok && ip != nil {
+		return ip, nil
+	}
+
+	service, err := parseIPv4Subnet("service", config.ServiceSubnet)
+	if err != nil {
+		return nil, err
+	}
+
+	ip := allocateIP(service, nil, config.ServiceVIPs)
+	if ip == nil {
+		return nil, fmt.Errorf("no free service VIPs in %s", config.ServiceSubnet)
+	}
+
+	if config.ServiceVIPs == nil {
+		config.ServiceVIPs = make(map[string]net.IP)
+	}
+	config.ServiceVIPs[serviceID] = ip
+	return ip, nil
+}
+
+// ValidateServiceSubnet checks a service subnet is an IPv4 CIDR no larger
+// than MaxServiceSubnetPrefix allows
+func ValidateServiceSubnet(cidr string) error {
+	subnet, err := parseIPv4Subnet("service", cidr)
+	if err != nil {
+		return err
+	}
+	if ones, _ := subnet.Mask.Size(); ones < MaxServiceSubnetPrefix {
+		return fmt.Errorf("service subnet %s is larger than a /%d", cidr, MaxServiceSubnetPrefix)
+	}
+	return nil
+}
+
+// parseIPv4Subnet parses an IPv4 CIDR, naming the subnet in errors
+func parseIPv4Subnet(kind, cidr string) (*net.IPNet, error) {
+	_, subnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s subnet %q: %w", kind, cidr, err)
+	}
+	if subnet.IP.To4() == nil {
+		return nil, fmt.Errorf("%s subnet %s is not IPv4", kind, cidr)
+	}
+	return subnet, nil
+}
+
+// allocateIP returns the lowest host address in subnet that is neither in
+// exclude nor in use, or nil if the subnet is exhausted
+func allocateIP(subnet, exclude *net.IPNet, inUse map[string]net.IP) net.IP {
+	used := make(map[uint32]bool, len(inUse))
+	for _, ip := range inUse {
+		if ip4 := ip.To4(); ip4 != nil {
+			used[binary.BigEndian.Uint32(ip4)] = true
+		}
+	}
+
+	ones, bits := subnet.Mask.Size()
+	first := binary.BigEndian.Uint32(subnet.IP.To4())
+	last := first + uint32(1<<(bits-ones)) - 1
+
+	// Skip the network and broadcast addresses
+	for n := first + 1; n < last; n++ {
+		if used[n] {
+			continue
+		}
+		ip := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(ip, n)
+		if exclude != nil && exclude.Contains(ip) {
+			continue
+		}
+		return ip
+	}
+
+	return nil
+}
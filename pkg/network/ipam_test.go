@@ -0,0 +1,113 @@
+package network
+
+import (
+	"net"
+	"testing"
+
+	"github.com/cuemby/warren/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocateNodeIP(t *testing.T) {
+	config := DefaultNetworkConfig()
+
+	ip, err := AllocateNodeIP(config, "node-1")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1", ip.String(), "network address is skipped")
+
+	ip, err = AllocateNodeIP(config, "node-2")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.2", ip.String())
+
+	// Re-registering keeps the existing IP
+	ip, err = AllocateNodeIP(config, "node-1")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1", ip.String())
+	assert.Len(t, config.NodeIPs, 2)
+}
+
+func TestAllocateNodeIP_SkipsServiceSubnet(t *testing.T) {
+	config := &types.NetworkConfig{
+		ClusterSubnet: "10.0.0.0/23",
+		ServiceSubnet: "10.0.0.0/24",
+	}
+
+	ip, err := AllocateNodeIP(config, "node-1")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.1.0", ip.String())
+}
+
+func TestAllocateNodeIP_ReusesReleasedIP(t *testing.T) {
+	config := &types.NetworkConfig{
+		ClusterSubnet: "10.0.0.0/16",
+		NodeIPs: map[string]net.IP{
+			"node-1": net.ParseIP("10.0.0.1"),
+			"node-3": net.ParseIP("10.0.0.3"),
+		},
+	}
+
+	ip, err := AllocateNodeIP(config, "node-4")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.2", ip.String())
+}
+
+func TestAllocateNodeIP_Exhausted(t *testing.T) {
+	config := &types.NetworkConfig{ClusterSubnet: "10.0.0.0/30"}
+
+	_, err := AllocateNodeIP(config, "node-1")
+	require.NoError(t, err)
+	_, err = AllocateNodeIP(config, "node-2")
+	require.NoError(t, err)
+
+	_, err = AllocateNodeIP(config, "node-3")
+	assert.Error(t, err, "broadcast address must not be allocated")
+}
+
+func TestAllocateNodeIP_InvalidSubnet(t *testing.T) {
+	_, err := AllocateNodeIP(&types.NetworkConfig{ClusterSubnet: "bogus"}, "node-1")
+	assert.Error(t, err)
+}
+
+func TestAllocateServiceVIP(t *testing.T) {
+	config := DefaultNetworkConfig()
+
+	ip, err := AllocateServiceVIP(config, "svc-1")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.1.1", ip.String())
+
+	ip, err = AllocateServiceVIP(config, "svc-2")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.1.2", ip.String())
+
+	// A service keeps its VIP
+	ip, err = AllocateServiceVIP(config, "svc-1")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.1.1", ip.String())
+
+	// Released VIPs are reused
+	delete(config.ServiceVIPs, "svc-1")
+	ip, err = AllocateServiceVIP(config, "svc-3")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.1.1", ip.String())
+}
+
+func TestAllocateServiceVIP_Exhausted(t *testing.T) {
+	config := &types.NetworkConfig{ServiceSubnet: "10.0.1.0/30"}
+
+	_, err := AllocateServiceVIP(config, "svc-1")
+	require.NoError(t, err)
+	_, err = AllocateServiceVIP(config, "svc-2")
+	require.NoError(t, err)
+
+	_, err = AllocateServiceVIP(config, "svc-3")
+	assert.Error(t, err)
+}
+
+func TestValidateServiceSubnet(t *testing.T) {
+	assert.NoError(t, ValidateServiceSubnet(DefaultServiceSubnet))
+	assert.NoError(t, ValidateServiceSubnet("10.0.0.0/23"))
+	assert.Error(t, ValidateServiceSubnet("10.0.0.0/20"), "too many VIPs for the forward ports")
+	assert.Error(t, ValidateServiceSubnet("bogus"))
+	assert.Error(t, ValidateServiceSubnet("fd00::/120"))
+}
@@ -0,0 +1,77 @@
+package network
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// KeyPair is a WireGuard Curve25519 keypair, base64-encoded as wg(8) expects
+type KeyPair struct {
+	PrivateKey string
+	PublicKey  string
+}
+
+// GenerateKeyPair generates a new WireGuard keypair
+func GenerateKeyPair() (*KeyPair, error) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate private key: %w", err)
+	}
+
+	return &KeyPair{
+		PrivateKey: base64.StdEncoding.EncodeToString(key.Bytes()),
+		PublicKey:  base64.StdEncoding.EncodeToString(key.PublicKey().Bytes()),
+	}, nil
+}
+
+// PublicKeyFromPrivate derives the public key for a base64-encoded private key
+func PublicKeyFromPrivate(privateKey string) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("invalid private key encoding: %w", err)
+	}
+
+	key, err := ecdh.X25519().NewPrivateKey(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid private key: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(key.PublicKey().Bytes()), nil
+}
+
+// LoadOrCreateKeyPair loads the private key stored at path, generating and
+// saving a new one if the file does not exist. Keeping the key across
+// restarts means peers do not need to be reconfigured when a node restarts.
+func LoadOrCreateKeyPair(path string) (*KeyPair, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		privateKey := strings.TrimSpace(string(data))
+		publicKey, err := PublicKeyFromPrivate(privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load key from %s: %w", path, err)
+		}
+		return &KeyPair{PrivateKey: privateKey, PublicKey: publicKey}, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	keys, err := GenerateKeyPair()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create key directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(keys.PrivateKey+"\n"), 0600); err != nil {
+		return nil, fmt.Errorf("failed to write key file: %w", err)
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,53 @@
+package network
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateKeyPair(t *testing.T) {
+	keys, err := GenerateKeyPair()
+	require.NoError(t, err)
+
+	publicKey, err := PublicKeyFromPrivate(keys.PrivateKey)
+	require.NoError(t, err)
+	assert.Equal(t, keys.PublicKey, publicKey)
+
+	raw, err := base64.StdEncoding.DecodeString(keys.PublicKey)
+	require.NoError(t, err)
+	assert.Len(t, raw, 32)
+}
+
+// TestPublicKeyFromPrivate checks X25519 derivation against RFC 7748 section 6.1
+func TestPublicKeyFromPrivate(t *testing.T) {
+	private, _ := hex.DecodeString("77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a")
+	public, _ := hex.DecodeString("8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a")
+
+	got, err := PublicKeyFromPrivate(base64.StdEncoding.EncodeToString(private))
+	require.NoError(t, err)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(public), got)
+
+	_, err = PublicKeyFromPrivate("not base64!")
+	assert.Error(t, err)
+}
+
+func TestLoadOrCreateKeyPair(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wireguard", "private.key")
+
+	created, err := LoadOrCreateKeyPair(path)
+	require.NoError(t, err)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	loaded, err := LoadOrCreateKeyPair(path)
+	require.NoError(t, err)
+	assert.Equal(t, created, loaded, "the key must survive restarts")
+}
@@ -0,0 +1,143 @@
+package network
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Netlink abstracts the kernel operations the overlay needs, so the overlay
+// logic can be tested without root privileges or a WireGuard module
+type Netlink interface {
+	// EnsureWireGuardLink creates a WireGuard interface if it does not exist
+	EnsureWireGuardLink(name string) error
+
+	// DeleteLink removes an interface; a missing interface is not an error
+	DeleteLink(name string) error
+
+	// ReplaceAddress sets the interface address, replacing any previous one
+	ReplaceAddress(name string, addr *net.IPNet) error
+
+	// SetLinkUp brings an interface up
+	SetLinkUp(name string) error
+
+	// ConfigureWireGuard applies the full device configuration. Peers not in
+	// the configuration are removed.
+	ConfigureWireGuard(name string, config *WireGuardConfig) error
+}
+
+// WireGuardConfig is the configuration of a WireGuard device
+type WireGuardConfig struct {
+	PrivateKey string
+	ListenPort int
+	Peers      []WireGuardPeer
+}
+
+// WireGuardPeer is a remote node reachable through the overlay
+type WireGuardPeer struct {
+	PublicKey           string
+	Endpoint            string // host:port; empty if the peer is not directly reachable
+	AllowedIPs          []*net.IPNet
+	PersistentKeepalive time.Duration
+}
+
+// commandNetlink implements Netlink with the ip(8) and wg(8) tools
+type commandNetlink struct{}
+
+// NewCommandNetlink returns a Netlink that drives the kernel through the
+// ip and wg command line tools
+func NewCommandNetlink() Netlink {
+	return &commandNetlink{}
+}
+
+// EnsureWireGuardLink creates a WireGuard interface if it does not exist
+func (c *commandNetlink) EnsureWireGuardLink(name string) error {
+	if err := runCommand(nil, "ip", "link", "show", "dev", name); err == nil {
+		return nil
+	}
+	return runCommand(nil, "ip", "link", "add", "dev", name, "type", "wireguard")
+}
+
+// DeleteLink removes an interface
+func (c *commandNetlink) DeleteLink(name string) error {
+	if err := runCommand(nil, "ip", "link", "show", "dev", name); err != nil {
+		return nil // Already gone
+	}
+	return runCommand(nil, "ip", "link", "del", "dev", name)
+}
+
+// ReplaceAddress sets the interface address
+func (c *commandNetlink) ReplaceAddress(name string, addr *net.IPNet) error {
+	if err := runCommand(nil, "ip", "address", "flush", "dev", name); err != nil {
+		return err
+	}
+	return runCommand(nil, "ip", "address", "add", addr.String(), "dev", name)
+}
+
+// SetLinkUp brings an interface up
+func (c *commandNetlink) SetLinkUp(name string) error {
+	return runCommand(nil, "ip", "link", "set", "up", "dev", name)
+}
+
+// ConfigureWireGuard applies the device configuration with wg setconf,
+// passing the configuration on stdin so the private key never touches disk
+func (c *commandNetlink) ConfigureWireGuard(name string, config *WireGuardConfig) error {
+	return runCommand(strings.NewReader(renderWireGuardConfig(config)), "wg", "setconf", name, "/dev/stdin")
+}
+
+// renderWireGuardConfig renders a configuration in wg(8) setconf format.
+// Peers are sorted by public key so the output is stable.
+func renderWireGuardConfig(config *WireGuardConfig) string {
+	var b strings.Builder
+
+	b.WriteString("[Interface]\n")
+	fmt.Fprintf(&b, "PrivateKey = %s\n", config.PrivateKey)
+	if config.ListenPort > 0 {
+		fmt.Fprintf(&b, "ListenPort = %d\n", config.ListenPort)
+	}
+
+	peers := make([]WireGuardPeer, len(config.Peers))
+	copy(peers, config.Peers)
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].PublicKey < peers[j].PublicKey
+	})
+
+	for _, peer := range peers {
+		b.WriteString("\n[Peer]\n")
+		fmt.Fprintf(&b, "PublicKey = %s\n", peer.PublicKey)
+		if peer.Endpoint != "" {
+			fmt.Fprintf(&b, "Endpoint = %s\n", peer.Endpoint)
+		}
+		if len(peer.AllowedIPs) > 0 {
+			allowed := make([]string, len(peer.AllowedIPs))
+			for i, ipNet := range peer.AllowedIPs {
+				allowed[i] = ipNet.String()
+			}
+			fmt.Fprintf(&b, "AllowedIPs = %s\n", strings.Join(allowed, ", "))
+		}
+		if peer.PersistentKeepalive > 0 {
+			fmt.Fprintf(&b, "PersistentKeepalive = %d\n", int(peer.PersistentKeepalive/time.Second))
+		}
+	}
+
+	return b.String()
+}
+
+// runCommand executes a command, optionally feeding it stdin
+func runCommand(stdin *strings.Reader, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s %s failed: %w (output: %s)", name, strings.Join(args, " "), err, strings.TrimSpace(output.String()))
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package network
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cuemby/warren/pkg/types"
+)
+
+const (
+	// DefaultOverlayInterface is the name of the WireGuard interface
+	DefaultOverlayInterface = "warren0"
+
+	// DefaultOverlayPort is the UDP port WireGuard listens on
+	DefaultOverlayPort = 51820
+
+	// overlayKeepalive keeps NAT mappings open between peers
+	overlayKeepalive = 25 * time.Second
+)
+
+// OverlayConfig holds overlay network configuration for a node
+type OverlayConfig struct {
+	NodeID     string
+	Interface  string // Defaults to DefaultOverlayInterface
+	ListenPort int    // Defaults to DefaultOverlayPort
+	KeyFile    string // Private key location; a new key is generated if empty or missing
+}
+
+// Overlay manages a node's WireGuard interface and its peers.
+// Each node gets an IP from the cluster subnet and one peer per other node,
+// whose AllowedIPs is that node's overlay IP.
+type Overlay struct {
+	nodeID     string
+	iface      string
+	listenPort int
+	keys       *KeyPair
+	link       Netlink
+
+	mu      sync.Mutex
+	address *net.IPNet                // Local overlay address (IP + cluster mask)
+	peers   map[string]*WireGuardPeer // nodeID -> peer currently configured
+}
+
+// NewOverlay creates an overlay, loading or generating the node's keypair
+func NewOverlay(cfg OverlayConfig, link Netlink) (*Overlay, error) {
+	iface := cfg.Interface
+	if iface == "" {
+		iface = DefaultOverlayInterface
+	}
+	port := cfg.ListenPort
+	if port == 0 {
+		port = DefaultOverlayPort
+	}
+
+	var keys *KeyPair
+	var err error
+	if cfg.KeyFile != "" {
+		keys, err = LoadOrCreateKeyPair(cfg.KeyFile)
+	} else {
+		keys, err = GenerateKeyPair()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &Overlay{
+		nodeID:     cfg.NodeID,
+		iface:      iface,
+		listenPort: port,
+		keys:       keys,
+		link:       link,
+		peers:      make(map[string]*WireGuardPeer),
+	}, nil
+}
+
+// PublicKey returns the node's WireGuard public key, sent on registration
+func (o *Overlay) PublicKey() string {
+	return o.keys.PublicKey
+}
+
+// ListenPort returns the UDP port WireGuard listens on
+func (o *Overlay) ListenPort() int {
+	return o.listenPort
+}
+
+// Address returns the local overlay address, or nil before Up
+func (o *Overlay) Address() *net.IPNet {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.address
+}
+
+// Up creates and configures the WireGuard interface with the overlay IP
+// assigned by the manager. clusterSubnet is the CIDR the IP belongs to.
+func (o *Overlay) Up(ip net.IP, clusterSubnet string) error {
+	_, subnet, err := net.ParseCIDR(clusterSubnet)
+	if err != nil {
+		return fmt.Errorf("invalid cluster subnet %q: %w", clusterSubnet, err)
+	}
+	if ip == nil || !subnet.Contains(ip) {
+		return fmt.Errorf("overlay IP %s is not in cluster subnet %s", ip, clusterSubnet)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	address := &net.IPNet{IP: ip, Mask: subnet.Mask}
+
+	if err := o.link.EnsureWireGuardLink(o.iface); err != nil {
+		return fmt.Errorf("failed to create %s: %w", o.iface, err)
+	}
+	if err := o.link.ReplaceAddress(o.iface, address); err != nil {
+		return fmt.Errorf("failed to set address on %s: %w", o.iface, err)
+	}
+	if err := o.configure(o.peers); err != nil {
+		return err
+	}
+	if err := o.link.SetLinkUp(o.iface); err != nil {
+		return fmt.Errorf("failed to bring up %s: %w", o.iface, err)
+	}
+
+	o.address = address
+	return nil
+}
+
+// SyncPeers reconciles the configured peers with the cluster's nodes.
+// Nodes without an overlay IP or public key are skipped, as is this node.
+// The device is only reconfigured when the peer set changed; it returns
+// whether it was.
+func (o *Overlay) SyncPeers(nodes []*types.Node) (bool, error) {
+	desired := make(map[string]*WireGuardPeer)
+	for _, node := range nodes {
+		if peer := o.peerFor(node); peer != nil {
+			desired[node.ID] = peer
+		}
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.address == nil {
+		return false, fmt.Errorf("overlay is not up")
+	}
+	if reflect.DeepEqual(desired, o.peers) {
+		return false, nil
+	}
+
+	if err := o.configure(desired); err != nil {
+		return false, err
+	}
+	o.peers = desired
+	return true, nil
+}
+
+// Peers returns the node IDs of the configured peers
+func (o *Overlay) Peers() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	ids := make([]string, 0, len(o.peers))
+	for id := range o.peers {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Down removes the WireGuard interface
+func (o *Overlay) Down() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.address == nil {
+		return nil
+	}
+	if err := o.link.DeleteLink(o.iface); err != nil {
+		return fmt.Errorf("failed to delete %s: %w", o.iface, err)
+	}
+
+	o.address = nil
+	o.peers = make(map[string]*WireGuardPeer)
+	return nil
+}
+
+// peerFor builds the peer entry for a node, or nil if it cannot be a peer
+func (o *Overlay) peerFor(node *types.Node) *WireGuardPeer {
+	if node.ID == o.nodeID || node.OverlayPublicKey == "" || node.OverlayIP.To4() == nil {
+		return nil
+	}
+
+	peer := &WireGuardPeer{
+		PublicKey:           node.OverlayPublicKey,
+		AllowedIPs:          []*net.IPNet{{IP: node.OverlayIP.To4(), Mask: net.CIDRMask(32, 32)}},
+		PersistentKeepalive: overlayKeepalive,
+	}
+
+	// Loopback addresses are only meaningful on the node itself; such peers
+	// are reached once they initiate the handshake
+	if isRoutableAddress(node.Address) {
+		port := node.OverlayPort
+		if port == 0 {
+			port = DefaultOverlayPort
+		}
+		peer.Endpoint = net.JoinHostPort(node.Address, strconv.Itoa(port))
+	}
+
+	return peer
+}
+
+// isRoutableAddress reports whether other nodes can reach address
+func isRoutableAddress(address string) bool {
+	if address == "" || address == "localhost" {
+		return false
+	}
+	if ip := net.ParseIP(address); ip != nil {
+		return !ip.IsLoopback() && !ip.IsUnspecified()
+	}
+	return true // Hostname, resolved by wg
+}
+
+// configure writes the device configuration for a set of peers
+func (o *Overlay) configure(peers map[string]*WireGuardPeer) error {
+	config := &WireGuardConfig{
+		PrivateKey: o.keys.PrivateKey,
+		ListenPort: o.listenPort,
+		Peers:      make([]WireGuardPeer, 0, len(peers)),
+	}
+	for _, peer := range peers {
+		config.Peers = append(config.Peers, *peer)
+	}
+
+	if err := o.link.ConfigureWireGuard(o.iface, config); err != nil {
+		return fmt.Errorf("failed to configure %s: %w", o.iface, err)
+	}
+	return nil
+}
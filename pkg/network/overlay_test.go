@@ -0,0 +1,209 @@
+package network
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cuemby/warren/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNetlink records kernel operations instead of performing them
+type fakeNetlink struct {
+	links      map[string]bool
+	addresses  map[string]string
+	up         map[string]bool
+	configs    map[string]*WireGuardConfig
+	configures int
+	failLink   bool
+}
+
+func newFakeNetlink() *fakeNetlink {
+	return &fakeNetlink{
+		links:     make(map[string]bool),
+		addresses: make(map[string]string),
+		up:        make(map[string]bool),
+		configs:   make(map[string]*WireGuardConfig),
+	}
+}
+
+func (f *fakeNetlink) EnsureWireGuardLink(name string) error {
+	if f.failLink {
+		return fmt.Errorf("wireguard module not loaded")
+	}
+	f.links[name] = true
+	return nil
+}
+
+func (f *fakeNetlink) DeleteLink(name string) error {
+	delete(f.links, name)
+	delete(f.addresses, name)
+	delete(f.up, name)
+	delete(f.configs, name)
+	return nil
+}
+
+func (f *fakeNetlink) ReplaceAddress(name string, addr *net.IPNet) error {
+	f.addresses[name] = addr.String()
+	return nil
+}
+
+func (f *fakeNetlink) SetLinkUp(name string) error {
+	f.up[name] = true
+	return nil
+}
+
+func (f *fakeNetlink) ConfigureWireGuard(name string, config *WireGuardConfig) error {
+	f.configs[name] = config
+	f.configures++
+	return nil
+}
+
+// peerEndpoints returns the configured peers as "endpoint allowed-ips"
+func (f *fakeNetlink) peerEndpoints(name string) []string {
+	var peers []string
+	for _, peer := range f.configs[name].Peers {
+		peers = append(peers, fmt.Sprintf("%s %s", peer.Endpoint, peer.AllowedIPs[0]))
+	}
+	sort.Strings(peers)
+	return peers
+}
+
+func testNode(id, address, overlayIP string) *types.Node {
+	return &types.Node{
+		ID:               id,
+		Address:          address,
+		OverlayIP:        net.ParseIP(overlayIP),
+		OverlayPublicKey: "key-" + id,
+	}
+}
+
+func newTestOverlay(t *testing.T, link Netlink) *Overlay {
+	overlay, err := NewOverlay(OverlayConfig{NodeID: "node-1"}, link)
+	require.NoError(t, err)
+	return overlay
+}
+
+func TestOverlay_Up(t *testing.T) {
+	link := newFakeNetlink()
+	overlay := newTestOverlay(t, link)
+
+	require.NoError(t, overlay.Up(net.ParseIP("10.0.0.1"), "10.0.0.0/16"))
+
+	assert.True(t, link.links[DefaultOverlayInterface])
+	assert.True(t, link.up[DefaultOverlayInterface])
+	assert.Equal(t, "10.0.0.1/16", link.addresses[DefaultOverlayInterface])
+	require.NotNil(t, link.configs[DefaultOverlayInterface])
+	assert.Equal(t, DefaultOverlayPort, link.configs[DefaultOverlayInterface].ListenPort)
+	assert.Empty(t, link.configs[DefaultOverlayInterface].Peers)
+
+	require.NoError(t, overlay.Down())
+	assert.False(t, link.links[DefaultOverlayInterface])
+}
+
+func TestOverlay_UpErrors(t *testing.T) {
+	overlay := newTestOverlay(t, newFakeNetlink())
+	assert.Error(t, overlay.Up(net.ParseIP("192.168.0.1"), "10.0.0.0/16"), "IP outside the subnet")
+
+	link := newFakeNetlink()
+	link.failLink = true
+	overlay = newTestOverlay(t, link)
+	assert.Error(t, overlay.Up(net.ParseIP("10.0.0.1"), "10.0.0.0/16"))
+	assert.Nil(t, overlay.Address())
+
+	_, err := overlay.SyncPeers(nil)
+	assert.Error(t, err, "peers cannot be synced before the overlay is up")
+}
+
+func TestOverlay_SyncPeers(t *testing.T) {
+	link := newFakeNetlink()
+	overlay := newTestOverlay(t, link)
+	require.NoError(t, overlay.Up(net.ParseIP("10.0.0.1"), "10.0.0.0/16"))
+
+	nodes := []*types.Node{
+		testNode("node-1", "192.168.1.10", "10.0.0.1"), // Self
+		testNode("node-2", "192.168.1.11", "10.0.0.2"),
+		{ID: "node-old", Address: "192.168.1.9", OverlayIP: net.ParseIP("10.0.0.9")}, // No public key
+	}
+
+	changed, err := overlay.SyncPeers(nodes)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, []string{"192.168.1.11:51820 10.0.0.2/32"}, link.peerEndpoints(DefaultOverlayInterface))
+	assert.Equal(t, overlayKeepalive, link.configs[DefaultOverlayInterface].Peers[0].PersistentKeepalive)
+
+	// Unchanged node list does not touch the device
+	configures := link.configures
+	changed, err = overlay.SyncPeers(nodes)
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, configures, link.configures)
+
+	// A node joins
+	node3 := testNode("node-3", "192.168.1.12", "10.0.0.3")
+	node3.OverlayPort = 51900
+	nodes = append(nodes, node3)
+	changed, err = overlay.SyncPeers(nodes)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, []string{
+		"192.168.1.11:51820 10.0.0.2/32",
+		"192.168.1.12:51900 10.0.0.3/32",
+	}, link.peerEndpoints(DefaultOverlayInterface))
+
+	// node-2 leaves
+	nodes = []*types.Node{nodes[0], node3}
+	changed, err = overlay.SyncPeers(nodes)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, []string{"192.168.1.12:51900 10.0.0.3/32"}, link.peerEndpoints(DefaultOverlayInterface))
+	assert.Equal(t, []string{"node-3"}, overlay.Peers())
+}
+
+func TestOverlay_PeerWithoutRoutableAddress(t *testing.T) {
+	link := newFakeNetlink()
+	overlay := newTestOverlay(t, link)
+	require.NoError(t, overlay.Up(net.ParseIP("10.0.0.1"), "10.0.0.0/16"))
+
+	_, err := overlay.SyncPeers([]*types.Node{testNode("node-2", "127.0.0.1", "10.0.0.2")})
+	require.NoError(t, err)
+
+	peers := link.configs[DefaultOverlayInterface].Peers
+	require.Len(t, peers, 1)
+	assert.Empty(t, peers[0].Endpoint, "loopback addresses are not usable endpoints")
+}
+
+func TestRenderWireGuardConfig(t *testing.T) {
+	_, allowed, _ := net.ParseCIDR("10.0.0.2/32")
+	config := renderWireGuardConfig(&WireGuardConfig{
+		PrivateKey: "private",
+		ListenPort: 51820,
+		Peers: []WireGuardPeer{
+			{PublicKey: "peer-b", AllowedIPs: []*net.IPNet{allowed}},
+			{PublicKey: "peer-a", Endpoint: "192.168.1.11:51820", AllowedIPs: []*net.IPNet{allowed}, PersistentKeepalive: 25 * time.Second},
+		},
+	})
+
+	expected := strings.Join([]string{
+		"[Interface]",
+		"PrivateKey = private",
+		"ListenPort = 51820",
+		"",
+		"[Peer]",
+		"PublicKey = peer-a",
+		"Endpoint = 192.168.1.11:51820",
+		"AllowedIPs = 10.0.0.2/32",
+		"PersistentKeepalive = 25",
+		"",
+		"[Peer]",
+		"PublicKey = peer-b",
+		"AllowedIPs = 10.0.0.2/32",
+		"",
+	}, "\n")
+	assert.Equal(t, expected, config)
+}
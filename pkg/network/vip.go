@@ -0,0 +1,322 @@
+package network
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cuemby/warren/pkg/types"
+)
+
+// iptables chains owned by the service proxy
+const (
+	servicesChain    = "WARREN-SERVICES"
+	postroutingChain = "WARREN-POSTROUTING"
+	forwardChain     = "WARREN-FORWARD"
+)
+
+// Forward ports are where a node accepts traffic for its own replicas of a
+// service port from other nodes. Containers have their own network
+// namespace, so a replica cannot be reached on its node's overlay IP and
+// container port; instead each service port gets a port on every overlay IP
+// that only the node holding the replica translates. The port follows from
+// the low bits of the service VIP and the port's position in the service,
+// so it is unique while the service subnet is no larger than
+// MaxServiceSubnetPrefix allows and a service has at most
+// forwardPortsPerService ports. The ports, 61000-65095, lie above Linux's
+// default ephemeral range (ip_local_port_range 32768-60999), so replies to
+// the node's own outgoing connections are never taken for forwarded traffic.
+const (
+	forwardPortBase        = 61000
+	forwardPortsPerService = 8
+	forwardVIPBits         = 32 - MaxServiceSubnetPrefix
+)
+
+// ForwardPort returns the forward port of the service port with the given
+// container port and protocol, or 0 if it has none. Ports sharing a
+// container port and protocol share the forward port of the first of them.
+func ForwardPort(vip net.IP, ports []*types.PortMapping, containerPort int, protocol string) int {
+	ip := vip.To4()
+	if ip == nil {
+		return 0
+	}
+
+	protocol = normalizeProtocol(protocol)
+	for i, port := range ports {
+		if port.ContainerPort != containerPort || normalizeProtocol(port.Protocol) != protocol {
+			continue
+		}
+		if i >= forwardPortsPerService {
+			return 0
+		}
+		offset := (int(ip[2])<<8 | int(ip[3])) & (1<<forwardVIPBits - 1)
+		return forwardPortBase + offset*forwardPortsPerService + i
+	}
+	return 0
+}
+
+// normalizeProtocol returns protocol, defaulting to tcp
+func normalizeProtocol(protocol string) string {
+	if protocol == "" {
+		return "tcp"
+	}
+	return protocol
+}
+
+// IPTables abstracts the kernel's netfilter tables, so the service proxy can
+// be tested without root privileges or iptables
+type IPTables interface {
+	// EnsureJump makes the built-in chain jump to target if it does not already
+	EnsureJump(table, chain, target string) error
+
+	// DeleteJump removes a jump added by EnsureJump; a missing jump is not an error
+	DeleteJump(table, chain, target string) error
+
+	// Restore atomically replaces the contents of the chains declared in
+	// rules, leaving every other chain untouched
+	Restore(rules string) error
+
+	// DeleteChain flushes and removes a chain; a missing chain is not an error
+	DeleteChain(table, chain string) error
+}
+
+// ServiceVIP is a service virtual IP and the replicas behind each of its ports
+type ServiceVIP struct {
+	Name  string
+	VIP   net.IP // nil if the service only publishes ingress ports
+	Ports []ServiceVIPPort
+}
+
+// ServiceVIPPort is a port on a service VIP
+type ServiceVIPPort struct {
+	Protocol      string   // "tcp" or "udp"
+	Port          int      // Port on the VIP
+	PublishedPort int      // Port published on every node (routing mesh); 0 if none
+	ForwardPort   int      // Port other nodes forward to for this node's replicas; 0 if none
+	Backends      []string // host:port of each healthy replica
+	Local         []string // host:port of the replicas on this node
+}
+
+// ServiceProxy load balances connections to service VIPs and ingress
+// published ports across replicas with iptables DNAT rules, so the kernel
+// forwards traffic without a userspace proxy
+type ServiceProxy struct {
+	ipt IPTables
+
+	mu      sync.Mutex
+	applied string // Last rules restored; empty before the first sync
+}
+
+// serviceJump is a jump from a built-in chain to one of the proxy's chains
+type serviceJump struct {
+	table, chain, target string
+}
+
+// serviceJumps are the built-in chains that jump to the proxy's chains
+var serviceJumps = []serviceJump{
+	{"nat", "PREROUTING", servicesChain}, // Traffic from containers and other hosts
+	{"nat", "OUTPUT", servicesChain},     // Traffic from the host itself
+	{"nat", "POSTROUTING", postroutingChain},
+	{"filter", "FORWARD", forwardChain},
+}
+
+// NewServiceProxy creates a service proxy that programs the given tables
+func NewServiceProxy(ipt IPTables) *ServiceProxy {
+	return &ServiceProxy{ipt: ipt}
+}
+
+// Sync programs the kernel so each service VIP and ingress published port
+// balances across its backends, and each forward port on nodeIP (this
+// node's overlay IP, nil without an overlay) across the local replicas.
+// Traffic DNATed by these rules is accepted for forwarding and masqueraded,
+// so replies from a replica on another node return through this one. It
+// reports whether the rules changed.
+func (p *ServiceProxy) Sync(serviceSubnet string, nodeIP net.IP, services []ServiceVIP) (bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	rules := renderServiceRules(serviceSubnet, nodeIP, services)
+	changed := rules != p.applied
+	if changed {
+		if err := p.ipt.Restore(rules); err != nil {
+			return false, fmt.Errorf("failed to restore service rules: %w", err)
+		}
+		p.applied = rules
+	}
+
+	// Jumps are checked on every sync in case something else removed them
+	for _, jump := range serviceJumps {
+		if err := p.ipt.EnsureJump(jump.table, jump.chain, jump.target); err != nil {
+			return changed, fmt.Errorf("failed to add jump from %s: %w", jump.chain, err)
+		}
+	}
+
+	return changed, nil
+}
+
+// Down removes every rule and chain the proxy created
+func (p *ServiceProxy) Down() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, jump := range serviceJumps {
+		if err := p.ipt.DeleteJump(jump.table, jump.chain, jump.target); err != nil {
+			return fmt.Errorf("failed to remove jump from %s: %w", jump.chain, err)
+		}
+	}
+	for _, chain := range [][2]string{{"nat", servicesChain}, {"nat", postroutingChain}, {"filter", forwardChain}} {
+		if err := p.ipt.DeleteChain(chain[0], chain[1]); err != nil {
+			return fmt.Errorf("failed to remove chain %s: %w", chain[1], err)
+		}
+	}
+
+	p.applied = ""
+	return nil
+}
+
+// renderServiceRules renders the proxy chains in iptables-restore format.
+// Each backend is chosen with probability 1/(remaining backends), which
+// spreads new connections evenly; conntrack keeps a connection on the
+// backend it was first sent to. Published ports only match traffic
+// addressed to this node, so every node answers on them (routing mesh).
+// Forward ports come first and only reach local replicas, so traffic
+// another node already balanced is never balanced again, even by a
+// published port rule for the same port number.
+func renderServiceRules(serviceSubnet string, nodeIP net.IP, services []ServiceVIP) string {
+	sorted := make([]ServiceVIP, len(services))
+	copy(sorted, services)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	// Conntrack matches for connections the proxy DNATed
+	var dnatMatches []string
+	if serviceSubnet != "" {
+		dnatMatches = append(dnatMatches, fmt.Sprintf("-m conntrack --ctstate DNAT --ctorigdst %s", serviceSubnet))
+	}
+
+	var forward, nat strings.Builder
+	for _, svc := range sorted {
+		ports := make([]ServiceVIPPort, len(svc.Ports))
+		copy(ports, svc.Ports)
+		sort.Slice(ports, func(i, j int) bool {
+			if ports[i].Port != ports[j].Port {
+				return ports[i].Port < ports[j].Port
+			}
+			return ports[i].Protocol < ports[j].Protocol
+		})
+
+		for _, port := range ports {
+			protocol := normalizeProtocol(port.Protocol)
+
+			backends := make([]string, len(port.Backends))
+			copy(backends, port.Backends)
+			sort.Strings(backends)
+
+			if nodeIP != nil && port.ForwardPort > 0 {
+				local := make([]string, len(port.Local))
+				copy(local, port.Local)
+				sort.Strings(local)
+
+				// Without local replicas, forwarded traffic must still not
+				// fall through to a published port rule
+				match := fmt.Sprintf("-A %s -d %s/32 -p %s --dport %d -m comment --comment \"%s:%d/%s forward\"",
+					servicesChain, nodeIP, protocol, port.ForwardPort, svc.Name, port.Port, protocol)
+				if len(local) == 0 {
+					fmt.Fprintf(&forward, "%s -j RETURN\n", match)
+				} else {
+					writeDNATRules(&forward, match, local)
+					dnatMatches = append(dnatMatches, fmt.Sprintf("-p %s -m conntrack --ctstate DNAT --ctorigdst %s/32 --ctorigdstport %d", protocol, nodeIP, port.ForwardPort))
+				}
+			}
+
+			if svc.VIP != nil {
+				match := fmt.Sprintf("-A %s -d %s/32 -p %s --dport %d -m comment --comment \"%s:%d/%s\"",
+					servicesChain, svc.VIP, protocol, port.Port, svc.Name, port.Port, protocol)
+				writeDNATRules(&nat, match, backends)
+			}
+
+			if port.PublishedPort > 0 {
+				match := fmt.Sprintf("-A %s -m addrtype --dst-type LOCAL -p %s --dport %d -m comment --comment \"%s:%d/%s ingress\"",
+					servicesChain, protocol, port.PublishedPort, svc.Name, port.PublishedPort, protocol)
+				writeDNATRules(&nat, match, backends)
+				dnatMatches = append(dnatMatches, fmt.Sprintf("-p %s -m conntrack --ctstate DNAT --ctorigdstport %d", protocol, port.PublishedPort))
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("*nat\n")
+	fmt.Fprintf(&b, ":%s - [0:0]\n", servicesChain)
+	fmt.Fprintf(&b, ":%s - [0:0]\n", postroutingChain)
+	b.WriteString(forward.String())
+	b.WriteString(nat.String())
+	for _, match := range dnatMatches {
+		fmt.Fprintf(&b, "-A %s %s -j MASQUERADE\n", postroutingChain, match)
+	}
+	b.WriteString("COMMIT\n")
+
+	b.WriteString("*filter\n")
+	fmt.Fprintf(&b, ":%s - [0:0]\n", forwardChain)
+	for _, match := range dnatMatches {
+		fmt.Fprintf(&b, "-A %s %s -j ACCEPT\n", forwardChain, match)
+	}
+	b.WriteString("COMMIT\n")
+
+	return b.String()
+}
+
+// writeDNATRules writes one DNAT rule per backend, spreading connections
+// evenly across them
+func writeDNATRules(b *strings.Builder, match string, backends []string) {
+	for i, backend := range backends {
+		remaining := len(backends) - i
+		if remaining > 1 {
+			fmt.Fprintf(b, "%s -m statistic --mode random --probability %.5f -j DNAT --to-destination %s\n",
+				match, 1/float64(remaining), backend)
+		} else {
+			fmt.Fprintf(b, "%s -j DNAT --to-destination %s\n", match, backend)
+		}
+	}
+}
+
+// commandIPTables implements IPTables with the iptables tools
+type commandIPTables struct{}
+
+// NewCommandIPTables returns an IPTables that drives the kernel through the
+// iptables and iptables-restore command line tools
+func NewCommandIPTables() IPTables {
+	return &commandIPTables{}
+}
+
+// EnsureJump inserts a jump at the top of a built-in chain if it is missing
+func (c *commandIPTables) EnsureJump(table, chain, target string) error {
+	if err := runCommand(nil, "iptables", "-t", table, "-C", chain, "-j", target); err == nil {
+		return nil
+	}
+	return runCommand(nil, "iptables", "-t", table, "-I", chain, "1", "-j", target)
+}
+
+// DeleteJump removes a jump from a built-in chain
+func (c *commandIPTables) DeleteJump(table, chain, target string) error {
+	if err := runCommand(nil, "iptables", "-t", table, "-C", chain, "-j", target); err != nil {
+		return nil // Already gone
+	}
+	return runCommand(nil, "iptables", "-t", table, "-D", chain, "-j", target)
+}
+
+// Restore replaces the declared chains without flushing the rest of the tables
+func (c *commandIPTables) Restore(rules string) error {
+	return runCommand(strings.NewReader(rules), "iptables-restore", "--noflush")
+}
+
+// DeleteChain flushes and removes a chain
+func (c *commandIPTables) DeleteChain(table, chain string) error {
+	if err := runCommand(nil, "iptables", "-t", table, "-L", chain, "-n"); err != nil {
+		return nil // Already gone
+	}
+	if err := runCommand(nil, "iptables", "-t", table, "-F", chain); err != nil {
+		return err
+	}
+	return runCommand(nil, "iptables", "-t", table, "-X", chain)
+}
@@ -0,0 +1,318 @@
+package network
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/cuemby/warren/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeIPTables records netfilter operations instead of performing them
+type fakeIPTables struct {
+	jumps    map[string]bool
+	chains   map[string]bool
+	restores int
+	rules    string
+	failNext bool
+}
+
+func newFakeIPTables() *fakeIPTables {
+	return &fakeIPTables{
+		jumps:  make(map[string]bool),
+		chains: make(map[string]bool),
+	}
+}
+
+func (f *fakeIPTables) EnsureJump(table, chain, target string) error {
+	f.jumps[table+"/"+chain+"->"+target] = true
+	return nil
+}
+
+func (f *fakeIPTables) DeleteJump(table, chain, target string) error {
+	delete(f.jumps, table+"/"+chain+"->"+target)
+	return nil
+}
+
+func (f *fakeIPTables) Restore(rules string) error {
+	if f.failNext {
+		f.failNext = false
+		return fmt.Errorf("iptables-restore: line 3 failed")
+	}
+	f.restores++
+	f.rules = rules
+	f.chains["nat/"+servicesChain] = true
+	f.chains["nat/"+postroutingChain] = true
+	f.chains["filter/"+forwardChain] = true
+	return nil
+}
+
+func (f *fakeIPTables) DeleteChain(table, chain string) error {
+	delete(f.chains, table+"/"+chain)
+	return nil
+}
+
+func webService(backends ...string) ServiceVIP {
+	return ServiceVIP{
+		Name: "web",
+		VIP:  net.ParseIP("10.0.1.2"),
+		Ports: []ServiceVIPPort{
+			{Protocol: "tcp", Port: 80, Backends: backends},
+		},
+	}
+}
+
+func TestRenderServiceRules(t *testing.T) {
+	rules := renderServiceRules("10.0.1.0/24", nil, []ServiceVIP{
+		webService("10.0.0.3:80", "10.0.0.2:80", "172.17.0.5:80"),
+		{Name: "dns", VIP: net.ParseIP("10.0.1.3"), Ports: []ServiceVIPPort{{Protocol: "udp", Port: 53, Backends: []string{"10.0.0.4:53"}}}},
+		{Name: "novip", Ports: []ServiceVIPPort{{Port: 8080, Backends: []string{"10.0.0.5:8080"}}}},
+	})
+
+	expected := `*nat
+:WARREN-SERVICES - [0:0]
+:WARREN-POSTROUTING - [0:0]
+-A WARREN-SERVICES -d 10.0.1.3/32 -p udp --dport 53 -m comment --comment "dns:53/udp" -j DNAT --to-destination 10.0.0.4:53
+-A WARREN-SERVICES -d 10.0.1.2/32 -p tcp --dport 80 -m comment --comment "web:80/tcp" -m statistic --mode random --probability 0.33333 -j DNAT --to-destination 10.0.0.2:80
+-A WARREN-SERVICES -d 10.0.1.2/32 -p tcp --dport 80 -m comment --comment "web:80/tcp" -m statistic --mode random --probability 0.50000 -j DNAT --to-destination 10.0.0.3:80
+-A WARREN-SERVICES -d 10.0.1.2/32 -p tcp --dport 80 -m comment --comment "web:80/tcp" -j DNAT --to-destination 172.17.0.5:80
+-A WARREN-POSTROUTING -m conntrack --ctstate DNAT --ctorigdst 10.0.1.0/24 -j MASQUERADE
+COMMIT
+*filter
+:WARREN-FORWARD - [0:0]
+-A WARREN-FORWARD -m conntrack --ctstate DNAT --ctorigdst 10.0.1.0/24 -j ACCEPT
+COMMIT
+`
+	assert.Equal(t, expected, rules)
+}
+
+func TestRenderServiceRules_RoutingMesh(t *testing.T) {
+	rules := renderServiceRules("10.0.1.0/24", nil, []ServiceVIP{
+		{Name: "web", Ports: []ServiceVIPPort{{Protocol: "tcp", Port: 80, PublishedPort: 8080, Backends: []string{"10.0.0.3:80", "10.0.0.2:80"}}}},
+	})
+
+	expected := `*nat
+:WARREN-SERVICES - [0:0]
+:WARREN-POSTROUTING - [0:0]
+-A WARREN-SERVICES -m addrtype --dst-type LOCAL -p tcp --dport 8080 -m comment --comment "web:8080/tcp ingress" -m statistic --mode random --probability 0.50000 -j DNAT --to-destination 10.0.0.2:80
+-A WARREN-SERVICES -m addrtype --dst-type LOCAL -p tcp --dport 8080 -m comment --comment "web:8080/tcp ingress" -j DNAT --to-destination 10.0.0.3:80
+-A WARREN-POSTROUTING -m conntrack --ctstate DNAT --ctorigdst 10.0.1.0/24 -j MASQUERADE
+-A WARREN-POSTROUTING -p tcp -m conntrack --ctstate DNAT --ctorigdstport 8080 -j MASQUERADE
+COMMIT
+*filter
+:WARREN-FORWARD - [0:0]
+-A WARREN-FORWARD -m conntrack --ctstate DNAT --ctorigdst 10.0.1.0/24 -j ACCEPT
+-A WARREN-FORWARD -p tcp -m conntrack --ctstate DNAT --ctorigdstport 8080 -j ACCEPT
+COMMIT
+`
+	assert.Equal(t, expected, rules, "a service without a VIP still gets its published port")
+}
+
+// TestRenderServiceRules_TwoNodes tests a service with a replica on each of
+// two nodes: each node balances the VIP across its own replica and the other
+// node's forward port, and translates its forward port to its own replica only
+func TestRenderServiceRules_TwoNodes(t *testing.T) {
+	nodeA, nodeB := net.ParseIP("10.0.0.2"), net.ParseIP("10.0.0.3")
+	forward := ForwardPort(net.ParseIP("10.0.1.2"), []*types.PortMapping{{ContainerPort: 80}}, 80, "tcp")
+	require.Equal(t, 63064, forward)
+
+	// Both replicas have the same container IP, each in its node's bridge
+	service := func(remote string) ServiceVIP {
+		svc := webService("10.88.0.5:80", remote)
+		svc.Ports[0].ForwardPort = forward
+		svc.Ports[0].Local = []string{"10.88.0.5:80"}
+		return svc
+	}
+
+	expected := `*nat
+:WARREN-SERVICES - [0:0]
+:WARREN-POSTROUTING - [0:0]
+-A WARREN-SERVICES -d 10.0.0.2/32 -p tcp --dport 63064 -m comment --comment "web:80/tcp forward" -j DNAT --to-destination 10.88.0.5:80
+-A WARREN-SERVICES -d 10.0.1.2/32 -p tcp --dport 80 -m comment --comment "web:80/tcp" -m statistic --mode random --probability 0.50000 -j DNAT --to-destination 10.0.0.3:63064
+-A WARREN-SERVICES -d 10.0.1.2/32 -p tcp --dport 80 -m comment --comment "web:80/tcp" -j DNAT --to-destination 10.88.0.5:80
+-A WARREN-POSTROUTING -m conntrack --ctstate DNAT --ctorigdst 10.0.1.0/24 -j MASQUERADE
+-A WARREN-POSTROUTING -p tcp -m conntrack --ctstate DNAT --ctorigdst 10.0.0.2/32 --ctorigdstport 63064 -j MASQUERADE
+COMMIT
+*filter
+:WARREN-FORWARD - [0:0]
+-A WARREN-FORWARD -m conntrack --ctstate DNAT --ctorigdst 10.0.1.0/24 -j ACCEPT
+-A WARREN-FORWARD -p tcp -m conntrack --ctstate DNAT --ctorigdst 10.0.0.2/32 --ctorigdstport 63064 -j ACCEPT
+COMMIT
+`
+	assert.Equal(t, expected, renderServiceRules("10.0.1.0/24", nodeA, []ServiceVIP{service("10.0.0.3:63064")}))
+
+	rulesB := renderServiceRules("10.0.1.0/24", nodeB, []ServiceVIP{service("10.0.0.2:63064")})
+	assert.Contains(t, rulesB, `-A WARREN-SERVICES -d 10.0.0.3/32 -p tcp --dport 63064 -m comment --comment "web:80/tcp forward" -j DNAT --to-destination 10.88.0.5:80`+"\n")
+	assert.Contains(t, rulesB, "-j DNAT --to-destination 10.0.0.2:63064\n", "node B balances the VIP onto node A")
+	assert.NotContains(t, rulesB, "10.0.0.3:63064", "node B never forwards to itself")
+}
+
+// TestRenderServiceRules_RoutingMeshRemoteReplica tests a published port
+// whose only replica runs on another node, with the published port different
+// from the container port: the node receiving the connection sends it to the
+// replica's node forward port, and that node translates it to its replica
+// before its own published port rule can balance it again
+func TestRenderServiceRules_RoutingMeshRemoteReplica(t *testing.T) {
+	nodeA, nodeB := net.ParseIP("10.0.0.2"), net.ParseIP("10.0.0.3")
+	port := ServiceVIPPort{Protocol: "tcp", Port: 80, PublishedPort: 8080, ForwardPort: 63064}
+
+	onA := port
+	onA.Backends = []string{"10.0.0.3:63064"}
+	rulesA := renderServiceRules("10.0.1.0/24", nodeA, []ServiceVIP{{Name: "web", VIP: net.ParseIP("10.0.1.2"), Ports: []ServiceVIPPort{onA}}})
+
+	expected := `*nat
+:WARREN-SERVICES - [0:0]
+:WARREN-POSTROUTING - [0:0]
+-A WARREN-SERVICES -d 10.0.0.2/32 -p tcp --dport 63064 -m comment --comment "web:80/tcp forward" -j RETURN
+-A WARREN-SERVICES -d 10.0.1.2/32 -p tcp --dport 80 -m comment --comment "web:80/tcp" -j DNAT --to-destination 10.0.0.3:63064
+-A WARREN-SERVICES -m addrtype --dst-type LOCAL -p tcp --dport 8080 -m comment --comment "web:8080/tcp ingress" -j DNAT --to-destination 10.0.0.3:63064
+-A WARREN-POSTROUTING -m conntrack --ctstate DNAT --ctorigdst 10.0.1.0/24 -j MASQUERADE
+-A WARREN-POSTROUTING -p tcp -m conntrack --ctstate DNAT --ctorigdstport 8080 -j MASQUERADE
+COMMIT
+*filter
+:WARREN-FORWARD - [0:0]
+-A WARREN-FORWARD -m conntrack --ctstate DNAT --ctorigdst 10.0.1.0/24 -j ACCEPT
+-A WARREN-FORWARD -p tcp -m conntrack --ctstate DNAT --ctorigdstport 8080 -j ACCEPT
+COMMIT
+`
+	assert.Equal(t, expected, rulesA)
+
+	onB := port
+	onB.Backends = []string{"10.88.0.7:80"}
+	onB.Local = []string{"10.88.0.7:80"}
+	rulesB := renderServiceRules("10.0.1.0/24", nodeB, []ServiceVIP{{Name: "web", VIP: net.ParseIP("10.0.1.2"), Ports: []ServiceVIPPort{onB}}})
+
+	forward := `-A WARREN-SERVICES -d 10.0.0.3/32 -p tcp --dport 63064 -m comment --comment "web:80/tcp forward" -j DNAT --to-destination 10.88.0.7:80` + "\n"
+	published := `-A WARREN-SERVICES -m addrtype --dst-type LOCAL -p tcp --dport 8080 -m comment --comment "web:8080/tcp ingress" -j DNAT --to-destination 10.88.0.7:80` + "\n"
+	require.Contains(t, rulesB, forward)
+	require.Contains(t, rulesB, published)
+	assert.Less(t, strings.Index(rulesB, forward), strings.Index(rulesB, published), "forwarded traffic is translated before the published port rule")
+}
+
+// TestRenderServiceRules_ForwardPortNotRebalanced tests that traffic
+// forwarded to a node without local replicas is not caught by a published
+// port rule that happens to use the same port number
+func TestRenderServiceRules_ForwardPortNotRebalanced(t *testing.T) {
+	rules := renderServiceRules("10.0.1.0/24", net.ParseIP("10.0.0.2"), []ServiceVIP{
+		{Name: "api", Ports: []ServiceVIPPort{{Protocol: "tcp", Port: 80, PublishedPort: 63064, Backends: []string{"10.0.0.3:63072"}}}},
+		{Name: "web", VIP: net.ParseIP("10.0.1.2"), Ports: []ServiceVIPPort{{Protocol: "tcp", Port: 80, ForwardPort: 63064, Backends: []string{"10.0.0.3:63064"}}}},
+	})
+
+	forward := `-A WARREN-SERVICES -d 10.0.0.2/32 -p tcp --dport 63064 -m comment --comment "web:80/tcp forward" -j RETURN` + "\n"
+	require.Contains(t, rules, forward)
+	assert.Less(t, strings.Index(rules, forward), strings.Index(rules, "--dst-type LOCAL"))
+}
+
+// TestRenderServiceRules_NoOverlay tests that a node without an overlay IP
+// gets no forward rules
+func TestRenderServiceRules_NoOverlay(t *testing.T) {
+	svc := webService("10.88.0.5:80")
+	svc.Ports[0].ForwardPort = 63064
+	svc.Ports[0].Local = []string{"10.88.0.5:80"}
+
+	assert.NotContains(t, renderServiceRules("10.0.1.0/24", nil, []ServiceVIP{svc}), "forward")
+}
+
+func TestForwardPort(t *testing.T) {
+	ports := []*types.PortMapping{
+		{ContainerPort: 80},
+		{ContainerPort: 53, Protocol: "udp"},
+		{ContainerPort: 80, HostPort: 8080, PublishMode: types.PublishModeIngress},
+	}
+
+	tests := []struct {
+		name     string
+		vip      string
+		port     int
+		protocol string
+		expected int
+	}{
+		{name: "first port", vip: "10.0.1.2", port: 80, protocol: "tcp", expected: 63064},
+		{name: "second port", vip: "10.0.1.2", port: 53, protocol: "udp", expected: 63065},
+		{name: "next VIP", vip: "10.0.1.3", port: 80, protocol: "tcp", expected: 63072},
+		{name: "empty protocol is tcp", vip: "10.0.1.2", port: 80, expected: 63064},
+		{name: "last VIP in a /23", vip: "10.0.1.254", port: 80, protocol: "tcp", expected: 65080},
+		{name: "other protocol", vip: "10.0.1.2", port: 80, protocol: "udp"},
+		{name: "unknown port", vip: "10.0.1.2", port: 443, protocol: "tcp"},
+		{name: "no VIP", port: 80, protocol: "tcp"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ForwardPort(net.ParseIP(tt.vip), ports, tt.port, tt.protocol))
+		})
+	}
+
+	many := make([]*types.PortMapping, forwardPortsPerService+1)
+	for i := range many {
+		many[i] = &types.PortMapping{ContainerPort: 8000 + i}
+	}
+	assert.Zero(t, ForwardPort(net.ParseIP("10.0.1.2"), many, 8000+forwardPortsPerService, "tcp"), "ports beyond the per-service block have none")
+}
+
+func TestRenderServiceRules_NoBackends(t *testing.T) {
+	rules := renderServiceRules("10.0.1.0/24", nil, []ServiceVIP{webService()})
+	assert.NotContains(t, rules, "DNAT --to-destination", "a port without replicas gets no rules")
+}
+
+func TestServiceProxySync(t *testing.T) {
+	ipt := newFakeIPTables()
+	proxy := NewServiceProxy(ipt)
+
+	changed, err := proxy.Sync("10.0.1.0/24", nil, []ServiceVIP{webService("10.0.0.2:80")})
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, 1, ipt.restores)
+	assert.True(t, ipt.jumps["nat/PREROUTING->WARREN-SERVICES"])
+	assert.True(t, ipt.jumps["nat/OUTPUT->WARREN-SERVICES"])
+	assert.True(t, ipt.jumps["nat/POSTROUTING->WARREN-POSTROUTING"])
+	assert.True(t, ipt.jumps["filter/FORWARD->WARREN-FORWARD"])
+
+	// Same endpoints: no restore, but jumps are re-checked
+	delete(ipt.jumps, "nat/OUTPUT->WARREN-SERVICES")
+	changed, err = proxy.Sync("10.0.1.0/24", nil, []ServiceVIP{webService("10.0.0.2:80")})
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, 1, ipt.restores)
+	assert.True(t, ipt.jumps["nat/OUTPUT->WARREN-SERVICES"])
+
+	// New replica
+	changed, err = proxy.Sync("10.0.1.0/24", nil, []ServiceVIP{webService("10.0.0.2:80", "10.0.0.3:80")})
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, 2, ipt.restores)
+	assert.Contains(t, ipt.rules, "10.0.0.3:80")
+}
+
+func TestServiceProxySync_RetriesAfterFailure(t *testing.T) {
+	ipt := newFakeIPTables()
+	ipt.failNext = true
+	proxy := NewServiceProxy(ipt)
+
+	_, err := proxy.Sync("10.0.1.0/24", nil, []ServiceVIP{webService("10.0.0.2:80")})
+	require.Error(t, err)
+
+	changed, err := proxy.Sync("10.0.1.0/24", nil, []ServiceVIP{webService("10.0.0.2:80")})
+	require.NoError(t, err)
+	assert.True(t, changed, "rules that failed to apply are restored again")
+}
+
+func TestServiceProxyDown(t *testing.T) {
+	ipt := newFakeIPTables()
+	proxy := NewServiceProxy(ipt)
+
+	_, err := proxy.Sync("10.0.1.0/24", nil, []ServiceVIP{webService("10.0.0.2:80")})
+	require.NoError(t, err)
+
+	require.NoError(t, proxy.Down())
+	assert.Empty(t, ipt.jumps)
+	assert.Empty(t, ipt.chains)
+
+	// Rules are restored again after coming back up
+	changed, err := proxy.Sync("10.0.1.0/24", nil, []ServiceVIP{webService("10.0.0.2:80")})
+	require.NoError(t, err)
+	assert.True(t, changed)
+}
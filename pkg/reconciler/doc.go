@@ -122,7 +122,9 @@ For each task in the cluster:
 
  4. Garbage collect completed tasks:
     • If DesiredState = Shutdown and ActualState = Complete
+    • Or the task finished and its service was deleted
     • Wait 5 minutes, then delete task
+    • Job tasks that completed on their own stay until the job is deleted
 
 # Usage Examples
 
@@ -429,6 +431,7 @@ The reconciler exports Prometheus metrics:
 # See Also
 
   - pkg/scheduler - Task placement and orchestration
+  - pkg/jobs - Job status and cronjob schedules
   - pkg/manager - Cluster state management
   - pkg/health - Health check implementation
   - pkg/worker - Task execution and state reporting
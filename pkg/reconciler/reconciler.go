@@ -8,6 +8,7 @@ import (
 	"github.com/cuemby/warren/pkg/log"
 	"github.com/cuemby/warren/pkg/manager"
 	"github.com/cuemby/warren/pkg/metrics"
+	"github.com/cuemby/warren/pkg/scheduler"
 	"github.com/cuemby/warren/pkg/types"
 	"github.com/rs/zerolog"
 )
@@ -122,6 +123,15 @@ func (r *Reconciler) reconcileContainers() error {
 		return fmt.Errorf("failed to list containers: %w", err)
 	}
 
+	services, err := r.manager.ListServices()
+	if err != nil {
+		return fmt.Errorf("failed to list services: %w", err)
+	}
+	serviceByID := make(map[string]*types.Service, len(services))
+	for _, service := range services {
+		serviceByID[service.ID] = service
+	}
+
 	for _, container := range containers {
 		// Handle failed containers
 		if container.ActualState == types.ContainerStateFailed && container.DesiredState == types.ContainerStateRunning {
@@ -163,6 +173,22 @@ func (r *Reconciler) reconcileContainers() error {
 			}
 		}
 
+		// Containers never placed on a node have nothing to stop
+		if container.NodeID == "" {
+			if container.DesiredState != types.ContainerStateRunning {
+				r.logger.Debug().
+					Str("container_id", container.ID).
+					Msg("Deleting unplaced container")
+				if err := r.manager.DeleteContainer(container.ID); err != nil {
+					r.logger.Error().
+						Err(err).
+						Str("container_id", container.ID).
+						Msg("Failed to delete unplaced container")
+				}
+			}
+			continue
+		}
+
 		// Handle containers on down nodes
 		node, err := r.manager.GetNode(container.NodeID)
 		if err != nil {
@@ -191,8 +217,34 @@ func (r *Reconciler) reconcileContainers() error {
 			}
 		}
 
-		// Clean up completed shutdown containers
-		if container.DesiredState == types.ContainerStateShutdown && container.ActualState == types.ContainerStateComplete {
+		// Evict containers from nodes with a NoExecute taint their service
+		// does not tolerate (the scheduler places replacements elsewhere)
+		if service, ok := serviceByID[container.ServiceID]; ok && container.DesiredState == types.ContainerStateRunning {
+			if taint, untolerated := scheduler.UntoleratedTaint(service, node, types.TaintEffectNoExecute); untolerated {
+				r.logger.Info().
+					Str("container_id", container.ID).
+					Str("node_id", node.ID).
+					Str("taint", scheduler.FormatTaint(taint)).
+					Msg("Evicting container from tainted node")
+
+				container.DesiredState = types.ContainerStateShutdown
+				container.Error = fmt.Sprintf("evicted: untolerated taint %s", scheduler.FormatTaint(taint))
+				if err := r.manager.UpdateContainer(container); err != nil {
+					r.logger.Error().
+						Err(err).
+						Str("container_id", container.ID).
+						Msg("Failed to evict container")
+				}
+			}
+		}
+
+		// Clean up completed shutdown containers, and finished containers
+		// of deleted services. A job's containers that completed on their
+		// own are its progress and stay until the job is deleted.
+		_, serviceExists := serviceByID[container.ServiceID]
+		finished := container.ActualState == types.ContainerStateComplete || container.ActualState == types.ContainerStateFailed
+		if (container.DesiredState == types.ContainerStateShutdown && container.ActualState == types.ContainerStateComplete) ||
+			(!serviceExists && finished) {
 			// Container can be deleted after some grace period
 			if time.Since(container.FinishedAt) > 5*time.Minute {
 				r.logger.Debug().
@@ -7,6 +7,7 @@ import (
 	"net"
 	"os/exec"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/containerd/containerd/namespaces"
 	"github.com/containerd/containerd/oci"
 	"github.com/cuemby/warren/pkg/types"
+	"github.com/google/uuid"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
 )
 
@@ -30,6 +32,10 @@ const (
 type ContainerdRuntime struct {
 	client    *containerd.Client
 	namespace string
+
+	// Container output capture
+	logConfig LogConfig
+	logsMu    sync.Mutex
 }
 
 // NewContainerdRuntime creates a new containerd runtime client
@@ -49,6 +55,14 @@ func NewContainerdRuntime(socketPath string) (*ContainerdRuntime, error) {
 	}, nil
 }
 
+// SetLogConfig sets where container output is stored and how it is rotated.
+// It applies to containers started afterwards.
+func (r *ContainerdRuntime) SetLogConfig(config LogConfig) {
+	r.logsMu.Lock()
+	defer r.logsMu.Unlock()
+	r.logConfig = config
+}
+
 // Close closes the containerd client connection
 func (r *ContainerdRuntime) Close() error {
 	if r.client != nil {
@@ -218,8 +232,18 @@ func (r *ContainerdRuntime) StartContainer(ctx context.Context, containerID stri
 		return fmt.Errorf("failed to load container %s: %w", containerID, err)
 	}
 
+	// The shim hands stdout/stderr to the logger binary, which writes the
+	// container's log file whether or not this process is running
+	r.logsMu.Lock()
+	config := r.logConfig
+	r.logsMu.Unlock()
+	logURI, err := LoggerURI(config)
+	if err != nil {
+		return err
+	}
+
 	// Create a task (running instance)
-	task, err := container.NewTask(ctx, cio.NullIO)
+	task, err := container.NewTask(ctx, cio.LogURI(logURI))
 	if err != nil {
 		return fmt.Errorf("failed to create task: %w", err)
 	}
@@ -232,6 +256,26 @@ func (r *ContainerdRuntime) StartContainer(ctx context.Context, containerID stri
 	return nil
 }
 
+// RestartContainer replaces the exited task of a container with a new one
+func (r *ContainerdRuntime) RestartContainer(ctx context.Context, containerID string) error {
+	ctx = namespaces.WithNamespace(ctx, r.namespace)
+
+	// Get the container
+	container, err := r.client.LoadContainer(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("failed to load container %s: %w", containerID, err)
+	}
+
+	// Remove the previous task, if any
+	if task, err := container.Task(ctx, nil); err == nil {
+		if _, err := task.Delete(ctx, containerd.WithProcessKill); err != nil {
+			return fmt.Errorf("failed to delete task: %w", err)
+		}
+	}
+
+	return r.StartContainer(ctx, containerID)
+}
+
 // StopContainer stops a running container
 func (r *ContainerdRuntime) StopContainer(ctx context.Context, containerID string, timeout time.Duration) error {
 	ctx = namespaces.WithNamespace(ctx, r.namespace)
@@ -305,6 +349,11 @@ func (r *ContainerdRuntime) DeleteContainer(ctx context.Context, containerID str
 		return fmt.Errorf("failed to delete container: %w", err)
 	}
 
+	// Remove captured output
+	if err := RemoveContainerLogs(r.logConfig, containerID); err != nil {
+		fmt.Printf("Warning: failed to remove container logs: %v\n", err)
+	}
+
 	return nil
 }
 
@@ -348,27 +397,99 @@ func (r *ContainerdRuntime) GetContainerStatus(ctx context.Context, containerID
 	}
 }
 
-// GetContainerLogs streams container logs (simplified implementation)
+// GetContainerLogs returns the captured output of a container, one line per
+// entry
 func (r *ContainerdRuntime) GetContainerLogs(ctx context.Context, containerID string) (io.ReadCloser, error) {
+	var buf strings.Builder
+	err := r.ReadContainerLogs(ctx, containerID, LogOptions{}, func(entry LogEntry) error {
+		buf.WriteString(entry.Line)
+		buf.WriteByte('\n')
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return io.NopCloser(strings.NewReader(buf.String())), nil
+}
+
+// ReadContainerLogs calls fn for each captured log line of a container,
+// following new output if opts.Follow is set
+func (r *ContainerdRuntime) ReadContainerLogs(ctx context.Context, containerID string, opts LogOptions, fn func(LogEntry) error) error {
+	r.logsMu.Lock()
+	config := r.logConfig
+	r.logsMu.Unlock()
+
+	return ReadContainerLogs(ctx, config, containerID, opts, fn)
+}
+
+// Exec runs a command inside a running container, copying its output to
+// stdout and stderr, and returns the command's exit code. The command is
+// killed when ctx is done.
+func (r *ContainerdRuntime) Exec(ctx context.Context, containerID string, command []string, stdout, stderr io.Writer) (int, error) {
 	ctx = namespaces.WithNamespace(ctx, r.namespace)
 
+	if len(command) == 0 {
+		return -1, fmt.Errorf("no command specified")
+	}
+
 	// Get the container
 	container, err := r.client.LoadContainer(ctx, containerID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load container %s: %w", containerID, err)
+		return -1, fmt.Errorf("failed to load container %s: %w", containerID, err)
 	}
 
 	// Get the task
 	task, err := container.Task(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get task: %w", err)
+		return -1, fmt.Errorf("failed to get task: %w", err)
 	}
 
-	// Get logs (this is simplified - real implementation would use cio.LogFile)
-	_ = task // Use task for future log implementation
+	// Run with the container's user, environment and working directory
+	spec, err := container.Spec(ctx)
+	if err != nil {
+		return -1, fmt.Errorf("failed to get container spec: %w", err)
+	}
+	pspec := *spec.Process
+	pspec.Args = command
+	pspec.Terminal = false
 
-	// For now, return nil (logs implementation deferred)
-	return nil, fmt.Errorf("logs not yet implemented")
+	execID := "exec-" + uuid.New().String()[:8]
+	process, err := task.Exec(ctx, execID, &pspec, cio.NewCreator(cio.WithStreams(nil, stdout, stderr)))
+	if err != nil {
+		return -1, fmt.Errorf("failed to exec in container: %w", err)
+	}
+
+	// Clean up with a fresh context, ctx may already be done
+	cleanupCtx := namespaces.WithNamespace(context.Background(), r.namespace)
+	defer func() {
+		_, _ = process.Delete(cleanupCtx, containerd.WithProcessKill)
+	}()
+
+	// Wait must be set up before start to not miss the exit
+	statusC, err := process.Wait(cleanupCtx)
+	if err != nil {
+		return -1, fmt.Errorf("failed to wait for exec process: %w", err)
+	}
+
+	if err := process.Start(ctx); err != nil {
+		return -1, fmt.Errorf("failed to start exec process: %w", err)
+	}
+
+	select {
+	case status := <-statusC:
+		// Make sure all output has been copied before returning
+		process.IO().Wait()
+		code, _, err := status.Result()
+		if err != nil {
+			return -1, fmt.Errorf("failed to get exec exit status: %w", err)
+		}
+		return int(code), nil
+	case <-ctx.Done():
+		_ = process.Kill(cleanupCtx, syscall.SIGKILL)
+		<-statusC
+		return -1, fmt.Errorf("exec timed out: %w", ctx.Err())
+	}
 }
 
 // IsRunning checks if a container is currently running
@@ -203,6 +203,25 @@ Getting Container IP Address:
 	}
 	fmt.Printf("Container IP: %s\n", ip)
 
+# Container Logs
+
+StartContainer has containerd's shim hand the task's stdout and stderr to a
+logger binary (LoggerURI), which writes them to a per-container log file. Each line is stored as a JSON entry with its
+timestamp and stream:
+
+	{"time":"2024-01-01T12:00:00Z","stream":"stdout","log":"listening on :80"}
+
+Files live in LogConfig.Dir (default /var/lib/warren/logs) and are rotated
+when they reach LogConfig.MaxSize; LogConfig.MaxFiles files are kept per
+container (<id>.log, <id>.log.1, ...). ReadContainerLogs reads them oldest
+first, supports Since/Tail filtering and can follow new output across
+rotations. Logs are removed with the container.
+
+The logger is the warren binary itself, run by the shim as
+"warren container-logger <config>" (RunLogger). The shim owns the pipes and
+the logger, so output keeps being written, and containers never block on a
+full pipe, while the worker is restarting.
+
 # Integration Points
 
 This package integrates with:
@@ -287,7 +306,7 @@ Container Fails to Start:
   - Symptom: CreateContainer succeeds but StartContainer fails
   - Check: Image exists and is unpacked (PullImage first)
   - Check: OCI spec is valid (resource limits, mounts)
-  - Check: Container logs (via containerd-shim logs)
+  - Check: Container logs (warren service logs NAME)
   - Solution: Review task definition for invalid configuration
 
 Resource Limit Enforcement:
@@ -0,0 +1,478 @@
+package runtime
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/runtime/v2/logging"
+)
+
+const (
+	// DefaultLogDir is where per-container log files are written
+	DefaultLogDir = "/var/lib/warren/logs"
+
+	// DefaultLogMaxSize is the size at which a container log file is rotated
+	DefaultLogMaxSize = 10 * 1024 * 1024 // 10MB
+
+	// DefaultLogMaxFiles is the number of log files kept per container,
+	// including the active one
+	DefaultLogMaxFiles = 3
+
+	// logFollowInterval is how often a followed log file is polled for new lines
+	logFollowInterval = 250 * time.Millisecond
+
+	// LoggerCommand is the command of the logger binary that RunLogger
+	// serves; containerd's shim runs it as "<binary> container-logger <config>"
+	LoggerCommand = "container-logger"
+)
+
+// LogConfig controls where container logs are stored and how they are rotated
+type LogConfig struct {
+	Dir      string
+	MaxSize  int64  // Bytes per file before rotation
+	MaxFiles int    // Files kept per container, including the active one
+	Logger   string // Binary the shim runs to write logs (default: this executable)
+}
+
+// LogEntry is a single line of container output
+type LogEntry struct {
+	Timestamp time.Time `json:"time"`
+	Stream    string    `json:"stream"` // "stdout" or "stderr"
+	Line      string    `json:"log"`
+}
+
+// LogOptions selects which log lines to read
+type LogOptions struct {
+	Since  time.Time // Only lines at or after this time (zero = all)
+	Tail   int       // Only the last N lines (0 = all)
+	Follow bool      // Keep reading new lines until ctx is cancelled
+}
+
+// withDefaults fills in unset fields
+func (c LogConfig) withDefaults() LogConfig {
+	if c.Dir == "" {
+		c.Dir = DefaultLogDir
+	}
+	if c.MaxSize <= 0 {
+		c.MaxSize = DefaultLogMaxSize
+	}
+	if c.MaxFiles <= 0 {
+		c.MaxFiles = DefaultLogMaxFiles
+	}
+	return c
+}
+
+// LoggerURI returns the binary:// URI that makes containerd's shim start
+// the logger binary for a task and hand it the task's stdout and stderr. The
+// shim owns the pipes and the logger, so output keeps being written while the
+// worker is down.
+func LoggerURI(config LogConfig) (*url.URL, error) {
+	config = config.withDefaults()
+
+	binary := config.Logger
+	if binary == "" {
+		executable, err := os.Executable()
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the logger binary: %w", err)
+		}
+		binary = executable
+	}
+
+	// The shim passes each query key and value as arguments, in no
+	// particular order, so the whole configuration is a single value
+	args := url.Values{}
+	args.Set("dir", config.Dir)
+	args.Set("max-size", strconv.FormatInt(config.MaxSize, 10))
+	args.Set("max-files", strconv.Itoa(config.MaxFiles))
+
+	uri, err := cio.LogURIGenerator("binary", binary, map[string]string{LoggerCommand: args.Encode()})
+	if err != nil {
+		return nil, fmt.Errorf("invalid logger binary: %w", err)
+	}
+	return uri, nil
+}
+
+// ParseLoggerConfig parses the configuration LoggerURI passes to the logger
+// binary
+func ParseLoggerConfig(arg string) (LogConfig, error) {
+	args, err := url.ParseQuery(arg)
+	if err != nil {
+		return LogConfig{}, fmt.Errorf("invalid logger configuration: %w", err)
+	}
+
+	config := LogConfig{Dir: args.Get("dir")}
+	if v := args.Get("max-size"); v != "" {
+		if config.MaxSize, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return LogConfig{}, fmt.Errorf("invalid max-size %q: %w", v, err)
+		}
+	}
+	if v := args.Get("max-files"); v != "" {
+		if config.MaxFiles, err = strconv.Atoi(v); err != nil {
+			return LogConfig{}, fmt.Errorf("invalid max-files %q: %w", v, err)
+		}
+	}
+	return config, nil
+}
+
+// RunLogger serves as the logger binary of a task: it writes the stdout and
+// stderr the shim hands it to the container's log file until both close,
+// then exits the process.
+func RunLogger(config LogConfig) {
+	logging.Run(func(ctx context.Context, cfg *logging.Config, ready func() error) error {
+		return writeLogs(config, cfg.ID, cfg.Stdout, cfg.Stderr, ready)
+	})
+}
+
+// writeLogs copies a container's output streams to its log file. ready is
+// called once the file is open, which lets the shim start the task.
+func writeLogs(config LogConfig, containerID string, stdout, stderr io.Reader, ready func() error) error {
+	containerLog, err := OpenContainerLog(config, containerID)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for _, stream := range []struct {
+		r io.Reader
+		w io.Writer
+	}{{stdout, containerLog.Stdout()}, {stderr, containerLog.Stderr()}} {
+		wg.Add(1)
+		go func(r io.Reader, w io.Writer) {
+			defer wg.Done()
+			// Keep draining if the file can't be written, so the
+			// container never blocks on a full pipe
+			if _, err := io.Copy(w, r); err != nil {
+				_, _ = io.Copy(io.Discard, r)
+			}
+		}(stream.r, stream.w)
+	}
+
+	if err := ready(); err != nil {
+		_ = containerLog.Close()
+		return fmt.Errorf("failed to signal the shim: %w", err)
+	}
+
+	wg.Wait()
+	return containerLog.Close()
+}
+
+// logPath returns the active log file of a container; rotated files carry
+// a numeric suffix (.1 is the most recent)
+func (c LogConfig) logPath(containerID string) string {
+	return filepath.Join(c.Dir, containerID+".log")
+}
+
+func (c LogConfig) rotatedPath(containerID string, n int) string {
+	return fmt.Sprintf("%s.%d", c.logPath(containerID), n)
+}
+
+// ContainerLog writes the stdout and stderr of one container to a rotated
+// JSON-lines log file
+type ContainerLog struct {
+	config      LogConfig
+	containerID string
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+
+	stdout *logStreamWriter
+	stderr *logStreamWriter
+}
+
+// OpenContainerLog opens (or appends to) the log file of a container
+func OpenContainerLog(config LogConfig, containerID string) (*ContainerLog, error) {
+	config = config.withDefaults()
+	if err := os.MkdirAll(config.Dir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	l := &ContainerLog{config: config, containerID: containerID}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	l.stdout = &logStreamWriter{log: l, stream: "stdout"}
+	l.stderr = &logStreamWriter{log: l, stream: "stderr"}
+	return l, nil
+}
+
+// Stdout returns the writer for the container's standard output
+func (l *ContainerLog) Stdout() io.Writer {
+	return l.stdout
+}
+
+// Stderr returns the writer for the container's standard error
+func (l *ContainerLog) Stderr() io.Writer {
+	return l.stderr
+}
+
+// Close flushes partial lines and closes the log file
+func (l *ContainerLog) Close() error {
+	l.stdout.flush()
+	l.stderr.flush()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+func (l *ContainerLog) open() error {
+	// #nosec G304 - path is built from the log directory and container ID
+	file, err := os.OpenFile(l.config.logPath(l.containerID), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+	l.file = file
+	l.size = info.Size()
+	return nil
+}
+
+// writeEntry appends one entry, rotating the file first if it is full
+func (l *ContainerLog) writeEntry(entry LogEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return os.ErrClosed
+	}
+
+	if l.size > 0 && l.size+int64(len(data)) > l.config.MaxSize {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := l.file.Write(data)
+	l.size += int64(n)
+	return err
+}
+
+// rotate shifts rotated files up by one, dropping the oldest, and starts a
+// new active file
+func (l *ContainerLog) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+
+	oldest := l.config.MaxFiles - 1
+	if oldest < 1 {
+		_ = os.Remove(l.config.logPath(l.containerID))
+	} else {
+		_ = os.Remove(l.config.rotatedPath(l.containerID, oldest))
+		for n := oldest - 1; n >= 1; n-- {
+			_ = os.Rename(l.config.rotatedPath(l.containerID, n), l.config.rotatedPath(l.containerID, n+1))
+		}
+		if err := os.Rename(l.config.logPath(l.containerID), l.config.rotatedPath(l.containerID, 1)); err != nil {
+			return fmt.Errorf("failed to rotate log file: %w", err)
+		}
+	}
+
+	return l.open()
+}
+
+// logStreamWriter splits one output stream into timestamped lines
+type logStreamWriter struct {
+	log    *ContainerLog
+	stream string
+
+	mu      sync.Mutex
+	partial []byte
+}
+
+func (w *logStreamWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			break
+		}
+		line := string(w.partial[:i])
+		w.partial = w.partial[i+1:]
+		if err := w.log.writeEntry(LogEntry{Timestamp: time.Now().UTC(), Stream: w.stream, Line: line}); err != nil {
+			return len(p), err
+		}
+	}
+	return len(p), nil
+}
+
+// flush writes any unterminated line
+func (w *logStreamWriter) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.partial) == 0 {
+		return
+	}
+	_ = w.log.writeEntry(LogEntry{Timestamp: time.Now().UTC(), Stream: w.stream, Line: string(w.partial)})
+	w.partial = nil
+}
+
+// ReadContainerLogs calls fn for each stored log line of a container, oldest
+// first. With opts.Follow it keeps polling the active file, across
+// rotations, until ctx is cancelled or fn returns an error.
+func ReadContainerLogs(ctx context.Context, config LogConfig, containerID string, opts LogOptions, fn func(LogEntry) error) error {
+	config = config.withDefaults()
+
+	// Collect existing files, oldest first
+	var paths []string
+	for n := config.MaxFiles - 1; n >= 1; n-- {
+		path := config.rotatedPath(containerID, n)
+		if _, err := os.Stat(path); err == nil {
+			paths = append(paths, path)
+		}
+	}
+	active := config.logPath(containerID)
+	current, err := os.Stat(active)
+	if os.IsNotExist(err) && len(paths) == 0 {
+		return fmt.Errorf("no logs found for container %s", containerID)
+	}
+
+	// Read rotated files fully and the active file up to its current end
+	var entries []LogEntry
+	collect := func(entry LogEntry) error {
+		if !opts.Since.IsZero() && entry.Timestamp.Before(opts.Since) {
+			return nil
+		}
+		entries = append(entries, entry)
+		if opts.Tail > 0 && len(entries) > opts.Tail {
+			entries = entries[1:]
+		}
+		return nil
+	}
+	for _, path := range paths {
+		if _, err := readLogFile(path, 0, collect); err != nil {
+			return err
+		}
+	}
+	offset, err := readLogFile(active, 0, collect)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	for _, entry := range entries {
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+
+	if !opts.Follow {
+		return nil
+	}
+
+	emit := func(entry LogEntry) error {
+		if !opts.Since.IsZero() && entry.Timestamp.Before(opts.Since) {
+			return nil
+		}
+		return fn(entry)
+	}
+
+	ticker := time.NewTicker(logFollowInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(active)
+		if err != nil {
+			continue
+		}
+		if current != nil && !os.SameFile(current, info) {
+			// The file was rotated: finish the previous file, then start over
+			if _, err := readLogFile(config.rotatedPath(containerID, 1), offset, emit); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			offset = 0
+		}
+		current = info
+		if info.Size() == offset {
+			continue
+		}
+		offset, err = readLogFile(active, offset, emit)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// readLogFile calls fn for each complete line after offset and returns the
+// offset just past the last complete line
+func readLogFile(path string, offset int64, fn func(LogEntry) error) (int64, error) {
+	// #nosec G304 - path is built from the log directory and container ID
+	file, err := os.Open(path)
+	if err != nil {
+		return offset, err
+	}
+	defer file.Close()
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return offset, fmt.Errorf("failed to seek log file: %w", err)
+	}
+
+	reader := bufio.NewReader(file)
+	for {
+		data, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			// Leave partial lines for the next read
+			return offset, nil
+		}
+		if err != nil {
+			return offset, fmt.Errorf("failed to read log file: %w", err)
+		}
+		offset += int64(len(data))
+
+		var entry LogEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			continue
+		}
+		if err := fn(entry); err != nil {
+			return offset, err
+		}
+	}
+}
+
+// RemoveContainerLogs deletes all log files of a container
+func RemoveContainerLogs(config LogConfig, containerID string) error {
+	config = config.withDefaults()
+	matches, err := filepath.Glob(config.logPath(containerID) + "*")
+	if err != nil {
+		return err
+	}
+	for _, path := range matches {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
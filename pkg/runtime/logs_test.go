@@ -0,0 +1,200 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readLines reads all stored lines of a container
+func readLines(t *testing.T, config LogConfig, containerID string, opts LogOptions) []string {
+	t.Helper()
+	var lines []string
+	err := ReadContainerLogs(context.Background(), config, containerID, opts, func(entry LogEntry) error {
+		lines = append(lines, entry.Line)
+		return nil
+	})
+	require.NoError(t, err)
+	return lines
+}
+
+// TestContainerLogSplitsLines tests that output is stored per line and stream
+func TestContainerLogSplitsLines(t *testing.T) {
+	config := LogConfig{Dir: t.TempDir()}
+	l, err := OpenContainerLog(config, "c1")
+	require.NoError(t, err)
+
+	_, _ = l.Stdout().Write([]byte("hello\nwor"))
+	_, _ = l.Stdout().Write([]byte("ld\n"))
+	_, _ = l.Stderr().Write([]byte("oops\npartial"))
+	require.NoError(t, l.Close())
+
+	var entries []LogEntry
+	err = ReadContainerLogs(context.Background(), config, "c1", LogOptions{}, func(entry LogEntry) error {
+		entries = append(entries, entry)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+
+	assert.Equal(t, LogEntry{Stream: "stdout", Line: "hello"}, LogEntry{Stream: entries[0].Stream, Line: entries[0].Line})
+	assert.Equal(t, "world", entries[1].Line)
+	assert.Equal(t, "stderr", entries[2].Stream)
+	assert.Equal(t, "partial", entries[3].Line, "unterminated line is flushed on close")
+	assert.False(t, entries[0].Timestamp.IsZero())
+}
+
+// TestContainerLogRotation tests that files rotate at MaxSize and old files are dropped
+func TestContainerLogRotation(t *testing.T) {
+	config := LogConfig{Dir: t.TempDir(), MaxSize: 200, MaxFiles: 3}
+	l, err := OpenContainerLog(config, "c1")
+	require.NoError(t, err)
+
+	for i := 0; i < 20; i++ {
+		_, err := fmt.Fprintf(l.Stdout(), "line-%02d\n", i)
+		require.NoError(t, err)
+	}
+	require.NoError(t, l.Close())
+
+	for _, path := range []string{config.logPath("c1"), config.rotatedPath("c1", 1), config.rotatedPath("c1", 2)} {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, info.Size(), int64(200))
+	}
+	_, err = os.Stat(config.rotatedPath("c1", 3))
+	assert.True(t, os.IsNotExist(err), "only MaxFiles files are kept")
+
+	// The newest lines survive, in order
+	lines := readLines(t, config, "c1", LogOptions{})
+	require.NotEmpty(t, lines)
+	assert.Less(t, len(lines), 20)
+	assert.Equal(t, "line-19", lines[len(lines)-1])
+	for i := 1; i < len(lines); i++ {
+		assert.Less(t, lines[i-1], lines[i])
+	}
+
+	require.NoError(t, RemoveContainerLogs(config, "c1"))
+	err = ReadContainerLogs(context.Background(), config, "c1", LogOptions{}, func(LogEntry) error { return nil })
+	assert.Error(t, err)
+}
+
+// TestReadContainerLogsOptions tests tail and since filtering
+func TestReadContainerLogsOptions(t *testing.T) {
+	config := LogConfig{Dir: t.TempDir()}
+	l, err := OpenContainerLog(config, "c1")
+	require.NoError(t, err)
+
+	_, _ = l.Stdout().Write([]byte("a\nb\n"))
+	cutoff := time.Now().UTC()
+	time.Sleep(5 * time.Millisecond)
+	_, _ = l.Stdout().Write([]byte("c\nd\ne\n"))
+	require.NoError(t, l.Close())
+
+	tests := []struct {
+		name     string
+		opts     LogOptions
+		expected []string
+	}{
+		{name: "all", opts: LogOptions{}, expected: []string{"a", "b", "c", "d", "e"}},
+		{name: "tail", opts: LogOptions{Tail: 2}, expected: []string{"d", "e"}},
+		{name: "since", opts: LogOptions{Since: cutoff}, expected: []string{"c", "d", "e"}},
+		{name: "since and tail", opts: LogOptions{Since: cutoff, Tail: 1}, expected: []string{"e"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, readLines(t, config, "c1", tt.opts))
+		})
+	}
+}
+
+// TestReadContainerLogsFollow tests that new output is delivered across a rotation
+func TestReadContainerLogsFollow(t *testing.T) {
+	config := LogConfig{Dir: t.TempDir(), MaxSize: 120, MaxFiles: 2}
+	l, err := OpenContainerLog(config, "c1")
+	require.NoError(t, err)
+	defer l.Close()
+
+	_, _ = l.Stdout().Write([]byte("first\n"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	lines := make(chan string, 16)
+	go func() {
+		_ = ReadContainerLogs(ctx, config, "c1", LogOptions{Follow: true}, func(entry LogEntry) error {
+			lines <- entry.Line
+			return nil
+		})
+	}()
+
+	expect := func(want string) {
+		t.Helper()
+		select {
+		case got := <-lines:
+			assert.Equal(t, want, got)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for %q", want)
+		}
+	}
+
+	expect("first")
+	_, _ = l.Stdout().Write([]byte("second\n"))
+	expect("second")
+
+	// Each entry is ~80 bytes, so this write rotates the file
+	_, _ = l.Stdout().Write([]byte("third\n"))
+	expect("third")
+}
+
+// TestLoggerURI tests that the shim is given the logger binary and a
+// configuration the logger parses back
+func TestLoggerURI(t *testing.T) {
+	config := LogConfig{Dir: "/var/lib/warren/logs dir", MaxSize: 1024, MaxFiles: 5, Logger: "/usr/local/bin/warren"}
+
+	uri, err := LoggerURI(config)
+	require.NoError(t, err)
+	assert.Equal(t, "binary", uri.Scheme)
+	assert.Equal(t, "/usr/local/bin/warren", uri.Path)
+
+	// The shim runs the binary with each query key and its value as arguments
+	args := uri.Query()
+	require.Len(t, args, 1)
+	parsed, err := ParseLoggerConfig(args.Get(LoggerCommand))
+	require.NoError(t, err)
+	assert.Equal(t, LogConfig{Dir: config.Dir, MaxSize: 1024, MaxFiles: 5}, parsed)
+}
+
+// TestWriteLogs tests that the logger signals readiness once the file is
+// open and stores both streams until they close
+func TestWriteLogs(t *testing.T) {
+	config := LogConfig{Dir: t.TempDir()}
+	stdoutR, stdoutW, err := os.Pipe()
+	require.NoError(t, err)
+	stderrR, stderrW, err := os.Pipe()
+	require.NoError(t, err)
+
+	ready := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- writeLogs(config, "c1", stdoutR, stderrR, func() error {
+			close(ready)
+			return nil
+		})
+	}()
+
+	<-ready
+	_, _ = stdoutW.WriteString("hello\n")
+	_, _ = stderrW.WriteString("oops")
+	require.NoError(t, stdoutW.Close())
+	require.NoError(t, stderrW.Close())
+	require.NoError(t, <-done)
+
+	lines := readLines(t, config, "c1", LogOptions{})
+	assert.ElementsMatch(t, []string{"hello", "oops"}, lines)
+}
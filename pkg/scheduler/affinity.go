@@ -0,0 +1,132 @@
+package scheduler
+
+import (
+	"fmt"
+
+	"github.com/cuemby/warren/pkg/types"
+)
+
+// Reasons a node fails a service's required affinity terms
+const (
+	reasonAffinity     = "affinity rules not satisfied"
+	reasonAntiAffinity = "anti-affinity rules not satisfied"
+)
+
+// ValidateAffinity checks that every affinity term selects services
+func ValidateAffinity(affinity *types.Affinity) error {
+	if affinity == nil {
+		return nil
+	}
+	for _, terms := range [][]types.AffinityTerm{affinity.Required, affinity.Preferred} {
+		for _, term := range terms {
+			if term.Service == "" && len(term.Labels) == 0 {
+				return fmt.Errorf("invalid affinity term: set a service name or labels")
+			}
+			if term.Weight < 0 {
+				return fmt.Errorf("invalid affinity term: weight cannot be negative")
+			}
+		}
+	}
+	return nil
+}
+
+// termMatchesService reports whether a service is selected by a term
+func termMatchesService(term types.AffinityTerm, service *types.Service) bool {
+	if term.Service != "" && term.Service != service.Name {
+		return false
+	}
+	for key, value := range term.Labels {
+		if v, ok := service.Labels[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// matches reports whether any of the services in the list is selected by the term
+func matches(term types.AffinityTerm, services []*types.Service) bool {
+	for _, service := range services {
+		if termMatchesService(term, service) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkAffinity returns why a node fails a service's required affinity or
+// anti-affinity terms, or an empty string if it passes. A required
+// affinity term that selects the service itself is met anywhere while no
+// matching replica runs yet, so the first replica can be placed.
+func checkAffinity(cluster *ClusterState, service *types.Service, node *types.Node) string {
+	if !hasAffinity(service) {
+		return ""
+	}
+
+	onNode := cluster.ServicesOn(node.ID)
+	if service.Affinity != nil {
+		for _, term := range service.Affinity.Required {
+			if matches(term, onNode) {
+				continue
+			}
+			if termMatchesService(term, service) && !matches(term, cluster.runningServices()) {
+				continue
+			}
+			return reasonAffinity
+		}
+	}
+	if service.AntiAffinity != nil {
+		for _, term := range service.AntiAffinity.Required {
+			if matches(term, onNode) {
+				return reasonAntiAffinity
+			}
+		}
+	}
+	return ""
+}
+
+// affinityScore rates a node by the service's preferred terms, between -1
+// (matches every preferred anti-affinity term) and 1 (matches every
+// preferred affinity term)
+func affinityScore(cluster *ClusterState, service *types.Service, node *types.Node) float64 {
+	if !hasAffinity(service) {
+		return 0
+	}
+
+	onNode := cluster.ServicesOn(node.ID)
+	var score, total float64
+	if service.Affinity != nil {
+		for _, term := range service.Affinity.Preferred {
+			weight := termWeight(term)
+			total += weight
+			if matches(term, onNode) {
+				score += weight
+			}
+		}
+	}
+	if service.AntiAffinity != nil {
+		for _, term := range service.AntiAffinity.Preferred {
+			weight := termWeight(term)
+			total += weight
+			if matches(term, onNode) {
+				score -= weight
+			}
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return score / total
+}
+
+// termWeight returns a preferred term's weight, defaulting to 1
+func termWeight(term types.AffinityTerm) float64 {
+	if term.Weight <= 0 {
+		return 1
+	}
+	return float64(term.Weight)
+}
+
+// hasAffinity reports whether a service has any affinity or anti-affinity terms
+func hasAffinity(service *types.Service) bool {
+	return service.Affinity != nil || service.AntiAffinity != nil
+}
@@ -0,0 +1,137 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/cuemby/warren/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func affinityNodes() []*types.Node {
+	return []*types.Node{
+		{ID: "node-1", Role: types.NodeRoleWorker, Status: types.NodeStatusReady},
+		{ID: "node-2", Role: types.NodeRoleWorker, Status: types.NodeStatusReady},
+		{ID: "node-3", Role: types.NodeRoleWorker, Status: types.NodeStatusReady},
+	}
+}
+
+func runningOn(service *types.Service, nodeID string) *types.Container {
+	return &types.Container{
+		ServiceID:    service.ID,
+		ServiceName:  service.Name,
+		NodeID:       nodeID,
+		DesiredState: types.ContainerStateRunning,
+	}
+}
+
+func TestValidateAffinity(t *testing.T) {
+	assert.NoError(t, ValidateAffinity(nil))
+	assert.NoError(t, ValidateAffinity(&types.Affinity{Required: []types.AffinityTerm{{Service: "app"}}}))
+	assert.Error(t, ValidateAffinity(&types.Affinity{Required: []types.AffinityTerm{{}}}))
+	assert.Error(t, ValidateAffinity(&types.Affinity{Preferred: []types.AffinityTerm{{Service: "app", Weight: -1}}}))
+}
+
+func TestCheckAffinity(t *testing.T) {
+	app := &types.Service{ID: "app", Name: "app", Labels: map[string]string{"tier": "backend"}}
+	cache := &types.Service{
+		ID:       "cache",
+		Name:     "cache",
+		Affinity: &types.Affinity{Required: []types.AffinityTerm{{Labels: map[string]string{"tier": "backend"}}}},
+	}
+	web := &types.Service{
+		ID:           "web",
+		Name:         "web",
+		AntiAffinity: &types.Affinity{Required: []types.AffinityTerm{{Service: "web"}}},
+	}
+	nodes := affinityNodes()
+
+	cluster := NewClusterState([]*types.Service{app, cache, web}, []*types.Container{
+		runningOn(app, "node-1"),
+		runningOn(web, "node-2"),
+	})
+
+	assert.Empty(t, checkAffinity(cluster, cache, nodes[0]), "cache runs next to app")
+	assert.Equal(t, reasonAffinity, checkAffinity(cluster, cache, nodes[1]))
+
+	assert.Empty(t, checkAffinity(cluster, web, nodes[0]))
+	assert.Equal(t, reasonAntiAffinity, checkAffinity(cluster, web, nodes[1]), "web replicas stay apart")
+
+	// Placements within a cycle count
+	cluster.add(runningOn(web, "node-3"))
+	assert.Equal(t, reasonAntiAffinity, checkAffinity(cluster, web, nodes[2]))
+
+	// Services without terms pass every node
+	assert.Empty(t, checkAffinity(cluster, app, nodes[1]))
+}
+
+func TestCheckAffinity_SelfAffinity(t *testing.T) {
+	svc := &types.Service{
+		ID:       "svc",
+		Name:     "svc",
+		Affinity: &types.Affinity{Required: []types.AffinityTerm{{Service: "svc"}}},
+	}
+	nodes := affinityNodes()
+	cluster := NewClusterState([]*types.Service{svc}, nil)
+
+	assert.Empty(t, checkAffinity(cluster, svc, nodes[0]), "first replica goes anywhere")
+
+	cluster.add(runningOn(svc, "node-1"))
+	assert.Empty(t, checkAffinity(cluster, svc, nodes[0]))
+	assert.Equal(t, reasonAffinity, checkAffinity(cluster, svc, nodes[1]), "later replicas join the first")
+}
+
+func TestSelectNodeForService_AntiAffinity(t *testing.T) {
+	web := &types.Service{
+		ID:           "web",
+		Name:         "web",
+		AntiAffinity: &types.Affinity{Required: []types.AffinityTerm{{Service: "web"}}},
+	}
+	nodes := affinityNodes()
+	sched := NewScheduler(nil)
+	require.NoError(t, sched.Configure(Config{Strategy: StrategyBinpack}))
+	sched.cluster = NewClusterState([]*types.Service{web}, nil)
+
+	var containers []*types.Container
+	placed := make(map[string]bool)
+	for i := 0; i < 3; i++ {
+		decision := sched.selectNodeForService(web, nodes, containers)
+		require.NotNil(t, decision.node, decision.Reason)
+		assert.False(t, placed[decision.NodeID], "replica %d placed next to another", i)
+		placed[decision.NodeID] = true
+
+		container := runningOn(web, decision.NodeID)
+		containers = append(containers, container)
+		sched.recordPlacement(decision.node, container)
+	}
+
+	decision := sched.selectNodeForService(web, nodes, containers)
+	assert.Nil(t, decision.node)
+	assert.Equal(t, "no suitable node: anti-affinity rules not satisfied on 3 nodes", decision.Reason)
+}
+
+func TestSelectNode_PreferredAffinity(t *testing.T) {
+	app := &types.Service{ID: "app", Name: "app"}
+	db := &types.Service{ID: "db", Name: "db"}
+	svc := &types.Service{
+		ID:           "svc",
+		Name:         "svc",
+		Affinity:     &types.Affinity{Preferred: []types.AffinityTerm{{Service: "app", Weight: 2}}},
+		AntiAffinity: &types.Affinity{Preferred: []types.AffinityTerm{{Service: "db"}}},
+	}
+	nodes := affinityNodes()
+	cluster := NewClusterState([]*types.Service{app, db, svc}, []*types.Container{
+		runningOn(app, "node-2"),
+		runningOn(app, "node-3"),
+		runningOn(db, "node-3"),
+	})
+
+	assert.InDelta(t, 0, affinityScore(cluster, svc, nodes[0]), 0.001)
+	assert.InDelta(t, 2.0/3, affinityScore(cluster, svc, nodes[1]), 0.001)
+	assert.InDelta(t, 1.0/3, affinityScore(cluster, svc, nodes[2]), 0.001)
+
+	sched := NewScheduler(nil)
+	sched.cluster = cluster
+	decision := sched.selectNodeForService(svc, nodes, nil)
+	assert.Equal(t, "node-2", decision.NodeID)
+}
@@ -0,0 +1,113 @@
+package scheduler
+
+import (
+	"github.com/cuemby/warren/pkg/types"
+)
+
+// ClusterState indexes the containers placed on each node for one
+// scheduling cycle, so plugins can check what already runs there.
+// Placements made during the cycle are added as they happen.
+type ClusterState struct {
+	services map[string]*types.Service     // By ID
+	byNode   map[string][]*types.Container // Containers holding a node
+	running  []*types.Container            // Every placed replica meant to run
+}
+
+// NewClusterState indexes the containers that hold a node: placed
+// containers meant to run, and ones still running while they stop.
+// Containers that exited (e.g. a job's finished containers) hold nothing.
+func NewClusterState(services []*types.Service, containers []*types.Container) *ClusterState {
+	c := &ClusterState{
+		services: make(map[string]*types.Service, len(services)),
+		byNode:   make(map[string][]*types.Container),
+	}
+	for _, service := range services {
+		c.services[service.ID] = service
+	}
+	for _, container := range containers {
+		c.add(container)
+	}
+	return c
+}
+
+// add records a container placed on a node
+func (c *ClusterState) add(container *types.Container) {
+	if c == nil || container.NodeID == "" {
+		return
+	}
+	if container.DesiredState != types.ContainerStateRunning && container.ActualState != types.ContainerStateRunning {
+		return
+	}
+	if container.ActualState == types.ContainerStateComplete || container.ActualState == types.ContainerStateFailed {
+		return
+	}
+	c.byNode[container.NodeID] = append(c.byNode[container.NodeID], container)
+	if container.DesiredState == types.ContainerStateRunning {
+		c.running = append(c.running, container)
+	}
+}
+
+// without returns a copy of the state without the given containers, as it
+// will be once they have stopped
+func (c *ClusterState) without(removed map[string]bool) *ClusterState {
+	if c == nil {
+		return nil
+	}
+	w := &ClusterState{services: c.services, byNode: make(map[string][]*types.Container)}
+	for _, containers := range c.byNode {
+		for _, container := range containers {
+			if !removed[container.ID] {
+				w.add(container)
+			}
+		}
+	}
+	return w
+}
+
+// Containers returns the containers holding a node, including ones that
+// are still running while they stop
+func (c *ClusterState) Containers(nodeID string) []*types.Container {
+	if c == nil {
+		return nil
+	}
+	return c.byNode[nodeID]
+}
+
+// Service returns a service by ID, or nil if it is unknown
+func (c *ClusterState) Service(id string) *types.Service {
+	if c == nil {
+		return nil
+	}
+	return c.services[id]
+}
+
+// ServicesOn returns the service of each replica meant to run on a node
+func (c *ClusterState) ServicesOn(nodeID string) []*types.Service {
+	if c == nil {
+		return nil
+	}
+	var services []*types.Service
+	for _, container := range c.byNode[nodeID] {
+		if container.DesiredState != types.ContainerStateRunning {
+			continue
+		}
+		if service, ok := c.services[container.ServiceID]; ok {
+			services = append(services, service)
+		}
+	}
+	return services
+}
+
+// runningServices returns the service of every replica meant to run
+func (c *ClusterState) runningServices() []*types.Service {
+	if c == nil {
+		return nil
+	}
+	services := make([]*types.Service, 0, len(c.running))
+	for _, container := range c.running {
+		if service, ok := c.services[container.ServiceID]; ok {
+			services = append(services, service)
+		}
+	}
+	return services
+}
@@ -0,0 +1,123 @@
+package scheduler
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config selects the plugins the scheduler runs and how scores are
+// weighted. It is loaded from --scheduler-config and must match on every
+// manager, since whichever is leader schedules:
+//
+//	strategy: binpack
+//	disabled: [spread]
+//	enabled: [gpu]      # Custom plugins registered with RegisterPlugin
+//	weights:
+//	  resources: 3
+//	autoRebalance: true
+type Config struct {
+	Strategy      Strategy       `yaml:"strategy"`      // Scoring of the resources plugin
+	Disabled      []string       `yaml:"disabled"`      // Built-in plugins to turn off
+	Enabled       []string       `yaml:"enabled"`       // Registered plugins to turn on
+	Weights       map[string]int `yaml:"weights"`       // Score weight by plugin; 0 turns scoring off
+	AutoRebalance bool           `yaml:"autoRebalance"` // Move replicas to even out skew without being asked
+}
+
+// DefaultPlugins are the built-in plugins, in the order their filters run
+var DefaultPlugins = []string{
+	PluginLabels,
+	PluginTaints,
+	PluginVolumes,
+	PluginPorts,
+	PluginResources,
+	PluginAffinity,
+	PluginSpread,
+}
+
+// defaultWeights favor avoiding PreferNoSchedule taints, the strategy and
+// preferred affinity over spreading replicas; plugins not listed weigh 1
+var defaultWeights = map[string]int{
+	PluginTaints:    2,
+	PluginResources: 2,
+	PluginAffinity:  2,
+	PluginSpread:    1,
+}
+
+// DefaultConfig returns the configuration used without --scheduler-config
+func DefaultConfig() Config {
+	return Config{Strategy: StrategySpread}
+}
+
+// LoadConfig reads a scheduler configuration file
+func LoadConfig(path string) (Config, error) {
+	cfg := DefaultConfig()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read scheduler config: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse scheduler config: %w", err)
+	}
+
+	strategy, err := ParseStrategy(string(cfg.Strategy))
+	if err != nil {
+		return cfg, err
+	}
+	cfg.Strategy = strategy
+
+	return cfg, cfg.Validate()
+}
+
+// Validate checks that every plugin the configuration names is registered
+func (c Config) Validate() error {
+	for _, list := range [][]string{c.Disabled, c.Enabled} {
+		for _, name := range list {
+			if _, ok := registeredPlugin(name); !ok {
+				return fmt.Errorf("unknown scheduler plugin %q (registered: %s)", name, strings.Join(sortedPluginNames(), ", "))
+			}
+		}
+	}
+	for name, weight := range c.Weights {
+		if _, ok := registeredPlugin(name); !ok {
+			return fmt.Errorf("unknown scheduler plugin %q in weights", name)
+		}
+		if weight < 0 {
+			return fmt.Errorf("scheduler plugin %q: weight cannot be negative", name)
+		}
+	}
+	return nil
+}
+
+// pluginNames returns the enabled plugins: the defaults that are not
+// disabled, then the explicitly enabled ones
+func (c Config) pluginNames() []string {
+	skip := make(map[string]bool)
+	for _, name := range c.Disabled {
+		skip[name] = true
+	}
+
+	var names []string
+	for _, name := range append(append([]string{}, DefaultPlugins...), c.Enabled...) {
+		if skip[name] {
+			continue
+		}
+		skip[name] = true // Each plugin runs once
+		names = append(names, name)
+	}
+	return names
+}
+
+// weight returns a plugin's score weight
+func (c Config) weight(name string) int {
+	if weight, ok := c.Weights[name]; ok {
+		return weight
+	}
+	if weight, ok := defaultWeights[name]; ok {
+		return weight
+	}
+	return 1
+}
@@ -0,0 +1,151 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cuemby/warren/pkg/types"
+)
+
+// Node attributes placement constraints and preferences can refer to
+const (
+	attrNodeID       = "node.id"
+	attrNodeHostname = "node.hostname"
+	attrNodeRole     = "node.role"
+	attrNodeLabels   = "node.labels."
+)
+
+// reasonConstraints is reported for nodes that fail a placement constraint
+const reasonConstraints = "placement constraints not satisfied"
+
+// Constraint is a parsed placement constraint such as "node.labels.zone==eu-1"
+type Constraint struct {
+	Key   string // Node attribute, e.g. "node.labels.zone"
+	Equal bool   // true for ==, false for !=
+	Value string
+}
+
+// ParseConstraint parses a constraint of the form "<attribute>==<value>" or
+// "<attribute>!=<value>", where attribute is node.id, node.hostname,
+// node.role or node.labels.<key>
+func ParseConstraint(expr string) (Constraint, error) {
+	var c Constraint
+	var parts []string
+	if strings.Contains(expr, "==") {
+		parts = strings.SplitN(expr, "==", 2)
+		c.Equal = true
+	} else if strings.Contains(expr, "!=") {
+		parts = strings.SplitN(expr, "!=", 2)
+	} else {
+		return c, fmt.Errorf("invalid constraint %q: expected == or !=", expr)
+	}
+
+	c.Key = strings.TrimSpace(parts[0])
+	c.Value = strings.TrimSpace(parts[1])
+	if err := validateAttribute(c.Key); err != nil {
+		return c, fmt.Errorf("invalid constraint %q: %w", expr, err)
+	}
+	if c.Value == "" {
+		return c, fmt.Errorf("invalid constraint %q: missing value", expr)
+	}
+
+	return c, nil
+}
+
+// ParseConstraints parses a list of placement constraints
+func ParseConstraints(exprs []string) ([]Constraint, error) {
+	constraints := make([]Constraint, 0, len(exprs))
+	for _, expr := range exprs {
+		c, err := ParseConstraint(expr)
+		if err != nil {
+			return nil, err
+		}
+		constraints = append(constraints, c)
+	}
+	return constraints, nil
+}
+
+// ValidatePlacement checks that a service's constraints parse and that its
+// preferences spread across node labels
+func ValidatePlacement(placement *types.Placement) error {
+	if placement == nil {
+		return nil
+	}
+	if _, err := ParseConstraints(placement.Constraints); err != nil {
+		return err
+	}
+	for _, pref := range placement.Preferences {
+		if !strings.HasPrefix(pref.Spread, attrNodeLabels) || pref.Spread == attrNodeLabels {
+			return fmt.Errorf("invalid placement preference spread=%q: expected node.labels.<key>", pref.Spread)
+		}
+	}
+	return nil
+}
+
+// validateAttribute checks that a constraint refers to a known node attribute
+func validateAttribute(key string) error {
+	switch {
+	case key == attrNodeID, key == attrNodeHostname, key == attrNodeRole:
+		return nil
+	case strings.HasPrefix(key, attrNodeLabels) && len(key) > len(attrNodeLabels):
+		return nil
+	}
+	return fmt.Errorf("unknown attribute %q (use node.id, node.hostname, node.role or node.labels.<key>)", key)
+}
+
+// Matches reports whether a node satisfies the constraint. A hybrid node
+// has both the manager and worker roles. A node without the label never
+// equals a value, so it satisfies every != constraint on that label.
+func (c Constraint) Matches(node *types.Node) bool {
+	found := false
+	for _, value := range nodeAttribute(node, c.Key) {
+		if value == c.Value {
+			found = true
+			break
+		}
+	}
+	return found == c.Equal
+}
+
+// nodeAttribute returns the values of a node attribute
+func nodeAttribute(node *types.Node, key string) []string {
+	switch key {
+	case attrNodeID:
+		return []string{node.ID}
+	case attrNodeHostname:
+		return []string{node.Hostname}
+	case attrNodeRole:
+		if node.Role == types.NodeRoleHybrid {
+			return []string{string(types.NodeRoleManager), string(types.NodeRoleWorker), string(types.NodeRoleHybrid)}
+		}
+		return []string{string(node.Role)}
+	}
+
+	if value, ok := node.Labels[strings.TrimPrefix(key, attrNodeLabels)]; ok {
+		return []string{value}
+	}
+	return nil
+}
+
+// matchesConstraints reports whether a node satisfies every constraint
+func matchesConstraints(node *types.Node, constraints []Constraint) bool {
+	for _, c := range constraints {
+		if !c.Matches(node) {
+			return false
+		}
+	}
+	return true
+}
+
+// serviceConstraints parses a service's placement constraints
+func serviceConstraints(service *types.Service) ([]Constraint, error) {
+	if service.Placement == nil {
+		return nil, nil
+	}
+	return ParseConstraints(service.Placement.Constraints)
+}
+
+// labelKey returns the node label a preference spreads across
+func labelKey(spread string) string {
+	return strings.TrimPrefix(spread, attrNodeLabels)
+}
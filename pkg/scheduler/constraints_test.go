@@ -0,0 +1,119 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/cuemby/warren/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func labeledNode(id string, role types.NodeRole, labels map[string]string) *types.Node {
+	return &types.Node{ID: id, Hostname: id + ".local", Role: role, Status: types.NodeStatusReady, Labels: labels}
+}
+
+func TestParseConstraint(t *testing.T) {
+	c, err := ParseConstraint("node.labels.zone == eu-1")
+	require.NoError(t, err)
+	assert.Equal(t, Constraint{Key: "node.labels.zone", Equal: true, Value: "eu-1"}, c)
+
+	c, err = ParseConstraint("node.role!=manager")
+	require.NoError(t, err)
+	assert.Equal(t, Constraint{Key: "node.role", Equal: false, Value: "manager"}, c)
+
+	for _, expr := range []string{"node.labels.zone", "node.labels.==x", "node.os==linux", "node.hostname==", "zone=eu-1"} {
+		_, err := ParseConstraint(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestConstraintMatches(t *testing.T) {
+	worker := labeledNode("worker-1", types.NodeRoleWorker, map[string]string{"zone": "eu-1"})
+	hybrid := labeledNode("hybrid-1", types.NodeRoleHybrid, nil)
+
+	tests := []struct {
+		expr   string
+		node   *types.Node
+		expect bool
+	}{
+		{"node.labels.zone==eu-1", worker, true},
+		{"node.labels.zone==us-1", worker, false},
+		{"node.labels.zone!=us-1", worker, true},
+		{"node.labels.zone==eu-1", hybrid, false},
+		{"node.labels.zone!=eu-1", hybrid, true},
+		{"node.role==worker", worker, true},
+		{"node.role!=manager", worker, true},
+		{"node.role!=manager", hybrid, false},
+		{"node.role==worker", hybrid, true},
+		{"node.hostname==worker-1.local", worker, true},
+		{"node.id!=worker-1", worker, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr+"/"+tt.node.ID, func(t *testing.T) {
+			c, err := ParseConstraint(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expect, c.Matches(tt.node))
+		})
+	}
+}
+
+func TestValidatePlacement(t *testing.T) {
+	assert.NoError(t, ValidatePlacement(nil))
+	assert.NoError(t, ValidatePlacement(&types.Placement{
+		Constraints: []string{"node.labels.zone==eu-1"},
+		Preferences: []types.PlacementPreference{{Spread: "node.labels.rack"}},
+	}))
+	assert.Error(t, ValidatePlacement(&types.Placement{Constraints: []string{"zone=eu-1"}}))
+	assert.Error(t, ValidatePlacement(&types.Placement{Preferences: []types.PlacementPreference{{Spread: "node.role"}}}))
+}
+
+func TestSpreadPlugin_Preferences(t *testing.T) {
+	nodes := []*types.Node{
+		labeledNode("a1", types.NodeRoleWorker, map[string]string{"zone": "a"}),
+		labeledNode("a2", types.NodeRoleWorker, map[string]string{"zone": "a"}),
+		labeledNode("b1", types.NodeRoleWorker, map[string]string{"zone": "b"}),
+		labeledNode("none", types.NodeRoleWorker, nil),
+	}
+	service := &types.Service{Placement: &types.Placement{
+		Preferences: []types.PlacementPreference{{Spread: "node.labels.zone"}},
+	}}
+	state := &PlacementState{Service: service, Nodes: nodes, Containers: []*types.Container{
+		{NodeID: "a1", DesiredState: types.ContainerStateRunning},
+		{NodeID: "b1", DesiredState: types.ContainerStateRunning},
+		{NodeID: "none", DesiredState: types.ContainerStateRunning},
+		{NodeID: "a2", DesiredState: types.ContainerStateShutdown},
+	}}
+	spread := spreadPlugin{}
+
+	// Every zone (and the unlabeled group) runs one replica: the empty node wins
+	assert.Greater(t, spread.Score(state, nodes[1]), spread.Score(state, nodes[0]))
+	assert.Greater(t, spread.Score(state, nodes[1]), spread.Score(state, nodes[2]))
+
+	// Zone a runs two replicas: both other groups beat its empty node
+	state.Containers = append(state.Containers, &types.Container{NodeID: "a2", DesiredState: types.ContainerStateRunning})
+	assert.Greater(t, spread.Score(state, nodes[2]), spread.Score(state, nodes[1]))
+	assert.Equal(t, spread.Score(state, nodes[2]), spread.Score(state, nodes[3]))
+
+	sched := NewScheduler(nil)
+	decision := sched.selectNodeForService(service, nodes, state.Containers)
+	assert.Equal(t, "b1", decision.NodeID)
+}
+
+func TestSelectNodeForService_Constraints(t *testing.T) {
+	nodes := []*types.Node{
+		labeledNode("worker-1", types.NodeRoleWorker, map[string]string{"zone": "eu-1"}),
+		labeledNode("worker-2", types.NodeRoleWorker, map[string]string{"zone": "us-1"}),
+	}
+	sched := NewScheduler(nil)
+
+	service := &types.Service{Name: "web", Placement: &types.Placement{Constraints: []string{"node.labels.zone==us-1"}}}
+	decision := sched.selectNodeForService(service, nodes, nil)
+	assert.Equal(t, "worker-2", decision.NodeID)
+	assert.Empty(t, decision.Reason)
+
+	service.Placement.Constraints = []string{"node.labels.zone==ap-1"}
+	decision = sched.selectNodeForService(service, nodes, nil)
+	assert.Nil(t, decision.node)
+	assert.Equal(t, "no suitable node: placement constraints not satisfied on 2 nodes", decision.Reason)
+}
@@ -8,19 +8,26 @@ match their desired state and that containers are evenly distributed across the
 
 # Architecture
 
-The scheduler operates on a fixed 5-second interval, processing all services and
-their associated containers in each cycle:
+The scheduler is event driven. It subscribes to the manager's event broker and
+queues the services affected by each event: a service created or updated, a
+container that failed, completed, was deleted or was told to stop. Events that
+free capacity also requeue services with unplaced replicas, and node joins,
+departures, failures and label or taint changes queue a full pass. A full
+resync runs every 30 seconds to catch anything an event missed (dropped
+events). The scheduler runs on every manager but only the Raft leader
+schedules: followers drop their queued work, and a manager that becomes
+leader starts with a full pass:
 
 	┌────────────────────────────────────────────────────────────┐
 	│                    Scheduler Loop                          │
-	│                   (Every 5 seconds)                        │
+	│          (Queued services, full pass every 30s)            │
 	└────────────────┬───────────────────────────────────────────┘
 	                 │
 	                 ▼
 	┌────────────────────────────────────────────────────────────┐
 	│  1. List all services and worker nodes                    │
 	│  2. Filter nodes: Ready + Worker role only                │
-	│  3. For each service:                                      │
+	│  3. For each queued service:                               │
 	│     • List existing containers                             │
 	│     • Compare actual vs desired state                      │
 	│     • Create missing containers OR remove excess           │
@@ -44,12 +51,13 @@ their associated containers in each cycle:
 Scheduler: The main scheduling engine that orchestrates container placement.
 
 	scheduler := NewScheduler(manager)
-	scheduler.Start()  // Begins 5-second scheduling loop
+	scheduler.Start()  // Subscribes to events and starts the work queue
 	defer scheduler.Stop()
 
-The scheduler maintains no internal state beyond the manager reference - all
-cluster state is read from the manager on each cycle, making it stateless and
-resilient to restarts.
+The work queue deduplicates service IDs, so a burst of events for one service
+results in a single pass. Beyond the queue, the set of services with unplaced
+replicas and the placement decision log, the scheduler keeps no state - cluster
+state is read from the manager on each pass, so it is resilient to restarts.
 
 # Scheduling Algorithms
 
@@ -79,6 +87,19 @@ Global services run exactly one container per worker node:
 The scheduler automatically creates containers when new nodes join and removes containers
 when nodes are decommissioned.
 
+## Job Scheduling
+
+Job services run containers to completion instead of keeping them up:
+
+	Service: import (mode=job, completions=10, parallelism=3)
+	Succeeded: 8, running: 1
+	Action: Create 1 container (min(3, 10-8) - 1)
+
+Containers that exit successfully are not replaced, failed ones are until
+the backoff limit, and a finished job gets no new containers. Cronjob
+services are never scheduled themselves; the job controller (pkg/jobs)
+creates a job for each run.
+
 ## Volume Affinity
 
 When a service uses volumes, containers must be scheduled on the node where the
@@ -92,6 +113,168 @@ This ensures data locality for stateful workloads. If a volume doesn't exist
 yet, the scheduler selects a node using standard load balancing, and the volume
 is created on that node.
 
+## Resource Reservations
+
+A container reserving CPU or memory (ResourceRequirements.CPUReservation and
+MemoryReservation) is only placed on a node whose unreserved capacity fits it.
+The manager's FSM keeps each node's CPUAllocated and MemoryAllocated equal to
+the reservations of the containers placed on it, and the scheduler also counts
+the placements it makes within a cycle. If no node fits, the container is
+created unplaced (no NodeID) with the reason in its Error, for example:
+
+	no suitable node: insufficient memory on 2 nodes, insufficient CPU on 1 node
+
+Unplaced containers are retried on every cycle and show as pending in
+"warren service inspect".
+
+## Placement Constraints and Preferences
+
+A service's Placement restricts and guides where its replicas run.
+Constraints are hard requirements a node must meet:
+
+	node.labels.zone==eu-1   // label value
+	node.role!=manager       // hybrid nodes are both manager and worker
+	node.hostname==web-1
+	node.id==<node ID>
+
+Nodes failing a constraint are reported as "placement constraints not
+satisfied" when a replica cannot be placed. Replicas on nodes that stop
+satisfying the constraints (e.g. after "warren node update --label-rm") are
+shut down and replaced, and global services only run on matching nodes.
+
+Preferences are soft and scored by the spread plugin: nodes whose label
+value runs fewer replicas of the service score higher, each preference
+taking precedence over later ones and over the per-node replica count.
+Nodes without the label form a group of their own.
+
+## Affinity and Anti-Affinity
+
+Affinity terms place a service relative to other services' replicas rather
+than node attributes. A term selects services by name, by labels, or both,
+and a node matches it if it runs a replica of a selected service:
+
+	// Keep web replicas on different nodes (HA)
+	AntiAffinity: &types.Affinity{Required: []types.AffinityTerm{{Service: "web"}}}
+
+	// Run cache replicas next to the app, preferring nodes running a db
+	Affinity: &types.Affinity{
+		Required:  []types.AffinityTerm{{Service: "app"}},
+		Preferred: []types.AffinityTerm{{Labels: map[string]string{"tier": "db"}, Weight: 2}},
+	}
+
+Required terms are filters: a node must match every affinity term and no
+anti-affinity term. A required term that selects the service itself is met
+anywhere until a matching replica exists, so the first replica can start.
+Preferred terms are scored: a node scores the weight of the affinity terms
+it matches minus that of the anti-affinity terms, normalized to [-1, 1] and
+added to the strategy score.
+
+## Taints and Tolerations
+
+Node taints (KEY[=VALUE]:EFFECT, set with "warren node update --taint-add")
+keep services off a node unless they list a matching toleration:
+
+	node-role=manager:NoSchedule   // dedicated managers: system services only
+	spot:PreferNoSchedule          // used only when nothing else fits
+	maintenance:NoExecute          // also evicts running containers
+
+A toleration matches a taint's key and value (Equal) or key only (Exists),
+and one effect or all of them; an Exists toleration without a key matches
+every taint. NoExecute eviction is done by the reconciler, which shuts the
+container down so the scheduler replaces it on another node.
+
+## Scheduling Strategies
+
+The resources plugin scores the nodes that fit a container by the strategy
+(the --scheduler-strategy flag, or "strategy" in the scheduler config):
+
+  - spread (default): no resource score, so the spread plugin decides
+  - binpack: most allocated node, keeping whole nodes free
+  - least-allocated: most unreserved capacity
+
+## Plugin Framework
+
+Node selection runs a Framework of plugins. Filter plugins rule nodes out
+and say why; score plugins rank the nodes left. Each plugin's scores are
+normalized to [0, 1] across the candidates, multiplied by its weight and
+summed, and the first node with the highest total wins.
+
+Built-in plugins, in filter order:
+
+  - labels: placement constraints (filter)
+  - taints: NoSchedule and NoExecute taints (filter), PreferNoSchedule (score, weight 2)
+  - volumes: node holding an existing local volume (filter)
+  - ports: host-mode published port free on the node (filter)
+  - resources: reservable capacity (filter), strategy (score, weight 2)
+  - affinity: required terms (filter), preferred terms (score, weight 2)
+  - spread: placement preferences, then fewest replicas (score, weight 1)
+
+A YAML file passed with --scheduler-config disables plugins, enables custom
+ones and changes weights; it must match on every manager:
+
+	strategy: binpack
+	disabled: [spread]
+	enabled: [gpu]
+	weights:
+	  affinity: 5
+
+Custom plugins implement FilterPlugin, ScorePlugin or both, and register a
+factory from an init function of a package linked into the binary:
+
+	func init() {
+		scheduler.RegisterPlugin("gpu", func(cfg scheduler.Config, h scheduler.Handle) (scheduler.Plugin, error) {
+			return gpuPlugin{}, nil
+		})
+	}
+
+The leader keeps the last decisions for each service, including every
+node's filter result and scores, and serves them to
+"warren service ps NAME --why".
+
+## Priority and Preemption
+
+Services carry a Priority (default 0). Each pass schedules the most
+important services first, so they get capacity freed since the last pass
+before anything else.
+
+When a container of a service with a positive priority fits no node, the
+scheduler looks for lower-priority containers to stop. On each node it
+stops candidates, least important and then most recently created first,
+until the node passes every filter (resources, host ports, anti-affinity);
+the node needing the fewest, least important victims wins. Containers
+already stopping count as gone, so a pass never preempts twice for the
+same room, and the room made is claimed for the pending container within
+the pass, so that the next container of the service that preempts stops
+victims of its own.
+
+Victims get a normal shutdown: their worker stops them within their
+StopTimeout, and their Error and PreemptedBy name the preempting service.
+The FSM publishes a task.preempted event for each. The preempting
+container stays pending ("preempting 2 container(s) on worker-1") until the
+victims have stopped and a later pass places it. Global services preempt
+per node, on each node that cannot run their container.
+
+## Rebalancing
+
+Scheduling never moves a running replica, so after a node fails and
+returns, the replicas replaced on the other nodes stay there. Rebalancing
+is opt-in: "warren service rebalance NAME" marks one service, and
+autoRebalance in the scheduler config (or --auto-rebalance) covers all
+replicated services.
+
+A marked service is rebalanced at the end of its scheduling passes. The
+skew is the most minus the fewest replicas on the nodes that run one or
+pass every filter. Each move takes a running replica from the most loaded
+node, asks the framework where its replacement would go, and is only made
+if that narrows the gap between the two nodes. The replacement is created
+before the old replica is told to stop.
+
+Moves are batched: at most UpdateConfig.Parallelism (default 1) per pass,
+and only while fewer replicas than the service's DisruptionBudget (default
+1) are unavailable, i.e. starting or on a node that is not ready. The
+task.started event of a replacement queues the service for its next batch;
+a manual rebalance ends once no move narrows the skew.
+
 # Usage Examples
 
 ## Basic Scheduler Setup
@@ -108,7 +291,7 @@ is created on that node.
 	sched := scheduler.NewScheduler(mgr)
 	sched.Start()
 
-	// Scheduler runs automatically every 5 seconds
+	// Scheduler reacts to cluster events and resyncs every 30 seconds
 	// ...
 
 	// Gracefully stop scheduler
@@ -232,17 +415,17 @@ This clear separation prevents coupling and makes each component testable.
 
 ## Time Complexity
 
-Per scheduling cycle (N services, M nodes, C containers):
+Per scheduling pass (N services, M nodes, C containers):
 
   - List services: O(N)
   - List nodes: O(M)
-  - List containers per service: O(C)
+  - List containers once and index by service: O(C)
   - Node selection: O(M * C) worst case (counting containers per node)
   - Overall: O(N * (C + M))
 
 For a typical cluster (100 services, 10 nodes, 500 containers):
-  - ~0.5-1 second per scheduling cycle
-  - Well within 5-second interval
+  - ~0.5-1 second per full pass
+  - Event-driven passes only touch the queued services
 
 ## Memory Usage
 
@@ -256,12 +439,11 @@ Minimal memory footprint:
 
 Time from service creation to container running:
 
-  - Best case: 5 seconds (next scheduler cycle)
-  - Worst case: 10 seconds (just missed previous cycle)
-  - Average: 7.5 seconds
+  - Typical: milliseconds after the service.created event is applied
+  - Worst case: 30 seconds (event dropped, next resync)
 
-For faster scheduling, reduce the ticker interval in the run() method, but be
-aware of increased CPU usage and API load on the manager.
+The resync interval is the resyncInterval constant; lowering it increases CPU
+usage and API load on the manager.
 
 # Troubleshooting
 
@@ -306,6 +488,10 @@ If containers are not evenly distributed:
   - Scheduler only uses "Ready" worker nodes
   - Down nodes are excluded from scheduling
 
+3. Replicas replaced while a node was down stay where they are:
+  - Run: warren service rebalance NAME
+  - Or start managers with --auto-rebalance
+
 ## Volume Affinity Not Working
 
 If containers aren't being pinned to volume nodes:
@@ -320,7 +506,18 @@ If containers aren't being pinned to volume nodes:
 
 # Monitoring Metrics
 
-The scheduler doesn't currently export Prometheus metrics, but you can monitor:
+The scheduler exports these Prometheus metrics:
+
+  - warren_scheduler_queue_depth - Services waiting in the work queue (a
+    pending full pass counts as one)
+  - warren_scheduler_passes_total{scope} - Scheduling passes, by scope "all"
+    (full pass) or "services" (queued services only)
+  - warren_scheduler_rebalance_moves_total - Replicas moved to even out a
+    service's spread
+  - warren_scheduler_preemptions_total - Containers stopped to make room for
+    a higher-priority service
+
+Beyond these, you can monitor:
 
 ## Log-based Metrics
 
@@ -336,10 +533,9 @@ The scheduler doesn't currently export Prometheus metrics, but you can monitor:
 
 # Best Practices
 
-1. Scheduler Interval Tuning
-  - Default 5s balances responsiveness vs. overhead
-  - For large clusters (>100 nodes), consider 10s
-  - For dev/test, can reduce to 1s for faster feedback
+1. Scheduler Resync Tuning
+  - Placement follows events; the 30s resync is only a safety net
+  - A queue depth that stays above zero means passes can't keep up
 
 2. Service Replica Planning
   - Set replicas <= number of worker nodes (for even distribution)
@@ -0,0 +1,305 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cuemby/warren/pkg/types"
+)
+
+// Plugin is a named piece of placement logic. A plugin implements
+// FilterPlugin, ScorePlugin or both.
+type Plugin interface {
+	Name() string
+}
+
+// FilterPlugin rules out nodes a container cannot run on
+type FilterPlugin interface {
+	Plugin
+
+	// Filter returns why the node cannot run the container, or an empty
+	// string if it can
+	Filter(state *PlacementState, node *types.Node) string
+}
+
+// ScorePlugin ranks the nodes that passed every filter
+type ScorePlugin interface {
+	Plugin
+
+	// Score rates a node; higher is better. Scores are normalized across
+	// the candidate nodes before they are weighted and summed, so only
+	// their order and relative distance matter.
+	Score(state *PlacementState, node *types.Node) float64
+}
+
+// Handle gives plugins read access to cluster state beyond the placement
+type Handle interface {
+	GetVolumeByName(name string) (*types.Volume, error)
+}
+
+// PluginFactory creates a plugin from the scheduler configuration
+type PluginFactory func(cfg Config, handle Handle) (Plugin, error)
+
+// PlacementState is what plugins see when placing one container
+type PlacementState struct {
+	Service    *types.Service
+	Nodes      []*types.Node      // Every schedulable node
+	Containers []*types.Container // The service's containers, including placements made this cycle
+	Cluster    *ClusterState      // Containers on every node
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]PluginFactory)
+)
+
+// RegisterPlugin makes a plugin available to scheduler configurations
+// under the given name. Custom plugins register from an init function and
+// are enabled with the "enabled" list of the scheduler config.
+func RegisterPlugin(name string, factory PluginFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = factory
+}
+
+// registeredPlugin returns the factory registered under a name
+func registeredPlugin(name string) (PluginFactory, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	factory, ok := registry[name]
+	return factory, ok
+}
+
+// weightedScorer is a score plugin and the weight of its normalized score
+type weightedScorer struct {
+	plugin ScorePlugin
+	weight float64
+}
+
+// Framework runs the enabled filter and score plugins to place containers
+type Framework struct {
+	filters []FilterPlugin
+	scorers []weightedScorer
+}
+
+// NewFramework builds the plugin pipeline for a configuration. Filters run
+// in the order plugins are enabled.
+func NewFramework(cfg Config, handle Handle) (*Framework, error) {
+	f := &Framework{}
+	for _, name := range cfg.pluginNames() {
+		factory, ok := registeredPlugin(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown scheduler plugin %q", name)
+		}
+		plugin, err := factory(cfg, handle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create scheduler plugin %q: %w", name, err)
+		}
+
+		filter, isFilter := plugin.(FilterPlugin)
+		scorer, isScorer := plugin.(ScorePlugin)
+		if !isFilter && !isScorer {
+			return nil, fmt.Errorf("scheduler plugin %q implements neither Filter nor Score", name)
+		}
+		if isFilter {
+			f.filters = append(f.filters, filter)
+		}
+		if weight := cfg.weight(name); isScorer && weight > 0 {
+			f.scorers = append(f.scorers, weightedScorer{plugin: scorer, weight: float64(weight)})
+		}
+	}
+	return f, nil
+}
+
+// Filter runs the filters against one node, stopping at the first that
+// rules it out. It returns that plugin's name and reason, or empty strings.
+func (f *Framework) Filter(state *PlacementState, node *types.Node) (string, string) {
+	for _, filter := range f.filters {
+		if reason := filter.Filter(state, node); reason != "" {
+			return filter.Name(), reason
+		}
+	}
+	return "", ""
+}
+
+// Evaluate filters and scores every node for one placement. The decision
+// names the best node, or says why none fits.
+func (f *Framework) Evaluate(state *PlacementState) *Decision {
+	decision := &Decision{
+		ServiceID: state.Service.ID,
+		Time:      time.Now(),
+	}
+
+	reasons := make(map[string]int)
+	var candidates []*types.Node
+	var candidateEvals []*NodeEvaluation
+	for _, node := range state.Nodes {
+		eval := &NodeEvaluation{NodeID: node.ID}
+		decision.Nodes = append(decision.Nodes, eval)
+
+		eval.FilteredBy, eval.Reason = f.Filter(state, node)
+		if eval.Reason != "" {
+			reasons[eval.Reason]++
+			continue
+		}
+		candidates = append(candidates, node)
+		candidateEvals = append(candidateEvals, eval)
+	}
+
+	if len(candidates) == 0 {
+		decision.Reason = unschedulableReason(reasons)
+		return decision
+	}
+
+	// Normalize each plugin's scores to [0, 1] across the candidates, then
+	// weight and sum them
+	for _, eval := range candidateEvals {
+		eval.Scores = make(map[string]float64, len(f.scorers))
+	}
+	raw := make([]float64, len(candidates))
+	for _, scorer := range f.scorers {
+		for i, node := range candidates {
+			raw[i] = scorer.plugin.Score(state, node)
+		}
+		normalized := normalize(raw)
+		for i, eval := range candidateEvals {
+			eval.Scores[scorer.plugin.Name()] = normalized[i]
+			eval.Score += scorer.weight * normalized[i]
+		}
+	}
+
+	// First best node wins, so equal scores keep the node order
+	best := 0
+	for i, eval := range candidateEvals {
+		if eval.Score > candidateEvals[best].Score {
+			best = i
+		}
+	}
+
+	decision.node = candidates[best]
+	decision.NodeID = candidates[best].ID
+	return decision
+}
+
+// normalize scales scores linearly to [0, 1]; equal scores all become 0
+func normalize(scores []float64) []float64 {
+	normalized := make([]float64, len(scores))
+	if len(scores) == 0 {
+		return normalized
+	}
+
+	lo, hi := scores[0], scores[0]
+	for _, s := range scores {
+		if s < lo {
+			lo = s
+		}
+		if s > hi {
+			hi = s
+		}
+	}
+	if hi == lo {
+		return normalized
+	}
+	for i, s := range scores {
+		normalized[i] = (s - lo) / (hi - lo)
+	}
+	return normalized
+}
+
+// Decision explains where one container was placed, or why it was not
+type Decision struct {
+	ContainerID string
+	ServiceID   string
+	NodeID      string // Empty if no node fits
+	Reason      string // Why no node fits
+	Time        time.Time
+	Nodes       []*NodeEvaluation
+
+	node *types.Node
+}
+
+// NodeEvaluation is the outcome of running the plugins against one node
+type NodeEvaluation struct {
+	NodeID     string
+	FilteredBy string             // Filter plugin that ruled the node out
+	Reason     string             // Why it was ruled out
+	Scores     map[string]float64 // Normalized score per score plugin
+	Score      float64            // Weighted sum of the scores
+}
+
+// PluginNames returns the names of the enabled filter and score plugins
+func (f *Framework) PluginNames() (filters, scorers []string) {
+	for _, filter := range f.filters {
+		filters = append(filters, filter.Name())
+	}
+	for _, scorer := range f.scorers {
+		scorers = append(scorers, scorer.plugin.Name())
+	}
+	return filters, scorers
+}
+
+// decisionLog keeps the latest placement decisions of each service
+type decisionLog struct {
+	mu        sync.RWMutex
+	byService map[string][]*Decision
+}
+
+// maxDecisionsPerService bounds the decisions kept for each service
+const maxDecisionsPerService = 50
+
+func newDecisionLog() *decisionLog {
+	return &decisionLog{byService: make(map[string][]*Decision)}
+}
+
+// record adds a decision, dropping the service's oldest beyond the bound
+func (l *decisionLog) record(decision *Decision) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	decisions := append(l.byService[decision.ServiceID], decision)
+	if len(decisions) > maxDecisionsPerService {
+		decisions = decisions[len(decisions)-maxDecisionsPerService:]
+	}
+	l.byService[decision.ServiceID] = decisions
+}
+
+// forService returns a service's decisions, latest last
+func (l *decisionLog) forService(serviceID string) []*Decision {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	decisions := make([]*Decision, len(l.byService[serviceID]))
+	copy(decisions, l.byService[serviceID])
+	return decisions
+}
+
+// prune forgets services that no longer exist
+func (l *decisionLog) prune(services []*types.Service) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	exists := make(map[string]bool, len(services))
+	for _, service := range services {
+		exists[service.ID] = true
+	}
+	for id := range l.byService {
+		if !exists[id] {
+			delete(l.byService, id)
+		}
+	}
+}
+
+// sortedPluginNames returns the registered plugin names in order
+func sortedPluginNames() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
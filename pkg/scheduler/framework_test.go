@@ -0,0 +1,129 @@
+package scheduler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cuemby/warren/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gpuPlugin is a custom plugin that only admits nodes labeled gpu=true
+type gpuPlugin struct{}
+
+func (gpuPlugin) Name() string { return "gpu" }
+
+func (gpuPlugin) Filter(_ *PlacementState, node *types.Node) string {
+	if node.Labels["gpu"] != "true" {
+		return "no gpu"
+	}
+	return ""
+}
+
+func init() {
+	RegisterPlugin("gpu", func(Config, Handle) (Plugin, error) { return gpuPlugin{}, nil })
+}
+
+func TestNewFramework(t *testing.T) {
+	f, err := NewFramework(DefaultConfig(), nil)
+	require.NoError(t, err)
+	filters, scorers := f.PluginNames()
+	assert.Equal(t, []string{PluginLabels, PluginTaints, PluginVolumes, PluginPorts, PluginResources, PluginAffinity}, filters)
+	assert.Equal(t, []string{PluginTaints, PluginResources, PluginAffinity, PluginSpread}, scorers)
+
+	f, err = NewFramework(Config{Disabled: []string{PluginSpread}, Enabled: []string{"gpu"}, Weights: map[string]int{PluginAffinity: 0, PluginTaints: 0}}, nil)
+	require.NoError(t, err)
+	filters, scorers = f.PluginNames()
+	assert.Contains(t, filters, "gpu")
+	assert.Equal(t, []string{PluginResources}, scorers)
+
+	_, err = NewFramework(Config{Enabled: []string{"missing"}}, nil)
+	assert.Error(t, err)
+}
+
+func TestFramework_Evaluate(t *testing.T) {
+	nodes := []*types.Node{
+		labeledNode("worker-1", types.NodeRoleWorker, nil),
+		labeledNode("worker-2", types.NodeRoleWorker, map[string]string{"gpu": "true"}),
+		labeledNode("worker-3", types.NodeRoleWorker, map[string]string{"gpu": "true"}),
+	}
+	f, err := NewFramework(Config{Enabled: []string{"gpu"}}, nil)
+	require.NoError(t, err)
+
+	state := &PlacementState{
+		Service: &types.Service{ID: "svc"},
+		Nodes:   nodes,
+		Containers: []*types.Container{
+			{NodeID: "worker-2", DesiredState: types.ContainerStateRunning},
+		},
+	}
+	decision := f.Evaluate(state)
+	assert.Equal(t, "worker-3", decision.NodeID)
+	require.Len(t, decision.Nodes, 3)
+	assert.Equal(t, "gpu", decision.Nodes[0].FilteredBy)
+	assert.Equal(t, "no gpu", decision.Nodes[0].Reason)
+	assert.Equal(t, 1.0, decision.Nodes[2].Scores[PluginSpread])
+	assert.Greater(t, decision.Nodes[2].Score, decision.Nodes[1].Score)
+
+	state.Nodes = nodes[:1]
+	decision = f.Evaluate(state)
+	assert.Empty(t, decision.NodeID)
+	assert.Equal(t, "no suitable node: no gpu on 1 node", decision.Reason)
+}
+
+func TestPortsPlugin(t *testing.T) {
+	port := &types.PortMapping{ContainerPort: 80, HostPort: 8080, PublishMode: types.PublishModeHost}
+	web := &types.Service{ID: "web", Ports: []*types.PortMapping{port}}
+	node := labeledNode("worker-1", types.NodeRoleWorker, nil)
+	ports := portsPlugin{}
+
+	cluster := NewClusterState(nil, nil)
+	state := &PlacementState{Service: web, Cluster: cluster}
+	assert.Empty(t, ports.Filter(state, node))
+
+	cluster.add(&types.Container{ServiceID: "web", NodeID: "worker-1", DesiredState: types.ContainerStateRunning, Ports: []*types.PortMapping{port}})
+	assert.Equal(t, "host port 8080/tcp in use", ports.Filter(state, node))
+
+	// Ingress ports are not bound on the node
+	ingress := *port
+	ingress.PublishMode = types.PublishModeIngress
+	state.Service = &types.Service{ID: "api", Ports: []*types.PortMapping{&ingress}}
+	assert.Empty(t, ports.Filter(state, node))
+}
+
+func TestDecisionLog(t *testing.T) {
+	log := newDecisionLog()
+	for i := 0; i < maxDecisionsPerService+5; i++ {
+		log.record(&Decision{ServiceID: "svc", ContainerID: string(rune('a' + i%26))})
+	}
+	log.record(&Decision{ServiceID: "gone"})
+
+	assert.Len(t, log.forService("svc"), maxDecisionsPerService)
+
+	log.prune([]*types.Service{{ID: "svc"}})
+	assert.Empty(t, log.forService("gone"))
+	assert.NotEmpty(t, log.forService("svc"))
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "scheduler.yaml")
+
+	require.NoError(t, os.WriteFile(path, []byte("strategy: binpack\ndisabled: [spread]\nweights:\n  resources: 3\n"), 0o600))
+	cfg, err := LoadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, StrategyBinpack, cfg.Strategy)
+	assert.Equal(t, []string{PluginSpread}, cfg.Disabled)
+	assert.Equal(t, 3, cfg.weight(PluginResources))
+	assert.Equal(t, 2, cfg.weight(PluginAffinity))
+
+	require.NoError(t, os.WriteFile(path, []byte("disabled: [nope]\n"), 0o600))
+	_, err = LoadConfig(path)
+	assert.Error(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte("strategy: random\n"), 0o600))
+	_, err = LoadConfig(path)
+	assert.Error(t, err)
+}
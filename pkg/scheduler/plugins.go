@@ -0,0 +1,205 @@
+package scheduler
+
+import (
+	"fmt"
+
+	"github.com/cuemby/warren/pkg/types"
+)
+
+// Names of the built-in plugins
+const (
+	PluginLabels    = "labels"    // Placement constraints on node attributes and labels
+	PluginTaints    = "taints"    // Node taints the service does not tolerate
+	PluginVolumes   = "volumes"   // Volume affinity: run next to existing local volumes
+	PluginPorts     = "ports"     // Host-mode published ports not already in use
+	PluginResources = "resources" // Reservable capacity; scores by strategy
+	PluginAffinity  = "affinity"  // Service affinity and anti-affinity
+	PluginSpread    = "spread"    // Placement preferences, then fewest replicas per node
+)
+
+func init() {
+	RegisterPlugin(PluginLabels, func(Config, Handle) (Plugin, error) { return labelsPlugin{}, nil })
+	RegisterPlugin(PluginTaints, func(Config, Handle) (Plugin, error) { return taintsPlugin{}, nil })
+	RegisterPlugin(PluginVolumes, func(_ Config, h Handle) (Plugin, error) { return volumesPlugin{handle: h}, nil })
+	RegisterPlugin(PluginPorts, func(Config, Handle) (Plugin, error) { return portsPlugin{}, nil })
+	RegisterPlugin(PluginResources, func(cfg Config, _ Handle) (Plugin, error) {
+		return resourcesPlugin{strategy: cfg.Strategy}, nil
+	})
+	RegisterPlugin(PluginAffinity, func(Config, Handle) (Plugin, error) { return affinityPlugin{}, nil })
+	RegisterPlugin(PluginSpread, func(Config, Handle) (Plugin, error) { return spreadPlugin{}, nil })
+}
+
+// labelsPlugin filters nodes by the service's placement constraints
+type labelsPlugin struct{}
+
+func (labelsPlugin) Name() string { return PluginLabels }
+
+func (labelsPlugin) Filter(state *PlacementState, node *types.Node) string {
+	constraints, err := serviceConstraints(state.Service)
+	if err != nil {
+		return err.Error()
+	}
+	if !matchesConstraints(node, constraints) {
+		return reasonConstraints
+	}
+	return ""
+}
+
+// taintsPlugin rules out nodes with a NoSchedule or NoExecute taint the
+// service does not tolerate, and scores nodes lower for each untolerated
+// PreferNoSchedule taint
+type taintsPlugin struct{}
+
+func (taintsPlugin) Name() string { return PluginTaints }
+
+func (taintsPlugin) Filter(state *PlacementState, node *types.Node) string {
+	taint, untolerated := UntoleratedTaint(state.Service, node, types.TaintEffectNoSchedule, types.TaintEffectNoExecute)
+	if untolerated {
+		return fmt.Sprintf("untolerated taint %s", FormatTaint(taint))
+	}
+	return ""
+}
+
+func (taintsPlugin) Score(state *PlacementState, node *types.Node) float64 {
+	return -float64(untoleratedCount(state.Service, node, types.TaintEffectPreferNoSchedule))
+}
+
+// volumesPlugin keeps replicas on the node holding an existing local volume.
+// Global services run everywhere, each with its own volume, so they skip it.
+type volumesPlugin struct {
+	handle Handle
+}
+
+func (volumesPlugin) Name() string { return PluginVolumes }
+
+func (p volumesPlugin) Filter(state *PlacementState, node *types.Node) string {
+	if state.Service.Mode == types.ServiceModeGlobal || p.handle == nil {
+		return ""
+	}
+	for _, mount := range state.Service.Volumes {
+		volume, err := p.handle.GetVolumeByName(mount.Source)
+		if err != nil {
+			continue // Volume doesn't exist yet, will be created on the selected node
+		}
+		if volume.NodeID != "" && volume.NodeID != node.ID {
+			return fmt.Sprintf("volume %s is on another node", volume.Name)
+		}
+	}
+	return ""
+}
+
+// portsPlugin rules out nodes where a host-mode port the service publishes
+// is already bound by another container, including another replica of the
+// service. A global service's own container is being replaced, so it is
+// not counted.
+type portsPlugin struct{}
+
+func (portsPlugin) Name() string { return PluginPorts }
+
+func (portsPlugin) Filter(state *PlacementState, node *types.Node) string {
+	for _, port := range state.Service.Ports {
+		if !isHostPort(port) {
+			continue
+		}
+		for _, container := range state.Cluster.Containers(node.ID) {
+			if state.Service.Mode == types.ServiceModeGlobal && container.ServiceID == state.Service.ID {
+				continue
+			}
+			for _, used := range container.Ports {
+				if isHostPort(used) && used.HostPort == port.HostPort && portProtocol(used) == portProtocol(port) {
+					return fmt.Sprintf("host port %s in use", portKey(port))
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// resourcesPlugin filters nodes by unreserved capacity and scores them by
+// the scheduling strategy
+type resourcesPlugin struct {
+	strategy Strategy
+}
+
+func (resourcesPlugin) Name() string { return PluginResources }
+
+func (resourcesPlugin) Filter(state *PlacementState, node *types.Node) string {
+	return fitsResources(node, state.Service.Resources)
+}
+
+func (p resourcesPlugin) Score(state *PlacementState, node *types.Node) float64 {
+	switch p.strategy {
+	case StrategyBinpack:
+		return allocatedFraction(node)
+	case StrategyLeastAllocated:
+		return 1 - allocatedFraction(node)
+	default:
+		// Spread: every node scores the same, so the spread plugin decides
+		return 0
+	}
+}
+
+// affinityPlugin applies required affinity terms as a filter and preferred
+// ones as a score
+type affinityPlugin struct{}
+
+func (affinityPlugin) Name() string { return PluginAffinity }
+
+func (affinityPlugin) Filter(state *PlacementState, node *types.Node) string {
+	return checkAffinity(state.Cluster, state.Service, node)
+}
+
+func (affinityPlugin) Score(state *PlacementState, node *types.Node) float64 {
+	return affinityScore(state.Cluster, state.Service, node)
+}
+
+// spreadPlugin spreads replicas across the values of each placement
+// preference label in turn, then across nodes. Earlier preferences take
+// precedence over later ones and over the per-node spread.
+type spreadPlugin struct{}
+
+func (spreadPlugin) Name() string { return PluginSpread }
+
+func (spreadPlugin) Score(state *PlacementState, node *types.Node) float64 {
+	nodeByID := make(map[string]*types.Node, len(state.Nodes))
+	for _, n := range state.Nodes {
+		nodeByID[n.ID] = n
+	}
+
+	var labels []string
+	if state.Service.Placement != nil {
+		for _, pref := range state.Service.Placement.Preferences {
+			labels = append(labels, labelKey(pref.Spread))
+		}
+	}
+
+	// Replicas in the node's group for each preference, then on the node
+	counts := make([]float64, len(labels)+1)
+	replicas := 0
+	for _, container := range state.Containers {
+		if container.DesiredState != types.ContainerStateRunning || container.NodeID == "" {
+			continue
+		}
+		replicas++
+		other, ok := nodeByID[container.NodeID]
+		if !ok {
+			continue
+		}
+		for i, label := range labels {
+			if other.Labels[label] == node.Labels[label] {
+				counts[i]++
+			}
+		}
+		if other.ID == node.ID {
+			counts[len(labels)]++
+		}
+	}
+
+	// Fewer replicas is better; each level outweighs all later ones
+	base := float64(replicas + 1)
+	var score float64
+	for _, count := range counts {
+		score = score*base - count
+	}
+	return score
+}
@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	"fmt"
+
+	"github.com/cuemby/warren/pkg/types"
+)
+
+// ValidatePorts checks that the ports a new service publishes cannot clash
+// with its own or with those of existing services. Ingress ports are bound
+// on every node, and so are a global service's host ports, so either clashes
+// with any other service publishing the same port. Host ports of two
+// replicated services only clash on a shared node, which the ports plugin
+// avoids at placement time. The green and canary services of a deployment
+// share their original's ports; the deployer creates them directly, without
+// this check.
+func ValidatePorts(service *types.Service, existing []*types.Service) error {
+	seen := make(map[string]bool)
+	for _, port := range service.Ports {
+		if port.HostPort <= 0 {
+			continue
+		}
+		key := portKey(port)
+		if seen[key] {
+			return fmt.Errorf("port %s is published more than once", key)
+		}
+		seen[key] = true
+	}
+
+	for _, other := range existing {
+		for _, port := range service.Ports {
+			if port.HostPort <= 0 {
+				continue
+			}
+			for _, used := range other.Ports {
+				if used.HostPort != port.HostPort || portProtocol(used) != portProtocol(port) {
+					continue
+				}
+				if bindsEverywhere(service, port) || bindsEverywhere(other, used) {
+					return fmt.Errorf("port %s is already published by service %s", portKey(port), other.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// bindsEverywhere reports whether a published port is bound on every node
+func bindsEverywhere(service *types.Service, port *types.PortMapping) bool {
+	return port.PublishMode == types.PublishModeIngress || service.Mode == types.ServiceModeGlobal
+}
+
+// isHostPort reports whether a port is bound on the node running the container
+func isHostPort(port *types.PortMapping) bool {
+	return port.HostPort > 0 && port.PublishMode != types.PublishModeIngress
+}
+
+// portProtocol returns a port's protocol, defaulting to tcp
+func portProtocol(port *types.PortMapping) string {
+	if port.Protocol == "" {
+		return "tcp"
+	}
+	return port.Protocol
+}
+
+// portKey formats a published port as "<port>/<protocol>"
+func portKey(port *types.PortMapping) string {
+	return fmt.Sprintf("%d/%s", port.HostPort, portProtocol(port))
+}
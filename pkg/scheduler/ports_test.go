@@ -0,0 +1,106 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/cuemby/warren/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func publish(hostPort int, mode types.PublishMode) *types.PortMapping {
+	return &types.PortMapping{ContainerPort: 80, HostPort: hostPort, Protocol: "tcp", PublishMode: mode}
+}
+
+func TestValidatePorts(t *testing.T) {
+	existing := []*types.Service{
+		{Name: "web", Mode: types.ServiceModeReplicated, Ports: []*types.PortMapping{publish(8080, types.PublishModeHost)}},
+		{Name: "api", Mode: types.ServiceModeReplicated, Ports: []*types.PortMapping{publish(443, types.PublishModeIngress)}},
+		{Name: "agent", Mode: types.ServiceModeGlobal, Ports: []*types.PortMapping{publish(9100, types.PublishModeHost)}},
+	}
+
+	tests := []struct {
+		name    string
+		service *types.Service
+		wantErr string
+	}{
+		{
+			name:    "free port",
+			service: &types.Service{Name: "new", Ports: []*types.PortMapping{publish(8081, types.PublishModeHost)}},
+		},
+		{
+			name:    "host port of a replicated service is left to the scheduler",
+			service: &types.Service{Name: "new", Ports: []*types.PortMapping{publish(8080, types.PublishModeHost)}},
+		},
+		{
+			name:    "other protocol",
+			service: &types.Service{Name: "new", Ports: []*types.PortMapping{{ContainerPort: 53, HostPort: 443, Protocol: "udp", PublishMode: types.PublishModeIngress}}},
+		},
+		{
+			name:    "ingress port taken by ingress",
+			service: &types.Service{Name: "new", Ports: []*types.PortMapping{publish(443, types.PublishModeIngress)}},
+			wantErr: "port 443/tcp is already published by service api",
+		},
+		{
+			name:    "host port taken by ingress",
+			service: &types.Service{Name: "new", Ports: []*types.PortMapping{publish(443, types.PublishModeHost)}},
+			wantErr: "port 443/tcp is already published by service api",
+		},
+		{
+			name:    "host port taken by a global service",
+			service: &types.Service{Name: "new", Ports: []*types.PortMapping{publish(9100, types.PublishModeHost)}},
+			wantErr: "port 9100/tcp is already published by service agent",
+		},
+		{
+			name:    "global service on a replicated service's host port",
+			service: &types.Service{Name: "new", Mode: types.ServiceModeGlobal, Ports: []*types.PortMapping{publish(8080, types.PublishModeHost)}},
+			wantErr: "port 8080/tcp is already published by service web",
+		},
+		{
+			name:    "same service name",
+			service: &types.Service{Name: "api", Ports: []*types.PortMapping{publish(443, types.PublishModeIngress)}},
+			wantErr: "port 443/tcp is already published by service api",
+		},
+		{
+			name:    "duplicate within the service",
+			service: &types.Service{Name: "new", Ports: []*types.PortMapping{publish(8081, types.PublishModeHost), publish(8081, types.PublishModeIngress)}},
+			wantErr: "port 8081/tcp is published more than once",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePorts(tt.service, existing)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, tt.wantErr, err.Error())
+		})
+	}
+}
+
+func TestSelectNodeForService_HostPortConflict(t *testing.T) {
+	web := &types.Service{ID: "web", Name: "web", Ports: []*types.PortMapping{publish(8080, types.PublishModeHost)}}
+	nodes := affinityNodes()[:2]
+
+	sched := NewScheduler(nil)
+	sched.cluster = NewClusterState([]*types.Service{web}, nil)
+
+	var containers []*types.Container
+	for i := 0; i < 2; i++ {
+		decision := sched.selectNodeForService(web, nodes, containers)
+		require.NotNil(t, decision.node, decision.Reason)
+
+		container := runningOn(web, decision.NodeID)
+		container.Ports = web.Ports
+		containers = append(containers, container)
+		sched.recordPlacement(decision.node, container)
+	}
+	assert.NotEqual(t, containers[0].NodeID, containers[1].NodeID, "replicas share a host port")
+
+	decision := sched.selectNodeForService(web, nodes, containers)
+	assert.Nil(t, decision.node)
+	assert.Equal(t, "no suitable node: host port 8080/tcp in use on 2 nodes", decision.Reason)
+}
@@ -0,0 +1,164 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cuemby/warren/pkg/metrics"
+	"github.com/cuemby/warren/pkg/types"
+)
+
+// preemption is the cheapest way to make room for a container on a node
+type preemption struct {
+	node    *types.Node
+	victims []*types.Container // Empty if containers already stopping make room
+}
+
+// preemptFor makes room for a service's container that fits no node, by
+// stopping lower-priority containers on the node where the fewest (and
+// least important) have to go. It returns nil if no node can be freed;
+// otherwise the container is placed by a later pass, once the victims have
+// stopped. Until then the room is claimed for the container in this
+// cycle's state, so that the service's next container makes room of its
+// own instead of counting on the same victims.
+func (s *Scheduler) preemptFor(service *types.Service, container *types.Container, nodes []*types.Node, containers []*types.Container) *preemption {
+	if service.Priority <= 0 {
+		return nil
+	}
+
+	state := &PlacementState{Service: service, Nodes: nodes, Containers: containers, Cluster: s.cluster}
+	var best *preemption
+	for _, node := range nodes {
+		victims, ok := s.preemptionVictims(state, node)
+		if !ok {
+			continue
+		}
+		if best == nil || cheaperPreemption(victims, best.victims, s.cluster) {
+			best = &preemption{node: node, victims: victims}
+		}
+	}
+	if best == nil {
+		return nil
+	}
+
+	s.evict(service, best.victims)
+	claim := *container
+	claim.NodeID = best.node.ID
+	s.recordPlacement(best.node, &claim)
+	return best
+}
+
+// preemptionVictims returns the lower-priority containers to stop so the
+// node passes every filter for the placement. Containers already stopping
+// count as gone. It returns false if even stopping every lower-priority
+// container would not make room.
+func (s *Scheduler) preemptionVictims(state *PlacementState, node *types.Node) ([]*types.Container, bool) {
+	removed := make(map[string]bool)
+	var candidates []*types.Container
+	for _, container := range s.cluster.Containers(node.ID) {
+		if container.DesiredState != types.ContainerStateRunning {
+			removed[container.ID] = true
+			continue
+		}
+		if other := s.cluster.Service(container.ServiceID); other != nil && other.Priority < state.Service.Priority {
+			candidates = append(candidates, container)
+		}
+	}
+
+	// Least important first, then the most recently created
+	sort.SliceStable(candidates, func(i, j int) bool {
+		pi := s.cluster.Service(candidates[i].ServiceID).Priority
+		pj := s.cluster.Service(candidates[j].ServiceID).Priority
+		if pi != pj {
+			return pi < pj
+		}
+		return candidates[i].CreatedAt.After(candidates[j].CreatedAt)
+	})
+
+	if s.fitsWithout(state, node, removed) {
+		return nil, true
+	}
+	for i, container := range candidates {
+		removed[container.ID] = true
+		if s.fitsWithout(state, node, removed) {
+			return candidates[:i+1], true
+		}
+	}
+	return nil, false
+}
+
+// fitsWithout reports whether the node passes every filter once the given
+// containers have stopped and released their reservations
+func (s *Scheduler) fitsWithout(state *PlacementState, node *types.Node, removed map[string]bool) bool {
+	freed := *node
+	if node.Resources != nil {
+		res := *node.Resources
+		freed.Resources = &res
+		for _, container := range s.cluster.Containers(node.ID) {
+			if removed[container.ID] && container.Resources != nil {
+				res.CPUAllocated -= container.Resources.CPUReservation
+				res.MemoryAllocated -= container.Resources.MemoryReservation
+			}
+		}
+	}
+
+	simulated := *state
+	simulated.Cluster = s.cluster.without(removed)
+	_, reason := s.framework.Filter(&simulated, &freed)
+	return reason == ""
+}
+
+// cheaperPreemption reports whether stopping a disrupts less than stopping
+// b: fewer containers, then a lower highest priority among them
+func cheaperPreemption(a, b []*types.Container, cluster *ClusterState) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return highestPriority(a, cluster) < highestPriority(b, cluster)
+}
+
+// highestPriority returns the highest priority among the containers' services
+func highestPriority(containers []*types.Container, cluster *ClusterState) int {
+	highest := 0
+	for i, container := range containers {
+		priority := 0
+		if service := cluster.Service(container.ServiceID); service != nil {
+			priority = service.Priority
+		}
+		if i == 0 || priority > highest {
+			highest = priority
+		}
+	}
+	return highest
+}
+
+// evict stops containers to make room for a higher-priority service. They
+// stop like any other shutdown, with their StopTimeout, and the FSM
+// records a task.preempted event for each.
+func (s *Scheduler) evict(preemptor *types.Service, victims []*types.Container) {
+	for _, container := range victims {
+		container.DesiredState = types.ContainerStateShutdown
+		container.PreemptedBy = preemptor.Name
+		container.Error = fmt.Sprintf("preempted by service %s (priority %d)", preemptor.Name, preemptor.Priority)
+		if err := s.manager.UpdateContainer(container); err != nil {
+			s.logger.Error().Err(err).Str("container_id", container.ID).Msg("Failed to preempt container")
+			continue
+		}
+
+		metrics.SchedulerPreemptions.Inc()
+		s.logger.Info().
+			Str("container_id", container.ID).
+			Str("service_name", container.ServiceName).
+			Str("node_id", container.NodeID).
+			Str("preempted_by", preemptor.Name).
+			Msg("Preempted container")
+	}
+}
+
+// preemptionReason describes a container waiting for room being made
+func preemptionReason(p *preemption) string {
+	if len(p.victims) == 0 {
+		return fmt.Sprintf("waiting for containers stopping on %s", p.node.ID)
+	}
+	return fmt.Sprintf("preempting %d container(s) on %s", len(p.victims), p.node.ID)
+}
@@ -0,0 +1,152 @@
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cuemby/warren/pkg/manager"
+	"github.com/cuemby/warren/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reservingContainer is a running container reserving CPU on a node
+func reservingContainer(id, serviceID, nodeID string, cpu float64, age time.Duration) *types.Container {
+	return &types.Container{
+		ID:           id,
+		ServiceID:    serviceID,
+		NodeID:       nodeID,
+		DesiredState: types.ContainerStateRunning,
+		ActualState:  types.ContainerStateRunning,
+		Resources:    &types.ResourceRequirements{CPUReservation: cpu},
+		CreatedAt:    time.Now().Add(-age),
+	}
+}
+
+func TestPreemptionVictims(t *testing.T) {
+	batch := &types.Service{ID: "batch", Priority: -1}
+	web := &types.Service{ID: "web"}
+	logs := &types.Service{ID: "logs", Priority: 100, Resources: &types.ResourceRequirements{CPUReservation: 1}}
+
+	// Two full cores on a two-core node
+	node := resourceNode("worker-1", 2, 0, 2, 0)
+	containers := []*types.Container{
+		reservingContainer("web-1", "web", "worker-1", 0.5, time.Hour),
+		reservingContainer("batch-old", "batch", "worker-1", 0.5, time.Hour),
+		reservingContainer("batch-new", "batch", "worker-1", 0.5, time.Minute),
+		reservingContainer("logs-1", "logs", "worker-1", 0.5, time.Hour),
+	}
+
+	sched := NewScheduler(nil)
+	sched.cluster = NewClusterState([]*types.Service{batch, web, logs}, containers)
+	state := &PlacementState{Service: logs, Nodes: []*types.Node{node}, Cluster: sched.cluster}
+
+	// The least important go first, newest first, and only as many as needed
+	victims, ok := sched.preemptionVictims(state, node)
+	require.True(t, ok)
+	require.Len(t, victims, 2)
+	assert.Equal(t, "batch-new", victims[0].ID)
+	assert.Equal(t, "batch-old", victims[1].ID)
+
+	// Containers already stopping make room without more victims
+	containers[1].DesiredState = types.ContainerStateShutdown
+	containers[2].DesiredState = types.ContainerStateShutdown
+	victims, ok = sched.preemptionVictims(state, node)
+	assert.True(t, ok)
+	assert.Empty(t, victims)
+
+	// Equal or higher priorities are never preempted
+	state.Service = &types.Service{ID: "api", Resources: &types.ResourceRequirements{CPUReservation: 1.5}}
+	_, ok = sched.preemptionVictims(state, node)
+	assert.False(t, ok)
+}
+
+func TestPreemptionVictims_Ports(t *testing.T) {
+	port := &types.PortMapping{ContainerPort: 80, HostPort: 8080, PublishMode: types.PublishModeHost}
+	low := &types.Service{ID: "low", Ports: []*types.PortMapping{port}}
+	high := &types.Service{ID: "high", Priority: 10, Ports: []*types.PortMapping{port}}
+	node := labeledNode("worker-1", types.NodeRoleWorker, nil)
+
+	holder := reservingContainer("low-1", "low", "worker-1", 0, time.Hour)
+	holder.Resources = nil
+	holder.Ports = []*types.PortMapping{port}
+
+	sched := NewScheduler(nil)
+	sched.cluster = NewClusterState([]*types.Service{low, high}, []*types.Container{holder})
+	state := &PlacementState{Service: high, Nodes: []*types.Node{node}, Cluster: sched.cluster}
+
+	victims, ok := sched.preemptionVictims(state, node)
+	require.True(t, ok)
+	assert.Equal(t, []*types.Container{holder}, victims)
+}
+
+func TestPreemptFor_PriorityRequired(t *testing.T) {
+	sched := NewScheduler(nil)
+	sched.cluster = NewClusterState(nil, nil)
+	node := resourceNode("worker-1", 1, 0, 1, 0)
+	service := &types.Service{ID: "web", Resources: &types.ResourceRequirements{CPUReservation: 1}}
+
+	assert.Nil(t, sched.preemptFor(service, newContainer(service, ""), []*types.Node{node}, nil))
+}
+
+func TestCheaperPreemption(t *testing.T) {
+	cluster := NewClusterState([]*types.Service{{ID: "a", Priority: 1}, {ID: "b", Priority: 5}}, nil)
+	a := &types.Container{ServiceID: "a"}
+	b := &types.Container{ServiceID: "b"}
+
+	assert.True(t, cheaperPreemption(nil, []*types.Container{a}, cluster))
+	assert.True(t, cheaperPreemption([]*types.Container{a}, []*types.Container{b}, cluster))
+	assert.False(t, cheaperPreemption([]*types.Container{a, a}, []*types.Container{b}, cluster))
+}
+
+// TestPreemptionClaimsRoom tests that each container of a service makes
+// room of its own when several preempt in the same cycle
+func TestPreemptionClaimsRoom(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	mgr, err := manager.NewManager(&manager.Config{
+		NodeID:   "test-manager",
+		BindAddr: "127.0.0.1:0",
+		DataDir:  t.TempDir(),
+	})
+	require.NoError(t, err)
+	defer func() { _ = mgr.Shutdown() }()
+	require.NoError(t, mgr.Bootstrap())
+	require.Eventually(t, mgr.IsLeader, 5*time.Second, 100*time.Millisecond)
+
+	node := resourceNode("worker-1", 2, 0, 0, 0)
+	node.LastHeartbeat = time.Now()
+	require.NoError(t, mgr.CreateNode(node))
+
+	// Batch fills the node's two cores
+	batch := &types.Service{ID: "batch", Name: "batch", Priority: -1, Replicas: 4,
+		Resources: &types.ResourceRequirements{CPUReservation: 0.5}}
+	require.NoError(t, mgr.CreateService(batch))
+	for i := 0; i < 4; i++ {
+		container := reservingContainer(fmt.Sprintf("batch-%d", i), "batch", "worker-1", 0.5, time.Duration(i)*time.Minute)
+		container.ServiceName = "batch"
+		require.NoError(t, mgr.CreateContainer(container))
+	}
+
+	// Each of two replicas needs a full core
+	logs := &types.Service{ID: "logs", Name: "logs", Priority: 100, Replicas: 2,
+		Resources: &types.ResourceRequirements{CPUReservation: 1}}
+	require.NoError(t, mgr.CreateService(logs))
+
+	sched := NewScheduler(mgr)
+	sched.queue.Add("logs")
+	ids, _ := sched.queue.Drain()
+	require.NoError(t, sched.scheduleServices(ids))
+
+	containers, err := mgr.ListContainersByService("batch")
+	require.NoError(t, err)
+	preempted := 0
+	for _, container := range containers {
+		if container.DesiredState == types.ContainerStateShutdown {
+			preempted++
+		}
+	}
+	assert.Equal(t, 4, preempted, "both replicas need two batch containers stopped")
+}
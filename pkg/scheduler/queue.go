@@ -0,0 +1,93 @@
+package scheduler
+
+import (
+	"sync"
+
+	"github.com/cuemby/warren/pkg/metrics"
+)
+
+// workQueue holds the services waiting for a scheduling pass. Each service
+// is queued at most once however many events name it, and a full pass
+// replaces every queued service.
+type workQueue struct {
+	mu     sync.Mutex
+	ids    []string
+	queued map[string]bool
+	all    bool
+	ready  chan struct{} // Signaled when the queue becomes non-empty
+}
+
+func newWorkQueue() *workQueue {
+	return &workQueue{
+		queued: make(map[string]bool),
+		ready:  make(chan struct{}, 1),
+	}
+}
+
+// Add queues a service
+func (q *workQueue) Add(serviceID string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.all || q.queued[serviceID] {
+		return
+	}
+	q.queued[serviceID] = true
+	q.ids = append(q.ids, serviceID)
+	q.updated()
+}
+
+// AddAll queues a pass over every service
+func (q *workQueue) AddAll() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.all = true
+	q.ids = nil
+	q.queued = make(map[string]bool)
+	q.updated()
+}
+
+// Drain empties the queue, returning the queued services in order, or all
+// if a full pass was queued
+func (q *workQueue) Drain() (ids []string, all bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	ids, all = q.ids, q.all
+	q.ids = nil
+	q.all = false
+	q.queued = make(map[string]bool)
+	metrics.SchedulerQueueDepth.Set(0)
+	return ids, all
+}
+
+// Len returns the number of queued services; a full pass counts as one
+func (q *workQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.depth()
+}
+
+// Ready is signaled when work is queued
+func (q *workQueue) Ready() <-chan struct{} {
+	return q.ready
+}
+
+// updated records the queue depth and signals waiting workers (caller must
+// hold q.mu)
+func (q *workQueue) updated() {
+	metrics.SchedulerQueueDepth.Set(float64(q.depth()))
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// depth returns the queue length (caller must hold q.mu)
+func (q *workQueue) depth() int {
+	if q.all {
+		return 1
+	}
+	return len(q.ids)
+}
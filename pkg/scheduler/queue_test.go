@@ -0,0 +1,131 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/cuemby/warren/pkg/events"
+	"github.com/cuemby/warren/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkQueue(t *testing.T) {
+	q := newWorkQueue()
+	assert.Equal(t, 0, q.Len())
+
+	q.Add("web")
+	q.Add("api")
+	q.Add("web")
+	assert.Equal(t, 2, q.Len(), "a service is queued once")
+
+	select {
+	case <-q.Ready():
+	default:
+		t.Fatal("queue should be ready")
+	}
+
+	ids, all := q.Drain()
+	assert.Equal(t, []string{"web", "api"}, ids)
+	assert.False(t, all)
+	assert.Equal(t, 0, q.Len())
+
+	// A full pass covers services queued before and after it
+	q.Add("web")
+	q.AddAll()
+	q.Add("api")
+	assert.Equal(t, 1, q.Len())
+	ids, all = q.Drain()
+	assert.Empty(t, ids)
+	assert.True(t, all)
+}
+
+func TestHandleEvent(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    *events.Event
+		expected []string
+		all      bool
+	}{
+		{
+			name:     "service created",
+			event:    &events.Event{Type: events.EventServiceCreated, Metadata: map[string]string{"service_id": "web"}},
+			expected: []string{"web"},
+		},
+		{
+			name:     "container failed",
+			event:    &events.Event{Type: events.EventTaskFailed, Metadata: map[string]string{"service_id": "web"}},
+			expected: []string{"web", "pending"},
+		},
+		{
+			name: "container told to stop",
+			event: &events.Event{Type: events.EventTaskUpdated, Metadata: map[string]string{
+				"service_id": "web", "desired_state": string(types.ContainerStateShutdown),
+			}},
+			expected: []string{"web", "pending"},
+		},
+		{
+			name: "container started",
+			event: &events.Event{Type: events.EventTaskUpdated, Metadata: map[string]string{
+				"service_id": "web", "desired_state": string(types.ContainerStateRunning),
+			}},
+		},
+		{
+			name:  "node down",
+			event: &events.Event{Type: events.EventNodeDown, Metadata: map[string]string{"node_id": "node-1"}},
+			all:   true,
+		},
+		{
+			name:  "node labels changed",
+			event: &events.Event{Type: events.EventNodeUpdated, Metadata: map[string]string{"node_id": "node-1"}},
+			all:   true,
+		},
+		{
+			name:  "secret created",
+			event: &events.Event{Type: events.EventSecretCreated},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sched := NewScheduler(nil)
+			sched.setUnplaced("pending", true)
+
+			sched.handleEvent(tt.event)
+			ids, all := sched.queue.Drain()
+			assert.Equal(t, tt.expected, ids)
+			assert.Equal(t, tt.all, all)
+		})
+	}
+}
+
+// TestCheckLeader tests that only the leader schedules, starting with a
+// full pass when it becomes leader
+func TestCheckLeader(t *testing.T) {
+	sched := NewScheduler(nil)
+	leader := false
+	sched.isLeader = func() bool { return leader }
+
+	assert.False(t, sched.checkLeader())
+	assert.Equal(t, 0, sched.queue.Len())
+
+	leader = true
+	assert.True(t, sched.checkLeader())
+	_, all := sched.queue.Drain()
+	assert.True(t, all, "a new leader schedules every service")
+
+	assert.True(t, sched.checkLeader())
+	assert.Equal(t, 0, sched.queue.Len(), "only on becoming leader")
+
+	leader = false
+	assert.False(t, sched.checkLeader())
+	leader = true
+	assert.True(t, sched.checkLeader())
+	assert.Equal(t, 1, sched.queue.Len())
+}
+
+func TestSelectServices(t *testing.T) {
+	services := []*types.Service{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	assert.Len(t, selectServices(services, nil), 3)
+
+	selected := selectServices(services, []string{"c", "gone", "a"})
+	assert.Equal(t, []*types.Service{services[2], services[0]}, selected)
+}
@@ -0,0 +1,276 @@
+package scheduler
+
+import (
+	"fmt"
+
+	"github.com/cuemby/warren/pkg/jobs"
+	"github.com/cuemby/warren/pkg/metrics"
+	"github.com/cuemby/warren/pkg/types"
+)
+
+// RebalanceStatus describes how unevenly a service's replicas are spread
+type RebalanceStatus struct {
+	Skew  int // Most minus fewest replicas on the nodes the service may use
+	Moves int // Replicas the rebalancer would move to even them out
+}
+
+// rebalanceMove stops a replica and places its replacement elsewhere
+type rebalanceMove struct {
+	container *types.Container // Replica to stop
+	decision  *Decision        // Where its replacement goes
+}
+
+// Rebalance measures a replicated service's skew and, if moving replicas
+// would reduce it, marks the service so scheduling passes move them a
+// batch at a time until it is balanced
+func (s *Scheduler) Rebalance(serviceID string) (*RebalanceStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	service, err := s.manager.GetService(serviceID)
+	if err != nil {
+		return nil, fmt.Errorf("service not found: %w", err)
+	}
+	if service.Mode == types.ServiceModeGlobal {
+		return nil, fmt.Errorf("global services run one replica per node and are never rebalanced")
+	}
+	if jobs.IsJob(service) {
+		return nil, fmt.Errorf("jobs run to completion and are never rebalanced")
+	}
+
+	services, err := s.manager.ListServices()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+	nodes, err := s.manager.ListNodes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	containers, err := s.manager.ListContainers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+	s.cluster = NewClusterState(services, containers)
+
+	var own []*types.Container
+	for _, container := range containers {
+		if container.ServiceID == service.ID {
+			own = append(own, container)
+		}
+	}
+
+	moves, skew := s.planRebalance(service, filterSchedulableNodes(nodes), own, service.Replicas)
+	if len(moves) > 0 {
+		s.setRebalancing(service.ID, true)
+		s.queue.Add(service.ID)
+	}
+	return &RebalanceStatus{Skew: skew, Moves: len(moves)}, nil
+}
+
+// wantsRebalance reports whether passes over a service should rebalance it
+func (s *Scheduler) wantsRebalance(serviceID string) bool {
+	s.rebalanceMu.Lock()
+	defer s.rebalanceMu.Unlock()
+	return s.autoRebalance || s.rebalancing[serviceID]
+}
+
+// setRebalancing records whether a rebalance was requested for a service
+func (s *Scheduler) setRebalancing(serviceID string, rebalancing bool) {
+	s.rebalanceMu.Lock()
+	defer s.rebalanceMu.Unlock()
+	if rebalancing {
+		s.rebalancing[serviceID] = true
+	} else {
+		delete(s.rebalancing, serviceID)
+	}
+}
+
+// rebalanceService moves the next batch of a replicated service's
+// replicas off its most loaded nodes. It waits until the service has all
+// its replicas, moves at most UpdateConfig.Parallelism at a time, and
+// never leaves more replicas unavailable than the disruption budget.
+func (s *Scheduler) rebalanceService(service *types.Service, nodes []*types.Node, containers []*types.Container) {
+	if !s.wantsRebalance(service.ID) {
+		return
+	}
+
+	ready := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		ready[node.ID] = true
+	}
+
+	active, unavailable := 0, 0
+	for _, container := range containers {
+		if container.DesiredState != types.ContainerStateRunning {
+			continue
+		}
+		active++
+		if !ready[container.NodeID] || container.ActualState != types.ContainerStateRunning {
+			unavailable++
+		}
+	}
+	if active != service.Replicas {
+		return // Replicas are still being added or removed
+	}
+
+	limit := disruptionBudget(service) - unavailable
+	if parallelism := rebalanceParallelism(service); parallelism < limit {
+		limit = parallelism
+	}
+	if limit <= 0 {
+		return // Earlier moves are still starting
+	}
+
+	moves, skew := s.planRebalance(service, nodes, containers, limit)
+	if len(moves) == 0 {
+		s.setRebalancing(service.ID, false)
+		return
+	}
+
+	for _, move := range moves {
+		replacement := newContainer(service, move.decision.NodeID)
+		if err := s.manager.CreateContainer(replacement); err != nil {
+			s.logger.Error().Err(err).Str("service_name", service.Name).Msg("Failed to create rebalanced container")
+			return
+		}
+		move.decision.ContainerID = replacement.ID
+		s.decisions.record(move.decision)
+		s.recordPlacement(move.decision.node, replacement)
+
+		from := move.container.NodeID
+		move.container.DesiredState = types.ContainerStateShutdown
+		if err := s.manager.UpdateContainer(move.container); err != nil {
+			s.logger.Error().Err(err).Str("container_id", move.container.ID).Msg("Failed to shutdown rebalanced container")
+			return
+		}
+
+		metrics.SchedulerRebalanceMoves.Inc()
+		s.logger.Info().
+			Str("service_name", service.Name).
+			Str("container_id", move.container.ID).
+			Str("from_node", from).
+			Str("to_node", replacement.NodeID).
+			Int("skew", skew).
+			Msg("Moved container to rebalance service")
+	}
+}
+
+// planRebalance picks up to limit replicas to move from the most loaded
+// nodes to wherever the framework would place them, as long as each move
+// narrows the gap between the two nodes. Skew is measured on the nodes
+// that run a replica or could take one.
+func (s *Scheduler) planRebalance(service *types.Service, nodes []*types.Node, containers []*types.Container, limit int) ([]rebalanceMove, int) {
+	counts := make(map[string]int)
+	for _, container := range containers {
+		if container.DesiredState == types.ContainerStateRunning && container.NodeID != "" {
+			counts[container.NodeID]++
+		}
+	}
+
+	eligible := make(map[string]bool)
+	decision := s.framework.Evaluate(&PlacementState{
+		Service:    service,
+		Nodes:      nodes,
+		Containers: containers,
+		Cluster:    s.cluster,
+	})
+	for _, eval := range decision.Nodes {
+		if eval.FilteredBy == "" || counts[eval.NodeID] > 0 {
+			eligible[eval.NodeID] = true
+		}
+	}
+	skew := nodeSkew(nodes, eligible, counts)
+
+	// Plan on a copy, swapping each moved replica for its replacement
+	planned := append([]*types.Container{}, containers...)
+	var moves []rebalanceMove
+	for len(moves) < limit {
+		source := mostLoadedNode(nodes, eligible, counts)
+		if source == "" || nodeSkew(nodes, eligible, counts) <= 1 {
+			break
+		}
+
+		victim := -1
+		for i, container := range planned {
+			if container.NodeID == source && container.DesiredState == types.ContainerStateRunning &&
+				container.ActualState == types.ContainerStateRunning {
+				victim = i
+				break
+			}
+		}
+		if victim < 0 {
+			break
+		}
+
+		remaining := append(append([]*types.Container{}, planned[:victim]...), planned[victim+1:]...)
+		decision := s.framework.Evaluate(&PlacementState{
+			Service:    service,
+			Nodes:      nodes,
+			Containers: remaining,
+			Cluster:    s.cluster,
+		})
+		if decision.node == nil || counts[decision.NodeID]+1 >= counts[source] {
+			break
+		}
+
+		moves = append(moves, rebalanceMove{container: planned[victim], decision: decision})
+		counts[source]--
+		counts[decision.NodeID]++
+		planned = append(remaining, &types.Container{
+			ServiceID:    service.ID,
+			NodeID:       decision.NodeID,
+			DesiredState: types.ContainerStateRunning,
+		})
+	}
+	return moves, skew
+}
+
+// nodeSkew returns the most minus the fewest replicas on eligible nodes
+func nodeSkew(nodes []*types.Node, eligible map[string]bool, counts map[string]int) int {
+	fewest, most := -1, 0
+	for _, node := range nodes {
+		if !eligible[node.ID] {
+			continue
+		}
+		count := counts[node.ID]
+		if fewest < 0 || count < fewest {
+			fewest = count
+		}
+		if count > most {
+			most = count
+		}
+	}
+	if fewest < 0 {
+		return 0
+	}
+	return most - fewest
+}
+
+// mostLoadedNode returns the eligible node running the most replicas
+func mostLoadedNode(nodes []*types.Node, eligible map[string]bool, counts map[string]int) string {
+	best, most := "", 0
+	for _, node := range nodes {
+		if eligible[node.ID] && counts[node.ID] > most {
+			best, most = node.ID, counts[node.ID]
+		}
+	}
+	return best
+}
+
+// disruptionBudget returns how many replicas may be unavailable while a
+// service is rebalanced (default: 1)
+func disruptionBudget(service *types.Service) int {
+	if service.DisruptionBudget > 0 {
+		return service.DisruptionBudget
+	}
+	return 1
+}
+
+// rebalanceParallelism returns how many replicas are moved per batch,
+// following the service's update parallelism (default: 1)
+func rebalanceParallelism(service *types.Service) int {
+	if service.UpdateConfig != nil && service.UpdateConfig.Parallelism > 0 {
+		return service.UpdateConfig.Parallelism
+	}
+	return 1
+}
@@ -0,0 +1,123 @@
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cuemby/warren/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runningReplicas returns count running replicas of a service on a node
+func runningReplicas(serviceID, nodeID string, count int) []*types.Container {
+	var containers []*types.Container
+	for i := 0; i < count; i++ {
+		containers = append(containers, &types.Container{
+			ID:           fmt.Sprintf("%s-%s-%d", serviceID, nodeID, i),
+			ServiceID:    serviceID,
+			NodeID:       nodeID,
+			DesiredState: types.ContainerStateRunning,
+			ActualState:  types.ContainerStateRunning,
+		})
+	}
+	return containers
+}
+
+func TestPlanRebalance(t *testing.T) {
+	nodes := []*types.Node{
+		labeledNode("worker-1", types.NodeRoleWorker, nil),
+		labeledNode("worker-2", types.NodeRoleWorker, nil),
+		labeledNode("worker-3", types.NodeRoleWorker, nil),
+	}
+	service := &types.Service{ID: "web", Name: "web", Replicas: 6}
+
+	// worker-3 came back after its replicas were replaced on the others
+	containers := append(runningReplicas("web", "worker-1", 3), runningReplicas("web", "worker-2", 3)...)
+
+	sched := NewScheduler(nil)
+	sched.cluster = NewClusterState([]*types.Service{service}, containers)
+
+	moves, skew := sched.planRebalance(service, nodes, containers, service.Replicas)
+	assert.Equal(t, 3, skew)
+	require.Len(t, moves, 2)
+	from := map[string]int{}
+	for _, move := range moves {
+		assert.Equal(t, "worker-3", move.decision.NodeID)
+		from[move.container.NodeID]++
+	}
+	assert.Equal(t, map[string]int{"worker-1": 1, "worker-2": 1}, from)
+
+	// The limit caps each batch
+	moves, _ = sched.planRebalance(service, nodes, containers, 1)
+	assert.Len(t, moves, 1)
+
+	// A skew of one cannot be narrowed
+	containers = append(runningReplicas("web", "worker-1", 2), runningReplicas("web", "worker-2", 2)...)
+	containers = append(containers, runningReplicas("web", "worker-3", 1)...)
+	service.Replicas = 5
+	moves, skew = sched.planRebalance(service, nodes, containers, service.Replicas)
+	assert.Equal(t, 1, skew)
+	assert.Empty(t, moves)
+}
+
+func TestPlanRebalance_IneligibleNodes(t *testing.T) {
+	nodes := []*types.Node{
+		labeledNode("worker-1", types.NodeRoleWorker, map[string]string{"zone": "eu-1"}),
+		labeledNode("worker-2", types.NodeRoleWorker, map[string]string{"zone": "eu-1"}),
+		labeledNode("worker-3", types.NodeRoleWorker, map[string]string{"zone": "us-1"}),
+	}
+	service := &types.Service{
+		ID:        "web",
+		Replicas:  4,
+		Placement: &types.Placement{Constraints: []string{"node.labels.zone==eu-1"}},
+	}
+	containers := append(runningReplicas("web", "worker-1", 3), runningReplicas("web", "worker-2", 1)...)
+
+	sched := NewScheduler(nil)
+	sched.cluster = NewClusterState([]*types.Service{service}, containers)
+
+	// worker-3 doesn't count towards the skew and never gets a replica
+	moves, skew := sched.planRebalance(service, nodes, containers, service.Replicas)
+	assert.Equal(t, 2, skew)
+	require.Len(t, moves, 1)
+	assert.Equal(t, "worker-1", moves[0].container.NodeID)
+	assert.Equal(t, "worker-2", moves[0].decision.NodeID)
+}
+
+func TestRebalanceLimits(t *testing.T) {
+	service := &types.Service{ID: "web"}
+	assert.Equal(t, 1, disruptionBudget(service))
+	assert.Equal(t, 1, rebalanceParallelism(service))
+
+	service.DisruptionBudget = 3
+	service.UpdateConfig = &types.UpdateConfig{Parallelism: 2}
+	assert.Equal(t, 3, disruptionBudget(service))
+	assert.Equal(t, 2, rebalanceParallelism(service))
+}
+
+func TestRebalanceService_WaitsForMoves(t *testing.T) {
+	nodes := []*types.Node{
+		labeledNode("worker-1", types.NodeRoleWorker, nil),
+		labeledNode("worker-2", types.NodeRoleWorker, nil),
+	}
+	service := &types.Service{ID: "web", Replicas: 4}
+	containers := runningReplicas("web", "worker-1", 4)
+	containers[3].ActualState = types.ContainerStatePending // A moved replica still starting
+
+	// Without a manager, any move would panic: the budget must stop it
+	sched := NewScheduler(nil)
+	sched.cluster = NewClusterState([]*types.Service{service}, containers)
+	sched.setRebalancing("web", true)
+	sched.rebalanceService(service, nodes, containers)
+	assert.True(t, sched.wantsRebalance("web"))
+
+	// A balanced service is no longer rebalanced
+	containers = append(runningReplicas("web", "worker-1", 2), runningReplicas("web", "worker-2", 2)...)
+	sched.rebalanceService(service, nodes, containers)
+	assert.False(t, sched.wantsRebalance("web"))
+
+	// Auto-rebalance covers every service
+	require.NoError(t, sched.Configure(Config{AutoRebalance: true}))
+	assert.True(t, sched.wantsRebalance("api"))
+}
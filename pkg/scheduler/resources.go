@@ -0,0 +1,133 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cuemby/warren/pkg/types"
+)
+
+// Strategy selects how the scheduler ranks the nodes a container fits on
+type Strategy string
+
+const (
+	// StrategySpread places a container on the node running the fewest
+	// replicas of its service (default)
+	StrategySpread Strategy = "spread"
+
+	// StrategyBinpack places a container on the most allocated node that
+	// still fits it, keeping whole nodes free for large containers
+	StrategyBinpack Strategy = "binpack"
+
+	// StrategyLeastAllocated places a container on the node with the most
+	// unreserved capacity
+	StrategyLeastAllocated Strategy = "least-allocated"
+)
+
+// ParseStrategy parses a scoring strategy name; empty selects StrategySpread
+func ParseStrategy(name string) (Strategy, error) {
+	switch Strategy(name) {
+	case "", StrategySpread:
+		return StrategySpread, nil
+	case StrategyBinpack, StrategyLeastAllocated:
+		return Strategy(name), nil
+	}
+	return "", fmt.Errorf("unknown scheduling strategy %q (use spread, binpack or least-allocated)", name)
+}
+
+// Reasons a node cannot take a container
+const (
+	reasonInsufficientCPU    = "insufficient CPU"
+	reasonInsufficientMemory = "insufficient memory"
+	reasonUnknownResources   = "node resources unknown"
+)
+
+// fitsResources checks a container's reservations against the node's
+// unreserved capacity. It returns an empty reason if the container fits.
+func fitsResources(node *types.Node, req *types.ResourceRequirements) string {
+	if req == nil || (req.CPUReservation == 0 && req.MemoryReservation == 0) {
+		return ""
+	}
+
+	res := node.Resources
+	if res == nil || (res.CPUCores == 0 && res.MemoryBytes == 0) {
+		return reasonUnknownResources
+	}
+
+	if req.CPUReservation > 0 && res.CPUAllocated+req.CPUReservation > float64(res.CPUCores) {
+		return reasonInsufficientCPU
+	}
+	if req.MemoryReservation > 0 && res.MemoryAllocated+req.MemoryReservation > res.MemoryBytes {
+		return reasonInsufficientMemory
+	}
+	return ""
+}
+
+// reserve records a container's reservations against the node, so later
+// placements in the same scheduling cycle see the reduced capacity
+func reserve(node *types.Node, req *types.ResourceRequirements) {
+	if req == nil {
+		return
+	}
+	if node.Resources == nil {
+		node.Resources = &types.NodeResources{}
+	}
+	node.Resources.CPUAllocated += req.CPUReservation
+	node.Resources.MemoryAllocated += req.MemoryReservation
+}
+
+// allocatedFraction returns the mean fraction of a node's CPU and memory
+// that is reserved, between 0 and 1
+func allocatedFraction(node *types.Node) float64 {
+	res := node.Resources
+	if res == nil {
+		return 0
+	}
+
+	var sum float64
+	var n int
+	if res.CPUCores > 0 {
+		sum += res.CPUAllocated / float64(res.CPUCores)
+		n++
+	}
+	if res.MemoryBytes > 0 {
+		sum += float64(res.MemoryAllocated) / float64(res.MemoryBytes)
+		n++
+	}
+	if n == 0 {
+		return 0
+	}
+	return sum / float64(n)
+}
+
+// unschedulableReason summarizes why no node could take a container, e.g.
+// "no suitable node: insufficient memory on 2 nodes, insufficient CPU on 1 node"
+func unschedulableReason(reasons map[string]int) string {
+	if len(reasons) == 0 {
+		return "no suitable node"
+	}
+
+	names := make([]string, 0, len(reasons))
+	for reason := range reasons {
+		names = append(names, reason)
+	}
+	// Most common reason first
+	sort.Slice(names, func(i, j int) bool {
+		if reasons[names[i]] != reasons[names[j]] {
+			return reasons[names[i]] > reasons[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	parts := make([]string, 0, len(names))
+	for _, reason := range names {
+		noun := "nodes"
+		if reasons[reason] == 1 {
+			noun = "node"
+		}
+		parts = append(parts, fmt.Sprintf("%s on %d %s", reason, reasons[reason], noun))
+	}
+
+	return "no suitable node: " + strings.Join(parts, ", ")
+}
@@ -0,0 +1,105 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/cuemby/warren/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func resourceNode(id string, cores int, memory int64, cpuAllocated float64, memoryAllocated int64) *types.Node {
+	return &types.Node{
+		ID:     id,
+		Role:   types.NodeRoleWorker,
+		Status: types.NodeStatusReady,
+		Resources: &types.NodeResources{
+			CPUCores:        cores,
+			MemoryBytes:     memory,
+			CPUAllocated:    cpuAllocated,
+			MemoryAllocated: memoryAllocated,
+		},
+	}
+}
+
+func TestParseStrategy(t *testing.T) {
+	strategy, err := ParseStrategy("")
+	require.NoError(t, err)
+	assert.Equal(t, StrategySpread, strategy)
+
+	strategy, err = ParseStrategy("binpack")
+	require.NoError(t, err)
+	assert.Equal(t, StrategyBinpack, strategy)
+
+	_, err = ParseStrategy("random")
+	assert.Error(t, err)
+}
+
+func TestFitsResources(t *testing.T) {
+	const gb = 1024 * 1024 * 1024
+	node := resourceNode("worker-1", 4, 8*gb, 3, 6*gb)
+
+	tests := []struct {
+		name     string
+		node     *types.Node
+		req      *types.ResourceRequirements
+		expected string
+	}{
+		{"no reservation", node, nil, ""},
+		{"fits", node, &types.ResourceRequirements{CPUReservation: 1, MemoryReservation: 2 * gb}, ""},
+		{"insufficient CPU", node, &types.ResourceRequirements{CPUReservation: 1.5}, reasonInsufficientCPU},
+		{"insufficient memory", node, &types.ResourceRequirements{MemoryReservation: 3 * gb}, reasonInsufficientMemory},
+		{"unknown resources", &types.Node{ID: "worker-2"}, &types.ResourceRequirements{CPUReservation: 0.5}, reasonUnknownResources},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, fitsResources(tt.node, tt.req))
+		})
+	}
+}
+
+func TestReserve(t *testing.T) {
+	node := resourceNode("worker-1", 2, 1024, 0, 0)
+	req := &types.ResourceRequirements{CPUReservation: 1.5, MemoryReservation: 512}
+
+	reserve(node, req)
+	assert.Equal(t, "", fitsResources(node, &types.ResourceRequirements{CPUReservation: 0.5}))
+	assert.Equal(t, reasonInsufficientCPU, fitsResources(node, req), "second reservation sees the first")
+}
+
+func TestSelectNode_Strategies(t *testing.T) {
+	nodes := []*types.Node{
+		resourceNode("worker-1", 4, 4096, 3, 3072),
+		resourceNode("worker-2", 4, 4096, 1, 1024),
+	}
+	// worker-2 runs more replicas, so spread prefers worker-1
+	existing := []*types.Container{
+		{NodeID: "worker-2", DesiredState: types.ContainerStateRunning},
+	}
+
+	tests := []struct {
+		strategy Strategy
+		expected string
+	}{
+		{StrategySpread, "worker-1"},
+		{StrategyBinpack, "worker-1"},
+		{StrategyLeastAllocated, "worker-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			sched := NewScheduler(nil)
+			require.NoError(t, sched.Configure(Config{Strategy: tt.strategy}))
+			decision := sched.selectNodeForService(&types.Service{}, nodes, existing)
+			assert.Equal(t, tt.expected, decision.NodeID)
+		})
+	}
+}
+
+func TestUnschedulableReason(t *testing.T) {
+	assert.Equal(t, "no suitable node", unschedulableReason(nil))
+	assert.Equal(t,
+		"no suitable node: insufficient memory on 2 nodes, insufficient CPU on 1 node",
+		unschedulableReason(map[string]int{reasonInsufficientCPU: 1, reasonInsufficientMemory: 2}))
+}
@@ -2,9 +2,12 @@ package scheduler
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/cuemby/warren/pkg/events"
+	"github.com/cuemby/warren/pkg/jobs"
 	"github.com/cuemby/warren/pkg/log"
 	"github.com/cuemby/warren/pkg/manager"
 	"github.com/cuemby/warren/pkg/metrics"
@@ -13,25 +16,101 @@ import (
 	"github.com/rs/zerolog"
 )
 
-// Scheduler assigns containers to nodes based on resource availability
+// resyncInterval is how often every service is scheduled, catching
+// dropped events and changes no event reports
+const resyncInterval = 30 * time.Second
+
+// leaderCheckInterval is how often the scheduler checks whether this
+// manager became the leader
+const leaderCheckInterval = time.Second
+
+// Scheduler assigns containers to nodes by running the filter and score
+// plugins of its framework. Cluster events queue the services they affect
+// for a scheduling pass.
 type Scheduler struct {
-	manager *manager.Manager
-	logger  zerolog.Logger
-	mu      sync.RWMutex
-	stopCh  chan struct{}
+	manager   *manager.Manager
+	logger    zerolog.Logger
+	framework *Framework
+	cluster   *ClusterState // Containers by node for the current cycle
+	decisions *decisionLog
+	queue     *workQueue
+	mu        sync.RWMutex
+
+	// Services with containers no node could take, queued again whenever
+	// an event frees capacity
+	unplacedMu sync.Mutex
+	unplaced   map[string]bool
+
+	// Services whose replicas are moved to even out skew: those a
+	// rebalance was requested for, or every service with autoRebalance
+	rebalanceMu   sync.Mutex
+	rebalancing   map[string]bool
+	autoRebalance bool
+
+	// Only the leader schedules. isLeader reports whether this manager is
+	// the leader, and leading whether it was at the last check (run only).
+	isLeader func() bool
+	leading  bool
+
+	stopCh chan struct{}
 }
 
-// NewScheduler creates a new scheduler
+// NewScheduler creates a new scheduler with the default plugins
 func NewScheduler(mgr *manager.Manager) *Scheduler {
-	return &Scheduler{
-		manager: mgr,
-		logger:  log.WithComponent("scheduler"),
-		stopCh:  make(chan struct{}),
+	s := &Scheduler{
+		manager:     mgr,
+		logger:      log.WithComponent("scheduler"),
+		decisions:   newDecisionLog(),
+		queue:       newWorkQueue(),
+		unplaced:    make(map[string]bool),
+		rebalancing: make(map[string]bool),
+		stopCh:      make(chan struct{}),
+		isLeader:    func() bool { return false },
+	}
+	if mgr != nil {
+		s.isLeader = mgr.IsLeader
+	}
+	// The built-in plugins always build
+	s.framework, _ = NewFramework(DefaultConfig(), s.handle())
+	return s
+}
+
+// Configure replaces the plugins the scheduler runs
+func (s *Scheduler) Configure(cfg Config) error {
+	framework, err := NewFramework(cfg, s.handle())
+	if err != nil {
+		return err
 	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.framework = framework
+
+	s.rebalanceMu.Lock()
+	defer s.rebalanceMu.Unlock()
+	s.autoRebalance = cfg.AutoRebalance
+	return nil
+}
+
+// Decisions returns the latest placement decisions made for a service by
+// this manager, oldest first
+func (s *Scheduler) Decisions(serviceID string) []*Decision {
+	return s.decisions.forService(serviceID)
+}
+
+// handle returns the cluster access plugins get, or nil without a manager
+func (s *Scheduler) handle() Handle {
+	if s.manager == nil {
+		return nil
+	}
+	return s.manager
 }
 
 // Start begins the scheduler loop
 func (s *Scheduler) Start() {
+	if broker := s.manager.GetEventBroker(); broker != nil {
+		go s.watchEvents(broker)
+	}
 	go s.run()
 }
 
@@ -40,26 +119,154 @@ func (s *Scheduler) Stop() {
 	close(s.stopCh)
 }
 
-// run is the main scheduler loop
+// run is the main scheduler loop. On the leader, it schedules the queued
+// services as soon as events queue them, and every service when this
+// manager becomes leader and on each resync. Followers drop queued work,
+// as the leader does it and a new leader starts with a full pass.
 func (s *Scheduler) run() {
-	ticker := time.NewTicker(5 * time.Second)
+	ticker := time.NewTicker(resyncInterval)
 	defer ticker.Stop()
+	leaderTicker := time.NewTicker(leaderCheckInterval)
+	defer leaderTicker.Stop()
 
+	s.checkLeader()
 	for {
 		select {
-		case <-ticker.C:
-			if err := s.schedule(); err != nil {
+		case <-s.queue.Ready():
+			ids, all := s.queue.Drain()
+			if !s.checkLeader() {
+				continue
+			}
+			var err error
+			if all {
+				metrics.SchedulerPassesTotal.WithLabelValues("all").Inc()
+				err = s.schedule()
+			} else if len(ids) > 0 {
+				metrics.SchedulerPassesTotal.WithLabelValues("services").Inc()
+				err = s.scheduleServices(ids)
+			}
+			if err != nil {
 				// Log error but continue
 				s.logger.Error().Err(err).Msg("Scheduling cycle failed")
 			}
+		case <-leaderTicker.C:
+			s.checkLeader()
+		case <-ticker.C:
+			s.queue.AddAll()
 		case <-s.stopCh:
 			return
 		}
 	}
 }
 
-// schedule performs one scheduling cycle
+// checkLeader reports whether this manager is the leader, queueing a full
+// pass when it just became leader (run only)
+func (s *Scheduler) checkLeader() bool {
+	leader := s.isLeader()
+	if leader && !s.leading {
+		s.logger.Info().Msg("Became leader, scheduling every service")
+		s.queue.AddAll()
+	}
+	s.leading = leader
+	return leader
+}
+
+// watchEvents queues the services affected by cluster events. It only
+// queues, so it keeps up with events while a pass runs.
+func (s *Scheduler) watchEvents(broker *events.Broker) {
+	sub := broker.Subscribe()
+	defer broker.Unsubscribe(sub)
+
+	for {
+		select {
+		case event, ok := <-sub:
+			if !ok {
+				return
+			}
+			s.handleEvent(event)
+		case <-s.stopCh:
+			return
+		}
+	}
+}
+
+// handleEvent queues the services an event may leave with too few, too
+// many or unplaced containers
+func (s *Scheduler) handleEvent(event *events.Event) {
+	switch event.Type {
+	case events.EventServiceCreated, events.EventServiceUpdated:
+		s.queue.Add(event.Metadata["service_id"])
+	case events.EventTaskFailed, events.EventTaskCompleted, events.EventTaskDeleted:
+		s.queue.Add(event.Metadata["service_id"])
+		s.queueUnplaced()
+	case events.EventTaskUpdated:
+		// A container told to stop needs a replacement, and frees its node
+		if event.Metadata["desired_state"] != string(types.ContainerStateRunning) {
+			s.queue.Add(event.Metadata["service_id"])
+			s.queueUnplaced()
+		}
+	case events.EventTaskStarted:
+		// A moved replica is up, so the next batch may move
+		if s.wantsRebalance(event.Metadata["service_id"]) {
+			s.queue.Add(event.Metadata["service_id"])
+		}
+	case events.EventNodeJoined, events.EventNodeDown, events.EventNodeLeft:
+		// Capacity changed for every service
+		s.queue.AddAll()
+	case events.EventNodeUpdated:
+		// New labels or taints may admit unplaced replicas anywhere, or
+		// break the constraints of running ones
+		s.queue.AddAll()
+	}
+}
+
+// queueUnplaced queues the services waiting for a node to fit
+func (s *Scheduler) queueUnplaced() {
+	s.unplacedMu.Lock()
+	defer s.unplacedMu.Unlock()
+	for id := range s.unplaced {
+		s.queue.Add(id)
+	}
+}
+
+// setUnplaced records whether a service has containers no node could take
+func (s *Scheduler) setUnplaced(serviceID string, unplaced bool) {
+	s.unplacedMu.Lock()
+	defer s.unplacedMu.Unlock()
+	if unplaced {
+		s.unplaced[serviceID] = true
+	} else {
+		delete(s.unplaced, serviceID)
+	}
+}
+
+// pruneUnplaced forgets services that no longer exist
+func (s *Scheduler) pruneUnplaced(services []*types.Service) {
+	s.unplacedMu.Lock()
+	for id := range s.unplaced {
+		if len(selectServices(services, []string{id})) == 0 {
+			delete(s.unplaced, id)
+		}
+	}
+	s.unplacedMu.Unlock()
+
+	s.rebalanceMu.Lock()
+	defer s.rebalanceMu.Unlock()
+	for id := range s.rebalancing {
+		if len(selectServices(services, []string{id})) == 0 {
+			delete(s.rebalancing, id)
+		}
+	}
+}
+
+// schedule performs a scheduling pass over every service
 func (s *Scheduler) schedule() error {
+	return s.scheduleServices(nil)
+}
+
+// scheduleServices performs a scheduling pass over the given services, or
+// every service if ids is nil. Cluster state is read once per pass.
+func (s *Scheduler) scheduleServices(ids []string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -83,9 +290,28 @@ func (s *Scheduler) schedule() error {
 		return nil
 	}
 
-	// Schedule each service
-	for _, service := range services {
-		if err := s.scheduleService(service, readyNodes); err != nil {
+	// Index every container by node for plugins that look at what already runs there
+	containers, err := s.manager.ListContainers()
+	if err != nil {
+		return fmt.Errorf("failed to list containers: %w", err)
+	}
+	s.cluster = NewClusterState(services, containers)
+	s.decisions.prune(services)
+	s.pruneUnplaced(services)
+
+	byService := make(map[string][]*types.Container)
+	for _, container := range containers {
+		byService[container.ServiceID] = append(byService[container.ServiceID], container)
+	}
+
+	// Schedule each service, most important first so it gets freed
+	// capacity before anything else
+	selected := selectServices(services, ids)
+	sort.SliceStable(selected, func(i, j int) bool {
+		return selected[i].Priority > selected[j].Priority
+	})
+	for _, service := range selected {
+		if err := s.scheduleService(service, readyNodes, byService[service.ID]); err != nil {
 			s.logger.Error().
 				Err(err).
 				Str("service_name", service.Name).
@@ -98,22 +324,61 @@ func (s *Scheduler) schedule() error {
 	return nil
 }
 
-// scheduleService ensures the service has the correct number of containers
-func (s *Scheduler) scheduleService(service *types.Service, nodes []*types.Node) error {
-	// Get existing containers for this service
-	containers, err := s.manager.ListContainersByService(service.ID)
-	if err != nil {
-		return fmt.Errorf("failed to list containers: %w", err)
+// selectServices returns the services with the given IDs, in order, or
+// every service if ids is nil. Services deleted since they were queued are
+// skipped.
+func selectServices(services []*types.Service, ids []string) []*types.Service {
+	if ids == nil {
+		return services
 	}
+	byID := make(map[string]*types.Service, len(services))
+	for _, service := range services {
+		byID[service.ID] = service
+	}
+	selected := make([]*types.Service, 0, len(ids))
+	for _, id := range ids {
+		if service, ok := byID[id]; ok {
+			selected = append(selected, service)
+		}
+	}
+	return selected
+}
 
-	if service.Mode == types.ServiceModeGlobal {
+// scheduleService ensures the service has the correct number of containers
+func (s *Scheduler) scheduleService(service *types.Service, nodes []*types.Node, containers []*types.Container) error {
+	s.setUnplaced(service.ID, false)
+	switch service.Mode {
+	case types.ServiceModeGlobal:
 		return s.scheduleGlobalService(service, nodes, containers)
+	case types.ServiceModeJob:
+		return s.scheduleJob(service, nodes, containers)
+	case types.ServiceModeCronJob:
+		return nil // The job controller creates the jobs that run
 	}
-	return s.scheduleReplicatedService(service, nodes, containers)
+	if err := s.scheduleReplicatedService(service, nodes, containers); err != nil {
+		return err
+	}
+	s.rebalanceService(service, nodes, containers)
+	return nil
 }
 
-// scheduleGlobalService ensures one container per node for global services
+// scheduleGlobalService ensures one container per node for global services.
+// Only nodes that satisfy the service's placement constraints get one.
 func (s *Scheduler) scheduleGlobalService(service *types.Service, nodes []*types.Node, containers []*types.Container) error {
+	constraints, err := serviceConstraints(service)
+	if err != nil {
+		return err
+	}
+	var eligible []*types.Node
+	for _, node := range nodes {
+		if matchesConstraints(node, constraints) {
+			eligible = append(eligible, node)
+		}
+	}
+	nodes = eligible
+
+	state := &PlacementState{Service: service, Nodes: nodes, Containers: containers, Cluster: s.cluster}
+
 	// Build map of active containers by node
 	nodeContainerMap := make(map[string]*types.Container)
 	for _, container := range containers {
@@ -126,30 +391,31 @@ func (s *Scheduler) scheduleGlobalService(service *types.Service, nodes []*types
 	// Ensure each node has exactly one container
 	for _, node := range nodes {
 		if _, exists := nodeContainerMap[node.ID]; !exists {
+			// Skip nodes a filter plugin rules out, unless stopping
+			// lower-priority containers makes room
+			if plugin, reason := s.framework.Filter(state, node); reason != "" {
+				if s.preemptFor(service, newContainer(service, ""), []*types.Node{node}, containers) != nil {
+					s.setUnplaced(service.ID, true)
+					continue
+				}
+				s.logger.Debug().
+					Str("service_name", service.Name).
+					Str("node_id", node.ID).
+					Str("plugin", plugin).
+					Str("reason", reason).
+					Msg("Node cannot run global container")
+				continue
+			}
+
 			// Create container for this node
 			timer := metrics.NewTimer()
-			container := &types.Container{
-				ID:            uuid.New().String(),
-				ServiceID:     service.ID,
-				ServiceName:   service.Name,
-				NodeID:        node.ID,
-				DesiredState:  types.ContainerStateRunning,
-				ActualState:   types.ContainerStatePending,
-				Image:         service.Image,
-				Env:           service.Env,
-				Mounts:        service.Volumes,
-				Secrets:       service.Secrets,
-				Resources:     service.Resources,
-				HealthCheck:   service.HealthCheck,
-				RestartPolicy: service.RestartPolicy,
-				StopTimeout:   service.StopTimeout,
-				CreatedAt:     time.Now(),
-			}
+			container := newContainer(service, node.ID)
 
 			if err := s.manager.CreateContainer(container); err != nil {
 				metrics.ContainersFailed.Inc()
 				return fmt.Errorf("failed to create container: %w", err)
 			}
+			s.recordPlacement(node, container)
 
 			timer.ObserveDuration(metrics.SchedulingLatency)
 			metrics.ContainersScheduled.Inc()
@@ -162,7 +428,7 @@ func (s *Scheduler) scheduleGlobalService(service *types.Service, nodes []*types
 		}
 	}
 
-	// Remove containers for nodes that no longer exist
+	// Remove containers for nodes that no longer exist or no longer satisfy the constraints
 	for _, container := range containers {
 		if container.DesiredState != types.ContainerStateRunning {
 			continue
@@ -185,15 +451,23 @@ func (s *Scheduler) scheduleGlobalService(service *types.Service, nodes []*types
 			s.logger.Info().
 				Str("container_id", container.ID).
 				Str("node_id", container.NodeID).
-				Msg("Removed global container (node no longer exists)")
+				Msg("Removed global container (node no longer eligible)")
 		}
 	}
 
 	return nil
 }
 
-// scheduleReplicatedService handles replicated service scheduling
+// scheduleReplicatedService handles replicated service scheduling. A
+// container that fits no node is created without one, with the reason in
+// its Error, and is placed by a later cycle once capacity frees up.
 func (s *Scheduler) scheduleReplicatedService(service *types.Service, nodes []*types.Node, containers []*types.Container) error {
+	constraints, err := serviceConstraints(service)
+	if err != nil {
+		return err
+	}
+	s.stopConstraintViolations(service, nodes, containers, constraints)
+
 	// Count running/pending containers
 	activeContainers := 0
 	for _, container := range containers {
@@ -203,73 +477,51 @@ func (s *Scheduler) scheduleReplicatedService(service *types.Service, nodes []*t
 		}
 	}
 
-	desiredContainers := service.Replicas
+	// A rolling update surges replacements before stopping old containers
+	desiredContainers := service.Replicas + service.SurgeReplicas
 	containersToCreate := desiredContainers - activeContainers
 
-	// Create missing containers
-	if containersToCreate > 0 {
-		for i := 0; i < containersToCreate; i++ {
-			timer := metrics.NewTimer()
-
-			// Check if service has volume requirements
-			node, err := s.selectNodeForService(service, nodes, containers)
-			if err != nil {
-				metrics.ContainersFailed.Inc()
-				return fmt.Errorf("failed to select node: %w", err)
-			}
-			if node == nil {
-				metrics.ContainersFailed.Inc()
-				return fmt.Errorf("no suitable node found")
-			}
-
-			container := &types.Container{
-				ID:            uuid.New().String(),
-				ServiceID:     service.ID,
-				ServiceName:   service.Name,
-				NodeID:        node.ID,
-				DesiredState:  types.ContainerStateRunning,
-				ActualState:   types.ContainerStatePending,
-				Image:         service.Image,
-				Env:           service.Env,
-				Mounts:        service.Volumes,
-				Secrets:       service.Secrets,
-				Resources:     service.Resources,
-				HealthCheck:   service.HealthCheck,
-				RestartPolicy: service.RestartPolicy,
-				StopTimeout:   service.StopTimeout,
-				CreatedAt:     time.Now(),
-			}
-
-			if err := s.manager.CreateContainer(container); err != nil {
-				metrics.ContainersFailed.Inc()
-				return fmt.Errorf("failed to create container: %w", err)
+	// Place containers still waiting for a node, unless they are about to be removed
+	if containersToCreate >= 0 {
+		for _, container := range containers {
+			if container.NodeID == "" && container.DesiredState == types.ContainerStateRunning {
+				s.placePendingContainer(service, container, nodes, containers)
 			}
-
-			timer.ObserveDuration(metrics.SchedulingLatency)
-			metrics.ContainersScheduled.Inc()
-
-			s.logger.Info().
-				Str("container_id", container.ID).
-				Str("service_name", service.Name).
-				Str("node_id", node.ID).
-				Msg("Created container")
 		}
 	}
 
-	// Remove excess containers
+	// Create missing containers
+	containers, err = s.createContainers(service, nodes, containers, containersToCreate)
+	if err != nil {
+		return err
+	}
+
+	// Remove excess containers, starting with those never placed on a node
 	if containersToCreate < 0 {
 		containersToRemove := -containersToCreate
 		removed := 0
-		for _, container := range containers {
-			if removed >= containersToRemove {
-				break
-			}
-			if container.DesiredState == types.ContainerStateRunning {
-				container.DesiredState = types.ContainerStateShutdown
-				if err := s.manager.UpdateContainer(container); err != nil {
-					s.logger.Error().Err(err).Str("container_id", container.ID).Msg("Failed to shutdown container")
+		for _, unplaced := range []bool{true, false} {
+			for _, container := range containers {
+				if removed >= containersToRemove {
+					break
+				}
+				if container.DesiredState != types.ContainerStateRunning || (container.NodeID == "") != unplaced {
 					continue
 				}
+
+				if unplaced {
+					// Nothing is running, so there is nothing to stop
+					if err := s.manager.DeleteContainer(container.ID); err != nil {
+						s.logger.Error().Err(err).Str("container_id", container.ID).Msg("Failed to remove pending container")
+						continue
+					}
+				} else {
+					container.DesiredState = types.ContainerStateShutdown
+					if err := s.manager.UpdateContainer(container); err != nil {
+						s.logger.Error().Err(err).Str("container_id", container.ID).Msg("Failed to shutdown container")
+						continue
+					}
+				}
 				removed++
 			}
 		}
@@ -278,66 +530,203 @@ func (s *Scheduler) scheduleReplicatedService(service *types.Service, nodes []*t
 	return nil
 }
 
-// selectNodeForService selects a node for a service, considering volume affinity
-func (s *Scheduler) selectNodeForService(service *types.Service, nodes []*types.Node, existingContainers []*types.Container) (*types.Node, error) {
-	// Check if service has volume requirements
-	if len(service.Volumes) > 0 {
-		// Find node with volume affinity
-		for _, volumeMount := range service.Volumes {
-			volume, err := s.manager.GetVolumeByName(volumeMount.Source)
-			if err != nil {
-				// Volume doesn't exist yet, will be created on selected node
-				continue
-			}
+// scheduleJob runs a job's containers until it has enough successes:
+// up to its parallelism at a time, replacing those that fail. Finished
+// jobs get nothing; the job controller records their status and stops
+// what is left.
+func (s *Scheduler) scheduleJob(service *types.Service, nodes []*types.Node, containers []*types.Container) error {
+	active := 0
+	for _, container := range containers {
+		if container.DesiredState == types.ContainerStateRunning &&
+			(container.ActualState == types.ContainerStatePending || container.ActualState == types.ContainerStateRunning) {
+			active++
+		}
+	}
 
-			// If volume exists and has node affinity, use that node
-			if volume.NodeID != "" {
-				for _, node := range nodes {
-					if node.ID == volume.NodeID {
-						s.logger.Debug().
-							Str("node_id", node.ID).
-							Str("service_name", service.Name).
-							Str("volume_name", volume.Name).
-							Msg("Selected node for service (volume affinity)")
-						return node, nil
-					}
-				}
-				return nil, fmt.Errorf("volume %s requires node %s which is not available", volume.Name, volume.NodeID)
-			}
+	wanted := jobs.Wanted(service, containers, time.Now())
+	if wanted == 0 {
+		return nil
+	}
+	for _, container := range containers {
+		if container.NodeID == "" && container.DesiredState == types.ContainerStateRunning {
+			s.placePendingContainer(service, container, nodes, containers)
 		}
 	}
 
-	// No volume affinity, use standard selection
-	return s.selectNode(nodes, existingContainers), nil
+	_, err := s.createContainers(service, nodes, containers, wanted-active)
+	return err
 }
 
-// selectNode implements simple round-robin node selection
-func (s *Scheduler) selectNode(nodes []*types.Node, existingContainers []*types.Container) *types.Node {
-	if len(nodes) == 0 {
-		return nil
-	}
+// createContainers creates count containers for a service, placing each
+// on the best node. One that fits no node is created without one, after
+// trying to preempt lower-priority containers to make room. It returns
+// containers with the new ones appended.
+func (s *Scheduler) createContainers(service *types.Service, nodes []*types.Node, containers []*types.Container, count int) ([]*types.Container, error) {
+	for i := 0; i < count; i++ {
+		timer := metrics.NewTimer()
+
+		container := newContainer(service, "")
+		decision := s.selectNodeForService(service, nodes, containers)
+		node, reason := decision.node, decision.Reason
+		if node != nil {
+			container.NodeID = node.ID
+		} else {
+			if p := s.preemptFor(service, container, nodes, containers); p != nil {
+				reason = preemptionReason(p)
+			}
+			container.Error = reason
+		}
 
-	// Count containers per node
-	containerCounts := make(map[string]int)
-	for _, container := range existingContainers {
-		if container.DesiredState == types.ContainerStateRunning {
-			containerCounts[container.NodeID]++
+		if err := s.manager.CreateContainer(container); err != nil {
+			metrics.ContainersFailed.Inc()
+			return containers, fmt.Errorf("failed to create container: %w", err)
 		}
+		containers = append(containers, container)
+		decision.ContainerID = container.ID
+		s.decisions.record(decision)
+
+		if node == nil {
+			s.setUnplaced(service.ID, true)
+			s.logger.Warn().
+				Str("container_id", container.ID).
+				Str("service_name", service.Name).
+				Str("reason", reason).
+				Msg("Container pending: no suitable node")
+			continue
+		}
+		s.recordPlacement(node, container)
+
+		timer.ObserveDuration(metrics.SchedulingLatency)
+		metrics.ContainersScheduled.Inc()
+
+		s.logger.Info().
+			Str("container_id", container.ID).
+			Str("service_name", service.Name).
+			Str("node_id", node.ID).
+			Msg("Created container")
 	}
+	return containers, nil
+}
 
-	// Find node with fewest containers (simple load balancing)
-	var selectedNode *types.Node
-	minContainers := int(^uint(0) >> 1) // Max int
+// stopConstraintViolations shuts down replicas on ready nodes that no longer
+// satisfy the service's constraints (e.g. after a node label changed), so
+// they are replaced on nodes that do
+func (s *Scheduler) stopConstraintViolations(service *types.Service, nodes []*types.Node, containers []*types.Container, constraints []Constraint) {
+	if len(constraints) == 0 {
+		return
+	}
 
+	nodeByID := make(map[string]*types.Node, len(nodes))
 	for _, node := range nodes {
-		count := containerCounts[node.ID]
-		if count < minContainers {
-			minContainers = count
-			selectedNode = node
+		nodeByID[node.ID] = node
+	}
+
+	for _, container := range containers {
+		if container.DesiredState != types.ContainerStateRunning {
+			continue
+		}
+		node, ok := nodeByID[container.NodeID]
+		if !ok || matchesConstraints(node, constraints) {
+			continue
+		}
+
+		container.DesiredState = types.ContainerStateShutdown
+		if err := s.manager.UpdateContainer(container); err != nil {
+			s.logger.Error().Err(err).Str("container_id", container.ID).Msg("Failed to shutdown container")
+			continue
+		}
+		s.logger.Info().
+			Str("container_id", container.ID).
+			Str("service_name", service.Name).
+			Str("node_id", node.ID).
+			Msg("Stopping container on node that no longer satisfies placement constraints")
+	}
+}
+
+// placePendingContainer assigns a container that is waiting for a node to
+// one that fits, or refreshes the reason it is still pending
+func (s *Scheduler) placePendingContainer(service *types.Service, container *types.Container, nodes []*types.Node, containers []*types.Container) {
+	decision := s.selectNodeForService(service, nodes, containers)
+	node, reason := decision.node, decision.Reason
+	if node == nil {
+		s.setUnplaced(service.ID, true)
+		if p := s.preemptFor(service, container, nodes, containers); p != nil {
+			reason = preemptionReason(p)
+		}
+		if container.Error == reason {
+			return
 		}
+		container.Error = reason
+	} else {
+		container.NodeID = node.ID
+		container.Error = ""
+	}
+
+	if err := s.manager.UpdateContainer(container); err != nil {
+		s.logger.Error().Err(err).Str("container_id", container.ID).Msg("Failed to update pending container")
+		return
 	}
+	decision.ContainerID = container.ID
+	s.decisions.record(decision)
+
+	if node != nil {
+		s.recordPlacement(node, container)
+		metrics.ContainersScheduled.Inc()
+		s.logger.Info().
+			Str("container_id", container.ID).
+			Str("service_name", service.Name).
+			Str("node_id", node.ID).
+			Msg("Placed pending container")
+	}
+}
 
-	return selectedNode
+// recordPlacement accounts for a container placed on a node during this
+// cycle, so later placements see its reservations and affinity
+func (s *Scheduler) recordPlacement(node *types.Node, container *types.Container) {
+	reserve(node, container.Resources)
+	s.cluster.add(container)
+}
+
+// newContainer creates a pending container for a service on the given node
+// (empty if it has not been placed yet)
+func newContainer(service *types.Service, nodeID string) *types.Container {
+	return &types.Container{
+		ID:            uuid.New().String(),
+		ServiceID:     service.ID,
+		ServiceName:   service.Name,
+		NodeID:        nodeID,
+		DesiredState:  types.ContainerStateRunning,
+		ActualState:   types.ContainerStatePending,
+		Image:         service.Image,
+		Env:           service.Env,
+		Ports:         service.Ports,
+		Mounts:        service.Volumes,
+		Secrets:       service.Secrets,
+		Resources:     service.Resources,
+		HealthCheck:   service.HealthCheck,
+		RestartPolicy: service.RestartPolicy,
+		StopTimeout:   service.StopTimeout,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// selectNodeForService runs the framework to place one of a service's
+// containers. The decision names the node, or says why none fits.
+func (s *Scheduler) selectNodeForService(service *types.Service, nodes []*types.Node, existingContainers []*types.Container) *Decision {
+	decision := s.framework.Evaluate(&PlacementState{
+		Service:    service,
+		Nodes:      nodes,
+		Containers: existingContainers,
+		Cluster:    s.cluster,
+	})
+
+	if decision.node != nil {
+		s.logger.Debug().
+			Str("node_id", decision.NodeID).
+			Str("service_name", service.Name).
+			Msg("Selected node for service")
+	}
+	return decision
 }
 
 // filterSchedulableNodes returns nodes that can run workloads (workers and hybrid nodes)
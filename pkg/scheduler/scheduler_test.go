@@ -290,4 +290,23 @@ func TestReplicatedServiceScheduling(t *testing.T) {
 
 	assert.Equal(t, 2, runningCount, "Should have 2 running containers")
 	assert.Equal(t, 1, shutdownCount, "Should have 1 shutdown container")
+
+	// A rolling update's surge runs on top of the replicas
+	service.SurgeReplicas = 1
+	err = mgr.UpdateService(service)
+	assert.NoError(t, err)
+
+	err = sched.schedule()
+	assert.NoError(t, err)
+
+	containers, err = mgr.ListContainersByService(service.ID)
+	assert.NoError(t, err)
+
+	runningCount = 0
+	for _, container := range containers {
+		if container.DesiredState == types.ContainerStateRunning {
+			runningCount++
+		}
+	}
+	assert.Equal(t, 3, runningCount, "Should have 2 replicas and 1 surge container")
 }
@@ -91,7 +91,7 @@ func TestFilterSchedulableNodes(t *testing.T) {
 	}
 }
 
-// TestSelectNode tests the node selection logic using the scheduler's selectNode method
+// TestSelectNode tests the node selection logic using the scheduler's default plugins
 func TestSelectNode(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -117,7 +117,7 @@ func TestSelectNode(t *testing.T) {
 				{NodeID: "worker-1", ServiceID: "service-1"},
 			},
 			expectNode: true,
-			// selectNode should return worker-2 since worker-1 already has a container
+			// worker-2 should be selected since worker-1 already has a container
 		},
 		{
 			name:               "no nodes available",
@@ -130,13 +130,13 @@ func TestSelectNode(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Create a scheduler instance for testing
-			sched := &Scheduler{}
-			node := sched.selectNode(tt.nodes, tt.existingContainers)
+			sched := NewScheduler(nil)
+			decision := sched.selectNodeForService(&types.Service{}, tt.nodes, tt.existingContainers)
 
 			if tt.expectNode {
-				assert.NotNil(t, node)
+				assert.NotNil(t, decision.node)
 			} else {
-				assert.Nil(t, node)
+				assert.Nil(t, decision.node)
 			}
 		})
 	}
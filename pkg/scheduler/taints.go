@@ -0,0 +1,187 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cuemby/warren/pkg/types"
+)
+
+// ParseTaint parses a taint of the form "<key>=<value>:<effect>" or
+// "<key>:<effect>"
+func ParseTaint(expr string) (types.Taint, error) {
+	var taint types.Taint
+	i := strings.LastIndex(expr, ":")
+	if i < 0 {
+		return taint, fmt.Errorf("invalid taint %q: expected KEY[=VALUE]:EFFECT", expr)
+	}
+	effect, err := parseEffect(expr[i+1:])
+	if err != nil {
+		return taint, fmt.Errorf("invalid taint %q: %w", expr, err)
+	}
+	taint.Effect = effect
+	taint.Key, taint.Value, _ = strings.Cut(expr[:i], "=")
+	if taint.Key == "" {
+		return taint, fmt.Errorf("invalid taint %q: missing key", expr)
+	}
+	return taint, nil
+}
+
+// ParseTaintKey parses a taint to remove, of the form "<key>" for every
+// effect or "<key>:<effect>" for one
+func ParseTaintKey(expr string) (types.Taint, error) {
+	key, effect, found := strings.Cut(expr, ":")
+	taint := types.Taint{Key: key}
+	if key == "" {
+		return taint, fmt.Errorf("invalid taint %q: missing key", expr)
+	}
+	if found {
+		var err error
+		if taint.Effect, err = parseEffect(effect); err != nil {
+			return taint, fmt.Errorf("invalid taint %q: %w", expr, err)
+		}
+	}
+	return taint, nil
+}
+
+// ParseToleration parses a toleration. "<key>=<value>" matches the value,
+// "<key>" any value, and either may end in ":<effect>" to match only that
+// effect. "*" tolerates every taint, "*:<effect>" every taint with the effect.
+func ParseToleration(expr string) (types.Toleration, error) {
+	var tol types.Toleration
+	rest := expr
+	if i := strings.LastIndex(expr, ":"); i >= 0 {
+		effect, err := parseEffect(expr[i+1:])
+		if err != nil {
+			return tol, fmt.Errorf("invalid toleration %q: %w", expr, err)
+		}
+		tol.Effect = effect
+		rest = expr[:i]
+	}
+
+	if rest == "*" {
+		tol.Operator = types.TolerationOpExists
+		return tol, nil
+	}
+
+	key, value, hasValue := strings.Cut(rest, "=")
+	if key == "" {
+		return tol, fmt.Errorf("invalid toleration %q: missing key", expr)
+	}
+	tol.Key = key
+	if hasValue {
+		tol.Operator = types.TolerationOpEqual
+		tol.Value = value
+	} else {
+		tol.Operator = types.TolerationOpExists
+	}
+	return tol, nil
+}
+
+// ValidateTaints checks that taints have a key and a known effect
+func ValidateTaints(taints []types.Taint) error {
+	for _, taint := range taints {
+		if taint.Key == "" {
+			return fmt.Errorf("invalid taint: missing key")
+		}
+		if _, err := parseEffect(string(taint.Effect)); err != nil {
+			return fmt.Errorf("invalid taint %q: %w", taint.Key, err)
+		}
+	}
+	return nil
+}
+
+// ValidateTolerations checks that a service's tolerations are well formed
+func ValidateTolerations(tolerations []types.Toleration) error {
+	for _, tol := range tolerations {
+		switch tol.Operator {
+		case "", types.TolerationOpEqual:
+			if tol.Key == "" {
+				return fmt.Errorf("invalid toleration: the Equal operator needs a key")
+			}
+		case types.TolerationOpExists:
+			if tol.Value != "" {
+				return fmt.Errorf("invalid toleration %q: the Exists operator takes no value", tol.Key)
+			}
+		default:
+			return fmt.Errorf("invalid toleration %q: unknown operator %q", tol.Key, tol.Operator)
+		}
+		if tol.Effect != "" {
+			if _, err := parseEffect(string(tol.Effect)); err != nil {
+				return fmt.Errorf("invalid toleration %q: %w", tol.Key, err)
+			}
+		}
+	}
+	return nil
+}
+
+// parseEffect parses a taint effect
+func parseEffect(s string) (types.TaintEffect, error) {
+	switch effect := types.TaintEffect(s); effect {
+	case types.TaintEffectNoSchedule, types.TaintEffectPreferNoSchedule, types.TaintEffectNoExecute:
+		return effect, nil
+	}
+	return "", fmt.Errorf("unknown taint effect %q (use NoSchedule, PreferNoSchedule or NoExecute)", s)
+}
+
+// FormatTaint formats a taint as "<key>=<value>:<effect>"
+func FormatTaint(taint types.Taint) string {
+	if taint.Value == "" {
+		return fmt.Sprintf("%s:%s", taint.Key, taint.Effect)
+	}
+	return fmt.Sprintf("%s=%s:%s", taint.Key, taint.Value, taint.Effect)
+}
+
+// tolerates reports whether a toleration matches a taint
+func tolerates(tol types.Toleration, taint types.Taint) bool {
+	if tol.Effect != "" && tol.Effect != taint.Effect {
+		return false
+	}
+	if tol.Operator == types.TolerationOpExists {
+		return tol.Key == "" || tol.Key == taint.Key
+	}
+	return tol.Key == taint.Key && tol.Value == taint.Value
+}
+
+// toleratedBy reports whether any of a service's tolerations matches a taint
+func toleratedBy(service *types.Service, taint types.Taint) bool {
+	for _, tol := range service.Tolerations {
+		if tolerates(tol, taint) {
+			return true
+		}
+	}
+	return false
+}
+
+// UntoleratedTaint returns the first of the node's taints with one of the
+// given effects that the service does not tolerate
+func UntoleratedTaint(service *types.Service, node *types.Node, effects ...types.TaintEffect) (types.Taint, bool) {
+	for _, taint := range node.Taints {
+		if hasEffect(taint, effects) && !toleratedBy(service, taint) {
+			return taint, true
+		}
+	}
+	return types.Taint{}, false
+}
+
+// untoleratedCount returns how many of the node's taints with the effect
+// the service does not tolerate
+func untoleratedCount(service *types.Service, node *types.Node, effect types.TaintEffect) int {
+	count := 0
+	for _, taint := range node.Taints {
+		if taint.Effect == effect && !toleratedBy(service, taint) {
+			count++
+		}
+	}
+	return count
+}
+
+// hasEffect reports whether a taint has one of the effects
+func hasEffect(taint types.Taint, effects []types.TaintEffect) bool {
+	for _, effect := range effects {
+		if taint.Effect == effect {
+			return true
+		}
+	}
+	return false
+}
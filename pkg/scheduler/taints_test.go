@@ -0,0 +1,112 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/cuemby/warren/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTaint(t *testing.T) {
+	taint, err := ParseTaint("dedicated=build:NoSchedule")
+	require.NoError(t, err)
+	assert.Equal(t, types.Taint{Key: "dedicated", Value: "build", Effect: types.TaintEffectNoSchedule}, taint)
+	assert.Equal(t, "dedicated=build:NoSchedule", FormatTaint(taint))
+
+	taint, err = ParseTaint("gpu:PreferNoSchedule")
+	require.NoError(t, err)
+	assert.Equal(t, types.Taint{Key: "gpu", Effect: types.TaintEffectPreferNoSchedule}, taint)
+	assert.Equal(t, "gpu:PreferNoSchedule", FormatTaint(taint))
+
+	for _, expr := range []string{"dedicated=build", "dedicated:Never", ":NoExecute"} {
+		_, err := ParseTaint(expr)
+		assert.Error(t, err, expr)
+	}
+
+	taint, err = ParseTaintKey("dedicated")
+	require.NoError(t, err)
+	assert.Equal(t, types.Taint{Key: "dedicated"}, taint)
+	taint, err = ParseTaintKey("dedicated:NoExecute")
+	require.NoError(t, err)
+	assert.Equal(t, types.TaintEffectNoExecute, taint.Effect)
+}
+
+func TestParseToleration(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected types.Toleration
+	}{
+		{"dedicated=build:NoSchedule", types.Toleration{Key: "dedicated", Operator: types.TolerationOpEqual, Value: "build", Effect: types.TaintEffectNoSchedule}},
+		{"dedicated=build", types.Toleration{Key: "dedicated", Operator: types.TolerationOpEqual, Value: "build"}},
+		{"gpu:NoExecute", types.Toleration{Key: "gpu", Operator: types.TolerationOpExists, Effect: types.TaintEffectNoExecute}},
+		{"gpu", types.Toleration{Key: "gpu", Operator: types.TolerationOpExists}},
+		{"*", types.Toleration{Operator: types.TolerationOpExists}},
+		{"*:NoExecute", types.Toleration{Operator: types.TolerationOpExists, Effect: types.TaintEffectNoExecute}},
+	}
+	for _, tt := range tests {
+		tol, err := ParseToleration(tt.expr)
+		require.NoError(t, err, tt.expr)
+		assert.Equal(t, tt.expected, tol, tt.expr)
+		assert.NoError(t, ValidateTolerations([]types.Toleration{tol}))
+	}
+
+	_, err := ParseToleration("gpu:Sometimes")
+	assert.Error(t, err)
+	assert.Error(t, ValidateTolerations([]types.Toleration{{Operator: types.TolerationOpEqual, Value: "x"}}))
+	assert.Error(t, ValidateTolerations([]types.Toleration{{Key: "gpu", Operator: types.TolerationOpExists, Value: "x"}}))
+}
+
+func TestUntoleratedTaint(t *testing.T) {
+	node := &types.Node{ID: "node-1", Taints: []types.Taint{
+		{Key: "dedicated", Value: "build", Effect: types.TaintEffectNoSchedule},
+		{Key: "maintenance", Effect: types.TaintEffectNoExecute},
+	}}
+
+	service := &types.Service{}
+	taint, untolerated := UntoleratedTaint(service, node, types.TaintEffectNoSchedule, types.TaintEffectNoExecute)
+	assert.True(t, untolerated)
+	assert.Equal(t, "dedicated", taint.Key)
+
+	service.Tolerations = []types.Toleration{{Key: "dedicated", Operator: types.TolerationOpEqual, Value: "build"}}
+	taint, untolerated = UntoleratedTaint(service, node, types.TaintEffectNoSchedule, types.TaintEffectNoExecute)
+	assert.True(t, untolerated)
+	assert.Equal(t, "maintenance", taint.Key)
+
+	// A toleration for another effect does not match
+	service.Tolerations = append(service.Tolerations, types.Toleration{Key: "maintenance", Operator: types.TolerationOpExists, Effect: types.TaintEffectNoSchedule})
+	_, untolerated = UntoleratedTaint(service, node, types.TaintEffectNoExecute)
+	assert.True(t, untolerated)
+
+	service.Tolerations = []types.Toleration{{Operator: types.TolerationOpExists}}
+	_, untolerated = UntoleratedTaint(service, node, types.TaintEffectNoSchedule, types.TaintEffectNoExecute)
+	assert.False(t, untolerated, "an empty Exists toleration matches every taint")
+}
+
+func TestSelectNodeForService_Taints(t *testing.T) {
+	nodes := affinityNodes()
+	nodes[0].Taints = []types.Taint{{Key: "node-role", Value: "manager", Effect: types.TaintEffectNoSchedule}}
+	nodes[1].Taints = []types.Taint{{Key: "spot", Effect: types.TaintEffectPreferNoSchedule}}
+	sched := NewScheduler(nil)
+
+	// Untainted node-3 wins
+	web := &types.Service{ID: "web", Name: "web"}
+	decision := sched.selectNodeForService(web, nodes, nil)
+	assert.Equal(t, "node-3", decision.NodeID)
+	assert.Equal(t, PluginTaints, decision.Nodes[0].FilteredBy)
+	assert.Equal(t, "untolerated taint node-role=manager:NoSchedule", decision.Nodes[0].Reason)
+
+	// PreferNoSchedule is only avoided while another node fits
+	decision = sched.selectNodeForService(web, nodes[:2], nil)
+	assert.Equal(t, "node-2", decision.NodeID)
+
+	decision = sched.selectNodeForService(web, nodes[:1], nil)
+	assert.Equal(t, "no suitable node: untolerated taint node-role=manager:NoSchedule on 1 node", decision.Reason)
+
+	// A system service tolerating the manager taint may run there
+	system := &types.Service{ID: "system", Name: "system", Tolerations: []types.Toleration{
+		{Key: "node-role", Operator: types.TolerationOpEqual, Value: "manager"},
+	}}
+	decision = sched.selectNodeForService(system, nodes[:1], nil)
+	assert.Equal(t, "node-1", decision.NodeID)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/cuemby/warren/pkg/types"
@@ -21,6 +22,9 @@ var (
 	bucketCA              = []byte("ca")
 	bucketIngresses       = []byte("ingresses")
 	bucketTLSCertificates = []byte("tls_certificates")
+	bucketJoinTokens      = []byte("join_tokens")
+	bucketDeployments     = []byte("deployments")
+	bucketRevisions       = []byte("revisions")
 )
 
 // BoltStore implements Store interface using BoltDB
@@ -49,6 +53,9 @@ func NewBoltStore(dataDir string) (*BoltStore, error) {
 			bucketCA,
 			bucketIngresses,
 			bucketTLSCertificates,
+			bucketJoinTokens,
+			bucketDeployments,
+			bucketRevisions,
 		}
 
 		for _, bucket := range buckets {
@@ -704,3 +711,163 @@ func (s *BoltStore) DeleteTLSCertificate(id string) error {
 		return b.Delete([]byte(id))
 	})
 }
+
+// --- Join Tokens ---
+
+// CreateJoinToken stores a hashed join token
+func (s *BoltStore) CreateJoinToken(token *types.JoinToken) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketJoinTokens)
+		data, err := json.Marshal(token)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(token.ID), data)
+	})
+}
+
+// GetJoinToken retrieves a join token by ID
+func (s *BoltStore) GetJoinToken(id string) (*types.JoinToken, error) {
+	var token types.JoinToken
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketJoinTokens)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return fmt.Errorf("join token not found: %s", id)
+		}
+		return json.Unmarshal(data, &token)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// ListJoinTokens returns all join tokens
+func (s *BoltStore) ListJoinTokens() ([]*types.JoinToken, error) {
+	var tokens []*types.JoinToken
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketJoinTokens)
+		c := b.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			var token types.JoinToken
+			if err := json.Unmarshal(v, &token); err != nil {
+				return err
+			}
+			tokens = append(tokens, &token)
+		}
+		return nil
+	})
+	return tokens, err
+}
+
+// DeleteJoinToken deletes a join token
+func (s *BoltStore) DeleteJoinToken(id string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketJoinTokens)
+		return b.Delete([]byte(id))
+	})
+}
+
+// --- Deployment Operations ---
+
+// CreateDeployment stores a deployment
+func (s *BoltStore) CreateDeployment(deployment *types.Deployment) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketDeployments)
+		data, err := json.Marshal(deployment)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(deployment.ID), data)
+	})
+}
+
+// GetDeployment retrieves a deployment by ID
+func (s *BoltStore) GetDeployment(id string) (*types.Deployment, error) {
+	var deployment types.Deployment
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketDeployments)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return fmt.Errorf("deployment not found: %s", id)
+		}
+		return json.Unmarshal(data, &deployment)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &deployment, nil
+}
+
+// ListDeployments returns all deployments
+func (s *BoltStore) ListDeployments() ([]*types.Deployment, error) {
+	var deployments []*types.Deployment
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketDeployments)
+		return b.ForEach(func(k, v []byte) error {
+			var deployment types.Deployment
+			if err := json.Unmarshal(v, &deployment); err != nil {
+				return err
+			}
+			deployments = append(deployments, &deployment)
+			return nil
+		})
+	})
+	return deployments, err
+}
+
+// UpdateDeployment updates an existing deployment
+func (s *BoltStore) UpdateDeployment(deployment *types.Deployment) error {
+	return s.CreateDeployment(deployment)
+}
+
+// DeleteDeployment deletes a deployment
+func (s *BoltStore) DeleteDeployment(id string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketDeployments)
+		return b.Delete([]byte(id))
+	})
+}
+
+// --- Service Revision Operations ---
+
+// CreateServiceRevision stores a service revision
+func (s *BoltStore) CreateServiceRevision(revision *types.ServiceRevision) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketRevisions)
+		data, err := json.Marshal(revision)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(revision.ID), data)
+	})
+}
+
+// ListServiceRevisions returns the revisions of a service, oldest first
+func (s *BoltStore) ListServiceRevisions(serviceID string) ([]*types.ServiceRevision, error) {
+	var revisions []*types.ServiceRevision
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketRevisions)
+		return b.ForEach(func(k, v []byte) error {
+			var revision types.ServiceRevision
+			if err := json.Unmarshal(v, &revision); err != nil {
+				return err
+			}
+			if revision.ServiceID == serviceID {
+				revisions = append(revisions, &revision)
+			}
+			return nil
+		})
+	})
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].Revision < revisions[j].Revision })
+	return revisions, err
+}
+
+// DeleteServiceRevision deletes a service revision
+func (s *BoltStore) DeleteServiceRevision(id string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketRevisions)
+		return b.Delete([]byte(id))
+	})
+}
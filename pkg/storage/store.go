@@ -51,6 +51,12 @@ type Store interface {
 	ListNetworks() ([]*types.Network, error)
 	DeleteNetwork(id string) error
 
+	// Join Tokens
+	CreateJoinToken(token *types.JoinToken) error
+	GetJoinToken(id string) (*types.JoinToken, error)
+	ListJoinTokens() ([]*types.JoinToken, error)
+	DeleteJoinToken(id string) error
+
 	// Certificate Authority
 	SaveCA(data []byte) error
 	GetCA() ([]byte, error)
@@ -72,6 +78,18 @@ type Store interface {
 	UpdateTLSCertificate(cert *types.TLSCertificate) error
 	DeleteTLSCertificate(id string) error
 
+	// Deployments
+	CreateDeployment(deployment *types.Deployment) error
+	GetDeployment(id string) (*types.Deployment, error)
+	ListDeployments() ([]*types.Deployment, error)
+	UpdateDeployment(deployment *types.Deployment) error
+	DeleteDeployment(id string) error
+
+	// Service revisions
+	CreateServiceRevision(revision *types.ServiceRevision) error
+	ListServiceRevisions(serviceID string) ([]*types.ServiceRevision, error)
+	DeleteServiceRevision(id string) error
+
 	// Utility
 	Close() error
 }
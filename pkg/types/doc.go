@@ -37,7 +37,8 @@ Cluster Topology:
 
 Service Management:
   - Service: User-defined containerized workload
-  - ServiceMode: Replicated (N replicas) or global (one per node)
+  - ServiceMode: Replicated (N replicas), global (one per node), job (runs to
+    completion) or cronjob (runs a job on a schedule)
   - DeployStrategy: Rolling, blue-green, or canary updates
   - UpdateConfig: Update parallelism, delay, failure actions
 
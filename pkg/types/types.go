@@ -15,6 +15,11 @@ type ServiceManager interface {
 	DeleteService(id string) error
 	ListContainersByService(serviceID string) ([]*Container, error)
 	UpdateContainer(container *Container) error
+	CreateDeployment(deployment *Deployment) error
+	UpdateDeployment(deployment *Deployment) error
+	DeleteDeployment(id string) error
+	GetDeployment(id string) (*Deployment, error)
+	ListDeployments() ([]*Deployment, error)
 }
 
 // Cluster represents the entire Warren cluster
@@ -28,16 +33,20 @@ type Cluster struct {
 
 // Node represents a manager or worker node in the cluster
 type Node struct {
-	ID            string
-	Role          NodeRole
-	Address       string // Host IP address
-	OverlayIP     net.IP // WireGuard overlay IP
-	Hostname      string
-	Labels        map[string]string
-	Resources     *NodeResources
-	Status        NodeStatus
-	LastHeartbeat time.Time
-	CreatedAt     time.Time
+	ID               string
+	Role             NodeRole
+	Address          string // Host IP address
+	OverlayIP        net.IP // WireGuard overlay IP
+	OverlayPublicKey string // WireGuard public key
+	OverlayPort      int    // WireGuard listen port (UDP)
+	ManagerAddr      string // API address of the manager the worker is connected to
+	Hostname         string
+	Labels           map[string]string
+	Taints           []Taint // Repel services that do not tolerate them
+	Resources        *NodeResources
+	Status           NodeStatus
+	LastHeartbeat    time.Time
+	CreatedAt        time.Time
 }
 
 // NodeRole defines the role of a node
@@ -49,6 +58,22 @@ const (
 	NodeRoleHybrid  NodeRole = "hybrid"  // Manager + Worker combined
 )
 
+// Taint marks a node so that only services tolerating it run there
+type Taint struct {
+	Key    string
+	Value  string
+	Effect TaintEffect
+}
+
+// TaintEffect defines what a taint does to services that do not tolerate it
+type TaintEffect string
+
+const (
+	TaintEffectNoSchedule       TaintEffect = "NoSchedule"       // No new containers
+	TaintEffectPreferNoSchedule TaintEffect = "PreferNoSchedule" // New containers avoid the node if they can
+	TaintEffectNoExecute        TaintEffect = "NoExecute"        // No new containers; running ones are evicted
+)
+
 // NodeStatus represents the current state of a node
 type NodeStatus string
 
@@ -74,35 +99,145 @@ type NodeResources struct {
 
 // Service represents a user-defined workload
 type Service struct {
-	ID             string
-	Name           string
-	Image          string
-	Replicas       int
-	Mode           ServiceMode
-	DeployStrategy DeployStrategy
-	UpdateConfig   *UpdateConfig
-	Env            []string
-	Ports          []*PortMapping
-	Networks       []string
-	Secrets        []string
-	Volumes        []*VolumeMount
-	Labels         map[string]string
-	HealthCheck    *HealthCheck
-	RestartPolicy  *RestartPolicy
-	Resources      *ResourceRequirements
-	StopTimeout    int // Seconds to wait before force-killing containers (default: 10)
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
+	ID               string
+	Name             string
+	Image            string
+	Replicas         int
+	SurgeReplicas    int // Extra replicas a rolling update starts ahead of its batches, set by the deployer
+	Mode             ServiceMode
+	DeployStrategy   DeployStrategy
+	UpdateConfig     *UpdateConfig
+	Env              []string
+	Ports            []*PortMapping
+	Networks         []string
+	Secrets          []string
+	Volumes          []*VolumeMount
+	Labels           map[string]string
+	HealthCheck      *HealthCheck
+	RestartPolicy    *RestartPolicy
+	Resources        *ResourceRequirements
+	StopTimeout      int    // Seconds to wait before force-killing containers (default: 10)
+	VIP              net.IP // Virtual IP from the service subnet, load balanced across replicas
+	Placement        *Placement
+	Affinity         *Affinity       // Run replicas on nodes that run matching replicas
+	AntiAffinity     *Affinity       // Keep replicas off nodes that run matching replicas
+	Tolerations      []Toleration    // Node taints the service's replicas may ignore
+	DisruptionBudget int             // Most replicas rebalancing may leave unavailable at once (default: 1)
+	Priority         int             // Higher-priority services preempt lower ones when the cluster is full (default: 0)
+	Job              *JobConfig      // Job and cronjob modes
+	JobStatus        *JobStatus      // Job mode, kept up to date by the job controller
+	CronJob          *CronJobConfig  // Cronjob mode
+	LastScheduleTime time.Time       // Cronjob mode: when the last job was due
+	CanaryAnalysis   *CanaryAnalysis // Last comparison of a canary update with this service
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// Placement restricts and guides which nodes a service's replicas run on
+type Placement struct {
+	// Constraints are hard requirements a node must meet, e.g.
+	// "node.labels.zone==eu-1", "node.role!=manager" or "node.hostname==web-1"
+	Constraints []string
+
+	// Preferences are soft, applied in order to the nodes that meet the constraints
+	Preferences []PlacementPreference
+}
+
+// PlacementPreference spreads replicas evenly across the values of a node label
+type PlacementPreference struct {
+	Spread string // e.g. "node.labels.zone"
+}
+
+// Affinity lists terms matched against the replicas already running on a
+// node. A node matches a term if it runs a replica of a matching service.
+type Affinity struct {
+	Required  []AffinityTerm // Nodes must (or, for anti-affinity, must not) match every term
+	Preferred []AffinityTerm // Nodes are ranked by the weight of the terms they match
 }
 
+// AffinityTerm selects services by name, by labels, or both
+type AffinityTerm struct {
+	Service string            // Service name; a service may name itself
+	Labels  map[string]string // Labels the service must carry
+	Weight  int               // Preferred terms only (default 1)
+}
+
+// Toleration lets a service run on nodes with a matching taint. An empty
+// Effect matches every effect; an empty Key with the Exists operator
+// matches every taint.
+type Toleration struct {
+	Key      string
+	Operator TolerationOperator
+	Value    string // Equal operator only
+	Effect   TaintEffect
+}
+
+// TolerationOperator defines how a toleration matches a taint's value
+type TolerationOperator string
+
+const (
+	TolerationOpEqual  TolerationOperator = "Equal"  // Key and value match (default)
+	TolerationOpExists TolerationOperator = "Exists" // Key matches, any value
+)
+
 // ServiceMode defines how a service is scheduled
 type ServiceMode string
 
 const (
 	ServiceModeReplicated ServiceMode = "replicated" // N replicas
 	ServiceModeGlobal     ServiceMode = "global"     // One per node
+	ServiceModeJob        ServiceMode = "job"        // Containers run to completion
+	ServiceModeCronJob    ServiceMode = "cronjob"    // A job created on a schedule
+)
+
+// JobConfig controls how a job's containers run to completion
+type JobConfig struct {
+	Completions    int           // Containers that must exit successfully (default: 1)
+	Parallelism    int           // Containers running at once (default: 1)
+	BackoffLimit   *int          // Failed containers before the job fails (nil: 6; 0 fails on the first)
+	ActiveDeadline time.Duration // Time from creation after which the job fails (0: none)
+}
+
+// JobState is where a job is in its lifecycle
+type JobState string
+
+const (
+	JobStateRunning  JobState = "running"
+	JobStateComplete JobState = "complete"
+	JobStateFailed   JobState = "failed"
 )
 
+// JobStatus tracks a job's containers by outcome
+type JobStatus struct {
+	State          JobState
+	Active         int
+	Succeeded      int
+	Failed         int
+	StartTime      time.Time
+	CompletionTime time.Time // When the job completed or failed
+	Reason         string    // Why the job failed
+}
+
+// CronJobConfig creates a job from a cronjob service on a schedule
+type CronJobConfig struct {
+	Schedule                   string            // Cron expression in UTC, e.g. "0 2 * * *" or "@daily"
+	ConcurrencyPolicy          ConcurrencyPolicy // What to do when the previous job is still running
+	SuccessfulJobsHistoryLimit *int              // Completed jobs kept (nil: 3)
+	FailedJobsHistoryLimit     *int              // Failed jobs kept (nil: 1)
+}
+
+// ConcurrencyPolicy decides whether a cronjob's jobs may overlap
+type ConcurrencyPolicy string
+
+const (
+	ConcurrencyAllow   ConcurrencyPolicy = "Allow"   // Start the new job alongside (default)
+	ConcurrencyForbid  ConcurrencyPolicy = "Forbid"  // Skip the run
+	ConcurrencyReplace ConcurrencyPolicy = "Replace" // Stop the running job, then start the new one
+)
+
+// LabelCronJob names the cronjob that created a job
+const LabelCronJob = "warren.cronjob"
+
 // DeployStrategy defines how updates are deployed
 type DeployStrategy string
 
@@ -132,12 +267,71 @@ const (
 	LabelOriginalService    = "warren.deployment.original-service"
 )
 
+// Deployment is a service update in progress or finished. It is replicated
+// through Raft, so the leader can carry on with it after a failover.
+type Deployment struct {
+	ID             string
+	ServiceID      string
+	ServiceName    string
+	Strategy       DeployStrategy
+	Image          string // Image being rolled out
+	PreviousImage  string
+	PreviousSpec   *Service // Spec of the service before the deployment, restored by a rolling rollback
+	ReplaceAll     bool     // Rolling: also replace containers already running Image, e.g. after an env change
+	Phase          DeploymentPhase
+	Step           DeploymentStep // Current step of the strategy
+	StepIndex      int            // Rolling: batches done; canary: index into the canary steps
+	StepStartedAt  time.Time      // Waits in a step are measured from here
+	CloneServiceID string         // Blue-green: green service; canary: canary service
+	Failures       int            // Rolling: new containers seen failing so far
+	Message        string         // Why the deployment failed, was aborted or paused, or completed despite failures
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	CompletedAt    time.Time
+}
+
+// DeploymentPhase is where a deployment is in its lifecycle
+type DeploymentPhase string
+
+const (
+	DeploymentPhaseRunning   DeploymentPhase = "running"
+	DeploymentPhasePaused    DeploymentPhase = "paused"
+	DeploymentPhaseCompleted DeploymentPhase = "completed"
+	DeploymentPhaseFailed    DeploymentPhase = "failed"  // Rolled back after a failure
+	DeploymentPhaseAborted   DeploymentPhase = "aborted" // Rolled back by an operator
+)
+
+// DeploymentStep is a step of a deployment strategy
+type DeploymentStep string
+
+const (
+	DeploymentStepUpdateImage   DeploymentStep = "update-image"   // Rolling: set the new image on the service
+	DeploymentStepReplaceBatch  DeploymentStep = "replace-batch"  // Rolling: replace the next batch of containers
+	DeploymentStepCreateClone   DeploymentStep = "create-clone"   // Create the green or canary service
+	DeploymentStepScaleCanary   DeploymentStep = "scale-canary"   // Canary: scale to the step's weight
+	DeploymentStepWaitHealthy   DeploymentStep = "wait-healthy"   // Wait for the green or canary containers
+	DeploymentStepStabilize     DeploymentStep = "stabilize"      // Canary: stability window, then analysis
+	DeploymentStepSwitchTraffic DeploymentStep = "switch-traffic" // Promote the green or canary service
+)
+
+// ServiceRevision is a recorded version of a service's spec. Revisions are
+// replicated through Raft and numbered from 1 per service; rolling back to
+// one restores its spec as a new revision.
+type ServiceRevision struct {
+	ID          string
+	ServiceID   string
+	Revision    int
+	Spec        *Service // Image, env, resources, health check and other settings; no status
+	ChangeCause string   // Why the spec changed, e.g. "image updated to nginx:1.22"
+	CreatedAt   time.Time
+}
+
 // UpdateConfig controls how service updates are performed
 type UpdateConfig struct {
 	Parallelism             int           // How many containers to update simultaneously
 	Delay                   time.Duration // Delay between batches
 	FailureAction           string        // "pause", "rollback", "continue"
-	MaxSurge                int           // Max extra containers during update (default: 1)
+	MaxSurge                int           // Max extra containers during update (default: 1, or 0 when MaxUnavailable is set)
 	MaxUnavailable          int           // Max containers that can be unavailable (default: 0)
 	HealthCheckGracePeriod  time.Duration // Wait time for health checks (default: 30s)
 	CanaryWeight            int           // 0-100 (current canary traffic weight)
@@ -145,7 +339,44 @@ type UpdateConfig struct {
 	CanaryStabilityWindow   time.Duration // Wait time between canary steps (default: 5m)
 	BlueGreenGracePeriod    time.Duration // Time to keep blue version after switch (default: 5m)
 	AutoRollbackEnabled     bool          // Enable automatic rollback on failures
-	FailureThresholdPercent int           // Error rate % to trigger rollback (default: 10)
+	FailureThresholdPercent int           // Canary error rate % over stable to trigger rollback (default: 10)
+	P99ThresholdPercent     int           // Canary p99 latency % over stable to trigger rollback (default: 50)
+}
+
+// What a rolling update does when its new containers fail (UpdateConfig.FailureAction)
+const (
+	FailureActionPause    = "pause"    // Pause the deployment for an operator to resume or abort
+	FailureActionRollback = "rollback" // Roll back to the previous version (default)
+	FailureActionContinue = "continue" // Carry on replacing containers
+)
+
+// TrafficSummary is the ingress traffic a service served over a period
+type TrafficSummary struct {
+	Requests int
+	Errors   int           // Requests answered with a 5xx status
+	P99      time.Duration // 99th percentile latency
+}
+
+// CanaryAnalysisResult is the verdict of a canary analysis
+type CanaryAnalysisResult string
+
+const (
+	CanaryAnalysisPassed       CanaryAnalysisResult = "passed"
+	CanaryAnalysisFailed       CanaryAnalysisResult = "failed"
+	CanaryAnalysisInconclusive CanaryAnalysisResult = "inconclusive" // Too little canary traffic to judge
+)
+
+// CanaryAnalysis compares the traffic a canary and its stable service
+// served during one step of a canary update
+type CanaryAnalysis struct {
+	Result     CanaryAnalysisResult
+	Reason     string
+	Image      string // Image of the canary
+	Weight     int    // Canary weight during the step
+	Canary     TrafficSummary
+	Stable     TrafficSummary
+	RolledBack bool // Whether the failed analysis rolled the update back
+	AnalyzedAt time.Time
 }
 
 // PortMapping defines port exposure
@@ -254,6 +485,7 @@ type Container struct {
 	FinishedAt    time.Time
 	ExitCode      int
 	Error         string
+	PreemptedBy   string // Service whose higher-priority container evicted this one
 }
 
 // ContainerState represents the state of a container
@@ -276,6 +508,17 @@ type Secret struct {
 	UpdatedAt time.Time
 }
 
+// JoinToken represents a token that admits a node into the cluster.
+// Only the SHA-256 hash of the token is stored; the plaintext is shown once
+// when the token is generated.
+type JoinToken struct {
+	ID        string // First characters of the hash, used to list and revoke
+	Hash      string // Hex-encoded SHA-256 of the token
+	Role      string // "manager" or "worker"
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
 // Volume represents persistent storage
 type Volume struct {
 	ID        string
@@ -301,6 +544,7 @@ type NetworkConfig struct {
 	ClusterSubnet string            // Overall cluster subnet (e.g., "10.0.0.0/16")
 	ServiceSubnet string            // Subnet for service VIPs (e.g., "10.0.1.0/24")
 	NodeIPs       map[string]net.IP // Node ID -> Overlay IP mapping
+	ServiceVIPs   map[string]net.IP // Service ID -> Virtual IP mapping
 }
 
 // Event represents a cluster event (for streaming API)
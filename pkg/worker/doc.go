@@ -16,7 +16,7 @@ A Warren worker is a single-purpose agent that bridges managers and containers:
 	│  │              Worker Agent                     │          │
 	│  │  - gRPC client to manager                     │          │
 	│  │  - Heartbeat loop (5s)                        │          │
-	│  │  - Task sync loop (3s)                        │          │
+	│  │  - Task watch stream (push, 3s poll fallback) │          │
 	│  │  - Status reporting                           │          │
 	│  └──────┬──────────────────────────┬─────────────┘          │
 	│         │                          │                         │
@@ -93,13 +93,13 @@ Heartbeat Loop (5 seconds):
  3. Receive acknowledgment
  4. Update last heartbeat timestamp
 
-Task Sync Loop (3 seconds):
+Task Watch Stream:
 
- 1. Fetch assigned tasks from manager
- 2. Compare with local task cache
- 3. Start new tasks
- 4. Stop removed tasks
- 5. Report task status updates
+ 1. Resync: fetch all assigned tasks with ListContainers
+ 2. Open the WatchContainers stream for this node
+ 3. Start tasks on "add" events
+ 4. Stop tasks on "update" (desired state shutdown) and "delete" events
+ 5. If the stream fails, poll every 3 seconds until it is re-established
 
 Task Execution:
 
@@ -302,8 +302,9 @@ Host Mode (PublishModeHost):
 
 Ingress Mode (PublishModeIngress):
 
-  - Future: Routing mesh (not yet implemented)
-  - Will route to any task replica
+  - Routing mesh: the port is published on every node
+  - Traffic to any node is forwarded to a healthy replica anywhere in the
+    cluster (see Service VIPs)
   - Load balancing across tasks
 
 Port Conflicts:
@@ -312,6 +313,45 @@ Port Conflicts:
   - Reports error to manager
   - Scheduler avoids conflicting placements
 
+# Log Streaming
+
+Container output is captured to rotated log files by the runtime (see
+pkg/runtime). Workers cannot be dialed by the manager, so logs are served
+over worker-initiated streams:
+
+ 1. The worker keeps a WatchLogRequests stream open to its manager
+ 2. For `warren service logs`, the manager sends one LogRequest per replica
+    to the workers hosting them
+ 3. The worker answers each request on its own PushContainerLogs stream
+ 4. The manager interleaves the replicas and labels them (web.1, web.2, ...)
+
+Followed requests run until the manager sends a cancel for the request.
+
+The manager that accepts RegisterNode records its advertised API address on
+the node (the address the worker dialled may be loopback, as for the
+embedded worker of a hybrid manager). A manager asked for the logs of
+replicas whose workers are connected to another manager forwards the
+request to it, so logs can be read through any manager.
+
+# Overlay Network
+
+On start the worker loads its WireGuard key (DataDir/wireguard/private.key),
+sends the public key with RegisterNode and brings up warren0 with the overlay
+IP the manager allocated. It then watches node events and resyncs peers from
+ListNodes, so peers are added and removed as nodes join and leave (see
+pkg/network). If WireGuard is unavailable the worker logs a warning and runs
+without the overlay.
+
+# Service VIPs
+
+The worker programs iptables so each service VIP and ingress published port
+load balances across the service's healthy replicas. It fetches the endpoints of
+the service a service or task event names with ListServiceEndpoints, and of
+every service on node events and every 30s. Replicas on this node are reached
+on their container IP and also behind the port's forward port on this node's
+overlay IP; replicas on other nodes at their node's forward port. The rules
+are applied through network.ServiceProxy and removed on Stop.
+
 # Failure Scenarios
 
 Manager Disconnection:
@@ -352,7 +392,7 @@ Resource Usage:
 Loop Frequencies:
 
   - Heartbeat: Every 5 seconds
-  - Task sync: Every 3 seconds
+  - Task sync: Pushed by manager (3 second polling while stream is down)
   - Health checks: Per service config (30s typical)
 
 Task Operations:
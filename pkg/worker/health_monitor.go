@@ -3,6 +3,7 @@ package worker
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/cuemby/warren/api/proto"
@@ -202,10 +203,8 @@ func (hm *HealthMonitor) reportHealth(monitor *containerHealthMonitor) error {
 func (hm *HealthMonitor) createChecker(task *types.Container) (health.Checker, error) {
 	switch task.HealthCheck.Type {
 	case types.HealthCheckHTTP:
-		// Parse endpoint to get URL
-		// For now, construct URL from container IP and endpoint
-		url := fmt.Sprintf("http://localhost%s", task.HealthCheck.Endpoint)
-		return health.NewHTTPChecker(url), nil
+		// Endpoint is "scheme://:port/path" (or just a path); target localhost
+		return health.NewHTTPChecker(httpCheckURL(task.HealthCheck.Endpoint)), nil
 
 	case types.HealthCheckTCP:
 		// Parse endpoint to get address
@@ -213,10 +212,29 @@ func (hm *HealthMonitor) createChecker(task *types.Container) (health.Checker, e
 		return health.NewTCPChecker(address), nil
 
 	case types.HealthCheckExec:
-		// Create exec checker with command and container ID
-		return health.NewExecChecker(task.HealthCheck.Command).WithContainer(task.ContainerID), nil
+		// Run the command inside the container through containerd
+		checker := health.NewExecChecker(task.HealthCheck.Command).
+			WithContainer(task.ContainerID).
+			WithExecutor(hm.worker.runtime)
+		if task.HealthCheck.Timeout > 0 {
+			checker.WithTimeout(task.HealthCheck.Timeout)
+		}
+		return checker, nil
 
 	default:
 		return nil, fmt.Errorf("unsupported health check type: %s", task.HealthCheck.Type)
 	}
 }
+
+// httpCheckURL builds the URL of an HTTP health check endpoint, filling in
+// localhost when the endpoint has no host
+func httpCheckURL(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == "" {
+		return fmt.Sprintf("http://localhost%s", endpoint)
+	}
+	if u.Hostname() == "" {
+		u.Host = "localhost" + u.Host
+	}
+	return u.String()
+}
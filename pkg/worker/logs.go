@@ -0,0 +1,140 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cuemby/warren/api/proto"
+	"github.com/cuemby/warren/pkg/runtime"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// logRequestLoop keeps a log request stream open to the manager, answering
+// each request by pushing the container's captured output back
+func (w *Worker) logRequestLoop() {
+	for {
+		err := w.watchLogRequests()
+
+		select {
+		case <-w.stopCh:
+			return
+		default:
+		}
+
+		if err != nil {
+			fmt.Printf("Log request watch error: %v (reconnecting)\n", err)
+		}
+
+		select {
+		case <-time.After(containerPollInterval):
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+// watchLogRequests consumes log requests until the stream ends
+func (w *Worker) watchLogRequests() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-w.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	stream, err := w.client.WatchLogRequests(ctx, &proto.WatchLogRequestsRequest{
+		NodeId: w.nodeID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to watch log requests: %w", err)
+	}
+
+	// Followed requests run until the manager cancels them
+	var mu sync.Mutex
+	active := make(map[string]context.CancelFunc)
+
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		if req.Cancel {
+			if cancelReq, ok := active[req.RequestId]; ok {
+				cancelReq()
+				delete(active, req.RequestId)
+			}
+			mu.Unlock()
+			continue
+		}
+		reqCtx, cancelReq := context.WithCancel(ctx)
+		active[req.RequestId] = cancelReq
+		mu.Unlock()
+
+		go func(req *proto.LogRequest) {
+			defer func() {
+				mu.Lock()
+				if cancelReq, ok := active[req.RequestId]; ok {
+					cancelReq()
+					delete(active, req.RequestId)
+				}
+				mu.Unlock()
+			}()
+			w.serveLogRequest(reqCtx, req)
+		}(req)
+	}
+}
+
+// serveLogRequest streams the requested container output to the manager.
+// A followed request ends when ctx is cancelled.
+func (w *Worker) serveLogRequest(ctx context.Context, req *proto.LogRequest) {
+	push, err := w.client.PushContainerLogs(ctx)
+	if err != nil {
+		fmt.Printf("Failed to open log push stream for %s: %v\n", req.ContainerId, err)
+		return
+	}
+
+	opts := runtime.LogOptions{
+		Tail:   int(req.Tail),
+		Follow: req.Follow,
+	}
+	if req.Since != nil {
+		opts.Since = req.Since.AsTime()
+	}
+
+	send := func(entry runtime.LogEntry) error {
+		return push.Send(&proto.LogEntry{
+			RequestId:   req.RequestId,
+			ContainerId: req.ContainerId,
+			NodeId:      w.nodeID,
+			Timestamp:   timestamppb.New(entry.Timestamp),
+			Stream:      entry.Stream,
+			Line:        entry.Line,
+		})
+	}
+
+	// The first message identifies the request even if there is no output yet
+	if err := push.Send(&proto.LogEntry{RequestId: req.RequestId, ContainerId: req.ContainerId, NodeId: w.nodeID}); err != nil {
+		return
+	}
+
+	if err := w.runtime.ReadContainerLogs(ctx, req.ContainerId, opts, send); err != nil {
+		_ = push.Send(&proto.LogEntry{
+			RequestId:   req.RequestId,
+			ContainerId: req.ContainerId,
+			NodeId:      w.nodeID,
+			Timestamp:   timestamppb.Now(),
+			Stream:      "warren",
+			Line:        fmt.Sprintf("failed to read logs: %v", err),
+		})
+	}
+
+	_, _ = push.CloseAndRecv()
+}
@@ -0,0 +1,116 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/cuemby/warren/api/proto"
+	"github.com/cuemby/warren/pkg/events"
+	"github.com/cuemby/warren/pkg/types"
+)
+
+// overlayResyncInterval is how often peers are resynced even without node
+// events, and how long the worker waits before reconnecting the event stream
+const overlayResyncInterval = 30 * time.Second
+
+// overlayLoop keeps WireGuard peers in sync with the cluster's nodes.
+// Peers are resynced whenever a node joins, leaves or goes down, and
+// periodically in case an event was missed.
+func (w *Worker) overlayLoop() {
+	for {
+		if err := w.syncOverlayPeers(); err != nil {
+			fmt.Printf("Overlay peer sync error: %v\n", err)
+		}
+
+		err := w.watchNodeEvents()
+
+		select {
+		case <-w.stopCh:
+			return
+		default:
+		}
+
+		if err != nil {
+			fmt.Printf("Node event watch error: %v (reconnecting)\n", err)
+		}
+
+		select {
+		case <-time.After(containerPollInterval):
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+// watchNodeEvents resyncs peers on every node event until the stream ends.
+// It returns nil after overlayResyncInterval so the caller resyncs.
+func (w *Worker) watchNodeEvents() error {
+	ctx, cancel := context.WithTimeout(context.Background(), overlayResyncInterval)
+	defer cancel()
+
+	go func() {
+		select {
+		case <-w.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	stream, err := w.client.StreamEvents(ctx, &proto.StreamEventsRequest{
+		EventTypes: []string{
+			string(events.EventNodeJoined),
+			string(events.EventNodeLeft),
+			string(events.EventNodeDown),
+		},
+		Follow: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to watch node events: %w", err)
+	}
+
+	for {
+		if _, err := stream.Recv(); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		if err := w.syncOverlayPeers(); err != nil {
+			fmt.Printf("Overlay peer sync error: %v\n", err)
+		}
+	}
+}
+
+// syncOverlayPeers reconfigures WireGuard peers from the manager's node list
+func (w *Worker) syncOverlayPeers() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := w.client.ListNodes(ctx, &proto.ListNodesRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	nodes := make([]*types.Node, 0, len(resp.Nodes))
+	for _, n := range resp.Nodes {
+		nodes = append(nodes, &types.Node{
+			ID:               n.Id,
+			Address:          n.Address,
+			OverlayIP:        net.ParseIP(n.OverlayIp),
+			OverlayPublicKey: n.OverlayPublicKey,
+			OverlayPort:      int(n.OverlayPort),
+		})
+	}
+
+	changed, err := w.overlay.SyncPeers(nodes)
+	if err != nil {
+		return err
+	}
+	if changed {
+		fmt.Printf("Overlay peers updated: %d peers\n", len(w.overlay.Peers()))
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/cuemby/warren/api/proto"
+	"github.com/cuemby/warren/pkg/events"
+	"github.com/cuemby/warren/pkg/network"
+)
+
+// serviceVIPEvents are the cluster events that can change a service's endpoints
+var serviceVIPEvents = []events.EventType{
+	events.EventServiceCreated,
+	events.EventServiceUpdated,
+	events.EventServiceDeleted,
+	events.EventTaskStarted,
+	events.EventTaskUpdated,
+	events.EventTaskHealthChanged,
+	events.EventTaskFailed,
+	events.EventTaskCompleted,
+	events.EventTaskDeleted,
+	events.EventNodeJoined,
+	events.EventNodeLeft,
+	events.EventNodeDown,
+}
+
+// serviceVIPLoop keeps the kernel load balancing rules for service VIPs and
+// ingress published ports in sync with the replicas of each service. A
+// service is refreshed whenever an event names it, every service when a node
+// changes, and all of them periodically in case an event was missed.
+func (w *Worker) serviceVIPLoop() {
+	for {
+		if err := w.syncServiceVIPs(); err != nil {
+			fmt.Printf("Service VIP sync error: %v\n", err)
+		}
+
+		err := w.watchServiceEvents()
+
+		select {
+		case <-w.stopCh:
+			return
+		default:
+		}
+
+		if err != nil {
+			fmt.Printf("Service event watch error: %v (reconnecting)\n", err)
+		}
+
+		select {
+		case <-time.After(containerPollInterval):
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+// watchServiceEvents refreshes the services named by each relevant event,
+// or every service on node events, until the stream ends. It returns nil
+// after overlayResyncInterval so the caller resyncs.
+func (w *Worker) watchServiceEvents() error {
+	ctx, cancel := context.WithTimeout(context.Background(), overlayResyncInterval)
+	defer cancel()
+
+	go func() {
+		select {
+		case <-w.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	eventTypes := make([]string, 0, len(serviceVIPEvents))
+	for _, t := range serviceVIPEvents {
+		eventTypes = append(eventTypes, string(t))
+	}
+
+	stream, err := w.client.StreamEvents(ctx, &proto.StreamEventsRequest{
+		EventTypes: eventTypes,
+		Follow:     true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to watch service events: %w", err)
+	}
+
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		name := event.Metadata["service_name"]
+		switch events.EventType(event.Type) {
+		case events.EventNodeJoined, events.EventNodeLeft, events.EventNodeDown:
+			// Node addresses and availability affect every service
+			name = ""
+		}
+		if name == "" {
+			err = w.syncServiceVIPs()
+		} else {
+			err = w.refreshServiceVIPs(name)
+		}
+		if err != nil {
+			fmt.Printf("Service VIP sync error: %v\n", err)
+		}
+	}
+}
+
+// syncServiceVIPs reprograms service VIP rules from the manager's endpoint
+// list of every service
+func (w *Worker) syncServiceVIPs() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := w.client.ListServiceEndpoints(ctx, &proto.ListServiceEndpointsRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list service endpoints: %w", err)
+	}
+
+	w.serviceVIPs = make(map[string]network.ServiceVIP, len(resp.Services))
+	for _, svc := range resp.Services {
+		w.serviceVIPs[svc.ServiceName] = serviceVIP(ctx, svc, w.nodeID, w.containerIP)
+	}
+	return w.applyServiceVIPs(resp.ServiceSubnet)
+}
+
+// refreshServiceVIPs reprograms service VIP rules after fetching the
+// endpoints of the named services only. A service missing from the
+// response was deleted or no longer has a VIP.
+func (w *Worker) refreshServiceVIPs(names ...string) error {
+	if w.serviceVIPs == nil {
+		return w.syncServiceVIPs()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := w.client.ListServiceEndpoints(ctx, &proto.ListServiceEndpointsRequest{ServiceNames: names})
+	if err != nil {
+		return fmt.Errorf("failed to list service endpoints: %w", err)
+	}
+
+	for _, name := range names {
+		delete(w.serviceVIPs, name)
+	}
+	for _, svc := range resp.Services {
+		w.serviceVIPs[svc.ServiceName] = serviceVIP(ctx, svc, w.nodeID, w.containerIP)
+	}
+	return w.applyServiceVIPs(resp.ServiceSubnet)
+}
+
+// applyServiceVIPs programs the known services into the service proxy
+func (w *Worker) applyServiceVIPs(serviceSubnet string) error {
+	services := make([]network.ServiceVIP, 0, len(w.serviceVIPs))
+	for _, sv := range w.serviceVIPs {
+		services = append(services, sv)
+	}
+
+	var nodeIP net.IP
+	if w.overlayUp {
+		if addr := w.overlay.Address(); addr != nil {
+			nodeIP = addr.IP
+		}
+	}
+
+	changed, err := w.serviceProxy.Sync(serviceSubnet, nodeIP, services)
+	if err != nil {
+		return err
+	}
+	if changed {
+		fmt.Printf("Service VIP rules updated: %d services\n", len(services))
+	}
+	return nil
+}
+
+// containerIP returns the IP of a container running on this node
+func (w *Worker) containerIP(ctx context.Context, containerID string) (string, error) {
+	if w.runtime == nil {
+		return "", fmt.Errorf("no container runtime")
+	}
+	return w.runtime.GetContainerIP(ctx, containerID)
+}
+
+// serviceVIP converts a service's endpoints into proxy rules for the node
+// nodeID. Replicas on this node are reached directly on their container IP
+// and are also the targets of the port's forward port; others at the
+// address the manager resolved for them, which is their node's forward port.
+// A local replica whose IP cannot be found is left out rather than sent to
+// this node's own forward port.
+func serviceVIP(ctx context.Context, svc *proto.ServiceEndpoints, nodeID string, containerIP func(context.Context, string) (string, error)) network.ServiceVIP {
+	sv := network.ServiceVIP{Name: svc.ServiceName, VIP: net.ParseIP(svc.Vip)}
+	for _, port := range svc.Ports {
+		vp := network.ServiceVIPPort{
+			Protocol:      port.Protocol,
+			Port:          int(port.Port),
+			PublishedPort: int(port.PublishedPort),
+			ForwardPort:   int(port.ForwardPort),
+			Backends:      make([]string, 0, len(port.Endpoints)),
+		}
+		for _, ep := range port.Endpoints {
+			if ep.NodeId != nodeID {
+				vp.Backends = append(vp.Backends, ep.Address)
+				continue
+			}
+
+			ip, err := containerIP(ctx, ep.ContainerId)
+			if err != nil {
+				fmt.Printf("Skipping service %s backend %s: %v\n", svc.ServiceName, ep.ContainerId, err)
+				continue
+			}
+			addr := net.JoinHostPort(ip, strconv.Itoa(int(port.Port)))
+			vp.Backends = append(vp.Backends, addr)
+			vp.Local = append(vp.Local, addr)
+		}
+		sv.Ports = append(sv.Ports, vp)
+	}
+	return sv
+}
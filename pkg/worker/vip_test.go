@@ -0,0 +1,171 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cuemby/warren/api/proto"
+	"github.com/cuemby/warren/pkg/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// TestServiceVIPTwoNodes tests the rules each of two nodes gets for a
+// service with a replica on both: its own replica by container IP, and the
+// other node's replica at that node's forward port
+func TestServiceVIPTwoNodes(t *testing.T) {
+	svc := &proto.ServiceEndpoints{
+		ServiceName: "web",
+		Vip:         "10.0.1.2",
+		Ports: []*proto.ServicePortEndpoints{{
+			Protocol:      "tcp",
+			Port:          80,
+			PublishedPort: 8080,
+			ForwardPort:   63064,
+			Endpoints: []*proto.ServiceEndpoint{
+				{NodeId: "node-a", ContainerId: "web-a", Address: "10.0.0.2:63064"},
+				{NodeId: "node-b", ContainerId: "web-b", Address: "10.0.0.3:63064"},
+			},
+		}},
+	}
+
+	// Each node only knows the IPs of its own containers
+	containerIPs := map[string]map[string]string{
+		"node-a": {"web-a": "10.88.0.5"},
+		"node-b": {"web-b": "10.88.0.7"},
+	}
+
+	tests := []struct {
+		nodeID   string
+		backends []string
+		local    []string
+	}{
+		{nodeID: "node-a", backends: []string{"10.88.0.5:80", "10.0.0.3:63064"}, local: []string{"10.88.0.5:80"}},
+		{nodeID: "node-b", backends: []string{"10.0.0.2:63064", "10.88.0.7:80"}, local: []string{"10.88.0.7:80"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.nodeID, func(t *testing.T) {
+			containerIP := func(ctx context.Context, id string) (string, error) {
+				if ip, ok := containerIPs[tt.nodeID][id]; ok {
+					return ip, nil
+				}
+				return "", fmt.Errorf("container %s not found", id)
+			}
+
+			sv := serviceVIP(context.Background(), svc, tt.nodeID, containerIP)
+			assert.Equal(t, network.ServiceVIPPort{
+				Protocol:      "tcp",
+				Port:          80,
+				PublishedPort: 8080,
+				ForwardPort:   63064,
+				Backends:      tt.backends,
+				Local:         tt.local,
+			}, sv.Ports[0])
+		})
+	}
+}
+
+// TestServiceVIPSkipsUnknownLocalReplica tests that a local replica whose IP
+// cannot be found is not sent to this node's own forward port
+func TestServiceVIPSkipsUnknownLocalReplica(t *testing.T) {
+	svc := &proto.ServiceEndpoints{
+		ServiceName: "web",
+		Vip:         "10.0.1.2",
+		Ports: []*proto.ServicePortEndpoints{{
+			Protocol:    "tcp",
+			Port:        80,
+			ForwardPort: 63064,
+			Endpoints: []*proto.ServiceEndpoint{
+				{NodeId: "node-a", ContainerId: "web-a", Address: "10.0.0.2:63064"},
+			},
+		}},
+	}
+	containerIP := func(ctx context.Context, id string) (string, error) {
+		return "", fmt.Errorf("container is not running")
+	}
+
+	sv := serviceVIP(context.Background(), svc, "node-a", containerIP)
+	assert.Empty(t, sv.Ports[0].Backends)
+	assert.Empty(t, sv.Ports[0].Local)
+}
+
+// fakeEndpointsClient answers ListServiceEndpoints from a fixed set of
+// services, recording each request
+type fakeEndpointsClient struct {
+	proto.WarrenAPIClient
+	services []*proto.ServiceEndpoints
+	requests [][]string
+}
+
+func (f *fakeEndpointsClient) ListServiceEndpoints(ctx context.Context, req *proto.ListServiceEndpointsRequest, opts ...grpc.CallOption) (*proto.ListServiceEndpointsResponse, error) {
+	f.requests = append(f.requests, req.ServiceNames)
+	resp := &proto.ListServiceEndpointsResponse{ServiceSubnet: "10.0.1.0/24"}
+	for _, svc := range f.services {
+		for _, name := range req.ServiceNames {
+			if name == svc.ServiceName {
+				resp.Services = append(resp.Services, svc)
+			}
+		}
+		if len(req.ServiceNames) == 0 {
+			resp.Services = append(resp.Services, svc)
+		}
+	}
+	return resp, nil
+}
+
+// fakeIPTables keeps the last rules restored
+type fakeIPTables struct {
+	rules string
+}
+
+func (f *fakeIPTables) EnsureJump(table, chain, target string) error { return nil }
+func (f *fakeIPTables) DeleteJump(table, chain, target string) error { return nil }
+func (f *fakeIPTables) DeleteChain(table, chain string) error        { return nil }
+func (f *fakeIPTables) Restore(rules string) error {
+	f.rules = rules
+	return nil
+}
+
+func remoteService(name, vip, address string) *proto.ServiceEndpoints {
+	return &proto.ServiceEndpoints{
+		ServiceName: name,
+		Vip:         vip,
+		Ports: []*proto.ServicePortEndpoints{{
+			Protocol:  "tcp",
+			Port:      80,
+			Endpoints: []*proto.ServiceEndpoint{{NodeId: "node-b", ContainerId: name + "-b", Address: address}},
+		}},
+	}
+}
+
+// TestRefreshServiceVIPs tests that an event refreshes only the service it
+// names, keeping the rules of every other service
+func TestRefreshServiceVIPs(t *testing.T) {
+	client := &fakeEndpointsClient{services: []*proto.ServiceEndpoints{
+		remoteService("api", "10.0.1.3", "10.0.0.3:63072"),
+		remoteService("web", "10.0.1.2", "10.0.0.3:63064"),
+	}}
+	ipt := &fakeIPTables{}
+	w := &Worker{nodeID: "node-a", client: client, serviceProxy: network.NewServiceProxy(ipt)}
+
+	require.NoError(t, w.syncServiceVIPs())
+	assert.Contains(t, ipt.rules, "10.0.0.3:63072")
+	assert.Contains(t, ipt.rules, "10.0.0.3:63064")
+
+	// web moved to another node
+	client.services[1] = remoteService("web", "10.0.1.2", "10.0.0.4:63064")
+	require.NoError(t, w.refreshServiceVIPs("web"))
+	assert.Equal(t, []string{"web"}, client.requests[1])
+	assert.Contains(t, ipt.rules, "10.0.0.3:63072", "other services keep their rules")
+	assert.Contains(t, ipt.rules, "10.0.0.4:63064")
+	assert.NotContains(t, ipt.rules, "10.0.0.3:63064")
+
+	// web deleted
+	client.services = client.services[:1]
+	require.NoError(t, w.refreshServiceVIPs("web"))
+	assert.NotContains(t, ipt.rules, "web:80/tcp")
+	assert.Contains(t, ipt.rules, "api:80/tcp")
+}
@@ -5,13 +5,16 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"net"
 	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
 	specs "github.com/opencontainers/runtime-spec/specs-go"
 
 	"github.com/cuemby/warren/api/proto"
+	"github.com/cuemby/warren/pkg/convert"
 	"github.com/cuemby/warren/pkg/network"
 	"github.com/cuemby/warren/pkg/runtime"
 	"github.com/cuemby/warren/pkg/security"
@@ -22,9 +25,10 @@ import (
 
 // Worker represents a Warren worker node
 type Worker struct {
-	nodeID      string
-	managerAddr string
-	dataDir     string
+	nodeID        string
+	managerAddr   string
+	dataDir       string
+	advertiseAddr string
 
 	client         proto.WarrenAPIClient
 	conn           *grpc.ClientConn
@@ -34,6 +38,10 @@ type Worker struct {
 	healthMonitor  *HealthMonitor
 	dnsHandler     *DNSHandler
 	portPublisher  *network.HostPortPublisher
+	overlay        *network.Overlay
+	overlayUp      bool
+	serviceProxy   *network.ServiceProxy
+	serviceVIPs    map[string]network.ServiceVIP // Service name -> rules; owned by serviceVIPLoop
 
 	containers   map[string]*types.Container
 	containersMu sync.RWMutex
@@ -50,6 +58,7 @@ type Config struct {
 	EncryptionKey    []byte // Cluster-wide encryption key for secrets
 	ContainerdSocket string // Containerd socket path (empty = auto-detect)
 	JoinToken        string // Join token for initial authentication
+	AdvertiseAddr    string // Address other nodes reach this node at (empty = as seen by the manager)
 }
 
 // NewWorker creates a new worker instance
@@ -59,14 +68,18 @@ func NewWorker(cfg *Config) (*Worker, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize containerd runtime: %w", err)
 	}
+	if cfg.DataDir != "" {
+		rt.SetLogConfig(runtime.LogConfig{Dir: filepath.Join(cfg.DataDir, "logs")})
+	}
 
 	w := &Worker{
-		nodeID:      cfg.NodeID,
-		managerAddr: cfg.ManagerAddr,
-		dataDir:     cfg.DataDir,
-		runtime:     rt,
-		containers:  make(map[string]*types.Container),
-		stopCh:      make(chan struct{}),
+		nodeID:        cfg.NodeID,
+		managerAddr:   cfg.ManagerAddr,
+		dataDir:       cfg.DataDir,
+		advertiseAddr: cfg.AdvertiseAddr,
+		runtime:       rt,
+		containers:    make(map[string]*types.Container),
+		stopCh:        make(chan struct{}),
 	}
 
 	// Initialize secrets handler if encryption key provided
@@ -104,6 +117,20 @@ func NewWorker(cfg *Config) (*Worker, error) {
 	// Initialize port publisher for host mode port publishing
 	w.portPublisher = network.NewHostPortPublisher()
 
+	// Initialize WireGuard overlay (keypair persists in the data directory)
+	overlayCfg := network.OverlayConfig{NodeID: cfg.NodeID}
+	if cfg.DataDir != "" {
+		overlayCfg.KeyFile = filepath.Join(cfg.DataDir, "wireguard", "private.key")
+	}
+	overlay, err := network.NewOverlay(overlayCfg, network.NewCommandNetlink())
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize overlay network: %w", err)
+	}
+	w.overlay = overlay
+
+	// Load balance service VIPs and ingress published ports in the kernel
+	w.serviceProxy = network.NewServiceProxy(network.NewCommandIPTables())
+
 	return w, nil
 }
 
@@ -148,15 +175,21 @@ func (w *Worker) Start(resources *types.NodeResources, joinToken string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	// Services can constrain placement to a node with node.hostname
+	hostname, _ := os.Hostname()
+
 	resp, err := w.client.RegisterNode(ctx, &proto.RegisterNodeRequest{
-		Id:      w.nodeID,
-		Role:    "worker",
-		Address: "localhost", // TODO: Get actual address
+		Id:       w.nodeID,
+		Role:     "worker",
+		Address:  w.advertiseAddr,
+		Hostname: hostname,
 		Resources: &proto.NodeResources{
 			CpuCores:    int64(resources.CPUCores),
 			MemoryBytes: resources.MemoryBytes,
 			DiskBytes:   resources.DiskBytes,
 		},
+		OverlayPublicKey: w.overlay.PublicKey(),
+		OverlayPort:      int32(w.overlay.ListenPort()),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to register with manager: %w", err)
@@ -166,12 +199,26 @@ func (w *Worker) Start(resources *types.NodeResources, joinToken string) error {
 	fmt.Printf("  Node ID: %s\n", resp.Node.Id)
 	fmt.Printf("  Overlay IP: %s\n", resp.OverlayIp)
 
+	// Bring up the overlay; without it, cross-node traffic uses host ports only
+	if err := w.overlay.Up(net.ParseIP(resp.OverlayIp), resp.OverlaySubnet); err != nil {
+		fmt.Printf("Warning: overlay network disabled: %v\n", err)
+	} else {
+		w.overlayUp = true
+		go w.overlayLoop()
+	}
+
+	// Route service VIPs to their replicas
+	go w.serviceVIPLoop()
+
 	// Start heartbeat loop
 	go w.heartbeatLoop()
 
 	// Start task executor loop
 	go w.containerExecutorLoop()
 
+	// Answer log requests from the manager
+	go w.logRequestLoop()
+
 	// Start health monitor
 	w.healthMonitor.Start()
 
@@ -187,6 +234,18 @@ func (w *Worker) Stop() error {
 		w.healthMonitor.Stop()
 	}
 
+	// Remove the overlay interface
+	if w.overlayUp {
+		if err := w.overlay.Down(); err != nil {
+			fmt.Printf("Warning: failed to remove overlay interface: %v\n", err)
+		}
+	}
+
+	// Remove service VIP rules
+	if err := w.serviceProxy.Down(); err != nil {
+		fmt.Printf("Warning: failed to remove service VIP rules: %v\n", err)
+	}
+
 	if w.conn != nil {
 		w.conn.Close()
 	}
@@ -240,24 +299,91 @@ func (w *Worker) sendHeartbeat() error {
 	return err
 }
 
-// containerExecutorLoop polls for container assignments and executes them
-func (w *Worker) containerExecutorLoop() {
-	ticker := time.NewTicker(3 * time.Second)
-	defer ticker.Stop()
+// containerPollInterval is how often the worker retries the watch stream
+// (and resyncs by polling) while the stream is unavailable
+const containerPollInterval = 3 * time.Second
 
+// containerExecutorLoop watches for container assignments and executes them
+// Assignments are pushed by the manager over WatchContainers. Whenever the
+// stream is (re)established the worker first resyncs with a full
+// ListContainers, and it falls back to polling while the stream is down.
+func (w *Worker) containerExecutorLoop() {
 	for {
+		// Resync so nothing assigned while disconnected is missed
+		if err := w.syncContainers(); err != nil {
+			fmt.Printf("Container sync error: %v\n", err)
+		}
+
+		err := w.watchContainers()
+
 		select {
-		case <-ticker.C:
-			if err := w.syncContainers(); err != nil {
-				fmt.Printf("Container sync error: %v\n", err)
-			}
 		case <-w.stopCh:
 			return
+		default:
+		}
+
+		if err != nil {
+			fmt.Printf("Container watch error: %v (falling back to polling)\n", err)
+		}
+
+		select {
+		case <-time.After(containerPollInterval):
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+// watchContainers consumes the manager's container assignment stream until
+// it fails or the worker stops
+func (w *Worker) watchContainers() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-w.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	stream, err := w.client.WatchContainers(ctx, &proto.WatchContainersRequest{
+		NodeId: w.nodeID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to watch containers: %w", err)
+	}
+
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if event.Container == nil {
+			continue
+		}
+
+		if event.Type == "delete" {
+			// Check and mark under one lock, so only one delete stops the container
+			w.containersMu.Lock()
+			existing, exists := w.containers[event.Container.Id]
+			stop := exists && existing.DesiredState != types.ContainerStateShutdown
+			if stop {
+				existing.DesiredState = types.ContainerStateShutdown
+			}
+			w.containersMu.Unlock()
+			if stop {
+				go w.stopContainer(existing)
+			}
+			continue
 		}
+
+		w.handleContainerAssignment(event.Container)
 	}
 }
 
-// syncContainers fetches assigned containers from manager and executes them
+// syncContainers fetches all assigned containers from manager and executes them
 func (w *Worker) syncContainers() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -270,52 +396,45 @@ func (w *Worker) syncContainers() error {
 		return fmt.Errorf("failed to list containers: %w", err)
 	}
 
-	// Process each container
 	for _, protoContainer := range resp.Containers {
-		containerID := protoContainer.Id
+		w.handleContainerAssignment(protoContainer)
+	}
 
-		w.containersMu.Lock()
-		existingContainer, exists := w.containers[containerID]
-		w.containersMu.Unlock()
+	return nil
+}
 
-		// New container - start it
-		if !exists && protoContainer.DesiredState == "running" {
-			// Convert proto volume mounts to types.VolumeMount
-			var mounts []*types.VolumeMount
-			for _, pv := range protoContainer.Volumes {
-				mounts = append(mounts, &types.VolumeMount{
-					Source:   pv.Source,
-					Target:   pv.Target,
-					ReadOnly: pv.ReadOnly,
-				})
-			}
+// handleContainerAssignment starts a newly assigned container or stops one
+// whose desired state changed to shutdown. It is safe to call repeatedly
+// with the same assignment.
+func (w *Worker) handleContainerAssignment(protoContainer *proto.Container) {
+	containerID := protoContainer.Id
 
-			container := &types.Container{
-				ID:           protoContainer.Id,
-				ServiceID:    protoContainer.ServiceId,
-				ServiceName:  protoContainer.ServiceName,
-				NodeID:       protoContainer.NodeId,
-				DesiredState: types.ContainerState(protoContainer.DesiredState),
-				ActualState:  types.ContainerStatePending,
-				Image:        protoContainer.Image,
-				Secrets:      protoContainer.Secrets,
-				Mounts:       mounts,
-			}
+	w.containersMu.Lock()
+	existingContainer, exists := w.containers[containerID]
 
-			w.containersMu.Lock()
-			w.containers[containerID] = container
-			w.containersMu.Unlock()
+	// New container - start it
+	if !exists && protoContainer.DesiredState == "running" {
+		// Carry the full spec (env, ports, resources, health, restart policy)
+		container := convert.ProtoToContainer(protoContainer)
+		container.ActualState = types.ContainerStatePending
 
-			go w.executeContainer(container)
-		}
+		w.containers[containerID] = container
+		w.containersMu.Unlock()
 
-		// Existing container - handle shutdown
-		if exists && protoContainer.DesiredState == "shutdown" {
-			go w.stopContainer(existingContainer)
-		}
+		go w.executeContainer(container)
+		return
 	}
 
-	return nil
+	// Existing container - handle shutdown (once)
+	if exists && protoContainer.DesiredState == "shutdown" && existingContainer.DesiredState != types.ContainerStateShutdown {
+		existingContainer.DesiredState = types.ContainerStateShutdown
+		w.containersMu.Unlock()
+
+		go w.stopContainer(existingContainer)
+		return
+	}
+
+	w.containersMu.Unlock()
 }
 
 // executeContainer executes a single task using containerd
@@ -463,6 +582,8 @@ func (w *Worker) executeContainer(task *types.Container) {
 	ticker := time.NewTicker(5 * time.Second)
 	defer ticker.Stop()
 
+	restarts := 0
+
 	for {
 		select {
 		case <-ticker.C:
@@ -484,6 +605,13 @@ func (w *Worker) executeContainer(task *types.Container) {
 
 			// Update task state if container failed
 			if status == types.ContainerStateFailed || status == types.ContainerStateComplete {
+				if shouldRestart(task.RestartPolicy, status, restarts) {
+					restarts++
+					if w.restartContainer(ctx, task, containerID, restarts) {
+						continue
+					}
+				}
+
 				w.containersMu.Lock()
 				task.ActualState = status
 				if status == types.ContainerStateFailed {
@@ -500,6 +628,57 @@ func (w *Worker) executeContainer(task *types.Container) {
 	}
 }
 
+// shouldRestart reports whether an exited container should be restarted in
+// place according to its restart policy. Without a policy the exit is
+// reported to the manager and the reconciler takes over.
+func shouldRestart(policy *types.RestartPolicy, status types.ContainerState, restarts int) bool {
+	if policy == nil {
+		return false
+	}
+	if policy.MaxAttempts > 0 && restarts >= policy.MaxAttempts {
+		return false
+	}
+
+	switch policy.Condition {
+	case types.RestartAlways:
+		return true
+	case types.RestartOnFailure:
+		return status == types.ContainerStateFailed
+	default:
+		return false
+	}
+}
+
+// restartContainer waits for the restart delay and starts the container's
+// task again. It returns false if the task should not be restarted anymore.
+func (w *Worker) restartContainer(ctx context.Context, task *types.Container, containerID string, attempt int) bool {
+	if task.RestartPolicy.Delay > 0 {
+		select {
+		case <-time.After(task.RestartPolicy.Delay):
+		case <-w.stopCh:
+			return false
+		}
+	}
+
+	w.containersMu.RLock()
+	shutdown := task.DesiredState == types.ContainerStateShutdown
+	w.containersMu.RUnlock()
+	if shutdown {
+		return false
+	}
+
+	fmt.Printf("Restarting task %s (attempt %d, policy: %s)\n", task.ID, attempt, task.RestartPolicy.Condition)
+	if err := w.runtime.RestartContainer(ctx, containerID); err != nil {
+		fmt.Printf("Task %s failed to restart: %v\n", task.ID, err)
+		return false
+	}
+
+	w.containersMu.Lock()
+	task.StartedAt = time.Now()
+	w.containersMu.Unlock()
+	return true
+}
+
 // stopContainer stops a running task
 func (w *Worker) stopContainer(task *types.Container) {
 	ctx := context.Background()
@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/cuemby/warren/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestShouldRestart tests restart policy decisions for exited containers
+func TestShouldRestart(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   *types.RestartPolicy
+		status   types.ContainerState
+		restarts int
+		expected bool
+	}{
+		{name: "no policy", policy: nil, status: types.ContainerStateFailed, expected: false},
+		{name: "never", policy: &types.RestartPolicy{Condition: types.RestartNever}, status: types.ContainerStateFailed, expected: false},
+		{name: "on-failure after failure", policy: &types.RestartPolicy{Condition: types.RestartOnFailure}, status: types.ContainerStateFailed, expected: true},
+		{name: "on-failure after success", policy: &types.RestartPolicy{Condition: types.RestartOnFailure}, status: types.ContainerStateComplete, expected: false},
+		{name: "always after success", policy: &types.RestartPolicy{Condition: types.RestartAlways}, status: types.ContainerStateComplete, expected: true},
+		{name: "attempts left", policy: &types.RestartPolicy{Condition: types.RestartAlways, MaxAttempts: 3}, status: types.ContainerStateFailed, restarts: 2, expected: true},
+		{name: "attempts exhausted", policy: &types.RestartPolicy{Condition: types.RestartAlways, MaxAttempts: 3}, status: types.ContainerStateFailed, restarts: 3, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, shouldRestart(tt.policy, tt.status, tt.restarts))
+		})
+	}
+}
+
+// TestHTTPCheckURL tests that HTTP health check endpoints target localhost
+func TestHTTPCheckURL(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		expected string
+	}{
+		{endpoint: "http://:8080/health", expected: "http://localhost:8080/health"},
+		{endpoint: "https://:8443/ready", expected: "https://localhost:8443/ready"},
+		{endpoint: "http://10.0.0.5:80/", expected: "http://10.0.0.5:80/"},
+		{endpoint: ":8080/health", expected: "http://localhost:8080/health"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			assert.Equal(t, tt.expected, httpCheckURL(tt.endpoint))
+		})
+	}
+}